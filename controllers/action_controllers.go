@@ -6,6 +6,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/datarohit/gopher-social-backend/helpers"
 	"github.com/datarohit/gopher-social-backend/middlewares"
 	"github.com/datarohit/gopher-social-backend/models"
 	"github.com/datarohit/gopher-social-backend/stores"
@@ -15,9 +16,15 @@ import (
 )
 
 type ActionController struct {
-	authStore   *stores.AuthStore
-	actionStore *stores.ActionStore
-	logger      *logrus.Logger
+	authStore          *stores.AuthStore
+	actionStore        *stores.ActionStore
+	postStore          *stores.PostStore
+	sessionStore       *stores.SessionStore
+	moderationLogStore *stores.ModerationLogStore
+	statsStore         *stores.StatsStore
+	maintenanceStore   *stores.MaintenanceStore
+	webhookDispatcher  *helpers.WebhookDispatcher
+	logger             *logrus.Logger
 }
 
 // NewActionController creates a new ActionController.
@@ -25,21 +32,31 @@ type ActionController struct {
 // Parameters:
 //   - actionStore (*stores.ActionStore): ActionStore pointer to interact with user action data.
 //   - authStore (*stores.AuthStore): AuthStore pointer to interact with user data.
+//   - postStore (*stores.PostStore): PostStore pointer to interact with post data.
+//   - sessionStore (*stores.SessionStore): SessionStore pointer to revoke user sessions.
+//   - moderationLogStore (*stores.ModerationLogStore): ModerationLogStore pointer to read the moderation audit log.
+//   - webhookDispatcher (*helpers.WebhookDispatcher): WebhookDispatcher used to publish moderation events.
 //   - logger (*logrus.Logger): Logger for logging messages.
 //
 // Returns:
 //   - *ActionController: New ActionController instance.
-func NewActionController(actionStore *stores.ActionStore, authStore *stores.AuthStore, logger *logrus.Logger) *ActionController {
+func NewActionController(actionStore *stores.ActionStore, authStore *stores.AuthStore, postStore *stores.PostStore, sessionStore *stores.SessionStore, moderationLogStore *stores.ModerationLogStore, statsStore *stores.StatsStore, maintenanceStore *stores.MaintenanceStore, webhookDispatcher *helpers.WebhookDispatcher, logger *logrus.Logger) *ActionController {
 	return &ActionController{
-		actionStore: actionStore,
-		authStore:   authStore,
-		logger:      logger,
+		actionStore:        actionStore,
+		authStore:          authStore,
+		postStore:          postStore,
+		sessionStore:       sessionStore,
+		moderationLogStore: moderationLogStore,
+		statsStore:         statsStore,
+		maintenanceStore:   maintenanceStore,
+		webhookDispatcher:  webhookDispatcher,
+		logger:             logger,
 	}
 }
 
 // TimeoutUser godoc
 // @Summary      Timeout a user
-// @Description  Applies a timeout to a user, restricting their access for a specified duration.
+// @Description  Applies a timeout to a user, restricting their access for a specified duration. The duration must be one of the options returned by GET /action/timeout/options.
 // @Tags         action
 // @Accept       json
 // @Produce      json
@@ -54,16 +71,7 @@ func NewActionController(actionStore *stores.ActionStore, authStore *stores.Auth
 // @Failure      500 {object} models.TimeoutUserErrorResponse "Internal Server Error - Failed to timeout user"
 // @Router       /action/timeout/{userID} [post]
 func (ac *ActionController) TimeoutUser(c *gin.Context) {
-	userCtx, exists := c.Get("user")
-	if !exists {
-		ac.logger.Error("User not found in context. Middleware misconfiguration.")
-		c.JSON(http.StatusUnauthorized, models.TimeoutUserErrorResponse{
-			Message: "Unauthorized",
-			Error:   "user not authenticated",
-		})
-		return
-	}
-	requestingUser := userCtx.(*models.User)
+	requestingUser := helpers.RequireUser(c)
 
 	targetUserIDStr := c.Param("userID")
 	if targetUserIDStr == "" {
@@ -71,6 +79,7 @@ func (ac *ActionController) TimeoutUser(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.TimeoutUserErrorResponse{
 			Message: "Invalid Request",
 			Error:   "target userID is required path parameter",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
@@ -81,6 +90,7 @@ func (ac *ActionController) TimeoutUser(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.TimeoutUserErrorResponse{
 			Message: "Invalid Request",
 			Error:   "invalid target userID format",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
@@ -92,12 +102,14 @@ func (ac *ActionController) TimeoutUser(c *gin.Context) {
 			c.JSON(http.StatusNotFound, models.TimeoutUserErrorResponse{
 				Message: "User Not Found",
 				Error:   "target user not found",
+				Code:    helpers.ErrorCode(err),
 			})
 		} else {
 			ac.logger.WithFields(logrus.Fields{"error": err, "targetUserID": targetUserID, "requestingUserID": requestingUser.ID}).Error("Failed to get target user from store")
 			c.JSON(http.StatusInternalServerError, models.TimeoutUserErrorResponse{
 				Message: "Failed to Timeout User",
 				Error:   "could not retrieve user details",
+				Code:    helpers.CodeInternal,
 			})
 		}
 		return
@@ -108,6 +120,7 @@ func (ac *ActionController) TimeoutUser(c *gin.Context) {
 		c.JSON(http.StatusForbidden, models.TimeoutUserErrorResponse{
 			Message: "Forbidden",
 			Error:   "insufficient permissions",
+			Code:    helpers.CodeForbidden,
 		})
 		return
 	}
@@ -118,6 +131,7 @@ func (ac *ActionController) TimeoutUser(c *gin.Context) {
 			c.JSON(http.StatusForbidden, models.TimeoutUserErrorResponse{
 				Message: "Forbidden",
 				Error:   stores.ErrModeratorCannotTimeoutModeratorOrAdmin.Error(),
+				Code:    helpers.ErrorCode(stores.ErrModeratorCannotTimeoutModeratorOrAdmin),
 			})
 			return
 		}
@@ -129,6 +143,7 @@ func (ac *ActionController) TimeoutUser(c *gin.Context) {
 			c.JSON(http.StatusForbidden, models.TimeoutUserErrorResponse{
 				Message: "Forbidden",
 				Error:   stores.ErrAdminCannotTimeoutAdmin.Error(),
+				Code:    helpers.ErrorCode(stores.ErrAdminCannotTimeoutAdmin),
 			})
 			return
 		}
@@ -140,37 +155,30 @@ func (ac *ActionController) TimeoutUser(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.TimeoutUserErrorResponse{
 			Message: "Invalid Request Body",
 			Error:   err.Error(),
+			Code:    helpers.CodeValidationFailed,
 		})
 		return
 	}
 
-	var timeoutDuration time.Duration
-	switch strings.ToLower(string(req.TimeoutDuration)) {
-	case "30m":
-		timeoutDuration = 30 * time.Minute
-	case "1h":
-		timeoutDuration = time.Hour
-	case "6h":
-		timeoutDuration = 6 * time.Hour
-	case "12h":
-		timeoutDuration = 12 * time.Hour
-	case "1d":
-		timeoutDuration = 24 * time.Hour
-	default:
-		ac.logger.WithFields(logrus.Fields{"duration": req.TimeoutDuration, "targetUserID": targetUserID, "requestingUserID": requestingUser.ID}).Error("Invalid timeout duration provided")
+	timeoutValue := strings.ToLower(strings.TrimSpace(string(req.TimeoutDuration)))
+	if err := helpers.ValidateEnum(timeoutValue, helpers.TimeoutOptionValues()); err != nil {
+		ac.logger.WithFields(logrus.Fields{"error": err, "duration": req.TimeoutDuration, "targetUserID": targetUserID, "requestingUserID": requestingUser.ID}).Error("Invalid timeout duration provided")
 		c.JSON(http.StatusBadRequest, models.TimeoutUserErrorResponse{
 			Message: "Invalid Timeout Duration",
-			Error:   "timeout duration must be one of: 30m, 1h, 6h, 12h, 1d",
+			Error:   "timeout_duration " + err.Error(),
+			Code:    helpers.ErrorCode(err),
 		})
 		return
 	}
+	timeoutDuration, _ := helpers.LookupTimeoutOption(timeoutValue)
 
-	err = ac.actionStore.TimeoutUser(c, targetUserID, timeoutDuration)
+	err = ac.actionStore.TimeoutUser(c, requestingUser.ID, targetUserID, timeoutDuration)
 	if err != nil {
 		ac.logger.WithFields(logrus.Fields{"error": err, "targetUserID": targetUserID, "requestingUserID": requestingUser.ID, "duration": req.TimeoutDuration}).Error("Failed to timeout user in store")
 		c.JSON(http.StatusInternalServerError, models.TimeoutUserErrorResponse{
 			Message: "Failed to Timeout User",
 			Error:   "could not apply timeout to user",
+			Code:    helpers.CodeInternal,
 		})
 		return
 	}
@@ -180,6 +188,35 @@ func (ac *ActionController) TimeoutUser(c *gin.Context) {
 	})
 }
 
+// ListTimeoutOptions godoc
+// @Summary      List timeout durations
+// @Description  Lists the durations a user can be timed out for, configured with TIMEOUT_OPTIONS, so clients do not hardcode them. Accessible to moderators and admins.
+// @Tags         action
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} models.ListTimeoutOptionsSuccessResponse "Successfully retrieved timeout options"
+// @Failure      401 {object} models.ListTimeoutOptionsErrorResponse "Unauthorized - User not logged in or invalid token"
+// @Failure      403 {object} models.ListTimeoutOptionsErrorResponse "Forbidden - Insufficient permissions"
+// @Router       /action/timeout/options [get]
+func (ac *ActionController) ListTimeoutOptions(c *gin.Context) {
+	requestingUser := helpers.RequireUser(c)
+
+	if requestingUser.Role.Level < 2 {
+		ac.logger.WithFields(logrus.Fields{"requestingUserID": requestingUser.ID, "requestingUserRole": requestingUser.Role.Level}).Error("Unauthorized user role")
+		c.JSON(http.StatusForbidden, models.ListTimeoutOptionsErrorResponse{
+			Message: "Forbidden",
+			Error:   "insufficient permissions",
+			Code:    helpers.CodeForbidden,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ListTimeoutOptionsSuccessResponse{
+		Message: "Timeout Options Retrieved Successfully",
+		Options: helpers.TimeoutOptions(),
+	})
+}
+
 // RemoveTimeoutUser godoc
 // @Summary      Remove timeout from a user
 // @Description  Removes an active timeout from a user, restoring their access.
@@ -196,16 +233,7 @@ func (ac *ActionController) TimeoutUser(c *gin.Context) {
 // @Failure      500 {object} models.RemoveTimeoutUserErrorResponse "Internal Server Error - Failed to remove user timeout"
 // @Router       /action/timeout/{userID} [delete]
 func (ac *ActionController) RemoveTimeoutUser(c *gin.Context) {
-	userCtx, exists := c.Get("user")
-	if !exists {
-		ac.logger.Error("User not found in context. Middleware misconfiguration.")
-		c.JSON(http.StatusUnauthorized, models.RemoveTimeoutUserErrorResponse{
-			Message: "Unauthorized",
-			Error:   "user not authenticated",
-		})
-		return
-	}
-	requestingUser := userCtx.(*models.User)
+	requestingUser := helpers.RequireUser(c)
 
 	targetUserIDStr := c.Param("userID")
 	if targetUserIDStr == "" {
@@ -213,6 +241,7 @@ func (ac *ActionController) RemoveTimeoutUser(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.RemoveTimeoutUserErrorResponse{
 			Message: "Invalid Request",
 			Error:   "target userID is required path parameter",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
@@ -223,6 +252,7 @@ func (ac *ActionController) RemoveTimeoutUser(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.RemoveTimeoutUserErrorResponse{
 			Message: "Invalid Request",
 			Error:   "invalid target userID format",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
@@ -234,12 +264,14 @@ func (ac *ActionController) RemoveTimeoutUser(c *gin.Context) {
 			c.JSON(http.StatusNotFound, models.RemoveTimeoutUserErrorResponse{
 				Message: "User Not Found",
 				Error:   "target user not found",
+				Code:    helpers.ErrorCode(err),
 			})
 		} else {
 			ac.logger.WithFields(logrus.Fields{"error": err, "targetUserID": targetUserID, "requestingUserID": requestingUser.ID}).Error("Failed to get target user from store")
 			c.JSON(http.StatusInternalServerError, models.RemoveTimeoutUserErrorResponse{
 				Message: "Failed to Remove User Timeout",
 				Error:   "could not retrieve user details",
+				Code:    helpers.CodeInternal,
 			})
 		}
 		return
@@ -250,6 +282,7 @@ func (ac *ActionController) RemoveTimeoutUser(c *gin.Context) {
 		c.JSON(http.StatusForbidden, models.RemoveTimeoutUserErrorResponse{
 			Message: "Forbidden",
 			Error:   "insufficient permissions",
+			Code:    helpers.CodeForbidden,
 		})
 		return
 	}
@@ -260,6 +293,7 @@ func (ac *ActionController) RemoveTimeoutUser(c *gin.Context) {
 			c.JSON(http.StatusForbidden, models.RemoveTimeoutUserErrorResponse{
 				Message: "Forbidden",
 				Error:   stores.ErrModeratorCannotTimeoutModeratorOrAdmin.Error(),
+				Code:    helpers.ErrorCode(stores.ErrModeratorCannotTimeoutModeratorOrAdmin),
 			})
 			return
 		}
@@ -271,17 +305,19 @@ func (ac *ActionController) RemoveTimeoutUser(c *gin.Context) {
 			c.JSON(http.StatusForbidden, models.RemoveTimeoutUserErrorResponse{
 				Message: "Forbidden",
 				Error:   stores.ErrAdminCannotTimeoutAdmin.Error(),
+				Code:    helpers.ErrorCode(stores.ErrAdminCannotTimeoutAdmin),
 			})
 			return
 		}
 	}
 
-	err = ac.actionStore.RemoveTimeoutUser(c, targetUserID)
+	err = ac.actionStore.RemoveTimeoutUser(c, requestingUser.ID, targetUserID)
 	if err != nil {
 		ac.logger.WithFields(logrus.Fields{"error": err, "targetUserID": targetUserID, "requestingUserID": requestingUser.ID}).Error("Failed to remove timeout from user in store")
 		c.JSON(http.StatusInternalServerError, models.RemoveTimeoutUserErrorResponse{
 			Message: "Failed to Remove User Timeout",
 			Error:   "could not remove timeout from user",
+			Code:    helpers.CodeInternal,
 		})
 		return
 	}
@@ -293,46 +329,70 @@ func (ac *ActionController) RemoveTimeoutUser(c *gin.Context) {
 
 // ListTimedOutUsers godoc
 // @Summary      List timed out users
-// @Description  Retrieves a list of users who are currently timed out. Accessible to moderators and admins.
+// @Description  Retrieves a list of users who are currently timed out with their remaining timeout, optionally sorted and limited to timeouts expiring soon. Accessible to moderators and admins.
 // @Tags         action
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
 // @Param        page query integer false "Page number for pagination" default(1)
+// @Param        pageSize query integer false "Number of items per page, at most 100" default(10)
+// @Param        sort query string false "Sort order" Enums(expiry_asc, expiry_desc, recent) default(expiry_asc)
+// @Param        expiringWithin query string false "Only include timeouts ending within this Go duration, e.g. 30m or 24h"
 // @Success      200 {object} models.ListTimedOutUsersSuccessResponse "Successfully retrieved list of timed out users"
+// @Failure      400 {object} models.ListTimedOutUsersErrorResponse "Bad Request - Invalid sort or expiringWithin value"
 // @Failure      401 {object} models.ListTimedOutUsersErrorResponse "Unauthorized - User not logged in or invalid token"
 // @Failure      403 {object} models.ListTimedOutUsersErrorResponse "Forbidden - Insufficient permissions"
 // @Failure      500 {object} models.ListTimedOutUsersErrorResponse "Internal Server Error - Failed to list timed out users"
 // @Router       /action/timeout [get]
 func (ac *ActionController) ListTimedOutUsers(c *gin.Context) {
-	userCtx, exists := c.Get("user")
-	if !exists {
-		ac.logger.Error("User not found in context. Middleware misconfiguration.")
-		c.JSON(http.StatusUnauthorized, models.ListTimedOutUsersErrorResponse{
-			Message: "Unauthorized",
-			Error:   "user not authenticated",
-		})
-		return
-	}
-	requestingUser := userCtx.(*models.User)
+	requestingUser := helpers.RequireUser(c)
 
 	if requestingUser.Role.Level < 2 {
 		ac.logger.WithFields(logrus.Fields{"requestingUserID": requestingUser.ID, "requestingUserRole": requestingUser.Role.Level}).Error("Unauthorized user role")
 		c.JSON(http.StatusForbidden, models.ListTimedOutUsersErrorResponse{
 			Message: "Forbidden",
 			Error:   "insufficient permissions",
+			Code:    helpers.CodeForbidden,
 		})
 		return
 	}
 
 	pageNumber := c.GetInt(middlewares.PageNumberKey)
+	pageSize := c.GetInt(middlewares.PageSizeKey)
+	sort := c.DefaultQuery("sort", stores.TimeoutSortExpiryAsc)
+	if err := helpers.ValidateEnum(sort, stores.TimeoutSorts); err != nil {
+		ac.logger.WithFields(logrus.Fields{"error": err, "sort": sort, "requestingUserID": requestingUser.ID}).Error("Invalid sort value")
+		c.JSON(http.StatusBadRequest, models.ListTimedOutUsersErrorResponse{
+			Message: "Invalid Request",
+			Error:   "sort " + err.Error(),
+			Code:    helpers.ErrorCode(err),
+		})
+		return
+	}
 
-	timedOutUsers, err := ac.actionStore.ListTimedOutUsers(c, pageNumber, middlewares.PageSize)
+	var expiresBefore *time.Time
+	if expiringWithinStr := c.Query("expiringWithin"); expiringWithinStr != "" {
+		expiringWithin, err := time.ParseDuration(expiringWithinStr)
+		if err != nil || expiringWithin <= 0 {
+			ac.logger.WithFields(logrus.Fields{"error": err, "expiringWithin": expiringWithinStr}).Error("Invalid expiringWithin value")
+			c.JSON(http.StatusBadRequest, models.ListTimedOutUsersErrorResponse{
+				Message: "Invalid Request",
+				Error:   "expiringWithin must be a positive duration such as 30m or 24h",
+				Code:    helpers.CodeBadRequest,
+			})
+			return
+		}
+		cutoff := time.Now().Add(expiringWithin)
+		expiresBefore = &cutoff
+	}
+
+	timedOutUsers, err := ac.actionStore.ListTimedOutUsersSorted(c, sort, expiresBefore, pageNumber, pageSize)
 	if err != nil {
 		ac.logger.WithFields(logrus.Fields{"error": err, "requestingUserID": requestingUser.ID}).Error("Failed to list timed out users from store")
 		c.JSON(http.StatusInternalServerError, models.ListTimedOutUsersErrorResponse{
 			Message: "Failed to List Timed Out Users",
 			Error:   "could not retrieve timed out users from database",
+			Code:    helpers.CodeInternal,
 		})
 		return
 	}
@@ -359,16 +419,7 @@ func (ac *ActionController) ListTimedOutUsers(c *gin.Context) {
 // @Failure      500 {object} models.DeactivateUserErrorResponse "Internal Server Error - Failed to deactivate user"
 // @Router       /action/deactivate/{userID} [delete]
 func (ac *ActionController) DeactivateUser(c *gin.Context) {
-	userCtx, exists := c.Get("user")
-	if !exists {
-		ac.logger.Error("User not found in context. Middleware misconfiguration.")
-		c.JSON(http.StatusUnauthorized, models.DeactivateUserErrorResponse{
-			Message: "Unauthorized",
-			Error:   "user not authenticated",
-		})
-		return
-	}
-	requestingUser := userCtx.(*models.User)
+	requestingUser := helpers.RequireUser(c)
 
 	targetUserIDStr := c.Param("userID")
 	if targetUserIDStr == "" {
@@ -376,6 +427,7 @@ func (ac *ActionController) DeactivateUser(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.DeactivateUserErrorResponse{
 			Message: "Invalid Request",
 			Error:   "target userID is required path parameter",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
@@ -386,6 +438,7 @@ func (ac *ActionController) DeactivateUser(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.DeactivateUserErrorResponse{
 			Message: "Invalid Request",
 			Error:   "invalid target userID format",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
@@ -397,12 +450,14 @@ func (ac *ActionController) DeactivateUser(c *gin.Context) {
 			c.JSON(http.StatusNotFound, models.DeactivateUserErrorResponse{
 				Message: "User Not Found",
 				Error:   "target user not found",
+				Code:    helpers.ErrorCode(err),
 			})
 		} else {
 			ac.logger.WithFields(logrus.Fields{"error": err, "targetUserID": targetUserID, "requestingUserID": requestingUser.ID}).Error("Failed to get target user from store")
 			c.JSON(http.StatusInternalServerError, models.DeactivateUserErrorResponse{
 				Message: "Failed to Deactivate User",
 				Error:   "could not retrieve user details",
+				Code:    helpers.CodeInternal,
 			})
 		}
 		return
@@ -413,6 +468,7 @@ func (ac *ActionController) DeactivateUser(c *gin.Context) {
 		c.JSON(http.StatusForbidden, models.DeactivateUserErrorResponse{
 			Message: "Forbidden",
 			Error:   "insufficient permissions",
+			Code:    helpers.CodeForbidden,
 		})
 		return
 	}
@@ -423,6 +479,7 @@ func (ac *ActionController) DeactivateUser(c *gin.Context) {
 			c.JSON(http.StatusForbidden, models.DeactivateUserErrorResponse{
 				Message: "Forbidden",
 				Error:   stores.ErrModeratorCannotDeactivateModeratorOrAdmin.Error(),
+				Code:    helpers.ErrorCode(stores.ErrModeratorCannotDeactivateModeratorOrAdmin),
 			})
 			return
 		}
@@ -434,17 +491,19 @@ func (ac *ActionController) DeactivateUser(c *gin.Context) {
 			c.JSON(http.StatusForbidden, models.DeactivateUserErrorResponse{
 				Message: "Forbidden",
 				Error:   stores.ErrAdminCannotDeactivateAdmin.Error(),
+				Code:    helpers.ErrorCode(stores.ErrAdminCannotDeactivateAdmin),
 			})
 			return
 		}
 	}
 
-	err = ac.actionStore.DeactivateUser(c, targetUserID)
+	err = ac.actionStore.DeactivateUser(c, requestingUser.ID, targetUserID)
 	if err != nil {
 		ac.logger.WithFields(logrus.Fields{"error": err, "targetUserID": targetUserID, "requestingUserID": requestingUser.ID}).Error("Failed to deactivate user in store")
 		c.JSON(http.StatusInternalServerError, models.DeactivateUserErrorResponse{
 			Message: "Failed to Deactivate User",
 			Error:   "could not deactivate user",
+			Code:    helpers.CodeInternal,
 		})
 		return
 	}
@@ -470,16 +529,7 @@ func (ac *ActionController) DeactivateUser(c *gin.Context) {
 // @Failure      500 {object} models.ActivateUserErrorResponse "Internal Server Error - Failed to activate user"
 // @Router       /action/activate/{userID} [post]
 func (ac *ActionController) ActivateUser(c *gin.Context) {
-	userCtx, exists := c.Get("user")
-	if !exists {
-		ac.logger.Error("User not found in context. Middleware misconfiguration.")
-		c.JSON(http.StatusUnauthorized, models.ActivateUserErrorResponse{
-			Message: "Unauthorized",
-			Error:   "user not authenticated",
-		})
-		return
-	}
-	requestingUser := userCtx.(*models.User)
+	requestingUser := helpers.RequireUser(c)
 
 	targetUserIDStr := c.Param("userID")
 	if targetUserIDStr == "" {
@@ -487,6 +537,7 @@ func (ac *ActionController) ActivateUser(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.ActivateUserErrorResponse{
 			Message: "Invalid Request",
 			Error:   "target userID is required path parameter",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
@@ -497,6 +548,7 @@ func (ac *ActionController) ActivateUser(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.ActivateUserErrorResponse{
 			Message: "Invalid Request",
 			Error:   "invalid target userID format",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
@@ -508,12 +560,14 @@ func (ac *ActionController) ActivateUser(c *gin.Context) {
 			c.JSON(http.StatusNotFound, models.ActivateUserErrorResponse{
 				Message: "User Not Found",
 				Error:   "target user not found",
+				Code:    helpers.ErrorCode(err),
 			})
 		} else {
 			ac.logger.WithFields(logrus.Fields{"error": err, "targetUserID": targetUserID, "requestingUserID": requestingUser.ID}).Error("Failed to get target user from store")
 			c.JSON(http.StatusInternalServerError, models.ActivateUserErrorResponse{
 				Message: "Failed to Activate User",
 				Error:   "could not retrieve user details",
+				Code:    helpers.CodeInternal,
 			})
 		}
 		return
@@ -524,6 +578,7 @@ func (ac *ActionController) ActivateUser(c *gin.Context) {
 		c.JSON(http.StatusForbidden, models.ActivateUserErrorResponse{
 			Message: "Forbidden",
 			Error:   "insufficient permissions",
+			Code:    helpers.CodeForbidden,
 		})
 		return
 	}
@@ -534,17 +589,19 @@ func (ac *ActionController) ActivateUser(c *gin.Context) {
 			c.JSON(http.StatusForbidden, models.ActivateUserErrorResponse{
 				Message: "Forbidden",
 				Error:   stores.ErrModeratorCannotActivateModeratorOrAdmin.Error(),
+				Code:    helpers.ErrorCode(stores.ErrModeratorCannotActivateModeratorOrAdmin),
 			})
 			return
 		}
 	}
 
-	err = ac.actionStore.ActivateUser(c, targetUserID)
+	err = ac.actionStore.ActivateUser(c, requestingUser.ID, targetUserID)
 	if err != nil {
 		ac.logger.WithFields(logrus.Fields{"error": err, "targetUserID": targetUserID, "requestingUserID": requestingUser.ID}).Error("Failed to activate user in store")
 		c.JSON(http.StatusInternalServerError, models.ActivateUserErrorResponse{
 			Message: "Failed to Activate User",
 			Error:   "could not activate user",
+			Code:    helpers.CodeInternal,
 		})
 		return
 	}
@@ -570,16 +627,7 @@ func (ac *ActionController) ActivateUser(c *gin.Context) {
 // @Failure      500 {object} models.UnbanUserErrorResponse "Internal Server Error - Failed to unban user"
 // @Router       /action/unban/{userID} [post]
 func (ac *ActionController) UnbanUser(c *gin.Context) {
-	userCtx, exists := c.Get("user")
-	if !exists {
-		ac.logger.Error("User not found in context. Middleware misconfiguration.")
-		c.JSON(http.StatusUnauthorized, models.UnbanUserErrorResponse{
-			Message: "Unauthorized",
-			Error:   "user not authenticated",
-		})
-		return
-	}
-	requestingUser := userCtx.(*models.User)
+	requestingUser := helpers.RequireUser(c)
 
 	targetUserIDStr := c.Param("userID")
 	if targetUserIDStr == "" {
@@ -587,6 +635,7 @@ func (ac *ActionController) UnbanUser(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.UnbanUserErrorResponse{
 			Message: "Invalid Request",
 			Error:   "target userID is required path parameter",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
@@ -597,6 +646,7 @@ func (ac *ActionController) UnbanUser(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.UnbanUserErrorResponse{
 			Message: "Invalid Request",
 			Error:   "invalid target userID format",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
@@ -608,12 +658,14 @@ func (ac *ActionController) UnbanUser(c *gin.Context) {
 			c.JSON(http.StatusNotFound, models.UnbanUserErrorResponse{
 				Message: "User Not Found",
 				Error:   "target user not found",
+				Code:    helpers.ErrorCode(err),
 			})
 		} else {
 			ac.logger.WithFields(logrus.Fields{"error": err, "targetUserID": targetUserID, "requestingUserID": requestingUser.ID}).Error("Failed to get target user from store")
 			c.JSON(http.StatusInternalServerError, models.UnbanUserErrorResponse{
 				Message: "Failed to Unban User",
 				Error:   "could not retrieve user details",
+				Code:    helpers.CodeInternal,
 			})
 		}
 		return
@@ -624,6 +676,7 @@ func (ac *ActionController) UnbanUser(c *gin.Context) {
 		c.JSON(http.StatusForbidden, models.UnbanUserErrorResponse{
 			Message: "Forbidden",
 			Error:   "insufficient permissions",
+			Code:    helpers.CodeForbidden,
 		})
 		return
 	}
@@ -634,21 +687,25 @@ func (ac *ActionController) UnbanUser(c *gin.Context) {
 			c.JSON(http.StatusForbidden, models.UnbanUserErrorResponse{
 				Message: "Forbidden",
 				Error:   stores.ErrAdminCannotUnbanAdmin.Error(),
+				Code:    helpers.ErrorCode(stores.ErrAdminCannotUnbanAdmin),
 			})
 			return
 		}
 	}
 
-	err = ac.actionStore.UnbanUser(c, targetUserID)
+	err = ac.actionStore.UnbanUser(c, requestingUser.ID, targetUserID)
 	if err != nil {
 		ac.logger.WithFields(logrus.Fields{"error": err, "targetUserID": targetUserID, "requestingUserID": requestingUser.ID}).Error("Failed to unban user in store")
 		c.JSON(http.StatusInternalServerError, models.UnbanUserErrorResponse{
 			Message: "Failed to Unban User",
 			Error:   "could not unban user",
+			Code:    helpers.CodeInternal,
 		})
 		return
 	}
 
+	ac.webhookDispatcher.Dispatch(helpers.WebhookEventUserUnbanned, gin.H{"user_id": targetUserID, "actor_id": requestingUser.ID})
+
 	c.JSON(http.StatusOK, models.UnbanUserSuccessResponse{
 		Message: "User Unbanned Successfully",
 	})
@@ -670,16 +727,7 @@ func (ac *ActionController) UnbanUser(c *gin.Context) {
 // @Failure      500 {object} models.BanUserErrorResponse "Internal Server Error - Failed to ban user"
 // @Router       /action/ban/{userID} [post]
 func (ac *ActionController) BanUser(c *gin.Context) {
-	userCtx, exists := c.Get("user")
-	if !exists {
-		ac.logger.Error("User not found in context. Middleware misconfiguration.")
-		c.JSON(http.StatusUnauthorized, models.BanUserErrorResponse{
-			Message: "Unauthorized",
-			Error:   "user not authenticated",
-		})
-		return
-	}
-	requestingUser := userCtx.(*models.User)
+	requestingUser := helpers.RequireUser(c)
 
 	targetUserIDStr := c.Param("userID")
 	if targetUserIDStr == "" {
@@ -687,6 +735,7 @@ func (ac *ActionController) BanUser(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.BanUserErrorResponse{
 			Message: "Invalid Request",
 			Error:   "target userID is required path parameter",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
@@ -697,6 +746,7 @@ func (ac *ActionController) BanUser(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.BanUserErrorResponse{
 			Message: "Invalid Request",
 			Error:   "invalid target userID format",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
@@ -708,12 +758,14 @@ func (ac *ActionController) BanUser(c *gin.Context) {
 			c.JSON(http.StatusNotFound, models.BanUserErrorResponse{
 				Message: "User Not Found",
 				Error:   "target user not found",
+				Code:    helpers.ErrorCode(err),
 			})
 		} else {
 			ac.logger.WithFields(logrus.Fields{"error": err, "targetUserID": targetUserID, "requestingUserID": requestingUser.ID}).Error("Failed to get target user from store")
 			c.JSON(http.StatusInternalServerError, models.BanUserErrorResponse{
 				Message: "Failed to Ban User",
 				Error:   "could not retrieve user details",
+				Code:    helpers.CodeInternal,
 			})
 		}
 		return
@@ -724,6 +776,7 @@ func (ac *ActionController) BanUser(c *gin.Context) {
 		c.JSON(http.StatusForbidden, models.BanUserErrorResponse{
 			Message: "Forbidden",
 			Error:   "insufficient permissions",
+			Code:    helpers.CodeForbidden,
 		})
 		return
 	}
@@ -734,21 +787,34 @@ func (ac *ActionController) BanUser(c *gin.Context) {
 			c.JSON(http.StatusForbidden, models.BanUserErrorResponse{
 				Message: "Forbidden",
 				Error:   stores.ErrAdminCannotBanAdmin.Error(),
+				Code:    helpers.ErrorCode(stores.ErrAdminCannotBanAdmin),
 			})
 			return
 		}
 	}
 
-	err = ac.actionStore.BanUser(c, targetUserID)
+	err = ac.actionStore.BanUser(c, requestingUser.ID, targetUserID)
 	if err != nil {
-		ac.logger.WithFields(logrus.Fields{"error": err, "targetUserID": targetUserID, "requestingUserID": requestingUser.ID}).Error("Failed to ban user in store")
-		c.JSON(http.StatusInternalServerError, models.BanUserErrorResponse{
-			Message: "Failed to Ban User",
-			Error:   "could not ban user",
-		})
+		if errors.Is(err, stores.ErrUserNotFound) {
+			ac.logger.WithFields(logrus.Fields{"error": err, "targetUserID": targetUserID, "requestingUserID": requestingUser.ID}).Error("Target user not found while banning")
+			c.JSON(http.StatusNotFound, models.BanUserErrorResponse{
+				Message: "User Not Found",
+				Error:   "target user not found",
+				Code:    helpers.ErrorCode(err),
+			})
+		} else {
+			ac.logger.WithFields(logrus.Fields{"error": err, "targetUserID": targetUserID, "requestingUserID": requestingUser.ID}).Error("Failed to ban user in store")
+			c.JSON(http.StatusInternalServerError, models.BanUserErrorResponse{
+				Message: "Failed to Ban User",
+				Error:   "could not ban user",
+				Code:    helpers.CodeInternal,
+			})
+		}
 		return
 	}
 
+	ac.webhookDispatcher.Dispatch(helpers.WebhookEventUserBanned, gin.H{"user_id": targetUserID, "actor_id": requestingUser.ID})
+
 	c.JSON(http.StatusOK, models.BanUserSuccessResponse{
 		Message: "User Banned Successfully",
 	})
@@ -770,22 +836,14 @@ func (ac *ActionController) BanUser(c *gin.Context) {
 // @Failure      500 {object} models.DeleteCommentErrorResponse "Internal Server Error - Failed to delete comment"
 // @Router       /action/comment/{commentID} [delete]
 func (ac *ActionController) DeleteComment(c *gin.Context) {
-	userCtx, exists := c.Get("user")
-	if !exists {
-		ac.logger.Error("User not found in context. Middleware misconfiguration.")
-		c.JSON(http.StatusUnauthorized, models.DeleteCommentErrorResponse{
-			Message: "Unauthorized",
-			Error:   "user not authenticated",
-		})
-		return
-	}
-	requestingUser := userCtx.(*models.User)
+	requestingUser := helpers.RequireUser(c)
 
 	if requestingUser.Role.Level < 2 {
 		ac.logger.WithFields(logrus.Fields{"requestingUserID": requestingUser.ID, "requestingUserRole": requestingUser.Role.Level}).Error("Unauthorized user role")
 		c.JSON(http.StatusForbidden, models.DeleteCommentErrorResponse{
 			Message: "Forbidden",
 			Error:   "insufficient permissions",
+			Code:    helpers.CodeForbidden,
 		})
 		return
 	}
@@ -796,6 +854,7 @@ func (ac *ActionController) DeleteComment(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.DeleteCommentErrorResponse{
 			Message: "Invalid Request",
 			Error:   "commentID is required path parameter",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
@@ -806,6 +865,7 @@ func (ac *ActionController) DeleteComment(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.DeleteCommentErrorResponse{
 			Message: "Invalid Request",
 			Error:   "invalid commentID format",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
@@ -817,17 +877,21 @@ func (ac *ActionController) DeleteComment(c *gin.Context) {
 			c.JSON(http.StatusNotFound, models.DeleteCommentErrorResponse{
 				Message: "Comment Not Found",
 				Error:   "comment not found",
+				Code:    helpers.ErrorCode(err),
 			})
 		} else {
 			ac.logger.WithFields(logrus.Fields{"error": err, "commentID": commentID, "requestingUserID": requestingUser.ID}).Error("Failed to delete comment from store")
 			c.JSON(http.StatusInternalServerError, models.DeleteCommentErrorResponse{
 				Message: "Failed to Delete Comment",
 				Error:   "could not delete comment",
+				Code:    helpers.CodeInternal,
 			})
 		}
 		return
 	}
 
+	ac.webhookDispatcher.Dispatch(helpers.WebhookEventCommentRemoved, gin.H{"comment_id": commentID, "actor_id": requestingUser.ID})
+
 	c.JSON(http.StatusOK, models.DeleteCommentSuccessResponse{
 		Message: "Comment Deleted Successfully",
 	})
@@ -849,22 +913,14 @@ func (ac *ActionController) DeleteComment(c *gin.Context) {
 // @Failure      500 {object} models.DeletePostErrorResponse "Internal Server Error - Failed to delete post"
 // @Router       /action/post/{postID} [delete]
 func (ac *ActionController) DeletePost(c *gin.Context) {
-	userCtx, exists := c.Get("user")
-	if !exists {
-		ac.logger.Error("User not found in context. Middleware misconfiguration.")
-		c.JSON(http.StatusUnauthorized, models.DeletePostErrorResponse{
-			Message: "Unauthorized",
-			Error:   "user not authenticated",
-		})
-		return
-	}
-	requestingUser := userCtx.(*models.User)
+	requestingUser := helpers.RequireUser(c)
 
 	if requestingUser.Role.Level != 3 {
 		ac.logger.WithFields(logrus.Fields{"requestingUserID": requestingUser.ID, "requestingUserRole": requestingUser.Role.Level}).Error("Unauthorized user role")
 		c.JSON(http.StatusForbidden, models.DeletePostErrorResponse{
 			Message: "Forbidden",
 			Error:   stores.ErrAdminOnlyOperation.Error(),
+			Code:    helpers.ErrorCode(stores.ErrAdminOnlyOperation),
 		})
 		return
 	}
@@ -875,6 +931,7 @@ func (ac *ActionController) DeletePost(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.DeletePostErrorResponse{
 			Message: "Invalid Request",
 			Error:   "postID is required path parameter",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
@@ -885,6 +942,7 @@ func (ac *ActionController) DeletePost(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.DeletePostErrorResponse{
 			Message: "Invalid Request",
 			Error:   "invalid postID format",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
@@ -896,18 +954,769 @@ func (ac *ActionController) DeletePost(c *gin.Context) {
 			c.JSON(http.StatusNotFound, models.DeletePostErrorResponse{
 				Message: "Post Not Found",
 				Error:   "post not found",
+				Code:    helpers.ErrorCode(err),
 			})
 		} else {
 			ac.logger.WithFields(logrus.Fields{"error": err, "postID": postID, "requestingUserID": requestingUser.ID}).Error("Failed to delete post from store")
 			c.JSON(http.StatusInternalServerError, models.DeletePostErrorResponse{
 				Message: "Failed to Delete Post",
 				Error:   "could not delete post",
+				Code:    helpers.CodeInternal,
 			})
 		}
 		return
 	}
 
+	ac.webhookDispatcher.Dispatch(helpers.WebhookEventPostRemoved, gin.H{"post_id": postID, "actor_id": requestingUser.ID})
+
 	c.JSON(http.StatusOK, models.DeletePostSuccessResponse{
 		Message: "Post Deleted Successfully",
 	})
 }
+
+// TransferPostAuthor godoc
+// @Summary      Transfer a post to another author
+// @Description  Reassigns a post to another user, for example when merging accounts. Accessible to admins only. The new author must exist, be active and not be banned. The transfer is recorded in the moderation history of the previous author.
+// @Tags         action
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        postID path string true "Post ID to transfer"
+// @Param        request body models.TransferPostAuthorPayload true "ID of the new author"
+// @Success      200 {object} models.TransferPostAuthorSuccessResponse "Successfully transferred post"
+// @Failure      400 {object} models.TransferPostAuthorErrorResponse "Bad Request - Invalid input or new author is banned or inactive"
+// @Failure      401 {object} models.TransferPostAuthorErrorResponse "Unauthorized - User not logged in or invalid token"
+// @Failure      403 {object} models.TransferPostAuthorErrorResponse "Forbidden - Insufficient permissions"
+// @Failure      404 {object} models.TransferPostAuthorErrorResponse "Not Found - Post or new author not found"
+// @Failure      409 {object} models.TransferPostAuthorErrorResponse "Conflict - Post is already authored by the new author"
+// @Failure      500 {object} models.TransferPostAuthorErrorResponse "Internal Server Error - Failed to transfer post"
+// @Router       /action/post/{postID}/author [patch]
+func (ac *ActionController) TransferPostAuthor(c *gin.Context) {
+	requestingUser := helpers.RequireUser(c)
+
+	if requestingUser.Role.Level != 3 {
+		ac.logger.WithFields(logrus.Fields{"requestingUserID": requestingUser.ID, "requestingUserRole": requestingUser.Role.Level}).Error("Unauthorized user role")
+		c.JSON(http.StatusForbidden, models.TransferPostAuthorErrorResponse{
+			Message: "Forbidden",
+			Error:   stores.ErrAdminOnlyOperation.Error(),
+			Code:    helpers.ErrorCode(stores.ErrAdminOnlyOperation),
+		})
+		return
+	}
+
+	postIDStr := c.Param("postID")
+	postID, err := uuid.Parse(postIDStr)
+	if err != nil {
+		ac.logger.WithFields(logrus.Fields{"error": err, "postID": postIDStr}).Error("Invalid Post ID format")
+		c.JSON(http.StatusBadRequest, models.TransferPostAuthorErrorResponse{
+			Message: "Invalid Request",
+			Error:   "invalid postID format",
+			Code:    helpers.CodeBadRequest,
+		})
+		return
+	}
+
+	var req models.TransferPostAuthorPayload
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ac.logger.WithFields(logrus.Fields{"error": err, "postID": postID, "requestingUserID": requestingUser.ID}).Error("Invalid request body for transfer post author")
+		c.JSON(http.StatusBadRequest, models.TransferPostAuthorErrorResponse{
+			Message: "Invalid Request Body",
+			Error:   err.Error(),
+			Code:    helpers.CodeValidationFailed,
+		})
+		return
+	}
+
+	err = ac.actionStore.TransferPostAuthor(c, requestingUser.ID, postID, req.AuthorID)
+	if err != nil {
+		logFields := logrus.Fields{"error": err, "postID": postID, "newAuthorID": req.AuthorID, "requestingUserID": requestingUser.ID}
+		if errors.Is(err, stores.ErrPostNotFound) || errors.Is(err, stores.ErrUserNotFound) {
+			ac.logger.WithFields(logFields).Error("Post or new author not found")
+			c.JSON(http.StatusNotFound, models.TransferPostAuthorErrorResponse{
+				Message: "Transfer Post Author Failed",
+				Error:   err.Error(),
+				Code:    helpers.ErrorCode(err),
+			})
+		} else if errors.Is(err, stores.ErrCannotTransferPostToBannedUser) || errors.Is(err, stores.ErrCannotTransferPostToInactiveUser) {
+			ac.logger.WithFields(logFields).Error("New author is banned or inactive")
+			c.JSON(http.StatusBadRequest, models.TransferPostAuthorErrorResponse{
+				Message: "Transfer Post Author Failed",
+				Error:   err.Error(),
+				Code:    helpers.ErrorCode(err),
+			})
+		} else if errors.Is(err, stores.ErrPostAlreadyOwnedByUser) {
+			ac.logger.WithFields(logFields).Error("Post is already authored by the new author")
+			c.JSON(http.StatusConflict, models.TransferPostAuthorErrorResponse{
+				Message: "Transfer Post Author Failed",
+				Error:   err.Error(),
+				Code:    helpers.ErrorCode(err),
+			})
+		} else {
+			ac.logger.WithFields(logFields).Error("Failed to transfer post author in store")
+			c.JSON(http.StatusInternalServerError, models.TransferPostAuthorErrorResponse{
+				Message: "Failed to Transfer Post Author",
+				Error:   "could not transfer post author",
+				Code:    helpers.CodeInternal,
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, models.TransferPostAuthorSuccessResponse{
+		Message: "Post Author Transferred Successfully",
+	})
+}
+
+// ListAllPosts godoc
+// @Summary      List all posts
+// @Description  Lists posts of all users for moderation, newest first. Accessible to admins only.
+// @Tags         action
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        page query integer false "Page number for pagination" default(1)
+// @Param        pageSize query integer false "Number of items per page, at most 100" default(10)
+// @Param        authorID query string false "Only include posts of this author"
+// @Param        from query string false "Only include posts created at or after this RFC3339 timestamp"
+// @Param        to query string false "Only include posts created at or before this RFC3339 timestamp"
+// @Success      200 {object} models.ListAllPostsSuccessResponse "Successfully retrieved posts"
+// @Failure      400 {object} models.ListAllPostsErrorResponse "Bad Request - Invalid filter"
+// @Failure      401 {object} models.ListAllPostsErrorResponse "Unauthorized - User not logged in or invalid token"
+// @Failure      403 {object} models.ListAllPostsErrorResponse "Forbidden - Insufficient permissions"
+// @Failure      500 {object} models.ListAllPostsErrorResponse "Internal Server Error - Failed to list posts"
+// @Router       /action/posts [get]
+func (ac *ActionController) ListAllPosts(c *gin.Context) {
+	requestingUser := helpers.RequireUser(c)
+
+	if requestingUser.Role.Level != 3 {
+		ac.logger.WithFields(logrus.Fields{"requestingUserID": requestingUser.ID, "requestingUserRole": requestingUser.Role.Level}).Error("Unauthorized user role")
+		c.JSON(http.StatusForbidden, models.ListAllPostsErrorResponse{
+			Message: "Forbidden",
+			Error:   stores.ErrAdminOnlyOperation.Error(),
+			Code:    helpers.ErrorCode(stores.ErrAdminOnlyOperation),
+		})
+		return
+	}
+
+	var authorID *uuid.UUID
+	if authorIDStr := c.Query("authorID"); authorIDStr != "" {
+		parsedAuthorID, err := uuid.Parse(authorIDStr)
+		if err != nil {
+			ac.logger.WithFields(logrus.Fields{"error": err, "authorID": authorIDStr}).Error("Invalid Author ID format")
+			c.JSON(http.StatusBadRequest, models.ListAllPostsErrorResponse{
+				Message: "Invalid Request",
+				Error:   "invalid authorID format",
+				Code:    helpers.CodeBadRequest,
+			})
+			return
+		}
+		authorID = &parsedAuthorID
+	}
+
+	var from *time.Time
+	if fromStr := c.Query("from"); fromStr != "" {
+		parsedFrom, err := time.Parse(time.RFC3339, fromStr)
+		if err != nil {
+			ac.logger.WithFields(logrus.Fields{"error": err, "from": fromStr}).Error("Invalid from value")
+			c.JSON(http.StatusBadRequest, models.ListAllPostsErrorResponse{
+				Message: "Invalid Request",
+				Error:   "from must be an RFC3339 timestamp",
+				Code:    helpers.CodeBadRequest,
+			})
+			return
+		}
+		from = &parsedFrom
+	}
+
+	var to *time.Time
+	if toStr := c.Query("to"); toStr != "" {
+		parsedTo, err := time.Parse(time.RFC3339, toStr)
+		if err != nil {
+			ac.logger.WithFields(logrus.Fields{"error": err, "to": toStr}).Error("Invalid to value")
+			c.JSON(http.StatusBadRequest, models.ListAllPostsErrorResponse{
+				Message: "Invalid Request",
+				Error:   "to must be an RFC3339 timestamp",
+				Code:    helpers.CodeBadRequest,
+			})
+			return
+		}
+		to = &parsedTo
+	}
+
+	pageNumber := c.GetInt(middlewares.PageNumberKey)
+	pageSize := c.GetInt(middlewares.PageSizeKey)
+	posts, err := ac.postStore.ListAllPosts(c, authorID, from, to, pageNumber, pageSize)
+	if err != nil {
+		ac.logger.WithFields(logrus.Fields{"error": err, "requestingUserID": requestingUser.ID}).Error("Failed to list all posts from store")
+		c.JSON(http.StatusInternalServerError, models.ListAllPostsErrorResponse{
+			Message: "Failed to List Posts",
+			Error:   "could not retrieve posts",
+			Code:    helpers.CodeInternal,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ListAllPostsSuccessResponse{
+		Message: "Posts Retrieved Successfully",
+		Posts:   posts,
+	})
+}
+
+// UpdateUserRole godoc
+// @Summary      Update a user's role
+// @Description  Promotes or demotes a user to the role with the given level. Accessible to admins only. Admins cannot change the role of other admins, and the last admin cannot demote themselves.
+// @Tags         action
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        userID path string true "User ID whose role is updated"
+// @Param        request body models.UpdateUserRolePayload true "New role level"
+// @Success      200 {object} models.UpdateUserRoleSuccessResponse "Successfully updated user role"
+// @Failure      400 {object} models.UpdateUserRoleErrorResponse "Bad Request - Invalid input"
+// @Failure      401 {object} models.UpdateUserRoleErrorResponse "Unauthorized - User not logged in or invalid token"
+// @Failure      403 {object} models.UpdateUserRoleErrorResponse "Forbidden - Insufficient permissions or role cannot be changed"
+// @Failure      404 {object} models.UpdateUserRoleErrorResponse "Not Found - User or role not found"
+// @Failure      409 {object} models.UpdateUserRoleErrorResponse "Conflict - Last admin cannot be demoted"
+// @Failure      500 {object} models.UpdateUserRoleErrorResponse "Internal Server Error - Failed to update user role"
+// @Router       /action/role/{userID} [patch]
+func (ac *ActionController) UpdateUserRole(c *gin.Context) {
+	requestingUser := helpers.RequireUser(c)
+
+	if requestingUser.Role.Level != 3 {
+		ac.logger.WithFields(logrus.Fields{"requestingUserID": requestingUser.ID, "requestingUserRole": requestingUser.Role.Level}).Error("Unauthorized user role")
+		c.JSON(http.StatusForbidden, models.UpdateUserRoleErrorResponse{
+			Message: "Forbidden",
+			Error:   stores.ErrAdminOnlyOperation.Error(),
+			Code:    helpers.ErrorCode(stores.ErrAdminOnlyOperation),
+		})
+		return
+	}
+
+	targetUserIDStr := c.Param("userID")
+	targetUserID, err := uuid.Parse(targetUserIDStr)
+	if err != nil {
+		ac.logger.WithFields(logrus.Fields{"error": err, "userID": targetUserIDStr}).Error("Invalid Target User ID format")
+		c.JSON(http.StatusBadRequest, models.UpdateUserRoleErrorResponse{
+			Message: "Invalid Request",
+			Error:   "invalid target userID format",
+			Code:    helpers.CodeBadRequest,
+		})
+		return
+	}
+
+	var req models.UpdateUserRolePayload
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ac.logger.WithFields(logrus.Fields{"error": err, "targetUserID": targetUserID, "requestingUserID": requestingUser.ID}).Error("Invalid request body for update user role")
+		c.JSON(http.StatusBadRequest, models.UpdateUserRoleErrorResponse{
+			Message: "Invalid Request Body",
+			Error:   err.Error(),
+			Code:    helpers.CodeValidationFailed,
+		})
+		return
+	}
+
+	err = ac.authStore.UpdateUserRole(c, requestingUser.ID, targetUserID, req.Level)
+	if err != nil {
+		logFields := logrus.Fields{"error": err, "targetUserID": targetUserID, "requestingUserID": requestingUser.ID, "level": req.Level}
+		if errors.Is(err, stores.ErrUserNotFound) || errors.Is(err, stores.ErrRoleNotFound) {
+			ac.logger.WithFields(logFields).Error("Target user or role not found")
+			c.JSON(http.StatusNotFound, models.UpdateUserRoleErrorResponse{
+				Message: "Update User Role Failed",
+				Error:   err.Error(),
+				Code:    helpers.ErrorCode(err),
+			})
+		} else if errors.Is(err, stores.ErrAdminCannotChangeAdminRole) {
+			ac.logger.WithFields(logFields).Error("Admin cannot change role of another admin")
+			c.JSON(http.StatusForbidden, models.UpdateUserRoleErrorResponse{
+				Message: "Forbidden",
+				Error:   err.Error(),
+				Code:    helpers.ErrorCode(err),
+			})
+		} else if errors.Is(err, stores.ErrLastAdminCannotBeDemoted) {
+			ac.logger.WithFields(logFields).Error("Last admin cannot be demoted")
+			c.JSON(http.StatusConflict, models.UpdateUserRoleErrorResponse{
+				Message: "Update User Role Failed",
+				Error:   err.Error(),
+				Code:    helpers.ErrorCode(err),
+			})
+		} else {
+			ac.logger.WithFields(logFields).Error("Failed to update user role in store")
+			c.JSON(http.StatusInternalServerError, models.UpdateUserRoleErrorResponse{
+				Message: "Failed to Update User Role",
+				Error:   "could not update user role",
+				Code:    helpers.CodeInternal,
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, models.UpdateUserRoleSuccessResponse{
+		Message: "User Role Updated Successfully",
+	})
+}
+
+// ForceLogoutUser godoc
+// @Summary      Force logout a user everywhere
+// @Description  Revokes all sessions of a user and rejects every token issued before now. Accessible to admins only. Admins cannot force logout other admins. The action is recorded in the moderation audit log.
+// @Tags         action
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        userID path string true "User ID to force logout"
+// @Success      200 {object} models.ForceLogoutUserSuccessResponse "Successfully logged out user everywhere"
+// @Failure      400 {object} models.ForceLogoutUserErrorResponse "Bad Request - Invalid input"
+// @Failure      401 {object} models.ForceLogoutUserErrorResponse "Unauthorized - User not logged in or invalid token"
+// @Failure      403 {object} models.ForceLogoutUserErrorResponse "Forbidden - Insufficient permissions or target user is an admin"
+// @Failure      404 {object} models.ForceLogoutUserErrorResponse "Not Found - User not found"
+// @Failure      500 {object} models.ForceLogoutUserErrorResponse "Internal Server Error - Failed to force logout user"
+// @Router       /action/logout/{userID} [post]
+func (ac *ActionController) ForceLogoutUser(c *gin.Context) {
+	requestingUser := helpers.RequireUser(c)
+
+	if requestingUser.Role.Level != 3 {
+		ac.logger.WithFields(logrus.Fields{"requestingUserID": requestingUser.ID, "requestingUserRole": requestingUser.Role.Level}).Error("Unauthorized user role")
+		c.JSON(http.StatusForbidden, models.ForceLogoutUserErrorResponse{
+			Message: "Forbidden",
+			Error:   stores.ErrAdminOnlyOperation.Error(),
+			Code:    helpers.ErrorCode(stores.ErrAdminOnlyOperation),
+		})
+		return
+	}
+
+	targetUserIDStr := c.Param("userID")
+	targetUserID, err := uuid.Parse(targetUserIDStr)
+	if err != nil {
+		ac.logger.WithFields(logrus.Fields{"error": err, "userID": targetUserIDStr}).Error("Invalid Target User ID format")
+		c.JSON(http.StatusBadRequest, models.ForceLogoutUserErrorResponse{
+			Message: "Invalid Request",
+			Error:   "invalid target userID format",
+			Code:    helpers.CodeBadRequest,
+		})
+		return
+	}
+
+	targetUser, err := ac.authStore.GetUserByID(c, targetUserID)
+	if err != nil {
+		if errors.Is(err, stores.ErrUserNotFound) {
+			ac.logger.WithFields(logrus.Fields{"error": err, "targetUserID": targetUserID, "requestingUserID": requestingUser.ID}).Error("Target user not found")
+			c.JSON(http.StatusNotFound, models.ForceLogoutUserErrorResponse{
+				Message: "User Not Found",
+				Error:   "target user not found",
+				Code:    helpers.ErrorCode(err),
+			})
+		} else {
+			ac.logger.WithFields(logrus.Fields{"error": err, "targetUserID": targetUserID, "requestingUserID": requestingUser.ID}).Error("Failed to get target user from store")
+			c.JSON(http.StatusInternalServerError, models.ForceLogoutUserErrorResponse{
+				Message: "Failed to Force Logout User",
+				Error:   "could not retrieve target user",
+				Code:    helpers.CodeInternal,
+			})
+		}
+		return
+	}
+
+	if targetUser.Role.Level == 3 {
+		ac.logger.WithFields(logrus.Fields{"targetUserID": targetUserID, "requestingUserID": requestingUser.ID}).Error("Admin cannot force logout another admin")
+		c.JSON(http.StatusForbidden, models.ForceLogoutUserErrorResponse{
+			Message: "Forbidden",
+			Error:   stores.ErrAdminCannotLogoutAdmin.Error(),
+			Code:    helpers.ErrorCode(stores.ErrAdminCannotLogoutAdmin),
+		})
+		return
+	}
+
+	revokedSessions, err := ac.sessionStore.RevokeAllSessions(c, requestingUser.ID, targetUserID, helpers.RefreshTokenExpiry())
+	if err != nil {
+		ac.logger.WithFields(logrus.Fields{"error": err, "targetUserID": targetUserID, "requestingUserID": requestingUser.ID}).Error("Failed to revoke sessions of user")
+		c.JSON(http.StatusInternalServerError, models.ForceLogoutUserErrorResponse{
+			Message: "Failed to Force Logout User",
+			Error:   "could not revoke user sessions",
+			Code:    helpers.CodeInternal,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ForceLogoutUserSuccessResponse{
+		Message:         "User Logged Out Everywhere Successfully",
+		RevokedSessions: revokedSessions,
+	})
+}
+
+// ImpersonateUser godoc
+// @Summary      Impersonate a user
+// @Description  Issues a short-lived, read-only access token acting as a user, so support staff can reproduce what the user sees. The token carries an impersonatedBy claim with the admin's ID, is rejected on POST, PUT, PATCH and DELETE requests, and cannot be refreshed. Send it in an Authorization: Bearer header. Accessible to admins only. Admins cannot impersonate other admins. The impersonation is recorded in the moderation audit log.
+// @Tags         action
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        userID path string true "User ID to impersonate"
+// @Success      201 {object} models.ImpersonateUserSuccessResponse "Successfully issued impersonation token"
+// @Failure      400 {object} models.ImpersonateUserErrorResponse "Bad Request - Invalid input"
+// @Failure      401 {object} models.ImpersonateUserErrorResponse "Unauthorized - User not logged in or invalid token"
+// @Failure      403 {object} models.ImpersonateUserErrorResponse "Forbidden - Insufficient permissions or target user is an admin"
+// @Failure      404 {object} models.ImpersonateUserErrorResponse "Not Found - User not found"
+// @Failure      500 {object} models.ImpersonateUserErrorResponse "Internal Server Error - Failed to impersonate user"
+// @Router       /action/impersonate/{userID} [post]
+func (ac *ActionController) ImpersonateUser(c *gin.Context) {
+	requestingUser := helpers.RequireUser(c)
+
+	if requestingUser.Role.Level != 3 {
+		ac.logger.WithFields(logrus.Fields{"requestingUserID": requestingUser.ID, "requestingUserRole": requestingUser.Role.Level}).Error("Unauthorized user role")
+		c.JSON(http.StatusForbidden, models.ImpersonateUserErrorResponse{
+			Message: "Forbidden",
+			Error:   stores.ErrAdminOnlyOperation.Error(),
+			Code:    helpers.ErrorCode(stores.ErrAdminOnlyOperation),
+		})
+		return
+	}
+
+	targetUserIDStr := c.Param("userID")
+	targetUserID, err := uuid.Parse(targetUserIDStr)
+	if err != nil {
+		ac.logger.WithFields(logrus.Fields{"error": err, "userID": targetUserIDStr}).Error("Invalid Target User ID format")
+		c.JSON(http.StatusBadRequest, models.ImpersonateUserErrorResponse{
+			Message: "Invalid Request",
+			Error:   "invalid target userID format",
+			Code:    helpers.CodeBadRequest,
+		})
+		return
+	}
+
+	targetUser, err := ac.authStore.GetUserByID(c, targetUserID)
+	if err != nil {
+		if errors.Is(err, stores.ErrUserNotFound) {
+			ac.logger.WithFields(logrus.Fields{"error": err, "targetUserID": targetUserID, "requestingUserID": requestingUser.ID}).Error("Target user not found")
+			c.JSON(http.StatusNotFound, models.ImpersonateUserErrorResponse{
+				Message: "User Not Found",
+				Error:   "target user not found",
+				Code:    helpers.ErrorCode(err),
+			})
+		} else {
+			ac.logger.WithFields(logrus.Fields{"error": err, "targetUserID": targetUserID, "requestingUserID": requestingUser.ID}).Error("Failed to get target user from store")
+			c.JSON(http.StatusInternalServerError, models.ImpersonateUserErrorResponse{
+				Message: "Failed to Impersonate User",
+				Error:   "could not retrieve target user",
+				Code:    helpers.CodeInternal,
+			})
+		}
+		return
+	}
+
+	if targetUser.Role.Level == 3 {
+		ac.logger.WithFields(logrus.Fields{"targetUserID": targetUserID, "requestingUserID": requestingUser.ID}).Error("Admin cannot impersonate another admin")
+		c.JSON(http.StatusForbidden, models.ImpersonateUserErrorResponse{
+			Message: "Forbidden",
+			Error:   stores.ErrAdminCannotImpersonateAdmin.Error(),
+			Code:    helpers.ErrorCode(stores.ErrAdminCannotImpersonateAdmin),
+		})
+		return
+	}
+
+	accessToken, expiresAt, err := helpers.GenerateImpersonationToken(targetUserID, requestingUser.ID)
+	if err != nil {
+		ac.logger.WithFields(logrus.Fields{"error": err, "targetUserID": targetUserID, "requestingUserID": requestingUser.ID}).Error("Failed to generate impersonation token")
+		c.JSON(http.StatusInternalServerError, models.ImpersonateUserErrorResponse{
+			Message: "Failed to Impersonate User",
+			Error:   "could not generate impersonation token",
+			Code:    helpers.CodeInternal,
+		})
+		return
+	}
+
+	if err := ac.actionStore.RecordImpersonation(c, requestingUser.ID, targetUserID, expiresAt); err != nil {
+		ac.logger.WithFields(logrus.Fields{"error": err, "targetUserID": targetUserID, "requestingUserID": requestingUser.ID}).Error("Failed to record impersonation in audit log")
+		c.JSON(http.StatusInternalServerError, models.ImpersonateUserErrorResponse{
+			Message: "Failed to Impersonate User",
+			Error:   "could not record impersonation in audit log",
+			Code:    helpers.CodeInternal,
+		})
+		return
+	}
+
+	ac.logger.WithFields(logrus.Fields{"targetUserID": targetUserID, "requestingUserID": requestingUser.ID, "expiresAt": expiresAt}).Warn("Impersonation Token Issued")
+	c.JSON(http.StatusCreated, models.ImpersonateUserSuccessResponse{
+		Message:     "Impersonation Token Issued Successfully",
+		AccessToken: accessToken,
+		ExpiresAt:   expiresAt,
+		ReadOnly:    true,
+	})
+}
+
+// ListUserModerationHistory godoc
+// @Summary      List moderation history of a user
+// @Description  Retrieves the moderation actions taken against a user, such as timeouts, deactivations, bans, role changes and forced logouts, most recent first. Each entry includes who performed the action and its details. Accessible to moderators and admins only.
+// @Tags         action
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        userID path string true "User ID to get the moderation history of"
+// @Param        page query integer false "Page number for pagination" default(1)
+// @Param        pageSize query integer false "Number of items per page, at most 100" default(10)
+// @Success      200 {object} models.ListUserModerationHistorySuccessResponse "Successfully retrieved moderation history"
+// @Failure      400 {object} models.ListUserModerationHistoryErrorResponse "Bad Request - Invalid input"
+// @Failure      401 {object} models.ListUserModerationHistoryErrorResponse "Unauthorized - User not logged in or invalid token"
+// @Failure      403 {object} models.ListUserModerationHistoryErrorResponse "Forbidden - Insufficient permissions"
+// @Failure      404 {object} models.ListUserModerationHistoryErrorResponse "Not Found - User not found"
+// @Failure      500 {object} models.ListUserModerationHistoryErrorResponse "Internal Server Error - Failed to get moderation history"
+// @Router       /action/user/{userID}/history [get]
+func (ac *ActionController) ListUserModerationHistory(c *gin.Context) {
+	requestingUser := helpers.RequireUser(c)
+
+	if requestingUser.Role.Level < 2 {
+		ac.logger.WithFields(logrus.Fields{"requestingUserID": requestingUser.ID, "requestingUserRole": requestingUser.Role.Level}).Error("Unauthorized user role")
+		c.JSON(http.StatusForbidden, models.ListUserModerationHistoryErrorResponse{
+			Message: "Forbidden",
+			Error:   "insufficient permissions",
+			Code:    helpers.CodeForbidden,
+		})
+		return
+	}
+
+	targetUserIDStr := c.Param("userID")
+	targetUserID, err := uuid.Parse(targetUserIDStr)
+	if err != nil {
+		ac.logger.WithFields(logrus.Fields{"error": err, "userID": targetUserIDStr}).Error("Invalid Target User ID format")
+		c.JSON(http.StatusBadRequest, models.ListUserModerationHistoryErrorResponse{
+			Message: "Invalid Request",
+			Error:   "invalid target userID format",
+			Code:    helpers.CodeBadRequest,
+		})
+		return
+	}
+
+	_, err = ac.authStore.GetUserByID(c, targetUserID)
+	if err != nil {
+		if errors.Is(err, stores.ErrUserNotFound) {
+			ac.logger.WithFields(logrus.Fields{"error": err, "targetUserID": targetUserID, "requestingUserID": requestingUser.ID}).Error("Target user not found")
+			c.JSON(http.StatusNotFound, models.ListUserModerationHistoryErrorResponse{
+				Message: "User Not Found",
+				Error:   "target user not found",
+				Code:    helpers.ErrorCode(err),
+			})
+		} else {
+			ac.logger.WithFields(logrus.Fields{"error": err, "targetUserID": targetUserID, "requestingUserID": requestingUser.ID}).Error("Failed to get target user from store")
+			c.JSON(http.StatusInternalServerError, models.ListUserModerationHistoryErrorResponse{
+				Message: "Failed to Get Moderation History",
+				Error:   "could not retrieve target user",
+				Code:    helpers.CodeInternal,
+			})
+		}
+		return
+	}
+
+	pageNumber := c.GetInt(middlewares.PageNumberKey)
+	pageSize := c.GetInt(middlewares.PageSizeKey)
+
+	history, err := ac.moderationLogStore.ListModerationHistoryByUserID(c, targetUserID, pageNumber, pageSize)
+	if err != nil {
+		ac.logger.WithFields(logrus.Fields{"error": err, "targetUserID": targetUserID, "requestingUserID": requestingUser.ID}).Error("Failed to get moderation history from store")
+		c.JSON(http.StatusInternalServerError, models.ListUserModerationHistoryErrorResponse{
+			Message: "Failed to Get Moderation History",
+			Error:   "could not retrieve moderation history from database",
+			Code:    helpers.CodeInternal,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ListUserModerationHistorySuccessResponse{
+		Message: "Moderation History Retrieved Successfully",
+		History: history,
+	})
+}
+
+// UndoModerationAction godoc
+// @Summary      Undo a moderation action
+// @Description  Reverts a ban or timeout within MODERATION_UNDO_WINDOW_MINUTES of it being taken. An undone ban unbans the user and reactivates them if they had ever activated their account, but the content removed by the ban is not restored. An undone timeout is removed. Only the moderator who took the action, or a user with the same or a higher role, can undo it. The undo is recorded in the moderation history of the user.
+// @Tags         action
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        logID path string true "ID of the moderation log entry to undo"
+// @Success      200 {object} models.UndoModerationActionSuccessResponse "Successfully undone moderation action"
+// @Failure      400 {object} models.UndoModerationActionErrorResponse "Bad Request - Invalid input or action cannot be undone"
+// @Failure      401 {object} models.UndoModerationActionErrorResponse "Unauthorized - User not logged in or invalid token"
+// @Failure      403 {object} models.UndoModerationActionErrorResponse "Forbidden - Insufficient permissions"
+// @Failure      404 {object} models.UndoModerationActionErrorResponse "Not Found - Moderation log entry not found"
+// @Failure      409 {object} models.UndoModerationActionErrorResponse "Conflict - Action already undone or superseded by a later action"
+// @Failure      410 {object} models.UndoModerationActionErrorResponse "Gone - Undo window has passed"
+// @Failure      500 {object} models.UndoModerationActionErrorResponse "Internal Server Error - Failed to undo moderation action"
+// @Router       /action/logs/{logID}/undo [post]
+func (ac *ActionController) UndoModerationAction(c *gin.Context) {
+	requestingUser := helpers.RequireUser(c)
+
+	if requestingUser.Role.Level < 2 {
+		ac.logger.WithFields(logrus.Fields{"requestingUserID": requestingUser.ID, "requestingUserRole": requestingUser.Role.Level}).Error("Unauthorized user role")
+		c.JSON(http.StatusForbidden, models.UndoModerationActionErrorResponse{
+			Message: "Forbidden",
+			Error:   "insufficient permissions",
+			Code:    helpers.CodeForbidden,
+		})
+		return
+	}
+
+	logIDStr := c.Param("logID")
+	logID, err := uuid.Parse(logIDStr)
+	if err != nil {
+		ac.logger.WithFields(logrus.Fields{"error": err, "logID": logIDStr}).Error("Invalid Moderation Log ID format")
+		c.JSON(http.StatusBadRequest, models.UndoModerationActionErrorResponse{
+			Message: "Invalid Request",
+			Error:   "invalid logID format",
+			Code:    helpers.CodeBadRequest,
+		})
+		return
+	}
+
+	undone, err := ac.actionStore.UndoModerationAction(c, requestingUser.ID, requestingUser.Role.Level, logID)
+	if err != nil {
+		logFields := logrus.Fields{"error": err, "logID": logID, "requestingUserID": requestingUser.ID}
+		if errors.Is(err, stores.ErrModerationLogNotFound) || errors.Is(err, stores.ErrUserNotFound) {
+			ac.logger.WithFields(logFields).Error("Moderation log entry or target user not found")
+			c.JSON(http.StatusNotFound, models.UndoModerationActionErrorResponse{
+				Message: "Undo Moderation Action Failed",
+				Error:   err.Error(),
+				Code:    helpers.ErrorCode(err),
+			})
+		} else if errors.Is(err, stores.ErrModerationActionNotUndoable) {
+			ac.logger.WithFields(logFields).Error("Moderation action cannot be undone")
+			c.JSON(http.StatusBadRequest, models.UndoModerationActionErrorResponse{
+				Message: "Undo Moderation Action Failed",
+				Error:   err.Error(),
+				Code:    helpers.ErrorCode(err),
+			})
+		} else if errors.Is(err, stores.ErrCannotUndoHigherRoleAction) {
+			ac.logger.WithFields(logFields).Error("Moderation action taken by a higher role")
+			c.JSON(http.StatusForbidden, models.UndoModerationActionErrorResponse{
+				Message: "Forbidden",
+				Error:   err.Error(),
+				Code:    helpers.ErrorCode(err),
+			})
+		} else if errors.Is(err, stores.ErrModerationActionAlreadyUndone) || errors.Is(err, stores.ErrModerationActionSuperseded) {
+			ac.logger.WithFields(logFields).Error("Moderation action already undone or superseded")
+			c.JSON(http.StatusConflict, models.UndoModerationActionErrorResponse{
+				Message: "Undo Moderation Action Failed",
+				Error:   err.Error(),
+				Code:    helpers.ErrorCode(err),
+			})
+		} else if errors.Is(err, stores.ErrUndoWindowExpired) {
+			ac.logger.WithFields(logFields).Error("Undo window of moderation action has passed")
+			c.JSON(http.StatusGone, models.UndoModerationActionErrorResponse{
+				Message: "Undo Moderation Action Failed",
+				Error:   err.Error(),
+				Code:    helpers.ErrorCode(err),
+			})
+		} else {
+			ac.logger.WithFields(logFields).Error("Failed to undo moderation action in store")
+			c.JSON(http.StatusInternalServerError, models.UndoModerationActionErrorResponse{
+				Message: "Failed to Undo Moderation Action",
+				Error:   "could not undo moderation action",
+				Code:    helpers.CodeInternal,
+			})
+		}
+		return
+	}
+
+	if undone.Action == stores.ModerationActionBan {
+		ac.webhookDispatcher.Dispatch(helpers.WebhookEventUserUnbanned, gin.H{"user_id": undone.TargetUserID, "actor_id": requestingUser.ID})
+	}
+
+	c.JSON(http.StatusOK, models.UndoModerationActionSuccessResponse{
+		Message: "Moderation Action Undone Successfully",
+	})
+}
+
+// GetPlatformStats godoc
+// @Summary      Get platform stats
+// @Description  Retrieves the total, active and banned users, the total posts and comments, and the posts and new users of the last 24 hours. The stats are cached for PLATFORM_STATS_CACHE_TTL_SECONDS. Accessible to admins only.
+// @Tags         action
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} models.GetPlatformStatsSuccessResponse "Successfully retrieved platform stats"
+// @Failure      401 {object} models.GetPlatformStatsErrorResponse "Unauthorized - User not logged in or invalid token"
+// @Failure      403 {object} models.GetPlatformStatsErrorResponse "Forbidden - Admin role required"
+// @Failure      500 {object} models.GetPlatformStatsErrorResponse "Internal Server Error - Failed to retrieve platform stats"
+// @Router       /action/stats [get]
+func (ac *ActionController) GetPlatformStats(c *gin.Context) {
+	requestingUser := helpers.RequireUser(c)
+
+	if requestingUser.Role.Level != 3 {
+		ac.logger.WithFields(logrus.Fields{"requestingUserID": requestingUser.ID, "requestingUserRole": requestingUser.Role.Level}).Error("Unauthorized user role")
+		c.JSON(http.StatusForbidden, models.GetPlatformStatsErrorResponse{
+			Message: "Forbidden",
+			Error:   stores.ErrAdminOnlyOperation.Error(),
+			Code:    helpers.ErrorCode(stores.ErrAdminOnlyOperation),
+		})
+		return
+	}
+
+	stats, err := ac.statsStore.GetPlatformStats(c)
+	if err != nil {
+		ac.logger.WithFields(logrus.Fields{"error": err, "requestingUserID": requestingUser.ID}).Error("Failed to get platform stats from store")
+		c.JSON(http.StatusInternalServerError, models.GetPlatformStatsErrorResponse{
+			Message: "Failed to Get Platform Stats",
+			Error:   "could not retrieve platform stats",
+			Code:    helpers.CodeInternal,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.GetPlatformStatsSuccessResponse{
+		Message: "Platform Stats Retrieved Successfully",
+		Stats:   stats,
+	})
+}
+
+// SetMaintenanceMode godoc
+// @Summary      Turn read-only maintenance mode on or off
+// @Description  Blocks or allows again POST, PUT, PATCH and DELETE requests on every server instance. While read-only mode is on, writes get 503 Service Unavailable, except on the health, logout and maintenance routes, and reads are served as usual. Accessible to admins only.
+// @Tags         action
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        body body models.SetMaintenanceModePayload true "Request Body with the read-only state"
+// @Success      200 {object} models.SetMaintenanceModeSuccessResponse "Successfully updated maintenance mode"
+// @Failure      400 {object} models.SetMaintenanceModeErrorResponse "Bad Request - Invalid input"
+// @Failure      401 {object} models.SetMaintenanceModeErrorResponse "Unauthorized - User not logged in or invalid token"
+// @Failure      403 {object} models.SetMaintenanceModeErrorResponse "Forbidden - Admin role required"
+// @Failure      500 {object} models.SetMaintenanceModeErrorResponse "Internal Server Error - Failed to update maintenance mode"
+// @Router       /action/maintenance [put]
+func (ac *ActionController) SetMaintenanceMode(c *gin.Context) {
+	requestingUser := helpers.RequireUser(c)
+
+	if requestingUser.Role.Level != 3 {
+		ac.logger.WithFields(logrus.Fields{"requestingUserID": requestingUser.ID, "requestingUserRole": requestingUser.Role.Level}).Error("Unauthorized user role")
+		c.JSON(http.StatusForbidden, models.SetMaintenanceModeErrorResponse{
+			Message: "Forbidden",
+			Error:   stores.ErrAdminOnlyOperation.Error(),
+			Code:    helpers.ErrorCode(stores.ErrAdminOnlyOperation),
+		})
+		return
+	}
+
+	var payload models.SetMaintenanceModePayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		ac.logger.WithFields(logrus.Fields{"error": err, "requestingUserID": requestingUser.ID}).Error("Invalid request body for set maintenance mode")
+		c.JSON(http.StatusBadRequest, models.SetMaintenanceModeErrorResponse{
+			Message: "Invalid Request Body",
+			Error:   err.Error(),
+			Code:    helpers.CodeValidationFailed,
+		})
+		return
+	}
+
+	if err := ac.maintenanceStore.SetReadOnly(c, *payload.ReadOnly); err != nil {
+		ac.logger.WithFields(logrus.Fields{"error": err, "requestingUserID": requestingUser.ID, "readOnly": *payload.ReadOnly}).Error("Failed to set maintenance mode in store")
+		c.JSON(http.StatusInternalServerError, models.SetMaintenanceModeErrorResponse{
+			Message: "Failed to Update Maintenance Mode",
+			Error:   "could not update maintenance mode",
+			Code:    helpers.CodeInternal,
+		})
+		return
+	}
+
+	ac.logger.WithFields(logrus.Fields{"requestingUserID": requestingUser.ID, "readOnly": *payload.ReadOnly}).Warn("Maintenance Mode Updated")
+
+	c.JSON(http.StatusOK, models.SetMaintenanceModeSuccessResponse{
+		Message:  "Maintenance Mode Updated Successfully",
+		ReadOnly: *payload.ReadOnly,
+	})
+}
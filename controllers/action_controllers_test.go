@@ -0,0 +1,98 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/datarohit/gopher-social-backend/database/dbtest"
+	"github.com/datarohit/gopher-social-backend/helpers"
+	"github.com/datarohit/gopher-social-backend/stores"
+	"github.com/google/uuid"
+)
+
+// TestTransferPostAuthor checks who may transfer a post and to whom. Rejected transfers must leave the post and
+// the moderation log unchanged, and a successful one must list the post under the new author and be audited.
+func TestTransferPostAuthor(t *testing.T) {
+	pool := dbtest.NewPool(t)
+
+	adminID := dbtest.CreateUser(t, pool, "admin", 3)
+	moderatorID := dbtest.CreateUser(t, pool, "moderator", 2)
+	authorID := dbtest.CreateUser(t, pool, "author", 1)
+	newAuthorID := dbtest.CreateUser(t, pool, "new_author", 1)
+	inactiveID := dbtest.CreateUser(t, pool, "inactive", 1)
+	bannedID := dbtest.CreateUser(t, pool, "banned", 1)
+	dbtest.Exec(t, pool, `UPDATE users SET is_active = FALSE WHERE id = $1`, inactiveID)
+	dbtest.Exec(t, pool, `UPDATE users SET banned = TRUE, is_active = FALSE WHERE id = $1`, bannedID)
+	postID := dbtest.CreatePost(t, pool, authorID)
+
+	actionStore := stores.NewActionStore(pool)
+	postStore := stores.NewPostStore(pool)
+	ac := NewActionController(actionStore, stores.NewAuthStore(pool), postStore, nil, nil, nil, nil, nil, newTestLogger())
+	path := "/action/post/" + postID.String() + "/author"
+
+	tests := []struct {
+		name        string
+		requesterID uuid.UUID
+		newAuthorID uuid.UUID
+		wantStatus  int
+		wantCode    string
+	}{
+		{name: "user", requesterID: authorID, newAuthorID: newAuthorID, wantStatus: http.StatusForbidden, wantCode: helpers.ErrorCode(stores.ErrAdminOnlyOperation)},
+		{name: "moderator", requesterID: moderatorID, newAuthorID: newAuthorID, wantStatus: http.StatusForbidden, wantCode: helpers.ErrorCode(stores.ErrAdminOnlyOperation)},
+		{name: "missing new author", requesterID: adminID, newAuthorID: uuid.New(), wantStatus: http.StatusNotFound, wantCode: helpers.ErrorCode(stores.ErrUserNotFound)},
+		{name: "inactive new author", requesterID: adminID, newAuthorID: inactiveID, wantStatus: http.StatusBadRequest, wantCode: helpers.ErrorCode(stores.ErrCannotTransferPostToInactiveUser)},
+		{name: "banned new author", requesterID: adminID, newAuthorID: bannedID, wantStatus: http.StatusBadRequest, wantCode: helpers.ErrorCode(stores.ErrCannotTransferPostToBannedUser)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newTestRouter(loadUser(t, pool, tt.requesterID))
+			router.PATCH("/action/post/:postID/author", ac.TransferPostAuthor)
+
+			recorder := serve(router, http.MethodPatch, path, `{"author_id":"`+tt.newAuthorID.String()+`"}`)
+			assertStatus(t, recorder, tt.wantStatus)
+			assertCode(t, recorder, tt.wantCode)
+
+			if count := dbtest.Count(t, pool, `SELECT COUNT(*) FROM posts WHERE id = $1 AND author_id = $2`, postID, authorID); count != 1 {
+				t.Fatal("rejected transfer changed the author of the post")
+			}
+			if count := dbtest.Count(t, pool, `SELECT COUNT(*) FROM moderation_logs`); count != 0 {
+				t.Fatalf("rejected transfer wrote %d moderation log entries, want 0", count)
+			}
+		})
+	}
+
+	router := newTestRouter(loadUser(t, pool, adminID))
+	router.PATCH("/action/post/:postID/author", ac.TransferPostAuthor)
+	recorder := serve(router, http.MethodPatch, path, `{"author_id":"`+newAuthorID.String()+`"}`)
+	assertStatus(t, recorder, http.StatusOK)
+
+	ctx := context.Background()
+	posts, err := postStore.ListPostsByAuthorID(ctx, newAuthorID, 1, 10)
+	if err != nil {
+		t.Fatalf("ListPostsByAuthorID() error = %v", err)
+	}
+	if len(posts) != 1 || posts[0].ID != postID {
+		t.Fatalf("new author lists %d posts, want the transferred post", len(posts))
+	}
+	posts, err = postStore.ListPostsByAuthorID(ctx, authorID, 1, 10)
+	if err != nil {
+		t.Fatalf("ListPostsByAuthorID() error = %v", err)
+	}
+	if len(posts) != 0 {
+		t.Fatalf("previous author lists %d posts, want 0", len(posts))
+	}
+
+	count := dbtest.Count(t, pool, `
+		SELECT COUNT(*) FROM moderation_logs
+		WHERE actor_id = $1 AND target_user_id = $2 AND action = $3
+	`, adminID, authorID, stores.ModerationActionTransferPost)
+	if count != 1 {
+		t.Fatalf("%d transfer entries in the moderation log, want 1", count)
+	}
+
+	recorder = serve(router, http.MethodPatch, path, `{"author_id":"`+newAuthorID.String()+`"}`)
+	assertStatus(t, recorder, http.StatusConflict)
+	assertCode(t, recorder, helpers.ErrorCode(stores.ErrPostAlreadyOwnedByUser))
+}
@@ -0,0 +1,234 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/datarohit/gopher-social-backend/helpers"
+	"github.com/datarohit/gopher-social-backend/middlewares"
+	"github.com/datarohit/gopher-social-backend/models"
+	"github.com/datarohit/gopher-social-backend/stores"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// apiKeyPrefix is prepended to generated API keys, so leaked keys are easy to recognise.
+const apiKeyPrefix = "gsb_"
+
+type APIKeyController struct {
+	apiKeyStore *stores.APIKeyStore
+	logger      *logrus.Logger
+}
+
+// NewAPIKeyController creates a new APIKeyController.
+//
+// Parameters:
+//   - apiKeyStore (*stores.APIKeyStore): APIKeyStore pointer to interact with the database.
+//   - logger (*logrus.Logger): Logrus logger pointer to log messages.
+//
+// Returns:
+//   - *APIKeyController: Pointer to the APIKeyController.
+func NewAPIKeyController(apiKeyStore *stores.APIKeyStore, logger *logrus.Logger) *APIKeyController {
+	return &APIKeyController{
+		apiKeyStore: apiKeyStore,
+		logger:      logger,
+	}
+}
+
+// authenticatedByAPIKey reports whether the request was authenticated with an API key rather than a login session.
+// API keys cannot manage API keys, so a leaked key cannot mint new ones or revoke the owner's other keys.
+func authenticatedByAPIKey(c *gin.Context) bool {
+	_, authenticated := c.Get(middlewares.APIKeyKey)
+	return authenticated
+}
+
+// CreateAPIKey godoc
+// @Summary      Create an API key
+// @Description  Creates an API key for the logged-in user, acting with the user's current role. The plaintext key is only returned in this response, the server stores its hash. Scopes default to read. A user can have MAX_API_KEYS_PER_USER active keys, 5 by default. Requests authenticated with an API key cannot create API keys.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        body body models.CreateAPIKeyPayload true "Request Body for creating an API key"
+// @Success      201 {object} models.CreateAPIKeySuccessResponse "Successfully created API key"
+// @Failure      400 {object} models.CreateAPIKeyErrorResponse "Bad Request - Invalid input"
+// @Failure      401 {object} models.CreateAPIKeyErrorResponse "Unauthorized - User not logged in or invalid token"
+// @Failure      403 {object} models.CreateAPIKeyErrorResponse "Forbidden - User account is inactive or banned, or the request was authenticated with an API key"
+// @Failure      409 {object} models.CreateAPIKeyErrorResponse "Conflict - Maximum number of active API keys reached"
+// @Failure      500 {object} models.CreateAPIKeyErrorResponse "Internal Server Error - Failed to create API key"
+// @Router       /auth/api-keys [post]
+func (akc *APIKeyController) CreateAPIKey(c *gin.Context) {
+	userModel := helpers.RequireUser(c)
+
+	if authenticatedByAPIKey(c) {
+		akc.logger.WithFields(logrus.Fields{"userID": userModel.ID}).Warn("API Key Creation Attempted with an API Key")
+		c.JSON(http.StatusForbidden, models.CreateAPIKeyErrorResponse{
+			Message: "Forbidden",
+			Error:   "api keys cannot be managed with an api key",
+			Code:    helpers.CodeForbidden,
+		})
+		return
+	}
+
+	var req models.CreateAPIKeyPayload
+	if err := c.ShouldBindJSON(&req); err != nil {
+		akc.logger.WithFields(logrus.Fields{"error": err, "userID": userModel.ID}).Error("Invalid request body for creating API key")
+		c.JSON(http.StatusBadRequest, models.CreateAPIKeyErrorResponse{
+			Message: "Invalid Request Body",
+			Error:   err.Error(),
+			Code:    helpers.CodeValidationFailed,
+		})
+		return
+	}
+
+	req.Name = strings.TrimSpace(req.Name)
+	if len(req.Scopes) == 0 {
+		req.Scopes = []string{stores.APIKeyScopeRead}
+	}
+
+	secret, err := helpers.GenerateRandomString(32)
+	if err != nil {
+		akc.logger.WithFields(logrus.Fields{"error": err, "userID": userModel.ID}).Error("Failed to Generate API Key")
+		c.JSON(http.StatusInternalServerError, models.CreateAPIKeyErrorResponse{
+			Message: "Create API Key Failed",
+			Error:   "failed to generate api key",
+			Code:    helpers.CodeInternal,
+		})
+		return
+	}
+
+	key := apiKeyPrefix + secret
+	apiKey, err := akc.apiKeyStore.Create(c, userModel.ID, userModel.RoleID, req.Name, req.Scopes, key)
+	if err != nil {
+		if errors.Is(err, stores.ErrAPIKeyLimitReached) {
+			akc.logger.WithFields(logrus.Fields{"userID": userModel.ID}).Warn("API Key Limit Reached")
+			c.JSON(http.StatusConflict, models.CreateAPIKeyErrorResponse{
+				Message: "Create API Key Failed",
+				Error:   err.Error(),
+				Code:    helpers.ErrorCode(err),
+			})
+		} else {
+			akc.logger.WithFields(logrus.Fields{"error": err, "userID": userModel.ID}).Error("Failed to Create API Key in Store")
+			c.JSON(http.StatusInternalServerError, models.CreateAPIKeyErrorResponse{
+				Message: "Create API Key Failed",
+				Error:   "failed to create api key",
+				Code:    helpers.CodeInternal,
+			})
+		}
+		return
+	}
+
+	akc.logger.WithFields(logrus.Fields{"userID": userModel.ID, "apiKeyID": apiKey.ID}).Info("API Key Created")
+	c.JSON(http.StatusCreated, models.CreateAPIKeySuccessResponse{
+		Message: "API Key Created Successfully",
+		APIKey:  apiKey,
+		Key:     key,
+	})
+}
+
+// ListAPIKeys godoc
+// @Summary      List API keys
+// @Description  Lists the active and revoked API keys of the logged-in user, newest first, with their creation, last use and revocation times. Keys themselves are never returned. Requests authenticated with an API key cannot list API keys.
+// @Tags         auth
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} models.ListAPIKeysSuccessResponse "Successfully retrieved API keys"
+// @Failure      401 {object} models.ListAPIKeysErrorResponse "Unauthorized - User not logged in or invalid token"
+// @Failure      403 {object} models.ListAPIKeysErrorResponse "Forbidden - User account is inactive or banned, or the request was authenticated with an API key"
+// @Failure      500 {object} models.ListAPIKeysErrorResponse "Internal Server Error - Failed to list API keys"
+// @Router       /auth/api-keys [get]
+func (akc *APIKeyController) ListAPIKeys(c *gin.Context) {
+	userModel := helpers.RequireUser(c)
+
+	if authenticatedByAPIKey(c) {
+		akc.logger.WithFields(logrus.Fields{"userID": userModel.ID}).Warn("API Key Listing Attempted with an API Key")
+		c.JSON(http.StatusForbidden, models.ListAPIKeysErrorResponse{
+			Message: "Forbidden",
+			Error:   "api keys cannot be managed with an api key",
+			Code:    helpers.CodeForbidden,
+		})
+		return
+	}
+
+	apiKeys, err := akc.apiKeyStore.ListByUser(c, userModel.ID)
+	if err != nil {
+		akc.logger.WithFields(logrus.Fields{"error": err, "userID": userModel.ID}).Error("Failed to List API Keys from Store")
+		c.JSON(http.StatusInternalServerError, models.ListAPIKeysErrorResponse{
+			Message: "Failed to List API Keys",
+			Error:   "failed to list api keys",
+			Code:    helpers.CodeInternal,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ListAPIKeysSuccessResponse{
+		Message: "API Keys Retrieved Successfully",
+		APIKeys: apiKeys,
+	})
+}
+
+// RevokeAPIKey godoc
+// @Summary      Revoke an API key
+// @Description  Revokes an active API key of the logged-in user. The key stops working immediately and stays listed with its revocation time. Requests authenticated with an API key cannot revoke API keys.
+// @Tags         auth
+// @Produce      json
+// @Security     BearerAuth
+// @Param        apiKeyID path string true "API Key ID"
+// @Success      200 {object} models.RevokeAPIKeySuccessResponse "Successfully revoked API key"
+// @Failure      400 {object} models.RevokeAPIKeyErrorResponse "Bad Request - Invalid API key ID"
+// @Failure      401 {object} models.RevokeAPIKeyErrorResponse "Unauthorized - User not logged in or invalid token"
+// @Failure      403 {object} models.RevokeAPIKeyErrorResponse "Forbidden - User account is inactive or banned, or the request was authenticated with an API key"
+// @Failure      404 {object} models.RevokeAPIKeyErrorResponse "Not Found - API key not found or already revoked"
+// @Failure      500 {object} models.RevokeAPIKeyErrorResponse "Internal Server Error - Failed to revoke API key"
+// @Router       /auth/api-keys/{apiKeyID} [delete]
+func (akc *APIKeyController) RevokeAPIKey(c *gin.Context) {
+	userModel := helpers.RequireUser(c)
+
+	if authenticatedByAPIKey(c) {
+		akc.logger.WithFields(logrus.Fields{"userID": userModel.ID}).Warn("API Key Revocation Attempted with an API Key")
+		c.JSON(http.StatusForbidden, models.RevokeAPIKeyErrorResponse{
+			Message: "Forbidden",
+			Error:   "api keys cannot be managed with an api key",
+			Code:    helpers.CodeForbidden,
+		})
+		return
+	}
+
+	apiKeyID, err := uuid.Parse(c.Param("apiKeyID"))
+	if err != nil {
+		akc.logger.WithFields(logrus.Fields{"error": err, "apiKeyID": c.Param("apiKeyID")}).Error("Invalid API Key ID format")
+		c.JSON(http.StatusBadRequest, models.RevokeAPIKeyErrorResponse{
+			Message: "Invalid Request",
+			Error:   "invalid api key id format",
+			Code:    helpers.CodeBadRequest,
+		})
+		return
+	}
+
+	err = akc.apiKeyStore.Revoke(c, userModel.ID, apiKeyID)
+	if err != nil {
+		if errors.Is(err, stores.ErrAPIKeyNotFound) {
+			akc.logger.WithFields(logrus.Fields{"userID": userModel.ID, "apiKeyID": apiKeyID}).Error("API Key Not Found")
+			c.JSON(http.StatusNotFound, models.RevokeAPIKeyErrorResponse{
+				Message: "Revoke API Key Failed",
+				Error:   err.Error(),
+				Code:    helpers.ErrorCode(err),
+			})
+		} else {
+			akc.logger.WithFields(logrus.Fields{"error": err, "userID": userModel.ID, "apiKeyID": apiKeyID}).Error("Failed to Revoke API Key in Store")
+			c.JSON(http.StatusInternalServerError, models.RevokeAPIKeyErrorResponse{
+				Message: "Revoke API Key Failed",
+				Error:   "failed to revoke api key",
+				Code:    helpers.CodeInternal,
+			})
+		}
+		return
+	}
+
+	akc.logger.WithFields(logrus.Fields{"userID": userModel.ID, "apiKeyID": apiKeyID}).Info("API Key Revoked")
+	c.JSON(http.StatusOK, models.RevokeAPIKeySuccessResponse{
+		Message: "API Key Revoked Successfully",
+	})
+}
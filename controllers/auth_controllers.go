@@ -1,54 +1,265 @@
 package controllers
 
 import (
+	"context"
+	"crypto/subtle"
 	"errors"
 	"fmt"
 	"log"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/datarohit/gopher-social-backend/helpers"
+	"github.com/datarohit/gopher-social-backend/middlewares"
 	"github.com/datarohit/gopher-social-backend/models"
 	"github.com/datarohit/gopher-social-backend/stores"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
 	"github.com/sirupsen/logrus"
 )
 
 // DOMAIN is the domain of the application.
 var DOMAIN = helpers.GetEnv("DOMAIN", "http://localhost:8080")
 
+// PASSWORD_RESET_URL is the page linked from the password reset email, the token is added as the token query parameter.
+// It defaults to the reset password endpoint, a frontend page should collect the new password and post it there.
+var PASSWORD_RESET_URL = helpers.GetEnv("PASSWORD_RESET_URL", DOMAIN+"/api/v1/auth/reset-password")
+
+var (
+	loginLockoutThreshold = helpers.GetEnvAsInt("LOGIN_LOCKOUT_THRESHOLD", 5)
+	loginFailureWindow    = time.Duration(helpers.GetEnvAsInt("LOGIN_FAILURE_WINDOW_MINUTES", 15)) * time.Minute
+	loginLockoutDuration  = time.Duration(helpers.GetEnvAsInt("LOGIN_LOCKOUT_DURATION_MINUTES", 15)) * time.Minute
+)
+
+// Token delivery modes of Login, selected with the tokenDelivery query parameter.
+// Cookies are the default and the secure choice for browsers, body delivery is meant for API clients
+// that send the access token in an Authorization: Bearer header.
+const (
+	tokenDeliveryCookie = "cookie"
+	tokenDeliveryBody   = "body"
+)
+
+// tokenDeliveries lists the token delivery modes of Login.
+var tokenDeliveries = []string{tokenDeliveryCookie, tokenDeliveryBody}
+
+// loginFailKeyPrefix is the Redis key prefix for consecutive failed login counters.
+const loginFailKeyPrefix = "login_fail:"
+
+// userExportKeyPrefix is the Redis key prefix marking users who recently exported their data.
+const userExportKeyPrefix = "user_export:"
+
+// userExportInterval is the minimum time between two data exports of the same user.
+var userExportInterval = time.Duration(helpers.GetEnvAsInt("USER_EXPORT_INTERVAL_HOURS", 24)) * time.Hour
+
+// resendActivationKeyPrefix is the Redis key prefix marking identifiers that recently requested an activation link.
+const resendActivationKeyPrefix = "resend_activation:"
+
+// resendActivationInterval is the minimum time between two activation emails requested for the same identifier.
+var resendActivationInterval = time.Duration(helpers.GetEnvAsInt("RESEND_ACTIVATION_INTERVAL_SECONDS", 60)) * time.Second
+
+// forgotPasswordKeyPrefix is the Redis key prefix for the forgot password request counters. Requests for a user are
+// counted under the user ID, so the username and the email share one quota, requests for unknown identifiers are
+// counted under the identifier.
+const forgotPasswordKeyPrefix = "forgot_password:"
+
+// forgotPasswordMaxRequests is the number of reset links that can be requested for the same user within forgotPasswordWindow.
+var forgotPasswordMaxRequests = helpers.GetEnvAsInt("FORGOT_PASSWORD_MAX_REQUESTS", 3)
+
+// forgotPasswordWindow is the window in which forgot password requests of a user are counted.
+var forgotPasswordWindow = time.Duration(helpers.GetEnvAsInt("FORGOT_PASSWORD_WINDOW_MINUTES", 60)) * time.Minute
+
 type AuthController struct {
-	authStore    *stores.AuthStore
-	profileStore *stores.ProfileStore
-	logger       *logrus.Logger
+	dbPool            *pgxpool.Pool
+	authStore         *stores.AuthStore
+	profileStore      *stores.ProfileStore
+	userStore         *stores.UserStore
+	sessionStore      *stores.SessionStore
+	mailer            helpers.Mailer
+	webhookDispatcher *helpers.WebhookDispatcher
+	redisClient       *redis.Client
+	logger            *logrus.Logger
 }
 
 // NewAuthController creates a new AuthController.
 //
 // Parameters:
+//   - dbPool (*pgxpool.Pool): Pgx connection pool used to run multi-step operations in a transaction.
 //   - authStore (*stores.AuthStore): AuthStore pointer to interact with the database.
 //   - profileStore (*stores.ProfileStore): ProfileStore pointer to interact with the database.
+//   - userStore (*stores.UserStore): UserStore pointer to export user data.
+//   - sessionStore (*stores.SessionStore): SessionStore pointer to manage login sessions.
+//   - mailer (helpers.Mailer): Mailer used to send activation and password reset emails.
+//   - webhookDispatcher (*helpers.WebhookDispatcher): WebhookDispatcher used to publish account events.
+//   - redisClient (*redis.Client): Redis client used to store short lived auth state.
 //   - logger (*logrus.Logger): Logrus logger pointer to log messages.
 //
 // Returns:
 //   - *AuthController: Pointer to the AuthController.
-func NewAuthController(authStore *stores.AuthStore, profileStore *stores.ProfileStore, logger *logrus.Logger) *AuthController {
+func NewAuthController(dbPool *pgxpool.Pool, authStore *stores.AuthStore, profileStore *stores.ProfileStore, userStore *stores.UserStore, sessionStore *stores.SessionStore, mailer helpers.Mailer, webhookDispatcher *helpers.WebhookDispatcher, redisClient *redis.Client, logger *logrus.Logger) *AuthController {
 	return &AuthController{
-		authStore:    authStore,
-		profileStore: profileStore,
-		logger:       logger,
+		dbPool:            dbPool,
+		authStore:         authStore,
+		profileStore:      profileStore,
+		userStore:         userStore,
+		sessionStore:      sessionStore,
+		mailer:            mailer,
+		webhookDispatcher: webhookDispatcher,
+		redisClient:       redisClient,
+		logger:            logger,
+	}
+}
+
+// exposeLinks reports whether activation and reset links may be returned in responses.
+// Links are only exposed outside of release mode to ease local development.
+func exposeLinks() bool {
+	return gin.Mode() != gin.ReleaseMode
+}
+
+// loginLockoutRemaining returns how long the account stays locked, or zero if it is not locked.
+func (ac *AuthController) loginLockoutRemaining(ctx context.Context, userID uuid.UUID) (time.Duration, error) {
+	key := loginFailKeyPrefix + userID.String()
+
+	failures, err := ac.redisClient.Get(ctx, key).Int()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	if failures < loginLockoutThreshold {
+		return 0, nil
+	}
+
+	ttl, err := ac.redisClient.TTL(ctx, key).Result()
+	if err != nil {
+		return 0, err
+	}
+	if ttl <= 0 {
+		return loginLockoutDuration, nil
+	}
+	return ttl, nil
+}
+
+// rehashPassword replaces the password hash of a user with one made at the configured cost.
+// Failures are only logged, the old hash keeps working.
+func (ac *AuthController) rehashPassword(ctx context.Context, userID uuid.UUID, password string) {
+	hashedPassword, err := helpers.HashPassword(password)
+	if err != nil {
+		ac.logger.WithFields(logrus.Fields{"error": err, "userID": userID}).Warn("Failed to Rehash Password")
+		return
+	}
+
+	if err := ac.authStore.UpdateUserPassword(ctx, userID, hashedPassword); err != nil {
+		ac.logger.WithFields(logrus.Fields{"error": err, "userID": userID}).Warn("Failed to Store Rehashed Password")
+		return
+	}
+
+	ac.logger.WithFields(logrus.Fields{"userID": userID}).Info("Password Hash Upgraded to Current Cost")
+}
+
+// recordLoginFailure increments the failed login counter of the user.
+// Once the threshold is reached the counter is kept for the lockout duration and that duration is returned.
+func (ac *AuthController) recordLoginFailure(ctx context.Context, userID uuid.UUID) (time.Duration, error) {
+	key := loginFailKeyPrefix + userID.String()
+
+	failures, err := ac.redisClient.Incr(ctx, key).Result()
+	if err != nil {
+		return 0, err
+	}
+
+	if failures >= int64(loginLockoutThreshold) {
+		if err := ac.redisClient.Expire(ctx, key, loginLockoutDuration).Err(); err != nil {
+			return 0, err
+		}
+		return loginLockoutDuration, nil
+	}
+
+	if failures == 1 {
+		if err := ac.redisClient.Expire(ctx, key, loginFailureWindow).Err(); err != nil {
+			return 0, err
+		}
+	}
+	return 0, nil
+}
+
+// forgotPasswordUserSubject returns the forgot password throttle subject of an existing user.
+func forgotPasswordUserSubject(userID uuid.UUID) string {
+	return "user:" + userID.String()
+}
+
+// forgotPasswordIdentifierSubject returns the forgot password throttle subject of an identifier matching no user.
+func forgotPasswordIdentifierSubject(identifier string) string {
+	return "identifier:" + strings.ToLower(strings.TrimSpace(identifier))
+}
+
+// allowForgotPassword counts a forgot password request for the subject and reports whether it is within
+// FORGOT_PASSWORD_MAX_REQUESTS for the current window. The window starts with the first request of the subject.
+func (ac *AuthController) allowForgotPassword(ctx context.Context, subject string) (bool, error) {
+	key := forgotPasswordKeyPrefix + subject
+
+	requests, err := ac.redisClient.Incr(ctx, key).Result()
+	if err != nil {
+		return false, err
+	}
+
+	if requests == 1 {
+		if err := ac.redisClient.Expire(ctx, key, forgotPasswordWindow).Err(); err != nil {
+			return false, err
+		}
+	}
+	return requests <= int64(forgotPasswordMaxRequests), nil
+}
+
+// startSession creates a new login session for the user using the device and IP of the request.
+func (ac *AuthController) startSession(c *gin.Context, userID uuid.UUID) (*models.Session, error) {
+	return ac.sessionStore.CreateSession(c, &models.Session{
+		UserID:    userID,
+		UserAgent: c.Request.UserAgent(),
+		IPAddress: c.GetString(middlewares.RealIPKey),
+	})
+}
+
+// sendActivationEmail renders and sends the account activation email to the user.
+func (ac *AuthController) sendActivationEmail(user *models.User, activationLink string) error {
+	body, err := helpers.RenderActivationEmail(user.Username, activationLink)
+	if err != nil {
+		return err
+	}
+	return ac.mailer.Send(user.Email, "Activate Your Gopher Social Account", body)
+}
+
+// sendEmailChangeEmail renders and sends the email change verification email to the requested new email.
+func (ac *AuthController) sendEmailChangeEmail(user *models.User, newEmail string, verifyLink string) error {
+	body, err := helpers.RenderEmailChangeEmail(user.Username, verifyLink)
+	if err != nil {
+		return err
+	}
+	return ac.mailer.Send(newEmail, "Confirm Your New Gopher Social Email", body)
+}
+
+// sendPasswordResetEmail renders and sends the password reset email to the user.
+func (ac *AuthController) sendPasswordResetEmail(user *models.User, resetLink string) error {
+	body, err := helpers.RenderPasswordResetEmail(user.Username, resetLink)
+	if err != nil {
+		return err
 	}
+	return ac.mailer.Send(user.Email, "Reset Your Gopher Social Password", body)
 }
 
 // Register godoc
 // @Summary      Register a new user
-// @Description  Registers a new user to the platform
+// @Description  Registers a new user to the platform and emails them an activation link. The link is only included in the response outside of release mode. Usernames are trimmed and lowercased, must be 3 to 30 characters of letters, digits and underscores, and cannot be a reserved name.
 // @Tags         auth
 // @Accept       json
 // @Produce      json
 // @Param        body body models.UserRegisterPayload true "Request Body for User Registration"
 // @Success      201 {object} models.UserRegisterSuccessResponse "Successfully registered user"
-// @Failure      400 {object} models.UserRegisterErrorResponse "Bad Request - Invalid input"
+// @Failure      400 {object} models.UserRegisterErrorResponse "Bad Request - Invalid input or username"
 // @Failure      409 {object} models.UserRegisterErrorResponse "Conflict - User already exists"
 // @Failure      500 {object} models.UserRegisterErrorResponse "Internal Server Error - Failed to register user"
 // @Router       /auth/register [post]
@@ -59,9 +270,23 @@ func (ac *AuthController) Register(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.UserRegisterErrorResponse{
 			Message: "Invalid Request Body",
 			Error:   err.Error(),
+			Code:    helpers.CodeValidationFailed,
+		})
+		return
+	}
+
+	username, err := helpers.NormalizeUsername(req.Username)
+	if err != nil {
+		ac.logger.WithFields(logrus.Fields{"error": err, "username": req.Username}).Error("Invalid Username for User Registration")
+		c.JSON(http.StatusBadRequest, models.UserRegisterErrorResponse{
+			Message: "Invalid Username",
+			Error:   err.Error(),
+			Code:    helpers.ErrorCode(err),
+			Field:   "username",
 		})
 		return
 	}
+	req.Username = username
 
 	hashedPassword, err := helpers.HashPassword(req.Password)
 	if err != nil {
@@ -69,6 +294,7 @@ func (ac *AuthController) Register(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, models.UserRegisterErrorResponse{
 			Message: "Failed to Register User",
 			Error:   "failed to hash password",
+			Code:    helpers.CodeInternal,
 		})
 		return
 	}
@@ -86,6 +312,7 @@ func (ac *AuthController) Register(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, models.UserRegisterErrorResponse{
 			Message: "Failed to Register User",
 			Error:   "failed to generate activation token",
+			Code:    helpers.CodeInternal,
 		})
 		return
 	}
@@ -100,12 +327,14 @@ func (ac *AuthController) Register(c *gin.Context) {
 			c.JSON(http.StatusConflict, models.UserRegisterErrorResponse{
 				Message: "User Already Exists",
 				Error:   err.Error(),
+				Code:    helpers.ErrorCode(err),
 			})
 		} else {
 			ac.logger.WithFields(logrus.Fields{"error": err, "username": req.Username, "email": req.Email}).Error("Failed to Create User in Store")
 			c.JSON(http.StatusInternalServerError, models.UserRegisterErrorResponse{
 				Message: "Failed to Register User",
 				Error:   "failed to create user",
+				Code:    helpers.CodeInternal,
 			})
 		}
 		return
@@ -113,31 +342,56 @@ func (ac *AuthController) Register(c *gin.Context) {
 
 	activationLink := fmt.Sprintf("%s/api/v1/auth/activate?token=%s", DOMAIN, activationToken)
 
-	c.JSON(http.StatusCreated, models.UserRegisterSuccessResponse{
-		Message:        "User Registered Successfully",
-		User:           createdUser,
-		ActivationLink: activationLink,
-	})
+	if err := ac.sendActivationEmail(createdUser, activationLink); err != nil {
+		ac.logger.WithFields(logrus.Fields{"error": err, "userID": createdUser.ID}).Error("Failed to Send Activation Email")
+	}
+
+	ac.webhookDispatcher.Dispatch(helpers.WebhookEventUserRegistered, gin.H{"user_id": createdUser.ID, "username": createdUser.Username})
+
+	response := models.UserRegisterSuccessResponse{
+		Message: "User Registered Successfully",
+		User:    createdUser,
+	}
+	if exposeLinks() {
+		response.ActivationLink = activationLink
+	}
+
+	c.JSON(http.StatusCreated, response)
 }
 
 // Login godoc
 // @Summary      Login user
-// @Description  Logs in an existing user and returns access and refresh tokens as secure cookies.
+// @Description  Logs in an existing user and returns access and refresh tokens as secure cookies. The user includes their profile if one exists.
+// @Description  With tokenDelivery=body the tokens are returned in the response body instead, for clients sending an Authorization: Bearer header.
 // @Tags         auth
 // @Accept       json
 // @Produce      json
+// @Param        tokenDelivery query string false "Where to return the tokens: cookie (default) or body" Enums(cookie, body)
 // @Param        body body models.UserLoginPayload true "Request Body for User Login"
 // @Success      200 {object} models.UserLoginSuccessResponse "Successfully logged in"
 // @Failure      400 {object} models.UserLoginErrorResponse "Bad Request - Invalid input"
 // @Failure      401 {object} models.UserLoginErrorResponse "Unauthorized - Invalid credentials"
 // @Failure      403 {object} models.UserLoginErrorResponse "Forbidden - Account not activated"
+// @Failure      429 {object} models.UserLoginErrorResponse "Too Many Requests - Account temporarily locked"
 // @Failure      500 {object} models.UserLoginErrorResponse "Internal Server Error - Failed to login user"
 // @Router       /auth/login [post]
 func (ac *AuthController) Login(c *gin.Context) {
+	tokenDelivery := c.DefaultQuery("tokenDelivery", tokenDeliveryCookie)
+	if err := helpers.ValidateEnum(tokenDelivery, tokenDeliveries); err != nil {
+		ac.logger.WithFields(logrus.Fields{"error": err, "tokenDelivery": tokenDelivery}).Error("Invalid Token Delivery for User Login")
+		c.JSON(http.StatusBadRequest, models.UserLoginErrorResponse{
+			Message: "Invalid Request",
+			Error:   "tokenDelivery " + err.Error(),
+			Code:    helpers.ErrorCode(err),
+		})
+		return
+	}
+
 	accessTokenCookie, err := c.Cookie("access_token")
 	refreshTokenCookie, _ := c.Cookie("refresh_token")
 
-	if err == nil {
+	// Existing cookies are only reused for cookie delivery, a body delivery login always issues new tokens.
+	if err == nil && tokenDelivery == tokenDeliveryCookie {
 		accessToken, err := helpers.VerifyAccessToken(accessTokenCookie)
 		if err == nil && accessToken.Valid {
 			userID, err := helpers.ExtractUserIDFromToken(accessToken)
@@ -146,9 +400,28 @@ func (ac *AuthController) Login(c *gin.Context) {
 				c.JSON(http.StatusInternalServerError, models.UserLoginErrorResponse{
 					Message: "Login Failed",
 					Error:   "internal server error",
+					Code:    helpers.CodeInternal,
+				})
+				return
+			}
+
+			sessionID, err := helpers.ExtractSessionIDFromToken(accessToken)
+			if err != nil {
+				goto RefreshOrNormalLogin
+			}
+			revoked, err := ac.sessionStore.IsSessionRevoked(c, sessionID)
+			if err != nil {
+				ac.logger.WithFields(logrus.Fields{"error": err, "sessionID": sessionID}).Error("Failed to Check Session Denylist")
+				c.JSON(http.StatusInternalServerError, models.UserLoginErrorResponse{
+					Message: "Login Failed",
+					Error:   "internal server error",
+					Code:    helpers.CodeInternal,
 				})
 				return
 			}
+			if revoked {
+				goto RefreshOrNormalLogin
+			}
 
 			user, err := ac.authStore.GetUserByID(c, userID)
 			if err != nil {
@@ -159,6 +432,7 @@ func (ac *AuthController) Login(c *gin.Context) {
 					c.JSON(http.StatusInternalServerError, models.UserLoginErrorResponse{
 						Message: "Login Failed",
 						Error:   "internal server error",
+						Code:    helpers.CodeInternal,
 					})
 					return
 				}
@@ -168,6 +442,7 @@ func (ac *AuthController) Login(c *gin.Context) {
 				c.JSON(http.StatusForbidden, models.UserLoginErrorResponse{
 					Message: "Login Failed",
 					Error:   "account not activated",
+					Code:    helpers.CodeAccountNotActivated,
 				})
 				return
 			}
@@ -187,6 +462,23 @@ func (ac *AuthController) Login(c *gin.Context) {
 						c.JSON(http.StatusInternalServerError, models.UserLoginErrorResponse{
 							Message: "Login Failed",
 							Error:   "internal server error",
+							Code:    helpers.CodeInternal,
+						})
+						return
+					}
+					sessionID, err := helpers.ExtractSessionIDFromToken(refreshToken)
+					if err != nil {
+						goto NormalLogin
+					}
+					if err := ac.sessionStore.TouchSession(c, sessionID); err != nil {
+						if errors.Is(err, stores.ErrSessionRevoked) || errors.Is(err, stores.ErrSessionNotFound) {
+							goto NormalLogin
+						}
+						ac.logger.WithFields(logrus.Fields{"error": err, "sessionID": sessionID}).Error("Failed to Update Session for Refresh")
+						c.JSON(http.StatusInternalServerError, models.UserLoginErrorResponse{
+							Message: "Login Failed",
+							Error:   "internal server error",
+							Code:    helpers.CodeInternal,
 						})
 						return
 					}
@@ -199,6 +491,7 @@ func (ac *AuthController) Login(c *gin.Context) {
 							c.JSON(http.StatusInternalServerError, models.UserLoginErrorResponse{
 								Message: "Login Failed",
 								Error:   "internal server error",
+								Code:    helpers.CodeInternal,
 							})
 							return
 						}
@@ -208,31 +501,43 @@ func (ac *AuthController) Login(c *gin.Context) {
 						c.JSON(http.StatusForbidden, models.UserLoginErrorResponse{
 							Message: "Login Failed",
 							Error:   "account not activated",
+							Code:    helpers.CodeAccountNotActivated,
 						})
 						return
 					}
 
-					newAccessToken, err := helpers.GenerateAccessToken(user.ID)
+					newAccessToken, err := helpers.GenerateAccessToken(user.ID, sessionID)
 					if err != nil {
 						ac.logger.WithFields(logrus.Fields{"error": err, "userID": user.ID}).Error("Failed to generate new access token during refresh")
 						c.JSON(http.StatusInternalServerError, models.UserLoginErrorResponse{
 							Message: "Login Failed",
 							Error:   "failed to generate tokens",
+							Code:    helpers.CodeInternal,
 						})
 						return
 					}
-					newRefreshToken, err := helpers.GenerateRefreshToken(user.ID)
+					newRefreshToken, err := helpers.GenerateRefreshToken(user.ID, sessionID)
 					if err != nil {
 						ac.logger.WithFields(logrus.Fields{"error": err, "userID": user.ID}).Error("Failed to generate new refresh token during refresh")
 						c.JSON(http.StatusInternalServerError, models.UserLoginErrorResponse{
 							Message: "Login Failed",
 							Error:   "failed to generate tokens",
+							Code:    helpers.CodeInternal,
 						})
 						return
 					}
 
 					c.SetCookie("access_token", newAccessToken, int(time.Minute*30/time.Second), "/", "", true, true)
 					c.SetCookie("refresh_token", newRefreshToken, int(time.Hour*6/time.Second), "/", "", true, true)
+					if err := middlewares.SetCSRFCookie(c); err != nil {
+						ac.logger.WithFields(logrus.Fields{"error": err, "userID": user.ID}).Error("Failed to Generate CSRF Token")
+						c.JSON(http.StatusInternalServerError, models.UserLoginErrorResponse{
+							Message: "Login Failed",
+							Error:   "failed to generate tokens",
+							Code:    helpers.CodeInternal,
+						})
+						return
+					}
 
 					log.Printf("User Logged in Successfully (Refreshed Tokens): %v", user.ID)
 					c.JSON(http.StatusOK, models.UserLoginSuccessResponse{
@@ -246,7 +551,7 @@ func (ac *AuthController) Login(c *gin.Context) {
 	}
 
 RefreshOrNormalLogin:
-	if refreshTokenCookie != "" {
+	if refreshTokenCookie != "" && tokenDelivery == tokenDeliveryCookie {
 		refreshToken, err := helpers.VerifyRefreshToken(refreshTokenCookie)
 		if err == nil && refreshToken.Valid {
 			userID, err := helpers.ExtractUserIDFromToken(refreshToken)
@@ -255,6 +560,23 @@ RefreshOrNormalLogin:
 				c.JSON(http.StatusInternalServerError, models.UserLoginErrorResponse{
 					Message: "Login Failed",
 					Error:   "internal server error",
+					Code:    helpers.CodeInternal,
+				})
+				return
+			}
+			sessionID, err := helpers.ExtractSessionIDFromToken(refreshToken)
+			if err != nil {
+				goto NormalLogin
+			}
+			if err := ac.sessionStore.TouchSession(c, sessionID); err != nil {
+				if errors.Is(err, stores.ErrSessionRevoked) || errors.Is(err, stores.ErrSessionNotFound) {
+					goto NormalLogin
+				}
+				ac.logger.WithFields(logrus.Fields{"error": err, "sessionID": sessionID}).Error("Failed to Update Session for Refresh")
+				c.JSON(http.StatusInternalServerError, models.UserLoginErrorResponse{
+					Message: "Login Failed",
+					Error:   "internal server error",
+					Code:    helpers.CodeInternal,
 				})
 				return
 			}
@@ -267,6 +589,7 @@ RefreshOrNormalLogin:
 					c.JSON(http.StatusInternalServerError, models.UserLoginErrorResponse{
 						Message: "Login Failed",
 						Error:   "internal server error",
+						Code:    helpers.CodeInternal,
 					})
 					return
 				}
@@ -276,31 +599,43 @@ RefreshOrNormalLogin:
 				c.JSON(http.StatusForbidden, models.UserLoginErrorResponse{
 					Message: "Login Failed",
 					Error:   "account not activated",
+					Code:    helpers.CodeAccountNotActivated,
 				})
 				return
 			}
 
-			newAccessToken, err := helpers.GenerateAccessToken(user.ID)
+			newAccessToken, err := helpers.GenerateAccessToken(user.ID, sessionID)
 			if err != nil {
 				ac.logger.WithFields(logrus.Fields{"error": err, "userID": user.ID}).Error("Failed to generate new access token during refresh")
 				c.JSON(http.StatusInternalServerError, models.UserLoginErrorResponse{
 					Message: "Login Failed",
 					Error:   "failed to generate tokens",
+					Code:    helpers.CodeInternal,
 				})
 				return
 			}
-			newRefreshToken, err := helpers.GenerateRefreshToken(user.ID)
+			newRefreshToken, err := helpers.GenerateRefreshToken(user.ID, sessionID)
 			if err != nil {
 				ac.logger.WithFields(logrus.Fields{"error": err, "userID": user.ID}).Error("Failed to generate new refresh token during refresh")
 				c.JSON(http.StatusInternalServerError, models.UserLoginErrorResponse{
 					Message: "Login Failed",
 					Error:   "failed to generate tokens",
+					Code:    helpers.CodeInternal,
 				})
 				return
 			}
 
 			c.SetCookie("access_token", newAccessToken, int(time.Minute*30/time.Second), "/", "", true, true)
 			c.SetCookie("refresh_token", newRefreshToken, int(time.Hour*6/time.Second), "/", "", true, true)
+			if err := middlewares.SetCSRFCookie(c); err != nil {
+				ac.logger.WithFields(logrus.Fields{"error": err, "userID": user.ID}).Error("Failed to Generate CSRF Token")
+				c.JSON(http.StatusInternalServerError, models.UserLoginErrorResponse{
+					Message: "Login Failed",
+					Error:   "failed to generate tokens",
+					Code:    helpers.CodeInternal,
+				})
+				return
+			}
 
 			log.Printf("User Logged in Successfully (Refreshed Tokens): %v", user.ID)
 			c.JSON(http.StatusOK, models.UserLoginSuccessResponse{
@@ -318,6 +653,7 @@ NormalLogin:
 		c.JSON(http.StatusBadRequest, models.UserLoginErrorResponse{
 			Message: "Invalid Request Body",
 			Error:   err.Error(),
+			Code:    helpers.CodeValidationFailed,
 		})
 		return
 	}
@@ -329,12 +665,14 @@ NormalLogin:
 			c.JSON(http.StatusUnauthorized, models.UserLoginErrorResponse{
 				Message: "Login Failed",
 				Error:   "invalid credentials",
+				Code:    helpers.ErrorCode(err),
 			})
 		} else {
 			ac.logger.WithFields(logrus.Fields{"error": err, "identifier": req.Identifier}).Error("Failed to Fetch User from Store")
 			c.JSON(http.StatusInternalServerError, models.UserLoginErrorResponse{
 				Message: "Login Failed",
 				Error:   "failed to authenticate user",
+				Code:    helpers.CodeInternal,
 			})
 		}
 		return
@@ -345,41 +683,120 @@ NormalLogin:
 		c.JSON(http.StatusForbidden, models.UserLoginErrorResponse{
 			Message: "Login Failed",
 			Error:   "account not activated",
+			Code:    helpers.CodeAccountNotActivated,
+		})
+		return
+	}
+
+	lockoutRemaining, err := ac.loginLockoutRemaining(c, user.ID)
+	if err != nil {
+		ac.logger.WithFields(logrus.Fields{"error": err, "userID": user.ID}).Error("Failed to Get Login Failure Count from Redis")
+		c.JSON(http.StatusInternalServerError, models.UserLoginErrorResponse{
+			Message: "Login Failed",
+			Error:   "failed to authenticate user",
+			Code:    helpers.CodeInternal,
+		})
+		return
+	}
+	if lockoutRemaining > 0 {
+		ac.logger.WithFields(logrus.Fields{"userID": user.ID, "remaining": lockoutRemaining}).Warn("Login Attempt on Locked Account")
+		c.Header("Retry-After", strconv.Itoa(int(lockoutRemaining.Seconds())))
+		c.JSON(http.StatusTooManyRequests, models.UserLoginErrorResponse{
+			Message: "Account Temporarily Locked",
+			Error:   fmt.Sprintf("too many failed login attempts, try again in %s", lockoutRemaining.Round(time.Second)),
+			Code:    helpers.CodeAccountLocked,
 		})
 		return
 	}
 
 	if err := helpers.ComparePassword(user.PasswordHash, req.Password); err != nil {
 		ac.logger.WithFields(logrus.Fields{"error": err, "identifier": req.Identifier}).Error("Invalid Password")
+
+		lockoutDuration, err := ac.recordLoginFailure(c, user.ID)
+		if err != nil {
+			ac.logger.WithFields(logrus.Fields{"error": err, "userID": user.ID}).Error("Failed to Record Login Failure in Redis")
+		}
+		if lockoutDuration > 0 {
+			ac.logger.WithFields(logrus.Fields{"userID": user.ID, "lockout": lockoutDuration}).Warn("Account Locked After Repeated Failed Logins")
+			c.Header("Retry-After", strconv.Itoa(int(lockoutDuration.Seconds())))
+			c.JSON(http.StatusTooManyRequests, models.UserLoginErrorResponse{
+				Message: "Account Temporarily Locked",
+				Error:   fmt.Sprintf("too many failed login attempts, try again in %s", lockoutDuration),
+				Code:    helpers.CodeAccountLocked,
+			})
+			return
+		}
+
 		c.JSON(http.StatusUnauthorized, models.UserLoginErrorResponse{
 			Message: "Login Failed",
 			Error:   "invalid credentials",
+			Code:    helpers.CodeInvalidCredentials,
+		})
+		return
+	}
+
+	if err := ac.redisClient.Del(c, loginFailKeyPrefix+user.ID.String()).Err(); err != nil {
+		ac.logger.WithFields(logrus.Fields{"error": err, "userID": user.ID}).Warn("Failed to Reset Login Failure Count in Redis")
+	}
+
+	if helpers.PasswordNeedsRehash(user.PasswordHash) {
+		ac.rehashPassword(c, user.ID, req.Password)
+	}
+
+	session, err := ac.startSession(c, user.ID)
+	if err != nil {
+		ac.logger.WithFields(logrus.Fields{"error": err, "userID": user.ID}).Error("Failed to Create Session")
+		c.JSON(http.StatusInternalServerError, models.UserLoginErrorResponse{
+			Message: "Login Failed",
+			Error:   "failed to create session",
+			Code:    helpers.CodeInternal,
 		})
 		return
 	}
 
-	accessToken, err := helpers.GenerateAccessToken(user.ID)
+	accessToken, err := helpers.GenerateAccessToken(user.ID, session.ID)
 	if err != nil {
 		ac.logger.WithFields(logrus.Fields{"error": err, "userID": user.ID}).Error("Failed to Generate Access Token")
 		c.JSON(http.StatusInternalServerError, models.UserLoginErrorResponse{
 			Message: "Login Failed",
 			Error:   "failed to generate tokens",
+			Code:    helpers.CodeInternal,
 		})
 		return
 	}
 
-	refreshToken, err := helpers.GenerateRefreshToken(user.ID)
+	refreshToken, err := helpers.GenerateRefreshToken(user.ID, session.ID)
 	if err != nil {
 		ac.logger.WithFields(logrus.Fields{"error": err, "userID": user.ID}).Error("Failed to Generate Refresh Token")
 		c.JSON(http.StatusInternalServerError, models.UserLoginErrorResponse{
 			Message: "Login Failed",
 			Error:   "failed to generate tokens",
+			Code:    helpers.CodeInternal,
 		})
 		return
 	}
 
-	c.SetCookie("access_token", accessToken, int(time.Minute*30/time.Second), "/", "", true, true)
-	c.SetCookie("refresh_token", refreshToken, int(time.Hour*6/time.Second), "/", "", true, true)
+	var tokens *models.AuthTokens
+	if tokenDelivery == tokenDeliveryBody {
+		tokens = &models.AuthTokens{
+			AccessToken:  accessToken,
+			RefreshToken: refreshToken,
+			TokenType:    "Bearer",
+			ExpiresIn:    int(time.Minute * 30 / time.Second),
+		}
+	} else {
+		c.SetCookie("access_token", accessToken, int(time.Minute*30/time.Second), "/", "", true, true)
+		c.SetCookie("refresh_token", refreshToken, int(time.Hour*6/time.Second), "/", "", true, true)
+		if err := middlewares.SetCSRFCookie(c); err != nil {
+			ac.logger.WithFields(logrus.Fields{"error": err, "userID": user.ID}).Error("Failed to Generate CSRF Token")
+			c.JSON(http.StatusInternalServerError, models.UserLoginErrorResponse{
+				Message: "Login Failed",
+				Error:   "failed to generate tokens",
+				Code:    helpers.CodeInternal,
+			})
+			return
+		}
+	}
 
 	log.Printf("User Logged in Successfully: %v", user.ID)
 	loggedInUser, err := ac.authStore.GetUserByUsernameOrEmail(c, req.Identifier)
@@ -388,12 +805,14 @@ NormalLogin:
 		c.JSON(http.StatusInternalServerError, models.UserLoginErrorResponse{
 			Message: "Login Successful",
 			Error:   "failed to fetch user for response",
+			Code:    helpers.CodeInternal,
 		})
 		return
 	}
 	c.JSON(http.StatusOK, models.UserLoginSuccessResponse{
 		Message: "Login Successful",
 		User:    loggedInUser,
+		Tokens:  tokens,
 	})
 }
 
@@ -407,18 +826,31 @@ NormalLogin:
 // @Router       /auth/logout [post]
 func (ac *AuthController) Logout(c *gin.Context) {
 	_, errAccessToken := c.Cookie("access_token")
-	_, errRefreshToken := c.Cookie("refresh_token")
+	refreshTokenCookie, errRefreshToken := c.Cookie("refresh_token")
 
 	if errors.Is(errAccessToken, http.ErrNoCookie) || errors.Is(errRefreshToken, http.ErrNoCookie) {
 		ac.logger.WithFields(logrus.Fields{"request-id": c.GetString("request-id")}).Warn("Logout Attempted without Cookies, User Not Logged In")
 		c.JSON(http.StatusBadRequest, models.UserLogoutErrorResponse{
 			Message: "User Not Logged In",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
 
+	if refreshToken, err := helpers.VerifyRefreshToken(refreshTokenCookie); err == nil && refreshToken.Valid {
+		userID, errUserID := helpers.ExtractUserIDFromToken(refreshToken)
+		sessionID, errSessionID := helpers.ExtractSessionIDFromToken(refreshToken)
+		if errUserID == nil && errSessionID == nil {
+			err := ac.sessionStore.RevokeSession(c, userID, sessionID, helpers.RefreshTokenExpiry())
+			if err != nil && !errors.Is(err, stores.ErrSessionNotFound) {
+				ac.logger.WithFields(logrus.Fields{"error": err, "sessionID": sessionID}).Warn("Failed to Revoke Session on Logout")
+			}
+		}
+	}
+
 	c.SetCookie("access_token", "", -1, "/", "", true, true)
 	c.SetCookie("refresh_token", "", -1, "/", "", true, true)
+	middlewares.ClearCSRFCookie(c)
 
 	ac.logger.WithFields(logrus.Fields{"request-id": c.GetString("request-id")}).Info("User Logged Out Successfully")
 
@@ -429,7 +861,7 @@ func (ac *AuthController) Logout(c *gin.Context) {
 
 // ForgotPassword godoc
 // @Summary      Initiate forgot password flow
-// @Description  Initiates the forgot password flow by generating a reset link and sending it to the user's email if the user exists.
+// @Description  Initiates the forgot password flow by generating a reset link and sending it to the user's email if the user exists. Only the latest link of a user is valid, and requests past FORGOT_PASSWORD_MAX_REQUESTS per user within FORGOT_PASSWORD_WINDOW_MINUTES, whether it is named by username or email, get the same response without sending a link.
 // @Tags         auth
 // @Accept       json
 // @Produce      json
@@ -445,26 +877,53 @@ func (ac *AuthController) ForgotPassword(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.ForgotPasswordErrorResponse{
 			Message: "Invalid Request Body",
 			Error:   err.Error(),
+			Code:    helpers.CodeValidationFailed,
 		})
 		return
 	}
 
+	// Requests are counted under the user ID, so naming the same user by username and by email shares one quota.
+	// Unknown identifiers are counted under the identifier, so both cases touch the throttle alike.
+	subject := forgotPasswordIdentifierSubject(req.Identifier)
 	user, err := ac.authStore.GetUserByUsernameOrEmail(c, req.Identifier)
+	if err == nil {
+		subject = forgotPasswordUserSubject(user.ID)
+	} else if !errors.Is(err, stores.ErrUserNotFound) {
+		ac.logger.WithFields(logrus.Fields{"error": err, "identifier": req.Identifier}).Error("Failed to Get User from Store for Forgot Password")
+		c.JSON(http.StatusInternalServerError, models.ForgotPasswordErrorResponse{
+			Message: "Failed to Initiate Password Reset",
+			Error:   "failed to fetch user",
+			Code:    helpers.CodeInternal,
+		})
+		return
+	}
+
+	// Throttled requests get the same response as accepted ones, so the throttle reveals nothing about the account.
+	// Skipping them also keeps a burst of requests from sending several reset emails, only the latest link is usable anyway.
+	allowed, err := ac.allowForgotPassword(c, subject)
 	if err != nil {
-		if errors.Is(err, stores.ErrUserNotFound) {
-			ac.logger.WithFields(logrus.Fields{"identifier": req.Identifier}).Info("Forgot Password Request for Non-Existent User")
-			c.JSON(http.StatusOK, models.ForgotPasswordSuccessResponse{
-				Message: "Password Reset Link Sent Successfully",
-			})
-			return
-		} else {
-			ac.logger.WithFields(logrus.Fields{"error": err, "identifier": req.Identifier}).Error("Failed to Get User from Store for Forgot Password")
-			c.JSON(http.StatusInternalServerError, models.ForgotPasswordErrorResponse{
-				Message: "Failed to Initiate Password Reset",
-				Error:   "failed to fetch user",
-			})
-			return
-		}
+		ac.logger.WithFields(logrus.Fields{"error": err, "identifier": req.Identifier}).Error("Failed to Check Forgot Password Throttle in Redis")
+		c.JSON(http.StatusInternalServerError, models.ForgotPasswordErrorResponse{
+			Message: "Failed to Initiate Password Reset",
+			Error:   "failed to check forgot password throttle",
+			Code:    helpers.CodeInternal,
+		})
+		return
+	}
+	if !allowed {
+		ac.logger.WithFields(logrus.Fields{"identifier": req.Identifier}).Info("Forgot Password Throttled")
+		c.JSON(http.StatusOK, models.ForgotPasswordSuccessResponse{
+			Message: "Password Reset Link Sent Successfully",
+		})
+		return
+	}
+
+	if user == nil {
+		ac.logger.WithFields(logrus.Fields{"identifier": req.Identifier}).Info("Forgot Password Request for Non-Existent User")
+		c.JSON(http.StatusOK, models.ForgotPasswordSuccessResponse{
+			Message: "Password Reset Link Sent Successfully",
+		})
+		return
 	}
 
 	resetToken, err := helpers.GeneratePasswordResetToken(user.ID)
@@ -473,6 +932,7 @@ func (ac *AuthController) ForgotPassword(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, models.ForgotPasswordErrorResponse{
 			Message: "Failed to Initiate Password Reset",
 			Error:   "failed to generate reset token",
+			Code:    helpers.CodeInternal,
 		})
 		return
 	}
@@ -484,17 +944,29 @@ func (ac *AuthController) ForgotPassword(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, models.ForgotPasswordErrorResponse{
 			Message: "Failed to Initiate Password Reset",
 			Error:   "failed to save reset token",
+			Code:    helpers.CodeInternal,
 		})
 		return
 	}
 
+	resetLink := fmt.Sprintf("%s?token=%s", PASSWORD_RESET_URL, resetToken)
+	// A failed email is answered like a sent one, an error here would tell that the account exists.
+	if err := ac.sendPasswordResetEmail(user, resetLink); err != nil {
+		ac.logger.WithFields(logrus.Fields{"error": err, "userID": user.ID}).Error("Failed to Send Password Reset Email")
+	}
+
 	c.SetCookie("access_token", "", -1, "/", "", true, true)
 	c.SetCookie("refresh_token", "", -1, "/", "", true, true)
+	middlewares.ClearCSRFCookie(c)
 
-	c.JSON(http.StatusOK, models.ForgotPasswordSuccessResponse{
+	response := models.ForgotPasswordSuccessResponse{
 		Message: "Password Reset Link Sent Successfully",
-		Link:    fmt.Sprintf("%s/api/v1/reset-password?token=%s", DOMAIN, resetToken),
-	})
+	}
+	if exposeLinks() {
+		response.Link = resetLink
+	}
+
+	c.JSON(http.StatusOK, response)
 }
 
 // ResetPassword godoc
@@ -517,6 +989,7 @@ func (ac *AuthController) ResetPassword(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.ResetPasswordErrorResponse{
 			Message: "Invalid Request Body",
 			Error:   err.Error(),
+			Code:    helpers.CodeValidationFailed,
 		})
 		return
 	}
@@ -527,6 +1000,7 @@ func (ac *AuthController) ResetPassword(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.ResetPasswordErrorResponse{
 			Message: "Invalid Request",
 			Error:   "token is required in query parameters",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
@@ -538,12 +1012,14 @@ func (ac *AuthController) ResetPassword(c *gin.Context) {
 			c.JSON(http.StatusUnauthorized, models.ResetPasswordErrorResponse{
 				Message: "Invalid or Expired Reset Token",
 				Error:   "invalid or expired token",
+				Code:    helpers.ErrorCode(err),
 			})
 		} else {
 			ac.logger.WithFields(logrus.Fields{"error": err, "token": token}).Error("Failed to Validate Password Reset Token")
 			c.JSON(http.StatusInternalServerError, models.ResetPasswordErrorResponse{
 				Message: "Failed to Reset Password",
 				Error:   "failed to validate reset token",
+				Code:    helpers.CodeInternal,
 			})
 		}
 		return
@@ -555,6 +1031,7 @@ func (ac *AuthController) ResetPassword(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, models.ResetPasswordErrorResponse{
 			Message: "Failed to Reset Password",
 			Error:   "failed to hash new password",
+			Code:    helpers.CodeInternal,
 		})
 		return
 	}
@@ -565,6 +1042,7 @@ func (ac *AuthController) ResetPassword(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, models.ResetPasswordErrorResponse{
 			Message: "Failed to Reset Password",
 			Error:   "failed to update password",
+			Code:    helpers.CodeInternal,
 		})
 		return
 	}
@@ -579,71 +1057,282 @@ func (ac *AuthController) ResetPassword(c *gin.Context) {
 	})
 }
 
-// ActivateUser godoc
-// @Summary      Activate user account
-// @Description  Activates a user account using the activation token from the query parameter.
+// ChangeEmail godoc
+// @Summary      Request an email change
+// @Description  Starts changing the email of the logged-in user. After checking the current password, a verification link is sent to the new email, which only replaces the current email once the link is visited. The link is only included in the response outside of release mode. Users signed up through OAuth without a password cannot change their email.
 // @Tags         auth
+// @Accept       json
 // @Produce      json
-// @Param        token query string true "Activation Token"
-// @Success      200 {object} models.ActivateUserSuccessResponse "Successfully activated user account"
-// @Failure      400 {object} models.ActivateUserErrorResponse "Bad Request - Invalid input"
-// @Failure      401 {object} models.ActivateUserErrorResponse "Unauthorized - Invalid or expired activation token"
-// @Failure      500 {object} models.ActivateUserErrorResponse "Internal Server Error - Failed to activate user account"
-// @Router       /auth/activate [get]
-func (ac *AuthController) ActivateUser(c *gin.Context) {
-	token := c.Query("token")
-	if token == "" {
-		ac.logger.WithFields(logrus.Fields{"error": "token missing in query params"}).Error("Invalid Request: Token Missing")
-		c.JSON(http.StatusBadRequest, models.ActivateUserErrorResponse{
-			Message: "Invalid Request",
-			Error:   "token is required in query parameters",
+// @Security     BearerAuth
+// @Param        body body models.ChangeEmailPayload true "Request Body for Change Email"
+// @Success      200 {object} models.ChangeEmailSuccessResponse "Successfully sent email change verification link"
+// @Failure      400 {object} models.ChangeEmailErrorResponse "Bad Request - Invalid input or new email is the current email"
+// @Failure      401 {object} models.ChangeEmailErrorResponse "Unauthorized - User not logged in or invalid password"
+// @Failure      409 {object} models.ChangeEmailErrorResponse "Conflict - Email already in use"
+// @Failure      500 {object} models.ChangeEmailErrorResponse "Internal Server Error - Failed to request email change"
+// @Router       /auth/change-email [post]
+func (ac *AuthController) ChangeEmail(c *gin.Context) {
+	currentUserModel := helpers.RequireUser(c)
+
+	var req models.ChangeEmailPayload
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ac.logger.WithFields(logrus.Fields{"error": err, "userID": currentUserModel.ID}).Error("Invalid Request Body for Change Email")
+		c.JSON(http.StatusBadRequest, models.ChangeEmailErrorResponse{
+			Message: "Invalid Request Body",
+			Error:   err.Error(),
+			Code:    helpers.CodeValidationFailed,
 		})
 		return
 	}
+	newEmail := strings.TrimSpace(req.NewEmail)
 
-	userID, err := ac.authStore.ValidateActivationToken(c, token, time.Now())
+	// The user in the context may come from the user cache, which never holds the password hash.
+	user, err := ac.authStore.GetUserByUsernameOrEmail(c, currentUserModel.Username)
 	if err != nil {
-		if errors.Is(err, stores.ErrInvalidOrExpiredActivationToken) {
-			ac.logger.WithFields(logrus.Fields{"error": err, "token": token}).Error("Invalid or Expired Activation Token")
-			c.JSON(http.StatusUnauthorized, models.ActivateUserErrorResponse{
-				Message: "Invalid or Expired Activation Token",
-				Error:   "invalid or expired token",
-			})
-		} else {
-			ac.logger.WithFields(logrus.Fields{"error": err, "token": token}).Error("Failed to Validate Activation Token")
-			c.JSON(http.StatusInternalServerError, models.ActivateUserErrorResponse{
-				Message: "Failed to Activate User",
-				Error:   "failed to validate activation token",
-			})
-		}
+		ac.logger.WithFields(logrus.Fields{"error": err, "userID": currentUserModel.ID}).Error("Failed to Get User from Store for Change Email")
+		c.JSON(http.StatusInternalServerError, models.ChangeEmailErrorResponse{
+			Message: "Failed to Request Email Change",
+			Error:   "failed to fetch user",
+			Code:    helpers.CodeInternal,
+		})
 		return
 	}
 
-	err = ac.authStore.ActivateUser(c, userID)
-	if err != nil {
-		ac.logger.WithFields(logrus.Fields{"error": err, "userID": userID}).Error("Failed to Activate User in Store")
-		c.JSON(http.StatusInternalServerError, models.ActivateUserErrorResponse{
-			Message: "Failed to Activate User",
-			Error:   "failed to activate user in database",
+	if err := helpers.ComparePassword(user.PasswordHash, req.Password); err != nil {
+		ac.logger.WithFields(logrus.Fields{"error": err, "userID": user.ID}).Error("Invalid Password for Change Email")
+		c.JSON(http.StatusUnauthorized, models.ChangeEmailErrorResponse{
+			Message: "Change Email Failed",
+			Error:   "invalid credentials",
+			Code:    helpers.CodeInvalidCredentials,
 		})
 		return
 	}
 
-	_, err = ac.profileStore.CreateProfile(c, &models.Profile{UserID: userID})
-	if err != nil {
-		ac.logger.WithFields(logrus.Fields{"error": err, "userID": userID}).Error("Failed to Create Profile for User")
-		c.JSON(http.StatusInternalServerError, models.ActivateUserErrorResponse{
-			Message: "Failed to Activate User",
-			Error:   "failed to create profile",
+	if strings.EqualFold(newEmail, user.Email) {
+		ac.logger.WithFields(logrus.Fields{"userID": user.ID}).Error("New Email is the Current Email")
+		c.JSON(http.StatusBadRequest, models.ChangeEmailErrorResponse{
+			Message: "Invalid Request",
+			Error:   "new email is the current email",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
 
-	err = ac.authStore.InvalidateActivationToken(c, token)
+	emailChangeToken, err := helpers.GenerateEmailChangeToken(user.ID)
 	if err != nil {
-		ac.logger.WithFields(logrus.Fields{"error": err, "token": token}).Warn("Failed to Invalidate Activation Token, But User Activation was Successful")
+		ac.logger.WithFields(logrus.Fields{"error": err, "userID": user.ID}).Error("Failed to Generate Email Change Token")
+		c.JSON(http.StatusInternalServerError, models.ChangeEmailErrorResponse{
+			Message: "Failed to Request Email Change",
+			Error:   "failed to generate email change token",
+			Code:    helpers.CodeInternal,
+		})
+		return
 	}
 
+	expiryTime := time.Now().Add(time.Minute * 15)
+	if err := ac.authStore.CreateEmailChangeRequest(c, user.ID, newEmail, emailChangeToken, expiryTime); err != nil {
+		if errors.Is(err, stores.ErrEmailAlreadyInUse) {
+			ac.logger.WithFields(logrus.Fields{"userID": user.ID}).Error("New Email Already in Use")
+			c.JSON(http.StatusConflict, models.ChangeEmailErrorResponse{
+				Message: "Change Email Failed",
+				Error:   err.Error(),
+				Code:    helpers.ErrorCode(err),
+			})
+		} else {
+			ac.logger.WithFields(logrus.Fields{"error": err, "userID": user.ID}).Error("Failed to Save Email Change Request to Store")
+			c.JSON(http.StatusInternalServerError, models.ChangeEmailErrorResponse{
+				Message: "Failed to Request Email Change",
+				Error:   "failed to save email change request",
+				Code:    helpers.CodeInternal,
+			})
+		}
+		return
+	}
+
+	verifyLink := fmt.Sprintf("%s/api/v1/auth/verify-email-change?token=%s", DOMAIN, emailChangeToken)
+	if err := ac.sendEmailChangeEmail(user, newEmail, verifyLink); err != nil {
+		ac.logger.WithFields(logrus.Fields{"error": err, "userID": user.ID}).Error("Failed to Send Email Change Verification Email")
+		c.JSON(http.StatusInternalServerError, models.ChangeEmailErrorResponse{
+			Message: "Failed to Request Email Change",
+			Error:   "failed to send verification email",
+			Code:    helpers.CodeInternal,
+		})
+		return
+	}
+
+	response := models.ChangeEmailSuccessResponse{
+		Message: "Email Change Verification Link Sent Successfully",
+	}
+	if exposeLinks() {
+		response.Link = verifyLink
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// VerifyEmailChange godoc
+// @Summary      Confirm an email change
+// @Description  Replaces the email of a user with the new email requested through /auth/change-email, using the token of the verification link. The user is logged out of every session once the email changes.
+// @Tags         auth
+// @Produce      json
+// @Param        token query string true "Email Change Token"
+// @Success      200 {object} models.VerifyEmailChangeSuccessResponse "Successfully changed email"
+// @Failure      400 {object} models.VerifyEmailChangeErrorResponse "Bad Request - Invalid input"
+// @Failure      401 {object} models.VerifyEmailChangeErrorResponse "Unauthorized - Invalid or expired email change token"
+// @Failure      409 {object} models.VerifyEmailChangeErrorResponse "Conflict - Email already in use"
+// @Failure      500 {object} models.VerifyEmailChangeErrorResponse "Internal Server Error - Failed to change email"
+// @Router       /auth/verify-email-change [get]
+func (ac *AuthController) VerifyEmailChange(c *gin.Context) {
+	token := c.Query("token")
+	if token == "" {
+		ac.logger.WithFields(logrus.Fields{"error": "token missing in query params"}).Error("Invalid Request: Token Missing")
+		c.JSON(http.StatusBadRequest, models.VerifyEmailChangeErrorResponse{
+			Message: "Invalid Request",
+			Error:   "token is required in query parameters",
+			Code:    helpers.CodeBadRequest,
+		})
+		return
+	}
+
+	userID, newEmail, err := ac.authStore.ConfirmEmailChange(c, token, time.Now())
+	if err != nil {
+		if errors.Is(err, stores.ErrInvalidOrExpiredEmailChangeToken) {
+			ac.logger.WithFields(logrus.Fields{"error": err}).Error("Invalid or Expired Email Change Token")
+			c.JSON(http.StatusUnauthorized, models.VerifyEmailChangeErrorResponse{
+				Message: "Invalid or Expired Email Change Token",
+				Error:   "invalid or expired token",
+				Code:    helpers.ErrorCode(err),
+			})
+		} else if errors.Is(err, stores.ErrEmailAlreadyInUse) {
+			ac.logger.WithFields(logrus.Fields{"error": err}).Error("New Email Taken Before Email Change was Confirmed")
+			c.JSON(http.StatusConflict, models.VerifyEmailChangeErrorResponse{
+				Message: "Change Email Failed",
+				Error:   err.Error(),
+				Code:    helpers.ErrorCode(err),
+			})
+		} else {
+			ac.logger.WithFields(logrus.Fields{"error": err}).Error("Failed to Confirm Email Change")
+			c.JSON(http.StatusInternalServerError, models.VerifyEmailChangeErrorResponse{
+				Message: "Failed to Change Email",
+				Error:   "failed to change email",
+				Code:    helpers.CodeInternal,
+			})
+		}
+		return
+	}
+
+	if _, err := ac.sessionStore.EndAllSessions(c, userID, helpers.RefreshTokenExpiry()); err != nil {
+		ac.logger.WithFields(logrus.Fields{"error": err, "userID": userID}).Error("Failed to End Sessions After Email Change")
+	}
+
+	c.SetCookie("access_token", "", -1, "/", "", true, true)
+	c.SetCookie("refresh_token", "", -1, "/", "", true, true)
+	middlewares.ClearCSRFCookie(c)
+
+	c.JSON(http.StatusOK, models.VerifyEmailChangeSuccessResponse{
+		Message: "Email Changed Successfully",
+		Email:   newEmail,
+	})
+}
+
+// ActivateUser godoc
+// @Summary      Activate user account
+// @Description  Activates a user account using the activation token from the query parameter. The token stays valid until it expires, and using it for an account that was activated before succeeds without changes, so an account deactivated by a moderator stays deactivated. Banned accounts cannot be activated.
+// @Tags         auth
+// @Produce      json
+// @Param        token query string true "Activation Token"
+// @Success      200 {object} models.ActivateUserSuccessResponse "Successfully activated user account or account already active"
+// @Failure      400 {object} models.ActivateUserErrorResponse "Bad Request - Invalid input"
+// @Failure      401 {object} models.ActivateUserErrorResponse "Unauthorized - Invalid or expired activation token"
+// @Failure      403 {object} models.ActivateUserErrorResponse "Forbidden - User account is banned"
+// @Failure      500 {object} models.ActivateUserErrorResponse "Internal Server Error - Failed to activate user account"
+// @Router       /auth/activate [get]
+func (ac *AuthController) ActivateUser(c *gin.Context) {
+	token := c.Query("token")
+	if token == "" {
+		ac.logger.WithFields(logrus.Fields{"error": "token missing in query params"}).Error("Invalid Request: Token Missing")
+		c.JSON(http.StatusBadRequest, models.ActivateUserErrorResponse{
+			Message: "Invalid Request",
+			Error:   "token is required in query parameters",
+			Code:    helpers.CodeBadRequest,
+		})
+		return
+	}
+
+	userID, err := ac.authStore.ValidateActivationToken(c, token, time.Now())
+	if err != nil {
+		if errors.Is(err, stores.ErrInvalidOrExpiredActivationToken) {
+			ac.logger.WithFields(logrus.Fields{"error": err, "token": token}).Error("Invalid or Expired Activation Token")
+			c.JSON(http.StatusUnauthorized, models.ActivateUserErrorResponse{
+				Message: "Invalid or Expired Activation Token",
+				Error:   "invalid or expired token",
+				Code:    helpers.ErrorCode(err),
+			})
+		} else {
+			ac.logger.WithFields(logrus.Fields{"error": err, "token": token}).Error("Failed to Validate Activation Token")
+			c.JSON(http.StatusInternalServerError, models.ActivateUserErrorResponse{
+				Message: "Failed to Activate User",
+				Error:   "failed to validate activation token",
+				Code:    helpers.CodeInternal,
+			})
+		}
+		return
+	}
+
+	user, err := ac.authStore.GetUserByID(c, userID)
+	if err != nil {
+		ac.logger.WithFields(logrus.Fields{"error": err, "userID": userID}).Error("Failed to Get User from Store for Activation")
+		c.JSON(http.StatusInternalServerError, models.ActivateUserErrorResponse{
+			Message: "Failed to Activate User",
+			Error:   "failed to fetch user",
+			Code:    helpers.CodeInternal,
+		})
+		return
+	}
+
+	if user.Banned {
+		ac.logger.WithFields(logrus.Fields{"userID": userID}).Warn("Activation Attempt for Banned User")
+		c.JSON(http.StatusForbidden, models.ActivateUserErrorResponse{
+			Message: "Activate User Failed",
+			Error:   "user is banned",
+			Code:    helpers.CodeAccountBanned,
+		})
+		return
+	}
+
+	if user.IsActive {
+		c.JSON(http.StatusOK, models.ActivateUserSuccessResponse{
+			Message: "User Already Activated",
+		})
+		return
+	}
+
+	// Activate the user and create their profile atomically, so a user is never active without a profile.
+	// A user activated before stays as they are, a moderator may have deactivated them since.
+	err = stores.WithTx(c, ac.dbPool, func(tx pgx.Tx) error {
+		if err := ac.authStore.ActivateUserTx(c, tx, userID); err != nil {
+			return err
+		}
+		_, err := ac.profileStore.CreateProfileTx(c, tx, &models.Profile{UserID: userID})
+		return err
+	})
+	if errors.Is(err, stores.ErrUserAlreadyActivated) {
+		c.JSON(http.StatusOK, models.ActivateUserSuccessResponse{
+			Message: "User Already Activated",
+		})
+		return
+	}
+	if err != nil {
+		ac.logger.WithFields(logrus.Fields{"error": err, "userID": userID}).Error("Failed to Activate User and Create Profile in Store")
+		c.JSON(http.StatusInternalServerError, models.ActivateUserErrorResponse{
+			Message: "Failed to Activate User",
+			Error:   "failed to activate user in database",
+			Code:    helpers.CodeInternal,
+		})
+		return
+	}
+
+	// The token is kept until it expires, so opening the activation link again is answered as already activated.
 	c.JSON(http.StatusOK, models.ActivateUserSuccessResponse{
 		Message: "User Activated Successfully",
 	})
@@ -669,6 +1358,7 @@ func (ac *AuthController) ResendActivationLink(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.ResendActivationLinkErrorResponse{
 			Message: "Invalid Request Body",
 			Error:   err.Error(),
+			Code:    helpers.CodeValidationFailed,
 		})
 		return
 	}
@@ -680,6 +1370,7 @@ func (ac *AuthController) ResendActivationLink(c *gin.Context) {
 			c.JSON(http.StatusUnauthorized, models.ResendActivationLinkErrorResponse{
 				Message: "Resend Activation Link Failed",
 				Error:   "invalid credentials",
+				Code:    helpers.ErrorCode(err),
 			})
 			return
 		} else {
@@ -687,6 +1378,7 @@ func (ac *AuthController) ResendActivationLink(c *gin.Context) {
 			c.JSON(http.StatusInternalServerError, models.ResendActivationLinkErrorResponse{
 				Message: "Failed to Resend Activation Link",
 				Error:   "failed to fetch user",
+				Code:    helpers.CodeInternal,
 			})
 		}
 		return
@@ -697,6 +1389,7 @@ func (ac *AuthController) ResendActivationLink(c *gin.Context) {
 		c.JSON(http.StatusConflict, models.ResendActivationLinkErrorResponse{
 			Message: "Resend Activation Link Failed",
 			Error:   "user already active",
+			Code:    helpers.CodeConflict,
 		})
 		return
 	}
@@ -706,6 +1399,7 @@ func (ac *AuthController) ResendActivationLink(c *gin.Context) {
 		c.JSON(http.StatusUnauthorized, models.ResendActivationLinkErrorResponse{
 			Message: "Resend Activation Link Failed",
 			Error:   "invalid credentials",
+			Code:    helpers.CodeInvalidCredentials,
 		})
 		return
 	}
@@ -716,6 +1410,7 @@ func (ac *AuthController) ResendActivationLink(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, models.ResendActivationLinkErrorResponse{
 			Message: "Failed to Resend Activation Link",
 			Error:   "failed to generate activation token",
+			Code:    helpers.CodeInternal,
 		})
 		return
 	}
@@ -727,14 +1422,713 @@ func (ac *AuthController) ResendActivationLink(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, models.ResendActivationLinkErrorResponse{
 			Message: "Failed to Resend Activation Link",
 			Error:   "failed to save activation token",
+			Code:    helpers.CodeInternal,
+		})
+		return
+	}
+
+	activationLink := fmt.Sprintf("%s/api/v1/auth/activate?token=%s", DOMAIN, activationToken)
+
+	if err := ac.sendActivationEmail(user, activationLink); err != nil {
+		ac.logger.WithFields(logrus.Fields{"error": err, "userID": user.ID}).Error("Failed to Send Activation Email for Resend")
+		c.JSON(http.StatusInternalServerError, models.ResendActivationLinkErrorResponse{
+			Message: "Failed to Resend Activation Link",
+			Error:   "failed to send activation email",
+			Code:    helpers.CodeInternal,
 		})
 		return
 	}
 
+	response := models.ResendActivationLinkSuccessResponse{
+		Message: "Activation Link Sent Successfully",
+	}
+	if exposeLinks() {
+		response.ActivationLink = activationLink
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// ResendActivation godoc
+// @Summary      Resend Activation Link Without Password
+// @Description  Sends a new activation link to the email of the account with the given username or email, if it exists and is not active yet. Always responds with success so the endpoint cannot be used to find out which accounts exist. Only one link is sent per identifier within the resend interval.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        body body models.ResendActivationPayload true "Request Body for Resending Activation Link"
+// @Success      200 {object} models.ResendActivationSuccessResponse "Activation link sent if an inactive account exists"
+// @Failure      400 {object} models.ResendActivationErrorResponse "Bad Request - Invalid input"
+// @Failure      500 {object} models.ResendActivationErrorResponse "Internal Server Error - Failed to resend activation link"
+// @Router       /auth/resend-activation [post]
+func (ac *AuthController) ResendActivation(c *gin.Context) {
+	var req models.ResendActivationPayload
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ac.logger.WithFields(logrus.Fields{"error": err}).Error("Invalid Request Body for Resend Activation")
+		c.JSON(http.StatusBadRequest, models.ResendActivationErrorResponse{
+			Message: "Invalid Request Body",
+			Error:   err.Error(),
+			Code:    helpers.CodeValidationFailed,
+		})
+		return
+	}
+
+	response := models.ResendActivationSuccessResponse{
+		Message: "Activation Link Sent Successfully If an Inactive Account Exists",
+	}
+
+	key := resendActivationKeyPrefix + strings.ToLower(strings.TrimSpace(req.Identifier))
+	allowed, err := ac.redisClient.SetNX(c, key, time.Now().Unix(), resendActivationInterval).Result()
+	if err != nil {
+		ac.logger.WithFields(logrus.Fields{"error": err, "identifier": req.Identifier}).Error("Failed to Check Resend Activation Throttle in Redis")
+		c.JSON(http.StatusInternalServerError, models.ResendActivationErrorResponse{
+			Message: "Failed to Resend Activation Link",
+			Error:   "failed to check resend throttle",
+			Code:    helpers.CodeInternal,
+		})
+		return
+	}
+	if !allowed {
+		ac.logger.WithFields(logrus.Fields{"identifier": req.Identifier}).Info("Resend Activation Throttled for Identifier")
+		c.JSON(http.StatusOK, response)
+		return
+	}
+
+	user, err := ac.authStore.GetUserByUsernameOrEmail(c, req.Identifier)
+	if err != nil {
+		if errors.Is(err, stores.ErrUserNotFound) {
+			ac.logger.WithFields(logrus.Fields{"identifier": req.Identifier}).Info("Resend Activation Request for Non-Existent User")
+			c.JSON(http.StatusOK, response)
+		} else {
+			ac.logger.WithFields(logrus.Fields{"error": err, "identifier": req.Identifier}).Error("Failed to Get User from Store for Resend Activation")
+			c.JSON(http.StatusInternalServerError, models.ResendActivationErrorResponse{
+				Message: "Failed to Resend Activation Link",
+				Error:   "failed to fetch user",
+				Code:    helpers.CodeInternal,
+			})
+		}
+		return
+	}
+
+	if user.IsActive || user.Banned {
+		ac.logger.WithFields(logrus.Fields{"userID": user.ID}).Info("Resend Activation Request for Active or Banned User")
+		c.JSON(http.StatusOK, response)
+		return
+	}
+
+	// Failures past this point only happen for existing inactive accounts, so they are logged but not reported.
+	activationToken, err := helpers.GenerateActivationToken(user.ID)
+	if err != nil {
+		ac.logger.WithFields(logrus.Fields{"error": err, "userID": user.ID}).Error("Failed to Generate New Activation Token for Resend Activation")
+		c.JSON(http.StatusOK, response)
+		return
+	}
+
+	expiryTime := time.Now().Add(time.Minute * 15)
+	if err := ac.authStore.CreateActivationToken(c, user.ID, activationToken, expiryTime); err != nil {
+		ac.logger.WithFields(logrus.Fields{"error": err, "userID": user.ID}).Error("Failed to Save New Activation Token to Store for Resend Activation")
+		c.JSON(http.StatusOK, response)
+		return
+	}
+
 	activationLink := fmt.Sprintf("%s/api/v1/auth/activate?token=%s", DOMAIN, activationToken)
 
-	c.JSON(http.StatusOK, models.ResendActivationLinkSuccessResponse{
-		Message:        "Activation Link Sent Successfully",
-		ActivationLink: activationLink,
+	if err := ac.sendActivationEmail(user, activationLink); err != nil {
+		ac.logger.WithFields(logrus.Fields{"error": err, "userID": user.ID}).Error("Failed to Send Activation Email for Resend Activation")
+		c.JSON(http.StatusOK, response)
+		return
+	}
+
+	if exposeLinks() {
+		response.ActivationLink = activationLink
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// oauthStateKeyPrefix is the Redis key prefix for pending OAuth state values.
+const oauthStateKeyPrefix = "oauth_state:"
+
+// oauthStateCookieName is the cookie binding a pending OAuth state to the browser that started the login, so a
+// callback carrying a state issued to another browser is rejected.
+const oauthStateCookieName = "oauth_state"
+
+// setOAuthStateCookie sets the OAuth state cookie, a negative maxAge deletes it. The cookie is SameSite=Lax so that
+// it is still sent on the top-level redirect back from Google.
+func setOAuthStateCookie(c *gin.Context, state string, maxAge int) {
+	http.SetCookie(c.Writer, &http.Cookie{
+		Name:     oauthStateCookieName,
+		Value:    state,
+		MaxAge:   maxAge,
+		Path:     "/",
+		Secure:   true,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
 	})
 }
+
+// usernameFromEmail derives a unique username candidate from the local part of an email address.
+func usernameFromEmail(email string) (string, error) {
+	localPart := strings.ToLower(strings.SplitN(email, "@", 2)[0])
+
+	var builder strings.Builder
+	for _, r := range localPart {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '_' {
+			builder.WriteRune(r)
+		}
+	}
+	base := builder.String()
+	// Keep the base short enough for the "_" and six character suffix to fit in helpers.UsernameMaxLength.
+	if len(base) > helpers.UsernameMaxLength-7 {
+		base = base[:helpers.UsernameMaxLength-7]
+	}
+	if base == "" {
+		base = "gopher"
+	}
+
+	suffix, err := helpers.GenerateRandomString(3)
+	if err != nil {
+		return "", err
+	}
+
+	return base + "_" + suffix, nil
+}
+
+// GoogleLogin godoc
+// @Summary      Start Google OAuth login
+// @Description  Redirects the user to Google's consent screen. A state parameter is stored in Redis and in a short-lived HttpOnly cookie to protect the flow.
+// @Tags         auth
+// @Produce      json
+// @Success      307 "Redirect to Google consent screen"
+// @Failure      500 {object} models.GoogleOAuthErrorResponse "Internal Server Error - Failed to start OAuth flow"
+// @Failure      503 {object} models.GoogleOAuthErrorResponse "Service Unavailable - Google OAuth not configured"
+// @Router       /auth/oauth/google/login [get]
+func (ac *AuthController) GoogleLogin(c *gin.Context) {
+	if !helpers.GoogleOAuthEnabled() {
+		ac.logger.Error("Google OAuth Login Requested but Not Configured")
+		c.JSON(http.StatusServiceUnavailable, models.GoogleOAuthErrorResponse{
+			Message: "Google Login Unavailable",
+			Error:   "google oauth is not configured",
+			Code:    helpers.CodeServiceUnavailable,
+		})
+		return
+	}
+
+	state, err := helpers.GenerateRandomString(32)
+	if err != nil {
+		ac.logger.WithFields(logrus.Fields{"error": err}).Error("Failed to Generate OAuth State")
+		c.JSON(http.StatusInternalServerError, models.GoogleOAuthErrorResponse{
+			Message: "Google Login Failed",
+			Error:   "failed to generate state",
+			Code:    helpers.CodeInternal,
+		})
+		return
+	}
+
+	if err := ac.redisClient.Set(c, oauthStateKeyPrefix+state, "google", helpers.OAuthStateExpiry).Err(); err != nil {
+		ac.logger.WithFields(logrus.Fields{"error": err}).Error("Failed to Store OAuth State in Redis")
+		c.JSON(http.StatusInternalServerError, models.GoogleOAuthErrorResponse{
+			Message: "Google Login Failed",
+			Error:   "failed to store state",
+			Code:    helpers.CodeInternal,
+		})
+		return
+	}
+
+	setOAuthStateCookie(c, state, int(helpers.OAuthStateExpiry/time.Second))
+	c.Redirect(http.StatusTemporaryRedirect, helpers.GoogleAuthCodeURL(state))
+}
+
+// GoogleCallback godoc
+// @Summary      Complete Google OAuth login
+// @Description  Exchanges the authorization code with Google, logs in the user matched by email or creates a new activated user, and returns access and refresh tokens as secure cookies.
+// @Tags         auth
+// @Produce      json
+// @Param        state query string true "OAuth State"
+// @Param        code query string true "Authorization Code"
+// @Success      200 {object} models.GoogleOAuthCallbackSuccessResponse "Successfully logged in"
+// @Failure      400 {object} models.GoogleOAuthErrorResponse "Bad Request - Invalid state or code"
+// @Failure      403 {object} models.GoogleOAuthErrorResponse "Forbidden - Account banned or not activated"
+// @Failure      500 {object} models.GoogleOAuthErrorResponse "Internal Server Error - Failed to login user"
+// @Failure      502 {object} models.GoogleOAuthErrorResponse "Bad Gateway - Failed to communicate with Google"
+// @Router       /auth/oauth/google/callback [get]
+func (ac *AuthController) GoogleCallback(c *gin.Context) {
+	state := c.Query("state")
+	code := c.Query("code")
+	if state == "" || code == "" {
+		ac.logger.WithFields(logrus.Fields{"error": "state or code missing in query params"}).Error("Invalid Google OAuth Callback Request")
+		c.JSON(http.StatusBadRequest, models.GoogleOAuthErrorResponse{
+			Message: "Invalid Request",
+			Error:   "state and code are required in query parameters",
+			Code:    helpers.CodeBadRequest,
+		})
+		return
+	}
+
+	cookieState, err := c.Cookie(oauthStateCookieName)
+	setOAuthStateCookie(c, "", -1)
+	if err != nil || subtle.ConstantTimeCompare([]byte(cookieState), []byte(state)) != 1 {
+		ac.logger.WithFields(logrus.Fields{"state": state}).Error("OAuth State Does Not Match State Cookie")
+		c.JSON(http.StatusBadRequest, models.GoogleOAuthErrorResponse{
+			Message: "Google Login Failed",
+			Error:   "invalid or expired state",
+			Code:    helpers.CodeBadRequest,
+		})
+		return
+	}
+
+	provider, err := ac.redisClient.GetDel(c, oauthStateKeyPrefix+state).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			ac.logger.WithFields(logrus.Fields{"state": state}).Error("Invalid or Expired OAuth State")
+			c.JSON(http.StatusBadRequest, models.GoogleOAuthErrorResponse{
+				Message: "Google Login Failed",
+				Error:   "invalid or expired state",
+				Code:    helpers.CodeBadRequest,
+			})
+		} else {
+			ac.logger.WithFields(logrus.Fields{"error": err}).Error("Failed to Fetch OAuth State from Redis")
+			c.JSON(http.StatusInternalServerError, models.GoogleOAuthErrorResponse{
+				Message: "Google Login Failed",
+				Error:   "failed to validate state",
+				Code:    helpers.CodeInternal,
+			})
+		}
+		return
+	}
+
+	googleAccessToken, err := helpers.ExchangeGoogleCode(c, code)
+	if err != nil {
+		ac.logger.WithFields(logrus.Fields{"error": err}).Error("Failed to Exchange Google Authorization Code")
+		c.JSON(http.StatusBadGateway, models.GoogleOAuthErrorResponse{
+			Message: "Google Login Failed",
+			Error:   "failed to exchange authorization code",
+			Code:    helpers.CodeBadGateway,
+		})
+		return
+	}
+
+	googleUser, err := helpers.FetchGoogleUser(c, googleAccessToken)
+	if err != nil {
+		ac.logger.WithFields(logrus.Fields{"error": err}).Error("Failed to Fetch Google User Profile")
+		c.JSON(http.StatusBadGateway, models.GoogleOAuthErrorResponse{
+			Message: "Google Login Failed",
+			Error:   "failed to fetch google profile",
+			Code:    helpers.CodeBadGateway,
+		})
+		return
+	}
+
+	if googleUser.Email == "" || !googleUser.EmailVerified {
+		ac.logger.WithFields(logrus.Fields{"email": googleUser.Email}).Error("Google Account Email Not Verified")
+		c.JSON(http.StatusForbidden, models.GoogleOAuthErrorResponse{
+			Message: "Google Login Failed",
+			Error:   "google account email is not verified",
+			Code:    helpers.CodeForbidden,
+		})
+		return
+	}
+
+	user, err := ac.authStore.GetUserByUsernameOrEmail(c, googleUser.Email)
+	if err != nil && !errors.Is(err, stores.ErrUserNotFound) {
+		ac.logger.WithFields(logrus.Fields{"error": err, "email": googleUser.Email}).Error("Failed to Fetch User from Store")
+		c.JSON(http.StatusInternalServerError, models.GoogleOAuthErrorResponse{
+			Message: "Google Login Failed",
+			Error:   "failed to authenticate user",
+			Code:    helpers.ErrorCode(err),
+		})
+		return
+	}
+
+	if errors.Is(err, stores.ErrUserNotFound) {
+		randomPassword, err := helpers.GenerateRandomString(32)
+		if err != nil {
+			ac.logger.WithFields(logrus.Fields{"error": err}).Error("Failed to Generate Random Password")
+			c.JSON(http.StatusInternalServerError, models.GoogleOAuthErrorResponse{
+				Message: "Google Login Failed",
+				Error:   "failed to create user",
+				Code:    helpers.CodeInternal,
+			})
+			return
+		}
+
+		hashedPassword, err := helpers.HashPassword(randomPassword)
+		if err != nil {
+			ac.logger.WithFields(logrus.Fields{"error": err}).Error("Failed to Hash Random Password")
+			c.JSON(http.StatusInternalServerError, models.GoogleOAuthErrorResponse{
+				Message: "Google Login Failed",
+				Error:   "failed to create user",
+				Code:    helpers.CodeInternal,
+			})
+			return
+		}
+
+		username, err := usernameFromEmail(googleUser.Email)
+		if err != nil {
+			ac.logger.WithFields(logrus.Fields{"error": err}).Error("Failed to Generate Username")
+			c.JSON(http.StatusInternalServerError, models.GoogleOAuthErrorResponse{
+				Message: "Google Login Failed",
+				Error:   "failed to create user",
+				Code:    helpers.CodeInternal,
+			})
+			return
+		}
+
+		createdUser, err := ac.authStore.CreateOAuthUser(c, &models.User{
+			Username:      username,
+			Email:         googleUser.Email,
+			PasswordHash:  hashedPassword,
+			OAuthProvider: &provider,
+		})
+		if err != nil {
+			ac.logger.WithFields(logrus.Fields{"error": err, "email": googleUser.Email}).Error("Failed to Create OAuth User in Store")
+			c.JSON(http.StatusInternalServerError, models.GoogleOAuthErrorResponse{
+				Message: "Google Login Failed",
+				Error:   "failed to create user",
+				Code:    helpers.CodeInternal,
+			})
+			return
+		}
+
+		_, err = ac.profileStore.CreateProfile(c, &models.Profile{UserID: createdUser.ID})
+		if err != nil {
+			ac.logger.WithFields(logrus.Fields{"error": err, "userID": createdUser.ID}).Error("Failed to Create Profile for OAuth User")
+			c.JSON(http.StatusInternalServerError, models.GoogleOAuthErrorResponse{
+				Message: "Google Login Failed",
+				Error:   "failed to create profile",
+				Code:    helpers.CodeInternal,
+			})
+			return
+		}
+
+		user, err = ac.authStore.GetUserByID(c, createdUser.ID)
+		if err != nil {
+			ac.logger.WithFields(logrus.Fields{"error": err, "userID": createdUser.ID}).Error("Failed to Fetch Created OAuth User from Store")
+			c.JSON(http.StatusInternalServerError, models.GoogleOAuthErrorResponse{
+				Message: "Google Login Failed",
+				Error:   "failed to fetch user",
+				Code:    helpers.CodeInternal,
+			})
+			return
+		}
+	}
+
+	if user.Banned {
+		ac.logger.WithFields(logrus.Fields{"userID": user.ID}).Error("Banned User Attempted Google Login")
+		c.JSON(http.StatusForbidden, models.GoogleOAuthErrorResponse{
+			Message: "Google Login Failed",
+			Error:   "user is banned",
+			Code:    helpers.CodeAccountBanned,
+		})
+		return
+	}
+
+	if !user.IsActive {
+		ac.logger.WithFields(logrus.Fields{"userID": user.ID}).Error("User Account is Not Active")
+		c.JSON(http.StatusForbidden, models.GoogleOAuthErrorResponse{
+			Message: "Google Login Failed",
+			Error:   "account not activated",
+			Code:    helpers.CodeAccountNotActivated,
+		})
+		return
+	}
+
+	session, err := ac.startSession(c, user.ID)
+	if err != nil {
+		ac.logger.WithFields(logrus.Fields{"error": err, "userID": user.ID}).Error("Failed to Create Session")
+		c.JSON(http.StatusInternalServerError, models.GoogleOAuthErrorResponse{
+			Message: "Google Login Failed",
+			Error:   "failed to create session",
+			Code:    helpers.CodeInternal,
+		})
+		return
+	}
+
+	accessToken, err := helpers.GenerateAccessToken(user.ID, session.ID)
+	if err != nil {
+		ac.logger.WithFields(logrus.Fields{"error": err, "userID": user.ID}).Error("Failed to Generate Access Token")
+		c.JSON(http.StatusInternalServerError, models.GoogleOAuthErrorResponse{
+			Message: "Google Login Failed",
+			Error:   "failed to generate tokens",
+			Code:    helpers.CodeInternal,
+		})
+		return
+	}
+
+	refreshToken, err := helpers.GenerateRefreshToken(user.ID, session.ID)
+	if err != nil {
+		ac.logger.WithFields(logrus.Fields{"error": err, "userID": user.ID}).Error("Failed to Generate Refresh Token")
+		c.JSON(http.StatusInternalServerError, models.GoogleOAuthErrorResponse{
+			Message: "Google Login Failed",
+			Error:   "failed to generate tokens",
+			Code:    helpers.CodeInternal,
+		})
+		return
+	}
+
+	c.SetCookie("access_token", accessToken, int(time.Minute*30/time.Second), "/", "", true, true)
+	c.SetCookie("refresh_token", refreshToken, int(time.Hour*6/time.Second), "/", "", true, true)
+	if err := middlewares.SetCSRFCookie(c); err != nil {
+		ac.logger.WithFields(logrus.Fields{"error": err, "userID": user.ID}).Error("Failed to Generate CSRF Token")
+		c.JSON(http.StatusInternalServerError, models.GoogleOAuthErrorResponse{
+			Message: "Google Login Failed",
+			Error:   "failed to generate tokens",
+			Code:    helpers.CodeInternal,
+		})
+		return
+	}
+
+	ac.logger.WithFields(logrus.Fields{"userID": user.ID, "provider": provider}).Info("User Logged in Successfully with OAuth")
+	c.JSON(http.StatusOK, models.GoogleOAuthCallbackSuccessResponse{
+		Message: "Login Successful",
+		User:    user,
+	})
+}
+
+// GetCurrentUser godoc
+// @Summary      Get current user
+// @Description  Retrieves the logged-in user's record with role, follower, following and post counts, and their profile if one exists.
+// @Tags         auth
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} models.GetCurrentUserSuccessResponse "Successfully retrieved current user"
+// @Failure      401 {object} models.GetCurrentUserErrorResponse "Unauthorized - User not logged in or invalid token"
+// @Failure      403 {object} models.GetCurrentUserErrorResponse "Forbidden - User account is inactive or banned"
+// @Failure      500 {object} models.GetCurrentUserErrorResponse "Internal Server Error - Failed to retrieve current user"
+// @Router       /auth/me [get]
+func (ac *AuthController) GetCurrentUser(c *gin.Context) {
+	currentUserModel := helpers.RequireUser(c)
+
+	user, err := ac.authStore.GetCurrentUserByID(c, currentUserModel.ID)
+	if err != nil {
+		if errors.Is(err, stores.ErrUserNotFound) {
+			ac.logger.WithFields(logrus.Fields{"error": err, "userID": currentUserModel.ID}).Error("Current User Not Found")
+			c.JSON(http.StatusUnauthorized, models.GetCurrentUserErrorResponse{
+				Message: "Unauthorized",
+				Error:   "user not found",
+				Code:    helpers.ErrorCode(err),
+			})
+			return
+		}
+		ac.logger.WithFields(logrus.Fields{"error": err, "userID": currentUserModel.ID}).Error("Failed to Get Current User from Store")
+		c.JSON(http.StatusInternalServerError, models.GetCurrentUserErrorResponse{
+			Message: "Failed to Get Current User",
+			Error:   "failed to retrieve current user",
+			Code:    helpers.CodeInternal,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.GetCurrentUserSuccessResponse{
+		Message: "Current User Retrieved Successfully",
+		User:    user,
+	})
+}
+
+// GetAccountStatus godoc
+// @Summary      Get account status
+// @Description  Retrieves whether the logged-in user is active, banned or timed out, with the timeout expiry, the remaining seconds and the moderation reason. Timed out users can still access this route, so clients can show when access returns.
+// @Tags         auth
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} models.GetAccountStatusSuccessResponse "Successfully retrieved account status"
+// @Failure      401 {object} models.GetAccountStatusErrorResponse "Unauthorized - User not logged in or invalid token"
+// @Failure      403 {object} models.GetAccountStatusErrorResponse "Forbidden - User account is inactive or banned"
+// @Failure      500 {object} models.GetAccountStatusErrorResponse "Internal Server Error - Failed to retrieve account status"
+// @Router       /auth/me/status [get]
+func (ac *AuthController) GetAccountStatus(c *gin.Context) {
+	currentUserModel := helpers.RequireUser(c)
+
+	status, err := ac.authStore.GetAccountStatus(c, currentUserModel.ID)
+	if err != nil {
+		if errors.Is(err, stores.ErrUserNotFound) {
+			ac.logger.WithFields(logrus.Fields{"error": err, "userID": currentUserModel.ID}).Error("Current User Not Found for Account Status")
+			c.JSON(http.StatusUnauthorized, models.GetAccountStatusErrorResponse{
+				Message: "Unauthorized",
+				Error:   "user not found",
+				Code:    helpers.ErrorCode(err),
+			})
+		} else {
+			ac.logger.WithFields(logrus.Fields{"error": err, "userID": currentUserModel.ID}).Error("Failed to Get Account Status from Store")
+			c.JSON(http.StatusInternalServerError, models.GetAccountStatusErrorResponse{
+				Message: "Failed to Get Account Status",
+				Error:   "failed to retrieve account status",
+				Code:    helpers.CodeInternal,
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, models.GetAccountStatusSuccessResponse{
+		Message: "Account Status Retrieved Successfully",
+		Status:  status,
+	})
+}
+
+// ListSessions godoc
+// @Summary      List active sessions
+// @Description  Lists the active login sessions of the logged-in user, including device and IP information.
+// @Tags         auth
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} models.ListSessionsSuccessResponse "Successfully retrieved sessions"
+// @Failure      401 {object} models.ListSessionsErrorResponse "Unauthorized - User not logged in or invalid token"
+// @Failure      403 {object} models.ListSessionsErrorResponse "Forbidden - User account is inactive or banned"
+// @Failure      500 {object} models.ListSessionsErrorResponse "Internal Server Error - Failed to list sessions"
+// @Router       /auth/sessions [get]
+func (ac *AuthController) ListSessions(c *gin.Context) {
+	currentUserModel := helpers.RequireUser(c)
+
+	sessions, err := ac.sessionStore.ListSessionsByUserID(c, currentUserModel.ID)
+	if err != nil {
+		ac.logger.WithFields(logrus.Fields{"error": err, "userID": currentUserModel.ID}).Error("Failed to List Sessions from Store")
+		c.JSON(http.StatusInternalServerError, models.ListSessionsErrorResponse{
+			Message: "Failed to List Sessions",
+			Error:   "failed to list sessions",
+			Code:    helpers.CodeInternal,
+		})
+		return
+	}
+
+	currentSessionID, _ := c.Get(middlewares.SessionIDKey)
+	for _, session := range sessions {
+		session.Current = session.ID == currentSessionID
+	}
+
+	c.JSON(http.StatusOK, models.ListSessionsSuccessResponse{
+		Message:  "Sessions Retrieved Successfully",
+		Sessions: sessions,
+	})
+}
+
+// RevokeSession godoc
+// @Summary      Revoke a session
+// @Description  Revokes one of the logged-in user's sessions. Tokens issued for the session stop working immediately.
+// @Tags         auth
+// @Produce      json
+// @Security     BearerAuth
+// @Param        sessionID path string true "Session ID"
+// @Success      200 {object} models.RevokeSessionSuccessResponse "Successfully revoked session"
+// @Failure      400 {object} models.RevokeSessionErrorResponse "Bad Request - Invalid session ID"
+// @Failure      401 {object} models.RevokeSessionErrorResponse "Unauthorized - User not logged in or invalid token"
+// @Failure      403 {object} models.RevokeSessionErrorResponse "Forbidden - User account is inactive or banned"
+// @Failure      404 {object} models.RevokeSessionErrorResponse "Not Found - Session not found"
+// @Failure      500 {object} models.RevokeSessionErrorResponse "Internal Server Error - Failed to revoke session"
+// @Router       /auth/sessions/{sessionID} [delete]
+func (ac *AuthController) RevokeSession(c *gin.Context) {
+	currentUserModel := helpers.RequireUser(c)
+
+	sessionIDStr := c.Param("sessionID")
+	if sessionIDStr == "" {
+		ac.logger.WithFields(logrus.Fields{"userID": currentUserModel.ID}).Error("Session ID is required")
+		c.JSON(http.StatusBadRequest, models.RevokeSessionErrorResponse{
+			Message: "Invalid Request",
+			Error:   "session id is required in path",
+			Code:    helpers.CodeBadRequest,
+		})
+		return
+	}
+
+	sessionID, err := uuid.Parse(sessionIDStr)
+	if err != nil {
+		ac.logger.WithFields(logrus.Fields{"error": err, "sessionID": sessionIDStr}).Error("Invalid Session ID format")
+		c.JSON(http.StatusBadRequest, models.RevokeSessionErrorResponse{
+			Message: "Invalid Request",
+			Error:   "invalid session id format",
+			Code:    helpers.CodeBadRequest,
+		})
+		return
+	}
+
+	err = ac.sessionStore.RevokeSession(c, currentUserModel.ID, sessionID, helpers.RefreshTokenExpiry())
+	if err != nil {
+		if errors.Is(err, stores.ErrSessionNotFound) {
+			ac.logger.WithFields(logrus.Fields{"userID": currentUserModel.ID, "sessionID": sessionID}).Error("Session Not Found")
+			c.JSON(http.StatusNotFound, models.RevokeSessionErrorResponse{
+				Message: "Revoke Session Failed",
+				Error:   err.Error(),
+				Code:    helpers.ErrorCode(err),
+			})
+		} else {
+			ac.logger.WithFields(logrus.Fields{"error": err, "userID": currentUserModel.ID, "sessionID": sessionID}).Error("Failed to Revoke Session in Store")
+			c.JSON(http.StatusInternalServerError, models.RevokeSessionErrorResponse{
+				Message: "Revoke Session Failed",
+				Error:   "failed to revoke session",
+				Code:    helpers.CodeInternal,
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, models.RevokeSessionSuccessResponse{
+		Message: "Session Revoked Successfully",
+	})
+}
+
+// ExportUserData godoc
+// @Summary      Export user data
+// @Description  Downloads all data of the logged-in user as a single JSON document: the user with their profile, posts, comments, post and comment reactions, followers and followings. The document is streamed as it is read. A user can export their data once per export interval, 24 hours by default.
+// @Tags         auth
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} models.UserDataExport "Successfully exported user data"
+// @Failure      401 {object} models.ExportUserDataErrorResponse "Unauthorized - User not logged in or invalid token"
+// @Failure      403 {object} models.ExportUserDataErrorResponse "Forbidden - User account is inactive or banned"
+// @Failure      429 {object} models.ExportUserDataErrorResponse "Too Many Requests - User data was exported recently"
+// @Failure      500 {object} models.ExportUserDataErrorResponse "Internal Server Error - Failed to export user data"
+// @Router       /auth/me/export [get]
+func (ac *AuthController) ExportUserData(c *gin.Context) {
+	currentUserModel := helpers.RequireUser(c)
+
+	key := userExportKeyPrefix + currentUserModel.ID.String()
+	allowed, err := ac.redisClient.SetNX(c, key, time.Now().Unix(), userExportInterval).Result()
+	if err != nil {
+		ac.logger.WithFields(logrus.Fields{"error": err, "userID": currentUserModel.ID}).Error("Failed to Check User Data Export Limit in Redis")
+		c.JSON(http.StatusInternalServerError, models.ExportUserDataErrorResponse{
+			Message: "Failed to Export User Data",
+			Error:   "failed to export user data",
+			Code:    helpers.CodeInternal,
+		})
+		return
+	}
+	if !allowed {
+		retryAfter := userExportInterval
+		if ttl, err := ac.redisClient.TTL(c, key).Result(); err == nil && ttl > 0 {
+			retryAfter = ttl
+		}
+		retryAfterSeconds := int64(retryAfter.Seconds())
+		c.Header("Retry-After", strconv.FormatInt(retryAfterSeconds, 10))
+		ac.logger.WithFields(logrus.Fields{"userID": currentUserModel.ID, "retry-after": retryAfterSeconds}).Warn("User Data Export Limit Exceeded")
+		c.JSON(http.StatusTooManyRequests, models.ExportUserDataErrorResponse{
+			Message: "Too Many Requests",
+			Error:   fmt.Sprintf("user data was exported recently, try again after %d seconds", retryAfterSeconds),
+			Code:    helpers.CodeRateLimited,
+		})
+		return
+	}
+
+	c.Header("Content-Type", "application/json; charset=utf-8")
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="gopher-social-export-%s.json"`, currentUserModel.ID))
+	c.Status(http.StatusOK)
+
+	if err := ac.userStore.ExportUserData(c, currentUserModel.ID, c.Writer); err != nil {
+		ac.logger.WithFields(logrus.Fields{"error": err, "userID": currentUserModel.ID}).Error("Failed to Export User Data")
+
+		// Let the user try again, the export they got is incomplete.
+		if err := ac.redisClient.Del(c, key).Err(); err != nil {
+			ac.logger.WithFields(logrus.Fields{"error": err, "userID": currentUserModel.ID}).Error("Failed to Reset User Data Export Limit in Redis")
+		}
+
+		if !c.Writer.Written() {
+			c.Writer.Header().Del("Content-Disposition")
+			c.JSON(http.StatusInternalServerError, models.ExportUserDataErrorResponse{
+				Message: "Failed to Export User Data",
+				Error:   "failed to export user data",
+				Code:    helpers.CodeInternal,
+			})
+		} else {
+			// The status and part of the document are already sent, the truncated document tells the client the download failed.
+			c.Abort()
+		}
+		return
+	}
+}
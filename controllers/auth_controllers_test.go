@@ -0,0 +1,393 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/datarohit/gopher-social-backend/database/dbtest"
+	"github.com/datarohit/gopher-social-backend/helpers"
+	"github.com/datarohit/gopher-social-backend/models"
+	"github.com/datarohit/gopher-social-backend/stores"
+	"github.com/gin-gonic/gin"
+)
+
+func TestAllowForgotPassword(t *testing.T) {
+	server, client := newTestRedis(t)
+	ac := &AuthController{redisClient: client, logger: newTestLogger()}
+	ctx := context.Background()
+
+	for i := 1; i <= forgotPasswordMaxRequests; i++ {
+		allowed, err := ac.allowForgotPassword(ctx, forgotPasswordIdentifierSubject("Gopher"))
+		if err != nil {
+			t.Fatalf("allowForgotPassword() error = %v", err)
+		}
+		if !allowed {
+			t.Fatalf("request %d was throttled, want it allowed", i)
+		}
+	}
+
+	allowed, err := ac.allowForgotPassword(ctx, forgotPasswordIdentifierSubject(" gopher "))
+	if err != nil {
+		t.Fatalf("allowForgotPassword() error = %v", err)
+	}
+	if allowed {
+		t.Fatal("request over the limit for the same identifier in another case was allowed")
+	}
+
+	allowed, err = ac.allowForgotPassword(ctx, forgotPasswordIdentifierSubject("another"))
+	if err != nil || !allowed {
+		t.Fatalf("allowForgotPassword() of another identifier = %v, %v, want it allowed", allowed, err)
+	}
+
+	server.FastForward(forgotPasswordWindow)
+	allowed, err = ac.allowForgotPassword(ctx, forgotPasswordIdentifierSubject("gopher"))
+	if err != nil || !allowed {
+		t.Fatalf("allowForgotPassword() after the window = %v, %v, want it allowed", allowed, err)
+	}
+}
+
+// TestForgotPasswordThrottledRequestSendsNothing checks that a throttled request is answered like an accepted one
+// without sending an email, and that the quota used up by username also throttles requests by email.
+func TestForgotPasswordThrottledRequestSendsNothing(t *testing.T) {
+	pool := dbtest.NewPool(t)
+	server, client := newTestRedis(t)
+	mailer := &recordingMailer{}
+	ac := NewAuthController(pool, stores.NewAuthStore(pool), nil, nil, nil, mailer, nil, client, newTestLogger())
+	userID := dbtest.CreateUser(t, pool, "gopher", 1)
+
+	if err := server.Set(forgotPasswordKeyPrefix+forgotPasswordUserSubject(userID), strconv.Itoa(forgotPasswordMaxRequests)); err != nil {
+		t.Fatalf("failed to set the request counter: %v", err)
+	}
+
+	router := newTestRouter(nil)
+	router.POST("/auth/forgot-password", ac.ForgotPassword)
+
+	for _, identifier := range []string{"gopher", "gopher@example.com"} {
+		recorder := serve(router, http.MethodPost, "/auth/forgot-password", `{"identifier":"`+identifier+`"}`)
+		assertStatus(t, recorder, http.StatusOK)
+
+		var response models.ForgotPasswordSuccessResponse
+		if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if response.Message != "Password Reset Link Sent Successfully" || response.Link != "" {
+			t.Fatalf("response for %s = %+v, want the generic success message without a link", identifier, response)
+		}
+	}
+	if sent := mailer.emails(); len(sent) != 0 {
+		t.Fatalf("sent %d emails, want none", len(sent))
+	}
+}
+
+// TestForgotPasswordRapidRepeats checks that a burst of reset requests for one user, alternating between the username
+// and the email, sends at most FORGOT_PASSWORD_MAX_REQUESTS emails, answers every request alike, and leaves only the
+// latest link usable.
+func TestForgotPasswordRapidRepeats(t *testing.T) {
+	pool := dbtest.NewPool(t)
+	_, client := newTestRedis(t)
+	mailer := &recordingMailer{}
+
+	authStore := stores.NewAuthStore(pool)
+	ac := NewAuthController(pool, authStore, nil, nil, nil, mailer, nil, client, newTestLogger())
+	userID := dbtest.CreateUser(t, pool, "gopher", 1)
+
+	router := newTestRouter(nil)
+	router.POST("/auth/forgot-password", ac.ForgotPassword)
+
+	var links []string
+	identifiers := []string{"gopher", "gopher@example.com"}
+	for i := 0; i <= forgotPasswordMaxRequests; i++ {
+		recorder := serve(router, http.MethodPost, "/auth/forgot-password", `{"identifier":"`+identifiers[i%2]+`"}`)
+		assertStatus(t, recorder, http.StatusOK)
+
+		var response models.ForgotPasswordSuccessResponse
+		if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if response.Message != "Password Reset Link Sent Successfully" {
+			t.Fatalf("request %d message = %q, want the generic success message", i+1, response.Message)
+		}
+		if response.Link != "" {
+			links = append(links, response.Link)
+		}
+	}
+
+	if sent := mailer.emails(); len(sent) != forgotPasswordMaxRequests {
+		t.Fatalf("sent %d emails for %d requests, want %d", len(sent), forgotPasswordMaxRequests+1, forgotPasswordMaxRequests)
+	}
+	if len(links) != forgotPasswordMaxRequests {
+		t.Fatalf("got %d reset links, want %d", len(links), forgotPasswordMaxRequests)
+	}
+
+	ctx := context.Background()
+	latestToken := resetTokenFromLink(t, links[len(links)-1])
+	validatedID, err := authStore.ValidatePasswordResetToken(ctx, latestToken, time.Now())
+	if err != nil || validatedID != userID {
+		t.Fatalf("latest token validated as %s, %v, want user %s", validatedID, err, userID)
+	}
+	for _, link := range links[:len(links)-1] {
+		token := resetTokenFromLink(t, link)
+		if token == latestToken {
+			continue
+		}
+		if _, err := authStore.ValidatePasswordResetToken(ctx, token, time.Now()); !errors.Is(err, stores.ErrInvalidOrExpiredToken) {
+			t.Fatalf("earlier token error = %v, want %v", err, stores.ErrInvalidOrExpiredToken)
+		}
+	}
+}
+
+// failingMailer fails to send every email.
+type failingMailer struct{}
+
+func (failingMailer) Send(to string, subject string, body string) error {
+	return errors.New("smtp server unavailable")
+}
+
+// TestForgotPasswordEmailFailure checks that a failed reset email is answered like a sent one, so the response does
+// not tell whether the account exists, and that the reset link points to the reset password endpoint.
+func TestForgotPasswordEmailFailure(t *testing.T) {
+	pool := dbtest.NewPool(t)
+	_, client := newTestRedis(t)
+
+	ac := NewAuthController(pool, stores.NewAuthStore(pool), nil, nil, nil, failingMailer{}, nil, client, newTestLogger())
+	dbtest.CreateUser(t, pool, "gopher", 1)
+
+	router := newTestRouter(nil)
+	router.POST("/auth/forgot-password", ac.ForgotPassword)
+
+	for _, identifier := range []string{"gopher", "nobody"} {
+		recorder := serve(router, http.MethodPost, "/auth/forgot-password", `{"identifier":"`+identifier+`"}`)
+		assertStatus(t, recorder, http.StatusOK)
+
+		var response models.ForgotPasswordSuccessResponse
+		if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if response.Message != "Password Reset Link Sent Successfully" {
+			t.Fatalf("message for %q = %q, want the generic success message", identifier, response.Message)
+		}
+		if response.Link == "" {
+			continue
+		}
+		resetURL, err := url.Parse(response.Link)
+		if err != nil || resetURL.Path != "/api/v1/auth/reset-password" {
+			t.Fatalf("reset link = %q, want a link to /api/v1/auth/reset-password", response.Link)
+		}
+	}
+}
+
+// resetTokenFromLink returns the token query parameter of a password reset link.
+func resetTokenFromLink(t *testing.T, link string) string {
+	t.Helper()
+
+	resetURL, err := url.Parse(link)
+	if err != nil {
+		t.Fatalf("failed to parse reset link %q: %v", link, err)
+	}
+	token := resetURL.Query().Get("token")
+	if token == "" {
+		t.Fatalf("reset link %q has no token", link)
+	}
+	return token
+}
+
+// TestRegisterConcurrentDuplicateEmail sends two registrations for the same email at once. One must create the user
+// and the other must get 409 with the user already exists code, not a 500 from the unique constraint.
+func TestRegisterConcurrentDuplicateEmail(t *testing.T) {
+	pool := dbtest.NewPool(t)
+	_, client := newTestRedis(t)
+	dispatcher := newTestWebhookDispatcher(t, pool)
+
+	ac := NewAuthController(pool, stores.NewAuthStore(pool), nil, nil, nil, &recordingMailer{}, dispatcher, client, newTestLogger())
+	router := newTestRouter(nil)
+	router.POST("/auth/register", ac.Register)
+
+	bodies := []string{
+		`{"username":"gopher_one","email":"gopher@example.com","password":"P@ssw0rd!"}`,
+		`{"username":"gopher_two","email":"gopher@example.com","password":"P@ssw0rd!"}`,
+	}
+	recorders := make([]*httptest.ResponseRecorder, len(bodies))
+	var wg sync.WaitGroup
+	for i, body := range bodies {
+		wg.Add(1)
+		go func(i int, body string) {
+			defer wg.Done()
+			recorders[i] = serve(router, http.MethodPost, "/auth/register", body)
+		}(i, body)
+	}
+	wg.Wait()
+
+	statuses := map[int]*httptest.ResponseRecorder{}
+	for _, recorder := range recorders {
+		statuses[recorder.Code] = recorder
+	}
+	if statuses[http.StatusCreated] == nil || statuses[http.StatusConflict] == nil {
+		t.Fatalf("statuses = %d and %d, want %d and %d", recorders[0].Code, recorders[1].Code, http.StatusCreated, http.StatusConflict)
+	}
+	assertCode(t, statuses[http.StatusConflict], helpers.ErrorCode(stores.ErrUserAlreadyExists))
+
+	if count := dbtest.Count(t, pool, `SELECT COUNT(*) FROM users WHERE email = $1`, "gopher@example.com"); count != 1 {
+		t.Fatalf("%d users with the email, want 1", count)
+	}
+}
+
+// TestActivateUserTwice opens the activation link of a new user twice. Both requests must succeed, the second
+// one without changes, and the user must end up active with exactly one profile.
+func TestActivateUserTwice(t *testing.T) {
+	pool := dbtest.NewPool(t)
+
+	ac := NewAuthController(pool, stores.NewAuthStore(pool), stores.NewProfileStore(pool), nil, nil, nil, nil, nil, newTestLogger())
+	router := newTestRouter(nil)
+	router.GET("/auth/activate", ac.ActivateUser)
+
+	userID := dbtest.CreateUser(t, pool, "gopher", 1)
+	dbtest.Exec(t, pool, `DELETE FROM profiles WHERE user_id = $1`, userID)
+	dbtest.Exec(t, pool, `
+		UPDATE users
+		SET is_active = FALSE, activated_at = NULL, activation_token = 'activation-token', activation_token_expiry = now() + interval '15 minutes'
+		WHERE id = $1
+	`, userID)
+
+	for _, wantMessage := range []string{"User Activated Successfully", "User Already Activated"} {
+		recorder := serve(router, http.MethodGet, "/auth/activate?token=activation-token", "")
+		assertStatus(t, recorder, http.StatusOK)
+
+		var response models.ActivateUserSuccessResponse
+		if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if response.Message != wantMessage {
+			t.Fatalf("message = %q, want %q", response.Message, wantMessage)
+		}
+	}
+
+	if count := dbtest.Count(t, pool, `SELECT COUNT(*) FROM users WHERE id = $1 AND is_active = TRUE AND activated_at IS NOT NULL`, userID); count != 1 {
+		t.Fatal("user is not active after activation")
+	}
+	if count := dbtest.Count(t, pool, `SELECT COUNT(*) FROM profiles WHERE user_id = $1`, userID); count != 1 {
+		t.Fatalf("user has %d profiles, want 1", count)
+	}
+
+	dbtest.Exec(t, pool, `UPDATE users SET activation_token_expiry = now() - interval '1 minute' WHERE id = $1`, userID)
+	recorder := serve(router, http.MethodGet, "/auth/activate?token=activation-token", "")
+	assertStatus(t, recorder, http.StatusUnauthorized)
+	assertCode(t, recorder, helpers.ErrorCode(stores.ErrInvalidOrExpiredActivationToken))
+}
+
+// TestActivateUserKeepsRestrictedAccounts opens a still valid activation link of a user deactivated by a moderator
+// after their activation, and of a banned user. Neither request may make the user active again.
+func TestActivateUserKeepsRestrictedAccounts(t *testing.T) {
+	tests := []struct {
+		name       string
+		update     string
+		wantStatus int
+		wantCode   string
+	}{
+		{
+			name:       "deactivated after activation",
+			update:     `UPDATE users SET is_active = FALSE, activated_at = now() - interval '1 day' WHERE id = $1`,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "banned before activation",
+			update:     `UPDATE users SET is_active = FALSE, banned = TRUE, activated_at = NULL WHERE id = $1`,
+			wantStatus: http.StatusForbidden,
+			wantCode:   helpers.CodeAccountBanned,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool := dbtest.NewPool(t)
+
+			ac := NewAuthController(pool, stores.NewAuthStore(pool), stores.NewProfileStore(pool), nil, nil, nil, nil, nil, newTestLogger())
+			router := newTestRouter(nil)
+			router.GET("/auth/activate", ac.ActivateUser)
+
+			userID := dbtest.CreateUser(t, pool, "gopher", 1)
+			dbtest.Exec(t, pool, `
+				UPDATE users
+				SET activation_token = 'activation-token', activation_token_expiry = now() + interval '15 minutes'
+				WHERE id = $1
+			`, userID)
+			dbtest.Exec(t, pool, tt.update, userID)
+
+			recorder := serve(router, http.MethodGet, "/auth/activate?token=activation-token", "")
+			assertStatus(t, recorder, tt.wantStatus)
+			if tt.wantCode != "" {
+				assertCode(t, recorder, tt.wantCode)
+			}
+			if count := dbtest.Count(t, pool, `SELECT COUNT(*) FROM users WHERE id = $1 AND is_active = FALSE`, userID); count != 1 {
+				t.Fatal("user is active after opening the activation link")
+			}
+		})
+	}
+}
+
+// TestGoogleCallbackRequiresStateCookie checks that a state stored in Redis is only accepted together with the state
+// cookie of the browser that started the login, and that a rejected callback leaves the state unused.
+func TestGoogleCallbackRequiresStateCookie(t *testing.T) {
+	server, client := newTestRedis(t)
+	ac := NewAuthController(nil, nil, nil, nil, nil, nil, nil, client, newTestLogger())
+
+	router := newTestRouter(nil)
+	router.GET("/auth/oauth/google/callback", ac.GoogleCallback)
+
+	tests := []struct {
+		name   string
+		cookie string
+	}{
+		{name: "missing cookie"},
+		{name: "cookie of another state", cookie: "other-state"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := server.Set(oauthStateKeyPrefix+"issued-state", "google"); err != nil {
+				t.Fatalf("failed to store state: %v", err)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/auth/oauth/google/callback?state=issued-state&code=code", nil)
+			if tt.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: oauthStateCookieName, Value: tt.cookie})
+			}
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, req)
+
+			assertStatus(t, recorder, http.StatusBadRequest)
+			assertCode(t, recorder, helpers.CodeBadRequest)
+			if !server.Exists(oauthStateKeyPrefix + "issued-state") {
+				t.Fatal("rejected callback consumed the state")
+			}
+		})
+	}
+}
+
+func TestSetOAuthStateCookie(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(recorder)
+	setOAuthStateCookie(c, "issued-state", int(helpers.OAuthStateExpiry/time.Second))
+
+	cookies := recorder.Result().Cookies()
+	if len(cookies) != 1 {
+		t.Fatalf("got %d cookies, want 1", len(cookies))
+	}
+	cookie := cookies[0]
+	if cookie.Name != oauthStateCookieName || cookie.Value != "issued-state" {
+		t.Fatalf("cookie = %s=%s, want %s=issued-state", cookie.Name, cookie.Value, oauthStateCookieName)
+	}
+	if !cookie.HttpOnly || !cookie.Secure || cookie.SameSite != http.SameSiteLaxMode {
+		t.Fatalf("cookie HttpOnly = %v, Secure = %v, SameSite = %v, want HttpOnly, Secure and Lax", cookie.HttpOnly, cookie.Secure, cookie.SameSite)
+	}
+	if cookie.MaxAge != int(helpers.OAuthStateExpiry/time.Second) {
+		t.Fatalf("cookie MaxAge = %d, want %d", cookie.MaxAge, int(helpers.OAuthStateExpiry/time.Second))
+	}
+}
@@ -0,0 +1,227 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/datarohit/gopher-social-backend/helpers"
+	"github.com/datarohit/gopher-social-backend/middlewares"
+	"github.com/datarohit/gopher-social-backend/models"
+	"github.com/datarohit/gopher-social-backend/stores"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type BookmarkController struct {
+	bookmarkStore *stores.BookmarkStore
+	postStore     *stores.PostStore
+	followStore   *stores.FollowStore
+	logger        *logrus.Logger
+}
+
+// NewBookmarkController creates a new BookmarkController.
+//
+// Parameters:
+//   - bookmarkStore (*stores.BookmarkStore): BookmarkStore pointer to interact with the database.
+//   - postStore (*stores.PostStore): PostStore pointer to interact with the database.
+//   - followStore (*stores.FollowStore): FollowStore pointer to check the visibility of posts of private profiles.
+//   - logger (*logrus.Logger): Logrus logger pointer to log messages.
+//
+// Returns:
+//   - *BookmarkController: Pointer to the BookmarkController.
+func NewBookmarkController(bookmarkStore *stores.BookmarkStore, postStore *stores.PostStore, followStore *stores.FollowStore, logger *logrus.Logger) *BookmarkController {
+	return &BookmarkController{
+		bookmarkStore: bookmarkStore,
+		postStore:     postStore,
+		followStore:   followStore,
+		logger:        logger,
+	}
+}
+
+// AddBookmark godoc
+// @Summary      Bookmark a post
+// @Description  Privately bookmarks a post for the logged-in user. Posts of private profiles can only be bookmarked by the author and their followers.
+// @Tags         bookmarks
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        postID path string true "Post Identifier (Post ID)"
+// @Success      201 {object} models.AddBookmarkSuccessResponse "Successfully bookmarked post"
+// @Failure      400 {object} models.AddBookmarkErrorResponse "Bad Request - Invalid input"
+// @Failure      401 {object} models.AddBookmarkErrorResponse "Unauthorized - User not logged in or invalid token"
+// @Failure      403 {object} models.AddBookmarkErrorResponse "Forbidden - User account is inactive or banned"
+// @Failure      404 {object} models.AddBookmarkErrorResponse "Not Found - Post not found"
+// @Failure      409 {object} models.AddBookmarkErrorResponse "Conflict - Post already bookmarked"
+// @Failure      500 {object} models.AddBookmarkErrorResponse "Internal Server Error - Failed to bookmark post"
+// @Router       /post/{postID}/bookmark [post]
+func (bc *BookmarkController) AddBookmark(c *gin.Context) {
+	userModel := helpers.RequireUser(c)
+
+	postID, err := uuid.Parse(c.Param("postID"))
+	if err != nil {
+		bc.logger.WithFields(logrus.Fields{"error": err, "postID": c.Param("postID")}).Error("Invalid Post ID format")
+		c.JSON(http.StatusBadRequest, models.AddBookmarkErrorResponse{
+			Message: "Invalid Request",
+			Error:   "invalid post ID format",
+			Code:    helpers.CodeBadRequest,
+		})
+		return
+	}
+
+	post, err := bc.postStore.GetPostByID(c, postID)
+	if err != nil {
+		if errors.Is(err, stores.ErrPostNotFound) {
+			bc.logger.WithFields(logrus.Fields{"error": err, "postID": postID, "userID": userModel.ID}).Error("Post not found")
+			c.JSON(http.StatusNotFound, models.AddBookmarkErrorResponse{
+				Message: "Post Not Found",
+				Error:   "post not found",
+				Code:    helpers.ErrorCode(err),
+			})
+		} else {
+			bc.logger.WithFields(logrus.Fields{"error": err, "postID": postID, "userID": userModel.ID}).Error("Failed to get post from store")
+			c.JSON(http.StatusInternalServerError, models.AddBookmarkErrorResponse{
+				Message: "Failed to Bookmark Post",
+				Error:   "could not retrieve post from database",
+				Code:    helpers.CodeInternal,
+			})
+		}
+		return
+	}
+
+	canView, err := bc.followStore.CanViewPosts(c, userModel.ID, post.AuthorID)
+	if err != nil {
+		bc.logger.WithFields(logrus.Fields{"error": err, "postID": postID, "authorID": post.AuthorID}).Error("Failed to check post visibility")
+		c.JSON(http.StatusInternalServerError, models.AddBookmarkErrorResponse{
+			Message: "Failed to Bookmark Post",
+			Error:   "could not check post visibility",
+			Code:    helpers.CodeInternal,
+		})
+		return
+	}
+	if !canView {
+		bc.logger.WithFields(logrus.Fields{"postID": postID, "authorID": post.AuthorID, "userID": userModel.ID}).Error("Post of private profile not visible to user")
+		c.JSON(http.StatusNotFound, models.AddBookmarkErrorResponse{
+			Message: "Post Not Found",
+			Error:   "post not found",
+			Code:    helpers.ErrorCode(stores.ErrPostNotFound),
+		})
+		return
+	}
+
+	bookmark, err := bc.bookmarkStore.Add(c, userModel.ID, postID)
+	if err != nil {
+		if errors.Is(err, stores.ErrBookmarkAlreadyExists) {
+			bc.logger.WithFields(logrus.Fields{"error": err, "postID": postID, "userID": userModel.ID}).Error("Bookmark Already Exists")
+			c.JSON(http.StatusConflict, models.AddBookmarkErrorResponse{
+				Message: "Bookmark Post Failed",
+				Error:   err.Error(),
+				Code:    helpers.ErrorCode(err),
+			})
+		} else {
+			bc.logger.WithFields(logrus.Fields{"error": err, "postID": postID, "userID": userModel.ID}).Error("Failed to Bookmark Post in Store")
+			c.JSON(http.StatusInternalServerError, models.AddBookmarkErrorResponse{
+				Message: "Failed to Bookmark Post",
+				Error:   "could not bookmark post in database",
+				Code:    helpers.CodeInternal,
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.AddBookmarkSuccessResponse{
+		Message:  "Post Bookmarked Successfully",
+		Bookmark: bookmark,
+	})
+}
+
+// RemoveBookmark godoc
+// @Summary      Remove a bookmark
+// @Description  Removes the bookmark of the logged-in user on a post.
+// @Tags         bookmarks
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        postID path string true "Post Identifier (Post ID)"
+// @Success      200 {object} models.RemoveBookmarkSuccessResponse "Successfully removed bookmark"
+// @Failure      400 {object} models.RemoveBookmarkErrorResponse "Bad Request - Invalid input"
+// @Failure      401 {object} models.RemoveBookmarkErrorResponse "Unauthorized - User not logged in or invalid token"
+// @Failure      403 {object} models.RemoveBookmarkErrorResponse "Forbidden - User account is inactive or banned"
+// @Failure      404 {object} models.RemoveBookmarkErrorResponse "Not Found - Bookmark not found"
+// @Failure      500 {object} models.RemoveBookmarkErrorResponse "Internal Server Error - Failed to remove bookmark"
+// @Router       /post/{postID}/bookmark [delete]
+func (bc *BookmarkController) RemoveBookmark(c *gin.Context) {
+	userModel := helpers.RequireUser(c)
+
+	postID, err := uuid.Parse(c.Param("postID"))
+	if err != nil {
+		bc.logger.WithFields(logrus.Fields{"error": err, "postID": c.Param("postID")}).Error("Invalid Post ID format")
+		c.JSON(http.StatusBadRequest, models.RemoveBookmarkErrorResponse{
+			Message: "Invalid Request",
+			Error:   "invalid post ID format",
+			Code:    helpers.CodeBadRequest,
+		})
+		return
+	}
+
+	err = bc.bookmarkStore.Remove(c, userModel.ID, postID)
+	if err != nil {
+		if errors.Is(err, stores.ErrBookmarkNotFound) {
+			bc.logger.WithFields(logrus.Fields{"error": err, "postID": postID, "userID": userModel.ID}).Error("Bookmark Not Found")
+			c.JSON(http.StatusNotFound, models.RemoveBookmarkErrorResponse{
+				Message: "Remove Bookmark Failed",
+				Error:   err.Error(),
+				Code:    helpers.ErrorCode(err),
+			})
+		} else {
+			bc.logger.WithFields(logrus.Fields{"error": err, "postID": postID, "userID": userModel.ID}).Error("Failed to Remove Bookmark in Store")
+			c.JSON(http.StatusInternalServerError, models.RemoveBookmarkErrorResponse{
+				Message: "Failed to Remove Bookmark",
+				Error:   "could not remove bookmark in database",
+				Code:    helpers.CodeInternal,
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, models.RemoveBookmarkSuccessResponse{
+		Message: "Bookmark Removed Successfully",
+	})
+}
+
+// ListBookmarks godoc
+// @Summary      List bookmarked posts of logged-in user
+// @Description  Retrieves the posts bookmarked by the logged-in user, most recently bookmarked first. Each post includes its author and like, dislike and comment counts. Bookmarks are private and only listed to their owner.
+// @Tags         bookmarks
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        page query integer false "Page number for pagination" default(1)
+// @Param        pageSize query integer false "Number of items per page, at most 100" default(10)
+// @Success      200 {object} models.ListBookmarksSuccessResponse "Successfully retrieved bookmarked posts"
+// @Failure      401 {object} models.ListBookmarksErrorResponse "Unauthorized - User not logged in or invalid token"
+// @Failure      403 {object} models.ListBookmarksErrorResponse "Forbidden - User account is inactive or banned"
+// @Failure      500 {object} models.ListBookmarksErrorResponse "Internal Server Error - Failed to fetch bookmarks"
+// @Router       /post/bookmarks [get]
+func (bc *BookmarkController) ListBookmarks(c *gin.Context) {
+	userModel := helpers.RequireUser(c)
+	pageNumber := c.GetInt(middlewares.PageNumberKey)
+	pageSize := c.GetInt(middlewares.PageSizeKey)
+
+	bookmarks, pagination, err := bc.bookmarkStore.ListByUser(c, userModel.ID, pageNumber, pageSize)
+	if err != nil {
+		bc.logger.WithFields(logrus.Fields{"error": err, "userID": userModel.ID}).Error("Failed to get bookmarks from store")
+		c.JSON(http.StatusInternalServerError, models.ListBookmarksErrorResponse{
+			Message: "Failed to Get Bookmarks",
+			Error:   "could not retrieve bookmarks from database",
+			Code:    helpers.CodeInternal,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ListBookmarksSuccessResponse{
+		Message:    "Bookmarks Retrieved Successfully",
+		Bookmarks:  bookmarks,
+		Pagination: pagination,
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"net/http"
 
+	"github.com/datarohit/gopher-social-backend/helpers"
 	"github.com/datarohit/gopher-social-backend/middlewares"
 	"github.com/datarohit/gopher-social-backend/models"
 	"github.com/datarohit/gopher-social-backend/stores"
@@ -13,10 +14,11 @@ import (
 )
 
 type CommentController struct {
-	commentStore *stores.CommentStore
-	postStore    *stores.PostStore
-	authStore    *stores.AuthStore
-	logger       *logrus.Logger
+	commentStore  *stores.CommentStore
+	postStore     *stores.PostStore
+	authStore     *stores.AuthStore
+	contentPolicy *helpers.ContentPolicy
+	logger        *logrus.Logger
 }
 
 // NewCommentController creates a new CommentController.
@@ -25,16 +27,18 @@ type CommentController struct {
 //   - commentStore (*stores.CommentStore): CommentStore pointer to interact with the database.
 //   - postStore (*stores.PostStore): PostStore pointer to interact with the database.
 //   - authStore (*stores.AuthStore): AuthStore pointer to interact with the database.
+//   - contentPolicy (*helpers.ContentPolicy): ContentPolicy checking comments for disallowed words.
 //   - logger (*logrus.Logger): Logrus logger pointer to log messages.
 //
 // Returns:
 //   - *CommentController: Pointer to the CommentController.
-func NewCommentController(commentStore *stores.CommentStore, postStore *stores.PostStore, authStore *stores.AuthStore, logger *logrus.Logger) *CommentController {
+func NewCommentController(commentStore *stores.CommentStore, postStore *stores.PostStore, authStore *stores.AuthStore, contentPolicy *helpers.ContentPolicy, logger *logrus.Logger) *CommentController {
 	return &CommentController{
-		commentStore: commentStore,
-		postStore:    postStore,
-		authStore:    authStore,
-		logger:       logger,
+		commentStore:  commentStore,
+		postStore:     postStore,
+		authStore:     authStore,
+		contentPolicy: contentPolicy,
+		logger:        logger,
 	}
 }
 
@@ -50,6 +54,8 @@ func NewCommentController(commentStore *stores.CommentStore, postStore *stores.P
 // @Success 201 {object} models.CreateCommentSuccessResponse
 // @Failure 400 {object} models.CreateCommentErrorResponse
 // @Failure 401 {object} models.CreateCommentErrorResponse
+// @Failure 403 {object} models.CreateCommentErrorResponse
+// @Failure 404 {object} models.CreateCommentErrorResponse
 // @Failure 500 {object} models.CreateCommentErrorResponse
 // @Router /post/{postID}/comment/create [post]
 func (cc *CommentController) CreateComment(c *gin.Context) {
@@ -59,6 +65,7 @@ func (cc *CommentController) CreateComment(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.CreateCommentErrorResponse{
 			Message: "Invalid Request",
 			Error:   "postID is required path parameter",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
@@ -69,6 +76,7 @@ func (cc *CommentController) CreateComment(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.CreateCommentErrorResponse{
 			Message: "Invalid Request",
 			Error:   "invalid postID format",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
@@ -79,43 +87,63 @@ func (cc *CommentController) CreateComment(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.CreateCommentErrorResponse{
 			Message: "Invalid Request Body",
 			Error:   err.Error(),
+			Code:    helpers.CodeValidationFailed,
 		})
 		return
 	}
 
-	userCtx, exists := c.Get("user")
-	if !exists {
-		cc.logger.Error("User not found in context. Middleware misconfiguration.")
-		c.JSON(http.StatusUnauthorized, models.CreateCommentErrorResponse{
-			Message: "Unauthorized",
-			Error:   "user not authenticated",
+	if err := helpers.CheckMaxLength("content", req.Content, helpers.MaxCommentContentLength); err != nil {
+		cc.logger.WithFields(logrus.Fields{"error": err}).Error("Comment Exceeds Maximum Content Length")
+		c.JSON(http.StatusBadRequest, models.CreateCommentErrorResponse{
+			Message: "Invalid Request Body",
+			Error:   err.Error(),
+			Code:    helpers.CodeValidationFailed,
 		})
 		return
 	}
 
-	user, ok := userCtx.(*models.User)
-	if !ok {
-		cc.logger.Error("Invalid user type in context. Middleware misconfiguration.")
-		c.JSON(http.StatusInternalServerError, models.CreateCommentErrorResponse{
-			Message: "Server Error",
-			Error:   "internal server error",
+	if err := cc.contentPolicy.Check(req.Content); err != nil {
+		cc.logger.WithFields(logrus.Fields{"postID": postID}).Warn("Comment Violates Content Policy")
+		c.JSON(http.StatusBadRequest, models.CreateCommentErrorResponse{
+			Message: "Invalid Request Body",
+			Error:   err.Error(),
+			Code:    helpers.ErrorCode(err),
 		})
 		return
 	}
 
+	user := helpers.RequireUser(c)
+
 	comment := &models.Comment{
 		AuthorID: user.ID,
 		PostID:   postID,
-		Content:  req.Content,
+		Content:  helpers.SanitizeContent(req.Content),
 	}
 
 	createdComment, err := cc.commentStore.CreateComment(c.Request.Context(), comment)
 	if err != nil {
-		cc.logger.WithFields(logrus.Fields{"error": err}).Error("Failed to create comment in store")
-		c.JSON(http.StatusInternalServerError, models.CreateCommentErrorResponse{
-			Message: "Server Error",
-			Error:   "failed to create comment",
-		})
+		if errors.Is(err, stores.ErrCommentLimitReached) {
+			cc.logger.WithFields(logrus.Fields{"postID": postID, "userID": user.ID}).Warn("Post Reached Maximum Number of Comments")
+			c.JSON(http.StatusForbidden, models.CreateCommentErrorResponse{
+				Message: "Comment Limit Reached",
+				Error:   err.Error(),
+				Code:    helpers.ErrorCode(err),
+			})
+		} else if errors.Is(err, stores.ErrPostNotFound) {
+			cc.logger.WithFields(logrus.Fields{"postID": postID}).Error("Post Not Found for Comment Creation")
+			c.JSON(http.StatusNotFound, models.CreateCommentErrorResponse{
+				Message: "Post Not Found",
+				Error:   "post not found",
+				Code:    helpers.ErrorCode(err),
+			})
+		} else {
+			cc.logger.WithFields(logrus.Fields{"error": err}).Error("Failed to create comment in store")
+			c.JSON(http.StatusInternalServerError, models.CreateCommentErrorResponse{
+				Message: "Server Error",
+				Error:   "failed to create comment",
+				Code:    helpers.CodeInternal,
+			})
+		}
 		return
 	}
 
@@ -151,6 +179,7 @@ func (cc *CommentController) UpdateComment(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.UpdateCommentErrorResponse{
 			Message: "Invalid Request",
 			Error:   "postID and commentID are required path parameters",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
@@ -161,6 +190,7 @@ func (cc *CommentController) UpdateComment(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.UpdateCommentErrorResponse{
 			Message: "Invalid Request",
 			Error:   "invalid postID format",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
@@ -171,6 +201,7 @@ func (cc *CommentController) UpdateComment(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.UpdateCommentErrorResponse{
 			Message: "Invalid Request",
 			Error:   "invalid commentID format",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
@@ -181,36 +212,40 @@ func (cc *CommentController) UpdateComment(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.UpdateCommentErrorResponse{
 			Message: "Invalid Request Body",
 			Error:   err.Error(),
+			Code:    helpers.CodeValidationFailed,
 		})
 		return
 	}
 
-	userCtx, exists := c.Get("user")
-	if !exists {
-		cc.logger.Error("User not found in context. Middleware misconfiguration.")
-		c.JSON(http.StatusUnauthorized, models.UpdateCommentErrorResponse{
-			Message: "Unauthorized",
-			Error:   "user not authenticated",
+	if err := helpers.CheckMaxLength("content", req.Content, helpers.MaxCommentContentLength); err != nil {
+		cc.logger.WithFields(logrus.Fields{"error": err}).Error("Comment Exceeds Maximum Content Length")
+		c.JSON(http.StatusBadRequest, models.UpdateCommentErrorResponse{
+			Message: "Invalid Request Body",
+			Error:   err.Error(),
+			Code:    helpers.CodeValidationFailed,
 		})
 		return
 	}
 
-	user, ok := userCtx.(*models.User)
-	if !ok {
-		cc.logger.Error("Invalid user type in context. Middleware misconfiguration.")
-		c.JSON(http.StatusInternalServerError, models.UpdateCommentErrorResponse{
-			Message: "Server Error",
-			Error:   "internal server error",
+	if err := cc.contentPolicy.Check(req.Content); err != nil {
+		cc.logger.WithFields(logrus.Fields{"postID": postID}).Warn("Comment Violates Content Policy")
+		c.JSON(http.StatusBadRequest, models.UpdateCommentErrorResponse{
+			Message: "Invalid Request Body",
+			Error:   err.Error(),
+			Code:    helpers.ErrorCode(err),
 		})
 		return
 	}
 
+	user := helpers.RequireUser(c)
+
 	existingComment, err := cc.commentStore.GetCommentByID(c.Request.Context(), commentID, postID)
 	if err != nil {
 		if errors.Is(err, stores.ErrCommentNotFound) {
 			c.JSON(http.StatusNotFound, models.UpdateCommentErrorResponse{
 				Message: "Not Found",
 				Error:   "comment not found",
+				Code:    helpers.ErrorCode(err),
 			})
 			return
 		}
@@ -218,6 +253,7 @@ func (cc *CommentController) UpdateComment(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, models.UpdateCommentErrorResponse{
 			Message: "Server Error",
 			Error:   "failed to get comment",
+			Code:    helpers.CodeInternal,
 		})
 		return
 	}
@@ -227,6 +263,7 @@ func (cc *CommentController) UpdateComment(c *gin.Context) {
 		c.JSON(http.StatusForbidden, models.UpdateCommentErrorResponse{
 			Message: "Forbidden",
 			Error:   "user is not authorized to update this comment",
+			Code:    helpers.CodeForbidden,
 		})
 		return
 	}
@@ -235,7 +272,7 @@ func (cc *CommentController) UpdateComment(c *gin.Context) {
 		ID:       commentID,
 		PostID:   postID,
 		AuthorID: user.ID,
-		Content:  req.Content,
+		Content:  helpers.SanitizeContent(req.Content),
 	}
 
 	updatedComment, err := cc.commentStore.UpdateComment(c.Request.Context(), comment)
@@ -244,6 +281,7 @@ func (cc *CommentController) UpdateComment(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, models.UpdateCommentErrorResponse{
 			Message: "Server Error",
 			Error:   "failed to update comment",
+			Code:    helpers.CodeInternal,
 		})
 		return
 	}
@@ -279,6 +317,7 @@ func (cc *CommentController) DeleteComment(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.DeleteCommentErrorResponse{
 			Message: "Invalid Request",
 			Error:   "postID and commentID are required path parameters",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
@@ -289,6 +328,7 @@ func (cc *CommentController) DeleteComment(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.DeleteCommentErrorResponse{
 			Message: "Invalid Request",
 			Error:   "invalid postID format",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
@@ -299,29 +339,12 @@ func (cc *CommentController) DeleteComment(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.DeleteCommentErrorResponse{
 			Message: "Invalid Request",
 			Error:   "invalid commentID format",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
 
-	userCtx, exists := c.Get("user")
-	if !exists {
-		cc.logger.Error("User not found in context. Middleware misconfiguration.")
-		c.JSON(http.StatusUnauthorized, models.DeleteCommentErrorResponse{
-			Message: "Unauthorized",
-			Error:   "user not authenticated",
-		})
-		return
-	}
-
-	user, ok := userCtx.(*models.User)
-	if !ok {
-		cc.logger.Error("Invalid user type in context. Middleware misconfiguration.")
-		c.JSON(http.StatusInternalServerError, models.DeleteCommentErrorResponse{
-			Message: "Server Error",
-			Error:   "internal server error",
-		})
-		return
-	}
+	user := helpers.RequireUser(c)
 
 	existingComment, err := cc.commentStore.GetCommentByID(c.Request.Context(), commentID, postID)
 	if err != nil {
@@ -329,6 +352,7 @@ func (cc *CommentController) DeleteComment(c *gin.Context) {
 			c.JSON(http.StatusNotFound, models.DeleteCommentErrorResponse{
 				Message: "Not Found",
 				Error:   "comment not found",
+				Code:    helpers.ErrorCode(err),
 			})
 			return
 		}
@@ -336,6 +360,7 @@ func (cc *CommentController) DeleteComment(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, models.DeleteCommentErrorResponse{
 			Message: "Server Error",
 			Error:   "failed to get comment",
+			Code:    helpers.CodeInternal,
 		})
 		return
 	}
@@ -345,6 +370,7 @@ func (cc *CommentController) DeleteComment(c *gin.Context) {
 		c.JSON(http.StatusForbidden, models.DeleteCommentErrorResponse{
 			Message: "Forbidden",
 			Error:   "user is not authorized to delete this comment",
+			Code:    helpers.CodeForbidden,
 		})
 		return
 	}
@@ -355,6 +381,7 @@ func (cc *CommentController) DeleteComment(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, models.DeleteCommentErrorResponse{
 			Message: "Server Error",
 			Error:   "failed to delete comment",
+			Code:    helpers.CodeInternal,
 		})
 		return
 	}
@@ -386,6 +413,7 @@ func (cc *CommentController) GetComment(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.GetCommentErrorResponse{
 			Message: "Invalid Request",
 			Error:   "postID and commentID are required path parameters",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
@@ -396,6 +424,7 @@ func (cc *CommentController) GetComment(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.GetCommentErrorResponse{
 			Message: "Invalid Request",
 			Error:   "invalid postID format",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
@@ -406,6 +435,7 @@ func (cc *CommentController) GetComment(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.GetCommentErrorResponse{
 			Message: "Invalid Request",
 			Error:   "invalid commentID format",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
@@ -416,6 +446,7 @@ func (cc *CommentController) GetComment(c *gin.Context) {
 			c.JSON(http.StatusNotFound, models.GetCommentErrorResponse{
 				Message: "Not Found",
 				Error:   "comment not found",
+				Code:    helpers.ErrorCode(err),
 			})
 			return
 		}
@@ -423,6 +454,7 @@ func (cc *CommentController) GetComment(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, models.GetCommentErrorResponse{
 			Message: "Server Error",
 			Error:   "failed to get comment",
+			Code:    helpers.CodeInternal,
 		})
 		return
 	}
@@ -453,6 +485,7 @@ func (cc *CommentController) ListMyComments(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.ListMyCommentsErrorResponse{
 			Message: "Invalid Request",
 			Error:   "postID is required path parameter",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
@@ -463,42 +496,62 @@ func (cc *CommentController) ListMyComments(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.ListMyCommentsErrorResponse{
 			Message: "Invalid Request",
 			Error:   "invalid postID format",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
 
-	userCtx, exists := c.Get("user")
-	if !exists {
-		cc.logger.Error("User not found in context. Middleware misconfiguration.")
-		c.JSON(http.StatusUnauthorized, models.ListMyCommentsErrorResponse{
-			Message: "Unauthorized",
-			Error:   "user not authenticated",
-		})
-		return
-	}
+	user := helpers.RequireUser(c)
 
-	user, ok := userCtx.(*models.User)
-	if !ok {
-		cc.logger.Error("Invalid user type in context. Middleware misconfiguration.")
+	pageNumber := c.GetInt(middlewares.PageNumberKey)
+	pageSize := c.GetInt(middlewares.PageSizeKey)
+	comments, err := cc.commentStore.ListCommentsByAuthorIDForPost(c.Request.Context(), user.ID, postID, user.ID, pageNumber, pageSize)
+	if err != nil {
+		cc.logger.WithFields(logrus.Fields{"error": err}).Error("Failed to list comments from store")
 		c.JSON(http.StatusInternalServerError, models.ListMyCommentsErrorResponse{
 			Message: "Server Error",
-			Error:   "internal server error",
+			Error:   "failed to list comments",
+			Code:    helpers.CodeInternal,
 		})
 		return
 	}
 
+	c.JSON(http.StatusOK, models.ListMyCommentsSuccessResponse{
+		Message:  "Comments Retrieved Successfully",
+		Comments: comments,
+	})
+}
+
+// ListAllMyComments godoc
+// @Summary List comments of logged in user across all posts
+// @Description List all comments of logged in user across all posts, newest first, each with the post it belongs to. Requires authentication.
+// @Tags comments
+// @Accept json
+// @Produce json
+// @Param page query integer false "Page number for pagination" default(1)
+// @Param pageSize query integer false "Number of items per page, at most 100" default(10)
+// @Security BearerAuth
+// @Success 200 {object} models.ListAllMyCommentsSuccessResponse
+// @Failure 401 {object} models.ListAllMyCommentsErrorResponse
+// @Failure 500 {object} models.ListAllMyCommentsErrorResponse
+// @Router /comment/me [get]
+func (cc *CommentController) ListAllMyComments(c *gin.Context) {
+	user := helpers.RequireUser(c)
+
 	pageNumber := c.GetInt(middlewares.PageNumberKey)
-	comments, err := cc.commentStore.ListCommentsByAuthorIDForPost(c.Request.Context(), user.ID, postID, pageNumber, middlewares.PageSize)
+	pageSize := c.GetInt(middlewares.PageSizeKey)
+	comments, err := cc.commentStore.ListCommentsByAuthorID(c.Request.Context(), user.ID, pageNumber, pageSize)
 	if err != nil {
-		cc.logger.WithFields(logrus.Fields{"error": err}).Error("Failed to list comments from store")
-		c.JSON(http.StatusInternalServerError, models.ListMyCommentsErrorResponse{
+		cc.logger.WithFields(logrus.Fields{"error": err, "userID": user.ID}).Error("Failed to list comments from store")
+		c.JSON(http.StatusInternalServerError, models.ListAllMyCommentsErrorResponse{
 			Message: "Server Error",
 			Error:   "failed to list comments",
+			Code:    helpers.CodeInternal,
 		})
 		return
 	}
 
-	c.JSON(http.StatusOK, models.ListMyCommentsSuccessResponse{
+	c.JSON(http.StatusOK, models.ListAllMyCommentsSuccessResponse{
 		Message:  "Comments Retrieved Successfully",
 		Comments: comments,
 	})
@@ -526,6 +579,7 @@ func (cc *CommentController) ListCommentsByUserIdentifier(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.ListUserCommentsErrorResponse{
 			Message: "Invalid Request",
 			Error:   "postID and identifier are required path parameters",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
@@ -536,17 +590,25 @@ func (cc *CommentController) ListCommentsByUserIdentifier(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.ListUserCommentsErrorResponse{
 			Message: "Invalid Request",
 			Error:   "invalid postID format",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
 
+	viewerID := uuid.Nil
+	if viewer, exists := c.Get("user"); exists {
+		viewerID = viewer.(*models.User).ID
+	}
+
 	pageNumber := c.GetInt(middlewares.PageNumberKey)
-	comments, err := cc.commentStore.ListCommentsByUserIdentifierForPost(c.Request.Context(), identifier, postID, pageNumber, middlewares.PageSize)
+	pageSize := c.GetInt(middlewares.PageSizeKey)
+	comments, err := cc.commentStore.ListCommentsByUserIdentifierForPost(c.Request.Context(), identifier, postID, viewerID, pageNumber, pageSize)
 	if err != nil {
 		if errors.Is(err, stores.ErrUserNotFound) {
 			c.JSON(http.StatusNotFound, models.ListUserCommentsErrorResponse{
 				Message: "Not Found",
 				Error:   "user not found",
+				Code:    helpers.ErrorCode(err),
 			})
 			return
 		}
@@ -554,6 +616,7 @@ func (cc *CommentController) ListCommentsByUserIdentifier(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, models.ListUserCommentsErrorResponse{
 			Message: "Server Error",
 			Error:   "failed to list comments",
+			Code:    helpers.CodeInternal,
 		})
 		return
 	}
@@ -563,3 +626,61 @@ func (cc *CommentController) ListCommentsByUserIdentifier(c *gin.Context) {
 		Comments: comments,
 	})
 }
+
+// ListPostsCommentedByUser godoc
+// @Summary List posts commented on by a user
+// @Description List the distinct posts a user has commented on using user identifier (username or email or userID), most recent comment first. Requires authentication.
+// @Tags comments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param identifier path string true "User Identifier (username or email or userID)" example:"john_doe / john.doe@example.com / 550e8400-e29b-41d4-a716-446655440000"
+// @Param page query integer false "Page number for pagination" default(1)
+// @Param pageSize query integer false "Number of items per page, at most 100" default(10)
+// @Success 200 {object} models.ListPostsCommentedByUserSuccessResponse
+// @Failure 400 {object} models.ListPostsCommentedByUserErrorResponse
+// @Failure 401 {object} models.ListPostsCommentedByUserErrorResponse
+// @Failure 404 {object} models.ListPostsCommentedByUserErrorResponse
+// @Failure 500 {object} models.ListPostsCommentedByUserErrorResponse
+// @Router /user/{identifier}/commented [get]
+func (cc *CommentController) ListPostsCommentedByUser(c *gin.Context) {
+	identifier := c.Param("identifier")
+
+	if identifier == "" {
+		cc.logger.Error("User Identifier is required")
+		c.JSON(http.StatusBadRequest, models.ListPostsCommentedByUserErrorResponse{
+			Message: "Invalid Request",
+			Error:   "identifier is a required path parameter",
+			Code:    helpers.CodeBadRequest,
+		})
+		return
+	}
+
+	viewer := helpers.RequireUser(c)
+
+	pageNumber := c.GetInt(middlewares.PageNumberKey)
+	pageSize := c.GetInt(middlewares.PageSizeKey)
+	posts, err := cc.commentStore.ListPostsCommentedByUser(c.Request.Context(), identifier, viewer.ID, pageNumber, pageSize)
+	if err != nil {
+		if errors.Is(err, stores.ErrUserNotFound) {
+			c.JSON(http.StatusNotFound, models.ListPostsCommentedByUserErrorResponse{
+				Message: "Not Found",
+				Error:   "user not found",
+				Code:    helpers.ErrorCode(err),
+			})
+			return
+		}
+		cc.logger.WithFields(logrus.Fields{"error": err, "identifier": identifier}).Error("Failed to list commented posts from store")
+		c.JSON(http.StatusInternalServerError, models.ListPostsCommentedByUserErrorResponse{
+			Message: "Server Error",
+			Error:   "failed to list commented posts",
+			Code:    helpers.CodeInternal,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ListPostsCommentedByUserSuccessResponse{
+		Message: "Commented Posts Retrieved Successfully",
+		Posts:   posts,
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"net/http"
 
+	"github.com/datarohit/gopher-social-backend/helpers"
 	"github.com/datarohit/gopher-social-backend/middlewares"
 	"github.com/datarohit/gopher-social-backend/models"
 	"github.com/datarohit/gopher-social-backend/stores"
@@ -16,6 +17,7 @@ type CommentLikesController struct {
 	commentLikesStore *stores.CommentLikeStore
 	commentStore      *stores.CommentStore
 	postStore         *stores.PostStore
+	blockStore        *stores.BlockStore
 	authStore         *stores.AuthStore
 	logger            *logrus.Logger
 }
@@ -26,16 +28,18 @@ type CommentLikesController struct {
 //   - commentLikesStore (*stores.CommentLikeStore): CommentLikeStore pointer to interact with the database.
 //   - commentStore (*stores.CommentStore): CommentStore pointer to interact with the database.
 //   - postStore (*stores.PostStore): PostStore pointer to interact with the database.
+//   - blockStore (*stores.BlockStore): BlockStore pointer to interact with the database.
 //   - authStore (*stores.AuthStore): AuthStore pointer to interact with the database.
 //   - logger (*logrus.Logger): Logrus logger pointer to log messages.
 //
 // Returns:
 //   - *CommentLikesController: Pointer to the CommentLikesController.
-func NewCommentLikesController(commentLikesStore *stores.CommentLikeStore, commentStore *stores.CommentStore, postStore *stores.PostStore, authStore *stores.AuthStore, logger *logrus.Logger) *CommentLikesController {
+func NewCommentLikesController(commentLikesStore *stores.CommentLikeStore, commentStore *stores.CommentStore, postStore *stores.PostStore, blockStore *stores.BlockStore, authStore *stores.AuthStore, logger *logrus.Logger) *CommentLikesController {
 	return &CommentLikesController{
 		commentLikesStore: commentLikesStore,
 		commentStore:      commentStore,
 		postStore:         postStore,
+		blockStore:        blockStore,
 		authStore:         authStore,
 		logger:            logger,
 	}
@@ -53,22 +57,13 @@ func NewCommentLikesController(commentLikesStore *stores.CommentLikeStore, comme
 // @Success      200 {object} models.LikeCommentSuccessResponse "Successfully liked comment"
 // @Failure      400 {object} models.LikeCommentErrorResponse "Bad Request - Invalid input"
 // @Failure      401 {object} models.LikeCommentErrorResponse "Unauthorized - User not logged in or invalid token"
-// @Failure      403 {object} models.LikeCommentErrorResponse "Forbidden - User account is inactive or banned"
+// @Failure      403 {object} models.LikeCommentErrorResponse "Forbidden - User account is inactive or banned, or blocked by the author"
 // @Failure      404 {object} models.LikeCommentErrorResponse "Not Found - Post or Comment not found"
 // @Failure      409 {object} models.LikeCommentErrorResponse "Conflict - Already liked comment"
 // @Failure      500 {object} models.LikeCommentErrorResponse "Internal Server Error - Failed to like comment"
 // @Router       /post/{postID}/comment/{commentID}/like [post]
 func (clc *CommentLikesController) LikeComment(c *gin.Context) {
-	userCtx, exists := c.Get("user")
-	if !exists {
-		clc.logger.Error("User not found in context. Middleware misconfiguration.")
-		c.JSON(http.StatusUnauthorized, models.LikeCommentErrorResponse{
-			Message: "Unauthorized",
-			Error:   "user not authenticated",
-		})
-		return
-	}
-	userModel := userCtx.(*models.User)
+	userModel := helpers.RequireUser(c)
 
 	postIDStr := c.Param("postID")
 	commentIDStr := c.Param("commentID")
@@ -78,6 +73,7 @@ func (clc *CommentLikesController) LikeComment(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.LikeCommentErrorResponse{
 			Message: "Invalid Request",
 			Error:   "postID and commentID are required path parameters",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
@@ -88,6 +84,7 @@ func (clc *CommentLikesController) LikeComment(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.LikeCommentErrorResponse{
 			Message: "Invalid Request",
 			Error:   "invalid post ID format",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
@@ -98,6 +95,7 @@ func (clc *CommentLikesController) LikeComment(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.LikeCommentErrorResponse{
 			Message: "Invalid Request",
 			Error:   "invalid comment ID format",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
@@ -109,48 +107,68 @@ func (clc *CommentLikesController) LikeComment(c *gin.Context) {
 			c.JSON(http.StatusNotFound, models.LikeCommentErrorResponse{
 				Message: "Post Not Found",
 				Error:   "post not found",
+				Code:    helpers.ErrorCode(err),
 			})
 		} else {
 			clc.logger.WithFields(logrus.Fields{"error": err, "postID": postID, "commentID": commentID, "userID": userModel.ID}).Error("Failed to get post from store")
 			c.JSON(http.StatusInternalServerError, models.LikeCommentErrorResponse{
 				Message: "Failed to Like Comment",
 				Error:   "could not retrieve post from database",
+				Code:    helpers.CodeInternal,
 			})
 		}
 		return
 	}
 
-	_, err = clc.commentStore.GetCommentByID(c, commentID, postID)
+	err = clc.blockStore.CheckCommentAuthorBlock(c, commentID, postID, userModel.ID)
 	if err != nil {
 		if errors.Is(err, stores.ErrCommentNotFound) {
 			clc.logger.WithFields(logrus.Fields{"error": err, "postID": postID, "commentID": commentID, "userID": userModel.ID}).Error("Comment not found")
 			c.JSON(http.StatusNotFound, models.LikeCommentErrorResponse{
 				Message: "Comment Not Found",
 				Error:   "comment not found",
+				Code:    helpers.ErrorCode(err),
+			})
+		} else if errors.Is(err, stores.ErrBlockedByAuthor) {
+			clc.logger.WithFields(logrus.Fields{"postID": postID, "commentID": commentID, "userID": userModel.ID}).Warn("Blocked by Comment Author")
+			c.JSON(http.StatusForbidden, models.LikeCommentErrorResponse{
+				Message: "Like Comment Failed",
+				Error:   err.Error(),
+				Code:    helpers.ErrorCode(err),
 			})
 		} else {
 			clc.logger.WithFields(logrus.Fields{"error": err, "postID": postID, "commentID": commentID, "userID": userModel.ID}).Error("Failed to get comment from store")
 			c.JSON(http.StatusInternalServerError, models.LikeCommentErrorResponse{
 				Message: "Failed to Like Comment",
 				Error:   "could not retrieve comment from database",
+				Code:    helpers.CodeInternal,
 			})
 		}
 		return
 	}
 
-	_, err = clc.commentLikesStore.LikeComment(c, userModel.ID, commentID)
+	_, err = clc.commentLikesStore.LikeComment(c, userModel.ID, commentID, postID)
 	if err != nil {
-		if errors.Is(err, stores.ErrCommentLikeAlreadyExists) {
+		if errors.Is(err, stores.ErrCommentNotFound) {
+			clc.logger.WithFields(logrus.Fields{"error": err, "postID": postID, "commentID": commentID, "userID": userModel.ID}).Error("Comment not found for post")
+			c.JSON(http.StatusNotFound, models.LikeCommentErrorResponse{
+				Message: "Comment Not Found",
+				Error:   "comment not found",
+				Code:    helpers.ErrorCode(err),
+			})
+		} else if errors.Is(err, stores.ErrCommentLikeAlreadyExists) {
 			clc.logger.WithFields(logrus.Fields{"error": err, "postID": postID, "commentID": commentID, "userID": userModel.ID}).Error("Comment Like Already Exists")
 			c.JSON(http.StatusConflict, models.LikeCommentErrorResponse{
 				Message: "Like Comment Failed",
 				Error:   "already liked comment",
+				Code:    helpers.ErrorCode(err),
 			})
 		} else {
 			clc.logger.WithFields(logrus.Fields{"error": err, "postID": postID, "commentID": commentID, "userID": userModel.ID}).Error("Failed to Like Comment in Store")
 			c.JSON(http.StatusInternalServerError, models.LikeCommentErrorResponse{
 				Message: "Failed to Like Comment",
 				Error:   "could not like comment in database",
+				Code:    helpers.CodeInternal,
 			})
 		}
 		return
@@ -178,16 +196,7 @@ func (clc *CommentLikesController) LikeComment(c *gin.Context) {
 // @Failure      500 {object} models.UnlikeCommentErrorResponse "Internal Server Error - Failed to unlike comment"
 // @Router       /post/{postID}/comment/{commentID}/like [delete]
 func (clc *CommentLikesController) UnlikeComment(c *gin.Context) {
-	userCtx, exists := c.Get("user")
-	if !exists {
-		clc.logger.Error("User not found in context. Middleware misconfiguration.")
-		c.JSON(http.StatusUnauthorized, models.UnlikeCommentErrorResponse{
-			Message: "Unauthorized",
-			Error:   "user not authenticated",
-		})
-		return
-	}
-	userModel := userCtx.(*models.User)
+	userModel := helpers.RequireUser(c)
 
 	postIDStr := c.Param("postID")
 	commentIDStr := c.Param("commentID")
@@ -197,6 +206,7 @@ func (clc *CommentLikesController) UnlikeComment(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.UnlikeCommentErrorResponse{
 			Message: "Invalid Request",
 			Error:   "postID and commentID are required path parameters",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
@@ -207,6 +217,7 @@ func (clc *CommentLikesController) UnlikeComment(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.UnlikeCommentErrorResponse{
 			Message: "Invalid Request",
 			Error:   "invalid post ID format",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
@@ -217,6 +228,7 @@ func (clc *CommentLikesController) UnlikeComment(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.UnlikeCommentErrorResponse{
 			Message: "Invalid Request",
 			Error:   "invalid comment ID format",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
@@ -228,12 +240,14 @@ func (clc *CommentLikesController) UnlikeComment(c *gin.Context) {
 			c.JSON(http.StatusNotFound, models.UnlikeCommentErrorResponse{
 				Message: "Post Not Found",
 				Error:   "post not found",
+				Code:    helpers.ErrorCode(err),
 			})
 		} else {
 			clc.logger.WithFields(logrus.Fields{"error": err, "postID": postID, "commentID": commentID, "userID": userModel.ID}).Error("Failed to get post from store")
 			c.JSON(http.StatusInternalServerError, models.UnlikeCommentErrorResponse{
 				Message: "Failed to Unlike Comment",
 				Error:   "could not retrieve post from database",
+				Code:    helpers.CodeInternal,
 			})
 		}
 		return
@@ -246,12 +260,14 @@ func (clc *CommentLikesController) UnlikeComment(c *gin.Context) {
 			c.JSON(http.StatusNotFound, models.UnlikeCommentErrorResponse{
 				Message: "Comment Not Found",
 				Error:   "comment not found",
+				Code:    helpers.ErrorCode(err),
 			})
 		} else {
 			clc.logger.WithFields(logrus.Fields{"error": err, "postID": postID, "commentID": commentID, "userID": userModel.ID}).Error("Failed to get comment from store")
 			c.JSON(http.StatusInternalServerError, models.UnlikeCommentErrorResponse{
 				Message: "Failed to Unlike Comment",
 				Error:   "could not retrieve comment from database",
+				Code:    helpers.CodeInternal,
 			})
 		}
 		return
@@ -264,12 +280,14 @@ func (clc *CommentLikesController) UnlikeComment(c *gin.Context) {
 			c.JSON(http.StatusNotFound, models.UnlikeCommentErrorResponse{
 				Message: "Unlike Comment Failed",
 				Error:   "comment like not found",
+				Code:    helpers.ErrorCode(err),
 			})
 		} else {
 			clc.logger.WithFields(logrus.Fields{"error": err, "postID": postID, "commentID": commentID, "userID": userModel.ID}).Error("Failed to Unlike Comment in Store")
 			c.JSON(http.StatusInternalServerError, models.UnlikeCommentErrorResponse{
 				Message: "Failed to Unlike Comment",
 				Error:   "could not unlike comment in database",
+				Code:    helpers.CodeInternal,
 			})
 		}
 		return
@@ -292,22 +310,13 @@ func (clc *CommentLikesController) UnlikeComment(c *gin.Context) {
 // @Success      200 {object} models.DislikeCommentSuccessResponse "Successfully disliked comment"
 // @Failure      400 {object} models.DislikeCommentErrorResponse "Bad Request - Invalid input"
 // @Failure      401 {object} models.DislikeCommentErrorResponse "Unauthorized - User not logged in or invalid token"
-// @Failure      403 {object} models.DislikeCommentErrorResponse "Forbidden - User account is inactive or banned"
+// @Failure      403 {object} models.DislikeCommentErrorResponse "Forbidden - User account is inactive or banned, or blocked by the author"
 // @Failure      404 {object} models.DislikeCommentErrorResponse "Not Found - Post or Comment not found"
 // @Failure      409 {object} models.DislikeCommentErrorResponse "Conflict - Already disliked comment"
 // @Failure      500 {object} models.DislikeCommentErrorResponse "Internal Server Error - Failed to dislike comment"
 // @Router       /post/{postID}/comment/{commentID}/dislike [post]
 func (clc *CommentLikesController) DislikeComment(c *gin.Context) {
-	userCtx, exists := c.Get("user")
-	if !exists {
-		clc.logger.Error("User not found in context. Middleware misconfiguration.")
-		c.JSON(http.StatusUnauthorized, models.DislikeCommentErrorResponse{
-			Message: "Unauthorized",
-			Error:   "user not authenticated",
-		})
-		return
-	}
-	userModel := userCtx.(*models.User)
+	userModel := helpers.RequireUser(c)
 
 	postIDStr := c.Param("postID")
 	commentIDStr := c.Param("commentID")
@@ -317,6 +326,7 @@ func (clc *CommentLikesController) DislikeComment(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.DislikeCommentErrorResponse{
 			Message: "Invalid Request",
 			Error:   "postID and commentID are required path parameters",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
@@ -327,6 +337,7 @@ func (clc *CommentLikesController) DislikeComment(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.DislikeCommentErrorResponse{
 			Message: "Invalid Request",
 			Error:   "invalid post ID format",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
@@ -337,6 +348,7 @@ func (clc *CommentLikesController) DislikeComment(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.DislikeCommentErrorResponse{
 			Message: "Invalid Request",
 			Error:   "invalid comment ID format",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
@@ -348,48 +360,68 @@ func (clc *CommentLikesController) DislikeComment(c *gin.Context) {
 			c.JSON(http.StatusNotFound, models.DislikeCommentErrorResponse{
 				Message: "Post Not Found",
 				Error:   "post not found",
+				Code:    helpers.ErrorCode(err),
 			})
 		} else {
 			clc.logger.WithFields(logrus.Fields{"error": err, "postID": postID, "commentID": commentID, "userID": userModel.ID}).Error("Failed to get post from store")
 			c.JSON(http.StatusInternalServerError, models.DislikeCommentErrorResponse{
 				Message: "Failed to Dislike Comment",
 				Error:   "could not retrieve post from database",
+				Code:    helpers.CodeInternal,
 			})
 		}
 		return
 	}
 
-	_, err = clc.commentStore.GetCommentByID(c, commentID, postID)
+	err = clc.blockStore.CheckCommentAuthorBlock(c, commentID, postID, userModel.ID)
 	if err != nil {
 		if errors.Is(err, stores.ErrCommentNotFound) {
 			clc.logger.WithFields(logrus.Fields{"error": err, "postID": postID, "commentID": commentID, "userID": userModel.ID}).Error("Comment not found")
 			c.JSON(http.StatusNotFound, models.DislikeCommentErrorResponse{
 				Message: "Comment Not Found",
 				Error:   "comment not found",
+				Code:    helpers.ErrorCode(err),
+			})
+		} else if errors.Is(err, stores.ErrBlockedByAuthor) {
+			clc.logger.WithFields(logrus.Fields{"postID": postID, "commentID": commentID, "userID": userModel.ID}).Warn("Blocked by Comment Author")
+			c.JSON(http.StatusForbidden, models.DislikeCommentErrorResponse{
+				Message: "Dislike Comment Failed",
+				Error:   err.Error(),
+				Code:    helpers.ErrorCode(err),
 			})
 		} else {
 			clc.logger.WithFields(logrus.Fields{"error": err, "postID": postID, "commentID": commentID, "userID": userModel.ID}).Error("Failed to get comment from store")
 			c.JSON(http.StatusInternalServerError, models.DislikeCommentErrorResponse{
 				Message: "Failed to Dislike Comment",
 				Error:   "could not retrieve comment from database",
+				Code:    helpers.CodeInternal,
 			})
 		}
 		return
 	}
 
-	_, err = clc.commentLikesStore.DislikeComment(c, userModel.ID, commentID)
+	_, err = clc.commentLikesStore.DislikeComment(c, userModel.ID, commentID, postID)
 	if err != nil {
-		if errors.Is(err, stores.ErrCommentDislikeAlreadyExists) {
+		if errors.Is(err, stores.ErrCommentNotFound) {
+			clc.logger.WithFields(logrus.Fields{"error": err, "postID": postID, "commentID": commentID, "userID": userModel.ID}).Error("Comment not found for post")
+			c.JSON(http.StatusNotFound, models.DislikeCommentErrorResponse{
+				Message: "Comment Not Found",
+				Error:   "comment not found",
+				Code:    helpers.ErrorCode(err),
+			})
+		} else if errors.Is(err, stores.ErrCommentDislikeAlreadyExists) {
 			clc.logger.WithFields(logrus.Fields{"error": err, "postID": postID, "commentID": commentID, "userID": userModel.ID}).Error("Comment Dislike Already Exists")
 			c.JSON(http.StatusConflict, models.DislikeCommentErrorResponse{
 				Message: "Dislike Comment Failed",
 				Error:   "already disliked comment",
+				Code:    helpers.ErrorCode(err),
 			})
 		} else {
 			clc.logger.WithFields(logrus.Fields{"error": err, "postID": postID, "commentID": commentID, "userID": userModel.ID}).Error("Failed to Dislike Comment in Store")
 			c.JSON(http.StatusInternalServerError, models.DislikeCommentErrorResponse{
 				Message: "Failed to Dislike Comment",
 				Error:   "could not dislike comment in database",
+				Code:    helpers.CodeInternal,
 			})
 		}
 		return
@@ -417,16 +449,7 @@ func (clc *CommentLikesController) DislikeComment(c *gin.Context) {
 // @Failure      500 {object} models.UndislikeCommentErrorResponse "Internal Server Error - Failed to remove dislike from comment"
 // @Router       /post/{postID}/comment/{commentID}/dislike [delete]
 func (clc *CommentLikesController) UndislikeComment(c *gin.Context) {
-	userCtx, exists := c.Get("user")
-	if !exists {
-		clc.logger.Error("User not found in context. Middleware misconfiguration.")
-		c.JSON(http.StatusUnauthorized, models.UndislikeCommentErrorResponse{
-			Message: "Unauthorized",
-			Error:   "user not authenticated",
-		})
-		return
-	}
-	userModel := userCtx.(*models.User)
+	userModel := helpers.RequireUser(c)
 
 	postIDStr := c.Param("postID")
 	commentIDStr := c.Param("commentID")
@@ -436,6 +459,7 @@ func (clc *CommentLikesController) UndislikeComment(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.UndislikeCommentErrorResponse{
 			Message: "Invalid Request",
 			Error:   "postID and commentID are required path parameters",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
@@ -446,6 +470,7 @@ func (clc *CommentLikesController) UndislikeComment(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.UndislikeCommentErrorResponse{
 			Message: "Invalid Request",
 			Error:   "invalid post ID format",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
@@ -456,6 +481,7 @@ func (clc *CommentLikesController) UndislikeComment(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.UndislikeCommentErrorResponse{
 			Message: "Invalid Request",
 			Error:   "invalid comment ID format",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
@@ -467,12 +493,14 @@ func (clc *CommentLikesController) UndislikeComment(c *gin.Context) {
 			c.JSON(http.StatusNotFound, models.UndislikeCommentErrorResponse{
 				Message: "Post Not Found",
 				Error:   "post not found",
+				Code:    helpers.ErrorCode(err),
 			})
 		} else {
 			clc.logger.WithFields(logrus.Fields{"error": err, "postID": postID, "commentID": commentID, "userID": userModel.ID}).Error("Failed to get post from store")
 			c.JSON(http.StatusInternalServerError, models.UndislikeCommentErrorResponse{
 				Message: "Failed to Undislike Comment",
 				Error:   "could not retrieve post from database",
+				Code:    helpers.CodeInternal,
 			})
 		}
 		return
@@ -485,12 +513,14 @@ func (clc *CommentLikesController) UndislikeComment(c *gin.Context) {
 			c.JSON(http.StatusNotFound, models.UndislikeCommentErrorResponse{
 				Message: "Comment Not Found",
 				Error:   "comment not found",
+				Code:    helpers.ErrorCode(err),
 			})
 		} else {
 			clc.logger.WithFields(logrus.Fields{"error": err, "postID": postID, "commentID": commentID, "userID": userModel.ID}).Error("Failed to get comment from store")
 			c.JSON(http.StatusInternalServerError, models.UndislikeCommentErrorResponse{
 				Message: "Failed to Undislike Comment",
 				Error:   "could not retrieve comment from database",
+				Code:    helpers.CodeInternal,
 			})
 		}
 		return
@@ -503,12 +533,14 @@ func (clc *CommentLikesController) UndislikeComment(c *gin.Context) {
 			c.JSON(http.StatusNotFound, models.UndislikeCommentErrorResponse{
 				Message: "Undislike Comment Failed",
 				Error:   "comment dislike not found",
+				Code:    helpers.ErrorCode(err),
 			})
 		} else {
 			clc.logger.WithFields(logrus.Fields{"error": err, "postID": postID, "commentID": commentID, "userID": userModel.ID}).Error("Failed to Undislike Comment in Store")
 			c.JSON(http.StatusInternalServerError, models.UndislikeCommentErrorResponse{
 				Message: "Failed to Undislike Comment",
 				Error:   "could not undislike comment in database",
+				Code:    helpers.CodeInternal,
 			})
 		}
 		return
@@ -528,6 +560,7 @@ func (clc *CommentLikesController) UndislikeComment(c *gin.Context) {
 // @Security     BearerAuth
 // @Param        postID path string true "Post Identifier (Post ID)"
 // @Param        page query integer false "Page number for pagination" default(1)
+// @Param        pageSize query integer false "Number of items per page, at most 100" default(10)
 // @Success      200 {object} models.ListLikedCommentsUnderPostSuccessResponse "Successfully retrieved list of liked comments under post"
 // @Failure      400 {object} models.ListLikedCommentsUnderPostErrorResponse "Bad Request - Invalid input"
 // @Failure      401 {object} models.ListLikedCommentsUnderPostErrorResponse "Unauthorized - User not logged in or invalid token"
@@ -535,16 +568,7 @@ func (clc *CommentLikesController) UndislikeComment(c *gin.Context) {
 // @Failure      500 {object} models.ListLikedCommentsUnderPostErrorResponse "Internal Server Error - Failed to fetch liked comments under post"
 // @Router       /post/{postID}/comment/liked [get]
 func (clc *CommentLikesController) ListLikedCommentsUnderPost(c *gin.Context) {
-	userCtx, exists := c.Get("user")
-	if !exists {
-		clc.logger.Error("User not found in context. Middleware misconfiguration.")
-		c.JSON(http.StatusUnauthorized, models.ListLikedCommentsUnderPostErrorResponse{
-			Message: "Unauthorized",
-			Error:   "user not authenticated",
-		})
-		return
-	}
-	userModel := userCtx.(*models.User)
+	userModel := helpers.RequireUser(c)
 
 	postIDStr := c.Param("postID")
 	if postIDStr == "" {
@@ -552,6 +576,7 @@ func (clc *CommentLikesController) ListLikedCommentsUnderPost(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.ListLikedCommentsUnderPostErrorResponse{
 			Message: "Invalid Request",
 			Error:   "postID is required path parameter",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
@@ -562,6 +587,7 @@ func (clc *CommentLikesController) ListLikedCommentsUnderPost(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.ListLikedCommentsUnderPostErrorResponse{
 			Message: "Invalid Request",
 			Error:   "invalid post ID format",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
@@ -573,31 +599,36 @@ func (clc *CommentLikesController) ListLikedCommentsUnderPost(c *gin.Context) {
 			c.JSON(http.StatusNotFound, models.ListLikedCommentsUnderPostErrorResponse{
 				Message: "Post Not Found",
 				Error:   "post not found",
+				Code:    helpers.ErrorCode(err),
 			})
 		} else {
 			clc.logger.WithFields(logrus.Fields{"error": err, "postID": postID, "userID": userModel.ID}).Error("Failed to get post from store")
 			c.JSON(http.StatusInternalServerError, models.ListLikedCommentsUnderPostErrorResponse{
 				Message: "Failed to Get Liked Comments",
 				Error:   "could not retrieve post from database",
+				Code:    helpers.CodeInternal,
 			})
 		}
 		return
 	}
 
 	pageNumber := c.GetInt(middlewares.PageNumberKey)
-	comments, err := clc.commentLikesStore.ListLikedCommentsByUserIDForPost(c, userModel.ID, postID, pageNumber, middlewares.PageSize)
+	pageSize := c.GetInt(middlewares.PageSizeKey)
+	comments, pagination, err := clc.commentLikesStore.ListLikedCommentsByUserIDForPost(c, userModel.ID, postID, pageNumber, pageSize)
 	if err != nil {
 		clc.logger.WithFields(logrus.Fields{"error": err, "postID": postID, "userID": userModel.ID}).Error("Failed to get liked comments under post from store")
 		c.JSON(http.StatusInternalServerError, models.ListLikedCommentsUnderPostErrorResponse{
 			Message: "Failed to Get Liked Comments",
 			Error:   "could not retrieve liked comments from database",
+			Code:    helpers.CodeInternal,
 		})
 		return
 	}
 
 	c.JSON(http.StatusOK, models.ListLikedCommentsUnderPostSuccessResponse{
-		Message:  "Liked Comments Retrieved Successfully",
-		Comments: comments,
+		Message:    "Liked Comments Retrieved Successfully",
+		Comments:   comments,
+		Pagination: pagination,
 	})
 }
 
@@ -610,6 +641,7 @@ func (clc *CommentLikesController) ListLikedCommentsUnderPost(c *gin.Context) {
 // @Security     BearerAuth
 // @Param        postID path string true "Post Identifier (Post ID)"
 // @Param        page query integer false "Page number for pagination" default(1)
+// @Param        pageSize query integer false "Number of items per page, at most 100" default(10)
 // @Success      200 {object} models.ListDislikedCommentsUnderPostSuccessResponse "Successfully retrieved list of disliked comments under post"
 // @Failure      400 {object} models.ListDislikedCommentsUnderPostErrorResponse "Bad Request - Invalid input"
 // @Failure      401 {object} models.ListDislikedCommentsUnderPostErrorResponse "Unauthorized - User not logged in or invalid token"
@@ -617,16 +649,7 @@ func (clc *CommentLikesController) ListLikedCommentsUnderPost(c *gin.Context) {
 // @Failure      500 {object} models.ListDislikedCommentsUnderPostErrorResponse "Internal Server Error - Failed to fetch disliked comments under post"
 // @Router       /post/{postID}/comment/disliked [get]
 func (clc *CommentLikesController) ListDislikedCommentsUnderPost(c *gin.Context) {
-	userCtx, exists := c.Get("user")
-	if !exists {
-		clc.logger.Error("User not found in context. Middleware misconfiguration.")
-		c.JSON(http.StatusUnauthorized, models.ListDislikedCommentsUnderPostErrorResponse{
-			Message: "Unauthorized",
-			Error:   "user not authenticated",
-		})
-		return
-	}
-	userModel := userCtx.(*models.User)
+	userModel := helpers.RequireUser(c)
 
 	postIDStr := c.Param("postID")
 	if postIDStr == "" {
@@ -634,6 +657,7 @@ func (clc *CommentLikesController) ListDislikedCommentsUnderPost(c *gin.Context)
 		c.JSON(http.StatusBadRequest, models.ListDislikedCommentsUnderPostErrorResponse{
 			Message: "Invalid Request",
 			Error:   "postID is required path parameter",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
@@ -644,6 +668,7 @@ func (clc *CommentLikesController) ListDislikedCommentsUnderPost(c *gin.Context)
 		c.JSON(http.StatusBadRequest, models.ListDislikedCommentsUnderPostErrorResponse{
 			Message: "Invalid Request",
 			Error:   "invalid post ID format",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
@@ -655,31 +680,36 @@ func (clc *CommentLikesController) ListDislikedCommentsUnderPost(c *gin.Context)
 			c.JSON(http.StatusNotFound, models.ListDislikedCommentsUnderPostErrorResponse{
 				Message: "Post Not Found",
 				Error:   "post not found",
+				Code:    helpers.ErrorCode(err),
 			})
 		} else {
 			clc.logger.WithFields(logrus.Fields{"error": err, "postID": postID, "userID": userModel.ID}).Error("Failed to get post from store")
 			c.JSON(http.StatusInternalServerError, models.ListDislikedCommentsUnderPostErrorResponse{
 				Message: "Failed to Get Disliked Comments",
 				Error:   "could not retrieve post from database",
+				Code:    helpers.CodeInternal,
 			})
 		}
 		return
 	}
 
 	pageNumber := c.GetInt(middlewares.PageNumberKey)
-	comments, err := clc.commentLikesStore.ListDislikedCommentsByUserIDForPost(c, userModel.ID, postID, pageNumber, middlewares.PageSize)
+	pageSize := c.GetInt(middlewares.PageSizeKey)
+	comments, pagination, err := clc.commentLikesStore.ListDislikedCommentsByUserIDForPost(c, userModel.ID, postID, pageNumber, pageSize)
 	if err != nil {
 		clc.logger.WithFields(logrus.Fields{"error": err, "postID": postID, "userID": userModel.ID}).Error("Failed to get disliked comments under post from store")
 		c.JSON(http.StatusInternalServerError, models.ListDislikedCommentsUnderPostErrorResponse{
 			Message: "Failed to Get Disliked Comments",
 			Error:   "could not retrieve disliked comments from database",
+			Code:    helpers.CodeInternal,
 		})
 		return
 	}
 
 	c.JSON(http.StatusOK, models.ListDislikedCommentsUnderPostSuccessResponse{
-		Message:  "Disliked Comments Retrieved Successfully",
-		Comments: comments,
+		Message:    "Disliked Comments Retrieved Successfully",
+		Comments:   comments,
+		Pagination: pagination,
 	})
 }
 
@@ -700,16 +730,6 @@ func (clc *CommentLikesController) ListDislikedCommentsUnderPost(c *gin.Context)
 // @Failure      500 {object} models.ListLikedCommentsUnderPostErrorResponse "Internal Server Error - Failed to fetch liked comments"
 // @Router       /post/{postID}/comment/user/{identifier}/liked [get]
 func (clc *CommentLikesController) ListLikedCommentsByUserIdentifierForPost(c *gin.Context) {
-	_, exists := c.Get("user")
-	if !exists {
-		clc.logger.Error("User not found in context. Middleware misconfiguration.")
-		c.JSON(http.StatusUnauthorized, models.ListLikedCommentsUnderPostErrorResponse{
-			Message: "Unauthorized",
-			Error:   "user not authenticated",
-		})
-		return
-	}
-
 	postIDStr := c.Param("postID")
 	identifier := c.Param("identifier")
 
@@ -718,6 +738,7 @@ func (clc *CommentLikesController) ListLikedCommentsByUserIdentifierForPost(c *g
 		c.JSON(http.StatusBadRequest, models.ListLikedCommentsUnderPostErrorResponse{
 			Message: "Invalid Request",
 			Error:   "postID is required path parameter",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
@@ -726,6 +747,7 @@ func (clc *CommentLikesController) ListLikedCommentsByUserIdentifierForPost(c *g
 		c.JSON(http.StatusBadRequest, models.ListLikedCommentsUnderPostErrorResponse{
 			Message: "Invalid Request",
 			Error:   "user identifier is required in path",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
@@ -736,6 +758,7 @@ func (clc *CommentLikesController) ListLikedCommentsByUserIdentifierForPost(c *g
 		c.JSON(http.StatusBadRequest, models.ListLikedCommentsUnderPostErrorResponse{
 			Message: "Invalid Request",
 			Error:   "invalid post ID format",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
@@ -747,39 +770,45 @@ func (clc *CommentLikesController) ListLikedCommentsByUserIdentifierForPost(c *g
 			c.JSON(http.StatusNotFound, models.ListLikedCommentsUnderPostErrorResponse{
 				Message: "Post Not Found",
 				Error:   "post not found",
+				Code:    helpers.ErrorCode(err),
 			})
 		} else {
 			clc.logger.WithFields(logrus.Fields{"error": err, "postID": postID, "identifier": identifier}).Error("Failed to get post from store")
 			c.JSON(http.StatusInternalServerError, models.ListLikedCommentsUnderPostErrorResponse{
 				Message: "Failed to Get Liked Comments",
 				Error:   "could not retrieve post from database",
+				Code:    helpers.CodeInternal,
 			})
 		}
 		return
 	}
 
 	pageNumber := c.GetInt(middlewares.PageNumberKey)
-	comments, err := clc.commentLikesStore.ListLikedCommentsByUserIdentifierForPost(c, identifier, postID, pageNumber, middlewares.PageSize)
+	pageSize := c.GetInt(middlewares.PageSizeKey)
+	comments, pagination, err := clc.commentLikesStore.ListLikedCommentsByUserIdentifierForPost(c, identifier, postID, pageNumber, pageSize)
 	if err != nil {
 		if errors.Is(err, stores.ErrUserNotFound) {
 			clc.logger.WithFields(logrus.Fields{"error": err, "identifier": identifier, "postID": postID}).Error("User not found")
 			c.JSON(http.StatusNotFound, models.ListLikedCommentsUnderPostErrorResponse{
 				Message: "User Not Found",
 				Error:   "user not found",
+				Code:    helpers.ErrorCode(err),
 			})
 		} else {
 			clc.logger.WithFields(logrus.Fields{"error": err, "identifier": identifier, "postID": postID}).Error("Failed to get liked comments by user identifier for post from store")
 			c.JSON(http.StatusInternalServerError, models.ListLikedCommentsUnderPostErrorResponse{
 				Message: "Failed to Get Liked Comments",
 				Error:   "could not retrieve liked comments from database",
+				Code:    helpers.CodeInternal,
 			})
 		}
 		return
 	}
 
 	c.JSON(http.StatusOK, models.ListLikedCommentsUnderPostSuccessResponse{
-		Message:  "Liked Comments Retrieved Successfully",
-		Comments: comments,
+		Message:    "Liked Comments Retrieved Successfully",
+		Comments:   comments,
+		Pagination: pagination,
 	})
 }
 
@@ -800,16 +829,6 @@ func (clc *CommentLikesController) ListLikedCommentsByUserIdentifierForPost(c *g
 // @Failure      500 {object} models.ListDislikedCommentsUnderPostErrorResponse "Internal Server Error - Failed to fetch disliked comments"
 // @Router       /post/{postID}/comment/user/{identifier}/disliked [get]
 func (clc *CommentLikesController) ListDislikedCommentsByUserIdentifierForPost(c *gin.Context) {
-	_, exists := c.Get("user")
-	if !exists {
-		clc.logger.Error("User not found in context. Middleware misconfiguration.")
-		c.JSON(http.StatusUnauthorized, models.ListDislikedCommentsUnderPostErrorResponse{
-			Message: "Unauthorized",
-			Error:   "user not authenticated",
-		})
-		return
-	}
-
 	postIDStr := c.Param("postID")
 	identifier := c.Param("identifier")
 
@@ -818,6 +837,7 @@ func (clc *CommentLikesController) ListDislikedCommentsByUserIdentifierForPost(c
 		c.JSON(http.StatusBadRequest, models.ListDislikedCommentsUnderPostErrorResponse{
 			Message: "Invalid Request",
 			Error:   "postID is required path parameter",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
@@ -826,6 +846,7 @@ func (clc *CommentLikesController) ListDislikedCommentsByUserIdentifierForPost(c
 		c.JSON(http.StatusBadRequest, models.ListDislikedCommentsUnderPostErrorResponse{
 			Message: "Invalid Request",
 			Error:   "user identifier is required in path",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
@@ -836,6 +857,7 @@ func (clc *CommentLikesController) ListDislikedCommentsByUserIdentifierForPost(c
 		c.JSON(http.StatusBadRequest, models.ListDislikedCommentsUnderPostErrorResponse{
 			Message: "Invalid Request",
 			Error:   "invalid post ID format",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
@@ -847,38 +869,274 @@ func (clc *CommentLikesController) ListDislikedCommentsByUserIdentifierForPost(c
 			c.JSON(http.StatusNotFound, models.ListDislikedCommentsUnderPostErrorResponse{
 				Message: "Post Not Found",
 				Error:   "post not found",
+				Code:    helpers.ErrorCode(err),
 			})
 		} else {
 			clc.logger.WithFields(logrus.Fields{"error": err, "postID": postID, "identifier": identifier}).Error("Failed to get post from store")
 			c.JSON(http.StatusInternalServerError, models.ListDislikedCommentsUnderPostErrorResponse{
 				Message: "Failed to Get Disliked Comments",
 				Error:   "could not retrieve post from database",
+				Code:    helpers.CodeInternal,
 			})
 		}
 		return
 	}
 
 	pageNumber := c.GetInt(middlewares.PageNumberKey)
-	comments, err := clc.commentLikesStore.ListDislikedCommentsByUserIdentifierForPost(c, identifier, postID, pageNumber, middlewares.PageSize)
+	pageSize := c.GetInt(middlewares.PageSizeKey)
+	comments, pagination, err := clc.commentLikesStore.ListDislikedCommentsByUserIdentifierForPost(c, identifier, postID, pageNumber, pageSize)
 	if err != nil {
 		if errors.Is(err, stores.ErrUserNotFound) {
 			clc.logger.WithFields(logrus.Fields{"error": err, "identifier": identifier, "postID": postID}).Error("User not found")
 			c.JSON(http.StatusNotFound, models.ListDislikedCommentsUnderPostErrorResponse{
 				Message: "User Not Found",
 				Error:   "user not found",
+				Code:    helpers.ErrorCode(err),
 			})
 		} else {
 			clc.logger.WithFields(logrus.Fields{"error": err, "identifier": identifier, "postID": postID}).Error("Failed to get disliked comments by user identifier for post from store")
 			c.JSON(http.StatusInternalServerError, models.ListDislikedCommentsUnderPostErrorResponse{
 				Message: "Failed to Get Disliked Comments",
 				Error:   "could not retrieve disliked comments from database",
+				Code:    helpers.CodeInternal,
 			})
 		}
 		return
 	}
 
 	c.JSON(http.StatusOK, models.ListDislikedCommentsUnderPostSuccessResponse{
-		Message:  "Disliked Comments Retrieved Successfully",
-		Comments: comments,
+		Message:    "Disliked Comments Retrieved Successfully",
+		Comments:   comments,
+		Pagination: pagination,
+	})
+}
+
+// ListCommentLikers godoc
+// @Summary      List users who liked a comment
+// @Description  Retrieves the users who liked a comment (commentID) under a post (postID), most recent reaction first. Banned and inactive users are left out.
+// @Tags         comment_likes
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        postID    path     string  true  "Post Identifier (Post ID)"
+// @Param        commentID path     string  true  "Comment Identifier (Comment ID)"
+// @Param        page query integer false "Page number for pagination" default(1)
+// @Param        pageSize query integer false "Number of items per page, at most 100" default(10)
+// @Success      200 {object} models.ListCommentLikersSuccessResponse "Successfully retrieved users who liked the comment"
+// @Failure      400 {object} models.ListCommentLikersErrorResponse "Bad Request - Invalid input"
+// @Failure      401 {object} models.ListCommentLikersErrorResponse "Unauthorized - User not logged in or invalid token"
+// @Failure      404 {object} models.ListCommentLikersErrorResponse "Not Found - Post or Comment not found"
+// @Failure      500 {object} models.ListCommentLikersErrorResponse "Internal Server Error - Failed to fetch users who liked the comment"
+// @Router       /post/{postID}/comment/{commentID}/likes [get]
+func (clc *CommentLikesController) ListCommentLikers(c *gin.Context) {
+	userModel := helpers.RequireUser(c)
+
+	postIDStr := c.Param("postID")
+	commentIDStr := c.Param("commentID")
+
+	if postIDStr == "" || commentIDStr == "" {
+		clc.logger.Error("Post ID and Comment ID are required in path")
+		c.JSON(http.StatusBadRequest, models.ListCommentLikersErrorResponse{
+			Message: "Invalid Request",
+			Error:   "postID and commentID are required path parameters",
+			Code:    helpers.CodeBadRequest,
+		})
+		return
+	}
+
+	postID, err := uuid.Parse(postIDStr)
+	if err != nil {
+		clc.logger.WithFields(logrus.Fields{"error": err, "postID": postIDStr}).Error("Invalid Post ID format")
+		c.JSON(http.StatusBadRequest, models.ListCommentLikersErrorResponse{
+			Message: "Invalid Request",
+			Error:   "invalid post ID format",
+			Code:    helpers.CodeBadRequest,
+		})
+		return
+	}
+
+	commentID, err := uuid.Parse(commentIDStr)
+	if err != nil {
+		clc.logger.WithFields(logrus.Fields{"error": err, "commentID": commentIDStr}).Error("Invalid Comment ID format")
+		c.JSON(http.StatusBadRequest, models.ListCommentLikersErrorResponse{
+			Message: "Invalid Request",
+			Error:   "invalid comment ID format",
+			Code:    helpers.CodeBadRequest,
+		})
+		return
+	}
+
+	_, err = clc.postStore.GetPostByID(c, postID)
+	if err != nil {
+		if errors.Is(err, stores.ErrPostNotFound) {
+			clc.logger.WithFields(logrus.Fields{"error": err, "postID": postID, "commentID": commentID, "userID": userModel.ID}).Error("Post not found")
+			c.JSON(http.StatusNotFound, models.ListCommentLikersErrorResponse{
+				Message: "Post Not Found",
+				Error:   "post not found",
+				Code:    helpers.ErrorCode(err),
+			})
+		} else {
+			clc.logger.WithFields(logrus.Fields{"error": err, "postID": postID, "commentID": commentID, "userID": userModel.ID}).Error("Failed to get post from store")
+			c.JSON(http.StatusInternalServerError, models.ListCommentLikersErrorResponse{
+				Message: "Failed to Get Comment Likers",
+				Error:   "could not retrieve post from database",
+				Code:    helpers.CodeInternal,
+			})
+		}
+		return
+	}
+
+	_, err = clc.commentStore.GetCommentByID(c, commentID, postID)
+	if err != nil {
+		if errors.Is(err, stores.ErrCommentNotFound) {
+			clc.logger.WithFields(logrus.Fields{"error": err, "postID": postID, "commentID": commentID, "userID": userModel.ID}).Error("Comment not found under post")
+			c.JSON(http.StatusNotFound, models.ListCommentLikersErrorResponse{
+				Message: "Comment Not Found",
+				Error:   "comment not found",
+				Code:    helpers.ErrorCode(err),
+			})
+		} else {
+			clc.logger.WithFields(logrus.Fields{"error": err, "postID": postID, "commentID": commentID, "userID": userModel.ID}).Error("Failed to get comment from store")
+			c.JSON(http.StatusInternalServerError, models.ListCommentLikersErrorResponse{
+				Message: "Failed to Get Comment Likers",
+				Error:   "could not retrieve comment from database",
+				Code:    helpers.CodeInternal,
+			})
+		}
+		return
+	}
+
+	pageNumber := c.GetInt(middlewares.PageNumberKey)
+	pageSize := c.GetInt(middlewares.PageSizeKey)
+	users, pagination, err := clc.commentLikesStore.ListUsersByCommentReaction(c, commentID, true, pageNumber, pageSize)
+	if err != nil {
+		clc.logger.WithFields(logrus.Fields{"error": err, "postID": postID, "commentID": commentID, "userID": userModel.ID}).Error("Failed to get comment likers from store")
+		c.JSON(http.StatusInternalServerError, models.ListCommentLikersErrorResponse{
+			Message: "Failed to Get Comment Likers",
+			Error:   "could not retrieve users who liked the comment from database",
+			Code:    helpers.CodeInternal,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ListCommentLikersSuccessResponse{
+		Message:    "Comment Likers Retrieved Successfully",
+		Users:      users,
+		Pagination: pagination,
+	})
+}
+
+// ListCommentDislikers godoc
+// @Summary      List users who disliked a comment
+// @Description  Retrieves the users who disliked a comment (commentID) under a post (postID), most recent reaction first. Banned and inactive users are left out.
+// @Tags         comment_likes
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        postID    path     string  true  "Post Identifier (Post ID)"
+// @Param        commentID path     string  true  "Comment Identifier (Comment ID)"
+// @Param        page query integer false "Page number for pagination" default(1)
+// @Param        pageSize query integer false "Number of items per page, at most 100" default(10)
+// @Success      200 {object} models.ListCommentDislikersSuccessResponse "Successfully retrieved users who disliked the comment"
+// @Failure      400 {object} models.ListCommentDislikersErrorResponse "Bad Request - Invalid input"
+// @Failure      401 {object} models.ListCommentDislikersErrorResponse "Unauthorized - User not logged in or invalid token"
+// @Failure      404 {object} models.ListCommentDislikersErrorResponse "Not Found - Post or Comment not found"
+// @Failure      500 {object} models.ListCommentDislikersErrorResponse "Internal Server Error - Failed to fetch users who disliked the comment"
+// @Router       /post/{postID}/comment/{commentID}/dislikes [get]
+func (clc *CommentLikesController) ListCommentDislikers(c *gin.Context) {
+	userModel := helpers.RequireUser(c)
+
+	postIDStr := c.Param("postID")
+	commentIDStr := c.Param("commentID")
+
+	if postIDStr == "" || commentIDStr == "" {
+		clc.logger.Error("Post ID and Comment ID are required in path")
+		c.JSON(http.StatusBadRequest, models.ListCommentDislikersErrorResponse{
+			Message: "Invalid Request",
+			Error:   "postID and commentID are required path parameters",
+			Code:    helpers.CodeBadRequest,
+		})
+		return
+	}
+
+	postID, err := uuid.Parse(postIDStr)
+	if err != nil {
+		clc.logger.WithFields(logrus.Fields{"error": err, "postID": postIDStr}).Error("Invalid Post ID format")
+		c.JSON(http.StatusBadRequest, models.ListCommentDislikersErrorResponse{
+			Message: "Invalid Request",
+			Error:   "invalid post ID format",
+			Code:    helpers.CodeBadRequest,
+		})
+		return
+	}
+
+	commentID, err := uuid.Parse(commentIDStr)
+	if err != nil {
+		clc.logger.WithFields(logrus.Fields{"error": err, "commentID": commentIDStr}).Error("Invalid Comment ID format")
+		c.JSON(http.StatusBadRequest, models.ListCommentDislikersErrorResponse{
+			Message: "Invalid Request",
+			Error:   "invalid comment ID format",
+			Code:    helpers.CodeBadRequest,
+		})
+		return
+	}
+
+	_, err = clc.postStore.GetPostByID(c, postID)
+	if err != nil {
+		if errors.Is(err, stores.ErrPostNotFound) {
+			clc.logger.WithFields(logrus.Fields{"error": err, "postID": postID, "commentID": commentID, "userID": userModel.ID}).Error("Post not found")
+			c.JSON(http.StatusNotFound, models.ListCommentDislikersErrorResponse{
+				Message: "Post Not Found",
+				Error:   "post not found",
+				Code:    helpers.ErrorCode(err),
+			})
+		} else {
+			clc.logger.WithFields(logrus.Fields{"error": err, "postID": postID, "commentID": commentID, "userID": userModel.ID}).Error("Failed to get post from store")
+			c.JSON(http.StatusInternalServerError, models.ListCommentDislikersErrorResponse{
+				Message: "Failed to Get Comment Dislikers",
+				Error:   "could not retrieve post from database",
+				Code:    helpers.CodeInternal,
+			})
+		}
+		return
+	}
+
+	_, err = clc.commentStore.GetCommentByID(c, commentID, postID)
+	if err != nil {
+		if errors.Is(err, stores.ErrCommentNotFound) {
+			clc.logger.WithFields(logrus.Fields{"error": err, "postID": postID, "commentID": commentID, "userID": userModel.ID}).Error("Comment not found under post")
+			c.JSON(http.StatusNotFound, models.ListCommentDislikersErrorResponse{
+				Message: "Comment Not Found",
+				Error:   "comment not found",
+				Code:    helpers.ErrorCode(err),
+			})
+		} else {
+			clc.logger.WithFields(logrus.Fields{"error": err, "postID": postID, "commentID": commentID, "userID": userModel.ID}).Error("Failed to get comment from store")
+			c.JSON(http.StatusInternalServerError, models.ListCommentDislikersErrorResponse{
+				Message: "Failed to Get Comment Dislikers",
+				Error:   "could not retrieve comment from database",
+				Code:    helpers.CodeInternal,
+			})
+		}
+		return
+	}
+
+	pageNumber := c.GetInt(middlewares.PageNumberKey)
+	pageSize := c.GetInt(middlewares.PageSizeKey)
+	users, pagination, err := clc.commentLikesStore.ListUsersByCommentReaction(c, commentID, false, pageNumber, pageSize)
+	if err != nil {
+		clc.logger.WithFields(logrus.Fields{"error": err, "postID": postID, "commentID": commentID, "userID": userModel.ID}).Error("Failed to get comment dislikers from store")
+		c.JSON(http.StatusInternalServerError, models.ListCommentDislikersErrorResponse{
+			Message: "Failed to Get Comment Dislikers",
+			Error:   "could not retrieve users who disliked the comment from database",
+			Code:    helpers.CodeInternal,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ListCommentDislikersSuccessResponse{
+		Message:    "Comment Dislikers Retrieved Successfully",
+		Users:      users,
+		Pagination: pagination,
 	})
 }
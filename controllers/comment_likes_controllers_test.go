@@ -0,0 +1,134 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/datarohit/gopher-social-backend/database/dbtest"
+	"github.com/datarohit/gopher-social-backend/helpers"
+	"github.com/datarohit/gopher-social-backend/middlewares"
+	"github.com/datarohit/gopher-social-backend/models"
+	"github.com/datarohit/gopher-social-backend/stores"
+)
+
+func TestCommentReactionThroughOtherPostNotFound(t *testing.T) {
+	pool := dbtest.NewPool(t)
+
+	authorID := dbtest.CreateUser(t, pool, "author", 1)
+	readerID := dbtest.CreateUser(t, pool, "reader", 1)
+	postID := dbtest.CreatePost(t, pool, authorID)
+	otherPostID := dbtest.CreatePost(t, pool, authorID)
+	commentID := dbtest.CreateComment(t, pool, authorID, postID)
+
+	clc := NewCommentLikesController(stores.NewCommentLikeStore(pool), stores.NewCommentStore(pool), stores.NewPostStore(pool), stores.NewBlockStore(pool), stores.NewAuthStore(pool), newTestLogger())
+	router := newTestRouter(loadUser(t, pool, readerID))
+	router.POST("/post/:postID/comment/:commentID/like", clc.LikeComment)
+	router.POST("/post/:postID/comment/:commentID/dislike", clc.DislikeComment)
+
+	for _, action := range []string{"like", "dislike"} {
+		t.Run(action, func(t *testing.T) {
+			recorder := serve(router, http.MethodPost, "/post/"+otherPostID.String()+"/comment/"+commentID.String()+"/"+action, "")
+			assertStatus(t, recorder, http.StatusNotFound)
+			assertCode(t, recorder, helpers.ErrorCode(stores.ErrCommentNotFound))
+		})
+	}
+
+	if count := dbtest.Count(t, pool, `SELECT COUNT(*) FROM comment_likes WHERE comment_id = $1`, commentID); count != 0 {
+		t.Fatalf("%d reactions recorded through another post, want 0", count)
+	}
+}
+
+// TestCommentReactionBlockedByAuthor checks that a user blocked by the author of a comment can neither like nor dislike it,
+// while a user the author has not blocked still can.
+func TestCommentReactionBlockedByAuthor(t *testing.T) {
+	pool := dbtest.NewPool(t)
+
+	authorID := dbtest.CreateUser(t, pool, "author", 1)
+	blockedID := dbtest.CreateUser(t, pool, "blocked", 1)
+	readerID := dbtest.CreateUser(t, pool, "reader", 1)
+	postID := dbtest.CreatePost(t, pool, readerID)
+	commentID := dbtest.CreateComment(t, pool, authorID, postID)
+	dbtest.Exec(t, pool, `INSERT INTO blocks (blocker_id, blocked_id) VALUES ($1, $2)`, authorID, blockedID)
+
+	clc := NewCommentLikesController(stores.NewCommentLikeStore(pool), stores.NewCommentStore(pool), stores.NewPostStore(pool), stores.NewBlockStore(pool), stores.NewAuthStore(pool), newTestLogger())
+	path := "/post/" + postID.String() + "/comment/" + commentID.String() + "/"
+
+	blockedRouter := newTestRouter(loadUser(t, pool, blockedID))
+	blockedRouter.POST("/post/:postID/comment/:commentID/like", clc.LikeComment)
+	blockedRouter.POST("/post/:postID/comment/:commentID/dislike", clc.DislikeComment)
+
+	for _, action := range []string{"like", "dislike"} {
+		t.Run(action, func(t *testing.T) {
+			recorder := serve(blockedRouter, http.MethodPost, path+action, "")
+			assertStatus(t, recorder, http.StatusForbidden)
+			assertCode(t, recorder, helpers.ErrorCode(stores.ErrBlockedByAuthor))
+		})
+	}
+	if count := dbtest.Count(t, pool, `SELECT COUNT(*) FROM comment_likes WHERE user_id = $1`, blockedID); count != 0 {
+		t.Fatalf("%d reactions recorded for the blocked user, want 0", count)
+	}
+
+	readerRouter := newTestRouter(loadUser(t, pool, readerID))
+	readerRouter.POST("/post/:postID/comment/:commentID/like", clc.LikeComment)
+	assertStatus(t, serve(readerRouter, http.MethodPost, path+"like", ""), http.StatusOK)
+}
+
+// TestListReactedCommentsUnderPostPagination checks that the liked and disliked comment listings return an empty
+// array rather than null when nothing matches, and report the total count on every page, past the end included.
+func TestListReactedCommentsUnderPostPagination(t *testing.T) {
+	pool := dbtest.NewPool(t)
+
+	authorID := dbtest.CreateUser(t, pool, "author", 1)
+	readerID := dbtest.CreateUser(t, pool, "reader", 1)
+	postID := dbtest.CreatePost(t, pool, authorID)
+	for i := 0; i < 3; i++ {
+		commentID := dbtest.CreateComment(t, pool, authorID, postID)
+		dbtest.Exec(t, pool, `INSERT INTO comment_likes (user_id, comment_id, liked) VALUES ($1, $2, TRUE)`, readerID, commentID)
+	}
+
+	clc := NewCommentLikesController(stores.NewCommentLikeStore(pool), stores.NewCommentStore(pool), stores.NewPostStore(pool), stores.NewBlockStore(pool), stores.NewAuthStore(pool), newTestLogger())
+	router := newTestRouter(loadUser(t, pool, readerID))
+	router.GET("/post/:postID/comment/liked", middlewares.PaginationMiddleware(), clc.ListLikedCommentsUnderPost)
+	router.GET("/post/:postID/comment/disliked", middlewares.PaginationMiddleware(), clc.ListDislikedCommentsUnderPost)
+	router.GET("/post/:postID/comment/user/:identifier/disliked", middlewares.PaginationMiddleware(), clc.ListDislikedCommentsByUserIdentifierForPost)
+
+	tests := []struct {
+		name         string
+		path         string
+		wantComments int
+		wantTotal    int
+	}{
+		{name: "first page", path: "/comment/liked?page=1&pageSize=2", wantComments: 2, wantTotal: 3},
+		{name: "last page", path: "/comment/liked?page=2&pageSize=2", wantComments: 1, wantTotal: 3},
+		{name: "past the end", path: "/comment/liked?page=3&pageSize=2", wantComments: 0, wantTotal: 3},
+		{name: "none disliked", path: "/comment/disliked", wantComments: 0, wantTotal: 0},
+		{name: "none disliked by identifier", path: "/comment/user/reader/disliked", wantComments: 0, wantTotal: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := serve(router, http.MethodGet, "/post/"+postID.String()+tt.path, "")
+			assertStatus(t, recorder, http.StatusOK)
+
+			if tt.wantComments == 0 && !strings.Contains(recorder.Body.String(), `"comments":[]`) {
+				t.Fatalf("body = %s, want an empty comments array", recorder.Body.String())
+			}
+
+			var response struct {
+				Comments   []*models.Comment  `json:"comments"`
+				Pagination *models.Pagination `json:"pagination"`
+			}
+			if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if len(response.Comments) != tt.wantComments {
+				t.Fatalf("got %d comments, want %d", len(response.Comments), tt.wantComments)
+			}
+			if response.Pagination == nil || response.Pagination.TotalItems != tt.wantTotal {
+				t.Fatalf("pagination = %+v, want total %d", response.Pagination, tt.wantTotal)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"net/http"
 
+	"github.com/datarohit/gopher-social-backend/helpers"
 	"github.com/datarohit/gopher-social-backend/middlewares"
 	"github.com/datarohit/gopher-social-backend/models"
 	"github.com/datarohit/gopher-social-backend/stores"
@@ -39,18 +40,21 @@ func NewFeedController(feedStore *stores.FeedStore, logger *logrus.Logger) *Feed
 // @Accept       json
 // @Produce      json
 // @Param        page query integer false "Page number for pagination" default(1)
+// @Param        pageSize query integer false "Number of items per page, at most 100" default(10)
 // @Success      200 {object} models.ListFeedSuccessResponse "Successfully retrieved feed posts"
 // @Failure      500 {object} models.ListFeedErrorResponse "Internal Server Error - Failed to fetch feed posts"
 // @Router       /feed [get]
 func (fc *FeedController) ListFeed(c *gin.Context) {
 	pageNumber := c.GetInt(middlewares.PageNumberKey)
+	pageSize := c.GetInt(middlewares.PageSizeKey)
 
-	posts, err := fc.feedStore.ListLatestPosts(c, pageNumber, middlewares.PageSize)
+	posts, err := fc.feedStore.ListLatestPosts(c, pageNumber, pageSize)
 	if err != nil {
 		fc.logger.WithFields(logrus.Fields{"error": err}).Error("Failed to get latest posts from store")
 		c.JSON(http.StatusInternalServerError, models.ListFeedErrorResponse{
 			Message: "Failed to Get Feed Posts",
 			Error:   "could not retrieve latest posts from database",
+			Code:    helpers.CodeInternal,
 		})
 		return
 	}
@@ -63,7 +67,7 @@ func (fc *FeedController) ListFeed(c *gin.Context) {
 
 // GetFeedPost godoc
 // @Summary      Get a specific post with comments for feed
-// @Description  Retrieves a specific post by postID along with its comments in paginated form. No authentication required.
+// @Description  Retrieves a specific post by postID along with its comments in paginated form, with pagination metadata including the total number of comments. No authentication required.
 // @Tags         feed
 // @Accept       json
 // @Produce      json
@@ -71,17 +75,19 @@ func (fc *FeedController) ListFeed(c *gin.Context) {
 // @Param        page query integer false "Page number for comments pagination" default(1)
 // @Success      200 {object} models.GetFeedPostSuccessResponse "Successfully retrieved feed post with comments"
 // @Failure      400 {object} models.GetFeedPostErrorResponse "Bad Request - Invalid Post ID format"
-// @Failure      404 {object} models.GetFeedPostErrorResponse "Not Found - Post not found"
+// @Failure      404 {object} models.GetFeedPostErrorResponse "Not Found - Post not found or author's profile is private"
 // @Failure      500 {object} models.GetFeedPostErrorResponse "Internal Server Error - Failed to fetch feed post with comments"
 // @Router       /feed/{postID} [get]
 func (fc *FeedController) GetFeedPost(c *gin.Context) {
 	pageNumber := c.GetInt(middlewares.PageNumberKey)
+	pageSize := c.GetInt(middlewares.PageSizeKey)
 	postIDStr := c.Param("postID")
 	if postIDStr == "" {
 		fc.logger.Error("Post ID is required in path")
 		c.JSON(http.StatusBadRequest, models.GetFeedPostErrorResponse{
 			Message: "Invalid Request",
 			Error:   "post ID is required in path",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
@@ -92,23 +98,26 @@ func (fc *FeedController) GetFeedPost(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.GetFeedPostErrorResponse{
 			Message: "Invalid Request",
 			Error:   "invalid post ID format",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
 
-	feedPost, err := fc.feedStore.GetPostWithComments(c, postID, pageNumber, middlewares.PageSize)
+	feedPost, err := fc.feedStore.GetPostWithComments(c, postID, pageNumber, pageSize)
 	if err != nil {
 		if errors.Is(err, stores.ErrPostNotFound) {
 			fc.logger.WithFields(logrus.Fields{"error": err, "postID": postID}).Error("Post not found")
 			c.JSON(http.StatusNotFound, models.GetFeedPostErrorResponse{
 				Message: "Post Not Found",
 				Error:   "post not found",
+				Code:    helpers.ErrorCode(err),
 			})
 		} else {
 			fc.logger.WithFields(logrus.Fields{"error": err, "postID": postID}).Error("Failed to get post with comments from store")
 			c.JSON(http.StatusInternalServerError, models.GetFeedPostErrorResponse{
 				Message: "Failed to Get Feed Post",
 				Error:   "could not retrieve post with comments from database",
+				Code:    helpers.CodeInternal,
 			})
 		}
 		return
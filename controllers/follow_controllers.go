@@ -2,22 +2,36 @@ package controllers
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"net/http"
+	"strconv"
+	"time"
 
+	"github.com/datarohit/gopher-social-backend/helpers"
 	"github.com/datarohit/gopher-social-backend/middlewares"
 	"github.com/datarohit/gopher-social-backend/models"
 	"github.com/datarohit/gopher-social-backend/stores"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
 	"github.com/sirupsen/logrus"
 )
 
+// userSuggestionsKeyPrefix is the Redis key prefix for the cached follow suggestions of a user, one hash field per page and page size.
+const userSuggestionsKeyPrefix = "user_suggestions:"
+
+// userSuggestionsCacheTTL is how long follow suggestions are cached.
+const userSuggestionsCacheTTL = 5 * time.Minute
+
 type FollowController struct {
-	authStore    *stores.AuthStore
-	profileStore *stores.ProfileStore
-	followStore  *stores.FollowStore
-	logger       *logrus.Logger
+	authStore         *stores.AuthStore
+	profileStore      *stores.ProfileStore
+	followStore       *stores.FollowStore
+	notificationStore *stores.NotificationStore
+	feedStore         *stores.FeedStore
+	redisClient       *redis.Client
+	logger            *logrus.Logger
 }
 
 // NewFollowController creates a new FollowController.
@@ -26,46 +40,58 @@ type FollowController struct {
 //   - authStore (*stores.AuthStore): AuthStore pointer to interact with the database.
 //   - profileStore (*stores.ProfileStore): ProfileStore pointer to interact with the database.
 //   - followStore (*stores.FollowStore): FollowStore pointer to interact with the database.
+//   - notificationStore (*stores.NotificationStore): NotificationStore pointer to interact with the database.
+//   - feedStore (*stores.FeedStore): FeedStore pointer to invalidate cached home feeds.
+//   - redisClient (*redis.Client): Redis client used to cache follow suggestions.
 //   - logger (*logrus.Logger): Logrus logger pointer to log messages.
 //
 // Returns:
 //   - *FollowController: Pointer to the FollowController.
-func NewFollowController(authStore *stores.AuthStore, profileStore *stores.ProfileStore, followStore *stores.FollowStore, logger *logrus.Logger) *FollowController {
+func NewFollowController(authStore *stores.AuthStore, profileStore *stores.ProfileStore, followStore *stores.FollowStore, notificationStore *stores.NotificationStore, feedStore *stores.FeedStore, redisClient *redis.Client, logger *logrus.Logger) *FollowController {
 	return &FollowController{
-		authStore:    authStore,
-		profileStore: profileStore,
-		followStore:  followStore,
-		logger:       logger,
+		authStore:         authStore,
+		profileStore:      profileStore,
+		followStore:       followStore,
+		notificationStore: notificationStore,
+		feedStore:         feedStore,
+		redisClient:       redisClient,
+		logger:            logger,
+	}
+}
+
+// invalidateSuggestions drops the cached follow suggestions of a user after their followings changed.
+func (fc *FollowController) invalidateSuggestions(ctx context.Context, userID uuid.UUID) {
+	if err := fc.redisClient.Del(ctx, userSuggestionsKeyPrefix+userID.String()).Err(); err != nil {
+		fc.logger.WithFields(logrus.Fields{"error": err, "userID": userID}).Warn("Failed to Invalidate Cached User Suggestions")
+	}
+}
+
+// invalidateHomeFeed drops the cached home feed of a user after their followings changed.
+func (fc *FollowController) invalidateHomeFeed(ctx context.Context, userID uuid.UUID) {
+	if err := fc.feedStore.InvalidateHomeFeed(ctx, userID); err != nil {
+		fc.logger.WithFields(logrus.Fields{"error": err, "userID": userID}).Warn("Failed to Invalidate Cached Home Feed")
 	}
 }
 
 // FollowUser godoc
 // @Summary      Follow a user
-// @Description  Allows a logged-in user to follow another user.
+// @Description  Allows a logged-in user to follow another user. Following a private user sends a follow request instead.
 // @Tags         user_follow
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
 // @Param        identifier path string true "User Identifier (username, email, or user ID) of the followee"
 // @Success      200 {object} models.FollowUserSuccessResponse "Successfully followed user"
+// @Success      202 {object} models.FollowUserSuccessResponse "Follow request sent to private user"
 // @Failure      400 {object} models.FollowUserErrorResponse "Bad Request - Invalid input"
 // @Failure      401 {object} models.FollowUserErrorResponse "Unauthorized - User not logged in or invalid token"
-// @Failure      403 {object} models.FollowUserErrorResponse "Forbidden - User account is inactive or banned"
+// @Failure      403 {object} models.FollowUserErrorResponse "Forbidden - User account or followee account is inactive or banned"
 // @Failure      404 {object} models.FollowUserErrorResponse "Not Found - Followee user not found"
-// @Failure      409 {object} models.FollowUserErrorResponse "Conflict - Already following user"
+// @Failure      409 {object} models.FollowUserErrorResponse "Conflict - Already following user or follow request already sent"
 // @Failure      500 {object} models.FollowUserErrorResponse "Internal Server Error - Failed to follow user"
 // @Router       /user/follow/{identifier} [post]
 func (fc *FollowController) FollowUser(c *gin.Context) {
-	followerUser, exists := c.Get("user")
-	if !exists {
-		fc.logger.Error("User not Found in Context. Middleware Misconfiguration")
-		c.JSON(http.StatusUnauthorized, models.FollowUserErrorResponse{
-			Message: "Unauthorized",
-			Error:   "user not authenticated",
-		})
-		return
-	}
-	followerUserModel := followerUser.(*models.User)
+	followerUserModel := helpers.RequireUser(c)
 
 	identifier := c.Param("identifier")
 	if identifier == "" {
@@ -73,6 +99,7 @@ func (fc *FollowController) FollowUser(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.FollowUserErrorResponse{
 			Message: "Invalid Request",
 			Error:   "followee identifier is required in path",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
@@ -82,19 +109,20 @@ func (fc *FollowController) FollowUser(c *gin.Context) {
 	if err == nil {
 		followeeUserID = parsedUUID
 	} else {
-		followeeUser, err := fc.profileStore.GetProfileByUserID(context.Background(), followerUserModel.ID)
+		followeeUser, err := fc.profileStore.GetProfileByUserID(c.Request.Context(), followerUserModel.ID)
 		if err == nil {
 			if identifier == followeeUser.User.Username || identifier == followeeUser.User.Email {
 				followeeUserID = followeeUser.User.ID
 			}
 		}
 		if followeeUserID == uuid.Nil {
-			followeeUserFromAuth, err := fc.authStore.GetUserByUsernameOrEmail(context.Background(), identifier)
+			followeeUserFromAuth, err := fc.authStore.GetUserByUsernameOrEmail(c.Request.Context(), identifier)
 			if err != nil {
 				fc.logger.WithFields(logrus.Fields{"error": err, "followerUserID": followerUserModel.ID, "identifier": identifier}).Error("Followee User Not Found")
 				c.JSON(http.StatusNotFound, models.FollowUserErrorResponse{
 					Message: "Follow User Failed",
 					Error:   "followee user not found",
+					Code:    helpers.CodeNotFound,
 				})
 				return
 			}
@@ -107,28 +135,70 @@ func (fc *FollowController) FollowUser(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.FollowUserErrorResponse{
 			Message: "Invalid Request",
 			Error:   "cannot follow yourself",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
 
-	err = fc.followStore.FollowUser(context.Background(), followerUserModel.ID, followeeUserID)
+	requested, err := fc.followStore.FollowUser(c.Request.Context(), followerUserModel.ID, followeeUserID)
 	if err != nil {
 		if errors.Is(err, stores.ErrAlreadyFollowing) {
 			fc.logger.WithFields(logrus.Fields{"error": err, "followerUserID": followerUserModel.ID, "followeeUserID": followeeUserID}).Error("Already Following User")
 			c.JSON(http.StatusConflict, models.FollowUserErrorResponse{
 				Message: "Follow User Failed",
 				Error:   "already following user",
+				Code:    helpers.ErrorCode(err),
+			})
+		} else if errors.Is(err, stores.ErrFollowRequestAlreadyExists) {
+			fc.logger.WithFields(logrus.Fields{"error": err, "followerUserID": followerUserModel.ID, "followeeUserID": followeeUserID}).Error("Follow Request Already Exists")
+			c.JSON(http.StatusConflict, models.FollowUserErrorResponse{
+				Message: "Follow User Failed",
+				Error:   "follow request already sent",
+				Code:    helpers.ErrorCode(err),
+			})
+		} else if errors.Is(err, stores.ErrCannotFollowInactiveUser) {
+			fc.logger.WithFields(logrus.Fields{"error": err, "followerUserID": followerUserModel.ID, "followeeUserID": followeeUserID}).Error("Followee User is Banned or Inactive")
+			c.JSON(http.StatusForbidden, models.FollowUserErrorResponse{
+				Message: "Follow User Failed",
+				Error:   err.Error(),
+				Code:    helpers.ErrorCode(err),
+			})
+		} else if errors.Is(err, stores.ErrUserNotFound) {
+			fc.logger.WithFields(logrus.Fields{"error": err, "followerUserID": followerUserModel.ID, "followeeUserID": followeeUserID}).Error("Followee User Not Found")
+			c.JSON(http.StatusNotFound, models.FollowUserErrorResponse{
+				Message: "Follow User Failed",
+				Error:   "followee user not found",
+				Code:    helpers.CodeNotFound,
 			})
 		} else {
 			fc.logger.WithFields(logrus.Fields{"error": err, "followerUserID": followerUserModel.ID, "followeeUserID": followeeUserID}).Error("Failed to Follow User")
 			c.JSON(http.StatusInternalServerError, models.FollowUserErrorResponse{
 				Message: "Failed to Follow User",
 				Error:   "failed to follow user in database",
+				Code:    helpers.CodeInternal,
 			})
 		}
 		return
 	}
 
+	notificationType := stores.NotificationTypeFollow
+	if requested {
+		notificationType = stores.NotificationTypeFollowRequest
+	}
+	if err := fc.notificationStore.CreateNotification(c, followeeUserID, followerUserModel.ID, notificationType, nil); err != nil {
+		fc.logger.WithFields(logrus.Fields{"error": err, "followerUserID": followerUserModel.ID, "followeeUserID": followeeUserID}).Warn("Failed to Create Follow Notification")
+	}
+	fc.invalidateSuggestions(c, followerUserModel.ID)
+	fc.invalidateHomeFeed(c, followerUserModel.ID)
+
+	if requested {
+		c.JSON(http.StatusAccepted, models.FollowUserSuccessResponse{
+			Message:   "Follow Request Sent Successfully",
+			Requested: true,
+		})
+		return
+	}
+
 	c.JSON(http.StatusOK, models.FollowUserSuccessResponse{
 		Message: "User Followed Successfully",
 	})
@@ -150,16 +220,7 @@ func (fc *FollowController) FollowUser(c *gin.Context) {
 // @Failure      500 {object} models.UnfollowUserErrorResponse "Internal Server Error - Failed to unfollow user"
 // @Router       /user/unfollow/{identifier} [delete]
 func (fc *FollowController) UnfollowUser(c *gin.Context) {
-	followerUser, exists := c.Get("user")
-	if !exists {
-		fc.logger.Error("User not Found in Context. Middleware Misconfiguration")
-		c.JSON(http.StatusUnauthorized, models.UnfollowUserErrorResponse{
-			Message: "Unauthorized",
-			Error:   "user not authenticated",
-		})
-		return
-	}
-	followerUserModel := followerUser.(*models.User)
+	followerUserModel := helpers.RequireUser(c)
 
 	identifier := c.Param("identifier")
 	if identifier == "" {
@@ -167,6 +228,7 @@ func (fc *FollowController) UnfollowUser(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.UnfollowUserErrorResponse{
 			Message: "Invalid Request",
 			Error:   "followee identifier is required in path",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
@@ -176,19 +238,20 @@ func (fc *FollowController) UnfollowUser(c *gin.Context) {
 	if err == nil {
 		followeeUserID = parsedUUID
 	} else {
-		followeeUser, err := fc.profileStore.GetProfileByUserID(context.Background(), followerUserModel.ID)
+		followeeUser, err := fc.profileStore.GetProfileByUserID(c.Request.Context(), followerUserModel.ID)
 		if err == nil {
 			if identifier == followeeUser.User.Username || identifier == followeeUser.User.Email {
 				followeeUserID = followeeUser.User.ID
 			}
 		}
 		if followeeUserID == uuid.Nil {
-			followeeUserFromAuth, err := fc.authStore.GetUserByUsernameOrEmail(context.Background(), identifier)
+			followeeUserFromAuth, err := fc.authStore.GetUserByUsernameOrEmail(c.Request.Context(), identifier)
 			if err != nil {
 				fc.logger.WithFields(logrus.Fields{"error": err, "followerUserID": followerUserModel.ID, "identifier": identifier}).Error("Followee User Not Found")
 				c.JSON(http.StatusNotFound, models.UnfollowUserErrorResponse{
 					Message: "Unfollow User Failed",
 					Error:   "followee user not found",
+					Code:    helpers.CodeNotFound,
 				})
 				return
 			}
@@ -201,33 +264,121 @@ func (fc *FollowController) UnfollowUser(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.UnfollowUserErrorResponse{
 			Message: "Invalid Request",
 			Error:   "cannot unfollow yourself",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
 
-	err = fc.followStore.UnfollowUser(context.Background(), followerUserModel.ID, followeeUserID)
+	err = fc.followStore.UnfollowUser(c.Request.Context(), followerUserModel.ID, followeeUserID)
 	if err != nil {
 		if errors.Is(err, stores.ErrNotFollowing) {
 			fc.logger.WithFields(logrus.Fields{"error": err, "followerUserID": followerUserModel.ID, "followeeUserID": followeeUserID}).Error("Not Following User")
 			c.JSON(http.StatusNotFound, models.UnfollowUserErrorResponse{
 				Message: "Unfollow User Failed",
 				Error:   "not following user",
+				Code:    helpers.ErrorCode(err),
 			})
 		} else {
 			fc.logger.WithFields(logrus.Fields{"error": err, "followerUserID": followerUserModel.ID, "followeeUserID": followeeUserID}).Error("Failed to Unfollow User")
 			c.JSON(http.StatusInternalServerError, models.UnfollowUserErrorResponse{
 				Message: "Failed to Unfollow User",
 				Error:   "failed to unfollow user in database",
+				Code:    helpers.CodeInternal,
 			})
 		}
 		return
 	}
+	fc.invalidateSuggestions(c, followerUserModel.ID)
+	fc.invalidateHomeFeed(c, followerUserModel.ID)
 
 	c.JSON(http.StatusOK, models.UnfollowUserSuccessResponse{
 		Message: "User Unfollowed Successfully",
 	})
 }
 
+// RemoveFollower godoc
+// @Summary      Remove a follower
+// @Description  Allows a logged-in user to remove a user who is following them.
+// @Tags         user_follow
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        identifier path string true "User Identifier (username, email, or user ID) of the follower"
+// @Success      200 {object} models.RemoveFollowerSuccessResponse "Successfully removed follower"
+// @Failure      400 {object} models.RemoveFollowerErrorResponse "Bad Request - Invalid input"
+// @Failure      401 {object} models.RemoveFollowerErrorResponse "Unauthorized - User not logged in or invalid token"
+// @Failure      403 {object} models.RemoveFollowerErrorResponse "Forbidden - User account is inactive or banned"
+// @Failure      404 {object} models.RemoveFollowerErrorResponse "Not Found - Follower user not found or not following"
+// @Failure      500 {object} models.RemoveFollowerErrorResponse "Internal Server Error - Failed to remove follower"
+// @Router       /user/followers/{identifier} [delete]
+func (fc *FollowController) RemoveFollower(c *gin.Context) {
+	followeeUserModel := helpers.RequireUser(c)
+
+	identifier := c.Param("identifier")
+	if identifier == "" {
+		fc.logger.WithFields(logrus.Fields{"followeeUserID": followeeUserModel.ID}).Error("Follower Identifier is required")
+		c.JSON(http.StatusBadRequest, models.RemoveFollowerErrorResponse{
+			Message: "Invalid Request",
+			Error:   "follower identifier is required in path",
+			Code:    helpers.CodeBadRequest,
+		})
+		return
+	}
+
+	var followerUserID uuid.UUID
+	parsedUUID, err := uuid.Parse(identifier)
+	if err == nil {
+		followerUserID = parsedUUID
+	} else {
+		followerUser, err := fc.authStore.GetUserByUsernameOrEmail(c.Request.Context(), identifier)
+		if err != nil {
+			fc.logger.WithFields(logrus.Fields{"error": err, "followeeUserID": followeeUserModel.ID, "identifier": identifier}).Error("Follower User Not Found")
+			c.JSON(http.StatusNotFound, models.RemoveFollowerErrorResponse{
+				Message: "Remove Follower Failed",
+				Error:   "follower user not found",
+				Code:    helpers.ErrorCode(stores.ErrUserNotFound),
+			})
+			return
+		}
+		followerUserID = followerUser.ID
+	}
+
+	if followerUserID == followeeUserModel.ID {
+		fc.logger.WithFields(logrus.Fields{"followeeUserID": followeeUserModel.ID, "followerUserID": followerUserID}).Error("Cannot remove yourself as follower")
+		c.JSON(http.StatusBadRequest, models.RemoveFollowerErrorResponse{
+			Message: "Invalid Request",
+			Error:   "cannot remove yourself as follower",
+			Code:    helpers.CodeBadRequest,
+		})
+		return
+	}
+
+	err = fc.followStore.RemoveFollower(c.Request.Context(), followeeUserModel.ID, followerUserID)
+	if err != nil {
+		if errors.Is(err, stores.ErrNotFollowing) {
+			fc.logger.WithFields(logrus.Fields{"error": err, "followeeUserID": followeeUserModel.ID, "followerUserID": followerUserID}).Error("User Not Following")
+			c.JSON(http.StatusNotFound, models.RemoveFollowerErrorResponse{
+				Message: "Remove Follower Failed",
+				Error:   "user is not following you",
+				Code:    helpers.ErrorCode(err),
+			})
+		} else {
+			fc.logger.WithFields(logrus.Fields{"error": err, "followeeUserID": followeeUserModel.ID, "followerUserID": followerUserID}).Error("Failed to Remove Follower")
+			c.JSON(http.StatusInternalServerError, models.RemoveFollowerErrorResponse{
+				Message: "Failed to Remove Follower",
+				Error:   "failed to remove follower in database",
+				Code:    helpers.CodeInternal,
+			})
+		}
+		return
+	}
+	fc.invalidateHomeFeed(c, followerUserID)
+
+	c.JSON(http.StatusOK, models.RemoveFollowerSuccessResponse{
+		Message: "Follower Removed Successfully",
+	})
+}
+
 // GetFollowers godoc
 // @Summary      List followers of logged-in user
 // @Description  Retrieves a list of users who are following the logged-in user.
@@ -236,29 +387,23 @@ func (fc *FollowController) UnfollowUser(c *gin.Context) {
 // @Produce      json
 // @Security     BearerAuth
 // @Param        page query integer false "Page number for pagination" default(1)
+// @Param        pageSize query integer false "Number of items per page, at most 100" default(10)
 // @Success      200 {object} models.GetFollowersSuccessResponse "Successfully retrieved followers list"
 // @Failure      401 {object} models.GetFollowersErrorResponse "Unauthorized - User not logged in or invalid token"
 // @Failure      500 {object} models.GetFollowersErrorResponse "Internal Server Error - Failed to fetch followers"
 // @Router       /user/followers [get]
 func (fc *FollowController) GetFollowers(c *gin.Context) {
-	userCtx, exists := c.Get("user")
-	if !exists {
-		fc.logger.Error("User not found in context. Middleware misconfiguration.")
-		c.JSON(http.StatusUnauthorized, models.GetFollowersErrorResponse{
-			Message: "Unauthorized",
-			Error:   "user not authenticated",
-		})
-		return
-	}
-	userModel := userCtx.(*models.User)
+	userModel := helpers.RequireUser(c)
 	pageNumber := c.GetInt(middlewares.PageNumberKey)
+	pageSize := c.GetInt(middlewares.PageSizeKey)
 
-	followers, err := fc.followStore.GetFollowersByUserID(c, userModel.ID, pageNumber, middlewares.PageSize)
+	followers, err := fc.followStore.GetFollowersByUserID(c, userModel.ID, pageNumber, pageSize)
 	if err != nil {
 		fc.logger.WithFields(logrus.Fields{"error": err, "userID": userModel.ID}).Error("Failed to get followers")
 		c.JSON(http.StatusInternalServerError, models.GetFollowersErrorResponse{
 			Message: "Failed to Get Followers",
 			Error:   "could not retrieve followers from database",
+			Code:    helpers.CodeInternal,
 		})
 		return
 	}
@@ -277,29 +422,23 @@ func (fc *FollowController) GetFollowers(c *gin.Context) {
 // @Produce      json
 // @Security     BearerAuth
 // @Param        page query integer false "Page number for pagination" default(1)
+// @Param        pageSize query integer false "Number of items per page, at most 100" default(10)
 // @Success      200 {object} models.GetFollowingSuccessResponse "Successfully retrieved following list"
 // @Failure      401 {object} models.GetFollowingErrorResponse "Unauthorized - User not logged in or invalid token"
 // @Failure      500 {object} models.GetFollowingErrorResponse "Internal Server Error - Failed to fetch following users"
 // @Router       /user/following [get]
 func (fc *FollowController) GetFollowing(c *gin.Context) {
-	userCtx, exists := c.Get("user")
-	if !exists {
-		fc.logger.Error("User not found in context. Middleware misconfiguration.")
-		c.JSON(http.StatusUnauthorized, models.GetFollowingErrorResponse{
-			Message: "Unauthorized",
-			Error:   "user not authenticated",
-		})
-		return
-	}
-	userModel := userCtx.(*models.User)
+	userModel := helpers.RequireUser(c)
 	pageNumber := c.GetInt(middlewares.PageNumberKey)
+	pageSize := c.GetInt(middlewares.PageSizeKey)
 
-	following, err := fc.followStore.GetFollowingByUserID(c, userModel.ID, pageNumber, middlewares.PageSize)
+	following, err := fc.followStore.GetFollowingByUserID(c, userModel.ID, pageNumber, pageSize)
 	if err != nil {
 		fc.logger.WithFields(logrus.Fields{"error": err, "userID": userModel.ID}).Error("Failed to get following users")
 		c.JSON(http.StatusInternalServerError, models.GetFollowingErrorResponse{
 			Message: "Failed to Get Following Users",
 			Error:   "could not retrieve following users from database",
+			Code:    helpers.CodeInternal,
 		})
 		return
 	}
@@ -319,6 +458,7 @@ func (fc *FollowController) GetFollowing(c *gin.Context) {
 // @Security     BearerAuth
 // @Param        identifier path string true "User Identifier (username, email, or user ID) of the user"
 // @Param        page query integer false "Page number for pagination" default(1)
+// @Param        pageSize query integer false "Number of items per page, at most 100" default(10)
 // @Success      200 {object} models.GetUserFollowersSuccessResponse "Successfully retrieved followers list for user"
 // @Failure      400 {object} models.GetUserFollowersErrorResponse "Bad Request - Invalid input"
 // @Failure      401 {object} models.GetUserFollowersErrorResponse "Unauthorized - User not logged in or invalid token"
@@ -332,34 +472,38 @@ func (fc *FollowController) GetUserFollowers(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.GetUserFollowersErrorResponse{
 			Message: "Invalid Request",
 			Error:   "user identifier is required in path",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
 	pageNumber := c.GetInt(middlewares.PageNumberKey)
+	pageSize := c.GetInt(middlewares.PageSizeKey)
 
 	var requestedUserID uuid.UUID
 	parsedUUID, err := uuid.Parse(identifier)
 	if err == nil {
 		requestedUserID = parsedUUID
 	} else {
-		requestedUser, err := fc.authStore.GetUserByUsernameOrEmail(context.Background(), identifier)
+		requestedUser, err := fc.authStore.GetUserByUsernameOrEmail(c.Request.Context(), identifier)
 		if err != nil {
 			fc.logger.WithFields(logrus.Fields{"error": err, "identifier": identifier}).Error("User Not Found")
 			c.JSON(http.StatusNotFound, models.GetUserFollowersErrorResponse{
 				Message: "Get User Followers Failed",
 				Error:   "user not found",
+				Code:    helpers.CodeNotFound,
 			})
 			return
 		}
 		requestedUserID = requestedUser.ID
 	}
 
-	followers, err := fc.followStore.GetFollowersByUserID(c, requestedUserID, pageNumber, middlewares.PageSize)
+	followers, err := fc.followStore.GetFollowersByUserID(c, requestedUserID, pageNumber, pageSize)
 	if err != nil {
 		fc.logger.WithFields(logrus.Fields{"error": err, "userID": requestedUserID}).Error("Failed to get followers for user")
 		c.JSON(http.StatusInternalServerError, models.GetUserFollowersErrorResponse{
 			Message: "Failed to Get User Followers",
 			Error:   "could not retrieve followers from database",
+			Code:    helpers.CodeInternal,
 		})
 		return
 	}
@@ -370,6 +514,80 @@ func (fc *FollowController) GetUserFollowers(c *gin.Context) {
 	})
 }
 
+// ListMutuals godoc
+// @Summary      List mutuals of a user by identifier
+// @Description  Retrieves the users who both follow and are followed by the user identified by identifier.
+// @Tags         user_follow
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        identifier path string true "User Identifier (username, email, or user ID) of the user"
+// @Param        page query integer false "Page number for pagination" default(1)
+// @Param        pageSize query integer false "Number of items per page, at most 100" default(10)
+// @Success      200 {object} models.ListMutualsSuccessResponse "Successfully retrieved mutuals of user"
+// @Failure      400 {object} models.ListMutualsErrorResponse "Bad Request - Invalid input"
+// @Failure      401 {object} models.ListMutualsErrorResponse "Unauthorized - User not logged in or invalid token"
+// @Failure      404 {object} models.ListMutualsErrorResponse "Not Found - User not found"
+// @Failure      500 {object} models.ListMutualsErrorResponse "Internal Server Error - Failed to fetch mutuals"
+// @Router       /user/{identifier}/mutuals [get]
+func (fc *FollowController) ListMutuals(c *gin.Context) {
+	identifier := c.Param("identifier")
+	if identifier == "" {
+		fc.logger.Error("User Identifier is required")
+		c.JSON(http.StatusBadRequest, models.ListMutualsErrorResponse{
+			Message: "Invalid Request",
+			Error:   "user identifier is required in path",
+			Code:    helpers.CodeBadRequest,
+		})
+		return
+	}
+	pageNumber := c.GetInt(middlewares.PageNumberKey)
+	pageSize := c.GetInt(middlewares.PageSizeKey)
+
+	var requestedUser *models.User
+	parsedUUID, err := uuid.Parse(identifier)
+	if err == nil {
+		requestedUser, err = fc.authStore.GetUserByID(c, parsedUUID)
+	} else {
+		requestedUser, err = fc.authStore.GetUserByUsernameOrEmail(c, identifier)
+	}
+	if err != nil {
+		if errors.Is(err, stores.ErrUserNotFound) {
+			fc.logger.WithFields(logrus.Fields{"error": err, "identifier": identifier}).Error("User Not Found")
+			c.JSON(http.StatusNotFound, models.ListMutualsErrorResponse{
+				Message: "List Mutuals Failed",
+				Error:   "user not found",
+				Code:    helpers.ErrorCode(err),
+			})
+		} else {
+			fc.logger.WithFields(logrus.Fields{"error": err, "identifier": identifier}).Error("Failed to get user from store")
+			c.JSON(http.StatusInternalServerError, models.ListMutualsErrorResponse{
+				Message: "Failed to List Mutuals",
+				Error:   "could not retrieve user from database",
+				Code:    helpers.CodeInternal,
+			})
+		}
+		return
+	}
+
+	mutuals, pagination, err := fc.followStore.ListMutuals(c, requestedUser.ID, pageNumber, pageSize)
+	if err != nil {
+		fc.logger.WithFields(logrus.Fields{"error": err, "userID": requestedUser.ID}).Error("Failed to get mutuals for user")
+		c.JSON(http.StatusInternalServerError, models.ListMutualsErrorResponse{
+			Message: "Failed to List Mutuals",
+			Error:   "could not retrieve mutuals from database",
+			Code:    helpers.CodeInternal,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ListMutualsSuccessResponse{
+		Message:    "Mutuals Retrieved Successfully",
+		Mutuals:    mutuals,
+		Pagination: pagination,
+	})
+}
+
 // GetUserFollowing godoc
 // @Summary      List users being followed by a user by identifier
 // @Description  Retrieves a list of users that the user identified by identifier is following.
@@ -379,6 +597,7 @@ func (fc *FollowController) GetUserFollowers(c *gin.Context) {
 // @Security     BearerAuth
 // @Param        identifier path string true "User Identifier (username, email, or user ID) of the user"
 // @Param        page query integer false "Page number for pagination" default(1)
+// @Param        pageSize query integer false "Number of items per page, at most 100" default(10)
 // @Success      200 {object} models.GetUserFollowingSuccessResponse "Successfully retrieved following list for user"
 // @Failure      400 {object} models.GetUserFollowingErrorResponse "Bad Request - Invalid input"
 // @Failure      401 {object} models.GetUserFollowingErrorResponse "Unauthorized - User not logged in or invalid token"
@@ -392,34 +611,38 @@ func (fc *FollowController) GetUserFollowing(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.GetUserFollowingErrorResponse{
 			Message: "Invalid Request",
 			Error:   "user identifier is required in path",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
 	pageNumber := c.GetInt(middlewares.PageNumberKey)
+	pageSize := c.GetInt(middlewares.PageSizeKey)
 
 	var requestedUserID uuid.UUID
 	parsedUUID, err := uuid.Parse(identifier)
 	if err == nil {
 		requestedUserID = parsedUUID
 	} else {
-		requestedUser, err := fc.authStore.GetUserByUsernameOrEmail(context.Background(), identifier)
+		requestedUser, err := fc.authStore.GetUserByUsernameOrEmail(c.Request.Context(), identifier)
 		if err != nil {
 			fc.logger.WithFields(logrus.Fields{"error": err, "identifier": identifier}).Error("User Not Found")
 			c.JSON(http.StatusNotFound, models.GetUserFollowingErrorResponse{
 				Message: "Get User Following Failed",
 				Error:   "user not found",
+				Code:    helpers.CodeNotFound,
 			})
 			return
 		}
 		requestedUserID = requestedUser.ID
 	}
 
-	following, err := fc.followStore.GetFollowingByUserID(c, requestedUserID, pageNumber, middlewares.PageSize)
+	following, err := fc.followStore.GetFollowingByUserID(c, requestedUserID, pageNumber, pageSize)
 	if err != nil {
 		fc.logger.WithFields(logrus.Fields{"error": err, "userID": requestedUserID}).Error("Failed to get following users for user")
 		c.JSON(http.StatusInternalServerError, models.GetUserFollowingErrorResponse{
 			Message: "Failed to Get User Following Users",
 			Error:   "could not retrieve following users from database",
+			Code:    helpers.CodeInternal,
 		})
 		return
 	}
@@ -429,3 +652,254 @@ func (fc *FollowController) GetUserFollowing(c *gin.Context) {
 		Following: following,
 	})
 }
+
+// ListFollowRequests godoc
+// @Summary      List incoming follow requests
+// @Description  Retrieves the pending follow requests received by the logged-in user, newest first.
+// @Tags         user_follow
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        page query integer false "Page number for pagination" default(1)
+// @Param        pageSize query integer false "Number of items per page, at most 100" default(10)
+// @Success      200 {object} models.ListFollowRequestsSuccessResponse "Successfully retrieved follow requests"
+// @Failure      401 {object} models.ListFollowRequestsErrorResponse "Unauthorized - User not logged in or invalid token"
+// @Failure      500 {object} models.ListFollowRequestsErrorResponse "Internal Server Error - Failed to fetch follow requests"
+// @Router       /user/follow-requests [get]
+func (fc *FollowController) ListFollowRequests(c *gin.Context) {
+	userModel := helpers.RequireUser(c)
+	pageNumber := c.GetInt(middlewares.PageNumberKey)
+	pageSize := c.GetInt(middlewares.PageSizeKey)
+
+	followRequests, err := fc.followStore.ListFollowRequests(c, userModel.ID, pageNumber, pageSize)
+	if err != nil {
+		fc.logger.WithFields(logrus.Fields{"error": err, "userID": userModel.ID}).Error("Failed to get follow requests")
+		c.JSON(http.StatusInternalServerError, models.ListFollowRequestsErrorResponse{
+			Message: "Failed to Get Follow Requests",
+			Error:   "could not retrieve follow requests from database",
+			Code:    helpers.CodeInternal,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ListFollowRequestsSuccessResponse{
+		Message:        "Follow Requests Retrieved Successfully",
+		FollowRequests: followRequests,
+	})
+}
+
+// AcceptFollowRequest godoc
+// @Summary      Accept a follow request
+// @Description  Accepts a pending follow request received by the logged-in user, making the requester a follower.
+// @Tags         user_follow
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        requestID path string true "Follow Request ID"
+// @Success      200 {object} models.AcceptFollowRequestSuccessResponse "Successfully accepted follow request"
+// @Failure      400 {object} models.AcceptFollowRequestErrorResponse "Bad Request - Invalid follow request ID"
+// @Failure      401 {object} models.AcceptFollowRequestErrorResponse "Unauthorized - User not logged in or invalid token"
+// @Failure      404 {object} models.AcceptFollowRequestErrorResponse "Not Found - Follow request not found"
+// @Failure      500 {object} models.AcceptFollowRequestErrorResponse "Internal Server Error - Failed to accept follow request"
+// @Router       /user/follow-requests/{requestID}/accept [post]
+func (fc *FollowController) AcceptFollowRequest(c *gin.Context) {
+	userModel := helpers.RequireUser(c)
+
+	requestIDStr := c.Param("requestID")
+	requestID, err := uuid.Parse(requestIDStr)
+	if err != nil {
+		fc.logger.WithFields(logrus.Fields{"error": err, "requestID": requestIDStr, "userID": userModel.ID}).Error("Invalid Follow Request ID format")
+		c.JSON(http.StatusBadRequest, models.AcceptFollowRequestErrorResponse{
+			Message: "Invalid Request",
+			Error:   "invalid follow request ID format",
+			Code:    helpers.CodeBadRequest,
+		})
+		return
+	}
+
+	requesterID, err := fc.followStore.AcceptFollowRequest(c, userModel.ID, requestID)
+	if err != nil {
+		if errors.Is(err, stores.ErrFollowRequestNotFound) {
+			fc.logger.WithFields(logrus.Fields{"error": err, "requestID": requestID, "userID": userModel.ID}).Error("Follow Request Not Found")
+			c.JSON(http.StatusNotFound, models.AcceptFollowRequestErrorResponse{
+				Message: "Accept Follow Request Failed",
+				Error:   err.Error(),
+				Code:    helpers.ErrorCode(err),
+			})
+		} else {
+			fc.logger.WithFields(logrus.Fields{"error": err, "requestID": requestID, "userID": userModel.ID}).Error("Failed to Accept Follow Request")
+			c.JSON(http.StatusInternalServerError, models.AcceptFollowRequestErrorResponse{
+				Message: "Failed to Accept Follow Request",
+				Error:   "failed to accept follow request in database",
+				Code:    helpers.CodeInternal,
+			})
+		}
+		return
+	}
+	fc.invalidateHomeFeed(c, requesterID)
+
+	c.JSON(http.StatusOK, models.AcceptFollowRequestSuccessResponse{
+		Message: "Follow Request Accepted Successfully",
+	})
+}
+
+// RejectFollowRequest godoc
+// @Summary      Reject a follow request
+// @Description  Rejects a pending follow request received by the logged-in user.
+// @Tags         user_follow
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        requestID path string true "Follow Request ID"
+// @Success      200 {object} models.RejectFollowRequestSuccessResponse "Successfully rejected follow request"
+// @Failure      400 {object} models.RejectFollowRequestErrorResponse "Bad Request - Invalid follow request ID"
+// @Failure      401 {object} models.RejectFollowRequestErrorResponse "Unauthorized - User not logged in or invalid token"
+// @Failure      404 {object} models.RejectFollowRequestErrorResponse "Not Found - Follow request not found"
+// @Failure      500 {object} models.RejectFollowRequestErrorResponse "Internal Server Error - Failed to reject follow request"
+// @Router       /user/follow-requests/{requestID}/reject [post]
+func (fc *FollowController) RejectFollowRequest(c *gin.Context) {
+	userModel := helpers.RequireUser(c)
+
+	requestIDStr := c.Param("requestID")
+	requestID, err := uuid.Parse(requestIDStr)
+	if err != nil {
+		fc.logger.WithFields(logrus.Fields{"error": err, "requestID": requestIDStr, "userID": userModel.ID}).Error("Invalid Follow Request ID format")
+		c.JSON(http.StatusBadRequest, models.RejectFollowRequestErrorResponse{
+			Message: "Invalid Request",
+			Error:   "invalid follow request ID format",
+			Code:    helpers.CodeBadRequest,
+		})
+		return
+	}
+
+	err = fc.followStore.RejectFollowRequest(c, userModel.ID, requestID)
+	if err != nil {
+		if errors.Is(err, stores.ErrFollowRequestNotFound) {
+			fc.logger.WithFields(logrus.Fields{"error": err, "requestID": requestID, "userID": userModel.ID}).Error("Follow Request Not Found")
+			c.JSON(http.StatusNotFound, models.RejectFollowRequestErrorResponse{
+				Message: "Reject Follow Request Failed",
+				Error:   err.Error(),
+				Code:    helpers.ErrorCode(err),
+			})
+		} else {
+			fc.logger.WithFields(logrus.Fields{"error": err, "requestID": requestID, "userID": userModel.ID}).Error("Failed to Reject Follow Request")
+			c.JSON(http.StatusInternalServerError, models.RejectFollowRequestErrorResponse{
+				Message: "Failed to Reject Follow Request",
+				Error:   "failed to reject follow request in database",
+				Code:    helpers.CodeInternal,
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, models.RejectFollowRequestSuccessResponse{
+		Message: "Follow Request Rejected Successfully",
+	})
+}
+
+// GetFollowStatus godoc
+// @Summary      Check follow status of several users
+// @Description  Reports for up to 100 users identified by username, email, or user ID whether the logged-in user follows them. Unknown identifiers are reported as not followed.
+// @Tags         user_follow
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        body body models.FollowStatusPayload true "Request Body with User Identifiers"
+// @Success      200 {object} models.FollowStatusSuccessResponse "Successfully retrieved follow status"
+// @Failure      400 {object} models.FollowStatusErrorResponse "Bad Request - Invalid input"
+// @Failure      401 {object} models.FollowStatusErrorResponse "Unauthorized - User not logged in or invalid token"
+// @Failure      500 {object} models.FollowStatusErrorResponse "Internal Server Error - Failed to get follow status"
+// @Router       /user/follow-status [post]
+func (fc *FollowController) GetFollowStatus(c *gin.Context) {
+	userModel := helpers.RequireUser(c)
+
+	var req models.FollowStatusPayload
+	if err := c.ShouldBindJSON(&req); err != nil {
+		fc.logger.WithFields(logrus.Fields{"error": err, "userID": userModel.ID}).Error("Invalid Request Body for Follow Status")
+		c.JSON(http.StatusBadRequest, models.FollowStatusErrorResponse{
+			Message: "Invalid Request Body",
+			Error:   err.Error(),
+			Code:    helpers.CodeValidationFailed,
+		})
+		return
+	}
+
+	following, err := fc.followStore.FollowingStatusBatch(c, userModel.ID, req.Identifiers)
+	if err != nil {
+		fc.logger.WithFields(logrus.Fields{"error": err, "userID": userModel.ID}).Error("Failed to Get Follow Status")
+		c.JSON(http.StatusInternalServerError, models.FollowStatusErrorResponse{
+			Message: "Failed to Get Follow Status",
+			Error:   "failed to get follow status from database",
+			Code:    helpers.CodeInternal,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.FollowStatusSuccessResponse{
+		Message:   "Follow Status Retrieved Successfully",
+		Following: following,
+	})
+}
+
+// SuggestUsers godoc
+// @Summary      Suggest users to follow
+// @Description  Retrieves users the logged-in user does not follow yet, ranked by the number of people they follow who follow them, then by recent activity. Blocked users are excluded. Suggestions are cached for a few minutes.
+// @Tags         user_follow
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        page query integer false "Page number for pagination" default(1)
+// @Param        pageSize query integer false "Number of items per page, at most 100" default(10)
+// @Success      200 {object} models.SuggestUsersSuccessResponse "Successfully retrieved user suggestions"
+// @Failure      401 {object} models.SuggestUsersErrorResponse "Unauthorized - User not logged in or invalid token"
+// @Failure      500 {object} models.SuggestUsersErrorResponse "Internal Server Error - Failed to fetch user suggestions"
+// @Router       /user/suggestions [get]
+func (fc *FollowController) SuggestUsers(c *gin.Context) {
+	userModel := helpers.RequireUser(c)
+	pageNumber := c.GetInt(middlewares.PageNumberKey)
+	pageSize := c.GetInt(middlewares.PageSizeKey)
+
+	cacheKey := userSuggestionsKeyPrefix + userModel.ID.String()
+	cacheField := strconv.Itoa(pageNumber) + ":" + strconv.Itoa(pageSize)
+
+	cached, err := fc.redisClient.HGet(c, cacheKey, cacheField).Bytes()
+	if err == nil {
+		var suggestions []*models.UserSuggestion
+		if err := json.Unmarshal(cached, &suggestions); err == nil {
+			c.JSON(http.StatusOK, models.SuggestUsersSuccessResponse{
+				Message:     "User Suggestions Retrieved Successfully",
+				Suggestions: suggestions,
+			})
+			return
+		}
+		fc.logger.WithFields(logrus.Fields{"error": err, "userID": userModel.ID}).Warn("Failed to Decode Cached User Suggestions")
+	} else if !errors.Is(err, redis.Nil) {
+		fc.logger.WithFields(logrus.Fields{"error": err, "userID": userModel.ID}).Warn("Failed to Get Cached User Suggestions")
+	}
+
+	suggestions, err := fc.followStore.SuggestUsers(c, userModel.ID, pageNumber, pageSize)
+	if err != nil {
+		fc.logger.WithFields(logrus.Fields{"error": err, "userID": userModel.ID}).Error("Failed to get user suggestions")
+		c.JSON(http.StatusInternalServerError, models.SuggestUsersErrorResponse{
+			Message: "Failed to Get User Suggestions",
+			Error:   "could not retrieve user suggestions from database",
+			Code:    helpers.CodeInternal,
+		})
+		return
+	}
+
+	if encoded, err := json.Marshal(suggestions); err == nil {
+		// The TTL is only set with the first page, so every cached page expires together.
+		pipe := fc.redisClient.TxPipeline()
+		pipe.HSet(c, cacheKey, cacheField, encoded)
+		pipe.ExpireNX(c, cacheKey, userSuggestionsCacheTTL)
+		if _, err := pipe.Exec(c); err != nil {
+			fc.logger.WithFields(logrus.Fields{"error": err, "userID": userModel.ID}).Warn("Failed to Cache User Suggestions")
+		}
+	}
+
+	c.JSON(http.StatusOK, models.SuggestUsersSuccessResponse{
+		Message:     "User Suggestions Retrieved Successfully",
+		Suggestions: suggestions,
+	})
+}
@@ -0,0 +1,44 @@
+package controllers
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/datarohit/gopher-social-backend/database/dbtest"
+	"github.com/datarohit/gopher-social-backend/helpers"
+	"github.com/datarohit/gopher-social-backend/stores"
+)
+
+// TestFollowInactiveUserForbidden checks that following a banned or inactive user by ID or username is rejected
+// with 403 and no follow edge, while an active user can still be followed.
+func TestFollowInactiveUserForbidden(t *testing.T) {
+	pool := dbtest.NewPool(t)
+	_, client := newTestRedis(t)
+
+	followerID := dbtest.CreateUser(t, pool, "follower", 1)
+	bannedID := dbtest.CreateUser(t, pool, "banned", 1)
+	inactiveID := dbtest.CreateUser(t, pool, "inactive", 1)
+	activeID := dbtest.CreateUser(t, pool, "active", 1)
+	dbtest.Exec(t, pool, `UPDATE users SET banned = TRUE, is_active = FALSE WHERE id = $1`, bannedID)
+	dbtest.Exec(t, pool, `UPDATE users SET is_active = FALSE WHERE id = $1`, inactiveID)
+
+	fc := NewFollowController(stores.NewAuthStore(pool), stores.NewProfileStore(pool), stores.NewFollowStore(pool), stores.NewNotificationStore(pool), stores.NewFeedStore(pool, client), client, newTestLogger())
+	router := newTestRouter(loadUser(t, pool, followerID))
+	router.POST("/user/follow/:identifier", fc.FollowUser)
+
+	for _, identifier := range []string{bannedID.String(), "banned", inactiveID.String(), "inactive"} {
+		t.Run(identifier, func(t *testing.T) {
+			recorder := serve(router, http.MethodPost, "/user/follow/"+identifier, "")
+			assertStatus(t, recorder, http.StatusForbidden)
+			assertCode(t, recorder, helpers.ErrorCode(stores.ErrCannotFollowInactiveUser))
+		})
+	}
+	if count := dbtest.Count(t, pool, `SELECT COUNT(*) FROM follows WHERE follower_id = $1`, followerID); count != 0 {
+		t.Fatalf("%d follows created to banned or inactive users, want 0", count)
+	}
+
+	assertStatus(t, serve(router, http.MethodPost, "/user/follow/"+activeID.String(), ""), http.StatusOK)
+	if count := dbtest.Count(t, pool, `SELECT COUNT(*) FROM follows WHERE follower_id = $1 AND followee_id = $2`, followerID, activeID); count != 1 {
+		t.Fatal("follow of an active user was not created")
+	}
+}
@@ -4,7 +4,9 @@ import (
 	"net/http"
 
 	"github.com/datarohit/gopher-social-backend/database"
+	"github.com/datarohit/gopher-social-backend/helpers"
 	"github.com/datarohit/gopher-social-backend/models"
+	"github.com/datarohit/gopher-social-backend/stores"
 	"github.com/gin-gonic/gin"
 )
 
@@ -80,3 +82,53 @@ func (hc *HealthController) HealthPostgres(c *gin.Context) {
 		})
 	}
 }
+
+// HealthInfo godoc
+// @Summary      Health Details and Build Info
+// @Description  Returns the version, commit and build time of the running build, its uptime and Go version, the health of Postgres and Redis, the hits and misses of the user cache, a histogram of the durations of traced database queries and the connection pool usage, where a growing empty_acquire_count means requests wait for a free connection
+// @Tags         health
+// @Produce      json
+// @Success      200 {object} models.HealthInfoResponse "Successfully retrieved health details"
+// @Router       /health/info [get]
+func (hc *HealthController) HealthInfo(c *gin.Context) {
+	dependencies := map[string]string{
+		"postgres": "Healthy!",
+		"redis":    "Healthy!",
+	}
+	status := "Healthy!"
+
+	if database.PostgresDB == nil || database.PostgresDB.Ping(c) != nil {
+		dependencies["postgres"] = "Unhealthy!"
+		status = "Degraded!"
+	}
+	if database.RedisClient == nil || database.RedisClient.Ping(c).Err() != nil {
+		dependencies["redis"] = "Unhealthy!"
+		status = "Degraded!"
+	}
+
+	userCacheHits, userCacheMisses := stores.UserCacheStats()
+
+	var databasePool *models.DatabasePoolStats
+	if database.PostgresDB != nil {
+		poolStat := database.PostgresDB.Stat()
+		databasePool = &models.DatabasePoolStats{
+			TotalConns:              poolStat.TotalConns(),
+			AcquiredConns:           poolStat.AcquiredConns(),
+			IdleConns:               poolStat.IdleConns(),
+			MaxConns:                poolStat.MaxConns(),
+			EmptyAcquireCount:       poolStat.EmptyAcquireCount(),
+			CanceledAcquireCount:    poolStat.CanceledAcquireCount(),
+			AcquireWaitMilliseconds: poolStat.AcquireDuration().Milliseconds(),
+		}
+	}
+
+	c.JSON(http.StatusOK, models.HealthInfoResponse{
+		Status:        status,
+		Build:         helpers.GetBuildInfo(),
+		UptimeSeconds: int64(helpers.Uptime().Seconds()),
+		Dependencies:  dependencies,
+		UserCache:     models.UserCacheStats{Hits: userCacheHits, Misses: userCacheMisses},
+		Queries:       stores.QueryDurationStats(),
+		DatabasePool:  databasePool,
+	})
+}
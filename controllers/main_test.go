@@ -0,0 +1,144 @@
+package controllers
+
+import (
+	"context"
+	"io"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/datarohit/gopher-social-backend/helpers"
+	"github.com/datarohit/gopher-social-backend/models"
+	"github.com/datarohit/gopher-social-backend/stores"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+)
+
+func TestMain(m *testing.M) {
+	gin.SetMode(gin.TestMode)
+	os.Exit(m.Run())
+}
+
+// newTestLogger returns a logger discarding its output.
+func newTestLogger() *logrus.Logger {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	return logger
+}
+
+// newTestRedis starts an in-memory Redis server for the test and returns it with a client connected to it.
+func newTestRedis(t *testing.T) (*miniredis.Miniredis, *redis.Client) {
+	t.Helper()
+
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return server, client
+}
+
+// newTestWebhookDispatcher returns a webhook dispatcher reading webhooks from the pool, shut down when the test ends.
+// It must be created after the pool so that it is shut down before the pool is closed.
+func newTestWebhookDispatcher(t *testing.T, pool *pgxpool.Pool) *helpers.WebhookDispatcher {
+	t.Helper()
+
+	dispatcher := helpers.NewWebhookDispatcher(stores.NewWebhookStore(pool), newTestLogger())
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = dispatcher.Shutdown(ctx)
+	})
+	return dispatcher
+}
+
+// sentEmail is an email delivered through a recordingMailer.
+type sentEmail struct {
+	to      string
+	subject string
+	body    string
+}
+
+// recordingMailer records the emails it is asked to send instead of delivering them.
+type recordingMailer struct {
+	mu   sync.Mutex
+	sent []sentEmail
+}
+
+func (rm *recordingMailer) Send(to string, subject string, body string) error {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	rm.sent = append(rm.sent, sentEmail{to: to, subject: subject, body: body})
+	return nil
+}
+
+// emails returns the emails sent so far.
+func (rm *recordingMailer) emails() []sentEmail {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	return append([]sentEmail(nil), rm.sent...)
+}
+
+// loadUser reads a user the way the auth middleware does, so it can be put in the context of test requests.
+func loadUser(t *testing.T, pool *pgxpool.Pool, userID uuid.UUID) *models.User {
+	t.Helper()
+
+	user, err := stores.NewAuthStore(pool).GetUserByID(context.Background(), userID)
+	if err != nil {
+		t.Fatalf("failed to load user %s: %v", userID, err)
+	}
+	return user
+}
+
+// newTestRouter returns a router that authenticates every request as user, like AuthMiddleware does,
+// and marks moderators and admins as moderator viewers. A nil user leaves requests unauthenticated.
+func newTestRouter(user *models.User) *gin.Engine {
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		if user != nil {
+			c.Set("user", user)
+			if user.Role != nil && user.Role.Level >= 2 {
+				c.Request = c.Request.WithContext(stores.WithModeratorViewer(c.Request.Context()))
+			}
+		}
+		c.Next()
+	})
+	return router
+}
+
+// serve sends a request with an optional JSON body to the router and returns the recorded response.
+func serve(router *gin.Engine, method string, path string, body string) *httptest.ResponseRecorder {
+	var reader io.Reader
+	if body != "" {
+		reader = strings.NewReader(body)
+	}
+	req := httptest.NewRequest(method, path, reader)
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	return recorder
+}
+
+// assertStatus fails the test if the response does not have the wanted status.
+func assertStatus(t *testing.T, recorder *httptest.ResponseRecorder, want int) {
+	t.Helper()
+	if recorder.Code != want {
+		t.Fatalf("status = %d, want %d, body %s", recorder.Code, want, recorder.Body.String())
+	}
+}
+
+// assertCode fails the test if the JSON response does not carry the wanted error code.
+func assertCode(t *testing.T, recorder *httptest.ResponseRecorder, want string) {
+	t.Helper()
+	if !strings.Contains(recorder.Body.String(), `"code":"`+want+`"`) {
+		t.Fatalf("body = %s, want code %s", recorder.Body.String(), want)
+	}
+}
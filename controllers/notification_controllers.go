@@ -0,0 +1,142 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/datarohit/gopher-social-backend/helpers"
+	"github.com/datarohit/gopher-social-backend/middlewares"
+	"github.com/datarohit/gopher-social-backend/models"
+	"github.com/datarohit/gopher-social-backend/stores"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+type NotificationController struct {
+	notificationStore *stores.NotificationStore
+	logger            *logrus.Logger
+}
+
+// NewNotificationController creates a new NotificationController.
+//
+// Parameters:
+//   - notificationStore (*stores.NotificationStore): NotificationStore pointer to interact with the database.
+//   - logger (*logrus.Logger): Logrus logger pointer to log messages.
+//
+// Returns:
+//   - *NotificationController: Pointer to the NotificationController.
+func NewNotificationController(notificationStore *stores.NotificationStore, logger *logrus.Logger) *NotificationController {
+	return &NotificationController{
+		notificationStore: notificationStore,
+		logger:            logger,
+	}
+}
+
+// ListNotifications godoc
+// @Summary      List notifications
+// @Description  Retrieves the notifications of the logged-in user, newest first.
+// @Tags         notifications
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        page query integer false "Page number for pagination" default(1)
+// @Param        pageSize query integer false "Number of items per page, at most 100" default(10)
+// @Success      200 {object} models.ListNotificationsSuccessResponse "Successfully retrieved notifications"
+// @Failure      401 {object} models.ListNotificationsErrorResponse "Unauthorized - User not logged in or invalid token"
+// @Failure      500 {object} models.ListNotificationsErrorResponse "Internal Server Error - Failed to fetch notifications"
+// @Router       /notifications [get]
+func (nc *NotificationController) ListNotifications(c *gin.Context) {
+	userModel := helpers.RequireUser(c)
+	pageNumber := c.GetInt(middlewares.PageNumberKey)
+	pageSize := c.GetInt(middlewares.PageSizeKey)
+
+	notifications, err := nc.notificationStore.ListNotifications(c, userModel.ID, pageNumber, pageSize)
+	if err != nil {
+		nc.logger.WithFields(logrus.Fields{"error": err, "userID": userModel.ID}).Error("Failed to get notifications")
+		c.JSON(http.StatusInternalServerError, models.ListNotificationsErrorResponse{
+			Message: "Failed to Get Notifications",
+			Error:   "could not retrieve notifications from database",
+			Code:    helpers.CodeInternal,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ListNotificationsSuccessResponse{
+		Message:       "Notifications Retrieved Successfully",
+		Notifications: notifications,
+	})
+}
+
+// GetUnreadCount godoc
+// @Summary      Get unread notifications count
+// @Description  Retrieves the number of unread notifications of the logged-in user. Cheap enough to poll for badge counts.
+// @Tags         notifications
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} models.GetUnreadNotificationsCountSuccessResponse "Successfully retrieved unread notifications count"
+// @Failure      401 {object} models.GetUnreadNotificationsCountErrorResponse "Unauthorized - User not logged in or invalid token"
+// @Failure      500 {object} models.GetUnreadNotificationsCountErrorResponse "Internal Server Error - Failed to count unread notifications"
+// @Router       /notifications/unread-count [get]
+func (nc *NotificationController) GetUnreadCount(c *gin.Context) {
+	userModel := helpers.RequireUser(c)
+
+	count, err := nc.notificationStore.CountUnread(c, userModel.ID)
+	if err != nil {
+		nc.logger.WithFields(logrus.Fields{"error": err, "userID": userModel.ID}).Error("Failed to count unread notifications")
+		c.JSON(http.StatusInternalServerError, models.GetUnreadNotificationsCountErrorResponse{
+			Message: "Failed to Get Unread Notifications Count",
+			Error:   "could not count unread notifications in database",
+			Code:    helpers.CodeInternal,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.GetUnreadNotificationsCountSuccessResponse{
+		Message: "Unread Notifications Count Retrieved Successfully",
+		Count:   count,
+	})
+}
+
+// MarkRead godoc
+// @Summary      Mark notifications as read
+// @Description  Marks the given notifications of the logged-in user as read. IDs of other users' notifications are ignored. At most 100 IDs per request.
+// @Tags         notifications
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        body body models.MarkNotificationsReadPayload true "Request Body with Notification IDs"
+// @Success      200 {object} models.MarkNotificationsReadSuccessResponse "Successfully marked notifications as read"
+// @Failure      400 {object} models.MarkNotificationsReadErrorResponse "Bad Request - Invalid input"
+// @Failure      401 {object} models.MarkNotificationsReadErrorResponse "Unauthorized - User not logged in or invalid token"
+// @Failure      500 {object} models.MarkNotificationsReadErrorResponse "Internal Server Error - Failed to mark notifications as read"
+// @Router       /notifications/read [post]
+func (nc *NotificationController) MarkRead(c *gin.Context) {
+	userModel := helpers.RequireUser(c)
+
+	var req models.MarkNotificationsReadPayload
+	if err := c.ShouldBindJSON(&req); err != nil {
+		nc.logger.WithFields(logrus.Fields{"error": err, "userID": userModel.ID}).Error("Invalid request body for marking notifications as read")
+		c.JSON(http.StatusBadRequest, models.MarkNotificationsReadErrorResponse{
+			Message: "Invalid Request Body",
+			Error:   err.Error(),
+			Code:    helpers.CodeValidationFailed,
+		})
+		return
+	}
+
+	unreadCount, err := nc.notificationStore.MarkReadByIDs(c, userModel.ID, req.IDs)
+	if err != nil {
+		nc.logger.WithFields(logrus.Fields{"error": err, "userID": userModel.ID}).Error("Failed to mark notifications as read")
+		c.JSON(http.StatusInternalServerError, models.MarkNotificationsReadErrorResponse{
+			Message: "Failed to Mark Notifications as Read",
+			Error:   "could not mark notifications as read in database",
+			Code:    helpers.CodeInternal,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.MarkNotificationsReadSuccessResponse{
+		Message:     "Notifications Marked as Read Successfully",
+		UnreadCount: unreadCount,
+	})
+}
@@ -1,9 +1,15 @@
 package controllers
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/datarohit/gopher-social-backend/helpers"
 	"github.com/datarohit/gopher-social-backend/middlewares"
 	"github.com/datarohit/gopher-social-backend/models"
 	"github.com/datarohit/gopher-social-backend/stores"
@@ -13,31 +19,78 @@ import (
 )
 
 type PostController struct {
-	postStore *stores.PostStore
-	authStore *stores.AuthStore
-	logger    *logrus.Logger
+	postStore         *stores.PostStore
+	authStore         *stores.AuthStore
+	followStore       *stores.FollowStore
+	feedStore         *stores.FeedStore
+	notificationStore *stores.NotificationStore
+	contentPolicy     *helpers.ContentPolicy
+	logger            *logrus.Logger
 }
 
+// feedFanOutTimeout bounds how long adding a new post to the cached home feeds of followers, and notifying them of it, may take.
+const feedFanOutTimeout = 10 * time.Second
+
 // NewPostController creates a new PostController.
 //
 // Parameters:
 //   - postStore (*stores.PostStore): PostStore pointer to interact with the database.
 //   - authStore (*stores.AuthStore): AuthStore pointer to interact with the database.
+//   - followStore (*stores.FollowStore): FollowStore pointer to check post visibility of private profiles.
+//   - feedStore (*stores.FeedStore): FeedStore pointer to read and update cached home feeds.
+//   - notificationStore (*stores.NotificationStore): NotificationStore pointer to notify authors of quoted posts.
+//   - contentPolicy (*helpers.ContentPolicy): ContentPolicy checking posts for disallowed words.
 //   - logger (*logrus.Logger): Logrus logger pointer to log messages.
 //
 // Returns:
 //   - *PostController: Pointer to the PostController.
-func NewPostController(postStore *stores.PostStore, authStore *stores.AuthStore, logger *logrus.Logger) *PostController {
+func NewPostController(postStore *stores.PostStore, authStore *stores.AuthStore, followStore *stores.FollowStore, feedStore *stores.FeedStore, notificationStore *stores.NotificationStore, contentPolicy *helpers.ContentPolicy, logger *logrus.Logger) *PostController {
 	return &PostController{
-		postStore: postStore,
-		authStore: authStore,
-		logger:    logger,
+		postStore:         postStore,
+		authStore:         authStore,
+		followStore:       followStore,
+		feedStore:         feedStore,
+		notificationStore: notificationStore,
+		contentPolicy:     contentPolicy,
+		logger:            logger,
 	}
 }
 
+// postETag returns the weak ETag of a post. It changes whenever the post is updated or its likes, dislikes or comment count change.
+//
+// Parameters:
+//   - post (*models.Post): Post to compute the ETag for.
+//
+// Returns:
+//   - string: Weak ETag of the post.
+func postETag(post *models.Post) string {
+	return fmt.Sprintf(`W/"%s-%d-%d-%d-%d"`, post.ID, post.UpdatedAt.UnixNano(), post.Likes, post.Dislikes, post.Comments)
+}
+
+// etagMatches reports whether an If-None-Match header matches an ETag, using weak comparison.
+//
+// Parameters:
+//   - ifNoneMatch (string): Value of the If-None-Match request header.
+//   - etag (string): Current ETag of the resource.
+//
+// Returns:
+//   - bool: True if the client's cached copy is still current.
+func etagMatches(ifNoneMatch string, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
+
 // CreatePost godoc
 // @Summary      Create a new post
-// @Description  Creates a new post by a logged-in user.
+// @Description  Creates a new post by a logged-in user. A post may quote another post by setting quoted_post_id, the quoted post is embedded in responses and its author is notified. Posts of banned or private authors, and of authors blocking or blocked by the user, cannot be quoted.
 // @Tags         posts
 // @Accept       json
 // @Produce      json
@@ -46,20 +99,12 @@ func NewPostController(postStore *stores.PostStore, authStore *stores.AuthStore,
 // @Success      201 {object} models.CreatePostSuccessResponse "Successfully created post"
 // @Failure      400 {object} models.CreatePostErrorResponse "Bad Request - Invalid input"
 // @Failure      401 {object} models.CreatePostErrorResponse "Unauthorized - User not logged in or invalid token"
-// @Failure      403 {object} models.CreatePostErrorResponse "Forbidden - User account is inactive or banned"
+// @Failure      403 {object} models.CreatePostErrorResponse "Forbidden - User account is inactive or banned, or the quoted post cannot be quoted"
+// @Failure      404 {object} models.CreatePostErrorResponse "Not Found - Quoted post not found"
 // @Failure      500 {object} models.CreatePostErrorResponse "Internal Server Error - Failed to create post"
 // @Router       /post/create [post]
 func (pc *PostController) CreatePost(c *gin.Context) {
-	user, exists := c.Get("user")
-	if !exists {
-		pc.logger.Error("User not found in context. Middleware misconfiguration.")
-		c.JSON(http.StatusUnauthorized, models.CreatePostErrorResponse{
-			Message: "Unauthorized",
-			Error:   "user not authenticated",
-		})
-		return
-	}
-	userModel := user.(*models.User)
+	userModel := helpers.RequireUser(c)
 
 	var req models.CreatePostPayload
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -67,28 +112,90 @@ func (pc *PostController) CreatePost(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.CreatePostErrorResponse{
 			Message: "Invalid Request Body",
 			Error:   err.Error(),
+			Code:    helpers.CodeValidationFailed,
+		})
+		return
+	}
+
+	req.Title = strings.TrimSpace(req.Title)
+	req.SubTitle = strings.TrimSpace(req.SubTitle)
+	req.Description = strings.TrimSpace(req.Description)
+	req.Content = strings.TrimSpace(req.Content)
+
+	if err := helpers.CheckPostLengths(req.Title, req.Content); err != nil {
+		pc.logger.WithFields(logrus.Fields{"error": err, "userID": userModel.ID}).Error("Post exceeds maximum content length")
+		c.JSON(http.StatusBadRequest, models.CreatePostErrorResponse{
+			Message: "Invalid Request Body",
+			Error:   err.Error(),
+			Code:    helpers.CodeValidationFailed,
+		})
+		return
+	}
+
+	if err := pc.contentPolicy.Check(strings.Join([]string{req.Title, req.SubTitle, req.Description, req.Content}, "\n")); err != nil {
+		pc.logger.WithFields(logrus.Fields{"userID": userModel.ID}).Warn("Post violates content policy")
+		c.JSON(http.StatusBadRequest, models.CreatePostErrorResponse{
+			Message: "Invalid Request Body",
+			Error:   err.Error(),
+			Code:    helpers.ErrorCode(err),
 		})
 		return
 	}
 
 	post := &models.Post{
 		AuthorID:    userModel.ID,
-		Title:       req.Title,
-		SubTitle:    req.SubTitle,
-		Description: req.Description,
-		Content:     req.Content,
+		Title:       helpers.SanitizeContent(req.Title),
+		SubTitle:    helpers.SanitizeContent(req.SubTitle),
+		Description: helpers.SanitizeContent(req.Description),
+		Content:     helpers.SanitizeContent(req.Content),
+	}
+	if req.QuotedPostID != "" {
+		quotedPostID, err := uuid.Parse(req.QuotedPostID)
+		if err != nil {
+			pc.logger.WithFields(logrus.Fields{"error": err, "quotedPostID": req.QuotedPostID}).Error("Invalid quoted post ID format")
+			c.JSON(http.StatusBadRequest, models.CreatePostErrorResponse{
+				Message: "Invalid Request Body",
+				Error:   "invalid quoted_post_id format",
+				Code:    helpers.CodeValidationFailed,
+			})
+			return
+		}
+		post.QuotedPostID = &quotedPostID
 	}
 
 	createdPost, err := pc.postStore.CreatePost(c, post)
 	if err != nil {
-		pc.logger.WithFields(logrus.Fields{"error": err, "userID": userModel.ID}).Error("Failed to create post in store")
-		c.JSON(http.StatusInternalServerError, models.CreatePostErrorResponse{
-			Message: "Failed to Create Post",
-			Error:   "could not save post to database",
-		})
+		if errors.Is(err, stores.ErrQuotedPostNotFound) {
+			pc.logger.WithFields(logrus.Fields{"error": err, "userID": userModel.ID, "quotedPostID": post.QuotedPostID}).Error("Quoted post not found")
+			c.JSON(http.StatusNotFound, models.CreatePostErrorResponse{
+				Message: "Quoted Post Not Found",
+				Error:   err.Error(),
+				Code:    helpers.ErrorCode(err),
+			})
+		} else if errors.Is(err, stores.ErrPostCannotBeQuoted) {
+			pc.logger.WithFields(logrus.Fields{"error": err, "userID": userModel.ID, "quotedPostID": post.QuotedPostID}).Error("Post cannot be quoted")
+			c.JSON(http.StatusForbidden, models.CreatePostErrorResponse{
+				Message: "Forbidden",
+				Error:   err.Error(),
+				Code:    helpers.ErrorCode(err),
+			})
+		} else {
+			pc.logger.WithFields(logrus.Fields{"error": err, "userID": userModel.ID}).Error("Failed to create post in store")
+			c.JSON(http.StatusInternalServerError, models.CreatePostErrorResponse{
+				Message: "Failed to Create Post",
+				Error:   "could not save post to database",
+				Code:    helpers.CodeInternal,
+			})
+		}
 		return
 	}
 
+	if createdPost.QuotedPost != nil && createdPost.QuotedPost.AuthorID != userModel.ID {
+		if err := pc.notificationStore.CreateNotification(c, createdPost.QuotedPost.AuthorID, userModel.ID, stores.NotificationTypeQuote, &createdPost.ID); err != nil {
+			pc.logger.WithFields(logrus.Fields{"error": err, "postID": createdPost.ID, "quotedPostID": createdPost.QuotedPost.ID}).Warn("Failed to Create Quote Notification")
+		}
+	}
+
 	// Author information is not needed in the response as per requirement.
 	// If you need author info, uncomment below lines and update response models accordingly.
 	/*
@@ -98,12 +205,41 @@ func (pc *PostController) CreatePost(c *gin.Context) {
 			c.JSON(http.StatusInternalServerError, models.CreatePostErrorResponse{
 				Message: "Failed to Create Post",
 				Error:   "could not fetch author details",
+				Code:    helpers.CodeInternal,
 			})
 			return
 		}
 		createdPost.Author = author
 	*/
 
+	go func(post *models.Post) {
+		ctx, cancel := context.WithTimeout(context.Background(), feedFanOutTimeout)
+		defer cancel()
+
+		if err := pc.feedStore.FanOutPost(ctx, post); err != nil {
+			pc.logger.WithFields(logrus.Fields{"error": err, "postID": post.ID, "authorID": post.AuthorID}).Warn("Failed to fan out post to home feeds")
+		}
+
+		followerIDs, err := pc.followStore.ListFollowerIDs(ctx, post.AuthorID)
+		if err != nil {
+			pc.logger.WithFields(logrus.Fields{"error": err, "postID": post.ID, "authorID": post.AuthorID}).Warn("Failed to list followers for new post notifications")
+			return
+		}
+
+		notifications := make([]*models.Notification, 0, len(followerIDs))
+		for _, followerID := range followerIDs {
+			notifications = append(notifications, &models.Notification{
+				UserID:   followerID,
+				ActorID:  &post.AuthorID,
+				Type:     stores.NotificationTypeNewPost,
+				EntityID: &post.ID,
+			})
+		}
+		if err := pc.notificationStore.CreateBatch(ctx, notifications); err != nil {
+			pc.logger.WithFields(logrus.Fields{"error": err, "postID": post.ID, "authorID": post.AuthorID, "followers": len(followerIDs)}).Warn("Failed to create new post notifications")
+		}
+	}(createdPost)
+
 	c.JSON(http.StatusCreated, models.CreatePostSuccessResponse{
 		Message: "Post Created Successfully",
 		Post:    createdPost,
@@ -127,16 +263,7 @@ func (pc *PostController) CreatePost(c *gin.Context) {
 // @Failure      500 {object} models.UpdatePostErrorResponse "Internal Server Error - Failed to update post"
 // @Router       /post/{postID} [put]
 func (pc *PostController) UpdatePost(c *gin.Context) {
-	user, exists := c.Get("user")
-	if !exists {
-		pc.logger.Error("User not found in context. Middleware misconfiguration.")
-		c.JSON(http.StatusUnauthorized, models.UpdatePostErrorResponse{
-			Message: "Unauthorized",
-			Error:   "user not authenticated",
-		})
-		return
-	}
-	userModel := user.(*models.User)
+	userModel := helpers.RequireUser(c)
 
 	postIDStr := c.Param("postID")
 	if postIDStr == "" {
@@ -144,6 +271,7 @@ func (pc *PostController) UpdatePost(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.UpdatePostErrorResponse{
 			Message: "Invalid Request",
 			Error:   "post ID is required in path",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
@@ -154,6 +282,7 @@ func (pc *PostController) UpdatePost(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.UpdatePostErrorResponse{
 			Message: "Invalid Request",
 			Error:   "invalid post ID format",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
@@ -164,6 +293,32 @@ func (pc *PostController) UpdatePost(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.UpdatePostErrorResponse{
 			Message: "Invalid Request Body",
 			Error:   err.Error(),
+			Code:    helpers.CodeValidationFailed,
+		})
+		return
+	}
+
+	req.Title = strings.TrimSpace(req.Title)
+	req.SubTitle = strings.TrimSpace(req.SubTitle)
+	req.Description = strings.TrimSpace(req.Description)
+	req.Content = strings.TrimSpace(req.Content)
+
+	if err := helpers.CheckPostLengths(req.Title, req.Content); err != nil {
+		pc.logger.WithFields(logrus.Fields{"error": err, "postID": postID, "userID": userModel.ID}).Error("Post exceeds maximum content length")
+		c.JSON(http.StatusBadRequest, models.UpdatePostErrorResponse{
+			Message: "Invalid Request Body",
+			Error:   err.Error(),
+			Code:    helpers.CodeValidationFailed,
+		})
+		return
+	}
+
+	if err := pc.contentPolicy.Check(strings.Join([]string{req.Title, req.SubTitle, req.Description, req.Content}, "\n")); err != nil {
+		pc.logger.WithFields(logrus.Fields{"postID": postID, "userID": userModel.ID}).Warn("Post violates content policy")
+		c.JSON(http.StatusBadRequest, models.UpdatePostErrorResponse{
+			Message: "Invalid Request Body",
+			Error:   err.Error(),
+			Code:    helpers.ErrorCode(err),
 		})
 		return
 	}
@@ -175,12 +330,14 @@ func (pc *PostController) UpdatePost(c *gin.Context) {
 			c.JSON(http.StatusNotFound, models.UpdatePostErrorResponse{
 				Message: "Post Not Found",
 				Error:   "post not found",
+				Code:    helpers.ErrorCode(err),
 			})
 		} else {
 			pc.logger.WithFields(logrus.Fields{"error": err, "postID": postID, "userID": userModel.ID}).Error("Failed to get post from store")
 			c.JSON(http.StatusInternalServerError, models.UpdatePostErrorResponse{
 				Message: "Failed to Update Post",
 				Error:   "could not retrieve post from database",
+				Code:    helpers.CodeInternal,
 			})
 		}
 		return
@@ -191,16 +348,17 @@ func (pc *PostController) UpdatePost(c *gin.Context) {
 		c.JSON(http.StatusForbidden, models.UpdatePostErrorResponse{
 			Message: "Forbidden",
 			Error:   "you are not the author of this post",
+			Code:    helpers.CodeForbidden,
 		})
 		return
 	}
 
 	post := &models.Post{
 		ID:          postID,
-		Title:       req.Title,
-		SubTitle:    req.SubTitle,
-		Description: req.Description,
-		Content:     req.Content,
+		Title:       helpers.SanitizeContent(req.Title),
+		SubTitle:    helpers.SanitizeContent(req.SubTitle),
+		Description: helpers.SanitizeContent(req.Description),
+		Content:     helpers.SanitizeContent(req.Content),
 	}
 
 	updatedPost, err := pc.postStore.UpdatePost(c, post)
@@ -209,6 +367,7 @@ func (pc *PostController) UpdatePost(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, models.UpdatePostErrorResponse{
 			Message: "Failed to Update Post",
 			Error:   "could not update post in database",
+			Code:    helpers.CodeInternal,
 		})
 		return
 	}
@@ -222,6 +381,7 @@ func (pc *PostController) UpdatePost(c *gin.Context) {
 			c.JSON(http.StatusInternalServerError, models.UpdatePostErrorResponse{
 				Message: "Failed to Update Post",
 				Error:   "could not fetch author details after update",
+				Code:    helpers.CodeInternal,
 			})
 			return
 		}
@@ -249,16 +409,7 @@ func (pc *PostController) UpdatePost(c *gin.Context) {
 // @Failure      500 {object} models.DeletePostErrorResponse "Internal Server Error - Failed to delete post"
 // @Router       /post/{postID} [delete]
 func (pc *PostController) DeletePost(c *gin.Context) {
-	user, exists := c.Get("user")
-	if !exists {
-		pc.logger.Error("User not found in context. Middleware misconfiguration.")
-		c.JSON(http.StatusUnauthorized, models.DeletePostErrorResponse{
-			Message: "Unauthorized",
-			Error:   "user not authenticated",
-		})
-		return
-	}
-	userModel := user.(*models.User)
+	userModel := helpers.RequireUser(c)
 
 	postIDStr := c.Param("postID")
 	if postIDStr == "" {
@@ -266,6 +417,7 @@ func (pc *PostController) DeletePost(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.DeletePostErrorResponse{
 			Message: "Invalid Request",
 			Error:   "post ID is required in path",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
@@ -276,6 +428,7 @@ func (pc *PostController) DeletePost(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.DeletePostErrorResponse{
 			Message: "Invalid Request",
 			Error:   "invalid post ID format",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
@@ -287,12 +440,14 @@ func (pc *PostController) DeletePost(c *gin.Context) {
 			c.JSON(http.StatusNotFound, models.DeletePostErrorResponse{
 				Message: "Post Not Found",
 				Error:   "post not found",
+				Code:    helpers.ErrorCode(err),
 			})
 		} else {
 			pc.logger.WithFields(logrus.Fields{"error": err, "postID": postID, "userID": userModel.ID}).Error("Failed to get post from store")
 			c.JSON(http.StatusInternalServerError, models.DeletePostErrorResponse{
 				Message: "Failed to Delete Post",
 				Error:   "could not retrieve post from database",
+				Code:    helpers.CodeInternal,
 			})
 		}
 		return
@@ -303,6 +458,7 @@ func (pc *PostController) DeletePost(c *gin.Context) {
 		c.JSON(http.StatusForbidden, models.DeletePostErrorResponse{
 			Message: "Forbidden",
 			Error:   "you are not the author of this post",
+			Code:    helpers.CodeForbidden,
 		})
 		return
 	}
@@ -313,39 +469,206 @@ func (pc *PostController) DeletePost(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, models.DeletePostErrorResponse{
 			Message: "Failed to Delete Post",
 			Error:   "could not delete post from database",
+			Code:    helpers.CodeInternal,
 		})
 		return
 	}
 
+	if err := pc.feedStore.RemovePostFromFeeds(c, existingPost.AuthorID, postID); err != nil {
+		pc.logger.WithFields(logrus.Fields{"error": err, "postID": postID, "userID": userModel.ID}).Warn("Failed to remove post from home feeds")
+	}
+
 	c.JSON(http.StatusOK, models.DeletePostSuccessResponse{
 		Message: "Post Deleted Successfully",
 	})
 }
 
+// PinPost godoc
+// @Summary      Pin a post to the profile
+// @Description  Pins a post of the logged-in user to the top of their profile. Pinning a post replaces the previously pinned post.
+// @Tags         posts
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        postID path string true "Post ID to be pinned"
+// @Success      200 {object} models.PinPostSuccessResponse "Successfully pinned post"
+// @Failure      400 {object} models.PinPostErrorResponse "Bad Request - Invalid post ID"
+// @Failure      401 {object} models.PinPostErrorResponse "Unauthorized - User not logged in or invalid token"
+// @Failure      403 {object} models.PinPostErrorResponse "Forbidden - User is not the author of the post"
+// @Failure      404 {object} models.PinPostErrorResponse "Not Found - Post not found"
+// @Failure      500 {object} models.PinPostErrorResponse "Internal Server Error - Failed to pin post"
+// @Router       /post/{postID}/pin [post]
+func (pc *PostController) PinPost(c *gin.Context) {
+	userModel := helpers.RequireUser(c)
+
+	postID, err := uuid.Parse(c.Param("postID"))
+	if err != nil {
+		pc.logger.WithFields(logrus.Fields{"error": err, "postID": c.Param("postID")}).Error("Invalid Post ID format")
+		c.JSON(http.StatusBadRequest, models.PinPostErrorResponse{
+			Message: "Invalid Request",
+			Error:   "invalid post ID format",
+			Code:    helpers.CodeBadRequest,
+		})
+		return
+	}
+
+	existingPost, err := pc.postStore.GetPostByID(c, postID)
+	if err != nil {
+		if errors.Is(err, stores.ErrPostNotFound) {
+			pc.logger.WithFields(logrus.Fields{"error": err, "postID": postID, "userID": userModel.ID}).Error("Post not found")
+			c.JSON(http.StatusNotFound, models.PinPostErrorResponse{
+				Message: "Post Not Found",
+				Error:   "post not found",
+				Code:    helpers.ErrorCode(err),
+			})
+		} else {
+			pc.logger.WithFields(logrus.Fields{"error": err, "postID": postID, "userID": userModel.ID}).Error("Failed to get post from store")
+			c.JSON(http.StatusInternalServerError, models.PinPostErrorResponse{
+				Message: "Failed to Pin Post",
+				Error:   "could not retrieve post from database",
+				Code:    helpers.CodeInternal,
+			})
+		}
+		return
+	}
+
+	if existingPost.AuthorID != userModel.ID {
+		pc.logger.WithFields(logrus.Fields{"postID": postID, "userID": userModel.ID, "authorID": existingPost.AuthorID}).Error("User is not the author of the post")
+		c.JSON(http.StatusForbidden, models.PinPostErrorResponse{
+			Message: "Forbidden",
+			Error:   "you are not the author of this post",
+			Code:    helpers.CodeForbidden,
+		})
+		return
+	}
+
+	err = pc.postStore.PinPost(c, userModel.ID, postID)
+	if err != nil {
+		if errors.Is(err, stores.ErrPostNotFound) {
+			pc.logger.WithFields(logrus.Fields{"error": err, "postID": postID, "userID": userModel.ID}).Error("Post not found")
+			c.JSON(http.StatusNotFound, models.PinPostErrorResponse{
+				Message: "Post Not Found",
+				Error:   "post not found",
+				Code:    helpers.ErrorCode(err),
+			})
+		} else {
+			pc.logger.WithFields(logrus.Fields{"error": err, "postID": postID, "userID": userModel.ID}).Error("Failed to pin post in store")
+			c.JSON(http.StatusInternalServerError, models.PinPostErrorResponse{
+				Message: "Failed to Pin Post",
+				Error:   "could not pin post in database",
+				Code:    helpers.CodeInternal,
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, models.PinPostSuccessResponse{
+		Message: "Post Pinned Successfully",
+	})
+}
+
+// UnpinPost godoc
+// @Summary      Unpin a post from the profile
+// @Description  Removes the pinned post of the logged-in user from the top of their profile.
+// @Tags         posts
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        postID path string true "Post ID to be unpinned"
+// @Success      200 {object} models.UnpinPostSuccessResponse "Successfully unpinned post"
+// @Failure      400 {object} models.UnpinPostErrorResponse "Bad Request - Invalid post ID"
+// @Failure      401 {object} models.UnpinPostErrorResponse "Unauthorized - User not logged in or invalid token"
+// @Failure      403 {object} models.UnpinPostErrorResponse "Forbidden - User is not the author of the post"
+// @Failure      404 {object} models.UnpinPostErrorResponse "Not Found - Post not found or not pinned"
+// @Failure      500 {object} models.UnpinPostErrorResponse "Internal Server Error - Failed to unpin post"
+// @Router       /post/{postID}/pin [delete]
+func (pc *PostController) UnpinPost(c *gin.Context) {
+	userModel := helpers.RequireUser(c)
+
+	postID, err := uuid.Parse(c.Param("postID"))
+	if err != nil {
+		pc.logger.WithFields(logrus.Fields{"error": err, "postID": c.Param("postID")}).Error("Invalid Post ID format")
+		c.JSON(http.StatusBadRequest, models.UnpinPostErrorResponse{
+			Message: "Invalid Request",
+			Error:   "invalid post ID format",
+			Code:    helpers.CodeBadRequest,
+		})
+		return
+	}
+
+	existingPost, err := pc.postStore.GetPostByID(c, postID)
+	if err != nil {
+		if errors.Is(err, stores.ErrPostNotFound) {
+			pc.logger.WithFields(logrus.Fields{"error": err, "postID": postID, "userID": userModel.ID}).Error("Post not found")
+			c.JSON(http.StatusNotFound, models.UnpinPostErrorResponse{
+				Message: "Post Not Found",
+				Error:   "post not found",
+				Code:    helpers.ErrorCode(err),
+			})
+		} else {
+			pc.logger.WithFields(logrus.Fields{"error": err, "postID": postID, "userID": userModel.ID}).Error("Failed to get post from store")
+			c.JSON(http.StatusInternalServerError, models.UnpinPostErrorResponse{
+				Message: "Failed to Unpin Post",
+				Error:   "could not retrieve post from database",
+				Code:    helpers.CodeInternal,
+			})
+		}
+		return
+	}
+
+	if existingPost.AuthorID != userModel.ID {
+		pc.logger.WithFields(logrus.Fields{"postID": postID, "userID": userModel.ID, "authorID": existingPost.AuthorID}).Error("User is not the author of the post")
+		c.JSON(http.StatusForbidden, models.UnpinPostErrorResponse{
+			Message: "Forbidden",
+			Error:   "you are not the author of this post",
+			Code:    helpers.CodeForbidden,
+		})
+		return
+	}
+
+	err = pc.postStore.UnpinPost(c, userModel.ID, postID)
+	if err != nil {
+		if errors.Is(err, stores.ErrPostNotPinned) {
+			pc.logger.WithFields(logrus.Fields{"error": err, "postID": postID, "userID": userModel.ID}).Error("Post is not pinned")
+			c.JSON(http.StatusNotFound, models.UnpinPostErrorResponse{
+				Message: "Unpin Post Failed",
+				Error:   err.Error(),
+				Code:    helpers.ErrorCode(err),
+			})
+		} else {
+			pc.logger.WithFields(logrus.Fields{"error": err, "postID": postID, "userID": userModel.ID}).Error("Failed to unpin post in store")
+			c.JSON(http.StatusInternalServerError, models.UnpinPostErrorResponse{
+				Message: "Failed to Unpin Post",
+				Error:   "could not unpin post in database",
+				Code:    helpers.CodeInternal,
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, models.UnpinPostSuccessResponse{
+		Message: "Post Unpinned Successfully",
+	})
+}
+
 // GetPost godoc
 // @Summary      Get a post by ID
-// @Description  Retrieves a post by its ID. Any logged-in user can access this route.
+// @Description  Retrieves a post by its ID. Any logged-in user can access this route. The response carries a weak ETag derived from the post's update time, like counts and comment count. Sending it back in If-None-Match returns 304 Not Modified while the post is unchanged.
 // @Tags         posts
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
 // @Param        postID path string true "Post ID to be retrieved"
+// @Param        If-None-Match header string false "ETag of a previously retrieved copy of the post"
 // @Success      200 {object} models.GetPostSuccessResponse "Successfully retrieved post"
+// @Success      304 "Not Modified - Post unchanged since the given ETag"
 // @Failure      400 {object} models.GetPostErrorResponse "Bad Request - Invalid input"
 // @Failure      401 {object} models.GetPostErrorResponse "Unauthorized - User not logged in or invalid token"
-// @Failure      404 {object} models.GetPostErrorResponse "Not Found - Post not found"
+// @Failure      404 {object} models.GetPostErrorResponse "Not Found - Post not found or author's profile is private"
 // @Failure      500 {object} models.GetPostErrorResponse "Internal Server Error - Failed to get post"
 // @Router       /post/{postID} [get]
 func (pc *PostController) GetPost(c *gin.Context) {
-	_, exists := c.Get("user")
-	if !exists {
-		pc.logger.Error("User not found in context. Middleware misconfiguration.")
-		c.JSON(http.StatusUnauthorized, models.GetPostErrorResponse{
-			Message: "Unauthorized",
-			Error:   "user not authenticated",
-		})
-		return
-	}
+	userModel := helpers.RequireUser(c)
 
 	postIDStr := c.Param("postID")
 	if postIDStr == "" {
@@ -353,6 +676,7 @@ func (pc *PostController) GetPost(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.GetPostErrorResponse{
 			Message: "Invalid Request",
 			Error:   "post ID is required in path",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
@@ -363,6 +687,7 @@ func (pc *PostController) GetPost(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.GetPostErrorResponse{
 			Message: "Invalid Request",
 			Error:   "invalid post ID format",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
@@ -374,17 +699,39 @@ func (pc *PostController) GetPost(c *gin.Context) {
 			c.JSON(http.StatusNotFound, models.GetPostErrorResponse{
 				Message: "Post Not Found",
 				Error:   "post not found",
+				Code:    helpers.ErrorCode(err),
 			})
 		} else {
 			pc.logger.WithFields(logrus.Fields{"error": err, "postID": postID}).Error("Failed to get post from store")
 			c.JSON(http.StatusInternalServerError, models.GetPostErrorResponse{
 				Message: "Failed to Get Post",
 				Error:   "could not retrieve post from database",
+				Code:    helpers.CodeInternal,
 			})
 		}
 		return
 	}
 
+	canView, err := pc.followStore.CanViewPosts(c, userModel.ID, retrievedPost.AuthorID)
+	if err != nil {
+		pc.logger.WithFields(logrus.Fields{"error": err, "postID": postID, "authorID": retrievedPost.AuthorID}).Error("Failed to check post visibility")
+		c.JSON(http.StatusInternalServerError, models.GetPostErrorResponse{
+			Message: "Failed to Get Post",
+			Error:   "could not check post visibility",
+			Code:    helpers.CodeInternal,
+		})
+		return
+	}
+	if !canView {
+		pc.logger.WithFields(logrus.Fields{"postID": postID, "authorID": retrievedPost.AuthorID, "userID": userModel.ID}).Error("Post of private profile not visible to user")
+		c.JSON(http.StatusNotFound, models.GetPostErrorResponse{
+			Message: "Post Not Found",
+			Error:   "post not found",
+			Code:    helpers.ErrorCode(stores.ErrPostNotFound),
+		})
+		return
+	}
+
 	// Author information is not needed in the response as per requirement.
 	// If you need author info, uncomment below lines and update response models accordingly.
 	/*
@@ -394,49 +741,302 @@ func (pc *PostController) GetPost(c *gin.Context) {
 			c.JSON(http.StatusInternalServerError, models.GetPostErrorResponse{
 				Message: "Failed to Get Post",
 				Error:   "could not fetch author details",
+				Code:    helpers.CodeInternal,
 			})
 			return
 		}
 		retrievedPost.Author = author
 	*/
 
+	etag := postETag(retrievedPost)
+	c.Header("ETag", etag)
+	c.Header("Cache-Control", "private, no-cache")
+	if etagMatches(c.GetHeader("If-None-Match"), etag) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
 	c.JSON(http.StatusOK, models.GetPostSuccessResponse{
 		Message: "Post Retrieved Successfully",
 		Post:    retrievedPost,
 	})
 }
 
+// GetPostWithComments godoc
+// @Summary      Get a post with its first page of comments
+// @Description  Retrieves a post by its ID together with a page of its comments, oldest first, in one response. Comments are paginated with the commentsPage and commentsPageSize query parameters. Posts of private profiles are only visible to approved followers.
+// @Tags         posts
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        postID path string true "Post ID to be retrieved"
+// @Param        commentsPage query int false "Page number of the comments (default: 1)"
+// @Param        commentsPageSize query int false "Number of comments per page (default: 10)"
+// @Success      200 {object} models.GetPostWithCommentsSuccessResponse "Successfully retrieved post with comments"
+// @Failure      400 {object} models.GetPostWithCommentsErrorResponse "Bad Request - Invalid post ID or comments pagination"
+// @Failure      401 {object} models.GetPostWithCommentsErrorResponse "Unauthorized - User not logged in or invalid token"
+// @Failure      404 {object} models.GetPostWithCommentsErrorResponse "Not Found - Post not found or author's profile is private"
+// @Failure      500 {object} models.GetPostWithCommentsErrorResponse "Internal Server Error - Failed to get post with comments"
+// @Router       /post/{postID}/full [get]
+func (pc *PostController) GetPostWithComments(c *gin.Context) {
+	userModel := helpers.RequireUser(c)
+
+	postID, err := uuid.Parse(c.Param("postID"))
+	if err != nil {
+		pc.logger.WithFields(logrus.Fields{"error": err, "postID": c.Param("postID")}).Error("Invalid Post ID format")
+		c.JSON(http.StatusBadRequest, models.GetPostWithCommentsErrorResponse{
+			Message: "Invalid Request",
+			Error:   "invalid post ID format",
+			Code:    helpers.CodeBadRequest,
+		})
+		return
+	}
+
+	commentsPage, err := strconv.Atoi(c.DefaultQuery("commentsPage", "1"))
+	if err != nil || commentsPage < 1 {
+		c.JSON(http.StatusBadRequest, models.GetPostWithCommentsErrorResponse{
+			Message: "Invalid Request",
+			Error:   "commentsPage must be an integer >= 1",
+			Code:    helpers.CodeBadRequest,
+		})
+		return
+	}
+
+	commentsPageSize, err := strconv.Atoi(c.DefaultQuery("commentsPageSize", strconv.Itoa(middlewares.PageSize)))
+	if err != nil || commentsPageSize < 1 || commentsPageSize > middlewares.MaxPageSize {
+		c.JSON(http.StatusBadRequest, models.GetPostWithCommentsErrorResponse{
+			Message: "Invalid Request",
+			Error:   fmt.Sprintf("commentsPageSize must be an integer between 1 and %d", middlewares.MaxPageSize),
+			Code:    helpers.CodeBadRequest,
+		})
+		return
+	}
+
+	postWithComments, err := pc.postStore.GetPostWithComments(c, postID, userModel.ID, commentsPage, commentsPageSize)
+	if err != nil {
+		if errors.Is(err, stores.ErrPostNotFound) {
+			pc.logger.WithFields(logrus.Fields{"error": err, "postID": postID, "userID": userModel.ID}).Error("Post not found or not visible to user")
+			c.JSON(http.StatusNotFound, models.GetPostWithCommentsErrorResponse{
+				Message: "Post Not Found",
+				Error:   "post not found",
+				Code:    helpers.ErrorCode(err),
+			})
+		} else {
+			pc.logger.WithFields(logrus.Fields{"error": err, "postID": postID}).Error("Failed to get post with comments from store")
+			c.JSON(http.StatusInternalServerError, models.GetPostWithCommentsErrorResponse{
+				Message: "Failed to Get Post",
+				Error:   "could not retrieve post with comments from database",
+				Code:    helpers.CodeInternal,
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, models.GetPostWithCommentsSuccessResponse{
+		Message: "Post with Comments Retrieved Successfully",
+		Post:    postWithComments,
+	})
+}
+
+// ExportPost godoc
+// @Summary      Export a post with its comments
+// @Description  Downloads a post and all its comments, oldest first, as a JSON document or as Markdown with the post under headers followed by a list of its comments. The export is streamed as it is read and named after the post title. Only the author of the post or an admin can export it.
+// @Tags         posts
+// @Produce      json
+// @Produce      text/markdown
+// @Security     BearerAuth
+// @Param        postID path string true "Post ID to be exported"
+// @Param        format query string false "Export format, json or markdown (default: json)"
+// @Success      200 {object} models.PostExport "Successfully exported post"
+// @Failure      400 {object} models.ExportPostErrorResponse "Bad Request - Invalid post ID or format"
+// @Failure      401 {object} models.ExportPostErrorResponse "Unauthorized - User not logged in or invalid token"
+// @Failure      403 {object} models.ExportPostErrorResponse "Forbidden - User is not the author of the post or an admin"
+// @Failure      404 {object} models.ExportPostErrorResponse "Not Found - Post not found"
+// @Failure      500 {object} models.ExportPostErrorResponse "Internal Server Error - Failed to export post"
+// @Router       /post/{postID}/export [get]
+func (pc *PostController) ExportPost(c *gin.Context) {
+	userModel := helpers.RequireUser(c)
+
+	postID, err := uuid.Parse(c.Param("postID"))
+	if err != nil {
+		pc.logger.WithFields(logrus.Fields{"error": err, "postID": c.Param("postID")}).Error("Invalid Post ID format")
+		c.JSON(http.StatusBadRequest, models.ExportPostErrorResponse{
+			Message: "Invalid Request",
+			Error:   "invalid post ID format",
+			Code:    helpers.CodeBadRequest,
+		})
+		return
+	}
+
+	format := strings.ToLower(c.DefaultQuery("format", stores.PostExportFormatJSON))
+	if err := helpers.ValidateEnum(format, stores.PostExportFormats); err != nil {
+		pc.logger.WithFields(logrus.Fields{"error": err, "format": format}).Error("Invalid post export format")
+		c.JSON(http.StatusBadRequest, models.ExportPostErrorResponse{
+			Message: "Invalid Request",
+			Error:   "format " + err.Error(),
+			Code:    helpers.ErrorCode(err),
+		})
+		return
+	}
+	contentType, extension, _ := stores.PostExportContentType(format)
+
+	post, err := pc.postStore.GetPostByID(c, postID)
+	if err != nil {
+		if errors.Is(err, stores.ErrPostNotFound) {
+			pc.logger.WithFields(logrus.Fields{"error": err, "postID": postID, "userID": userModel.ID}).Error("Post not found")
+			c.JSON(http.StatusNotFound, models.ExportPostErrorResponse{
+				Message: "Post Not Found",
+				Error:   "post not found",
+				Code:    helpers.ErrorCode(err),
+			})
+		} else {
+			pc.logger.WithFields(logrus.Fields{"error": err, "postID": postID, "userID": userModel.ID}).Error("Failed to get post from store")
+			c.JSON(http.StatusInternalServerError, models.ExportPostErrorResponse{
+				Message: "Failed to Export Post",
+				Error:   "could not retrieve post from database",
+				Code:    helpers.CodeInternal,
+			})
+		}
+		return
+	}
+
+	if post.AuthorID != userModel.ID && userModel.Role.Level != 3 {
+		pc.logger.WithFields(logrus.Fields{"postID": postID, "userID": userModel.ID, "authorID": post.AuthorID}).Error("User is not the author of the post or an admin")
+		c.JSON(http.StatusForbidden, models.ExportPostErrorResponse{
+			Message: "Forbidden",
+			Error:   "you are not the author of this post",
+			Code:    helpers.CodeForbidden,
+		})
+		return
+	}
+
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.%s"`, postExportFilename(post.Title), extension))
+	c.Status(http.StatusOK)
+
+	if err := pc.postStore.ExportPost(c, post, format, c.Writer); err != nil {
+		pc.logger.WithFields(logrus.Fields{"error": err, "postID": postID, "userID": userModel.ID}).Error("Failed to export post")
+		if !c.Writer.Written() {
+			c.Writer.Header().Del("Content-Disposition")
+			c.JSON(http.StatusInternalServerError, models.ExportPostErrorResponse{
+				Message: "Failed to Export Post",
+				Error:   "failed to export post",
+				Code:    helpers.CodeInternal,
+			})
+		} else {
+			// The status and part of the export are already sent, the truncated export tells the client the download failed.
+			c.Abort()
+		}
+		return
+	}
+}
+
+// postExportFilename derives the file name of a post export from the post title, keeping lower case letters
+// and digits and joining the words with dashes, so the name is safe in a Content-Disposition header.
+func postExportFilename(title string) string {
+	words := strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z') && !(r >= '0' && r <= '9')
+	})
+
+	filename := strings.Join(words, "-")
+	if len(filename) > 64 {
+		filename = strings.TrimRight(filename[:64], "-")
+	}
+	if filename == "" {
+		return "post"
+	}
+	return filename
+}
+
+// ListHomeFeed godoc
+// @Summary      Get home feed of logged-in user
+// @Description  Retrieves the newest posts of the users the logged-in user follows, together with their own posts. The most recent posts are served from a cache that is updated whenever a followed user creates or deletes a post.
+// @Tags         posts
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        page query integer false "Page number for pagination" default(1)
+// @Param        pageSize query integer false "Number of items per page, at most 100" default(10)
+// @Success      200 {object} models.ListHomeFeedSuccessResponse "Successfully retrieved home feed"
+// @Failure      400 {object} models.ListHomeFeedErrorResponse "Bad Request - Invalid page or pageSize"
+// @Failure      401 {object} models.ListHomeFeedErrorResponse "Unauthorized - User not logged in or invalid token"
+// @Failure      500 {object} models.ListHomeFeedErrorResponse "Internal Server Error - Failed to fetch home feed"
+// @Router       /post/feed [get]
+func (pc *PostController) ListHomeFeed(c *gin.Context) {
+	userModel := helpers.RequireUser(c)
+	pageNumber := c.GetInt(middlewares.PageNumberKey)
+	pageSize := c.GetInt(middlewares.PageSizeKey)
+
+	posts, err := pc.feedStore.ListHomeFeed(c, userModel.ID, pageNumber, pageSize)
+	if err != nil {
+		pc.logger.WithFields(logrus.Fields{"error": err, "userID": userModel.ID}).Error("Failed to get home feed from store")
+		c.JSON(http.StatusInternalServerError, models.ListHomeFeedErrorResponse{
+			Message: "Failed to Get Home Feed",
+			Error:   "could not retrieve home feed",
+			Code:    helpers.CodeInternal,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ListHomeFeedSuccessResponse{
+		Message: "Home Feed Retrieved Successfully",
+		Posts:   posts,
+	})
+}
+
 // ListMyPosts godoc
 // @Summary      List posts of logged-in user
-// @Description  Retrieves a list of posts created by the logged-in user.
+// @Description  Retrieves a list of posts created by the logged-in user, optionally sorted and filtered by creation time.
 // @Tags         posts
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
 // @Param        page query integer false "Page number for pagination" default(1)
+// @Param        pageSize query integer false "Number of items per page, at most 100" default(10)
+// @Param        sort query string false "Sort order" Enums(newest, oldest, most_liked, most_commented) default(newest)
+// @Param        since query string false "Only include posts created at or after this RFC3339 timestamp"
 // @Success      200 {object} models.ListMyPostsSuccessResponse "Successfully retrieved list of user's posts"
+// @Failure      400 {object} models.ListMyPostsErrorResponse "Bad Request - Invalid sort or since value"
 // @Failure      401 {object} models.ListMyPostsErrorResponse "Unauthorized - User not logged in or invalid token"
 // @Failure      500 {object} models.ListMyPostsErrorResponse "Internal Server Error - Failed to fetch user's posts"
 // @Router       /post/me [get]
 func (pc *PostController) ListMyPosts(c *gin.Context) {
-	user, exists := c.Get("user")
-	if !exists {
-		pc.logger.Error("User not found in context. Middleware misconfiguration.")
-		c.JSON(http.StatusUnauthorized, models.ListMyPostsErrorResponse{
-			Message: "Unauthorized",
-			Error:   "user not authenticated",
+	userModel := helpers.RequireUser(c)
+	pageNumber := c.GetInt(middlewares.PageNumberKey)
+	pageSize := c.GetInt(middlewares.PageSizeKey)
+	sort := c.DefaultQuery("sort", stores.PostSortNewest)
+	if err := helpers.ValidateEnum(sort, stores.PostSorts); err != nil {
+		pc.logger.WithFields(logrus.Fields{"error": err, "sort": sort, "userID": userModel.ID}).Error("Invalid sort value")
+		c.JSON(http.StatusBadRequest, models.ListMyPostsErrorResponse{
+			Message: "Invalid Request",
+			Error:   "sort " + err.Error(),
+			Code:    helpers.ErrorCode(err),
 		})
 		return
 	}
-	userModel := user.(*models.User)
-	pageNumber := c.GetInt(middlewares.PageNumberKey)
 
-	posts, err := pc.postStore.ListPostsByAuthorID(c, userModel.ID, pageNumber, middlewares.PageSize)
+	var since *time.Time
+	if sinceStr := c.Query("since"); sinceStr != "" {
+		parsedSince, err := time.Parse(time.RFC3339, sinceStr)
+		if err != nil {
+			pc.logger.WithFields(logrus.Fields{"error": err, "since": sinceStr}).Error("Invalid since value")
+			c.JSON(http.StatusBadRequest, models.ListMyPostsErrorResponse{
+				Message: "Invalid Request",
+				Error:   "since must be an RFC3339 timestamp",
+				Code:    helpers.CodeBadRequest,
+			})
+			return
+		}
+		since = &parsedSince
+	}
+
+	posts, err := pc.postStore.ListPostsByAuthorIDSorted(c, userModel.ID, sort, since, pageNumber, pageSize)
 	if err != nil {
 		pc.logger.WithFields(logrus.Fields{"error": err, "userID": userModel.ID}).Error("Failed to get posts by author ID from store")
 		c.JSON(http.StatusInternalServerError, models.ListMyPostsErrorResponse{
 			Message: "Failed to Get User Posts",
 			Error:   "could not retrieve posts from database",
+			Code:    helpers.CodeInternal,
 		})
 		return
 	}
@@ -456,22 +1056,16 @@ func (pc *PostController) ListMyPosts(c *gin.Context) {
 // @Security     BearerAuth
 // @Param        identifier path string true "User Identifier (username, email, or user ID)"
 // @Param        page query integer false "Page number for pagination" default(1)
+// @Param        pageSize query integer false "Number of items per page, at most 100" default(10)
 // @Success      200 {object} models.ListUserPostsSuccessResponse "Successfully retrieved list of user's posts"
 // @Failure      400 {object} models.ListUserPostsErrorResponse "Bad Request - Invalid input"
 // @Failure      401 {object} models.ListUserPostsErrorResponse "Unauthorized - User not logged in or invalid token"
+// @Failure      403 {object} models.ListUserPostsErrorResponse "Forbidden - Account is private and the user is not a follower"
 // @Failure      404 {object} models.ListUserPostsErrorResponse "Not Found - User not found"
 // @Failure      500 {object} models.ListUserPostsErrorResponse "Internal Server Error - Failed to fetch user's posts"
 // @Router       /post/user/{identifier} [get]
 func (pc *PostController) ListPostsByUserIdentifier(c *gin.Context) {
-	_, exists := c.Get("user")
-	if !exists {
-		pc.logger.Error("User not found in context. Middleware misconfiguration.")
-		c.JSON(http.StatusUnauthorized, models.ListUserPostsErrorResponse{
-			Message: "Unauthorized",
-			Error:   "user not authenticated",
-		})
-		return
-	}
+	currentUserModel := helpers.RequireUser(c)
 
 	identifier := c.Param("identifier")
 	if identifier == "" {
@@ -479,10 +1073,12 @@ func (pc *PostController) ListPostsByUserIdentifier(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.ListUserPostsErrorResponse{
 			Message: "Invalid Request",
 			Error:   "user identifier is required in path",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
 	pageNumber := c.GetInt(middlewares.PageNumberKey)
+	pageSize := c.GetInt(middlewares.PageSizeKey)
 
 	user, err := pc.authStore.GetUserByUsernameOrEmail(c, identifier)
 	if err != nil {
@@ -491,22 +1087,46 @@ func (pc *PostController) ListPostsByUserIdentifier(c *gin.Context) {
 			c.JSON(http.StatusNotFound, models.ListUserPostsErrorResponse{
 				Message: "User Not Found",
 				Error:   "user not found",
+				Code:    helpers.ErrorCode(err),
 			})
 		} else {
 			pc.logger.WithFields(logrus.Fields{"error": err, "identifier": identifier}).Error("Failed to get user by identifier from store")
 			c.JSON(http.StatusInternalServerError, models.ListUserPostsErrorResponse{
 				Message: "Failed to Get User Posts",
 				Error:   "could not retrieve user from database",
+				Code:    helpers.CodeInternal,
 			})
 		}
+		return
+	}
+
+	canView, err := pc.followStore.CanViewPosts(c, currentUserModel.ID, user.ID)
+	if err != nil {
+		pc.logger.WithFields(logrus.Fields{"error": err, "identifier": identifier}).Error("Failed to check post visibility")
+		c.JSON(http.StatusInternalServerError, models.ListUserPostsErrorResponse{
+			Message: "Failed to Get User Posts",
+			Error:   "could not check post visibility",
+			Code:    helpers.CodeInternal,
+		})
+		return
+	}
+	if !canView {
+		pc.logger.WithFields(logrus.Fields{"identifier": identifier, "userID": currentUserModel.ID}).Error("Posts of private profile not visible to user")
+		c.JSON(http.StatusForbidden, models.ListUserPostsErrorResponse{
+			Message: "Forbidden",
+			Error:   stores.ErrPrivateAccount.Error(),
+			Code:    helpers.ErrorCode(stores.ErrPrivateAccount),
+		})
+		return
 	}
 
-	posts, err := pc.postStore.ListPostsByAuthorID(c, user.ID, pageNumber, middlewares.PageSize)
+	posts, err := pc.postStore.ListPostsByAuthorID(c, user.ID, pageNumber, pageSize)
 	if err != nil {
 		pc.logger.WithFields(logrus.Fields{"error": err, "identifier": identifier}).Error("Failed to get posts by author ID from store")
 		c.JSON(http.StatusInternalServerError, models.ListUserPostsErrorResponse{
 			Message: "Failed to Get User Posts",
 			Error:   "could not retrieve posts from database",
+			Code:    helpers.CodeInternal,
 		})
 		return
 	}
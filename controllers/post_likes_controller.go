@@ -3,7 +3,9 @@ package controllers
 import (
 	"errors"
 	"net/http"
+	"time"
 
+	"github.com/datarohit/gopher-social-backend/helpers"
 	"github.com/datarohit/gopher-social-backend/middlewares"
 	"github.com/datarohit/gopher-social-backend/models"
 	"github.com/datarohit/gopher-social-backend/stores"
@@ -15,6 +17,7 @@ import (
 type PostLikesController struct {
 	postLikesStore *stores.PostLikeStore
 	postStore      *stores.PostStore
+	blockStore     *stores.BlockStore
 	authStore      *stores.AuthStore
 	logger         *logrus.Logger
 }
@@ -24,15 +27,17 @@ type PostLikesController struct {
 // Parameters:
 //   - postLikesStore (*stores.PostLikeStore): PostLikeStore pointer to interact with the database.
 //   - postStore (*stores.PostStore): PostStore pointer to interact with the database.
+//   - blockStore (*stores.BlockStore): BlockStore pointer to interact with the database.
 //   - authStore (*stores.AuthStore): AuthStore pointer to interact with the database.
 //   - logger (*logrus.Logger): Logrus logger pointer to log messages.
 //
 // Returns:
 //   - *PostLikesController: Pointer to the PostLikesController.
-func NewPostLikesController(postLikesStore *stores.PostLikeStore, postStore *stores.PostStore, authStore *stores.AuthStore, logger *logrus.Logger) *PostLikesController {
+func NewPostLikesController(postLikesStore *stores.PostLikeStore, postStore *stores.PostStore, blockStore *stores.BlockStore, authStore *stores.AuthStore, logger *logrus.Logger) *PostLikesController {
 	return &PostLikesController{
 		postLikesStore: postLikesStore,
 		postStore:      postStore,
+		blockStore:     blockStore,
 		authStore:      authStore,
 		logger:         logger,
 	}
@@ -49,22 +54,13 @@ func NewPostLikesController(postLikesStore *stores.PostLikeStore, postStore *sto
 // @Success      200 {object} models.LikePostSuccessResponse "Successfully liked post"
 // @Failure      400 {object} models.LikePostErrorResponse "Bad Request - Invalid input"
 // @Failure      401 {object} models.LikePostErrorResponse "Unauthorized - User not logged in or invalid token"
-// @Failure      403 {object} models.LikePostErrorResponse "Forbidden - User account is inactive or banned"
+// @Failure      403 {object} models.LikePostErrorResponse "Forbidden - User account is inactive or banned, or blocked by the author"
 // @Failure      404 {object} models.LikePostErrorResponse "Not Found - Post not found"
 // @Failure      409 {object} models.LikePostErrorResponse "Conflict - Already liked post"
 // @Failure      500 {object} models.LikePostErrorResponse "Internal Server Error - Failed to like post"
 // @Router       /post/{postID}/like [post]
 func (plc *PostLikesController) LikePost(c *gin.Context) {
-	userCtx, exists := c.Get("user")
-	if !exists {
-		plc.logger.Error("User not found in context. Middleware misconfiguration.")
-		c.JSON(http.StatusUnauthorized, models.LikePostErrorResponse{
-			Message: "Unauthorized",
-			Error:   "user not authenticated",
-		})
-		return
-	}
-	userModel := userCtx.(*models.User)
+	userModel := helpers.RequireUser(c)
 
 	postIDStr := c.Param("postID")
 	if postIDStr == "" {
@@ -72,6 +68,7 @@ func (plc *PostLikesController) LikePost(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.LikePostErrorResponse{
 			Message: "Invalid Request",
 			Error:   "post ID is required in path",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
@@ -82,23 +79,33 @@ func (plc *PostLikesController) LikePost(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.LikePostErrorResponse{
 			Message: "Invalid Request",
 			Error:   "invalid post ID format",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
 
-	_, err = plc.postStore.GetPostByID(c, postID)
+	err = plc.blockStore.CheckPostAuthorBlock(c, postID, userModel.ID)
 	if err != nil {
 		if errors.Is(err, stores.ErrPostNotFound) {
 			plc.logger.WithFields(logrus.Fields{"error": err, "postID": postID, "userID": userModel.ID}).Error("Post not found")
 			c.JSON(http.StatusNotFound, models.LikePostErrorResponse{
 				Message: "Post Not Found",
 				Error:   "post not found",
+				Code:    helpers.ErrorCode(err),
+			})
+		} else if errors.Is(err, stores.ErrBlockedByAuthor) {
+			plc.logger.WithFields(logrus.Fields{"postID": postID, "userID": userModel.ID}).Warn("Blocked by Post Author")
+			c.JSON(http.StatusForbidden, models.LikePostErrorResponse{
+				Message: "Like Post Failed",
+				Error:   err.Error(),
+				Code:    helpers.ErrorCode(err),
 			})
 		} else {
 			plc.logger.WithFields(logrus.Fields{"error": err, "postID": postID, "userID": userModel.ID}).Error("Failed to get post from store")
 			c.JSON(http.StatusInternalServerError, models.LikePostErrorResponse{
 				Message: "Failed to Like Post",
 				Error:   "could not retrieve post from database",
+				Code:    helpers.CodeInternal,
 			})
 		}
 		return
@@ -111,12 +118,14 @@ func (plc *PostLikesController) LikePost(c *gin.Context) {
 			c.JSON(http.StatusConflict, models.LikePostErrorResponse{
 				Message: "Like Post Failed",
 				Error:   "already liked post",
+				Code:    helpers.ErrorCode(err),
 			})
 		} else {
 			plc.logger.WithFields(logrus.Fields{"error": err, "postID": postID, "userID": userModel.ID}).Error("Failed to Like Post in Store")
 			c.JSON(http.StatusInternalServerError, models.LikePostErrorResponse{
 				Message: "Failed to Like Post",
 				Error:   "could not like post in database",
+				Code:    helpers.CodeInternal,
 			})
 		}
 		return
@@ -138,22 +147,13 @@ func (plc *PostLikesController) LikePost(c *gin.Context) {
 // @Success      200 {object} models.DislikePostSuccessResponse "Successfully disliked post"
 // @Failure      400 {object} models.DislikePostErrorResponse "Bad Request - Invalid input"
 // @Failure      401 {object} models.DislikePostErrorResponse "Unauthorized - User not logged in or invalid token"
-// @Failure      403 {object} models.DislikePostErrorResponse "Forbidden - User account is inactive or banned"
+// @Failure      403 {object} models.DislikePostErrorResponse "Forbidden - User account is inactive or banned, or blocked by the author"
 // @Failure      404 {object} models.DislikePostErrorResponse "Not Found - Post not found"
 // @Failure      409 {object} models.DislikePostErrorResponse "Conflict - Already disliked post"
 // @Failure      500 {object} models.DislikePostErrorResponse "Internal Server Error - Failed to dislike post"
 // @Router       /post/{postID}/dislike [post]
 func (plc *PostLikesController) DislikePost(c *gin.Context) {
-	userCtx, exists := c.Get("user")
-	if !exists {
-		plc.logger.Error("User not found in context. Middleware misconfiguration.")
-		c.JSON(http.StatusUnauthorized, models.DislikePostErrorResponse{
-			Message: "Unauthorized",
-			Error:   "user not authenticated",
-		})
-		return
-	}
-	userModel := userCtx.(*models.User)
+	userModel := helpers.RequireUser(c)
 
 	postIDStr := c.Param("postID")
 	if postIDStr == "" {
@@ -161,6 +161,7 @@ func (plc *PostLikesController) DislikePost(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.DislikePostErrorResponse{
 			Message: "Invalid Request",
 			Error:   "post ID is required in path",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
@@ -171,23 +172,33 @@ func (plc *PostLikesController) DislikePost(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.DislikePostErrorResponse{
 			Message: "Invalid Request",
 			Error:   "invalid post ID format",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
 
-	_, err = plc.postStore.GetPostByID(c, postID)
+	err = plc.blockStore.CheckPostAuthorBlock(c, postID, userModel.ID)
 	if err != nil {
 		if errors.Is(err, stores.ErrPostNotFound) {
 			plc.logger.WithFields(logrus.Fields{"error": err, "postID": postID, "userID": userModel.ID}).Error("Post not found")
 			c.JSON(http.StatusNotFound, models.DislikePostErrorResponse{
 				Message: "Post Not Found",
 				Error:   "post not found",
+				Code:    helpers.ErrorCode(err),
+			})
+		} else if errors.Is(err, stores.ErrBlockedByAuthor) {
+			plc.logger.WithFields(logrus.Fields{"postID": postID, "userID": userModel.ID}).Warn("Blocked by Post Author")
+			c.JSON(http.StatusForbidden, models.DislikePostErrorResponse{
+				Message: "Dislike Post Failed",
+				Error:   err.Error(),
+				Code:    helpers.ErrorCode(err),
 			})
 		} else {
 			plc.logger.WithFields(logrus.Fields{"error": err, "postID": postID, "userID": userModel.ID}).Error("Failed to get post from store")
 			c.JSON(http.StatusInternalServerError, models.DislikePostErrorResponse{
 				Message: "Failed to Dislike Post",
 				Error:   "could not retrieve post from database",
+				Code:    helpers.CodeInternal,
 			})
 		}
 		return
@@ -200,12 +211,14 @@ func (plc *PostLikesController) DislikePost(c *gin.Context) {
 			c.JSON(http.StatusConflict, models.DislikePostErrorResponse{
 				Message: "Dislike Post Failed",
 				Error:   "already disliked post",
+				Code:    helpers.ErrorCode(err),
 			})
 		} else {
 			plc.logger.WithFields(logrus.Fields{"error": err, "postID": postID, "userID": userModel.ID}).Error("Failed to Dislike Post in Store")
 			c.JSON(http.StatusInternalServerError, models.DislikePostErrorResponse{
 				Message: "Failed to Dislike Post",
 				Error:   "could not dislike post in database",
+				Code:    helpers.CodeInternal,
 			})
 		}
 		return
@@ -232,16 +245,7 @@ func (plc *PostLikesController) DislikePost(c *gin.Context) {
 // @Failure      500 {object} models.UnlikePostErrorResponse "Internal Server Error - Failed to unlike post"
 // @Router       /post/{postID}/like [delete]
 func (plc *PostLikesController) UnlikePost(c *gin.Context) {
-	userCtx, exists := c.Get("user")
-	if !exists {
-		plc.logger.Error("User not found in context. Middleware misconfiguration.")
-		c.JSON(http.StatusUnauthorized, models.UnlikePostErrorResponse{
-			Message: "Unauthorized",
-			Error:   "user not authenticated",
-		})
-		return
-	}
-	userModel := userCtx.(*models.User)
+	userModel := helpers.RequireUser(c)
 
 	postIDStr := c.Param("postID")
 	if postIDStr == "" {
@@ -249,6 +253,7 @@ func (plc *PostLikesController) UnlikePost(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.UnlikePostErrorResponse{
 			Message: "Invalid Request",
 			Error:   "post ID is required in path",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
@@ -259,6 +264,7 @@ func (plc *PostLikesController) UnlikePost(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.UnlikePostErrorResponse{
 			Message: "Invalid Request",
 			Error:   "invalid post ID format",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
@@ -270,12 +276,14 @@ func (plc *PostLikesController) UnlikePost(c *gin.Context) {
 			c.JSON(http.StatusNotFound, models.UnlikePostErrorResponse{
 				Message: "Post Not Found",
 				Error:   "post not found",
+				Code:    helpers.ErrorCode(err),
 			})
 		} else {
 			plc.logger.WithFields(logrus.Fields{"error": err, "postID": postID, "userID": userModel.ID}).Error("Failed to get post from store")
 			c.JSON(http.StatusInternalServerError, models.UnlikePostErrorResponse{
 				Message: "Failed to Unlike Post",
 				Error:   "could not retrieve post from database",
+				Code:    helpers.CodeInternal,
 			})
 		}
 		return
@@ -288,12 +296,14 @@ func (plc *PostLikesController) UnlikePost(c *gin.Context) {
 			c.JSON(http.StatusNotFound, models.UnlikePostErrorResponse{
 				Message: "Unlike Post Failed",
 				Error:   "post like not found",
+				Code:    helpers.ErrorCode(err),
 			})
 		} else {
 			plc.logger.WithFields(logrus.Fields{"error": err, "postID": postID, "userID": userModel.ID}).Error("Failed to Unlike Post in Store")
 			c.JSON(http.StatusInternalServerError, models.UnlikePostErrorResponse{
 				Message: "Failed to Unlike Post",
 				Error:   "could not unlike post in database",
+				Code:    helpers.CodeInternal,
 			})
 		}
 		return
@@ -320,16 +330,7 @@ func (plc *PostLikesController) UnlikePost(c *gin.Context) {
 // @Failure      500 {object} models.UndislikePostErrorResponse "Internal Server Error - Failed to undislike post"
 // @Router       /post/{postID}/undislike [delete]
 func (plc *PostLikesController) UndislikePost(c *gin.Context) {
-	userCtx, exists := c.Get("user")
-	if !exists {
-		plc.logger.Error("User not found in context. Middleware misconfiguration.")
-		c.JSON(http.StatusUnauthorized, models.UndislikePostErrorResponse{
-			Message: "Unauthorized",
-			Error:   "user not authenticated",
-		})
-		return
-	}
-	userModel := userCtx.(*models.User)
+	userModel := helpers.RequireUser(c)
 
 	postIDStr := c.Param("postID")
 	if postIDStr == "" {
@@ -337,6 +338,7 @@ func (plc *PostLikesController) UndislikePost(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.UndislikePostErrorResponse{
 			Message: "Invalid Request",
 			Error:   "post ID is required in path",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
@@ -347,6 +349,7 @@ func (plc *PostLikesController) UndislikePost(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.UndislikePostErrorResponse{
 			Message: "Invalid Request",
 			Error:   "invalid post ID format",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
@@ -358,12 +361,14 @@ func (plc *PostLikesController) UndislikePost(c *gin.Context) {
 			c.JSON(http.StatusNotFound, models.UndislikePostErrorResponse{
 				Message: "Post Not Found",
 				Error:   "post not found",
+				Code:    helpers.ErrorCode(err),
 			})
 		} else {
 			plc.logger.WithFields(logrus.Fields{"error": err, "postID": postID, "userID": userModel.ID}).Error("Failed to get post from store")
 			c.JSON(http.StatusInternalServerError, models.UndislikePostErrorResponse{
 				Message: "Failed to Undislike Post",
 				Error:   "could not retrieve post from database",
+				Code:    helpers.CodeInternal,
 			})
 		}
 		return
@@ -376,12 +381,14 @@ func (plc *PostLikesController) UndislikePost(c *gin.Context) {
 			c.JSON(http.StatusNotFound, models.UndislikePostErrorResponse{
 				Message: "Undislike Post Failed",
 				Error:   "post dislike not found",
+				Code:    helpers.ErrorCode(err),
 			})
 		} else {
 			plc.logger.WithFields(logrus.Fields{"error": err, "postID": postID, "userID": userModel.ID}).Error("Failed to Undislike Post in Store")
 			c.JSON(http.StatusInternalServerError, models.UndislikePostErrorResponse{
 				Message: "Failed to Undislike Post",
 				Error:   "could not undislike post in database",
+				Code:    helpers.CodeInternal,
 			})
 		}
 		return
@@ -392,37 +399,109 @@ func (plc *PostLikesController) UndislikePost(c *gin.Context) {
 	})
 }
 
+// ListPostReactions godoc
+// @Summary      List post reactions of logged-in user
+// @Description  Retrieves the likes and dislikes of the logged-in user on posts in one list, most recent reaction first. Each entry names the reaction and includes the post.
+// @Tags         post_likes
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        page query integer false "Page number for pagination" default(1)
+// @Param        pageSize query integer false "Number of items per page, at most 100" default(10)
+// @Success      200 {object} models.ListPostReactionsSuccessResponse "Successfully retrieved list of post reactions"
+// @Failure      401 {object} models.ListPostReactionsErrorResponse "Unauthorized - User not logged in or invalid token"
+// @Failure      500 {object} models.ListPostReactionsErrorResponse "Internal Server Error - Failed to fetch post reactions"
+// @Router       /post/reactions [get]
+func (plc *PostLikesController) ListPostReactions(c *gin.Context) {
+	userModel := helpers.RequireUser(c)
+	pageNumber := c.GetInt(middlewares.PageNumberKey)
+	pageSize := c.GetInt(middlewares.PageSizeKey)
+
+	reactions, err := plc.postLikesStore.ListReactionsByUserID(c, userModel.ID, pageNumber, pageSize)
+	if err != nil {
+		plc.logger.WithFields(logrus.Fields{"error": err, "userID": userModel.ID}).Error("Failed to get post reactions from store")
+		c.JSON(http.StatusInternalServerError, models.ListPostReactionsErrorResponse{
+			Message: "Failed to Get Post Reactions",
+			Error:   "could not retrieve post reactions from database",
+			Code:    helpers.CodeInternal,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ListPostReactionsSuccessResponse{
+		Message:   "Post Reactions Retrieved Successfully",
+		Reactions: reactions,
+	})
+}
+
 // ListLikedPosts godoc
 // @Summary      List liked posts of logged-in user
-// @Description  Retrieves a list of posts liked by the logged-in user.
+// @Description  Retrieves a list of posts liked by the logged-in user. With since or until, only posts liked within that range are returned, ordered by like time.
 // @Tags         post_likes
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
 // @Param        page query integer false "Page number for pagination" default(1)
+// @Param        pageSize query integer false "Number of items per page, at most 100" default(10)
+// @Param        since query string false "Only include posts liked at or after this RFC3339 timestamp"
+// @Param        until query string false "Only include posts liked at or before this RFC3339 timestamp"
 // @Success      200 {object} models.ListLikedPostsSuccessResponse "Successfully retrieved list of liked posts"
+// @Failure      400 {object} models.ListLikedPostsErrorResponse "Bad Request - Invalid since or until value"
 // @Failure      401 {object} models.ListLikedPostsErrorResponse "Unauthorized - User not logged in or invalid token"
 // @Failure      500 {object} models.ListLikedPostsErrorResponse "Internal Server Error - Failed to fetch liked posts"
 // @Router       /post/liked [get]
 func (plc *PostLikesController) ListLikedPosts(c *gin.Context) {
-	userCtx, exists := c.Get("user")
-	if !exists {
-		plc.logger.Error("User not found in context. Middleware misconfiguration.")
-		c.JSON(http.StatusUnauthorized, models.ListLikedPostsErrorResponse{
-			Message: "Unauthorized",
-			Error:   "user not authenticated",
+	userModel := helpers.RequireUser(c)
+	pageNumber := c.GetInt(middlewares.PageNumberKey)
+	pageSize := c.GetInt(middlewares.PageSizeKey)
+
+	var since *time.Time
+	if sinceStr := c.Query("since"); sinceStr != "" {
+		parsedSince, err := time.Parse(time.RFC3339, sinceStr)
+		if err != nil {
+			plc.logger.WithFields(logrus.Fields{"error": err, "since": sinceStr}).Error("Invalid since value")
+			c.JSON(http.StatusBadRequest, models.ListLikedPostsErrorResponse{
+				Message: "Invalid Request",
+				Error:   "since must be an RFC3339 timestamp",
+				Code:    helpers.CodeBadRequest,
+			})
+			return
+		}
+		since = &parsedSince
+	}
+
+	var until *time.Time
+	if untilStr := c.Query("until"); untilStr != "" {
+		parsedUntil, err := time.Parse(time.RFC3339, untilStr)
+		if err != nil {
+			plc.logger.WithFields(logrus.Fields{"error": err, "until": untilStr}).Error("Invalid until value")
+			c.JSON(http.StatusBadRequest, models.ListLikedPostsErrorResponse{
+				Message: "Invalid Request",
+				Error:   "until must be an RFC3339 timestamp",
+				Code:    helpers.CodeBadRequest,
+			})
+			return
+		}
+		until = &parsedUntil
+	}
+
+	if since != nil && until != nil && until.Before(*since) {
+		plc.logger.WithFields(logrus.Fields{"since": since, "until": until}).Error("Invalid liked posts time range")
+		c.JSON(http.StatusBadRequest, models.ListLikedPostsErrorResponse{
+			Message: "Invalid Request",
+			Error:   "until must not be before since",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
-	userModel := userCtx.(*models.User)
-	pageNumber := c.GetInt(middlewares.PageNumberKey)
 
-	posts, err := plc.postLikesStore.ListLikedPostsByUserID(c, userModel.ID, pageNumber, middlewares.PageSize)
+	posts, err := plc.postLikesStore.ListLikedPostsByUserID(c, userModel.ID, since, until, pageNumber, pageSize)
 	if err != nil {
 		plc.logger.WithFields(logrus.Fields{"error": err, "userID": userModel.ID}).Error("Failed to get liked posts from store")
 		c.JSON(http.StatusInternalServerError, models.ListLikedPostsErrorResponse{
 			Message: "Failed to Get Liked Posts",
 			Error:   "could not retrieve liked posts from database",
+			Code:    helpers.CodeInternal,
 		})
 		return
 	}
@@ -445,24 +524,17 @@ func (plc *PostLikesController) ListLikedPosts(c *gin.Context) {
 // @Failure      500 {object} models.ListDislikedPostsErrorResponse "Internal Server Error - Failed to fetch disliked posts"
 // @Router       /post/disliked [get]
 func (plc *PostLikesController) ListDislikedPosts(c *gin.Context) {
-	userCtx, exists := c.Get("user")
-	if !exists {
-		plc.logger.Error("User not found in context. Middleware misconfiguration.")
-		c.JSON(http.StatusUnauthorized, models.ListDislikedPostsErrorResponse{
-			Message: "Unauthorized",
-			Error:   "user not authenticated",
-		})
-		return
-	}
-	userModel := userCtx.(*models.User)
+	userModel := helpers.RequireUser(c)
 	pageNumber := c.GetInt(middlewares.PageNumberKey)
+	pageSize := c.GetInt(middlewares.PageSizeKey)
 
-	posts, err := plc.postLikesStore.ListDislikedPostsByUserID(c, userModel.ID, pageNumber, middlewares.PageSize)
+	posts, err := plc.postLikesStore.ListDislikedPostsByUserID(c, userModel.ID, pageNumber, pageSize)
 	if err != nil {
 		plc.logger.WithFields(logrus.Fields{"error": err, "userID": userModel.ID}).Error("Failed to get disliked posts from store")
 		c.JSON(http.StatusInternalServerError, models.ListDislikedPostsErrorResponse{
 			Message: "Failed to Get Disliked Posts",
 			Error:   "could not retrieve disliked posts from database",
+			Code:    helpers.CodeInternal,
 		})
 		return
 	}
@@ -488,40 +560,34 @@ func (plc *PostLikesController) ListDislikedPosts(c *gin.Context) {
 // @Failure      500 {object} models.ListLikedPostsErrorResponse "Internal Server Error - Failed to fetch liked posts"
 // @Router       /post/user/{identifier}/liked [get]
 func (plc *PostLikesController) ListLikedPostsByUserIdentifier(c *gin.Context) {
-	_, exists := c.Get("user")
-	if !exists {
-		plc.logger.Error("User not found in context. Middleware misconfiguration.")
-		c.JSON(http.StatusUnauthorized, models.ListLikedPostsErrorResponse{
-			Message: "Unauthorized",
-			Error:   "user not authenticated",
-		})
-		return
-	}
-
 	identifier := c.Param("identifier")
 	if identifier == "" {
 		plc.logger.Error("User Identifier is required in path")
 		c.JSON(http.StatusBadRequest, models.ListLikedPostsErrorResponse{
 			Message: "Invalid Request",
 			Error:   "user identifier is required in path",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
 	pageNumber := c.GetInt(middlewares.PageNumberKey)
+	pageSize := c.GetInt(middlewares.PageSizeKey)
 
-	posts, err := plc.postLikesStore.ListLikedPostsByUserIdentifier(c, identifier, pageNumber, middlewares.PageSize)
+	posts, err := plc.postLikesStore.ListLikedPostsByUserIdentifier(c, identifier, pageNumber, pageSize)
 	if err != nil {
 		if errors.Is(err, stores.ErrUserNotFound) {
 			plc.logger.WithFields(logrus.Fields{"error": err, "identifier": identifier}).Error("User not found")
 			c.JSON(http.StatusNotFound, models.ListLikedPostsErrorResponse{
 				Message: "User Not Found",
 				Error:   "user not found",
+				Code:    helpers.ErrorCode(err),
 			})
 		} else {
 			plc.logger.WithFields(logrus.Fields{"error": err, "identifier": identifier}).Error("Failed to get liked posts by user identifier from store")
 			c.JSON(http.StatusInternalServerError, models.ListLikedPostsErrorResponse{
 				Message: "Failed to Get Liked Posts",
 				Error:   "could not retrieve liked posts from database",
+				Code:    helpers.CodeInternal,
 			})
 		}
 		return
@@ -548,40 +614,34 @@ func (plc *PostLikesController) ListLikedPostsByUserIdentifier(c *gin.Context) {
 // @Failure      500 {object} models.ListDislikedPostsErrorResponse "Internal Server Error - Failed to fetch disliked posts"
 // @Router       /post/user/{identifier}/disliked [get]
 func (plc *PostLikesController) ListDislikedPostsByUserIdentifier(c *gin.Context) {
-	_, exists := c.Get("user")
-	if !exists {
-		plc.logger.Error("User not found in context. Middleware misconfiguration.")
-		c.JSON(http.StatusUnauthorized, models.ListDislikedPostsErrorResponse{
-			Message: "Unauthorized",
-			Error:   "user not authenticated",
-		})
-		return
-	}
-
 	identifier := c.Param("identifier")
 	if identifier == "" {
 		plc.logger.Error("User Identifier is required in path")
 		c.JSON(http.StatusBadRequest, models.ListDislikedPostsErrorResponse{
 			Message: "Invalid Request",
 			Error:   "user identifier is required in path",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
 	pageNumber := c.GetInt(middlewares.PageNumberKey)
+	pageSize := c.GetInt(middlewares.PageSizeKey)
 
-	posts, err := plc.postLikesStore.ListDislikedPostsByUserIdentifier(c, identifier, pageNumber, middlewares.PageSize)
+	posts, err := plc.postLikesStore.ListDislikedPostsByUserIdentifier(c, identifier, pageNumber, pageSize)
 	if err != nil {
 		if errors.Is(err, stores.ErrUserNotFound) {
 			plc.logger.WithFields(logrus.Fields{"error": err, "identifier": identifier}).Error("User not found")
 			c.JSON(http.StatusNotFound, models.ListDislikedPostsErrorResponse{
 				Message: "User Not Found",
 				Error:   "user not found",
+				Code:    helpers.ErrorCode(err),
 			})
 		} else {
 			plc.logger.WithFields(logrus.Fields{"error": err, "identifier": identifier}).Error("Failed to get disliked posts by user identifier from store")
 			c.JSON(http.StatusInternalServerError, models.ListDislikedPostsErrorResponse{
 				Message: "Failed to Get Disliked Posts",
 				Error:   "could not retrieve disliked posts from database",
+				Code:    helpers.CodeInternal,
 			})
 		}
 		return
@@ -592,3 +652,155 @@ func (plc *PostLikesController) ListDislikedPostsByUserIdentifier(c *gin.Context
 		Posts:   posts,
 	})
 }
+
+// GetPostReactionTimeline godoc
+// @Summary      Get the reaction timeline of a post
+// @Description  Retrieves the likes and dislikes of a post per hour or per day (UTC), with the count of each bucket and the running totals. Ranges are limited to 168 hourly or 366 daily buckets. Without since, the timeline starts at the creation of the post, or as far back as the limit allows. Accessible to the post author and admins only.
+// @Tags         post_likes
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        postID path string true "Post Identifier (Post ID)"
+// @Param        bucket query string false "Bucket size: hour or day" default(hour)
+// @Param        since query string false "Start of the range as an RFC3339 timestamp, rounded down to the start of its bucket"
+// @Param        until query string false "End of the range as an RFC3339 timestamp, defaults to now"
+// @Success      200 {object} models.GetPostReactionTimelineSuccessResponse "Successfully retrieved post reaction timeline"
+// @Failure      400 {object} models.GetPostReactionTimelineErrorResponse "Bad Request - Invalid post ID, bucket, since, until or range"
+// @Failure      401 {object} models.GetPostReactionTimelineErrorResponse "Unauthorized - User not logged in or invalid token"
+// @Failure      403 {object} models.GetPostReactionTimelineErrorResponse "Forbidden - User is not the author of the post or an admin"
+// @Failure      404 {object} models.GetPostReactionTimelineErrorResponse "Not Found - Post not found"
+// @Failure      500 {object} models.GetPostReactionTimelineErrorResponse "Internal Server Error - Failed to get post reaction timeline"
+// @Router       /post/{postID}/reactions/timeline [get]
+func (plc *PostLikesController) GetPostReactionTimeline(c *gin.Context) {
+	userModel := helpers.RequireUser(c)
+
+	postIDStr := c.Param("postID")
+	postID, err := uuid.Parse(postIDStr)
+	if err != nil {
+		plc.logger.WithFields(logrus.Fields{"error": err, "postID": postIDStr}).Error("Invalid Post ID format")
+		c.JSON(http.StatusBadRequest, models.GetPostReactionTimelineErrorResponse{
+			Message: "Invalid Request",
+			Error:   "invalid post ID format",
+			Code:    helpers.CodeBadRequest,
+		})
+		return
+	}
+
+	bucket := c.DefaultQuery("bucket", stores.ReactionTimelineBucketHour)
+	if err := helpers.ValidateEnum(bucket, stores.ReactionTimelineBuckets); err != nil {
+		plc.logger.WithFields(logrus.Fields{"error": err, "bucket": bucket}).Error("Invalid reaction timeline bucket")
+		c.JSON(http.StatusBadRequest, models.GetPostReactionTimelineErrorResponse{
+			Message: "Invalid Request",
+			Error:   "bucket " + err.Error(),
+			Code:    helpers.ErrorCode(err),
+		})
+		return
+	}
+	maxRange, _ := stores.ReactionTimelineMaxRange(bucket)
+
+	until := time.Now()
+	if untilStr := c.Query("until"); untilStr != "" {
+		parsedUntil, err := time.Parse(time.RFC3339, untilStr)
+		if err != nil {
+			plc.logger.WithFields(logrus.Fields{"error": err, "until": untilStr}).Error("Invalid until value")
+			c.JSON(http.StatusBadRequest, models.GetPostReactionTimelineErrorResponse{
+				Message: "Invalid Request",
+				Error:   "until must be an RFC3339 timestamp",
+				Code:    helpers.CodeBadRequest,
+			})
+			return
+		}
+		until = parsedUntil
+	}
+
+	var since *time.Time
+	if sinceStr := c.Query("since"); sinceStr != "" {
+		parsedSince, err := time.Parse(time.RFC3339, sinceStr)
+		if err != nil {
+			plc.logger.WithFields(logrus.Fields{"error": err, "since": sinceStr}).Error("Invalid since value")
+			c.JSON(http.StatusBadRequest, models.GetPostReactionTimelineErrorResponse{
+				Message: "Invalid Request",
+				Error:   "since must be an RFC3339 timestamp",
+				Code:    helpers.CodeBadRequest,
+			})
+			return
+		}
+		since = &parsedSince
+	}
+
+	post, err := plc.postStore.GetPostByID(c, postID)
+	if err != nil {
+		if errors.Is(err, stores.ErrPostNotFound) {
+			plc.logger.WithFields(logrus.Fields{"error": err, "postID": postID, "userID": userModel.ID}).Error("Post not found")
+			c.JSON(http.StatusNotFound, models.GetPostReactionTimelineErrorResponse{
+				Message: "Post Not Found",
+				Error:   "post not found",
+				Code:    helpers.ErrorCode(err),
+			})
+		} else {
+			plc.logger.WithFields(logrus.Fields{"error": err, "postID": postID, "userID": userModel.ID}).Error("Failed to get post from store")
+			c.JSON(http.StatusInternalServerError, models.GetPostReactionTimelineErrorResponse{
+				Message: "Failed to Get Post Reaction Timeline",
+				Error:   "could not retrieve post from database",
+				Code:    helpers.CodeInternal,
+			})
+		}
+		return
+	}
+
+	if post.AuthorID != userModel.ID && userModel.Role.Level != 3 {
+		plc.logger.WithFields(logrus.Fields{"postID": postID, "userID": userModel.ID, "authorID": post.AuthorID}).Error("User is not the author of the post or an admin")
+		c.JSON(http.StatusForbidden, models.GetPostReactionTimelineErrorResponse{
+			Message: "Forbidden",
+			Error:   "you are not the author of this post",
+			Code:    helpers.CodeForbidden,
+		})
+		return
+	}
+
+	if since == nil {
+		// Start at the creation of the post, leaving room for since to be rounded down to the start of its bucket.
+		earliest := until.Add(-maxRange).Add(reactionTimelineBucketDuration(bucket))
+		defaultSince := post.CreatedAt
+		if defaultSince.Before(earliest) {
+			defaultSince = earliest
+		}
+		if defaultSince.After(until) {
+			defaultSince = until
+		}
+		since = &defaultSince
+	}
+
+	timeline, err := plc.postLikesStore.GetReactionTimeline(c, postID, bucket, *since, until)
+	if err != nil {
+		if errors.Is(err, stores.ErrInvalidReactionTimelineRange) {
+			plc.logger.WithFields(logrus.Fields{"error": err, "postID": postID, "since": since, "until": until}).Error("Invalid reaction timeline range")
+			c.JSON(http.StatusBadRequest, models.GetPostReactionTimelineErrorResponse{
+				Message: "Invalid Request",
+				Error:   err.Error(),
+				Code:    helpers.ErrorCode(err),
+			})
+		} else {
+			plc.logger.WithFields(logrus.Fields{"error": err, "postID": postID, "userID": userModel.ID}).Error("Failed to get post reaction timeline from store")
+			c.JSON(http.StatusInternalServerError, models.GetPostReactionTimelineErrorResponse{
+				Message: "Failed to Get Post Reaction Timeline",
+				Error:   "could not retrieve post reaction timeline from database",
+				Code:    helpers.CodeInternal,
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, models.GetPostReactionTimelineSuccessResponse{
+		Message:  "Post Reaction Timeline Retrieved Successfully",
+		Timeline: timeline,
+	})
+}
+
+// reactionTimelineBucketDuration returns the length of a reaction timeline bucket.
+func reactionTimelineBucketDuration(bucket string) time.Duration {
+	if bucket == stores.ReactionTimelineBucketDay {
+		return 24 * time.Hour
+	}
+	return time.Hour
+}
@@ -0,0 +1,43 @@
+package controllers
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/datarohit/gopher-social-backend/database/dbtest"
+	"github.com/datarohit/gopher-social-backend/helpers"
+	"github.com/datarohit/gopher-social-backend/stores"
+)
+
+// TestPostReactionBlockedByAuthor checks that a user blocked by the author of a post can neither like nor dislike it,
+// while a user the author has not blocked still can.
+func TestPostReactionBlockedByAuthor(t *testing.T) {
+	pool := dbtest.NewPool(t)
+
+	authorID := dbtest.CreateUser(t, pool, "author", 1)
+	blockedID := dbtest.CreateUser(t, pool, "blocked", 1)
+	readerID := dbtest.CreateUser(t, pool, "reader", 1)
+	postID := dbtest.CreatePost(t, pool, authorID)
+	dbtest.Exec(t, pool, `INSERT INTO blocks (blocker_id, blocked_id) VALUES ($1, $2)`, authorID, blockedID)
+
+	plc := NewPostLikesController(stores.NewPostLikeStore(pool), stores.NewPostStore(pool), stores.NewBlockStore(pool), stores.NewAuthStore(pool), newTestLogger())
+
+	blockedRouter := newTestRouter(loadUser(t, pool, blockedID))
+	blockedRouter.POST("/post/:postID/like", plc.LikePost)
+	blockedRouter.POST("/post/:postID/dislike", plc.DislikePost)
+
+	for _, action := range []string{"like", "dislike"} {
+		t.Run(action, func(t *testing.T) {
+			recorder := serve(blockedRouter, http.MethodPost, "/post/"+postID.String()+"/"+action, "")
+			assertStatus(t, recorder, http.StatusForbidden)
+			assertCode(t, recorder, helpers.ErrorCode(stores.ErrBlockedByAuthor))
+		})
+	}
+	if count := dbtest.Count(t, pool, `SELECT COUNT(*) FROM post_likes WHERE user_id = $1`, blockedID); count != 0 {
+		t.Fatalf("%d reactions recorded for the blocked user, want 0", count)
+	}
+
+	readerRouter := newTestRouter(loadUser(t, pool, readerID))
+	readerRouter.POST("/post/:postID/like", plc.LikePost)
+	assertStatus(t, serve(readerRouter, http.MethodPost, "/post/"+postID.String()+"/like", ""), http.StatusOK)
+}
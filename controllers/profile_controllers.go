@@ -3,15 +3,22 @@ package controllers
 import (
 	"errors"
 	"net/http"
+	"time"
 
+	"github.com/datarohit/gopher-social-backend/helpers"
+	"github.com/datarohit/gopher-social-backend/middlewares"
 	"github.com/datarohit/gopher-social-backend/models"
 	"github.com/datarohit/gopher-social-backend/stores"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 )
 
 type ProfileController struct {
 	profileStore *stores.ProfileStore
+	authStore    *stores.AuthStore
+	statsStore   *stores.StatsStore
+	blockStore   *stores.BlockStore
 	logger       *logrus.Logger
 }
 
@@ -19,13 +26,19 @@ type ProfileController struct {
 //
 // Parameters:
 //   - profileStore (*stores.ProfileStore): ProfileStore pointer to interact with the database.
+//   - authStore (*stores.AuthStore): AuthStore pointer to look up user summaries.
+//   - statsStore (*stores.StatsStore): StatsStore pointer to compute user activity histograms.
+//   - blockStore (*stores.BlockStore): BlockStore pointer to list blocked users.
 //   - logger (*logrus.Logger): Logrus logger pointer to log messages.
 //
 // Returns:
 //   - *ProfileController: Pointer to the ProfileController.
-func NewProfileController(profileStore *stores.ProfileStore, logger *logrus.Logger) *ProfileController {
+func NewProfileController(profileStore *stores.ProfileStore, authStore *stores.AuthStore, statsStore *stores.StatsStore, blockStore *stores.BlockStore, logger *logrus.Logger) *ProfileController {
 	return &ProfileController{
 		profileStore: profileStore,
+		authStore:    authStore,
+		statsStore:   statsStore,
+		blockStore:   blockStore,
 		logger:       logger,
 	}
 }
@@ -45,16 +58,7 @@ func NewProfileController(profileStore *stores.ProfileStore, logger *logrus.Logg
 // @Failure      500 {object} models.UpdateProfileErrorResponse "Internal Server Error - Failed to update profile"
 // @Router       /profile/update [put]
 func (pc *ProfileController) UpdateProfile(c *gin.Context) {
-	user, exists := c.Get("user")
-	if !exists {
-		pc.logger.Error("User not Found in Context. Middleware Misconfiguration")
-		c.JSON(http.StatusUnauthorized, models.UpdateProfileErrorResponse{
-			Message: "Unauthorized",
-			Error:   "user not authenticated",
-		})
-		return
-	}
-	userModel := user.(*models.User)
+	userModel := helpers.RequireUser(c)
 
 	var req models.UpdateProfilePayload
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -62,6 +66,7 @@ func (pc *ProfileController) UpdateProfile(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.UpdateProfileErrorResponse{
 			Message: "Invalid Request Body",
 			Error:   err.Error(),
+			Code:    helpers.CodeValidationFailed,
 		})
 		return
 	}
@@ -75,6 +80,7 @@ func (pc *ProfileController) UpdateProfile(c *gin.Context) {
 		LinkedIn:      req.LinkedIn,
 		Twitter:       req.Twitter,
 		GoogleScholar: req.GoogleScholar,
+		IsPrivate:     req.IsPrivate,
 	}
 
 	updatedProfile, err := pc.profileStore.UpdateProfile(c, profile)
@@ -84,12 +90,14 @@ func (pc *ProfileController) UpdateProfile(c *gin.Context) {
 			c.JSON(http.StatusNotFound, models.UpdateProfileErrorResponse{
 				Message: "Profile Not Found",
 				Error:   err.Error(),
+				Code:    helpers.ErrorCode(err),
 			})
 		} else {
 			pc.logger.WithFields(logrus.Fields{"error": err, "userID": userModel.ID}).Error("Failed to Update Profile in Store")
 			c.JSON(http.StatusInternalServerError, models.UpdateProfileErrorResponse{
 				Message: "Failed to Update Profile",
 				Error:   "failed to update profile in database",
+				Code:    helpers.CodeInternal,
 			})
 		}
 		return
@@ -115,16 +123,7 @@ func (pc *ProfileController) UpdateProfile(c *gin.Context) {
 // @Failure      500 {object} models.GetLoggedInUserProfileErrorResponse "Internal Server Error - Failed to get profile"
 // @Router       /profile/me [get]
 func (pc *ProfileController) GetLoggedInUserProfile(c *gin.Context) {
-	user, exists := c.Get("user")
-	if !exists {
-		pc.logger.Error("User not Found in Context. Middleware Misconfiguration")
-		c.JSON(http.StatusUnauthorized, models.GetLoggedInUserProfileErrorResponse{
-			Message: "Unauthorized",
-			Error:   "user not authenticated",
-		})
-		return
-	}
-	userModel := user.(*models.User)
+	userModel := helpers.RequireUser(c)
 
 	profile, err := pc.profileStore.GetProfileByUserID(c, userModel.ID)
 	if err != nil {
@@ -133,12 +132,14 @@ func (pc *ProfileController) GetLoggedInUserProfile(c *gin.Context) {
 			c.JSON(http.StatusNotFound, models.GetLoggedInUserProfileErrorResponse{
 				Message: "Profile Not Found",
 				Error:   err.Error(),
+				Code:    helpers.ErrorCode(err),
 			})
 		} else {
 			pc.logger.WithFields(logrus.Fields{"error": err, "userID": userModel.ID}).Error("Failed to Get Profile from Store")
 			c.JSON(http.StatusInternalServerError, models.GetLoggedInUserProfileErrorResponse{
 				Message: "Failed to Get Profile",
 				Error:   "failed to get profile from database",
+				Code:    helpers.CodeInternal,
 			})
 		}
 		return
@@ -173,6 +174,7 @@ func (pc *ProfileController) GetUserProfile(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.GetUserProfileErrorResponse{
 			Message: "Invalid Request",
 			Error:   "identifier is required in path parameters",
+			Code:    helpers.CodeBadRequest,
 		})
 		return
 	}
@@ -184,12 +186,14 @@ func (pc *ProfileController) GetUserProfile(c *gin.Context) {
 			c.JSON(http.StatusNotFound, models.GetUserProfileErrorResponse{
 				Message: "Profile Not Found",
 				Error:   err.Error(),
+				Code:    helpers.ErrorCode(err),
 			})
 		} else {
 			pc.logger.WithFields(logrus.Fields{"error": err, "identifier": identifier}).Error("Failed to Get Profile from Store by Identifier")
 			c.JSON(http.StatusInternalServerError, models.GetUserProfileErrorResponse{
 				Message: "Failed to Get Profile",
 				Error:   "failed to get profile from database",
+				Code:    helpers.CodeInternal,
 			})
 		}
 		return
@@ -200,6 +204,7 @@ func (pc *ProfileController) GetUserProfile(c *gin.Context) {
 		c.JSON(http.StatusForbidden, models.GetUserProfileErrorResponse{
 			Message: "Forbidden",
 			Error:   "requested user profile is banned",
+			Code:    helpers.CodeForbidden,
 		})
 		return
 	}
@@ -208,6 +213,7 @@ func (pc *ProfileController) GetUserProfile(c *gin.Context) {
 		c.JSON(http.StatusForbidden, models.GetUserProfileErrorResponse{
 			Message: "Forbidden",
 			Error:   "requested user profile is inactive",
+			Code:    helpers.CodeForbidden,
 		})
 		return
 	}
@@ -217,3 +223,430 @@ func (pc *ProfileController) GetUserProfile(c *gin.Context) {
 		Profile: profile,
 	})
 }
+
+// GetUserStats godoc
+// @Summary      Get user stats by identifier
+// @Description  Retrieves aggregate stats of a user by their identifier (username, email, or user ID): posts, comments, likes and dislikes received, followers and following counts.
+// @Tags         profile
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        identifier path string true "User Identifier (username, email, or user ID)"
+// @Success      200 {object} models.GetUserStatsSuccessResponse "Successfully retrieved user stats"
+// @Failure      400 {object} models.GetUserStatsErrorResponse "Bad Request - Invalid identifier format"
+// @Failure      401 {object} models.GetUserStatsErrorResponse "Unauthorized - User not logged in or invalid token"
+// @Failure      403 {object} models.GetUserStatsErrorResponse "Forbidden - User account is inactive or banned"
+// @Failure      404 {object} models.GetUserStatsErrorResponse "Not Found - User not found for the given identifier"
+// @Failure      500 {object} models.GetUserStatsErrorResponse "Internal Server Error - Failed to get user stats"
+// @Router       /user/{identifier}/stats [get]
+func (pc *ProfileController) GetUserStats(c *gin.Context) {
+	identifier := c.Param("identifier")
+
+	if identifier == "" {
+		pc.logger.Error("Identifier is missing in the request path")
+		c.JSON(http.StatusBadRequest, models.GetUserStatsErrorResponse{
+			Message: "Invalid Request",
+			Error:   "identifier is required in path parameters",
+			Code:    helpers.CodeBadRequest,
+		})
+		return
+	}
+
+	stats, err := pc.profileStore.GetUserStats(c, identifier)
+	if err != nil {
+		if errors.Is(err, stores.ErrUserNotFound) {
+			pc.logger.WithFields(logrus.Fields{"error": err, "identifier": identifier}).Error("User Not Found for Stats")
+			c.JSON(http.StatusNotFound, models.GetUserStatsErrorResponse{
+				Message: "User Not Found",
+				Error:   err.Error(),
+				Code:    helpers.ErrorCode(err),
+			})
+		} else {
+			pc.logger.WithFields(logrus.Fields{"error": err, "identifier": identifier}).Error("Failed to Get User Stats from Store")
+			c.JSON(http.StatusInternalServerError, models.GetUserStatsErrorResponse{
+				Message: "Failed to Get User Stats",
+				Error:   "failed to get user stats from database",
+				Code:    helpers.CodeInternal,
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, models.GetUserStatsSuccessResponse{
+		Message: "User Stats Retrieved Successfully",
+		Stats:   stats,
+	})
+}
+
+// GetUsersBatch godoc
+// @Summary      Get public summaries of several users
+// @Description  Retrieves the public summaries of up to 100 users identified by username or user ID, with role and follower and following counts, for example to render mention lists or notification actors. Each user is returned once, in the order of the first identifier naming them. Unknown identifiers and banned or inactive users are omitted. Emails are not accepted.
+// @Tags         profile
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        body body models.GetUsersBatchPayload true "Request Body with Usernames or User IDs"
+// @Success      200 {object} models.GetUsersBatchSuccessResponse "Successfully retrieved users"
+// @Failure      400 {object} models.GetUsersBatchErrorResponse "Bad Request - Invalid input"
+// @Failure      401 {object} models.GetUsersBatchErrorResponse "Unauthorized - User not logged in or invalid token"
+// @Failure      403 {object} models.GetUsersBatchErrorResponse "Forbidden - User account is inactive or banned"
+// @Failure      500 {object} models.GetUsersBatchErrorResponse "Internal Server Error - Failed to get users"
+// @Router       /user/batch [post]
+func (pc *ProfileController) GetUsersBatch(c *gin.Context) {
+	userModel := helpers.RequireUser(c)
+
+	var req models.GetUsersBatchPayload
+	if err := c.ShouldBindJSON(&req); err != nil {
+		pc.logger.WithFields(logrus.Fields{"error": err, "userID": userModel.ID}).Error("Invalid Request Body for Users Batch")
+		c.JSON(http.StatusBadRequest, models.GetUsersBatchErrorResponse{
+			Message: "Invalid Request Body",
+			Error:   err.Error(),
+			Code:    helpers.CodeValidationFailed,
+		})
+		return
+	}
+
+	users, err := pc.authStore.GetUsersByIdentifiers(c, req.Identifiers)
+	if err != nil {
+		pc.logger.WithFields(logrus.Fields{"error": err, "userID": userModel.ID}).Error("Failed to Get Users Batch from Store")
+		c.JSON(http.StatusInternalServerError, models.GetUsersBatchErrorResponse{
+			Message: "Failed to Get Users",
+			Error:   "failed to get users from database",
+			Code:    helpers.CodeInternal,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.GetUsersBatchSuccessResponse{
+		Message: "Users Retrieved Successfully",
+		Users:   users,
+	})
+}
+
+// activityDateLayout is the layout of the from and to query parameters of the user activity histogram.
+const activityDateLayout = "2006-01-02"
+
+// GetUserActivity godoc
+// @Summary      Get user activity histogram by identifier
+// @Description  Retrieves the number of posts and comments a user created per day or per week (UTC, weeks start on Monday) over a range of at most 366 days. Defaults to the last 30 days for day granularity and the last 12 weeks for week granularity.
+// @Tags         profile
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        identifier path string true "User Identifier (username, email, or user ID)"
+// @Param        granularity query string false "Bucket size: day or week" default(day)
+// @Param        from query string false "First day of the range (YYYY-MM-DD)"
+// @Param        to query string false "Last day of the range (YYYY-MM-DD), defaults to today"
+// @Success      200 {object} models.GetUserActivitySuccessResponse "Successfully retrieved user activity"
+// @Failure      400 {object} models.GetUserActivityErrorResponse "Bad Request - Invalid granularity, date or range"
+// @Failure      401 {object} models.GetUserActivityErrorResponse "Unauthorized - User not logged in or invalid token"
+// @Failure      403 {object} models.GetUserActivityErrorResponse "Forbidden - User account is inactive or banned"
+// @Failure      404 {object} models.GetUserActivityErrorResponse "Not Found - User not found for the given identifier"
+// @Failure      500 {object} models.GetUserActivityErrorResponse "Internal Server Error - Failed to get user activity"
+// @Router       /user/{identifier}/activity [get]
+func (pc *ProfileController) GetUserActivity(c *gin.Context) {
+	identifier := c.Param("identifier")
+
+	if identifier == "" {
+		pc.logger.Error("Identifier is missing in the request path")
+		c.JSON(http.StatusBadRequest, models.GetUserActivityErrorResponse{
+			Message: "Invalid Request",
+			Error:   "identifier is required in path parameters",
+			Code:    helpers.CodeBadRequest,
+		})
+		return
+	}
+
+	granularity := c.DefaultQuery("granularity", stores.ActivityGranularityDay)
+	if err := helpers.ValidateEnum(granularity, stores.ActivityGranularities); err != nil {
+		pc.logger.WithFields(logrus.Fields{"error": err, "identifier": identifier, "granularity": granularity}).Error("Invalid User Activity Granularity")
+		c.JSON(http.StatusBadRequest, models.GetUserActivityErrorResponse{
+			Message: "Invalid Request",
+			Error:   "granularity " + err.Error(),
+			Code:    helpers.ErrorCode(err),
+		})
+		return
+	}
+
+	to := time.Now().UTC()
+	if toParam := c.Query("to"); toParam != "" {
+		parsed, err := time.Parse(activityDateLayout, toParam)
+		if err != nil {
+			pc.logger.WithFields(logrus.Fields{"error": err, "to": toParam}).Error("Invalid To Date for User Activity")
+			c.JSON(http.StatusBadRequest, models.GetUserActivityErrorResponse{
+				Message: "Invalid Request",
+				Error:   "to must be a date in YYYY-MM-DD format",
+				Code:    helpers.CodeBadRequest,
+			})
+			return
+		}
+		to = parsed
+	}
+
+	from := to.AddDate(0, 0, -29)
+	if granularity == stores.ActivityGranularityWeek {
+		from = to.AddDate(0, 0, -7*11)
+	}
+	if fromParam := c.Query("from"); fromParam != "" {
+		parsed, err := time.Parse(activityDateLayout, fromParam)
+		if err != nil {
+			pc.logger.WithFields(logrus.Fields{"error": err, "from": fromParam}).Error("Invalid From Date for User Activity")
+			c.JSON(http.StatusBadRequest, models.GetUserActivityErrorResponse{
+				Message: "Invalid Request",
+				Error:   "from must be a date in YYYY-MM-DD format",
+				Code:    helpers.CodeBadRequest,
+			})
+			return
+		}
+		from = parsed
+	}
+
+	activity, err := pc.statsStore.GetUserActivityHistogram(c, identifier, granularity, from, to)
+	if err != nil {
+		if errors.Is(err, stores.ErrInvalidActivityRange) {
+			pc.logger.WithFields(logrus.Fields{"error": err, "identifier": identifier, "granularity": granularity}).Error("Invalid User Activity Parameters")
+			c.JSON(http.StatusBadRequest, models.GetUserActivityErrorResponse{
+				Message: "Invalid Request",
+				Error:   err.Error(),
+				Code:    helpers.ErrorCode(err),
+			})
+		} else if errors.Is(err, stores.ErrUserNotFound) {
+			pc.logger.WithFields(logrus.Fields{"error": err, "identifier": identifier}).Error("User Not Found for Activity")
+			c.JSON(http.StatusNotFound, models.GetUserActivityErrorResponse{
+				Message: "User Not Found",
+				Error:   err.Error(),
+				Code:    helpers.ErrorCode(err),
+			})
+		} else {
+			pc.logger.WithFields(logrus.Fields{"error": err, "identifier": identifier}).Error("Failed to Get User Activity from Store")
+			c.JSON(http.StatusInternalServerError, models.GetUserActivityErrorResponse{
+				Message: "Failed to Get User Activity",
+				Error:   "failed to get user activity from database",
+				Code:    helpers.CodeInternal,
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, models.GetUserActivitySuccessResponse{
+		Message:  "User Activity Retrieved Successfully",
+		Activity: activity,
+	})
+}
+
+// ListBlockedUsers godoc
+// @Summary      List users blocked by logged-in user
+// @Description  Retrieves the public summaries of the users blocked by the logged-in user, most recently blocked first. The block list is private and only listed to its owner.
+// @Tags         profile
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        page query integer false "Page number for pagination" default(1)
+// @Param        pageSize query integer false "Number of items per page, at most 100" default(10)
+// @Success      200 {object} models.ListBlockedUsersSuccessResponse "Successfully retrieved blocked users"
+// @Failure      401 {object} models.ListBlockedUsersErrorResponse "Unauthorized - User not logged in or invalid token"
+// @Failure      403 {object} models.ListBlockedUsersErrorResponse "Forbidden - User account is inactive or banned"
+// @Failure      500 {object} models.ListBlockedUsersErrorResponse "Internal Server Error - Failed to fetch blocked users"
+// @Router       /user/blocks [get]
+func (pc *ProfileController) ListBlockedUsers(c *gin.Context) {
+	userModel := helpers.RequireUser(c)
+	pageNumber := c.GetInt(middlewares.PageNumberKey)
+	pageSize := c.GetInt(middlewares.PageSizeKey)
+
+	blocked, pagination, err := pc.blockStore.ListBlocked(c, userModel.ID, pageNumber, pageSize)
+	if err != nil {
+		pc.logger.WithFields(logrus.Fields{"error": err, "userID": userModel.ID}).Error("Failed to get blocked users from store")
+		c.JSON(http.StatusInternalServerError, models.ListBlockedUsersErrorResponse{
+			Message: "Failed to Get Blocked Users",
+			Error:   "could not retrieve blocked users from database",
+			Code:    helpers.CodeInternal,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ListBlockedUsersSuccessResponse{
+		Message:    "Blocked Users Retrieved Successfully",
+		Blocked:    blocked,
+		Pagination: pagination,
+	})
+}
+
+// resolveUserID returns the ID of the user named by a username, email or user ID.
+// A user ID is returned as is, the store reports whether such a user exists.
+func (pc *ProfileController) resolveUserID(c *gin.Context, identifier string) (uuid.UUID, error) {
+	if userID, err := uuid.Parse(identifier); err == nil {
+		return userID, nil
+	}
+
+	user, err := pc.authStore.GetUserByUsernameOrEmail(c, identifier)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return user.ID, nil
+}
+
+// BlockUser godoc
+// @Summary      Block a user
+// @Description  Blocks a user by their identifier (username, email, or user ID). Blocked users cannot like or dislike the posts and comments of the logged-in user.
+// @Tags         profile
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        identifier path string true "User Identifier (username, email, or user ID) of the user to block"
+// @Success      200 {object} models.BlockUserSuccessResponse "Successfully blocked user"
+// @Failure      400 {object} models.BlockUserErrorResponse "Bad Request - Invalid input or blocking yourself"
+// @Failure      401 {object} models.BlockUserErrorResponse "Unauthorized - User not logged in or invalid token"
+// @Failure      403 {object} models.BlockUserErrorResponse "Forbidden - User account is inactive or banned"
+// @Failure      404 {object} models.BlockUserErrorResponse "Not Found - User not found for the given identifier"
+// @Failure      409 {object} models.BlockUserErrorResponse "Conflict - User already blocked"
+// @Failure      500 {object} models.BlockUserErrorResponse "Internal Server Error - Failed to block user"
+// @Router       /user/blocks/{identifier} [post]
+func (pc *ProfileController) BlockUser(c *gin.Context) {
+	userModel := helpers.RequireUser(c)
+
+	identifier := c.Param("identifier")
+	if identifier == "" {
+		pc.logger.WithFields(logrus.Fields{"userID": userModel.ID}).Error("Identifier is missing in the request path")
+		c.JSON(http.StatusBadRequest, models.BlockUserErrorResponse{
+			Message: "Invalid Request",
+			Error:   "identifier is required in path parameters",
+			Code:    helpers.CodeBadRequest,
+		})
+		return
+	}
+
+	blockedID, err := pc.resolveUserID(c, identifier)
+	if err != nil {
+		if errors.Is(err, stores.ErrUserNotFound) {
+			pc.logger.WithFields(logrus.Fields{"error": err, "userID": userModel.ID, "identifier": identifier}).Error("User to Block Not Found")
+			c.JSON(http.StatusNotFound, models.BlockUserErrorResponse{
+				Message: "User Not Found",
+				Error:   err.Error(),
+				Code:    helpers.ErrorCode(err),
+			})
+		} else {
+			pc.logger.WithFields(logrus.Fields{"error": err, "userID": userModel.ID, "identifier": identifier}).Error("Failed to Get User to Block from Store")
+			c.JSON(http.StatusInternalServerError, models.BlockUserErrorResponse{
+				Message: "Failed to Block User",
+				Error:   "failed to get user from database",
+				Code:    helpers.CodeInternal,
+			})
+		}
+		return
+	}
+
+	if blockedID == userModel.ID {
+		pc.logger.WithFields(logrus.Fields{"userID": userModel.ID}).Error("Cannot block yourself")
+		c.JSON(http.StatusBadRequest, models.BlockUserErrorResponse{
+			Message: "Invalid Request",
+			Error:   "cannot block yourself",
+			Code:    helpers.CodeBadRequest,
+		})
+		return
+	}
+
+	err = pc.blockStore.Block(c, userModel.ID, blockedID)
+	if err != nil {
+		if errors.Is(err, stores.ErrUserNotFound) {
+			pc.logger.WithFields(logrus.Fields{"error": err, "userID": userModel.ID, "blockedID": blockedID}).Error("User to Block Not Found")
+			c.JSON(http.StatusNotFound, models.BlockUserErrorResponse{
+				Message: "User Not Found",
+				Error:   err.Error(),
+				Code:    helpers.ErrorCode(err),
+			})
+		} else if errors.Is(err, stores.ErrAlreadyBlocked) {
+			pc.logger.WithFields(logrus.Fields{"error": err, "userID": userModel.ID, "blockedID": blockedID}).Error("User Already Blocked")
+			c.JSON(http.StatusConflict, models.BlockUserErrorResponse{
+				Message: "Block User Failed",
+				Error:   err.Error(),
+				Code:    helpers.ErrorCode(err),
+			})
+		} else {
+			pc.logger.WithFields(logrus.Fields{"error": err, "userID": userModel.ID, "blockedID": blockedID}).Error("Failed to Block User in Store")
+			c.JSON(http.StatusInternalServerError, models.BlockUserErrorResponse{
+				Message: "Failed to Block User",
+				Error:   "failed to block user in database",
+				Code:    helpers.CodeInternal,
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, models.BlockUserSuccessResponse{
+		Message: "User Blocked Successfully",
+	})
+}
+
+// UnblockUser godoc
+// @Summary      Unblock a user
+// @Description  Removes the block of the logged-in user on a user identified by username, email, or user ID.
+// @Tags         profile
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        identifier path string true "User Identifier (username, email, or user ID) of the user to unblock"
+// @Success      200 {object} models.UnblockUserSuccessResponse "Successfully unblocked user"
+// @Failure      400 {object} models.UnblockUserErrorResponse "Bad Request - Invalid input"
+// @Failure      401 {object} models.UnblockUserErrorResponse "Unauthorized - User not logged in or invalid token"
+// @Failure      403 {object} models.UnblockUserErrorResponse "Forbidden - User account is inactive or banned"
+// @Failure      404 {object} models.UnblockUserErrorResponse "Not Found - User not found or not blocked"
+// @Failure      500 {object} models.UnblockUserErrorResponse "Internal Server Error - Failed to unblock user"
+// @Router       /user/blocks/{identifier} [delete]
+func (pc *ProfileController) UnblockUser(c *gin.Context) {
+	userModel := helpers.RequireUser(c)
+
+	identifier := c.Param("identifier")
+	if identifier == "" {
+		pc.logger.WithFields(logrus.Fields{"userID": userModel.ID}).Error("Identifier is missing in the request path")
+		c.JSON(http.StatusBadRequest, models.UnblockUserErrorResponse{
+			Message: "Invalid Request",
+			Error:   "identifier is required in path parameters",
+			Code:    helpers.CodeBadRequest,
+		})
+		return
+	}
+
+	blockedID, err := pc.resolveUserID(c, identifier)
+	if err != nil {
+		if errors.Is(err, stores.ErrUserNotFound) {
+			pc.logger.WithFields(logrus.Fields{"error": err, "userID": userModel.ID, "identifier": identifier}).Error("User to Unblock Not Found")
+			c.JSON(http.StatusNotFound, models.UnblockUserErrorResponse{
+				Message: "User Not Found",
+				Error:   err.Error(),
+				Code:    helpers.ErrorCode(err),
+			})
+		} else {
+			pc.logger.WithFields(logrus.Fields{"error": err, "userID": userModel.ID, "identifier": identifier}).Error("Failed to Get User to Unblock from Store")
+			c.JSON(http.StatusInternalServerError, models.UnblockUserErrorResponse{
+				Message: "Failed to Unblock User",
+				Error:   "failed to get user from database",
+				Code:    helpers.CodeInternal,
+			})
+		}
+		return
+	}
+
+	err = pc.blockStore.Unblock(c, userModel.ID, blockedID)
+	if err != nil {
+		if errors.Is(err, stores.ErrNotBlocked) {
+			pc.logger.WithFields(logrus.Fields{"error": err, "userID": userModel.ID, "blockedID": blockedID}).Error("User Not Blocked")
+			c.JSON(http.StatusNotFound, models.UnblockUserErrorResponse{
+				Message: "Unblock User Failed",
+				Error:   err.Error(),
+				Code:    helpers.ErrorCode(err),
+			})
+		} else {
+			pc.logger.WithFields(logrus.Fields{"error": err, "userID": userModel.ID, "blockedID": blockedID}).Error("Failed to Unblock User in Store")
+			c.JSON(http.StatusInternalServerError, models.UnblockUserErrorResponse{
+				Message: "Failed to Unblock User",
+				Error:   "failed to unblock user in database",
+				Code:    helpers.CodeInternal,
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, models.UnblockUserSuccessResponse{
+		Message: "User Unblocked Successfully",
+	})
+}
@@ -0,0 +1,81 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/datarohit/gopher-social-backend/database/dbtest"
+	"github.com/datarohit/gopher-social-backend/helpers"
+	"github.com/datarohit/gopher-social-backend/middlewares"
+	"github.com/datarohit/gopher-social-backend/models"
+	"github.com/datarohit/gopher-social-backend/stores"
+	"github.com/google/uuid"
+)
+
+// TestBlockAndUnblockUser blocks a user through the endpoint, checks that the block is listed and stops the blocked
+// user from liking the blocker's posts, then unblocks them and checks that liking works again.
+func TestBlockAndUnblockUser(t *testing.T) {
+	pool := dbtest.NewPool(t)
+
+	authorID := dbtest.CreateUser(t, pool, "author", 1)
+	blockedID := dbtest.CreateUser(t, pool, "blocked", 1)
+	postID := dbtest.CreatePost(t, pool, authorID)
+
+	blockStore := stores.NewBlockStore(pool)
+	pc := NewProfileController(stores.NewProfileStore(pool), stores.NewAuthStore(pool), nil, blockStore, newTestLogger())
+	plc := NewPostLikesController(stores.NewPostLikeStore(pool), stores.NewPostStore(pool), blockStore, stores.NewAuthStore(pool), newTestLogger())
+
+	authorRouter := newTestRouter(loadUser(t, pool, authorID))
+	authorRouter.GET("/user/blocks", middlewares.PaginationMiddleware(), pc.ListBlockedUsers)
+	authorRouter.POST("/user/blocks/:identifier", pc.BlockUser)
+	authorRouter.DELETE("/user/blocks/:identifier", pc.UnblockUser)
+
+	blockedRouter := newTestRouter(loadUser(t, pool, blockedID))
+	blockedRouter.POST("/post/:postID/like", plc.LikePost)
+
+	tests := []struct {
+		name       string
+		method     string
+		identifier string
+		wantStatus int
+		wantCode   string
+	}{
+		{name: "block by username", method: http.MethodPost, identifier: "blocked", wantStatus: http.StatusOK},
+		{name: "block again by user ID", method: http.MethodPost, identifier: blockedID.String(), wantStatus: http.StatusConflict, wantCode: helpers.ErrorCode(stores.ErrAlreadyBlocked)},
+		{name: "block yourself", method: http.MethodPost, identifier: "author", wantStatus: http.StatusBadRequest, wantCode: helpers.CodeBadRequest},
+		{name: "block unknown username", method: http.MethodPost, identifier: "nobody", wantStatus: http.StatusNotFound, wantCode: helpers.ErrorCode(stores.ErrUserNotFound)},
+		{name: "block unknown user ID", method: http.MethodPost, identifier: uuid.New().String(), wantStatus: http.StatusNotFound, wantCode: helpers.ErrorCode(stores.ErrUserNotFound)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := serve(authorRouter, tt.method, "/user/blocks/"+tt.identifier, "")
+			assertStatus(t, recorder, tt.wantStatus)
+			if tt.wantCode != "" {
+				assertCode(t, recorder, tt.wantCode)
+			}
+		})
+	}
+
+	recorder := serve(authorRouter, http.MethodGet, "/user/blocks", "")
+	assertStatus(t, recorder, http.StatusOK)
+	var response models.ListBlockedUsersSuccessResponse
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(response.Blocked) != 1 || response.Blocked[0].User.ID != blockedID {
+		t.Fatalf("blocked users = %+v, want only the blocked user", response.Blocked)
+	}
+
+	recorder = serve(blockedRouter, http.MethodPost, "/post/"+postID.String()+"/like", "")
+	assertStatus(t, recorder, http.StatusForbidden)
+	assertCode(t, recorder, helpers.ErrorCode(stores.ErrBlockedByAuthor))
+
+	assertStatus(t, serve(authorRouter, http.MethodDelete, "/user/blocks/blocked", ""), http.StatusOK)
+	recorder = serve(authorRouter, http.MethodDelete, "/user/blocks/blocked", "")
+	assertStatus(t, recorder, http.StatusNotFound)
+	assertCode(t, recorder, helpers.ErrorCode(stores.ErrNotBlocked))
+
+	assertStatus(t, serve(blockedRouter, http.MethodPost, "/post/"+postID.String()+"/like", ""), http.StatusOK)
+}
@@ -0,0 +1,58 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/datarohit/gopher-social-backend/helpers"
+	"github.com/datarohit/gopher-social-backend/models"
+	"github.com/datarohit/gopher-social-backend/stores"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+type RoleController struct {
+	authStore *stores.AuthStore
+	logger    *logrus.Logger
+}
+
+// NewRoleController creates a new RoleController.
+//
+// Parameters:
+//   - authStore (*stores.AuthStore): AuthStore pointer to read the roles.
+//   - logger (*logrus.Logger): Logrus logger pointer to log messages.
+//
+// Returns:
+//   - *RoleController: Pointer to the RoleController.
+func NewRoleController(authStore *stores.AuthStore, logger *logrus.Logger) *RoleController {
+	return &RoleController{
+		authStore: authStore,
+		logger:    logger,
+	}
+}
+
+// ListRoles godoc
+// @Summary      List roles
+// @Description  Lists the role catalog, the level and description of every role ordered by level, so clients can render role badges without hardcoding the level meanings. Public unless ROLES_REQUIRE_AUTH is set.
+// @Tags         roles
+// @Produce      json
+// @Success      200 {object} models.ListRolesSuccessResponse "Successfully retrieved roles"
+// @Failure      401 {object} models.ListRolesErrorResponse "Unauthorized - User not logged in or invalid token, when ROLES_REQUIRE_AUTH is set"
+// @Failure      500 {object} models.ListRolesErrorResponse "Internal Server Error - Failed to list roles"
+// @Router       /roles [get]
+func (rc *RoleController) ListRoles(c *gin.Context) {
+	roles, err := rc.authStore.ListRoles(c)
+	if err != nil {
+		rc.logger.WithFields(logrus.Fields{"error": err}).Error("Failed to List Roles from Store")
+		c.JSON(http.StatusInternalServerError, models.ListRolesErrorResponse{
+			Message: "Failed to List Roles",
+			Error:   "failed to list roles",
+			Code:    helpers.CodeInternal,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ListRolesSuccessResponse{
+		Message: "Roles Retrieved Successfully",
+		Roles:   roles,
+	})
+}
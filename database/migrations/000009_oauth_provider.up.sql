@@ -0,0 +1,3 @@
+ALTER TABLE users ADD COLUMN oauth_provider VARCHAR(32);
+
+CREATE INDEX idx_users_oauth_provider ON users (oauth_provider);
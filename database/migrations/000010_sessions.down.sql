@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_sessions_user_id;
+
+DROP TABLE IF EXISTS sessions;
+
+DROP EXTENSION IF EXISTS "uuid-ossp";
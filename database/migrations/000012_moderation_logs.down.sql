@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_moderation_logs_actor_id;
+DROP INDEX IF EXISTS idx_moderation_logs_target_user_id;
+
+DROP TABLE IF EXISTS moderation_logs;
+
+DROP EXTENSION IF EXISTS "uuid-ossp";
@@ -0,0 +1,15 @@
+CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+
+CREATE TABLE moderation_logs (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4 (),
+    actor_id UUID,
+    target_user_id UUID,
+    action VARCHAR(64) NOT NULL,
+    details TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    FOREIGN KEY (actor_id) REFERENCES users(id) ON DELETE SET NULL,
+    FOREIGN KEY (target_user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_moderation_logs_target_user_id ON moderation_logs (target_user_id, created_at);
+CREATE INDEX idx_moderation_logs_actor_id ON moderation_logs (actor_id);
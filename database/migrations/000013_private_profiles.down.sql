@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_follow_requests_target_id;
+
+DROP TABLE IF EXISTS follow_requests;
+
+ALTER TABLE profiles DROP COLUMN IF EXISTS is_private;
+
+DROP EXTENSION IF EXISTS "uuid-ossp";
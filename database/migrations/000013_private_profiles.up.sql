@@ -0,0 +1,15 @@
+CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+
+ALTER TABLE profiles ADD COLUMN is_private BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE follow_requests (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4 (),
+    requester_id UUID NOT NULL,
+    target_id UUID NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (requester_id, target_id),
+    FOREIGN KEY (requester_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (target_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_follow_requests_target_id ON follow_requests (target_id, created_at);
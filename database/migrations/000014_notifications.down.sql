@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_notifications_user_id_unread;
+DROP INDEX IF EXISTS idx_notifications_user_id;
+
+DROP TABLE IF EXISTS notifications;
+
+DROP EXTENSION IF EXISTS "uuid-ossp";
@@ -0,0 +1,3 @@
+ALTER TABLE comments DROP CONSTRAINT IF EXISTS comments_content_length_check;
+
+ALTER TABLE posts DROP CONSTRAINT IF EXISTS posts_content_length_check;
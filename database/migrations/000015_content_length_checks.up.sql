@@ -0,0 +1,3 @@
+ALTER TABLE posts ADD CONSTRAINT posts_content_length_check CHECK (char_length(content) <= 50000) NOT VALID;
+
+ALTER TABLE comments ADD CONSTRAINT comments_content_length_check CHECK (char_length(content) <= 500) NOT VALID;
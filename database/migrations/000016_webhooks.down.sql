@@ -0,0 +1,9 @@
+DROP TRIGGER IF EXISTS update_webhooks_updated_at ON webhooks;
+
+DROP INDEX IF EXISTS idx_webhook_dead_letters_webhook_id;
+
+DROP TABLE IF EXISTS webhook_dead_letters;
+
+DROP TABLE IF EXISTS webhooks;
+
+DROP EXTENSION IF EXISTS "uuid-ossp";
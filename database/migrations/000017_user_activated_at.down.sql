@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_users_unactivated_created_at;
+
+ALTER TABLE users DROP COLUMN IF EXISTS activated_at;
@@ -0,0 +1,5 @@
+ALTER TABLE users ADD COLUMN activated_at TIMESTAMPTZ;
+
+UPDATE users SET activated_at = updated_at WHERE is_active = TRUE OR oauth_provider IS NOT NULL OR activation_token IS NULL;
+
+CREATE INDEX idx_users_unactivated_created_at ON users (created_at) WHERE activated_at IS NULL;
@@ -0,0 +1 @@
+ALTER TABLE profiles ADD COLUMN pinned_post_id UUID REFERENCES posts(id) ON DELETE SET NULL;
@@ -0,0 +1,3 @@
+ALTER TABLE posts ADD COLUMN quoted_post_id UUID REFERENCES posts(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_posts_quoted_post_id ON posts (quoted_post_id);
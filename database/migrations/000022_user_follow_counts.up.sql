@@ -0,0 +1,41 @@
+-- Block follows and unfollows until the counts are backfilled and the trigger keeps them up to date.
+LOCK TABLE follows IN SHARE ROW EXCLUSIVE MODE;
+
+ALTER TABLE users ADD COLUMN followers_count BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE users ADD COLUMN following_count BIGINT NOT NULL DEFAULT 0;
+
+-- Follow counts change on every follow, they must not bump updated_at.
+DROP TRIGGER IF EXISTS update_users_updated_at ON users;
+CREATE TRIGGER update_users_updated_at
+BEFORE UPDATE ON users
+FOR EACH ROW
+WHEN ((to_jsonb(NEW) - 'followers_count' - 'following_count') IS DISTINCT FROM (to_jsonb(OLD) - 'followers_count' - 'following_count'))
+EXECUTE PROCEDURE update_updated_at_column();
+
+UPDATE users u SET
+    followers_count = (SELECT COUNT(*) FROM follows WHERE followee_id = u.id),
+    following_count = (SELECT COUNT(*) FROM follows WHERE follower_id = u.id);
+
+CREATE OR REPLACE FUNCTION update_follow_counts()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        UPDATE users SET
+            followers_count = followers_count + CASE WHEN id = NEW.followee_id THEN 1 ELSE 0 END,
+            following_count = following_count + CASE WHEN id = NEW.follower_id THEN 1 ELSE 0 END
+        WHERE id IN (NEW.follower_id, NEW.followee_id);
+        RETURN NEW;
+    END IF;
+
+    UPDATE users SET
+        followers_count = followers_count - CASE WHEN id = OLD.followee_id THEN 1 ELSE 0 END,
+        following_count = following_count - CASE WHEN id = OLD.follower_id THEN 1 ELSE 0 END
+    WHERE id IN (OLD.follower_id, OLD.followee_id);
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER update_follow_counts
+AFTER INSERT OR DELETE ON follows
+FOR EACH ROW
+EXECUTE PROCEDURE update_follow_counts();
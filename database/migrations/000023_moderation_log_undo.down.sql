@@ -0,0 +1 @@
+ALTER TABLE moderation_logs DROP COLUMN IF EXISTS undone_at;
@@ -0,0 +1 @@
+ALTER TABLE moderation_logs ADD COLUMN undone_at TIMESTAMPTZ;
@@ -0,0 +1,3 @@
+ALTER TABLE users ADD COLUMN pending_email VARCHAR(255);
+ALTER TABLE users ADD COLUMN email_change_token TEXT;
+ALTER TABLE users ADD COLUMN email_change_token_expiry TIMESTAMPTZ;
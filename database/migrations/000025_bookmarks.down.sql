@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_bookmarks_user_id_created_at;
+
+DROP TABLE IF EXISTS bookmarks;
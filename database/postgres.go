@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"time"
 
 	"github.com/datarohit/gopher-social-backend/helpers"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -13,6 +14,54 @@ import (
 
 var PostgresDB *pgxpool.Pool
 
+// Defaults for the connection pool settings, used when the matching environment variables are unset or invalid.
+const (
+	defaultDBMaxConns        = 10
+	defaultDBMinConns        = 0
+	defaultDBMaxConnLifetime = time.Hour
+	defaultDBMaxConnIdleTime = 30 * time.Minute
+)
+
+// applyPoolSettings sets the connection pool limits on config from DB_MAX_CONNS, DB_MIN_CONNS,
+// DB_MAX_CONN_LIFETIME and DB_MAX_CONN_IDLE_TIME. Out of range values are logged and replaced by their defaults.
+//
+// Parameters:
+//   - config (*pgxpool.Config): Pool configuration to update.
+//   - logger (*logrus.Logger): Logrus logger instance for logging.
+//
+// Returns:
+//   - None
+func applyPoolSettings(config *pgxpool.Config, logger *logrus.Logger) {
+	maxConns := helpers.GetEnvAsInt("DB_MAX_CONNS", defaultDBMaxConns)
+	if maxConns <= 0 {
+		logger.WithFields(logrus.Fields{"DB_MAX_CONNS": maxConns, "default": defaultDBMaxConns}).Warn("DB_MAX_CONNS must be positive, using default")
+		maxConns = defaultDBMaxConns
+	}
+
+	minConns := helpers.GetEnvAsInt("DB_MIN_CONNS", defaultDBMinConns)
+	if minConns < 0 || minConns > maxConns {
+		logger.WithFields(logrus.Fields{"DB_MIN_CONNS": minConns, "default": defaultDBMinConns}).Warn("DB_MIN_CONNS must be between 0 and DB_MAX_CONNS, using default")
+		minConns = defaultDBMinConns
+	}
+
+	maxConnLifetime := helpers.GetEnvAsDuration("DB_MAX_CONN_LIFETIME", defaultDBMaxConnLifetime)
+	if maxConnLifetime <= 0 {
+		logger.WithFields(logrus.Fields{"DB_MAX_CONN_LIFETIME": maxConnLifetime, "default": defaultDBMaxConnLifetime}).Warn("DB_MAX_CONN_LIFETIME must be positive, using default")
+		maxConnLifetime = defaultDBMaxConnLifetime
+	}
+
+	maxConnIdleTime := helpers.GetEnvAsDuration("DB_MAX_CONN_IDLE_TIME", defaultDBMaxConnIdleTime)
+	if maxConnIdleTime <= 0 {
+		logger.WithFields(logrus.Fields{"DB_MAX_CONN_IDLE_TIME": maxConnIdleTime, "default": defaultDBMaxConnIdleTime}).Warn("DB_MAX_CONN_IDLE_TIME must be positive, using default")
+		maxConnIdleTime = defaultDBMaxConnIdleTime
+	}
+
+	config.MaxConns = int32(maxConns)
+	config.MinConns = int32(minConns)
+	config.MaxConnLifetime = maxConnLifetime
+	config.MaxConnIdleTime = maxConnIdleTime
+}
+
 // InitPostgres initializes the PostgreSQL database connection pool.
 // It reads database connection parameters from environment variables or uses default values.
 //
@@ -44,6 +93,14 @@ func InitPostgres(logger *logrus.Logger) {
 		os.Exit(1)
 	}
 
+	applyPoolSettings(config, logger)
+	logger.WithFields(logrus.Fields{
+		"maxConns":        config.MaxConns,
+		"minConns":        config.MinConns,
+		"maxConnLifetime": config.MaxConnLifetime.String(),
+		"maxConnIdleTime": config.MaxConnIdleTime.String(),
+	}).Info("PostgreSQL Connection Pool Configured")
+
 	PostgresDB, err = pgxpool.NewWithConfig(context.Background(), config)
 	if err != nil {
 		logger.WithFields(logrus.Fields{"error": err}).Fatal("Failed to connect to PostgreSQL!")
@@ -0,0 +1,117 @@
+package database
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+)
+
+func TestApplyPoolSettings(t *testing.T) {
+	tests := []struct {
+		name                string
+		env                 map[string]string
+		wantMaxConns        int32
+		wantMinConns        int32
+		wantMaxConnLifetime time.Duration
+		wantMaxConnIdleTime time.Duration
+	}{
+		{
+			name:                "defaults",
+			wantMaxConns:        defaultDBMaxConns,
+			wantMinConns:        defaultDBMinConns,
+			wantMaxConnLifetime: defaultDBMaxConnLifetime,
+			wantMaxConnIdleTime: defaultDBMaxConnIdleTime,
+		},
+		{
+			name: "overrides",
+			env: map[string]string{
+				"DB_MAX_CONNS":          "40",
+				"DB_MIN_CONNS":          "5",
+				"DB_MAX_CONN_LIFETIME":  "2h",
+				"DB_MAX_CONN_IDLE_TIME": "5m",
+			},
+			wantMaxConns:        40,
+			wantMinConns:        5,
+			wantMaxConnLifetime: 2 * time.Hour,
+			wantMaxConnIdleTime: 5 * time.Minute,
+		},
+		{
+			name: "min equal to max",
+			env: map[string]string{
+				"DB_MAX_CONNS": "4",
+				"DB_MIN_CONNS": "4",
+			},
+			wantMaxConns:        4,
+			wantMinConns:        4,
+			wantMaxConnLifetime: defaultDBMaxConnLifetime,
+			wantMaxConnIdleTime: defaultDBMaxConnIdleTime,
+		},
+		{
+			name: "unparsable values",
+			env: map[string]string{
+				"DB_MAX_CONNS":          "lots",
+				"DB_MIN_CONNS":          "few",
+				"DB_MAX_CONN_LIFETIME":  "forever",
+				"DB_MAX_CONN_IDLE_TIME": "30",
+			},
+			wantMaxConns:        defaultDBMaxConns,
+			wantMinConns:        defaultDBMinConns,
+			wantMaxConnLifetime: defaultDBMaxConnLifetime,
+			wantMaxConnIdleTime: defaultDBMaxConnIdleTime,
+		},
+		{
+			name: "out of range values",
+			env: map[string]string{
+				"DB_MAX_CONNS":          "0",
+				"DB_MIN_CONNS":          "-1",
+				"DB_MAX_CONN_LIFETIME":  "-1h",
+				"DB_MAX_CONN_IDLE_TIME": "0s",
+			},
+			wantMaxConns:        defaultDBMaxConns,
+			wantMinConns:        defaultDBMinConns,
+			wantMaxConnLifetime: defaultDBMaxConnLifetime,
+			wantMaxConnIdleTime: defaultDBMaxConnIdleTime,
+		},
+		{
+			name: "min above max",
+			env: map[string]string{
+				"DB_MAX_CONNS": "4",
+				"DB_MIN_CONNS": "8",
+			},
+			wantMaxConns:        4,
+			wantMinConns:        defaultDBMinConns,
+			wantMaxConnLifetime: defaultDBMaxConnLifetime,
+			wantMaxConnIdleTime: defaultDBMaxConnIdleTime,
+		},
+	}
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, name := range []string{"DB_MAX_CONNS", "DB_MIN_CONNS", "DB_MAX_CONN_LIFETIME", "DB_MAX_CONN_IDLE_TIME"} {
+				t.Setenv(name, tt.env[name])
+			}
+
+			config := &pgxpool.Config{}
+			applyPoolSettings(config, logger)
+
+			if config.MaxConns != tt.wantMaxConns {
+				t.Errorf("MaxConns = %d, want %d", config.MaxConns, tt.wantMaxConns)
+			}
+			if config.MinConns != tt.wantMinConns {
+				t.Errorf("MinConns = %d, want %d", config.MinConns, tt.wantMinConns)
+			}
+			if config.MaxConnLifetime != tt.wantMaxConnLifetime {
+				t.Errorf("MaxConnLifetime = %s, want %s", config.MaxConnLifetime, tt.wantMaxConnLifetime)
+			}
+			if config.MaxConnIdleTime != tt.wantMaxConnIdleTime {
+				t.Errorf("MaxConnIdleTime = %s, want %s", config.MaxConnIdleTime, tt.wantMaxConnIdleTime)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package helpers
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/datarohit/gopher-social-backend/stores"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	accountCleanupInterval    = time.Duration(GetEnvAsInt("ACCOUNT_CLEANUP_INTERVAL_MINUTES", 60)) * time.Minute
+	unactivatedAccountGrace   = time.Duration(GetEnvAsInt("UNACTIVATED_ACCOUNT_GRACE_PERIOD_HOURS", 168)) * time.Hour
+	accountCleanupRunDeadline = 5 * time.Minute
+)
+
+// AccountCleanupJob periodically clears expired activation and password reset tokens and deletes
+// accounts that were never activated within the grace period.
+type AccountCleanupJob struct {
+	authStore *stores.AuthStore
+	stop      chan struct{}
+	stopOnce  sync.Once
+	done      chan struct{}
+	logger    *logrus.Logger
+}
+
+// NewAccountCleanupJob creates a new AccountCleanupJob and starts its background goroutine.
+// The first run happens one interval after the job is started.
+//
+// Parameters:
+//   - authStore (*stores.AuthStore): AuthStore pointer to clear tokens and delete accounts.
+//   - logger (*logrus.Logger): Logrus logger pointer to log messages.
+//
+// Returns:
+//   - *AccountCleanupJob: AccountCleanupJob instance.
+func NewAccountCleanupJob(authStore *stores.AuthStore, logger *logrus.Logger) *AccountCleanupJob {
+	job := &AccountCleanupJob{
+		authStore: authStore,
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+		logger:    logger,
+	}
+
+	go job.run()
+
+	return job
+}
+
+// Shutdown stops the job and waits until a run in progress has finished or ctx is done.
+//
+// Parameters:
+//   - ctx (context.Context): Context bounding how long to wait for the job to stop.
+//
+// Returns:
+//   - error: ctx.Err() if the job did not stop in time, nil otherwise.
+func (job *AccountCleanupJob) Shutdown(ctx context.Context) error {
+	job.stopOnce.Do(func() { close(job.stop) })
+
+	select {
+	case <-job.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// run calls cleanup on every tick until the job is stopped.
+func (job *AccountCleanupJob) run() {
+	defer close(job.done)
+
+	ticker := time.NewTicker(accountCleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-job.stop:
+			return
+		case <-ticker.C:
+			job.cleanup()
+		}
+	}
+}
+
+// cleanup clears expired tokens and purges unactivated accounts once, and logs a summary.
+// A run in progress is cancelled when the job is stopped.
+func (job *AccountCleanupJob) cleanup() {
+	ctx, cancel := context.WithTimeout(context.Background(), accountCleanupRunDeadline)
+	defer cancel()
+
+	go func() {
+		select {
+		case <-job.stop:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	now := time.Now()
+
+	activationTokens, resetTokens, err := job.authStore.ClearExpiredTokens(ctx, now)
+	if err != nil {
+		job.logger.WithFields(logrus.Fields{"error": err}).Error("Failed to Clear Expired Tokens!")
+		return
+	}
+
+	purgedUsers, err := job.authStore.PurgeUnactivatedUsers(ctx, now.Add(-unactivatedAccountGrace))
+	if err != nil {
+		job.logger.WithFields(logrus.Fields{"error": err}).Error("Failed to Purge Unactivated Accounts!")
+		return
+	}
+
+	job.logger.WithFields(logrus.Fields{
+		"activation_tokens_cleared": activationTokens,
+		"reset_tokens_cleared":      resetTokens,
+		"unactivated_users_purged":  purgedUsers,
+		"duration_ms":               time.Since(now).Milliseconds(),
+	}).Info("Account Cleanup Completed!")
+}
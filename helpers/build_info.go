@@ -0,0 +1,62 @@
+package helpers
+
+import (
+	"runtime"
+	"runtime/debug"
+	"time"
+
+	"github.com/datarohit/gopher-social-backend/models"
+)
+
+// Build details injected at build time, for example:
+//
+//	go build -ldflags "-X github.com/datarohit/gopher-social-backend/helpers.Version=v1.2.0 -X github.com/datarohit/gopher-social-backend/helpers.Commit=$(git rev-parse HEAD) -X github.com/datarohit/gopher-social-backend/helpers.BuildTime=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+//
+// When they are not injected, the commit and build time fall back to the VCS details embedded by the Go toolchain.
+var (
+	Version   = "dev"
+	Commit    = ""
+	BuildTime = ""
+)
+
+// startTime is when the process started, used to report uptime.
+var startTime = time.Now()
+
+// GetBuildInfo returns the details of the running build.
+//
+// Parameters:
+//   - None
+//
+// Returns:
+//   - models.BuildInfo: Version, commit, build time and Go version of the running build.
+func GetBuildInfo() models.BuildInfo {
+	info := models.BuildInfo{
+		Version:   Version,
+		Commit:    Commit,
+		BuildTime: BuildTime,
+		GoVersion: runtime.Version(),
+	}
+
+	if buildInfo, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range buildInfo.Settings {
+			if setting.Key == "vcs.revision" && info.Commit == "" {
+				info.Commit = setting.Value
+			} else if setting.Key == "vcs.time" && info.BuildTime == "" {
+				info.BuildTime = setting.Value
+			}
+		}
+	}
+
+	return info
+}
+
+// Uptime returns how long the process has been running.
+//
+// Parameters:
+//   - None
+//
+// Returns:
+//   - time.Duration: Time since the process started.
+func Uptime() time.Duration {
+	return time.Since(startTime)
+}
@@ -0,0 +1,45 @@
+package helpers
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// Maximum content lengths in characters. They can only tighten the limits enforced by the
+// payload binding tags and the database CHECK constraints, never loosen them.
+var (
+	MaxPostTitleLength      = GetEnvAsInt("POST_TITLE_MAX_LENGTH", 255)
+	MaxPostContentLength    = GetEnvAsInt("POST_CONTENT_MAX_LENGTH", 50000)
+	MaxCommentContentLength = GetEnvAsInt("COMMENT_CONTENT_MAX_LENGTH", 500)
+)
+
+// CheckMaxLength checks that a field does not exceed its maximum length in characters.
+//
+// Parameters:
+//   - field (string): JSON name of the field, used in the error message.
+//   - value (string): Value of the field.
+//   - maxLength (int): Maximum allowed length in characters.
+//
+// Returns:
+//   - error: An error naming the offending field if the value is too long, nil otherwise.
+func CheckMaxLength(field string, value string, maxLength int) error {
+	if utf8.RuneCountInString(value) > maxLength {
+		return fmt.Errorf("%s exceeds the maximum length of %d characters", field, maxLength)
+	}
+	return nil
+}
+
+// CheckPostLengths checks the title and content of a post against the configured maximum lengths.
+//
+// Parameters:
+//   - title (string): Title of the post.
+//   - content (string): Content of the post.
+//
+// Returns:
+//   - error: An error naming the first offending field, nil if both are within limits.
+func CheckPostLengths(title string, content string) error {
+	if err := CheckMaxLength("title", title, MaxPostTitleLength); err != nil {
+		return err
+	}
+	return CheckMaxLength("content", content, MaxPostContentLength)
+}
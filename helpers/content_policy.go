@@ -0,0 +1,142 @@
+package helpers
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// ErrContentViolatesPolicy is returned when content contains a word or phrase of the content policy.
+// It never names the matched word, so clients cannot probe the list.
+var ErrContentViolatesPolicy = errors.New("content violates policy")
+
+// ContentPolicy rejects content containing disallowed words or phrases, read from a word list file.
+// The file has one word or phrase per line, blank lines and lines starting with # are ignored.
+// Words are matched case insensitively against whole words of the content, phrases against consecutive words.
+// A policy without a word list file is disabled and accepts all content.
+type ContentPolicy struct {
+	path    string
+	mu      sync.RWMutex
+	words   map[string]bool
+	phrases [][]string
+}
+
+// NewContentPolicy creates a ContentPolicy and loads its word list.
+//
+// Parameters:
+//   - path (string): Path of the word list file, empty to disable the policy.
+//
+// Returns:
+//   - *ContentPolicy: ContentPolicy instance.
+//   - error: An error if the word list file cannot be read.
+func NewContentPolicy(path string) (*ContentPolicy, error) {
+	contentPolicy := &ContentPolicy{path: path}
+	if err := contentPolicy.Reload(); err != nil {
+		return nil, err
+	}
+	return contentPolicy, nil
+}
+
+// Enabled reports whether the policy has a word list file.
+//
+// Returns:
+//   - bool: True if content is checked against a word list.
+func (cp *ContentPolicy) Enabled() bool {
+	return cp != nil && cp.path != ""
+}
+
+// Reload reads the word list file again, so edits take effect without a restart.
+// If the file cannot be read, the previously loaded list is kept.
+//
+// Returns:
+//   - error: An error if the word list file cannot be read.
+func (cp *ContentPolicy) Reload() error {
+	if !cp.Enabled() {
+		return nil
+	}
+
+	file, err := os.Open(cp.path)
+	if err != nil {
+		return fmt.Errorf("failed to open content policy word list: %w", err)
+	}
+	defer file.Close()
+
+	words := make(map[string]bool)
+	var phrases [][]string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		tokens := contentTokens(line)
+		if len(tokens) == 1 {
+			words[tokens[0]] = true
+		} else if len(tokens) > 1 {
+			phrases = append(phrases, tokens)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read content policy word list: %w", err)
+	}
+
+	cp.mu.Lock()
+	cp.words = words
+	cp.phrases = phrases
+	cp.mu.Unlock()
+	return nil
+}
+
+// Check checks content against the word list.
+//
+// Parameters:
+//   - content (string): Content to check.
+//
+// Returns:
+//   - error: ErrContentViolatesPolicy if the content contains a disallowed word or phrase, nil otherwise.
+func (cp *ContentPolicy) Check(content string) error {
+	if !cp.Enabled() {
+		return nil
+	}
+
+	cp.mu.RLock()
+	defer cp.mu.RUnlock()
+
+	tokens := contentTokens(content)
+	for i, token := range tokens {
+		if cp.words[token] {
+			return ErrContentViolatesPolicy
+		}
+		for _, phrase := range cp.phrases {
+			if hasTokensAt(tokens, i, phrase) {
+				return ErrContentViolatesPolicy
+			}
+		}
+	}
+	return nil
+}
+
+// contentTokens splits text into lower case words, separated by anything other than letters and digits.
+func contentTokens(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// hasTokensAt reports whether tokens contains phrase starting at index start.
+func hasTokensAt(tokens []string, start int, phrase []string) bool {
+	if start+len(phrase) > len(tokens) {
+		return false
+	}
+	for j, word := range phrase {
+		if tokens[start+j] != word {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,117 @@
+package helpers
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeWordList writes a content policy word list to path.
+func writeWordList(t *testing.T, path string, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write word list: %v", err)
+	}
+}
+
+func TestContentPolicyCheck(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "words.txt")
+	writeWordList(t, path, "# disallowed words\n\nspam\nBuy Now\n")
+
+	policy, err := NewContentPolicy(path)
+	if err != nil {
+		t.Fatalf("NewContentPolicy() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		content string
+		wantErr bool
+	}{
+		{name: "clean content", content: "A post about gophers."},
+		{name: "word", content: "this is spam", wantErr: true},
+		{name: "word in upper case", content: "THIS IS SPAM", wantErr: true},
+		{name: "word in mixed case with punctuation", content: "Totally not SpAm!", wantErr: true},
+		{name: "word as substring", content: "spammer and spamming are fine"},
+		{name: "word inside another word", content: "antispam filters"},
+		{name: "phrase", content: "please buy now", wantErr: true},
+		{name: "phrase across punctuation and case", content: "BUY... now!", wantErr: true},
+		{name: "phrase words apart", content: "buy it now"},
+		{name: "phrase word alone", content: "buy a gopher"},
+		{name: "comment line is not a word", content: "disallowed words"},
+		{name: "empty content", content: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := policy.Check(tt.content)
+			if tt.wantErr && !errors.Is(err, ErrContentViolatesPolicy) {
+				t.Fatalf("Check(%q) error = %v, want ErrContentViolatesPolicy", tt.content, err)
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("Check(%q) error = %v, want nil", tt.content, err)
+			}
+		})
+	}
+}
+
+func TestContentPolicyEmptyList(t *testing.T) {
+	disabled, err := NewContentPolicy("")
+	if err != nil {
+		t.Fatalf("NewContentPolicy(\"\") error = %v", err)
+	}
+	if disabled.Enabled() {
+		t.Fatal("policy without a word list is enabled")
+	}
+	if err := disabled.Check("spam"); err != nil {
+		t.Fatalf("disabled policy Check() error = %v, want nil", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "words.txt")
+	writeWordList(t, path, "# nothing yet\n\n")
+	empty, err := NewContentPolicy(path)
+	if err != nil {
+		t.Fatalf("NewContentPolicy() error = %v", err)
+	}
+	if err := empty.Check("spam"); err != nil {
+		t.Fatalf("empty word list Check() error = %v, want nil", err)
+	}
+}
+
+func TestContentPolicyReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "words.txt")
+	writeWordList(t, path, "spam\n")
+
+	policy, err := NewContentPolicy(path)
+	if err != nil {
+		t.Fatalf("NewContentPolicy() error = %v", err)
+	}
+
+	writeWordList(t, path, "scam\n")
+	if err := policy.Reload(); err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+	if err := policy.Check("spam"); err != nil {
+		t.Fatalf("word removed by reload still rejected: %v", err)
+	}
+	if err := policy.Check("a scam"); !errors.Is(err, ErrContentViolatesPolicy) {
+		t.Fatalf("word added by reload Check() error = %v, want ErrContentViolatesPolicy", err)
+	}
+
+	if err := os.Remove(path); err != nil {
+		t.Fatalf("failed to remove word list: %v", err)
+	}
+	if err := policy.Reload(); err == nil {
+		t.Fatal("Reload() of a missing file error = nil, want an error")
+	}
+	if err := policy.Check("a scam"); !errors.Is(err, ErrContentViolatesPolicy) {
+		t.Fatal("failed reload dropped the previously loaded list")
+	}
+}
+
+func TestNewContentPolicyMissingFile(t *testing.T) {
+	if _, err := NewContentPolicy(filepath.Join(t.TempDir(), "missing.txt")); err == nil {
+		t.Fatal("NewContentPolicy() of a missing file error = nil, want an error")
+	}
+}
@@ -0,0 +1,44 @@
+package helpers
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ErrInvalidEnumValue is returned when a request parameter is not one of its allowed values.
+var ErrInvalidEnumValue = errors.New("invalid enum value")
+
+// EnumError describes a request parameter value outside of its allowed set.
+// It matches ErrInvalidEnumValue with errors.Is, and its message lists the allowed values.
+type EnumError struct {
+	Value   string
+	Allowed []string
+}
+
+// Error returns the allowed values and the rejected value, meant to follow the parameter name
+// such as "sort must be one of newest, oldest, got \"top\"".
+func (e *EnumError) Error() string {
+	return fmt.Sprintf("must be one of %s, got %q", strings.Join(e.Allowed, ", "), e.Value)
+}
+
+// Unwrap returns ErrInvalidEnumValue, so every EnumError maps to the same error code.
+func (e *EnumError) Unwrap() error {
+	return ErrInvalidEnumValue
+}
+
+// ValidateEnum checks that a request parameter is one of its allowed values, compared case sensitively.
+//
+// Parameters:
+//   - value (string): Value of the parameter.
+//   - allowed ([]string): Allowed values, in the order they are listed in the error.
+//
+// Returns:
+//   - error: An *EnumError if the value is not allowed, nil otherwise.
+func ValidateEnum(value string, allowed []string) error {
+	if slices.Contains(allowed, value) {
+		return nil
+	}
+	return &EnumError{Value: value, Allowed: allowed}
+}
@@ -0,0 +1,53 @@
+package helpers
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateEnum(t *testing.T) {
+	allowed := []string{"newest", "oldest", "most_liked"}
+
+	tests := []struct {
+		name    string
+		value   string
+		wantErr string
+	}{
+		{name: "first allowed value", value: "newest"},
+		{name: "last allowed value", value: "most_liked"},
+		{name: "disallowed value", value: "top", wantErr: `must be one of newest, oldest, most_liked, got "top"`},
+		{name: "case sensitive", value: "Newest", wantErr: `must be one of newest, oldest, most_liked, got "Newest"`},
+		{name: "empty string", value: "", wantErr: `must be one of newest, oldest, most_liked, got ""`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateEnum(tt.value, allowed)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("ValidateEnum(%q) error = %v, want nil", tt.value, err)
+				}
+				return
+			}
+
+			if err == nil {
+				t.Fatalf("ValidateEnum(%q) error = nil, want %q", tt.value, tt.wantErr)
+			}
+			if err.Error() != tt.wantErr {
+				t.Fatalf("ValidateEnum(%q) error = %q, want %q", tt.value, err.Error(), tt.wantErr)
+			}
+			if !errors.Is(err, ErrInvalidEnumValue) {
+				t.Fatalf("ValidateEnum(%q) error does not match ErrInvalidEnumValue", tt.value)
+			}
+			if ErrorCode(err) != "INVALID_ENUM_VALUE" {
+				t.Fatalf("ErrorCode(ValidateEnum(%q)) = %q, want INVALID_ENUM_VALUE", tt.value, ErrorCode(err))
+			}
+		})
+	}
+}
+
+func TestValidateEnumEmptyAllowedSet(t *testing.T) {
+	if err := ValidateEnum("", nil); !errors.Is(err, ErrInvalidEnumValue) {
+		t.Fatalf("ValidateEnum with no allowed values error = %v, want ErrInvalidEnumValue", err)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // GetEnv returns the value of an environment variable or
@@ -49,3 +50,53 @@ func GetEnvAsInt(env string, defaultValue int) int {
 
 	return value
 }
+
+// GetEnvAsBool returns the value of an environment variable as a boolean or
+// a default value if the environment variable is not set or is not a valid boolean such as true, false, 1 or 0.
+// The value is trimmed of leading and trailing whitespace.
+//
+// Parameters:
+//   - env (string): The name of the environment variable.
+//   - defaultValue (bool): The default value to return if the environment variable is not set or is not a valid boolean.
+//
+// Returns:
+//   - value (bool): The value of the environment variable as a boolean or the default value.
+func GetEnvAsBool(env string, defaultValue bool) bool {
+	environment := strings.TrimSpace(os.Getenv(env))
+	if environment == "" {
+		return defaultValue
+	}
+
+	value, err := strconv.ParseBool(environment)
+	if err != nil {
+		log.Printf("Warning: %s is not a valid boolean. Using default value: %t", env, defaultValue)
+		return defaultValue
+	}
+
+	return value
+}
+
+// GetEnvAsDuration returns the value of an environment variable as a time.Duration or
+// a default value if the environment variable is not set or is not a valid Go duration such as 30m or 1h.
+// The value is trimmed of leading and trailing whitespace.
+//
+// Parameters:
+//   - env (string): The name of the environment variable.
+//   - defaultValue (time.Duration): The default value to return if the environment variable is not set or invalid.
+//
+// Returns:
+//   - time.Duration: The value of the environment variable as a duration or the default value.
+func GetEnvAsDuration(env string, defaultValue time.Duration) time.Duration {
+	environment := strings.TrimSpace(os.Getenv(env))
+	if environment == "" {
+		return defaultValue
+	}
+
+	value, err := time.ParseDuration(environment)
+	if err != nil {
+		log.Printf("Warning: %s is not a valid duration. Using default value: %s", env, defaultValue)
+		return defaultValue
+	}
+
+	return value
+}
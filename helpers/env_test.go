@@ -0,0 +1,98 @@
+package helpers
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGetEnv(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{name: "unset", value: "", want: "fallback"},
+		{name: "whitespace only", value: "   ", want: "fallback"},
+		{name: "set", value: "value", want: "value"},
+		{name: "trimmed", value: " value\n", want: "value"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("TEST_ENV_VALUE", tt.value)
+			if got := GetEnv("TEST_ENV_VALUE", "fallback"); got != tt.want {
+				t.Fatalf("GetEnv() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetEnvAsInt(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  int
+	}{
+		{name: "unset", value: "", want: 10},
+		{name: "override", value: "25", want: 25},
+		{name: "trimmed", value: " 25 ", want: 25},
+		{name: "negative", value: "-3", want: -3},
+		{name: "invalid", value: "many", want: 10},
+		{name: "float", value: "2.5", want: 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("TEST_ENV_INT", tt.value)
+			if got := GetEnvAsInt("TEST_ENV_INT", 10); got != tt.want {
+				t.Fatalf("GetEnvAsInt() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetEnvAsBool(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  bool
+	}{
+		{name: "unset", value: "", want: true},
+		{name: "false", value: "false", want: false},
+		{name: "zero", value: "0", want: false},
+		{name: "trimmed", value: " FALSE ", want: false},
+		{name: "invalid", value: "no", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("TEST_ENV_BOOL", tt.value)
+			if got := GetEnvAsBool("TEST_ENV_BOOL", true); got != tt.want {
+				t.Fatalf("GetEnvAsBool() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetEnvAsDuration(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  time.Duration
+	}{
+		{name: "unset", value: "", want: time.Hour},
+		{name: "override", value: "15m", want: 15 * time.Minute},
+		{name: "trimmed", value: " 90s ", want: 90 * time.Second},
+		{name: "missing unit", value: "30", want: time.Hour},
+		{name: "invalid", value: "soon", want: time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("TEST_ENV_DURATION", tt.value)
+			if got := GetEnvAsDuration("TEST_ENV_DURATION", time.Hour); got != tt.want {
+				t.Fatalf("GetEnvAsDuration() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
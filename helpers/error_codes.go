@@ -0,0 +1,132 @@
+package helpers
+
+import (
+	"errors"
+
+	"github.com/datarohit/gopher-social-backend/stores"
+)
+
+// Generic error codes returned in the code field of error responses.
+const (
+	CodeBadRequest            = "BAD_REQUEST"
+	CodeValidationFailed      = "VALIDATION_FAILED"
+	CodeUnauthorized          = "UNAUTHORIZED"
+	CodeInvalidCredentials    = "INVALID_CREDENTIALS"
+	CodeSessionRevoked        = "SESSION_REVOKED"
+	CodeForbidden             = "FORBIDDEN"
+	CodeAccountNotActivated   = "ACCOUNT_NOT_ACTIVATED"
+	CodeAccountBanned         = "ACCOUNT_BANNED"
+	CodeAccountTimedOut       = "ACCOUNT_TIMED_OUT"
+	CodeAccountLocked         = "ACCOUNT_LOCKED"
+	CodeImpersonationReadOnly = "IMPERSONATION_READ_ONLY"
+	CodeNotFound              = "NOT_FOUND"
+	CodeConflict              = "CONFLICT"
+	CodeRequestTimeout        = "REQUEST_TIMEOUT"
+	CodeRateLimited           = "RATE_LIMITED"
+	CodeInternal              = "INTERNAL_ERROR"
+	CodeBadGateway            = "BAD_GATEWAY"
+	CodeServiceUnavailable    = "SERVICE_UNAVAILABLE"
+)
+
+// errorCodes maps the sentinel errors of the stores and helpers packages to stable error codes.
+var errorCodes = []struct {
+	err  error
+	code string
+}{
+	{stores.ErrUserAlreadyExists, "USER_ALREADY_EXISTS"},
+	{stores.ErrUserNotFound, "USER_NOT_FOUND"},
+	{stores.ErrUserAlreadyActivated, "USER_ALREADY_ACTIVATED"},
+	{stores.ErrInvalidOrExpiredToken, "INVALID_OR_EXPIRED_RESET_TOKEN"},
+	{stores.ErrInvalidOrExpiredActivationToken, "INVALID_OR_EXPIRED_ACTIVATION_TOKEN"},
+	{stores.ErrInvalidOrExpiredEmailChangeToken, "INVALID_OR_EXPIRED_EMAIL_CHANGE_TOKEN"},
+	{stores.ErrEmailAlreadyInUse, "EMAIL_ALREADY_IN_USE"},
+	{stores.ErrRoleNotFound, "ROLE_NOT_FOUND"},
+	{stores.ErrAdminCannotChangeAdminRole, "ADMIN_CANNOT_CHANGE_ADMIN_ROLE"},
+	{stores.ErrLastAdminCannotBeDemoted, "LAST_ADMIN_CANNOT_BE_DEMOTED"},
+	{stores.ErrAdminCannotTimeoutAdmin, "ADMIN_CANNOT_TIMEOUT_ADMIN"},
+	{stores.ErrModeratorCannotTimeoutModeratorOrAdmin, "MODERATOR_CANNOT_TIMEOUT_STAFF"},
+	{stores.ErrAdminCannotDeactivateAdmin, "ADMIN_CANNOT_DEACTIVATE_ADMIN"},
+	{stores.ErrModeratorCannotDeactivateModeratorOrAdmin, "MODERATOR_CANNOT_DEACTIVATE_STAFF"},
+	{stores.ErrAdminCannotActivateAdmin, "ADMIN_CANNOT_ACTIVATE_ADMIN"},
+	{stores.ErrModeratorCannotActivateModeratorOrAdmin, "MODERATOR_CANNOT_ACTIVATE_STAFF"},
+	{stores.ErrAdminCannotBanAdmin, "ADMIN_CANNOT_BAN_ADMIN"},
+	{stores.ErrAdminCannotUnbanAdmin, "ADMIN_CANNOT_UNBAN_ADMIN"},
+	{stores.ErrAdminCannotLogoutAdmin, "ADMIN_CANNOT_LOGOUT_ADMIN"},
+	{stores.ErrAdminCannotImpersonateAdmin, "ADMIN_CANNOT_IMPERSONATE_ADMIN"},
+	{stores.ErrAdminOnlyOperation, "ADMIN_ONLY_OPERATION"},
+	{stores.ErrInvalidTimeoutSort, "INVALID_SORT"},
+	{stores.ErrCannotTransferPostToBannedUser, "CANNOT_TRANSFER_POST_TO_BANNED_USER"},
+	{stores.ErrCannotTransferPostToInactiveUser, "CANNOT_TRANSFER_POST_TO_INACTIVE_USER"},
+	{stores.ErrPostAlreadyOwnedByUser, "POST_ALREADY_OWNED_BY_USER"},
+	{stores.ErrModerationLogNotFound, "MODERATION_LOG_NOT_FOUND"},
+	{stores.ErrModerationActionNotUndoable, "MODERATION_ACTION_NOT_UNDOABLE"},
+	{stores.ErrCannotUndoHigherRoleAction, "CANNOT_UNDO_HIGHER_ROLE_ACTION"},
+	{stores.ErrModerationActionAlreadyUndone, "MODERATION_ACTION_ALREADY_UNDONE"},
+	{stores.ErrModerationActionSuperseded, "MODERATION_ACTION_SUPERSEDED"},
+	{stores.ErrUndoWindowExpired, "UNDO_WINDOW_EXPIRED"},
+	{stores.ErrSessionNotFound, "SESSION_NOT_FOUND"},
+	{stores.ErrSessionRevoked, CodeSessionRevoked},
+	{stores.ErrCommentNotFound, "COMMENT_NOT_FOUND"},
+	{stores.ErrCommentLimitReached, "COMMENT_LIMIT_REACHED"},
+	{stores.ErrCommentLikeAlreadyExists, "COMMENT_ALREADY_LIKED"},
+	{stores.ErrCommentDislikeAlreadyExists, "COMMENT_ALREADY_DISLIKED"},
+	{stores.ErrCommentLikeNotFound, "COMMENT_LIKE_NOT_FOUND"},
+	{stores.ErrCommentDislikeNotFound, "COMMENT_DISLIKE_NOT_FOUND"},
+	{stores.ErrPostNotFound, "POST_NOT_FOUND"},
+	{stores.ErrInvalidPostSort, "INVALID_SORT"},
+	{stores.ErrInvalidPostExportFormat, "INVALID_EXPORT_FORMAT"},
+	{stores.ErrPostNotPinned, "POST_NOT_PINNED"},
+	{stores.ErrQuotedPostNotFound, "QUOTED_POST_NOT_FOUND"},
+	{stores.ErrPostCannotBeQuoted, "POST_CANNOT_BE_QUOTED"},
+	{stores.ErrAlreadyFollowing, "ALREADY_FOLLOWING"},
+	{stores.ErrNotFollowing, "NOT_FOLLOWING"},
+	{stores.ErrFollowRequestAlreadyExists, "FOLLOW_REQUEST_ALREADY_EXISTS"},
+	{stores.ErrFollowRequestNotFound, "FOLLOW_REQUEST_NOT_FOUND"},
+	{stores.ErrPrivateAccount, "PRIVATE_ACCOUNT"},
+	{stores.ErrCannotFollowInactiveUser, "CANNOT_FOLLOW_INACTIVE_USER"},
+	{stores.ErrPostLikeAlreadyExists, "POST_ALREADY_LIKED"},
+	{stores.ErrPostDislikeAlreadyExists, "POST_ALREADY_DISLIKED"},
+	{stores.ErrPostLikeNotFound, "POST_LIKE_NOT_FOUND"},
+	{stores.ErrPostDislikeNotFound, "POST_DISLIKE_NOT_FOUND"},
+	{stores.ErrInvalidReactionTimelineBucket, "INVALID_BUCKET"},
+	{stores.ErrInvalidReactionTimelineRange, "INVALID_RANGE"},
+	{stores.ErrBookmarkAlreadyExists, "BOOKMARK_ALREADY_EXISTS"},
+	{stores.ErrBookmarkNotFound, "BOOKMARK_NOT_FOUND"},
+	{stores.ErrProfileNotFound, "PROFILE_NOT_FOUND"},
+	{stores.ErrInvalidActivityGranularity, "INVALID_GRANULARITY"},
+	{stores.ErrInvalidActivityRange, "INVALID_RANGE"},
+	{stores.ErrBlockedByAuthor, "BLOCKED_BY_AUTHOR"},
+	{stores.ErrAlreadyBlocked, "ALREADY_BLOCKED"},
+	{stores.ErrNotBlocked, "NOT_BLOCKED"},
+	{stores.ErrInvalidAPIKey, "INVALID_API_KEY"},
+	{stores.ErrAPIKeyNotFound, "API_KEY_NOT_FOUND"},
+	{stores.ErrAPIKeyLimitReached, "API_KEY_LIMIT_REACHED"},
+	{ErrUsernameInvalidLength, "INVALID_USERNAME_LENGTH"},
+	{ErrUsernameInvalidCharacters, "INVALID_USERNAME_CHARACTERS"},
+	{ErrUsernameReserved, "USERNAME_RESERVED"},
+	{ErrContentViolatesPolicy, "CONTENT_VIOLATES_POLICY"},
+	{ErrInvalidEnumValue, "INVALID_ENUM_VALUE"},
+}
+
+// ErrorCode returns the stable, machine-readable code for an error.
+// Sentinel errors of the stores package, also when wrapped, map to their own code.
+// Any other non-nil error maps to CodeInternal.
+//
+// Parameters:
+//   - err (error): The error to map.
+//
+// Returns:
+//   - string: The error code, or an empty string if err is nil.
+func ErrorCode(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	for _, errorCode := range errorCodes {
+		if errors.Is(err, errorCode.err) {
+			return errorCode.code
+		}
+	}
+
+	return CodeInternal
+}
@@ -13,60 +13,121 @@ var (
 	refreshTokenSecret    = GetEnv("JWT_REFRESH_SECRET", "3b69f710a00d78ed724b6d26953f440d0beca2752762f7b2f546a6a27557137f")
 	passwordResetSecret   = GetEnv("JWT_RESET_SECRET", "e07ab84db119a5948e07e78be81ae7e2d6a29c872a1bf301225ccada3ff1c457")
 	activationTokenSecret = GetEnv("JWT_ACTIVATION_SECRET", "89be6deda2f0acdd570fde648b271e3f697fa05e51a24ccc8624c8d3bf7c56ab")
+	emailChangeSecret     = GetEnv("JWT_EMAIL_CHANGE_SECRET", "5f1d7c3e9a2b48c6d0e4f8a1b3c5d7e9f2a4b6c8d0e2f4a6b8c0d2e4f6a8b0c2")
 	accessTokenExpiry     = 30 * time.Minute
 	refreshTokenExpiry    = 6 * time.Hour
 	passwordResetExpiry   = 15 * time.Minute
 	activationTokenExpiry = 15 * time.Minute
+	emailChangeExpiry     = 15 * time.Minute
+	impersonationExpiry   = impersonationExpiryFromEnv()
 )
 
+// impersonationClaim is the claim of impersonation access tokens holding the ID of the admin who issued them.
+const impersonationClaim = "impersonatedBy"
+
+// maxImpersonationExpiry caps IMPERSONATION_TOKEN_TTL_MINUTES, so impersonation tokens never outlive a regular access token.
+const maxImpersonationExpiry = 30 * time.Minute
+
+// impersonationExpiryFromEnv reads IMPERSONATION_TOKEN_TTL_MINUTES, defaulting to 10 minutes and capped at 30 minutes.
+func impersonationExpiryFromEnv() time.Duration {
+	expiry := time.Duration(GetEnvAsInt("IMPERSONATION_TOKEN_TTL_MINUTES", 10)) * time.Minute
+	if expiry <= 0 {
+		return 10 * time.Minute
+	}
+	return min(expiry, maxImpersonationExpiry)
+}
+
 // GenerateAccessToken generates a new JWT access token.
 //
 // Parameters:
 //   - userID (uuid.UUID): User ID for whom to generate the token.
+//   - sessionID (uuid.UUID): Session ID the token belongs to, stored in the "sid" claim.
 //
 // Returns:
 //   - string: JWT access token.
 //   - error: An error if token generation fails.
-func GenerateAccessToken(userID uuid.UUID) (string, error) {
-	return generateToken(userID, accessTokenSecret, accessTokenExpiry)
+func GenerateAccessToken(userID uuid.UUID, sessionID uuid.UUID) (string, error) {
+	return generateToken(userID, jwt.MapClaims{"sid": sessionID.String()}, accessTokenSecret, accessTokenExpiry)
 }
 
 // GenerateRefreshToken generates a new JWT refresh token.
 //
 // Parameters:
 //   - userID (uuid.UUID): User ID for whom to generate the token.
+//   - sessionID (uuid.UUID): Session ID the token belongs to, stored in the "jti" claim.
 //
 // Returns:
 //   - string: JWT refresh token.
 //   - error: An error if token generation fails.
-func GenerateRefreshToken(userID uuid.UUID) (string, error) {
-	return generateToken(userID, refreshTokenSecret, refreshTokenExpiry)
+func GenerateRefreshToken(userID uuid.UUID, sessionID uuid.UUID) (string, error) {
+	return generateToken(userID, jwt.MapClaims{"jti": sessionID.String()}, refreshTokenSecret, refreshTokenExpiry)
+}
+
+// GenerateImpersonationToken generates a read-only access token letting an admin act as another user.
+// The token carries the admin's ID in the "impersonatedBy" claim and a session ID of its own, and no refresh
+// token is issued for it, so it stops working after IMPERSONATION_TOKEN_TTL_MINUTES.
+//
+// Parameters:
+//   - userID (uuid.UUID): ID of the impersonated user.
+//   - adminID (uuid.UUID): ID of the admin impersonating the user.
+//
+// Returns:
+//   - string: JWT access token.
+//   - time.Time: Time the token expires at.
+//   - error: An error if token generation fails.
+func GenerateImpersonationToken(userID uuid.UUID, adminID uuid.UUID) (string, time.Time, error) {
+	expiresAt := time.Now().Add(impersonationExpiry)
+	claims := jwt.MapClaims{"sid": uuid.New().String(), impersonationClaim: adminID.String()}
+	token, err := generateToken(userID, claims, accessTokenSecret, impersonationExpiry)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return token, expiresAt, nil
 }
 
 // GeneratePasswordResetToken generates a new JWT password reset token.
 func GeneratePasswordResetToken(userID uuid.UUID) (string, error) {
-	return generateToken(userID, passwordResetSecret, passwordResetExpiry)
+	return generateToken(userID, nil, passwordResetSecret, passwordResetExpiry)
 }
 
 // GenerateActivationToken generates a new JWT activation token.
 func GenerateActivationToken(userID uuid.UUID) (string, error) {
-	return generateToken(userID, activationTokenSecret, activationTokenExpiry)
+	return generateToken(userID, nil, activationTokenSecret, activationTokenExpiry)
+}
+
+// GenerateEmailChangeToken generates a new JWT email change token.
+func GenerateEmailChangeToken(userID uuid.UUID) (string, error) {
+	return generateToken(userID, nil, emailChangeSecret, emailChangeExpiry)
+}
+
+// RefreshTokenExpiry returns the lifetime of a refresh token.
+//
+// Returns:
+//   - time.Duration: Refresh token lifetime.
+func RefreshTokenExpiry() time.Duration {
+	return refreshTokenExpiry
 }
 
 // generateToken is a helper function to generate JWT tokens.
 //
 // Parameters:
 //   - userID (uuid.UUID): User ID for whom to generate the token.
+//   - extraClaims (jwt.MapClaims): Additional claims to include in the token, may be nil.
 //   - secretKey (string): Secret key to sign the token.
 //   - expiry (time.Duration): Token expiry duration.
 //
 // Returns:
 //   - string: JWT token.
 //   - error: An error if token generation fails.
-func generateToken(userID uuid.UUID, secretKey string, expiry time.Duration) (string, error) {
+func generateToken(userID uuid.UUID, extraClaims jwt.MapClaims, secretKey string, expiry time.Duration) (string, error) {
+	now := time.Now()
 	claims := jwt.MapClaims{
 		"user_id": userID.String(),
-		"exp":     time.Now().Add(expiry).Unix(),
+		"iat":     now.Unix(),
+		"exp":     now.Add(expiry).Unix(),
+	}
+	for key, value := range extraClaims {
+		claims[key] = value
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
@@ -163,3 +224,82 @@ func ExtractUserIDFromToken(token *jwt.Token) (uuid.UUID, error) {
 
 	return userID, nil
 }
+
+// ExtractSessionIDFromToken extracts the session ID from a valid access or refresh token.
+// Refresh tokens carry the session ID in the "jti" claim, access tokens in the "sid" claim.
+//
+// Parameters:
+//   - token *jwt.Token: Valid JWT token.
+//
+// Returns:
+//   - uuid.UUID: Session ID extracted from the token.
+//   - error: An error if session ID extraction fails.
+func ExtractSessionIDFromToken(token *jwt.Token) (uuid.UUID, error) {
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return uuid.Nil, fmt.Errorf("invalid token or claims")
+	}
+
+	sessionIDStr, ok := claims["jti"].(string)
+	if !ok {
+		sessionIDStr, ok = claims["sid"].(string)
+	}
+	if !ok {
+		return uuid.Nil, fmt.Errorf("session claim not found or invalid")
+	}
+
+	sessionID, err := uuid.Parse(sessionIDStr)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to parse session id as UUID: %w", err)
+	}
+
+	return sessionID, nil
+}
+
+// ExtractImpersonatorFromToken extracts the ID of the admin who issued an impersonation access token.
+// A token whose claim is present but not a valid ID is still reported as an impersonation token.
+//
+// Parameters:
+//   - token *jwt.Token: Valid JWT token.
+//
+// Returns:
+//   - uuid.UUID: ID of the impersonating admin.
+//   - bool: True if the token is an impersonation token.
+func ExtractImpersonatorFromToken(token *jwt.Token) (uuid.UUID, bool) {
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return uuid.Nil, false
+	}
+
+	value, found := claims[impersonationClaim]
+	if !found {
+		return uuid.Nil, false
+	}
+
+	adminIDStr, _ := value.(string)
+	adminID, err := uuid.Parse(adminIDStr)
+	if err != nil {
+		return uuid.Nil, true
+	}
+	return adminID, true
+}
+
+// ExtractIssuedAtFromToken extracts the issue time from a valid JWT token.
+// Tokens issued before the "iat" claim was added report the zero time.
+//
+// Parameters:
+//   - token *jwt.Token: Valid JWT token.
+//
+// Returns:
+//   - time.Time: Time the token was issued at.
+//   - error: An error if the claims are invalid.
+func ExtractIssuedAtFromToken(token *jwt.Token) (time.Time, error) {
+	issuedAt, err := token.Claims.GetIssuedAt()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("iat claim invalid: %w", err)
+	}
+	if issuedAt == nil {
+		return time.Time{}, nil
+	}
+	return issuedAt.Time, nil
+}
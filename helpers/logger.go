@@ -6,19 +6,28 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
+var logFormat = GetEnv("LOG_FORMAT", "text")
+
 // NewLogger creates a new logger and returns it.
+// Logs are written as JSON when LOG_FORMAT is set to json, otherwise as colored text.
 //
 // Returns:
 //   - logger (*logrus.Logger): The logger instance.
 func NewLogger() *logrus.Logger {
 	logger := logrus.New()
 
-	formatter := &logrus.TextFormatter{
-		ForceColors:     true,
-		FullTimestamp:   true,
-		TimestampFormat: "2006-01-02 15:04:05",
+	if logFormat == "json" {
+		logger.SetFormatter(&logrus.JSONFormatter{
+			TimestampFormat: "2006-01-02 15:04:05",
+		})
+	} else {
+		formatter := &logrus.TextFormatter{
+			ForceColors:     true,
+			FullTimestamp:   true,
+			TimestampFormat: "2006-01-02 15:04:05",
+		}
+		logger.SetFormatter(formatter)
 	}
-	logger.SetFormatter(formatter)
 	logger.SetOutput(os.Stdout)
 	logger.SetLevel(logrus.InfoLevel)
 
@@ -0,0 +1,65 @@
+package helpers
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestNewLoggerFormat(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   string
+		wantJSON bool
+	}{
+		{name: "json", format: "json", wantJSON: true},
+		{name: "text", format: "text", wantJSON: false},
+		{name: "unknown format falls back to text", format: "xml", wantJSON: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			previous := logFormat
+			logFormat = tt.format
+			t.Cleanup(func() { logFormat = previous })
+
+			var output bytes.Buffer
+			logger := NewLogger()
+			logger.SetOutput(&output)
+			logger.WithFields(logrus.Fields{"request-id": "request-1", "status": 200}).Info("HTTP/HTTPS Request - Success")
+
+			var entry map[string]any
+			err := json.Unmarshal(output.Bytes(), &entry)
+			if !tt.wantJSON {
+				if err == nil {
+					t.Fatalf("log output %q is JSON, want text", output.String())
+				}
+				if !strings.Contains(output.String(), "request-id") {
+					t.Fatalf("log output %q is missing the request-id field", output.String())
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("log output %q is not JSON: %v", output.String(), err)
+			}
+			want := map[string]any{
+				"level":      "info",
+				"msg":        "HTTP/HTTPS Request - Success",
+				"request-id": "request-1",
+				"status":     float64(200),
+			}
+			for field, value := range want {
+				if entry[field] != value {
+					t.Errorf("field %q = %v, want %v", field, entry[field], value)
+				}
+			}
+			if _, ok := entry["time"].(string); !ok {
+				t.Errorf("field \"time\" = %v, want a timestamp", entry["time"])
+			}
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package helpers
+
+import (
+	"bytes"
+	"fmt"
+	"net/smtp"
+	"regexp"
+	"text/template"
+
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	smtpHost = GetEnv("SMTP_HOST", "")
+	smtpPort = GetEnv("SMTP_PORT", "587")
+	smtpUser = GetEnv("SMTP_USER", "")
+	smtpPass = GetEnv("SMTP_PASS", "")
+	smtpFrom = GetEnv("SMTP_FROM", "no-reply@gopher-social.local")
+)
+
+// Mailer is the interface implemented by anything that can deliver an email.
+type Mailer interface {
+	Send(to string, subject string, body string) error
+}
+
+// SMTPMailer delivers emails through an SMTP server using plain auth.
+type SMTPMailer struct {
+	host     string
+	port     string
+	username string
+	password string
+	from     string
+}
+
+// LogMailer does not deliver emails, it only logs them. It is meant for development.
+type LogMailer struct {
+	logger *logrus.Logger
+}
+
+// linkTokenPattern matches the token query parameter of the links sent by email.
+var linkTokenPattern = regexp.MustCompile(`([?&]token=)[^&\s]+`)
+
+// NewMailer creates a new Mailer.
+// If SMTP_HOST is set an SMTPMailer is returned, otherwise a LogMailer is returned.
+//
+// Parameters:
+//   - logger (*logrus.Logger): Logrus logger instance used by the LogMailer.
+//
+// Returns:
+//   - Mailer: Mailer implementation based on the environment configuration.
+func NewMailer(logger *logrus.Logger) Mailer {
+	if smtpHost == "" {
+		return &LogMailer{logger: logger}
+	}
+
+	return &SMTPMailer{
+		host:     smtpHost,
+		port:     smtpPort,
+		username: smtpUser,
+		password: smtpPass,
+		from:     smtpFrom,
+	}
+}
+
+// Send sends an email through the configured SMTP server.
+//
+// Parameters:
+//   - to (string): Recipient email address.
+//   - subject (string): Subject of the email.
+//   - body (string): Plain text body of the email.
+//
+// Returns:
+//   - error: An error if sending the email fails.
+func (sm *SMTPMailer) Send(to string, subject string, body string) error {
+	var auth smtp.Auth
+	if sm.username != "" {
+		auth = smtp.PlainAuth("", sm.username, sm.password, sm.host)
+	}
+
+	message := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\nMIME-Version: 1.0\r\nContent-Type: text/plain; charset=\"UTF-8\"\r\n\r\n%s", sm.from, to, subject, body)
+
+	if err := smtp.SendMail(sm.host+":"+sm.port, auth, sm.from, []string{to}, []byte(message)); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	return nil
+}
+
+// Send logs the email instead of delivering it.
+// Tokens in the links of the body are redacted, so the log cannot be used to activate an account or reset a password.
+//
+// Parameters:
+//   - to (string): Recipient email address.
+//   - subject (string): Subject of the email.
+//   - body (string): Plain text body of the email.
+//
+// Returns:
+//   - error: Always nil.
+func (lm *LogMailer) Send(to string, subject string, body string) error {
+	redactedBody := linkTokenPattern.ReplaceAllString(body, "${1}REDACTED")
+	lm.logger.WithFields(logrus.Fields{"to": to, "subject": subject, "body": redactedBody}).Info("Email Not Sent, SMTP Not Configured")
+	return nil
+}
+
+var activationEmailTemplate = template.Must(template.New("activation").Parse(`Hi {{.Username}},
+
+Welcome to Gopher Social! Please activate your account by visiting the link below:
+
+{{.Link}}
+
+This link will expire in 15 minutes. If you did not create an account, you can ignore this email.
+
+- The Gopher Social Team
+`))
+
+var passwordResetEmailTemplate = template.Must(template.New("password_reset").Parse(`Hi {{.Username}},
+
+We received a request to reset your Gopher Social password. You can reset it by visiting the link below:
+
+{{.Link}}
+
+This link will expire in 15 minutes. If you did not request a password reset, you can ignore this email.
+
+- The Gopher Social Team
+`))
+
+var emailChangeEmailTemplate = template.Must(template.New("email_change").Parse(`Hi {{.Username}},
+
+We received a request to change the email of your Gopher Social account to this address. You can confirm it by visiting the link below:
+
+{{.Link}}
+
+This link will expire in 15 minutes. If you did not request an email change, you can ignore this email.
+
+- The Gopher Social Team
+`))
+
+// emailData holds the values rendered into the email templates.
+type emailData struct {
+	Username string
+	Link     string
+}
+
+// RenderActivationEmail renders the body of the account activation email.
+//
+// Parameters:
+//   - username (string): Username of the recipient.
+//   - link (string): Activation link.
+//
+// Returns:
+//   - string: Rendered email body.
+//   - error: An error if rendering the template fails.
+func RenderActivationEmail(username string, link string) (string, error) {
+	return renderEmail(activationEmailTemplate, emailData{Username: username, Link: link})
+}
+
+// RenderPasswordResetEmail renders the body of the password reset email.
+//
+// Parameters:
+//   - username (string): Username of the recipient.
+//   - link (string): Password reset link.
+//
+// Returns:
+//   - string: Rendered email body.
+//   - error: An error if rendering the template fails.
+func RenderPasswordResetEmail(username string, link string) (string, error) {
+	return renderEmail(passwordResetEmailTemplate, emailData{Username: username, Link: link})
+}
+
+// RenderEmailChangeEmail renders the body of the email change verification email.
+//
+// Parameters:
+//   - username (string): Username of the recipient.
+//   - link (string): Email change verification link.
+//
+// Returns:
+//   - string: Rendered email body.
+//   - error: An error if rendering the template fails.
+func RenderEmailChangeEmail(username string, link string) (string, error) {
+	return renderEmail(emailChangeEmailTemplate, emailData{Username: username, Link: link})
+}
+
+// renderEmail is a helper function to execute an email template into a string.
+func renderEmail(tmpl *template.Template, data emailData) (string, error) {
+	var body bytes.Buffer
+	if err := tmpl.Execute(&body, data); err != nil {
+		return "", fmt.Errorf("failed to render email template: %w", err)
+	}
+	return body.String(), nil
+}
@@ -0,0 +1,49 @@
+package helpers
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestLogMailerRedactsTokens(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      string
+		wantKept  string
+		wantToken string
+	}{
+		{
+			name:      "activation link",
+			body:      "Visit http://localhost:8080/api/v1/auth/activate?token=secret-activation-token to activate.",
+			wantKept:  "/api/v1/auth/activate?token=REDACTED to activate.",
+			wantToken: "secret-activation-token",
+		},
+		{
+			name:      "token followed by another parameter",
+			body:      "http://localhost:3000/reset?lang=en&token=secret-reset-token&source=email",
+			wantKept:  "?lang=en&token=REDACTED&source=email",
+			wantToken: "secret-reset-token",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var output bytes.Buffer
+			logger := logrus.New()
+			logger.SetOutput(&output)
+
+			if err := (&LogMailer{logger: logger}).Send("gopher@example.com", "Subject", tt.body); err != nil {
+				t.Fatalf("Send() error = %v", err)
+			}
+			if strings.Contains(output.String(), tt.wantToken) {
+				t.Fatalf("log output %q contains the token", output.String())
+			}
+			if !strings.Contains(output.String(), tt.wantKept) {
+				t.Fatalf("log output %q does not contain %q", output.String(), tt.wantKept)
+			}
+		})
+	}
+}
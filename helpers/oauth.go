@@ -0,0 +1,158 @@
+package helpers
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+var (
+	googleClientID     = GetEnv("GOOGLE_CLIENT_ID", "")
+	googleClientSecret = GetEnv("GOOGLE_CLIENT_SECRET", "")
+	googleRedirectURL  = GetEnv("GOOGLE_REDIRECT_URL", "http://localhost:8080/api/v1/auth/oauth/google/callback")
+	googleAuthURL      = "https://accounts.google.com/o/oauth2/v2/auth"
+	googleTokenURL     = "https://oauth2.googleapis.com/token"
+	googleUserInfoURL  = "https://openidconnect.googleapis.com/v1/userinfo"
+	oauthHTTPClient    = &http.Client{Timeout: 10 * time.Second}
+)
+
+// OAuthStateExpiry is how long an OAuth state parameter stays valid.
+const OAuthStateExpiry = 10 * time.Minute
+
+// GoogleUser is the subset of the Google user info response used for login.
+type GoogleUser struct {
+	Subject       string `json:"sub"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	Name          string `json:"name"`
+}
+
+// GoogleOAuthEnabled reports whether the Google OAuth client credentials are configured.
+//
+// Returns:
+//   - bool: True if both the client ID and client secret are set.
+func GoogleOAuthEnabled() bool {
+	return googleClientID != "" && googleClientSecret != ""
+}
+
+// GoogleAuthCodeURL builds the Google consent screen URL for the given state.
+//
+// Parameters:
+//   - state (string): Opaque state value used to protect the flow against CSRF.
+//
+// Returns:
+//   - string: URL to redirect the user to.
+func GoogleAuthCodeURL(state string) string {
+	params := url.Values{}
+	params.Set("client_id", googleClientID)
+	params.Set("redirect_uri", googleRedirectURL)
+	params.Set("response_type", "code")
+	params.Set("scope", "openid email profile")
+	params.Set("state", state)
+	params.Set("access_type", "online")
+	params.Set("prompt", "select_account")
+
+	return googleAuthURL + "?" + params.Encode()
+}
+
+// ExchangeGoogleCode exchanges an authorization code for a Google access token.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the HTTP request.
+//   - code (string): Authorization code returned by Google to the callback.
+//
+// Returns:
+//   - string: Google access token.
+//   - error: An error if the exchange fails.
+func ExchangeGoogleCode(ctx context.Context, code string) (string, error) {
+	form := url.Values{}
+	form.Set("code", code)
+	form.Set("client_id", googleClientID)
+	form.Set("client_secret", googleClientSecret)
+	form.Set("redirect_uri", googleRedirectURL)
+	form.Set("grant_type", "authorization_code")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, googleTokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("failed to build token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := oauthHTTPClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to exchange code: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected token response status: %d", resp.StatusCode)
+	}
+
+	var tokenResponse struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&tokenResponse); err != nil {
+		return "", fmt.Errorf("failed to decode token response: %w", err)
+	}
+	if tokenResponse.AccessToken == "" {
+		return "", fmt.Errorf("access token missing in token response")
+	}
+
+	return tokenResponse.AccessToken, nil
+}
+
+// FetchGoogleUser fetches the profile of the user owning the given Google access token.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the HTTP request.
+//   - accessToken (string): Google access token.
+//
+// Returns:
+//   - *GoogleUser: The Google user profile.
+//   - error: An error if fetching the profile fails.
+func FetchGoogleUser(ctx context.Context, accessToken string) (*GoogleUser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, googleUserInfoURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user info request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	resp, err := oauthHTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch user info: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected user info response status: %d", resp.StatusCode)
+	}
+
+	var googleUser GoogleUser
+	if err := json.NewDecoder(resp.Body).Decode(&googleUser); err != nil {
+		return nil, fmt.Errorf("failed to decode user info response: %w", err)
+	}
+
+	return &googleUser, nil
+}
+
+// GenerateRandomString generates a cryptographically secure random hex string.
+//
+// Parameters:
+//   - numBytes (int): Number of random bytes, the resulting string is twice as long.
+//
+// Returns:
+//   - string: Random hex string.
+//   - error: An error if reading random bytes fails.
+func GenerateRandomString(numBytes int) (string, error) {
+	buffer := make([]byte, numBytes)
+	if _, err := rand.Read(buffer); err != nil {
+		return "", fmt.Errorf("failed to generate random string: %w", err)
+	}
+	return hex.EncodeToString(buffer), nil
+}
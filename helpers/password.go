@@ -2,7 +2,20 @@ package helpers
 
 import "golang.org/x/crypto/bcrypt"
 
-// HashPassword hashes the password using bcrypt.
+// passwordHashCost is the bcrypt cost of new password hashes, read from PASSWORD_HASH_COST.
+// Values outside bcrypt's supported range fall back to bcrypt.DefaultCost.
+var passwordHashCost = passwordHashCostFromEnv()
+
+// passwordHashCostFromEnv reads PASSWORD_HASH_COST, defaulting to bcrypt.DefaultCost.
+func passwordHashCostFromEnv() int {
+	cost := GetEnvAsInt("PASSWORD_HASH_COST", bcrypt.DefaultCost)
+	if cost < bcrypt.MinCost || cost > bcrypt.MaxCost {
+		return bcrypt.DefaultCost
+	}
+	return cost
+}
+
+// HashPassword hashes the password using bcrypt with the configured cost.
 //
 // Parameters:
 //   - password (string): The password to be hashed.
@@ -11,7 +24,7 @@ import "golang.org/x/crypto/bcrypt"
 //   - string: The hashed password.
 //   - error: An error if hashing fails.
 func HashPassword(password string) (string, error) {
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), passwordHashCost)
 	if err != nil {
 		return "", err
 	}
@@ -30,3 +43,19 @@ func HashPassword(password string) (string, error) {
 func ComparePassword(hashedPassword string, plainPassword string) error {
 	return bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(plainPassword))
 }
+
+// PasswordNeedsRehash reports whether a password hash was made with a lower cost than the configured one,
+// so it should be replaced by a new hash the next time the plain password is known.
+//
+// Parameters:
+//   - hashedPassword (string): The stored hashed password.
+//
+// Returns:
+//   - bool: True if the hash should be upgraded. False if it is current or cannot be parsed.
+func PasswordNeedsRehash(hashedPassword string) bool {
+	cost, err := bcrypt.Cost([]byte(hashedPassword))
+	if err != nil {
+		return false
+	}
+	return cost < passwordHashCost
+}
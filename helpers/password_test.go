@@ -0,0 +1,67 @@
+package helpers
+
+import (
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// hashWithCost hashes a password with an explicit bcrypt cost.
+func hashWithCost(t *testing.T, password string, cost int) string {
+	t.Helper()
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), cost)
+	if err != nil {
+		t.Fatalf("failed to hash password: %v", err)
+	}
+	return string(hashedPassword)
+}
+
+func TestPasswordNeedsRehash(t *testing.T) {
+	previousCost := passwordHashCost
+	passwordHashCost = bcrypt.MinCost + 1
+	t.Cleanup(func() { passwordHashCost = previousCost })
+
+	tests := []struct {
+		name           string
+		hashedPassword string
+		want           bool
+	}{
+		{name: "below configured cost", hashedPassword: hashWithCost(t, "Password123!", bcrypt.MinCost), want: true},
+		{name: "at configured cost", hashedPassword: hashWithCost(t, "Password123!", bcrypt.MinCost+1), want: false},
+		{name: "above configured cost", hashedPassword: hashWithCost(t, "Password123!", bcrypt.MinCost+2), want: false},
+		{name: "invalid hash", hashedPassword: "not-a-bcrypt-hash", want: false},
+		{name: "empty hash", hashedPassword: "", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PasswordNeedsRehash(tt.hashedPassword); got != tt.want {
+				t.Fatalf("PasswordNeedsRehash() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHashPasswordUsesConfiguredCost(t *testing.T) {
+	previousCost := passwordHashCost
+	passwordHashCost = bcrypt.MinCost + 1
+	t.Cleanup(func() { passwordHashCost = previousCost })
+
+	hashedPassword, err := HashPassword("Password123!")
+	if err != nil {
+		t.Fatalf("HashPassword() error = %v", err)
+	}
+	cost, err := bcrypt.Cost([]byte(hashedPassword))
+	if err != nil {
+		t.Fatalf("bcrypt.Cost() error = %v", err)
+	}
+	if cost != passwordHashCost {
+		t.Fatalf("hash cost = %d, want %d", cost, passwordHashCost)
+	}
+	if PasswordNeedsRehash(hashedPassword) {
+		t.Fatal("freshly hashed password needs rehash")
+	}
+	if err := ComparePassword(hashedPassword, "Password123!"); err != nil {
+		t.Fatalf("ComparePassword() error = %v", err)
+	}
+}
@@ -0,0 +1,34 @@
+package helpers
+
+import (
+	"errors"
+
+	"github.com/datarohit/gopher-social-backend/models"
+	"github.com/gin-gonic/gin"
+)
+
+// ErrUserNotInContext is the panic value of RequireUser when the request has no authenticated user.
+var ErrUserNotInContext = errors.New("authenticated user not found in request context")
+
+// RequireUser returns the user set in the context by AuthMiddleware or APIKeyMiddleware.
+// Those middlewares already answer 401 Unauthorized to unauthenticated requests, so a missing user means a route
+// was registered without them. That is a server misconfiguration rather than a client error, so RequireUser panics
+// and RecovererMiddleware answers 500 Internal Server Error.
+//
+// Parameters:
+//   - c (*gin.Context): Gin context of the request.
+//
+// Returns:
+//   - *models.User: The authenticated user.
+func RequireUser(c *gin.Context) *models.User {
+	userCtx, exists := c.Get("user")
+	if !exists {
+		panic(ErrUserNotInContext)
+	}
+
+	user, ok := userCtx.(*models.User)
+	if !ok || user == nil {
+		panic(ErrUserNotInContext)
+	}
+	return user
+}
@@ -0,0 +1,151 @@
+package helpers
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+var contentSanitizationEnabled = GetEnv("CONTENT_SANITIZATION_ENABLED", "true") == "true"
+
+// allowedContentTags lists the HTML tags kept by SanitizeContent with the attributes allowed on each.
+var allowedContentTags = map[string]map[string]bool{
+	"a":          {"href": true, "title": true},
+	"b":          {},
+	"blockquote": {},
+	"br":         {},
+	"code":       {},
+	"em":         {},
+	"h1":         {},
+	"h2":         {},
+	"h3":         {},
+	"h4":         {},
+	"h5":         {},
+	"h6":         {},
+	"hr":         {},
+	"i":          {},
+	"li":         {},
+	"ol":         {},
+	"p":          {},
+	"pre":        {},
+	"s":          {},
+	"strong":     {},
+	"u":          {},
+	"ul":         {},
+}
+
+// droppedContentTags lists the HTML tags removed by SanitizeContent together with everything inside them.
+var droppedContentTags = map[string]bool{
+	"iframe":   true,
+	"noscript": true,
+	"object":   true,
+	"embed":    true,
+	"script":   true,
+	"style":    true,
+	"template": true,
+}
+
+// markupPattern matches text that would be parsed as markup if written back verbatim.
+var markupPattern = regexp.MustCompile(`<[a-zA-Z!/?]`)
+
+// allowedURLSchemes lists the URL schemes allowed in href attributes.
+var allowedURLSchemes = map[string]bool{
+	"http":   true,
+	"https":  true,
+	"mailto": true,
+}
+
+// SanitizeContent strips dangerous HTML from user supplied content before it is stored.
+// Plain text and markdown pass through unchanged, safe formatting tags are kept,
+// and scripts, event handlers, styles and unsafe links are removed.
+// Sanitization is skipped when CONTENT_SANITIZATION_ENABLED is set to false.
+//
+// Parameters:
+//   - content (string): The content to sanitize.
+//
+// Returns:
+//   - string: The sanitized content.
+func SanitizeContent(content string) string {
+	if !contentSanitizationEnabled || !strings.Contains(content, "<") {
+		return content
+	}
+
+	var builder strings.Builder
+	tokenizer := html.NewTokenizer(strings.NewReader(content))
+	droppedDepth := 0
+
+	for {
+		tokenType := tokenizer.Next()
+		if tokenType == html.ErrorToken {
+			return builder.String()
+		}
+
+		raw := string(tokenizer.Raw())
+		token := tokenizer.Token()
+		switch tokenType {
+		case html.StartTagToken, html.SelfClosingTagToken:
+			if droppedContentTags[token.Data] {
+				if tokenType == html.StartTagToken {
+					droppedDepth++
+				}
+				continue
+			}
+			if droppedDepth > 0 {
+				continue
+			}
+			if allowedAttributes, ok := allowedContentTags[token.Data]; ok {
+				token.Attr = sanitizeAttributes(token.Attr, allowedAttributes)
+				builder.WriteString(token.String())
+			}
+		case html.EndTagToken:
+			if droppedContentTags[token.Data] {
+				if droppedDepth > 0 {
+					droppedDepth--
+				}
+				continue
+			}
+			if droppedDepth > 0 {
+				continue
+			}
+			if _, ok := allowedContentTags[token.Data]; ok {
+				builder.WriteString(token.String())
+			}
+		case html.TextToken:
+			if droppedDepth > 0 {
+				continue
+			}
+			if markupPattern.MatchString(raw) {
+				builder.WriteString(html.EscapeString(token.Data))
+			} else {
+				builder.WriteString(raw)
+			}
+		}
+	}
+}
+
+// sanitizeAttributes keeps only the allowed attributes of a tag and drops links with unsafe URL schemes.
+//
+// Parameters:
+//   - attributes ([]html.Attribute): Attributes of the tag.
+//   - allowedAttributes (map[string]bool): Attributes allowed on the tag.
+//
+// Returns:
+//   - []html.Attribute: The allowed attributes.
+func sanitizeAttributes(attributes []html.Attribute, allowedAttributes map[string]bool) []html.Attribute {
+	var sanitized []html.Attribute
+	for _, attribute := range attributes {
+		if attribute.Namespace != "" || !allowedAttributes[attribute.Key] {
+			continue
+		}
+		if attribute.Key == "href" {
+			parsedURL, err := url.Parse(strings.TrimSpace(attribute.Val))
+			if err != nil || (parsedURL.Scheme != "" && !allowedURLSchemes[strings.ToLower(parsedURL.Scheme)]) {
+				continue
+			}
+		}
+		sanitized = append(sanitized, attribute)
+	}
+	return sanitized
+}
@@ -0,0 +1,89 @@
+package helpers
+
+import (
+	"context"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ShutdownHook stops a background component, such as a hub of long lived connections.
+// It must return once the component has stopped or when ctx is done.
+type ShutdownHook func(ctx context.Context) error
+
+// ShutdownCoordinator runs registered shutdown hooks, such as stopping background jobs.
+// main calls Shutdown and waits for it before server.Shutdown and before the database and Redis are closed,
+// so no hook runs against closed connections. In-flight HTTP requests are drained by server.Shutdown afterwards.
+type ShutdownCoordinator struct {
+	mu     sync.Mutex
+	hooks  map[string]ShutdownHook
+	logger *logrus.Logger
+}
+
+// NewShutdownCoordinator creates a new ShutdownCoordinator.
+//
+// Parameters:
+//   - logger (*logrus.Logger): Logrus logger pointer to log messages.
+//
+// Returns:
+//   - *ShutdownCoordinator: ShutdownCoordinator instance.
+func NewShutdownCoordinator(logger *logrus.Logger) *ShutdownCoordinator {
+	return &ShutdownCoordinator{
+		hooks:  make(map[string]ShutdownHook),
+		logger: logger,
+	}
+}
+
+// Register adds a named shutdown hook. Registering the same name again replaces the hook.
+//
+// Parameters:
+//   - name (string): Name of the component, used in logs.
+//   - hook (ShutdownHook): Function that stops the component.
+func (sc *ShutdownCoordinator) Register(name string, hook ShutdownHook) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	sc.hooks[name] = hook
+}
+
+// Shutdown runs all registered hooks concurrently and waits until they return or ctx is done.
+//
+// Parameters:
+//   - ctx (context.Context): Context bounding how long to wait for the hooks.
+//
+// Returns:
+//   - error: ctx.Err() if the hooks did not finish in time, nil otherwise.
+func (sc *ShutdownCoordinator) Shutdown(ctx context.Context) error {
+	sc.mu.Lock()
+	hooks := make(map[string]ShutdownHook, len(sc.hooks))
+	for name, hook := range sc.hooks {
+		hooks[name] = hook
+	}
+	sc.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for name, hook := range hooks {
+		wg.Add(1)
+		go func(name string, hook ShutdownHook) {
+			defer wg.Done()
+			if err := hook(ctx); err != nil {
+				sc.logger.WithFields(logrus.Fields{"component": name, "error": err}).Error("Shutdown Hook Failed!")
+				return
+			}
+			sc.logger.WithFields(logrus.Fields{"component": name}).Info("Component Stopped Successfully!")
+		}(name, hook)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
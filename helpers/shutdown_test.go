@@ -0,0 +1,139 @@
+package helpers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+func newTestLogger() *logrus.Logger {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	return logger
+}
+
+func TestShutdownCoordinatorWaitsForHooks(t *testing.T) {
+	coordinator := NewShutdownCoordinator(newTestLogger())
+
+	stopped := make(chan struct{})
+	coordinator.Register("slow", func(ctx context.Context) error {
+		time.Sleep(50 * time.Millisecond)
+		close(stopped)
+		return nil
+	})
+	coordinator.Register("failing", func(ctx context.Context) error {
+		return errors.New("boom")
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	if err := coordinator.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown() error = %v, want nil", err)
+	}
+	select {
+	case <-stopped:
+	default:
+		t.Fatal("Shutdown() returned before the hook finished")
+	}
+}
+
+func TestShutdownCoordinatorTimeout(t *testing.T) {
+	coordinator := NewShutdownCoordinator(newTestLogger())
+
+	release := make(chan struct{})
+	defer close(release)
+	coordinator.Register("stuck", func(ctx context.Context) error {
+		<-release
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if err := coordinator.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Shutdown() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+// TestShutdownCompletesInFlightRequest follows the shutdown sequence of main: the coordinator first,
+// then server.Shutdown. A request in flight when shutdown starts must complete instead of being cut.
+func TestShutdownCompletesInFlightRequest(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("done"))
+	})}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	go func() { _ = server.Serve(listener) }()
+
+	type result struct {
+		status int
+		body   string
+		err    error
+	}
+	responses := make(chan result, 1)
+	go func() {
+		resp, err := http.Get("http://" + listener.Addr().String())
+		if err != nil {
+			responses <- result{err: err}
+			return
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		responses <- result{status: resp.StatusCode, body: string(body), err: err}
+	}()
+	<-started
+
+	coordinator := NewShutdownCoordinator(newTestLogger())
+	hookDone := make(chan struct{})
+	coordinator.Register("background", func(ctx context.Context) error {
+		close(hookDone)
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	shutdownErr := make(chan error, 1)
+	go func() {
+		if err := coordinator.Shutdown(ctx); err != nil {
+			shutdownErr <- err
+			return
+		}
+		shutdownErr <- server.Shutdown(ctx)
+	}()
+
+	<-hookDone
+	select {
+	case err := <-shutdownErr:
+		t.Fatalf("shutdown finished while a request was in flight, error = %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+
+	res := <-responses
+	if res.err != nil {
+		t.Fatalf("in-flight request failed: %v", res.err)
+	}
+	if res.status != http.StatusOK || res.body != "done" {
+		t.Fatalf("in-flight request got status %d body %q, want 200 \"done\"", res.status, res.body)
+	}
+	if err := <-shutdownErr; err != nil {
+		t.Fatalf("shutdown error = %v, want nil", err)
+	}
+}
@@ -0,0 +1,101 @@
+package helpers
+
+import (
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/datarohit/gopher-social-backend/models"
+)
+
+// defaultTimeoutOptions are the timeout durations offered when TIMEOUT_OPTIONS is not set or has no valid entry.
+const defaultTimeoutOptions = "30m,1h,6h,12h,1d"
+
+// timeoutOptions are the timeout durations moderators may choose from, in the order they are offered.
+var timeoutOptions = timeoutOptionsFromEnv()
+
+// timeoutOptionsFromEnv reads TIMEOUT_OPTIONS, a comma separated list of durations such as 30m, 1h or 1d.
+// Invalid or repeated entries are skipped with a warning.
+func timeoutOptionsFromEnv() []models.TimeoutOption {
+	options := parseTimeoutOptions(GetEnv("TIMEOUT_OPTIONS", defaultTimeoutOptions))
+	if len(options) == 0 {
+		log.Printf("Warning: TIMEOUT_OPTIONS has no valid duration. Using default value: %s", defaultTimeoutOptions)
+		options = parseTimeoutOptions(defaultTimeoutOptions)
+	}
+	return options
+}
+
+// parseTimeoutOptions parses a comma separated list of timeout durations, skipping invalid and repeated entries.
+func parseTimeoutOptions(value string) []models.TimeoutOption {
+	var options []models.TimeoutOption
+	seen := make(map[string]bool)
+
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		if entry == "" || seen[entry] {
+			continue
+		}
+
+		duration, err := parseTimeoutDuration(entry)
+		if err != nil || duration <= 0 {
+			log.Printf("Warning: %q in TIMEOUT_OPTIONS is not a valid duration. Skipping it.", entry)
+			continue
+		}
+
+		seen[entry] = true
+		options = append(options, models.TimeoutOption{Value: entry, Seconds: int64(duration.Seconds())})
+	}
+
+	return options
+}
+
+// parseTimeoutDuration parses a Go duration such as 30m or 12h, or a whole number of days such as 1d.
+func parseTimeoutDuration(value string) (time.Duration, error) {
+	if days, found := strings.CutSuffix(value, "d"); found {
+		count, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, err
+		}
+		return time.Duration(count) * 24 * time.Hour, nil
+	}
+	return time.ParseDuration(value)
+}
+
+// TimeoutOptions returns the timeout durations moderators may choose from, configured with TIMEOUT_OPTIONS.
+//
+// Returns:
+//   - []models.TimeoutOption: The allowed timeout durations, in the order they are offered.
+func TimeoutOptions() []models.TimeoutOption {
+	return timeoutOptions
+}
+
+// TimeoutOptionValues returns the values of the allowed timeout options, such as 1h, in the order they are offered.
+//
+// Returns:
+//   - []string: The allowed timeout option values.
+func TimeoutOptionValues() []string {
+	values := make([]string, 0, len(timeoutOptions))
+	for _, option := range timeoutOptions {
+		values = append(values, option.Value)
+	}
+	return values
+}
+
+// LookupTimeoutOption returns the duration of an allowed timeout option.
+//
+// Parameters:
+//   - value (string): Timeout option such as 1h, compared case insensitively.
+//
+// Returns:
+//   - time.Duration: Duration of the option.
+//   - bool: False if the value is not one of the allowed timeout options.
+func LookupTimeoutOption(value string) (time.Duration, bool) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	for _, option := range timeoutOptions {
+		if option.Value == value {
+			return time.Duration(option.Seconds) * time.Second, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,62 @@
+package helpers
+
+import (
+	"errors"
+	"strings"
+)
+
+// Username length limits enforced by NormalizeUsername.
+const (
+	UsernameMinLength = 3
+	UsernameMaxLength = 30
+)
+
+// reservedUsernames are names that cannot be registered because they clash with routes or could impersonate staff.
+var reservedUsernames = map[string]bool{
+	"admin":         true,
+	"administrator": true,
+	"api":           true,
+	"me":            true,
+	"moderator":     true,
+	"root":          true,
+	"support":       true,
+	"system":        true,
+}
+
+// ErrUsernameInvalidLength is returned when a username is shorter or longer than allowed.
+var ErrUsernameInvalidLength = errors.New("username must be between 3 and 30 characters")
+
+// ErrUsernameInvalidCharacters is returned when a username contains characters other than letters, digits and underscores.
+var ErrUsernameInvalidCharacters = errors.New("username may only contain letters, digits and underscores")
+
+// ErrUsernameReserved is returned when a username is reserved.
+var ErrUsernameReserved = errors.New("username is reserved")
+
+// NormalizeUsername trims surrounding whitespace from a username and lowercases it, then validates the result:
+// it must be UsernameMinLength to UsernameMaxLength characters long, contain only [a-z0-9_] and not be reserved.
+//
+// Parameters:
+//   - username (string): The username as entered by the user.
+//
+// Returns:
+//   - string: The normalized username.
+//   - error: ErrUsernameInvalidLength, ErrUsernameInvalidCharacters or ErrUsernameReserved if the username is invalid.
+func NormalizeUsername(username string) (string, error) {
+	normalized := strings.ToLower(strings.TrimSpace(username))
+
+	if len(normalized) < UsernameMinLength || len(normalized) > UsernameMaxLength {
+		return "", ErrUsernameInvalidLength
+	}
+
+	for _, r := range normalized {
+		if !((r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '_') {
+			return "", ErrUsernameInvalidCharacters
+		}
+	}
+
+	if reservedUsernames[normalized] {
+		return "", ErrUsernameReserved
+	}
+
+	return normalized, nil
+}
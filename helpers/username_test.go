@@ -0,0 +1,46 @@
+package helpers
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeUsername(t *testing.T) {
+	tests := []struct {
+		name     string
+		username string
+		want     string
+		wantErr  error
+	}{
+		{name: "already normalized", username: "gopher_42", want: "gopher_42"},
+		{name: "trims whitespace", username: "  gopher \t\n", want: "gopher"},
+		{name: "lowercases", username: "GoPher_AB", want: "gopher_ab"},
+		{name: "trims before length check", username: "  ab  ", wantErr: ErrUsernameInvalidLength},
+		{name: "minimum length", username: "abc", want: "abc"},
+		{name: "below minimum length", username: "ab", wantErr: ErrUsernameInvalidLength},
+		{name: "empty", username: "", wantErr: ErrUsernameInvalidLength},
+		{name: "whitespace only", username: "     ", wantErr: ErrUsernameInvalidLength},
+		{name: "maximum length", username: strings.Repeat("a", 30), want: strings.Repeat("a", 30)},
+		{name: "above maximum length", username: strings.Repeat("a", 31), wantErr: ErrUsernameInvalidLength},
+		{name: "hyphen", username: "go-pher", wantErr: ErrUsernameInvalidCharacters},
+		{name: "dot", username: "go.pher", wantErr: ErrUsernameInvalidCharacters},
+		{name: "inner space", username: "go pher", wantErr: ErrUsernameInvalidCharacters},
+		{name: "non ascii letter", username: "gophér", wantErr: ErrUsernameInvalidCharacters},
+		{name: "reserved", username: "admin", wantErr: ErrUsernameReserved},
+		{name: "reserved after normalizing", username: " Support ", wantErr: ErrUsernameReserved},
+		{name: "reserved name as prefix", username: "admin_fan", want: "admin_fan"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeUsername(tt.username)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NormalizeUsername(%q) error = %v, want %v", tt.username, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Fatalf("NormalizeUsername(%q) = %q, want %q", tt.username, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package helpers
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// RegisterValidators registers the custom validation tags used in payload binding tags on gin's
+// validator engine. It must be called once before the router starts handling requests.
+//
+//   - notblank: the string field must contain at least one non-whitespace character.
+//
+// Returns:
+//   - error: An error if a validation tag cannot be registered.
+func RegisterValidators() error {
+	engine, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return nil
+	}
+
+	return engine.RegisterValidation("notblank", notBlank)
+}
+
+// notBlank reports whether a string field contains at least one non-whitespace character.
+func notBlank(fl validator.FieldLevel) bool {
+	return strings.TrimSpace(fl.Field().String()) != ""
+}
@@ -0,0 +1,53 @@
+package helpers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/datarohit/gopher-social-backend/models"
+	"github.com/gin-gonic/gin/binding"
+)
+
+func TestNotBlankBinding(t *testing.T) {
+	if err := RegisterValidators(); err != nil {
+		t.Fatalf("RegisterValidators() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		body    string
+		target  func() any
+		wantErr bool
+	}{
+		{name: "valid post", body: `{"title":"Gophers","content":"All about gophers."}`, target: func() any { return &models.CreatePostPayload{} }},
+		{name: "padded post", body: `{"title":"  Gophers  ","content":"\tAll about gophers.\n"}`, target: func() any { return &models.CreatePostPayload{} }},
+		{name: "spaces only title", body: `{"title":"   ","content":"All about gophers."}`, target: func() any { return &models.CreatePostPayload{} }, wantErr: true},
+		{name: "tab and newline content", body: `{"title":"Gophers","content":"\t\n"}`, target: func() any { return &models.CreatePostPayload{} }, wantErr: true},
+		{name: "update without fields", body: `{}`, target: func() any { return &models.UpdatePostPayload{} }},
+		{name: "update with valid title", body: `{"title":"Gophers"}`, target: func() any { return &models.UpdatePostPayload{} }},
+		{name: "update with spaces only title", body: `{"title":"   "}`, target: func() any { return &models.UpdatePostPayload{} }, wantErr: true},
+		{name: "update with tab and newline content", body: `{"content":"\t\n"}`, target: func() any { return &models.UpdatePostPayload{} }, wantErr: true},
+		{name: "valid api key name", body: `{"name":"deploy-bot"}`, target: func() any { return &models.CreateAPIKeyPayload{} }},
+		{name: "spaces only api key name", body: `{"name":"   "}`, target: func() any { return &models.CreateAPIKeyPayload{} }, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+
+			err := binding.JSON.Bind(req, tt.target())
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), "'notblank' tag") {
+					t.Fatalf("Bind() error = %v, want a notblank failure", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Bind() error = %v, want nil", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,265 @@
+package helpers
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/datarohit/gopher-social-backend/models"
+	"github.com/datarohit/gopher-social-backend/stores"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// Webhook events delivered to subscribed webhooks.
+const (
+	WebhookEventUserRegistered = "user.registered"
+	WebhookEventUserBanned     = "user.banned"
+	WebhookEventUserUnbanned   = "user.unbanned"
+	WebhookEventPostRemoved    = "post.removed"
+	WebhookEventCommentRemoved = "comment.removed"
+)
+
+// WebhookSignatureHeader is the header carrying the HMAC-SHA256 signature of the request body.
+const WebhookSignatureHeader = "X-Webhook-Signature"
+
+var (
+	webhookMaxAttempts = GetEnvAsInt("WEBHOOK_MAX_ATTEMPTS", 5)
+	webhookQueueSize   = GetEnvAsInt("WEBHOOK_QUEUE_SIZE", 256)
+	webhookBaseBackoff = time.Second
+)
+
+// WebhookEvent is the JSON body POSTed to webhooks.
+type WebhookEvent struct {
+	ID        uuid.UUID   `json:"id"`
+	Event     string      `json:"event"`
+	CreatedAt time.Time   `json:"created_at"`
+	Data      interface{} `json:"data"`
+}
+
+// WebhookDispatcher delivers events to the configured webhooks from a background goroutine.
+// Failed deliveries are retried with exponential backoff and stored as dead letters once all attempts failed.
+type WebhookDispatcher struct {
+	webhookStore *stores.WebhookStore
+	httpClient   *http.Client
+	queue        chan *WebhookEvent
+	stop         chan struct{}
+	stopOnce     sync.Once
+	mu           sync.RWMutex
+	closed       bool
+	wg           sync.WaitGroup
+	logger       *logrus.Logger
+}
+
+// NewWebhookDispatcher creates a new WebhookDispatcher and starts its delivery goroutine.
+//
+// Parameters:
+//   - webhookStore (*stores.WebhookStore): WebhookStore pointer to look up webhooks and store dead letters.
+//   - logger (*logrus.Logger): Logrus logger pointer to log messages.
+//
+// Returns:
+//   - *WebhookDispatcher: WebhookDispatcher instance.
+func NewWebhookDispatcher(webhookStore *stores.WebhookStore, logger *logrus.Logger) *WebhookDispatcher {
+	wd := &WebhookDispatcher{
+		webhookStore: webhookStore,
+		httpClient:   &http.Client{Timeout: 10 * time.Second},
+		queue:        make(chan *WebhookEvent, webhookQueueSize),
+		stop:         make(chan struct{}),
+		logger:       logger,
+	}
+
+	wd.wg.Add(1)
+	go wd.run()
+
+	return wd
+}
+
+// Dispatch queues an event for delivery without blocking the caller.
+// Events are dropped with a warning if the queue is full or the dispatcher has been shut down.
+//
+// Parameters:
+//   - event (string): Name of the event, one of the WebhookEvent constants.
+//   - data (interface{}): Event payload, serialized as JSON.
+func (wd *WebhookDispatcher) Dispatch(event string, data interface{}) {
+	wd.mu.RLock()
+	defer wd.mu.RUnlock()
+
+	if wd.closed {
+		wd.logger.WithFields(logrus.Fields{"event": event}).Warn("Webhook Dispatcher Closed, Dropping Event")
+		return
+	}
+
+	select {
+	case wd.queue <- &WebhookEvent{ID: uuid.New(), Event: event, CreatedAt: time.Now().UTC(), Data: data}:
+	default:
+		wd.logger.WithFields(logrus.Fields{"event": event}).Warn("Webhook Queue Full, Dropping Event")
+	}
+}
+
+// Shutdown stops accepting events and waits until the queued events are delivered or ctx is done.
+// Once ctx is done, pending retries are abandoned and stored as dead letters.
+//
+// Parameters:
+//   - ctx (context.Context): Context bounding how long to wait for the queue to drain.
+//
+// Returns:
+//   - error: ctx.Err() if the queue did not drain in time, nil otherwise.
+func (wd *WebhookDispatcher) Shutdown(ctx context.Context) error {
+	wd.mu.Lock()
+	if !wd.closed {
+		wd.closed = true
+		close(wd.queue)
+	}
+	wd.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		wd.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		wd.stopOnce.Do(func() { close(wd.stop) })
+		return ctx.Err()
+	}
+}
+
+// run delivers queued events until the queue is closed and drained.
+func (wd *WebhookDispatcher) run() {
+	defer wd.wg.Done()
+
+	for event := range wd.queue {
+		wd.deliver(event)
+	}
+}
+
+// deliver sends an event to every webhook subscribed to it.
+//
+// Parameters:
+//   - event (*WebhookEvent): Event to deliver.
+func (wd *WebhookDispatcher) deliver(event *WebhookEvent) {
+	body, err := json.Marshal(event)
+	if err != nil {
+		wd.logger.WithFields(logrus.Fields{"error": err, "event": event.Event}).Error("Failed to Marshal Webhook Event")
+		return
+	}
+
+	webhooks, err := wd.webhookStore.ListActiveWebhooksForEvent(context.Background(), event.Event)
+	if err != nil {
+		wd.logger.WithFields(logrus.Fields{"error": err, "event": event.Event}).Error("Failed to List Webhooks for Event")
+		return
+	}
+
+	for _, webhook := range webhooks {
+		wd.deliverWithRetry(webhook, event, body)
+	}
+}
+
+// deliverWithRetry POSTs an event to a webhook, retrying with exponential backoff,
+// and stores it as a dead letter if every attempt fails.
+//
+// Parameters:
+//   - webhook (*models.Webhook): Webhook to deliver to.
+//   - event (*WebhookEvent): Event being delivered.
+//   - body ([]byte): JSON body of the event.
+func (wd *WebhookDispatcher) deliverWithRetry(webhook *models.Webhook, event *WebhookEvent, body []byte) {
+	var lastErr error
+	attempts := 0
+
+	for attempts < webhookMaxAttempts {
+		if attempts > 0 {
+			select {
+			case <-time.After(webhookBaseBackoff << (attempts - 1)):
+			case <-wd.stop:
+				lastErr = fmt.Errorf("dispatcher shut down before retry: %w", lastErr)
+				wd.recordDeadLetter(webhook, event, body, attempts, lastErr)
+				return
+			}
+		}
+
+		attempts++
+		lastErr = wd.post(webhook, body)
+		if lastErr == nil {
+			return
+		}
+
+		wd.logger.WithFields(logrus.Fields{"error": lastErr, "webhookID": webhook.ID, "event": event.Event, "attempt": attempts}).Warn("Webhook Delivery Failed")
+	}
+
+	wd.recordDeadLetter(webhook, event, body, attempts, lastErr)
+}
+
+// post sends a single signed delivery to a webhook.
+//
+// Parameters:
+//   - webhook (*models.Webhook): Webhook to deliver to.
+//   - body ([]byte): JSON body of the event.
+//
+// Returns:
+//   - error: An error if the request fails or the webhook does not respond with a 2xx status.
+func (wd *WebhookDispatcher) post(webhook *models.Webhook, body []byte) error {
+	req, err := http.NewRequest(http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookSignatureHeader, "sha256="+SignWebhookBody(webhook.Secret, body))
+
+	resp, err := wd.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send webhook request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// recordDeadLetter stores an undeliverable event in the dead letter log.
+//
+// Parameters:
+//   - webhook (*models.Webhook): Webhook the delivery failed for.
+//   - event (*WebhookEvent): Event that could not be delivered.
+//   - body ([]byte): JSON body of the event.
+//   - attempts (int): Number of delivery attempts made.
+//   - lastErr (error): Error of the last attempt.
+func (wd *WebhookDispatcher) recordDeadLetter(webhook *models.Webhook, event *WebhookEvent, body []byte, attempts int, lastErr error) {
+	lastError := ""
+	if lastErr != nil {
+		lastError = lastErr.Error()
+	}
+
+	if err := wd.webhookStore.RecordDeadLetter(context.Background(), webhook.ID, event.Event, body, attempts, lastError); err != nil {
+		wd.logger.WithFields(logrus.Fields{"error": err, "webhookID": webhook.ID, "event": event.Event}).Error("Failed to Record Webhook Dead Letter")
+		return
+	}
+
+	wd.logger.WithFields(logrus.Fields{"webhookID": webhook.ID, "event": event.Event, "attempts": attempts}).Error("Webhook Delivery Moved to Dead Letters")
+}
+
+// SignWebhookBody computes the hex encoded HMAC-SHA256 signature of a webhook body.
+//
+// Parameters:
+//   - secret (string): Secret of the webhook.
+//   - body ([]byte): Body to sign.
+//
+// Returns:
+//   - string: Hex encoded signature.
+func SignWebhookBody(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
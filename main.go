@@ -13,6 +13,7 @@ import (
 	"github.com/datarohit/gopher-social-backend/helpers"
 	"github.com/datarohit/gopher-social-backend/middlewares"
 	"github.com/datarohit/gopher-social-backend/routes"
+	"github.com/datarohit/gopher-social-backend/stores"
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
 	swaggerFiles "github.com/swaggo/files"
@@ -22,6 +23,12 @@ import (
 var (
 	SERVER_MODE = helpers.GetEnv("SERVER_MODE", "release")
 	SERVER_PORT = helpers.GetEnv("SERVER_PORT", ":8080")
+
+	SERVER_SHUTDOWN_TIMEOUT_SECONDS = helpers.GetEnvAsInt("SERVER_SHUTDOWN_TIMEOUT_SECONDS", 15)
+
+	ENABLE_SWAGGER   = helpers.GetEnvAsBool("ENABLE_SWAGGER", SERVER_MODE == gin.DebugMode)
+	SWAGGER_USERNAME = helpers.GetEnv("SWAGGER_USERNAME", "")
+	SWAGGER_PASSWORD = helpers.GetEnv("SWAGGER_PASSWORD", "")
 )
 
 // @title           Gopher Social API
@@ -49,35 +56,89 @@ func main() {
 
 	logger := helpers.NewLogger()
 
+	if err := helpers.RegisterValidators(); err != nil {
+		logger.WithFields(logrus.Fields{"error": err}).Fatal("Failed to Register Payload Validators!")
+	}
+
 	database.InitRedis(logger)
 	defer database.CloseRedis(logger)
 
 	database.InitPostgres(logger)
 	defer database.ClosePostgres(logger)
 
+	stores.EnableUserCache(database.RedisClient)
+	stores.EnableQueryTracing(logger)
+
+	contentPolicy, err := helpers.NewContentPolicy(helpers.GetEnv("CONTENT_POLICY_WORDS_FILE", ""))
+	if err != nil {
+		logger.WithFields(logrus.Fields{"error": err}).Fatal("Failed to Load Content Policy Word List!")
+	}
+
+	// SIGHUP reloads the content policy word list without a restart.
+	reloadSignal := make(chan os.Signal, 1)
+	signal.Notify(reloadSignal, syscall.SIGHUP)
+	go func() {
+		for range reloadSignal {
+			if err := contentPolicy.Reload(); err != nil {
+				logger.WithFields(logrus.Fields{"error": err}).Error("Failed to Reload Content Policy Word List!")
+				continue
+			}
+			logger.Info("Content Policy Word List Reloaded!")
+		}
+	}()
+
+	shutdownCoordinator := helpers.NewShutdownCoordinator(logger)
+	webhookDispatcher := helpers.NewWebhookDispatcher(stores.NewWebhookStore(database.PostgresDB), logger)
+
+	accountCleanupJob := helpers.NewAccountCleanupJob(stores.NewAuthStore(database.PostgresDB), logger)
+	shutdownCoordinator.Register("account-cleanup", accountCleanupJob.Shutdown)
+
 	router := gin.New()
+	// Handlers pass the gin context to stores, fall back to the request context so request timeouts cancel queries.
+	router.ContextWithFallback = true
 
 	router.Use(middlewares.RequestIDMiddleware())
 	router.Use(middlewares.RealIPMiddleware())
 	router.Use(middlewares.LoggerMiddleware(logger))
 	router.Use(middlewares.RecovererMiddleware(logger))
+	router.Use(middlewares.IPFilterMiddleware(logger))
 	router.Use(middlewares.CORSMiddleware())
-	router.Use(middlewares.TimeoutMiddleware(10 * time.Second))
+	router.Use(middlewares.TimeoutMiddleware(10*time.Second, map[string]time.Duration{
+		"/api/v1/health/redis":    3 * time.Second,
+		"/api/v1/health/postgres": 3 * time.Second,
+		"/api/v1/health/info":     3 * time.Second,
+		"/api/v1/auth/me/export":  2 * time.Minute,
+	}))
+	router.Use(middlewares.APIKeyMiddleware(logger))
+	router.Use(middlewares.CSRFMiddleware(logger))
 	router.Use(middlewares.RateLimiterMiddleware(database.RedisClient, 120, time.Minute, logger))
+	router.Use(middlewares.MaintenanceMiddleware(database.RedisClient, logger))
+	router.Use(middlewares.LastSeenMiddleware(database.RedisClient, logger))
 
 	apiv1 := router.Group("/api/v1")
 	routes.HealthRoutes(apiv1)
-	routes.AuthRoutes(apiv1, database.PostgresDB, logger)
-	routes.ProfileRoutes(apiv1, database.PostgresDB, logger)
-	routes.FollowRoutes(apiv1, database.PostgresDB, logger)
-	routes.PostRoutes(apiv1, database.PostgresDB, logger)
-	routes.PostLikeRoutes(apiv1, database.PostgresDB, logger)
-	routes.CommentRoutes(apiv1, database.PostgresDB, logger)
-	routes.CommentLikeRoutes(apiv1, database.PostgresDB, logger)
-	routes.FeedRoutes(apiv1, database.PostgresDB, logger)
-	routes.ActionRoutes(apiv1, database.PostgresDB, logger)
-
-	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	routes.AuthRoutes(apiv1, database.PostgresDB, database.RedisClient, webhookDispatcher, logger)
+	routes.ProfileRoutes(apiv1, database.PostgresDB, database.RedisClient, logger)
+	routes.FollowRoutes(apiv1, database.PostgresDB, database.RedisClient, logger)
+	routes.PostRoutes(apiv1, database.PostgresDB, database.RedisClient, contentPolicy, logger)
+	routes.PostLikeRoutes(apiv1, database.PostgresDB, database.RedisClient, logger)
+	routes.BookmarkRoutes(apiv1, database.PostgresDB, logger)
+	routes.CommentRoutes(apiv1, database.PostgresDB, contentPolicy, logger)
+	routes.CommentLikeRoutes(apiv1, database.PostgresDB, database.RedisClient, logger)
+	routes.FeedRoutes(apiv1, database.PostgresDB, database.RedisClient, logger)
+	routes.ActionRoutes(apiv1, database.PostgresDB, database.RedisClient, webhookDispatcher, logger)
+	routes.NotificationRoutes(apiv1, database.PostgresDB, logger)
+	routes.RoleRoutes(apiv1, database.PostgresDB, logger)
+
+	if ENABLE_SWAGGER {
+		swaggerHandlers := []gin.HandlerFunc{ginSwagger.WrapHandler(swaggerFiles.Handler)}
+		if SWAGGER_USERNAME != "" && SWAGGER_PASSWORD != "" {
+			swaggerHandlers = append([]gin.HandlerFunc{gin.BasicAuth(gin.Accounts{SWAGGER_USERNAME: SWAGGER_PASSWORD})}, swaggerHandlers...)
+		} else if SERVER_MODE == gin.ReleaseMode {
+			logger.Warn("Swagger UI Enabled in Release Mode without SWAGGER_USERNAME and SWAGGER_PASSWORD!")
+		}
+		router.GET("/swagger/*any", swaggerHandlers...)
+	}
 
 	server := &http.Server{
 		Addr:    SERVER_PORT,
@@ -97,12 +158,24 @@ func main() {
 	<-quit
 	logger.WithFields(logrus.Fields{"signal": "SIGINT"}).Info("Shutdown Signal Received, Exiting Gracefully...")
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(SERVER_SHUTDOWN_TIMEOUT_SECONDS)*time.Second)
 	defer cancel()
 
+	// Background components are stopped and awaited first, while the database and Redis are still open.
+	// Hijacked connections such as WebSockets are not tracked by server.Shutdown, they are stopped here too.
+	if err := shutdownCoordinator.Shutdown(ctx); err != nil {
+		logger.WithFields(logrus.Fields{"error": err}).Error("Background Components Did Not Stop in Time!")
+	}
+
+	// server.Shutdown stops accepting new connections and waits for in-flight requests to complete.
 	if err := server.Shutdown(ctx); err != nil {
 		logger.WithFields(logrus.Fields{"error": err}).Error("Server Forced to Shutdown!")
 	}
 
+	// In-flight requests may still queue webhook events, so the dispatcher is drained after the server stopped.
+	if err := webhookDispatcher.Shutdown(ctx); err != nil {
+		logger.WithFields(logrus.Fields{"error": err}).Error("Webhook Dispatcher Did Not Drain in Time!")
+	}
+
 	logger.WithFields(logrus.Fields{"mode": SERVER_MODE}).Info("Server Shutdown Successfully!")
 }
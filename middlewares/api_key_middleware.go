@@ -0,0 +1,104 @@
+package middlewares
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+
+	"github.com/datarohit/gopher-social-backend/database"
+	"github.com/datarohit/gopher-social-backend/helpers"
+	"github.com/datarohit/gopher-social-backend/stores"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// APIKeyKey is the context key under which the API key of a request authenticated by APIKeyMiddleware is stored.
+	APIKeyKey = "apiKey"
+
+	// RateLimitBypassKey is the context key of the flag telling RateLimiterMiddleware to skip the request.
+	RateLimitBypassKey = "rateLimitBypass"
+)
+
+// APIKeyMiddleware is a middleware that authenticates server-to-server callers by the X-API-Key header.
+// Requests without the header pass through untouched. A valid key sets the key's owner as the user in the
+// context with the role configured on the key, and flags the request so RateLimiterMiddleware does not limit it.
+// AuthMiddleware then accepts the request without token cookies. Keys with only the read scope may make GET,
+// HEAD and OPTIONS requests. Unknown or revoked keys get a 401 Unauthorized error, a key without the scope
+// needed for the method, or owned by a banned, inactive or timed out user, gets a 403 Forbidden error.
+// It must be registered before RateLimiterMiddleware.
+//
+// Parameters:
+//   - logger (*logrus.Logger): Logger for logging rejected keys.
+//
+// Returns:
+//   - gin.HandlerFunc: Gin middleware handler for API key authentication.
+func APIKeyMiddleware(logger *logrus.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader("X-API-Key")
+		if key == "" {
+			c.Next()
+			return
+		}
+
+		apiKeyStore := stores.NewAPIKeyStore(database.PostgresDB)
+		authStore := stores.NewAuthStore(database.PostgresDB)
+
+		apiKey, err := apiKeyStore.Validate(c, key)
+		if err != nil {
+			if errors.Is(err, stores.ErrInvalidAPIKey) {
+				logger.WithFields(logrus.Fields{"path": c.Request.URL.Path}).Warn("Invalid or revoked API key")
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"message": "Unauthorized", "error": "invalid api key", "code": helpers.ErrorCode(err)})
+			} else {
+				logger.WithFields(logrus.Fields{"error": err}).Error("Failed to validate API key")
+				c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"message": "Internal Server Error", "error": "internal server error", "code": helpers.CodeInternal})
+			}
+			return
+		}
+
+		if !apiKeyAllowsMethod(apiKey.Scopes, c.Request.Method) {
+			logger.WithFields(logrus.Fields{"apiKeyID": apiKey.ID, "method": c.Request.Method, "scopes": apiKey.Scopes}).Warn("API key scope does not allow request method")
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"message": "Forbidden", "error": "api key scope does not allow this request", "code": helpers.CodeForbidden})
+			return
+		}
+
+		user, err := authStore.GetUserByID(c, apiKey.UserID)
+		if err != nil {
+			if errors.Is(err, stores.ErrUserNotFound) {
+				logger.WithFields(logrus.Fields{"apiKeyID": apiKey.ID, "userID": apiKey.UserID}).Warn("User not found for API key")
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"message": "Unauthorized", "error": "user not found", "code": helpers.CodeUnauthorized})
+			} else {
+				logger.WithFields(logrus.Fields{"error": err, "userID": apiKey.UserID}).Error("Failed to get user by ID from API key")
+				c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"message": "Internal Server Error", "error": "internal server error", "code": helpers.CodeInternal})
+			}
+			return
+		}
+
+		if rejected := rejectRestrictedUser(c, logger, user); rejected {
+			return
+		}
+
+		user.RoleID = apiKey.Role.ID
+		user.Role = apiKey.Role
+
+		markModeratorViewer(c, user)
+		c.Set("user", user)
+		c.Set(APIKeyKey, apiKey)
+		c.Set(RateLimitBypassKey, true)
+		c.Next()
+	}
+}
+
+// apiKeyAllowsMethod reports whether a key with the given scopes may make a request with the given method.
+func apiKeyAllowsMethod(scopes []string, method string) bool {
+	if slices.Contains(scopes, stores.APIKeyScopeWrite) {
+		return true
+	}
+
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return slices.Contains(scopes, stores.APIKeyScopeRead)
+	default:
+		return false
+	}
+}
@@ -2,7 +2,11 @@ package middlewares
 
 import (
 	"errors"
+	"fmt"
+	"math"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/datarohit/gopher-social-backend/database"
@@ -10,12 +14,34 @@ import (
 	"github.com/datarohit/gopher-social-backend/models"
 	"github.com/datarohit/gopher-social-backend/stores"
 	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 )
 
+// SessionIDKey is the context key under which the current session ID is stored.
+const SessionIDKey = "sessionID"
+
+// timeoutExemptRoutes are routes timed out users may always access, so they can learn when their timeout ends.
+var timeoutExemptRoutes = map[string]bool{
+	"/api/v1/auth/me/status": true,
+}
+
+// ImpersonatedByKey is the context key under which the ID of the impersonating admin is stored,
+// set when the request was authenticated with an impersonation token.
+const ImpersonatedByKey = "impersonatedBy"
+
+// BearerAuthKey is the context key set when the request was authenticated with an Authorization: Bearer header.
+const BearerAuthKey = "bearerAuth"
+
 // AuthMiddleware is a middleware function to authenticate user requests using JWT tokens from cookies.
 // It checks for access token and refresh token cookies, verifies them, and sets the user in the context.
 // It also handles access token refreshing using refresh token if access token is expired.
+// Tokens belonging to a revoked session, or issued before the user's token epoch, are rejected.
+// Requests already authenticated by APIKeyMiddleware are passed through unchanged.
+// An access token in an Authorization: Bearer header takes precedence over the cookies. Bearer tokens are
+// never refreshed here, header-based clients log in again with tokenDelivery=body once their token expires.
+// Impersonation tokens issued to admins are read-only, POST, PUT, PATCH and DELETE requests made with them are rejected.
 //
 // Parameters:
 //   - logger (*logrus.Logger): Logrus logger instance for logging.
@@ -24,11 +50,26 @@ import (
 //   - gin.HandlerFunc: Gin middleware handler function.
 func AuthMiddleware(logger *logrus.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		if _, authenticated := c.Get(APIKeyKey); authenticated {
+			c.Next()
+			return
+		}
+
 		accessTokenCookie, errAccessToken := c.Cookie("access_token")
 		refreshTokenCookie, errRefreshToken := c.Cookie("refresh_token")
 
+		bearerToken, bearerAuth := bearerTokenFromHeader(c)
+		if bearerAuth {
+			accessTokenCookie, errAccessToken = bearerToken, nil
+			refreshTokenCookie, errRefreshToken = "", http.ErrNoCookie
+		}
+
 		authStore := stores.NewAuthStore(database.PostgresDB)
+		sessionStore := stores.NewSessionStore(database.PostgresDB, database.RedisClient)
 		var user *models.User
+		var sessionID uuid.UUID
+		var impersonatedBy uuid.UUID
+		var impersonated bool
 
 		if errAccessToken == nil {
 			accessToken, err := helpers.VerifyAccessToken(accessTokenCookie)
@@ -36,7 +77,37 @@ func AuthMiddleware(logger *logrus.Logger) gin.HandlerFunc {
 				userID, err := helpers.ExtractUserIDFromToken(accessToken)
 				if err != nil {
 					logger.WithFields(logrus.Fields{"error": err}).Warn("Failed to extract User ID from Access Token")
-					c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"message": "Unauthorized", "error": "invalid access token"})
+					c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"message": "Unauthorized", "error": "invalid access token", "code": helpers.CodeUnauthorized})
+					return
+				}
+
+				sessionID, err = helpers.ExtractSessionIDFromToken(accessToken)
+				if err != nil {
+					logger.WithFields(logrus.Fields{"error": err}).Warn("Failed to extract Session ID from Access Token")
+					c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"message": "Unauthorized", "error": "invalid access token", "code": helpers.CodeUnauthorized})
+					return
+				}
+
+				revoked, err := sessionStore.IsSessionRevoked(c, sessionID)
+				if err != nil {
+					logger.WithFields(logrus.Fields{"error": err, "sessionID": sessionID}).Error("Failed to check session denylist")
+					c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"message": "Internal Server Error", "error": "internal server error", "code": helpers.CodeInternal})
+					return
+				}
+				if revoked {
+					logger.WithFields(logrus.Fields{"userID": userID, "sessionID": sessionID}).Warn("Access token used for revoked session")
+					c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"message": "Unauthorized", "error": "session revoked", "code": helpers.CodeSessionRevoked})
+					return
+				}
+
+				if rejected := rejectTokenBeforeEpoch(c, sessionStore, logger, accessToken, userID); rejected {
+					return
+				}
+
+				impersonatedBy, impersonated = helpers.ExtractImpersonatorFromToken(accessToken)
+				if impersonated && isWriteMethod(c.Request.Method) {
+					logger.WithFields(logrus.Fields{"userID": userID, "impersonatedBy": impersonatedBy, "path": c.Request.URL.Path}).Warn("Write attempted with impersonation token")
+					c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"message": "Forbidden", "error": "impersonation tokens are read-only", "code": helpers.CodeImpersonationReadOnly})
 					return
 				}
 
@@ -44,34 +115,61 @@ func AuthMiddleware(logger *logrus.Logger) gin.HandlerFunc {
 				if err != nil {
 					if errors.Is(err, stores.ErrUserNotFound) {
 						logger.WithFields(logrus.Fields{"userID": userID}).Warn("User not found from access token's User ID")
-						c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"message": "Unauthorized", "error": "user not found"})
+						c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"message": "Unauthorized", "error": "user not found", "code": helpers.CodeUnauthorized})
 						return
 					}
 					logger.WithFields(logrus.Fields{"error": err, "userID": userID}).Error("Failed to get user by ID from access token")
-					c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"message": "Internal Server Error", "error": "internal server error"})
+					c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"message": "Internal Server Error", "error": "internal server error", "code": helpers.CodeInternal})
 					return
 				}
 			}
 		}
 
 		if user == nil {
+			if bearerAuth {
+				logger.Warn("Unauthorized access attempt: Invalid or expired bearer token")
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"message": "Unauthorized", "error": "invalid access token", "code": helpers.CodeUnauthorized})
+				return
+			}
 			if errRefreshToken != nil {
 				logger.Warn("Unauthorized access attempt: No valid access or refresh token found")
-				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"message": "Unauthorized", "error": "missing auth tokens"})
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"message": "Unauthorized", "error": "missing auth tokens", "code": helpers.CodeUnauthorized})
 				return
 			}
 
 			refreshToken, err := helpers.VerifyRefreshToken(refreshTokenCookie)
 			if err != nil || !refreshToken.Valid {
 				logger.WithFields(logrus.Fields{"error": err}).Warn("Invalid refresh token")
-				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"message": "Unauthorized", "error": "invalid refresh token"})
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"message": "Unauthorized", "error": "invalid refresh token", "code": helpers.CodeUnauthorized})
 				return
 			}
 
 			userID, err := helpers.ExtractUserIDFromToken(refreshToken)
 			if err != nil {
 				logger.WithFields(logrus.Fields{"error": err}).Warn("Failed to extract User ID from Refresh Token")
-				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"message": "Unauthorized", "error": "invalid refresh token"})
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"message": "Unauthorized", "error": "invalid refresh token", "code": helpers.CodeUnauthorized})
+				return
+			}
+
+			sessionID, err = helpers.ExtractSessionIDFromToken(refreshToken)
+			if err != nil {
+				logger.WithFields(logrus.Fields{"error": err}).Warn("Failed to extract Session ID from Refresh Token")
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"message": "Unauthorized", "error": "invalid refresh token", "code": helpers.CodeUnauthorized})
+				return
+			}
+
+			if rejected := rejectTokenBeforeEpoch(c, sessionStore, logger, refreshToken, userID); rejected {
+				return
+			}
+
+			if err := sessionStore.TouchSession(c, sessionID); err != nil {
+				if errors.Is(err, stores.ErrSessionRevoked) || errors.Is(err, stores.ErrSessionNotFound) {
+					logger.WithFields(logrus.Fields{"userID": userID, "sessionID": sessionID}).Warn("Refresh token used for revoked session")
+					c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"message": "Unauthorized", "error": "session revoked", "code": helpers.CodeSessionRevoked})
+					return
+				}
+				logger.WithFields(logrus.Fields{"error": err, "sessionID": sessionID}).Error("Failed to update session from refresh token")
+				c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"message": "Internal Server Error", "error": "internal server error", "code": helpers.CodeInternal})
 				return
 			}
 
@@ -79,25 +177,25 @@ func AuthMiddleware(logger *logrus.Logger) gin.HandlerFunc {
 			if err != nil {
 				if errors.Is(err, stores.ErrUserNotFound) {
 					logger.WithFields(logrus.Fields{"userID": userID}).Warn("User not found from refresh token's User ID")
-					c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"message": "Unauthorized", "error": "user not found"})
+					c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"message": "Unauthorized", "error": "user not found", "code": helpers.CodeUnauthorized})
 					return
 				}
 				logger.WithFields(logrus.Fields{"error": err, "userID": userID}).Error("Failed to get user by ID from refresh token")
-				c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"message": "Internal Server Error", "error": "internal server error"})
+				c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"message": "Internal Server Error", "error": "internal server error", "code": helpers.CodeInternal})
 				return
 			}
 
-			newAccessToken, err := helpers.GenerateAccessToken(user.ID)
+			newAccessToken, err := helpers.GenerateAccessToken(user.ID, sessionID)
 			if err != nil {
 				logger.WithFields(logrus.Fields{"error": err, "userID": user.ID}).Error("Failed to generate new access token during refresh")
-				c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"message": "Internal Server Error", "error": "internal server error"})
+				c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"message": "Internal Server Error", "error": "internal server error", "code": helpers.CodeInternal})
 				return
 			}
 
-			newRefreshToken, err := helpers.GenerateRefreshToken(user.ID)
+			newRefreshToken, err := helpers.GenerateRefreshToken(user.ID, sessionID)
 			if err != nil {
 				logger.WithFields(logrus.Fields{"error": err, "userID": user.ID}).Error("Failed to generate new refresh token during refresh")
-				c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"message": "Internal Server Error", "error": "internal server error"})
+				c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"message": "Internal Server Error", "error": "internal server error", "code": helpers.CodeInternal})
 				return
 			}
 
@@ -105,25 +203,138 @@ func AuthMiddleware(logger *logrus.Logger) gin.HandlerFunc {
 			c.SetCookie("refresh_token", newRefreshToken, int(time.Hour*6/time.Second), "/", "", true, true)
 		}
 
-		if user.Banned {
-			logger.WithFields(logrus.Fields{"userID": user.ID}).Warn("Banned user attempted authorized action")
-			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"message": "Forbidden", "error": "account banned"})
-			return
-		}
-
-		if !user.IsActive {
-			logger.WithFields(logrus.Fields{"userID": user.ID}).Warn("Inactive user attempted authorized action")
-			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"message": "Forbidden", "error": "account not active"})
+		if rejected := rejectRestrictedUser(c, logger, user); rejected {
 			return
 		}
 
-		if user.TimeoutUntil != nil && user.TimeoutUntil.After(time.Now()) {
-			logger.WithFields(logrus.Fields{"userID": user.ID, "timeout_until": user.TimeoutUntil}).Warn("User timeout, attempted authorized action")
-			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"message": "Forbidden", "error": "account timeout"})
-			return
+		// Cookie-authenticated clients that predate CSRF protection, or whose session cookie expired, get a new CSRF token.
+		if _, err := c.Cookie(CSRFCookieName); err != nil && !bearerAuth {
+			if err := SetCSRFCookie(c); err != nil {
+				logger.WithFields(logrus.Fields{"error": err, "userID": user.ID}).Warn("Failed to Issue Missing CSRF Token")
+			}
 		}
 
+		markModeratorViewer(c, user)
 		c.Set("user", user)
+		c.Set(SessionIDKey, sessionID)
+		if bearerAuth {
+			c.Set(BearerAuthKey, true)
+		}
+		if impersonated {
+			c.Set(ImpersonatedByKey, impersonatedBy)
+		}
 		c.Next()
 	}
 }
+
+// bearerTokenFromHeader returns the token of an Authorization: Bearer header, if the request has one.
+//
+// Parameters:
+//   - c (*gin.Context): Gin context of the request.
+//
+// Returns:
+//   - string: The bearer token.
+//   - bool: True if the request has a non-empty bearer token.
+func bearerTokenFromHeader(c *gin.Context) (string, bool) {
+	header := c.GetHeader("Authorization")
+	if len(header) < len("Bearer ") || !strings.EqualFold(header[:len("Bearer ")], "Bearer ") {
+		return "", false
+	}
+
+	token := strings.TrimSpace(header[len("Bearer "):])
+	return token, token != ""
+}
+
+// rejectTokenBeforeEpoch aborts the request if a token was issued before the token epoch of its user,
+// which is bumped when an admin force logs out the user.
+//
+// Parameters:
+//   - c (*gin.Context): Gin context of the request.
+//   - sessionStore (*stores.SessionStore): SessionStore used to look up the token epoch.
+//   - logger (*logrus.Logger): Logrus logger instance for logging.
+//   - token (*jwt.Token): Verified access or refresh token.
+//   - userID (uuid.UUID): ID of the user owning the token.
+//
+// Returns:
+//   - bool: True if the request was aborted.
+func rejectTokenBeforeEpoch(c *gin.Context, sessionStore *stores.SessionStore, logger *logrus.Logger, token *jwt.Token, userID uuid.UUID) bool {
+	issuedAt, err := helpers.ExtractIssuedAtFromToken(token)
+	if err != nil {
+		logger.WithFields(logrus.Fields{"error": err, "userID": userID}).Warn("Failed to extract Issued At from Token")
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"message": "Unauthorized", "error": "invalid token", "code": helpers.CodeUnauthorized})
+		return true
+	}
+
+	beforeEpoch, err := sessionStore.IsTokenBeforeEpoch(c, userID, issuedAt)
+	if err != nil {
+		logger.WithFields(logrus.Fields{"error": err, "userID": userID}).Error("Failed to check token epoch")
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"message": "Internal Server Error", "error": "internal server error", "code": helpers.CodeInternal})
+		return true
+	}
+	if beforeEpoch {
+		logger.WithFields(logrus.Fields{"userID": userID, "issuedAt": issuedAt}).Warn("Token issued before user's token epoch")
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"message": "Unauthorized", "error": "session revoked", "code": helpers.CodeSessionRevoked})
+		return true
+	}
+
+	return false
+}
+
+// rejectRestrictedUser aborts the request if the user is banned or not activated, or if the user is timed out
+// and the request is a write. Timed out users may still read, and may access the routes in timeoutExemptRoutes.
+// It is shared by AuthMiddleware and APIKeyMiddleware, so every authenticated mutating route is covered.
+//
+// Parameters:
+//   - c (*gin.Context): Gin context of the request.
+//   - logger (*logrus.Logger): Logrus logger instance for logging.
+//   - user (*models.User): Authenticated user.
+//
+// Returns:
+//   - bool: True if the request was aborted.
+func rejectRestrictedUser(c *gin.Context, logger *logrus.Logger, user *models.User) bool {
+	if user.Banned {
+		logger.WithFields(logrus.Fields{"userID": user.ID}).Warn("Banned user attempted authorized action")
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"message": "Forbidden", "error": "account banned", "code": helpers.CodeAccountBanned})
+		return true
+	}
+
+	if !user.IsActive {
+		logger.WithFields(logrus.Fields{"userID": user.ID}).Warn("Inactive user attempted authorized action")
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"message": "Forbidden", "error": "account not active", "code": helpers.CodeAccountNotActivated})
+		return true
+	}
+
+	if user.TimeoutUntil != nil && user.TimeoutUntil.After(time.Now()) && isWriteMethod(c.Request.Method) && !timeoutExemptRoutes[c.FullPath()] {
+		remaining := time.Until(*user.TimeoutUntil)
+		logger.WithFields(logrus.Fields{"userID": user.ID, "timeout_until": user.TimeoutUntil}).Warn("User timeout, attempted write action")
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(remaining.Seconds()))))
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+			"message":                   "Forbidden",
+			"error":                     fmt.Sprintf("account timed out, try again in %s", remaining.Round(time.Second)),
+			"code":                      helpers.CodeAccountTimedOut,
+			"timeout_until":             user.TimeoutUntil,
+			"timeout_remaining_seconds": int64(math.Ceil(remaining.Seconds())),
+		})
+		return true
+	}
+
+	return false
+}
+
+// markModeratorViewer marks the request context of moderators and admins, so stores show them content of
+// banned authors regardless of BANNED_AUTHOR_CONTENT. It is shared by AuthMiddleware and APIKeyMiddleware.
+func markModeratorViewer(c *gin.Context, user *models.User) {
+	if user.Role != nil && user.Role.Level >= 2 {
+		c.Request = c.Request.WithContext(stores.WithModeratorViewer(c.Request.Context()))
+	}
+}
+
+// isWriteMethod reports whether an HTTP method changes state on the server.
+func isWriteMethod(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
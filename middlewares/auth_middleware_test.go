@@ -0,0 +1,85 @@
+package middlewares
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/datarohit/gopher-social-backend/helpers"
+	"github.com/datarohit/gopher-social-backend/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func TestRejectRestrictedUser(t *testing.T) {
+	timeoutUntil := time.Now().Add(time.Hour)
+	expiredTimeout := time.Now().Add(-time.Minute)
+
+	timedOut := &models.User{ID: uuid.New(), IsActive: true, TimeoutUntil: &timeoutUntil}
+	tests := []struct {
+		name       string
+		user       *models.User
+		method     string
+		path       string
+		wantStatus int
+		wantCode   string
+	}{
+		{name: "active user write", user: &models.User{ID: uuid.New(), IsActive: true}, method: http.MethodPost, path: "/posts", wantStatus: http.StatusOK},
+		{name: "banned user read", user: &models.User{ID: uuid.New(), IsActive: true, Banned: true}, method: http.MethodGet, path: "/posts", wantStatus: http.StatusForbidden, wantCode: helpers.CodeAccountBanned},
+		{name: "inactive user read", user: &models.User{ID: uuid.New()}, method: http.MethodGet, path: "/posts", wantStatus: http.StatusForbidden, wantCode: helpers.CodeAccountNotActivated},
+		{name: "timed out user post", user: timedOut, method: http.MethodPost, path: "/posts", wantStatus: http.StatusForbidden, wantCode: helpers.CodeAccountTimedOut},
+		{name: "timed out user put", user: timedOut, method: http.MethodPut, path: "/posts", wantStatus: http.StatusForbidden, wantCode: helpers.CodeAccountTimedOut},
+		{name: "timed out user patch", user: timedOut, method: http.MethodPatch, path: "/posts", wantStatus: http.StatusForbidden, wantCode: helpers.CodeAccountTimedOut},
+		{name: "timed out user delete", user: timedOut, method: http.MethodDelete, path: "/posts", wantStatus: http.StatusForbidden, wantCode: helpers.CodeAccountTimedOut},
+		{name: "timed out user get", user: timedOut, method: http.MethodGet, path: "/posts", wantStatus: http.StatusOK},
+		{name: "timed out user status", user: timedOut, method: http.MethodGet, path: "/api/v1/auth/me/status", wantStatus: http.StatusOK},
+		{name: "timed out user write to exempt route", user: timedOut, method: http.MethodPost, path: "/api/v1/auth/me/status", wantStatus: http.StatusOK},
+		{name: "expired timeout write", user: &models.User{ID: uuid.New(), IsActive: true, TimeoutUntil: &expiredTimeout}, method: http.MethodPost, path: "/posts", wantStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.Use(func(c *gin.Context) {
+				if rejectRestrictedUser(c, newTestLogger(), tt.user) {
+					return
+				}
+				c.Next()
+			})
+			router.Handle(tt.method, tt.path, func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, httptest.NewRequest(tt.method, tt.path, nil))
+
+			if recorder.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", recorder.Code, tt.wantStatus)
+			}
+			if tt.wantCode == "" {
+				return
+			}
+
+			var body struct {
+				Code                    string `json:"code"`
+				TimeoutRemainingSeconds int64  `json:"timeout_remaining_seconds"`
+			}
+			if err := json.Unmarshal(recorder.Body.Bytes(), &body); err != nil {
+				t.Fatalf("failed to decode body %q: %v", recorder.Body.String(), err)
+			}
+			if body.Code != tt.wantCode {
+				t.Fatalf("code = %q, want %q", body.Code, tt.wantCode)
+			}
+			if tt.wantCode != helpers.CodeAccountTimedOut {
+				return
+			}
+			retryAfter, err := strconv.ParseInt(recorder.Header().Get("Retry-After"), 10, 64)
+			if err != nil || retryAfter != body.TimeoutRemainingSeconds || retryAfter < 3599 || retryAfter > 3600 {
+				t.Fatalf("Retry-After = %q, timeout_remaining_seconds = %d, want about 3600 for both", recorder.Header().Get("Retry-After"), body.TimeoutRemainingSeconds)
+			}
+		})
+	}
+}
@@ -17,7 +17,7 @@ func CORSMiddleware() gin.HandlerFunc {
 	config := cors.DefaultConfig()
 	config.AllowOrigins = []string{"*"}
 	config.AllowMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"}
-	config.AllowHeaders = []string{"Origin", "Content-Length", "Content-Type", "Accept", "Accept-Encoding", "Accept-Language", "Authorization"}
+	config.AllowHeaders = []string{"Origin", "Content-Length", "Content-Type", "Accept", "Accept-Encoding", "Accept-Language", "Authorization", CSRFHeaderName, "X-Request-ID"}
 	config.AllowCredentials = true
 	config.MaxAge = 12 * time.Hour
 
@@ -0,0 +1,121 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestCSRFMiddleware(t *testing.T) {
+	tests := []struct {
+		name        string
+		method      string
+		cookies     map[string]string
+		headers     map[string]string
+		apiKey      bool
+		wantStatus  int
+		wantHandled bool
+	}{
+		{
+			name:        "safe method without token",
+			method:      http.MethodGet,
+			cookies:     map[string]string{"access_token": "jwt"},
+			wantStatus:  http.StatusOK,
+			wantHandled: true,
+		},
+		{
+			name:        "mutation without auth cookies",
+			method:      http.MethodPost,
+			wantStatus:  http.StatusOK,
+			wantHandled: true,
+		},
+		{
+			name:       "missing header",
+			method:     http.MethodPost,
+			cookies:    map[string]string{"access_token": "jwt", CSRFCookieName: "token"},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "missing cookie",
+			method:     http.MethodDelete,
+			cookies:    map[string]string{"refresh_token": "jwt"},
+			headers:    map[string]string{CSRFHeaderName: "token"},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "mismatched token",
+			method:     http.MethodPut,
+			cookies:    map[string]string{"access_token": "jwt", CSRFCookieName: "token"},
+			headers:    map[string]string{CSRFHeaderName: "other"},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:        "matching cookie and header",
+			method:      http.MethodPatch,
+			cookies:     map[string]string{"access_token": "jwt", CSRFCookieName: "token"},
+			headers:     map[string]string{CSRFHeaderName: "token"},
+			wantStatus:  http.StatusOK,
+			wantHandled: true,
+		},
+		{
+			name:        "bearer request exempt",
+			method:      http.MethodPost,
+			cookies:     map[string]string{"access_token": "jwt"},
+			headers:     map[string]string{"Authorization": "Bearer jwt"},
+			wantStatus:  http.StatusOK,
+			wantHandled: true,
+		},
+		{
+			name:       "empty bearer header not exempt",
+			method:     http.MethodPost,
+			cookies:    map[string]string{"access_token": "jwt"},
+			headers:    map[string]string{"Authorization": "Bearer "},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:        "api key request exempt",
+			method:      http.MethodPost,
+			cookies:     map[string]string{"access_token": "jwt"},
+			apiKey:      true,
+			wantStatus:  http.StatusOK,
+			wantHandled: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handled := false
+			router := gin.New()
+			router.Use(func(c *gin.Context) {
+				if tt.apiKey {
+					c.Set(APIKeyKey, true)
+				}
+				c.Next()
+			})
+			router.Use(CSRFMiddleware(newTestLogger()))
+			router.Handle(tt.method, "/resource", func(c *gin.Context) {
+				handled = true
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(tt.method, "/resource", nil)
+			for name, value := range tt.cookies {
+				req.AddCookie(&http.Cookie{Name: name, Value: value})
+			}
+			for name, value := range tt.headers {
+				req.Header.Set(name, value)
+			}
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, req)
+
+			if recorder.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", recorder.Code, tt.wantStatus)
+			}
+			if handled != tt.wantHandled {
+				t.Fatalf("handler ran = %v, want %v", handled, tt.wantHandled)
+			}
+		})
+	}
+}
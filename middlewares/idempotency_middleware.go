@@ -0,0 +1,171 @@
+package middlewares
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/datarohit/gopher-social-backend/helpers"
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+)
+
+// idempotencyKeyMaxLength is the longest Idempotency-Key header value accepted.
+const idempotencyKeyMaxLength = 255
+
+// idempotencyPending is stored under an idempotency key while the first request with it is being handled.
+const idempotencyPending = "pending"
+
+// idempotencyPendingTTL bounds how long a key stays pending when its request never finishes, such as when the
+// process dies. It is longer than the request timeout, so a slow request is not run twice.
+const idempotencyPendingTTL = 30 * time.Second
+
+// idempotencyKeyTTL is how long the response to a request with an Idempotency-Key is replayed.
+var idempotencyKeyTTL = idempotencyKeyTTLFromEnv()
+
+// idempotencyKeyTTLFromEnv reads IDEMPOTENCY_KEY_TTL_SECONDS, defaulting to 10 minutes.
+func idempotencyKeyTTLFromEnv() time.Duration {
+	seconds := helpers.GetEnvAsInt("IDEMPOTENCY_KEY_TTL_SECONDS", 600)
+	if seconds <= 0 {
+		return 10 * time.Minute
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// idempotentResponse is the response of a request stored under its idempotency key.
+type idempotentResponse struct {
+	Status      int    `json:"status"`
+	ContentType string `json:"content_type"`
+	Body        []byte `json:"body"`
+}
+
+// idempotencyResponseWriter records the response body written by the handler, in addition to sending it.
+type idempotencyResponseWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *idempotencyResponseWriter) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *idempotencyResponseWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// IdempotencyMiddleware is a middleware that makes mutations safe to retry with an Idempotency-Key header.
+// The first request with a key runs normally and its response is stored in Redis for IDEMPOTENCY_KEY_TTL_SECONDS,
+// later requests with the same key get the stored response, with an Idempotent-Replayed header, without running
+// the handler again. Keys are scoped to the logged-in user, the method and the request path. A request whose key is
+// still being handled gets a 409 Conflict error. Server errors and panics are not stored, the key is released so
+// the request can be retried. Requests without the header pass through untouched. It must run after AuthMiddleware.
+//
+// Parameters:
+//   - redisClient (*redis.Client): Redis client used to store responses.
+//   - logger (*logrus.Logger): Logger for logging Redis failures.
+//
+// Returns:
+//   - gin.HandlerFunc: Gin middleware handler for idempotent requests.
+func IdempotencyMiddleware(redisClient *redis.Client, logger *logrus.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		idempotencyKey := c.GetHeader("Idempotency-Key")
+		if idempotencyKey == "" {
+			c.Next()
+			return
+		}
+
+		if len(idempotencyKey) > idempotencyKeyMaxLength {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"error": "Idempotency-Key must be at most 255 characters!",
+				"code":  helpers.CodeBadRequest,
+			})
+			return
+		}
+
+		user := helpers.RequireUser(c)
+
+		ctx := c.Request.Context()
+		key := "idem:" + user.ID.String() + ":" + c.Request.Method + ":" + c.Request.URL.Path + ":" + idempotencyKey
+
+		acquired, err := redisClient.SetNX(ctx, key, idempotencyPending, idempotencyPendingTTL).Result()
+		if err != nil {
+			logger.WithFields(logrus.Fields{"error": err, "userID": user.ID}).Error("Failed to Reserve Idempotency Key in Redis!")
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Internal Server Error!", "code": helpers.CodeInternal})
+			return
+		}
+
+		if !acquired {
+			stored, err := redisClient.Get(ctx, key).Result()
+			if errors.Is(err, redis.Nil) {
+				c.Next()
+				return
+			}
+			if err != nil {
+				logger.WithFields(logrus.Fields{"error": err, "userID": user.ID}).Error("Failed to Get Idempotent Response from Redis!")
+				c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Internal Server Error!", "code": helpers.CodeInternal})
+				return
+			}
+
+			if stored == idempotencyPending {
+				c.AbortWithStatusJSON(http.StatusConflict, gin.H{
+					"error": "A Request with this Idempotency-Key is Still Being Processed!",
+					"code":  helpers.CodeConflict,
+				})
+				return
+			}
+
+			var response idempotentResponse
+			if err := json.Unmarshal([]byte(stored), &response); err != nil {
+				logger.WithFields(logrus.Fields{"error": err, "userID": user.ID}).Error("Failed to Decode Idempotent Response!")
+				c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Internal Server Error!", "code": helpers.CodeInternal})
+				return
+			}
+
+			c.Header("Idempotent-Replayed", "true")
+			c.Data(response.Status, response.ContentType, response.Body)
+			c.Abort()
+			return
+		}
+
+		writer := &idempotencyResponseWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+
+		// Deferred so the key is also released when the handler panics, RecovererMiddleware answers the panic.
+		completed := false
+		defer func() {
+			// Use a fresh context, the request context may already be cancelled by a timeout.
+			storeCtx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+			defer cancel()
+
+			if !completed || writer.Status() >= http.StatusInternalServerError {
+				if err := redisClient.Del(storeCtx, key).Err(); err != nil {
+					logger.WithFields(logrus.Fields{"error": err, "userID": user.ID}).Error("Failed to Release Idempotency Key in Redis!")
+				}
+				return
+			}
+
+			data, err := json.Marshal(idempotentResponse{
+				Status:      writer.Status(),
+				ContentType: writer.Header().Get("Content-Type"),
+				Body:        writer.body.Bytes(),
+			})
+			if err != nil {
+				logger.WithFields(logrus.Fields{"error": err, "userID": user.ID}).Error("Failed to Encode Idempotent Response!")
+				return
+			}
+
+			if err := redisClient.Set(storeCtx, key, data, idempotencyKeyTTL).Err(); err != nil {
+				logger.WithFields(logrus.Fields{"error": err, "userID": user.ID}).Error("Failed to Store Idempotent Response in Redis!")
+			}
+		}()
+
+		c.Next()
+		completed = true
+	}
+}
@@ -0,0 +1,147 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/datarohit/gopher-social-backend/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// newIdempotencyRouter returns a router serving POST /like behind IdempotencyMiddleware for the given user.
+func newIdempotencyRouter(redisClient *redis.Client, user *models.User, handler gin.HandlerFunc) *gin.Engine {
+	router := gin.New()
+	router.Use(RecovererMiddleware(newTestLogger()))
+	router.Use(func(c *gin.Context) {
+		c.Set("user", user)
+		c.Next()
+	})
+	router.POST("/like", IdempotencyMiddleware(redisClient, newTestLogger()), handler)
+	return router
+}
+
+func postWithIdempotencyKey(router *gin.Engine, key string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/like", nil)
+	if key != "" {
+		req.Header.Set("Idempotency-Key", key)
+	}
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func TestIdempotencyMiddlewareReplaysRepeatedRequest(t *testing.T) {
+	_, redisClient := newTestRedis(t)
+	calls := 0
+	router := newIdempotencyRouter(redisClient, &models.User{ID: uuid.New()}, func(c *gin.Context) {
+		calls++
+		c.JSON(http.StatusCreated, gin.H{"message": "Post Liked Successfully", "call": calls})
+	})
+
+	first := postWithIdempotencyKey(router, "retry-1")
+	if first.Code != http.StatusCreated || calls != 1 {
+		t.Fatalf("first call got status %d after %d handler calls, want 201 after 1", first.Code, calls)
+	}
+	if first.Header().Get("Idempotent-Replayed") != "" {
+		t.Fatal("first call is marked as replayed")
+	}
+
+	second := postWithIdempotencyKey(router, "retry-1")
+	if calls != 1 {
+		t.Fatalf("handler ran %d times, want 1", calls)
+	}
+	if second.Code != first.Code || second.Body.String() != first.Body.String() {
+		t.Fatalf("replay got %d %q, want %d %q", second.Code, second.Body.String(), first.Code, first.Body.String())
+	}
+	if second.Header().Get("Idempotent-Replayed") != "true" {
+		t.Fatal("replayed response has no Idempotent-Replayed header")
+	}
+	if second.Header().Get("Content-Type") != first.Header().Get("Content-Type") {
+		t.Fatalf("replay content type %q, want %q", second.Header().Get("Content-Type"), first.Header().Get("Content-Type"))
+	}
+
+	postWithIdempotencyKey(router, "retry-2")
+	postWithIdempotencyKey(router, "")
+	if calls != 3 {
+		t.Fatalf("handler ran %d times for a new key and no key, want 3 in total", calls)
+	}
+}
+
+func TestIdempotencyMiddlewareScopesKeysPerUser(t *testing.T) {
+	_, redisClient := newTestRedis(t)
+	calls := 0
+	handler := func(c *gin.Context) {
+		calls++
+		c.JSON(http.StatusCreated, gin.H{})
+	}
+
+	postWithIdempotencyKey(newIdempotencyRouter(redisClient, &models.User{ID: uuid.New()}, handler), "shared")
+	postWithIdempotencyKey(newIdempotencyRouter(redisClient, &models.User{ID: uuid.New()}, handler), "shared")
+	if calls != 2 {
+		t.Fatalf("handler ran %d times for two users with the same key, want 2", calls)
+	}
+}
+
+func TestIdempotencyMiddlewarePendingKeyHasShortTTL(t *testing.T) {
+	redisServer, redisClient := newTestRedis(t)
+	user := &models.User{ID: uuid.New()}
+	var pendingTTL time.Duration
+	router := newIdempotencyRouter(redisClient, user, func(c *gin.Context) {
+		pendingTTL = redisServer.TTL("idem:" + user.ID.String() + ":POST:/like:slow")
+
+		replay := postWithIdempotencyKey(newIdempotencyRouter(redisClient, user, nil), "slow")
+		if replay.Code != http.StatusConflict {
+			t.Errorf("request with a pending key got status %d, want 409", replay.Code)
+		}
+		c.JSON(http.StatusOK, gin.H{})
+	})
+
+	postWithIdempotencyKey(router, "slow")
+	if pendingTTL != idempotencyPendingTTL {
+		t.Fatalf("pending key TTL = %v, want %v", pendingTTL, idempotencyPendingTTL)
+	}
+}
+
+func TestIdempotencyMiddlewareReleasesKeyAfterFailure(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler gin.HandlerFunc
+	}{
+		{
+			name:    "server error",
+			handler: func(c *gin.Context) { c.JSON(http.StatusInternalServerError, gin.H{}) },
+		},
+		{
+			name:    "panic",
+			handler: func(c *gin.Context) { panic("handler failed") },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			redisServer, redisClient := newTestRedis(t)
+			user := &models.User{ID: uuid.New()}
+
+			failed := postWithIdempotencyKey(newIdempotencyRouter(redisClient, user, tt.handler), "retry")
+			if failed.Code != http.StatusInternalServerError {
+				t.Fatalf("failing call got status %d, want 500", failed.Code)
+			}
+			if redisServer.Exists("idem:" + user.ID.String() + ":POST:/like:retry") {
+				t.Fatal("idempotency key was kept after the failure")
+			}
+
+			calls := 0
+			retry := postWithIdempotencyKey(newIdempotencyRouter(redisClient, user, func(c *gin.Context) {
+				calls++
+				c.JSON(http.StatusCreated, gin.H{})
+			}), "retry")
+			if retry.Code != http.StatusCreated || calls != 1 {
+				t.Fatalf("retry got status %d after %d handler calls, want 201 after 1", retry.Code, calls)
+			}
+		})
+	}
+}
@@ -0,0 +1,236 @@
+package middlewares
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/datarohit/gopher-social-backend/helpers"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	IP_FILTER_ALLOW          = helpers.GetEnv("IP_FILTER_ALLOW", "")
+	IP_FILTER_DENY           = helpers.GetEnv("IP_FILTER_DENY", "")
+	IP_FILTER_FILE           = helpers.GetEnv("IP_FILTER_FILE", "")
+	IP_FILTER_RELOAD_SECONDS = helpers.GetEnvAsInt("IP_FILTER_RELOAD_SECONDS", 30)
+)
+
+// ipFilter holds the allow and deny networks of the IP filter.
+// Networks from the environment are fixed, networks from the rules file are replaced
+// whenever the file changes on disk.
+type ipFilter struct {
+	mu          sync.RWMutex
+	envAllow    []*net.IPNet
+	envDeny     []*net.IPNet
+	fileAllow   []*net.IPNet
+	fileDeny    []*net.IPNet
+	filePath    string
+	fileModTime time.Time
+	nextCheck   time.Time
+	interval    time.Duration
+	logger      *logrus.Logger
+}
+
+// IPFilterMiddleware is a middleware that restricts access by client IP address.
+// The IP is the one computed by RealIPMiddleware, so it must be registered after it.
+// Allowed and denied networks are read from IP_FILTER_ALLOW and IP_FILTER_DENY as comma separated
+// CIDRs or bare IP addresses, and from the optional IP_FILTER_FILE rules file with one
+// "allow <cidr>" or "deny <cidr>" rule per line. The file is checked for changes at most every
+// IP_FILTER_RELOAD_SECONDS seconds and reloaded without restarting the server.
+// A denied network always wins. If any allowed networks are configured, only IPs inside them pass.
+// In debug mode loopback addresses are always allowed. Rejected requests get a 403 Forbidden error.
+//
+// Parameters:
+//   - logger (*logrus.Logger): Logger for logging rejected requests and invalid rules.
+//
+// Returns:
+//   - gin.HandlerFunc: Gin middleware handler for IP filtering.
+func IPFilterMiddleware(logger *logrus.Logger) gin.HandlerFunc {
+	filter := &ipFilter{
+		envAllow: parseNetworks(strings.Split(IP_FILTER_ALLOW, ","), logger),
+		envDeny:  parseNetworks(strings.Split(IP_FILTER_DENY, ","), logger),
+		filePath: IP_FILTER_FILE,
+		interval: time.Duration(IP_FILTER_RELOAD_SECONDS) * time.Second,
+		logger:   logger,
+	}
+	filter.reloadIfChanged()
+
+	return func(c *gin.Context) {
+		realIP, exists := c.Get(RealIPKey)
+		if !exists {
+			logger.Warn("Real IP Middleware not Configured Correctly! Falling Back to RemoteAddr for IP Filtering!")
+			realIP, _, _ = net.SplitHostPort(c.Request.RemoteAddr)
+		}
+		ipAddress := realIP.(string)
+
+		filter.reloadIfChanged()
+
+		if !filter.allows(net.ParseIP(ipAddress)) {
+			logger.WithFields(logrus.Fields{"ip": ipAddress, "path": c.Request.URL.Path}).Warn("Request Rejected by IP Filter!")
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"error":   "Forbidden!",
+				"message": "Access From your IP Address is not Allowed!",
+				"code":    helpers.CodeForbidden,
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// allows reports whether a request from ip may pass the filter.
+func (f *ipFilter) allows(ip net.IP) bool {
+	if ip != nil && ip.IsLoopback() && gin.Mode() == gin.DebugMode {
+		return true
+	}
+
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	if ip == nil {
+		return len(f.envAllow) == 0 && len(f.fileAllow) == 0
+	}
+
+	if containsIP(f.envDeny, ip) || containsIP(f.fileDeny, ip) {
+		return false
+	}
+
+	if len(f.envAllow) == 0 && len(f.fileAllow) == 0 {
+		return true
+	}
+
+	return containsIP(f.envAllow, ip) || containsIP(f.fileAllow, ip)
+}
+
+// reloadIfChanged reloads the rules file when its modification time changed.
+// The file is checked at most once per reload interval. If the file cannot be read, the
+// previously loaded rules are kept.
+func (f *ipFilter) reloadIfChanged() {
+	if f.filePath == "" {
+		return
+	}
+
+	now := time.Now()
+
+	f.mu.Lock()
+	if now.Before(f.nextCheck) {
+		f.mu.Unlock()
+		return
+	}
+	f.nextCheck = now.Add(f.interval)
+	lastModTime := f.fileModTime
+	f.mu.Unlock()
+
+	info, err := os.Stat(f.filePath)
+	if err != nil {
+		f.logger.WithFields(logrus.Fields{"error": err, "file": f.filePath}).Error("Failed to Stat IP Filter Rules File!")
+		return
+	}
+	if info.ModTime().Equal(lastModTime) {
+		return
+	}
+
+	allow, deny, err := readRulesFile(f.filePath, f.logger)
+	if err != nil {
+		f.logger.WithFields(logrus.Fields{"error": err, "file": f.filePath}).Error("Failed to Load IP Filter Rules File!")
+		return
+	}
+
+	f.mu.Lock()
+	f.fileAllow = allow
+	f.fileDeny = deny
+	f.fileModTime = info.ModTime()
+	f.mu.Unlock()
+
+	f.logger.WithFields(logrus.Fields{"file": f.filePath, "allow": len(allow), "deny": len(deny)}).Info("IP Filter Rules Loaded Successfully!")
+}
+
+// readRulesFile reads "allow <cidr>" and "deny <cidr>" rules from a file.
+// Empty lines and lines starting with '#' are ignored, invalid rules are logged and skipped.
+func readRulesFile(path string, logger *logrus.Logger) ([]*net.IPNet, []*net.IPNet, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open ip filter rules file: %w", err)
+	}
+	defer file.Close()
+
+	var allow, deny []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			logger.WithFields(logrus.Fields{"rule": line}).Warn("Invalid IP Filter Rule, Skipping!")
+			continue
+		}
+
+		if strings.EqualFold(fields[0], "allow") {
+			allow = append(allow, fields[1])
+		} else if strings.EqualFold(fields[0], "deny") {
+			deny = append(deny, fields[1])
+		} else {
+			logger.WithFields(logrus.Fields{"rule": line}).Warn("Invalid IP Filter Rule, Skipping!")
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to read ip filter rules file: %w", err)
+	}
+
+	return parseNetworks(allow, logger), parseNetworks(deny, logger), nil
+}
+
+// parseNetworks parses CIDRs and bare IP addresses into networks.
+// A bare IP address becomes a single address network. Invalid entries are logged and skipped.
+func parseNetworks(entries []string, logger *logrus.Logger) []*net.IPNet {
+	var networks []*net.IPNet
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		if !strings.Contains(entry, "/") {
+			ip := net.ParseIP(entry)
+			if ip == nil {
+				logger.WithFields(logrus.Fields{"entry": entry}).Warn("Invalid IP Filter Address, Skipping!")
+				continue
+			}
+			if ip.To4() != nil {
+				entry += "/32"
+			} else {
+				entry += "/128"
+			}
+		}
+
+		_, network, err := net.ParseCIDR(entry)
+		if err != nil {
+			logger.WithFields(logrus.Fields{"entry": entry, "error": err}).Warn("Invalid IP Filter CIDR, Skipping!")
+			continue
+		}
+		networks = append(networks, network)
+	}
+	return networks
+}
+
+// containsIP reports whether any of the networks contains ip.
+func containsIP(networks []*net.IPNet, ip net.IP) bool {
+	for _, network := range networks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
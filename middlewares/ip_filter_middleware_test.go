@@ -0,0 +1,157 @@
+package middlewares
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestIPFilterAllows(t *testing.T) {
+	tests := []struct {
+		name  string
+		allow string
+		deny  string
+		ip    string
+		want  bool
+	}{
+		{name: "no rules", ip: "203.0.113.7", want: true},
+		{name: "inside allowed cidr", allow: "10.0.0.0/8", ip: "10.1.2.3", want: true},
+		{name: "outside allowed cidr", allow: "10.0.0.0/8", ip: "192.168.1.1", want: false},
+		{name: "allowed single ip", allow: "192.168.1.1", ip: "192.168.1.1", want: true},
+		{name: "single ip is not a network", allow: "192.168.1.1", ip: "192.168.1.2", want: false},
+		{name: "allowed single ipv6", allow: "2001:db8::1", ip: "2001:db8::1", want: true},
+		{name: "denied cidr", deny: "198.51.100.0/24", ip: "198.51.100.9", want: false},
+		{name: "outside denied cidr", deny: "198.51.100.0/24", ip: "198.51.101.9", want: true},
+		{name: "denied single ip", deny: "203.0.113.7", ip: "203.0.113.7", want: false},
+		{name: "deny wins over allow", allow: "10.0.0.0/8", deny: "10.0.0.5", ip: "10.0.0.5", want: false},
+		{name: "allow next to denied ip", allow: "10.0.0.0/8", deny: "10.0.0.5", ip: "10.0.0.6", want: true},
+		{name: "invalid entries skipped", allow: "not-an-ip, 10.0.0.0/8, 10.0.0.0/99", ip: "10.0.0.1", want: true},
+		{name: "unparsable ip without allow list", ip: "garbage", want: true},
+		{name: "unparsable ip with allow list", allow: "10.0.0.0/8", ip: "garbage", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := newTestLogger()
+			filter := &ipFilter{
+				envAllow: parseNetworks(strings.Split(tt.allow, ","), logger),
+				envDeny:  parseNetworks(strings.Split(tt.deny, ","), logger),
+				logger:   logger,
+			}
+			if got := filter.allows(net.ParseIP(tt.ip)); got != tt.want {
+				t.Fatalf("allows(%s) = %v, want %v", tt.ip, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIPFilterMiddlewareRejectsDeniedIP(t *testing.T) {
+	previousAllow, previousDeny := IP_FILTER_ALLOW, IP_FILTER_DENY
+	IP_FILTER_ALLOW, IP_FILTER_DENY = "10.0.0.0/8", "10.0.0.5"
+	t.Cleanup(func() { IP_FILTER_ALLOW, IP_FILTER_DENY = previousAllow, previousDeny })
+
+	tests := []struct {
+		ip         string
+		wantStatus int
+	}{
+		{ip: "10.0.0.4", wantStatus: http.StatusOK},
+		{ip: "10.0.0.5", wantStatus: http.StatusForbidden},
+		{ip: "172.16.0.1", wantStatus: http.StatusForbidden},
+	}
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set(RealIPKey, c.GetHeader("X-Test-IP"))
+		c.Next()
+	})
+	router.Use(IPFilterMiddleware(newTestLogger()))
+	router.GET("/", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("X-Test-IP", tt.ip)
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+		if recorder.Code != tt.wantStatus {
+			t.Errorf("request from %s got status %d, want %d", tt.ip, recorder.Code, tt.wantStatus)
+		}
+	}
+}
+
+func TestIPFilterReloadsRulesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ip_rules")
+	modTime := time.Now().Add(-time.Hour)
+	writeRules := func(rules string) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(rules), 0o600); err != nil {
+			t.Fatalf("failed to write rules file: %v", err)
+		}
+		modTime = modTime.Add(time.Minute)
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatalf("failed to set rules file time: %v", err)
+		}
+	}
+
+	writeRules("# office network\nallow 10.0.0.0/8\ndeny 10.0.0.5\n")
+	filter := &ipFilter{filePath: path, logger: newTestLogger()}
+	filter.reloadIfChanged()
+
+	if !filter.allows(net.ParseIP("10.1.1.1")) {
+		t.Fatal("IP in allowed file network was rejected")
+	}
+	if filter.allows(net.ParseIP("10.0.0.5")) {
+		t.Fatal("IP denied in file was allowed")
+	}
+	if filter.allows(net.ParseIP("192.168.0.1")) {
+		t.Fatal("IP outside allowed file network was allowed")
+	}
+
+	writeRules("allow 192.168.0.0/16\n")
+	filter.reloadIfChanged()
+
+	if !filter.allows(net.ParseIP("192.168.0.1")) {
+		t.Fatal("IP allowed by the reloaded file was rejected")
+	}
+	if filter.allows(net.ParseIP("10.1.1.1")) {
+		t.Fatal("IP only allowed by the previous file was still allowed")
+	}
+
+	if err := os.Remove(path); err != nil {
+		t.Fatalf("failed to remove rules file: %v", err)
+	}
+	filter.reloadIfChanged()
+
+	if !filter.allows(net.ParseIP("192.168.0.1")) {
+		t.Fatal("rules were dropped after the file became unreadable")
+	}
+}
+
+func TestIPFilterReloadInterval(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ip_rules")
+	if err := os.WriteFile(path, []byte("deny 10.0.0.1\n"), 0o600); err != nil {
+		t.Fatalf("failed to write rules file: %v", err)
+	}
+
+	filter := &ipFilter{filePath: path, interval: time.Hour, logger: newTestLogger()}
+	filter.reloadIfChanged()
+
+	if err := os.WriteFile(path, []byte("deny 10.0.0.2\n"), 0o600); err != nil {
+		t.Fatalf("failed to write rules file: %v", err)
+	}
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatalf("failed to set rules file time: %v", err)
+	}
+	filter.reloadIfChanged()
+
+	if filter.allows(net.ParseIP("10.0.0.1")) || !filter.allows(net.ParseIP("10.0.0.2")) {
+		t.Fatal("rules file was reloaded before the reload interval passed")
+	}
+}
@@ -0,0 +1,64 @@
+package middlewares
+
+import (
+	"context"
+	"time"
+
+	"github.com/datarohit/gopher-social-backend/database"
+	"github.com/datarohit/gopher-social-backend/models"
+	"github.com/datarohit/gopher-social-backend/stores"
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+)
+
+// lastSeenWriteInterval is the shortest time between two last seen updates of the same user.
+const lastSeenWriteInterval = time.Minute
+
+// LastSeenMiddleware is a middleware that records when the logged-in user was last active.
+// It runs after the handler, so it sees the user set by AuthMiddleware or APIKeyMiddleware on any route group,
+// and writes users.last_seen_at at most once per minute per user, throttled with a Redis key.
+// Requests made by an admin with an impersonation token do not count as activity of the impersonated user.
+// Failures are logged and never affect the response.
+//
+// Parameters:
+//   - redisClient (*redis.Client): Redis client used to throttle the updates.
+//   - logger (*logrus.Logger): Logger for logging update failures.
+//
+// Returns:
+//   - gin.HandlerFunc: Gin middleware handler for last seen tracking.
+func LastSeenMiddleware(redisClient *redis.Client, logger *logrus.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		userCtx, exists := c.Get("user")
+		if !exists {
+			return
+		}
+		if _, impersonated := c.Get(ImpersonatedByKey); impersonated {
+			return
+		}
+		user, ok := userCtx.(*models.User)
+		if !ok {
+			return
+		}
+
+		// Use a fresh context, the request context may already be cancelled by a timeout.
+		ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+		defer cancel()
+
+		acquired, err := redisClient.SetNX(ctx, "last_seen:"+user.ID.String(), "1", lastSeenWriteInterval).Result()
+		if err != nil {
+			logger.WithFields(logrus.Fields{"error": err, "userID": user.ID}).Warn("Failed to Throttle Last Seen Update in Redis!")
+			return
+		}
+		if !acquired {
+			return
+		}
+
+		authStore := stores.NewAuthStore(database.PostgresDB)
+		if err := authStore.UpdateLastSeen(ctx, user.ID); err != nil {
+			logger.WithFields(logrus.Fields{"error": err, "userID": user.ID}).Warn("Failed to Update User Last Seen!")
+		}
+	}
+}
@@ -3,15 +3,17 @@ package middlewares
 import (
 	"time"
 
+	"github.com/datarohit/gopher-social-backend/models"
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
 )
 
 // LoggerMiddleware is a middleware that logs HTTP requests using Logrus.
-// It logs the request ID, real IP, path, status code, and duration of the request.
+// It logs the request ID, client IP, method, route template, status code, latency in milliseconds,
+// response body size in bytes, and the ID of the authenticated user when present.
 // The log level is determined based on the HTTP status code:
-//   - Status codes 5xx and 4xx are logged as errors.
-//   - Status codes 3xx are logged as warnings.
+//   - Status codes 5xx are logged as errors.
+//   - Status codes 4xx and 3xx are logged as warnings.
 //   - All other status codes are logged as info.
 //
 // Parameters:
@@ -30,14 +32,32 @@ func LoggerMiddleware(logger *logrus.Logger) gin.HandlerFunc {
 		requestID, _ := c.Get(RequestIDKey)
 		realIP, _ := c.Get(RealIPKey)
 		statusCode := c.Writer.Status()
-		path := c.Request.URL.Path
+
+		// Size is -1 until the handler writes a body, log responses without one as 0 bytes.
+		responseSize := c.Writer.Size()
+		if responseSize < 0 {
+			responseSize = 0
+		}
+
+		path := c.FullPath()
+		if path == "" {
+			path = c.Request.URL.Path
+		}
 
 		logFields := logrus.Fields{
 			"request-id": requestID,
-			"real-ip":    realIP,
+			"method":     c.Request.Method,
 			"path":       path,
 			"status":     statusCode,
-			"duration":   duration,
+			"latency_ms": duration.Milliseconds(),
+			"client_ip":  realIP,
+			"bytes":      responseSize,
+		}
+
+		if user, exists := c.Get("user"); exists {
+			if userModel, ok := user.(*models.User); ok {
+				logFields["user_id"] = userModel.ID
+			}
 		}
 
 		switch {
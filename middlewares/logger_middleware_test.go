@@ -0,0 +1,103 @@
+package middlewares
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/datarohit/gopher-social-backend/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+func TestLoggerMiddlewareFields(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name       string
+		user       *models.User
+		path       string
+		wantStatus int
+		wantLevel  string
+		wantPath   string
+		wantBytes  float64
+	}{
+		{name: "authenticated request", user: &models.User{ID: userID}, path: "/posts/42", wantStatus: http.StatusOK, wantLevel: "info", wantPath: "/posts/:postID", wantBytes: 11},
+		{name: "anonymous client error", path: "/posts/missing", wantStatus: http.StatusNotFound, wantLevel: "warning", wantPath: "/posts/:postID", wantBytes: 0},
+		{name: "server error", path: "/posts/broken", wantStatus: http.StatusInternalServerError, wantLevel: "error", wantPath: "/posts/:postID", wantBytes: 0},
+		{name: "unmatched route", path: "/unknown", wantStatus: http.StatusNotFound, wantLevel: "warning", wantPath: "/unknown", wantBytes: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var output bytes.Buffer
+			logger := logrus.New()
+			logger.SetOutput(&output)
+			logger.SetFormatter(&logrus.JSONFormatter{})
+
+			router := gin.New()
+			router.Use(func(c *gin.Context) {
+				c.Set(RequestIDKey, "request-1")
+				c.Set(RealIPKey, "203.0.113.7")
+				if tt.user != nil {
+					c.Set("user", tt.user)
+				}
+				c.Next()
+			})
+			router.Use(LoggerMiddleware(logger))
+			router.GET("/posts/:postID", func(c *gin.Context) {
+				switch c.Param("postID") {
+				case "missing":
+					c.Status(http.StatusNotFound)
+				case "broken":
+					c.Status(http.StatusInternalServerError)
+				default:
+					c.String(http.StatusOK, "hello world")
+				}
+			})
+
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			if recorder.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", recorder.Code, tt.wantStatus)
+			}
+
+			var entry map[string]any
+			if err := json.Unmarshal(output.Bytes(), &entry); err != nil {
+				t.Fatalf("log output %q is not a single JSON entry: %v", output.String(), err)
+			}
+
+			want := map[string]any{
+				"level":      tt.wantLevel,
+				"request-id": "request-1",
+				"method":     http.MethodGet,
+				"path":       tt.wantPath,
+				"status":     float64(tt.wantStatus),
+				"client_ip":  "203.0.113.7",
+				"bytes":      tt.wantBytes,
+			}
+			for field, value := range want {
+				if entry[field] != value {
+					t.Errorf("field %q = %v, want %v", field, entry[field], value)
+				}
+			}
+			if _, ok := entry["latency_ms"].(float64); !ok {
+				t.Errorf("field \"latency_ms\" = %v, want a number", entry["latency_ms"])
+			}
+
+			userIDField, logged := entry["user_id"]
+			if tt.user == nil {
+				if logged {
+					t.Errorf("field \"user_id\" = %v on an anonymous request, want none", userIDField)
+				}
+				return
+			}
+			if userIDField != userID.String() {
+				t.Errorf("field \"user_id\" = %v, want %s", userIDField, userID)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package middlewares
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/datarohit/gopher-social-backend/helpers"
+	"github.com/datarohit/gopher-social-backend/stores"
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+)
+
+// maintenanceExemptPaths are the path prefixes still accepting writes in read-only maintenance mode:
+// health checks, logging out, and the admin route turning maintenance mode off again.
+var maintenanceExemptPaths = []string{
+	"/api/v1/health/",
+	"/api/v1/auth/logout",
+	"/api/v1/action/maintenance",
+}
+
+// MaintenanceMiddleware is a middleware that blocks writes while the API is in read-only maintenance mode,
+// so operators can run migrations without full downtime. While the maintenance:readonly Redis flag is set,
+// POST, PUT, PATCH and DELETE requests get a 503 Service Unavailable error, except on the health, logout
+// and maintenance routes. GET, HEAD and OPTIONS requests are always served.
+// If the flag cannot be read, the request is let through, so a Redis outage does not block all writes.
+//
+// Parameters:
+//   - redisClient (*redis.Client): Redis client holding the maintenance flag.
+//   - logger (*logrus.Logger): Logger for logging blocked requests and flag read failures.
+//
+// Returns:
+//   - gin.HandlerFunc: Gin middleware handler for read-only maintenance mode.
+func MaintenanceMiddleware(redisClient *redis.Client, logger *logrus.Logger) gin.HandlerFunc {
+	maintenanceStore := stores.NewMaintenanceStore(redisClient)
+
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			c.Next()
+			return
+		}
+
+		for _, prefix := range maintenanceExemptPaths {
+			if strings.HasPrefix(c.Request.URL.Path, prefix) {
+				c.Next()
+				return
+			}
+		}
+
+		readOnly, err := maintenanceStore.IsReadOnly(c)
+		if err != nil {
+			logger.WithFields(logrus.Fields{"error": err, "path": c.Request.URL.Path}).Warn("Failed to Read Maintenance Flag from Redis!")
+			c.Next()
+			return
+		}
+
+		if readOnly {
+			logger.WithFields(logrus.Fields{"path": c.Request.URL.Path, "method": c.Request.Method}).Info("Write Rejected in Read-Only Maintenance Mode")
+			c.Header("Retry-After", "60")
+			c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{
+				"error":   "Service Unavailable!",
+				"message": "The API is in Read-Only Maintenance Mode, Please Try Again Later!",
+				"code":    helpers.CodeServiceUnavailable,
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
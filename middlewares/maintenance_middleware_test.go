@@ -0,0 +1,70 @@
+package middlewares
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/datarohit/gopher-social-backend/helpers"
+	"github.com/datarohit/gopher-social-backend/stores"
+	"github.com/gin-gonic/gin"
+)
+
+func TestMaintenanceMiddleware(t *testing.T) {
+	tests := []struct {
+		name       string
+		readOnly   bool
+		redisDown  bool
+		method     string
+		path       string
+		wantStatus int
+	}{
+		{name: "write while off", method: http.MethodPost, path: "/api/v1/post", wantStatus: http.StatusOK},
+		{name: "post while on", readOnly: true, method: http.MethodPost, path: "/api/v1/post", wantStatus: http.StatusServiceUnavailable},
+		{name: "put while on", readOnly: true, method: http.MethodPut, path: "/api/v1/post/42", wantStatus: http.StatusServiceUnavailable},
+		{name: "patch while on", readOnly: true, method: http.MethodPatch, path: "/api/v1/profile/me", wantStatus: http.StatusServiceUnavailable},
+		{name: "delete while on", readOnly: true, method: http.MethodDelete, path: "/api/v1/post/42", wantStatus: http.StatusServiceUnavailable},
+		{name: "get while on", readOnly: true, method: http.MethodGet, path: "/api/v1/post/42", wantStatus: http.StatusOK},
+		{name: "head while on", readOnly: true, method: http.MethodHead, path: "/api/v1/post/42", wantStatus: http.StatusOK},
+		{name: "health while on", readOnly: true, method: http.MethodPost, path: "/api/v1/health/redis", wantStatus: http.StatusOK},
+		{name: "logout while on", readOnly: true, method: http.MethodPost, path: "/api/v1/auth/logout", wantStatus: http.StatusOK},
+		{name: "maintenance toggle while on", readOnly: true, method: http.MethodDelete, path: "/api/v1/action/maintenance", wantStatus: http.StatusOK},
+		{name: "write while redis fails", readOnly: true, redisDown: true, method: http.MethodPost, path: "/api/v1/post", wantStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, client := newTestRedis(t)
+			if err := stores.NewMaintenanceStore(client).SetReadOnly(context.Background(), tt.readOnly); err != nil {
+				t.Fatalf("SetReadOnly() error = %v", err)
+			}
+			if tt.redisDown {
+				server.SetError("LOADING Redis is loading the dataset in memory")
+			}
+
+			router := gin.New()
+			router.Use(MaintenanceMiddleware(client, newTestLogger()))
+			router.Handle(tt.method, tt.path, func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, httptest.NewRequest(tt.method, tt.path, nil))
+
+			if recorder.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", recorder.Code, tt.wantStatus)
+			}
+			if tt.wantStatus != http.StatusServiceUnavailable {
+				return
+			}
+			if got := recorder.Header().Get("Retry-After"); got != "60" {
+				t.Fatalf("Retry-After = %q, want \"60\"", got)
+			}
+			if !strings.Contains(recorder.Body.String(), `"code":"`+helpers.CodeServiceUnavailable+`"`) {
+				t.Fatalf("body = %s, want code %s", recorder.Body.String(), helpers.CodeServiceUnavailable)
+			}
+		})
+	}
+}
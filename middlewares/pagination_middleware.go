@@ -1,20 +1,27 @@
 package middlewares
 
 import (
+	"fmt"
 	"net/http"
 	"strconv"
 
+	"github.com/datarohit/gopher-social-backend/helpers"
 	"github.com/gin-gonic/gin"
 )
 
 const (
 	PageNumberKey = "page_number"
+	PageSizeKey   = "page_size"
 	PageSize      = 10
 )
 
-// PaginationMiddleware extracts and validates page number from query parameters.
-// It sets the page number in the gin context if valid, otherwise returns a 400 error.
-// The page number must be an integer >= 1.
+// MaxPageSize is the largest page size a client may request.
+var MaxPageSize = helpers.GetEnvAsInt("PAGE_SIZE_MAX", 100)
+
+// PaginationMiddleware extracts and validates page number and page size from query parameters.
+// It sets the page number and page size in the gin context if valid, otherwise returns a 400 error.
+// The page number must be an integer >= 1. The page size is read from the pageSize query parameter,
+// must be an integer between 1 and MaxPageSize, and defaults to PageSize when omitted.
 //
 // Parameters:
 //   - None
@@ -27,12 +34,22 @@ func PaginationMiddleware() gin.HandlerFunc {
 		page, err := strconv.Atoi(pageStr)
 
 		if err != nil || page < 1 {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid page number, page number must be an integer >= 1"})
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid page number, page number must be an integer >= 1", "code": helpers.CodeBadRequest})
+			c.Abort()
+			return
+		}
+
+		pageSizeStr := c.DefaultQuery("pageSize", strconv.Itoa(PageSize))
+		pageSize, err := strconv.Atoi(pageSizeStr)
+
+		if err != nil || pageSize < 1 || pageSize > MaxPageSize {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid page size, page size must be an integer between 1 and %d", MaxPageSize), "code": helpers.CodeBadRequest})
 			c.Abort()
 			return
 		}
 
 		c.Set(PageNumberKey, page)
+		c.Set(PageSizeKey, pageSize)
 		c.Next()
 	}
 }
@@ -0,0 +1,61 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestPaginationMiddleware(t *testing.T) {
+	previousMaxPageSize := MaxPageSize
+	MaxPageSize = 50
+	t.Cleanup(func() { MaxPageSize = previousMaxPageSize })
+
+	tests := []struct {
+		name         string
+		query        string
+		wantStatus   int
+		wantPage     int
+		wantPageSize int
+	}{
+		{name: "defaults", query: "", wantStatus: http.StatusOK, wantPage: 1, wantPageSize: PageSize},
+		{name: "custom page and size", query: "?page=3&pageSize=25", wantStatus: http.StatusOK, wantPage: 3, wantPageSize: 25},
+		{name: "size at max", query: "?pageSize=50", wantStatus: http.StatusOK, wantPage: 1, wantPageSize: 50},
+		{name: "size of one", query: "?pageSize=1", wantStatus: http.StatusOK, wantPage: 1, wantPageSize: 1},
+		{name: "size over max", query: "?pageSize=51", wantStatus: http.StatusBadRequest},
+		{name: "size zero", query: "?pageSize=0", wantStatus: http.StatusBadRequest},
+		{name: "size negative", query: "?pageSize=-5", wantStatus: http.StatusBadRequest},
+		{name: "size non-numeric", query: "?pageSize=ten", wantStatus: http.StatusBadRequest},
+		{name: "page zero", query: "?page=0", wantStatus: http.StatusBadRequest},
+		{name: "page non-numeric", query: "?page=first", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.GET("/items", PaginationMiddleware(), func(c *gin.Context) {
+				c.JSON(http.StatusOK, gin.H{
+					"page":     c.GetInt(PageNumberKey),
+					"pageSize": c.GetInt(PageSizeKey),
+				})
+			})
+
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/items"+tt.query, nil))
+
+			if recorder.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d, body %s", recorder.Code, tt.wantStatus, recorder.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			want := `{"page":` + strconv.Itoa(tt.wantPage) + `,"pageSize":` + strconv.Itoa(tt.wantPageSize) + `}`
+			if recorder.Body.String() != want {
+				t.Fatalf("body = %s, want %s", recorder.Body.String(), want)
+			}
+		})
+	}
+}
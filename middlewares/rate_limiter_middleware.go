@@ -1,10 +1,12 @@
 package middlewares
 
 import (
+	"math"
 	"net/http"
 	"strconv"
 	"time"
 
+	"github.com/datarohit/gopher-social-backend/helpers"
 	"github.com/gin-gonic/gin"
 	"github.com/redis/go-redis/v9"
 	"github.com/sirupsen/logrus"
@@ -12,7 +14,12 @@ import (
 
 // RateLimiterMiddleware is a middleware that limits the number of requests from a single IP address.
 // It uses Redis to store the request counts and enforces a rate limit of 'limit' requests per 'duration'.
-// If the client exceeds the rate limit, the middleware responds with a 429 Too Many Requests error.
+// The window starts with the first request and resets when the Redis counter expires.
+// Every response carries the X-RateLimit-Limit, X-RateLimit-Remaining and X-RateLimit-Reset headers,
+// computed from the counter and its TTL, with the reset given in seconds.
+// If the client exceeds the rate limit, the middleware responds with a 429 Too Many Requests error
+// and a Retry-After header equal to the seconds left until the window resets.
+// Requests authenticated by a valid API key through APIKeyMiddleware are not limited.
 //
 // Parameters:
 //   - redisClient (*redis.Client): Redis client to use for rate limiting.
@@ -24,6 +31,11 @@ import (
 //   - gin.HandlerFunc: Gin middleware handler for rate limiting.
 func RateLimiterMiddleware(redisClient *redis.Client, limit int, duration time.Duration, logger *logrus.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		if c.GetBool(RateLimitBypassKey) {
+			c.Next()
+			return
+		}
+
 		realIP, exists := c.Get(RealIPKey)
 		if !exists {
 			logger.Warn("Real IP Middleware not Configured Correctly! Falling Back to RemoteAddr for Rate Limiting!")
@@ -32,34 +44,45 @@ func RateLimiterMiddleware(redisClient *redis.Client, limit int, duration time.D
 		ipAddress := realIP.(string)
 
 		key := "rl:ip:" + ipAddress
-		now := time.Now()
 
 		pipe := redisClient.Pipeline()
-		pipe.Incr(c.Request.Context(), key)
-		pipe.PExpireAt(c.Request.Context(), key, now.Add(duration))
+		countCmd := pipe.Incr(c.Request.Context(), key)
+		ttlCmd := pipe.PTTL(c.Request.Context(), key)
 
-		results, err := pipe.Exec(c.Request.Context())
-		if err != nil {
+		if _, err := pipe.Exec(c.Request.Context()); err != nil {
 			logger.WithFields(logrus.Fields{"error": err, "ip": ipAddress}).Error("Failed to Increment Request Count in Redis!")
-			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Internal Server Error!"})
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Internal Server Error!", "code": helpers.CodeInternal})
 			return
 		}
 
-		countResult := results[0].(*redis.IntCmd)
-		count, err := countResult.Result()
-		if err != nil {
-			logger.WithFields(logrus.Fields{"error": err, "ip": ipAddress}).Error("Failed to Get Request Count from Redis!")
-			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Internal Server Error!"})
-			return
+		count := countCmd.Val()
+		ttl := ttlCmd.Val()
+		if ttl <= 0 {
+			if err := redisClient.PExpire(c.Request.Context(), key, duration).Err(); err != nil {
+				logger.WithFields(logrus.Fields{"error": err, "ip": ipAddress}).Error("Failed to Set Request Count Expiry in Redis!")
+				c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Internal Server Error!", "code": helpers.CodeInternal})
+				return
+			}
+			ttl = duration
 		}
 
+		resetSeconds := int64(math.Ceil(ttl.Seconds()))
+		remaining := int64(limit) - count
+		if remaining < 0 {
+			remaining = 0
+		}
+
+		c.Header("X-RateLimit-Limit", strconv.Itoa(limit))
+		c.Header("X-RateLimit-Remaining", strconv.FormatInt(remaining, 10))
+		c.Header("X-RateLimit-Reset", strconv.FormatInt(resetSeconds, 10))
+
 		if count > int64(limit) {
-			retryAfter := duration - time.Since(now)
-			c.Header("Retry-After", strconv.Itoa(int(retryAfter.Seconds())))
-			logger.WithFields(logrus.Fields{"ip": ipAddress, "limit": limit, "duration": duration}).Warn("Rate Limit Exceeded!")
+			c.Header("Retry-After", strconv.FormatInt(resetSeconds, 10))
+			logger.WithFields(logrus.Fields{"ip": ipAddress, "limit": limit, "duration": duration, "retry-after": resetSeconds}).Warn("Rate Limit Exceeded!")
 			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
 				"error":   "Too Many Requests!",
-				"message": "Rate Limit Exceeded! Please Try Again after a Minute!",
+				"message": "Rate Limit Exceeded! Please Try Again after " + strconv.FormatInt(resetSeconds, 10) + " Seconds!",
+				"code":    helpers.CodeRateLimited,
 			})
 			return
 		}
@@ -0,0 +1,135 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// newRateLimitedRouter returns a router limiting GET /resource to limit requests per window.
+func newRateLimitedRouter(t *testing.T, limit int, window time.Duration) (*gin.Engine, func(time.Duration)) {
+	t.Helper()
+
+	server, client := newTestRedis(t)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set(RealIPKey, "203.0.113.7")
+		c.Next()
+	})
+	router.Use(RateLimiterMiddleware(client, limit, window, newTestLogger()))
+	router.GET("/resource", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	return router, server.FastForward
+}
+
+func TestRateLimiterHeaders(t *testing.T) {
+	router, _ := newRateLimitedRouter(t, 2, time.Minute)
+
+	tests := []struct {
+		name          string
+		wantStatus    int
+		wantRemaining string
+		wantRetry     bool
+	}{
+		{name: "first request", wantStatus: http.StatusOK, wantRemaining: "1"},
+		{name: "last allowed request", wantStatus: http.StatusOK, wantRemaining: "0"},
+		{name: "throttled request", wantStatus: http.StatusTooManyRequests, wantRemaining: "0", wantRetry: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/resource", nil))
+
+			if recorder.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", recorder.Code, tt.wantStatus)
+			}
+			if got := recorder.Header().Get("X-RateLimit-Limit"); got != "2" {
+				t.Fatalf("X-RateLimit-Limit = %q, want \"2\"", got)
+			}
+			if got := recorder.Header().Get("X-RateLimit-Remaining"); got != tt.wantRemaining {
+				t.Fatalf("X-RateLimit-Remaining = %q, want %q", got, tt.wantRemaining)
+			}
+
+			reset, err := strconv.Atoi(recorder.Header().Get("X-RateLimit-Reset"))
+			if err != nil || reset < 1 || reset > 60 {
+				t.Fatalf("X-RateLimit-Reset = %q, want seconds between 1 and 60", recorder.Header().Get("X-RateLimit-Reset"))
+			}
+
+			retryAfter := recorder.Header().Get("Retry-After")
+			if !tt.wantRetry {
+				if retryAfter != "" {
+					t.Fatalf("Retry-After = %q on an allowed response, want none", retryAfter)
+				}
+				return
+			}
+			if retryAfter != strconv.Itoa(reset) {
+				t.Fatalf("Retry-After = %q, want %d to match X-RateLimit-Reset", retryAfter, reset)
+			}
+		})
+	}
+}
+
+func TestRateLimiterWindowReset(t *testing.T) {
+	router, fastForward := newRateLimitedRouter(t, 1, time.Minute)
+
+	send := func() *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/resource", nil))
+		return recorder
+	}
+
+	send()
+	fastForward(45 * time.Second)
+
+	throttled := send()
+	if throttled.Code != http.StatusTooManyRequests {
+		t.Fatalf("status = %d, want %d", throttled.Code, http.StatusTooManyRequests)
+	}
+	if got := throttled.Header().Get("Retry-After"); got != "15" {
+		t.Fatalf("Retry-After = %q, want \"15\"", got)
+	}
+
+	fastForward(15 * time.Second)
+
+	allowed := send()
+	if allowed.Code != http.StatusOK {
+		t.Fatalf("status after the window = %d, want %d", allowed.Code, http.StatusOK)
+	}
+	if got := allowed.Header().Get("X-RateLimit-Remaining"); got != "0" {
+		t.Fatalf("X-RateLimit-Remaining = %q, want \"0\"", got)
+	}
+	if got := allowed.Header().Get("X-RateLimit-Reset"); got != "60" {
+		t.Fatalf("X-RateLimit-Reset = %q, want \"60\"", got)
+	}
+}
+
+func TestRateLimiterBypass(t *testing.T) {
+	_, client := newTestRedis(t)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set(RealIPKey, "203.0.113.7")
+		c.Set(RateLimitBypassKey, true)
+		c.Next()
+	})
+	router.Use(RateLimiterMiddleware(client, 1, time.Minute, newTestLogger()))
+	router.GET("/resource", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	for i := 0; i < 3; i++ {
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/resource", nil))
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("request %d status = %d, want %d", i+1, recorder.Code, http.StatusOK)
+		}
+		if got := recorder.Header().Get("X-RateLimit-Limit"); got != "" {
+			t.Fatalf("bypassed request got X-RateLimit-Limit %q, want none", got)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package middlewares
 import (
 	"net/http"
 
+	"github.com/datarohit/gopher-social-backend/helpers"
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
 )
@@ -28,6 +29,7 @@ func RecovererMiddleware(logger *logrus.Logger) gin.HandlerFunc {
 
 				c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
 					"error": "Internal Server Error!",
+					"code":  helpers.CodeInternal,
 				})
 			}
 		}()
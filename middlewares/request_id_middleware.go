@@ -1,25 +1,88 @@
 package middlewares
 
 import (
+	"net/http"
+	"strconv"
+	"strings"
+
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 )
 
 const RequestIDKey = "requestID"
 
+// maxRequestIDLength is the longest X-Request-ID accepted from a client.
+const maxRequestIDLength = 64
+
+// clientRequestID returns the X-Request-ID sent by the client, or an empty string if it is missing or not made of
+// letters, digits, dashes, underscores and dots, so it can be logged and echoed safely.
+func clientRequestID(c *gin.Context) string {
+	requestID := c.GetHeader("X-Request-ID")
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		return ""
+	}
+	for _, r := range requestID {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_' || r == '.') {
+			return ""
+		}
+	}
+	return requestID
+}
+
+// requestIDResponseWriter adds the request ID to JSON error responses, so clients can quote it to support.
+type requestIDResponseWriter struct {
+	gin.ResponseWriter
+	requestID string
+	written   bool
+}
+
+func (w *requestIDResponseWriter) Write(data []byte) (int, error) {
+	if w.written || w.Status() < http.StatusBadRequest || len(data) < 2 || data[0] != '{' ||
+		!strings.HasPrefix(w.Header().Get("Content-Type"), "application/json") {
+		w.written = true
+		return w.ResponseWriter.Write(data)
+	}
+	w.written = true
+
+	field := `"request_id":` + strconv.Quote(w.requestID)
+	if data[1] != '}' {
+		field += ","
+	}
+
+	body := make([]byte, 0, len(data)+len(field))
+	body = append(body, '{')
+	body = append(body, field...)
+	body = append(body, data[1:]...)
+	if _, err := w.ResponseWriter.Write(body); err != nil {
+		return 0, err
+	}
+	return len(data), nil
+}
+
+func (w *requestIDResponseWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
 // RequestIDMiddleware is a middleware that sets a unique request ID for each request.
 // It also sets the request ID in the response header.
 // The request ID is stored in the context and can be accessed using the RequestIDKey.
-// The request ID is generated using the UUID v4 algorithm.
+// A valid X-Request-ID sent by the client is kept, so a request can be traced across services,
+// otherwise the request ID is generated using the UUID v4 algorithm.
 // The request ID is also set in the response header with the key "X-Request-ID".
+// JSON error responses, with a status of 400 or above, carry the request ID in their "request_id" field as well,
+// so every error path reports it without the handlers setting it.
 //
 // Returns:
 //   - gin.HandlerFunc: A middleware function that sets a unique request ID for each request.
 func RequestIDMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		requestID := uuid.New().String()
+		requestID := clientRequestID(c)
+		if requestID == "" {
+			requestID = uuid.New().String()
+		}
 		c.Set(RequestIDKey, requestID)
 		c.Writer.Header().Set("X-Request-ID", requestID)
+		c.Writer = &requestIDResponseWriter{ResponseWriter: c.Writer, requestID: requestID}
 		c.Next()
 	}
 }
@@ -0,0 +1,112 @@
+package middlewares
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// newRequestIDRouter returns a router using RequestIDMiddleware with routes writing JSON errors, plain text
+// and streamed bodies.
+func newRequestIDRouter() *gin.Engine {
+	router := gin.New()
+	router.Use(RequestIDMiddleware())
+	router.GET("/error", func(c *gin.Context) {
+		c.JSON(http.StatusNotFound, gin.H{"message": "Not Found", "code": "NOT_FOUND"})
+	})
+	router.GET("/empty-error", func(c *gin.Context) {
+		c.JSON(http.StatusBadRequest, gin.H{})
+	})
+	router.GET("/ok", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"message": "ok"})
+	})
+	router.GET("/text", func(c *gin.Context) {
+		c.String(http.StatusInternalServerError, "{not json")
+	})
+	router.GET("/stream", func(c *gin.Context) {
+		c.Header("Content-Type", "application/json")
+		c.Status(http.StatusServiceUnavailable)
+		_, _ = c.Writer.WriteString(`{"message":`)
+		_, _ = c.Writer.WriteString(`"Unavailable"}`)
+	})
+	return router
+}
+
+func TestRequestIDInJSONErrors(t *testing.T) {
+	router := newRequestIDRouter()
+
+	tests := []struct {
+		name      string
+		path      string
+		requestID string
+		wantID    string
+	}{
+		{name: "generated", path: "/error"},
+		{name: "supplied by the client", path: "/error", requestID: "client-request.id_1", wantID: "client-request.id_1"},
+		{name: "invalid client value replaced", path: "/error", requestID: "bad id\n"},
+		{name: "too long client value replaced", path: "/error", requestID: strings.Repeat("a", maxRequestIDLength+1)},
+		{name: "empty object", path: "/empty-error", requestID: "abc", wantID: "abc"},
+		{name: "streamed", path: "/stream", requestID: "abc", wantID: "abc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.requestID != "" {
+				req.Header.Set("X-Request-ID", tt.requestID)
+			}
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, req)
+
+			header := recorder.Header().Get("X-Request-ID")
+			if tt.wantID != "" && header != tt.wantID {
+				t.Fatalf("X-Request-ID = %q, want %q", header, tt.wantID)
+			}
+			if tt.wantID == "" {
+				if _, err := uuid.Parse(header); err != nil {
+					t.Fatalf("X-Request-ID = %q, want a generated UUID", header)
+				}
+			}
+
+			var body map[string]any
+			if err := json.Unmarshal(recorder.Body.Bytes(), &body); err != nil {
+				t.Fatalf("body %q is not valid JSON: %v", recorder.Body.String(), err)
+			}
+			if body["request_id"] != header {
+				t.Fatalf("body request_id = %v, want the X-Request-ID header %q", body["request_id"], header)
+			}
+		})
+	}
+}
+
+func TestRequestIDLeavesOtherBodiesIntact(t *testing.T) {
+	router := newRequestIDRouter()
+
+	tests := []struct {
+		name     string
+		path     string
+		wantBody string
+	}{
+		{name: "success", path: "/ok", wantBody: `{"message":"ok"}`},
+		{name: "plain text error", path: "/text", wantBody: "{not json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			if recorder.Body.String() != tt.wantBody {
+				t.Fatalf("body = %q, want %q", recorder.Body.String(), tt.wantBody)
+			}
+			if recorder.Header().Get("X-Request-ID") == "" {
+				t.Fatal("X-Request-ID header missing")
+			}
+		})
+	}
+}
@@ -1,41 +1,199 @@
 package middlewares
 
 import (
+	"bytes"
 	"context"
+	"net/http"
+	"sync"
 	"time"
 
+	"github.com/datarohit/gopher-social-backend/helpers"
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/render"
 )
 
+// timeoutResponseWriter buffers the response of a handler running behind TimeoutMiddleware. The response is copied
+// to the client only if the handler finishes in time, writes made after the timeout are dropped.
+type timeoutResponseWriter struct {
+	gin.ResponseWriter
+	header http.Header
+
+	mu       sync.Mutex
+	body     bytes.Buffer
+	status   int
+	written  bool
+	timedOut bool
+}
+
+func newTimeoutResponseWriter(w gin.ResponseWriter) *timeoutResponseWriter {
+	return &timeoutResponseWriter{ResponseWriter: w, header: w.Header().Clone(), status: http.StatusOK}
+}
+
+func (w *timeoutResponseWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *timeoutResponseWriter) WriteHeader(code int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.timedOut || w.written {
+		return
+	}
+	w.status = code
+}
+
+func (w *timeoutResponseWriter) WriteHeaderNow() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.written = true
+}
+
+func (w *timeoutResponseWriter) Write(data []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.timedOut {
+		return 0, http.ErrHandlerTimeout
+	}
+	w.written = true
+	return w.body.Write(data)
+}
+
+func (w *timeoutResponseWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+func (w *timeoutResponseWriter) Status() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.status
+}
+
+func (w *timeoutResponseWriter) Size() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if !w.written {
+		return -1
+	}
+	return w.body.Len()
+}
+
+func (w *timeoutResponseWriter) Written() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.written
+}
+
+// Flush does nothing, the response is sent when the handler finishes.
+func (w *timeoutResponseWriter) Flush() {}
+
+// timeout marks the response as timed out, so later writes of the handler are dropped.
+func (w *timeoutResponseWriter) timeout() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.timedOut = true
+}
+
+// flushTo copies the buffered headers, status and body to dst. It must only be called once the handler finished.
+func (w *timeoutResponseWriter) flushTo(dst gin.ResponseWriter) {
+	for key := range dst.Header() {
+		if _, ok := w.header[key]; !ok {
+			dst.Header().Del(key)
+		}
+	}
+	for key, values := range w.header {
+		dst.Header()[key] = values
+	}
+	dst.WriteHeader(w.status)
+	if w.written {
+		dst.WriteHeaderNow()
+	}
+	if w.body.Len() > 0 {
+		_, _ = dst.Write(w.body.Bytes())
+	}
+}
+
 // TimeoutMiddleware is a middleware that sets a timeout for each request.
-// If a handler takes longer than the specified timeout, the request will be aborted
+// The timeout is looked up in routeTimeouts by the matched route template, such as
+// "/api/v1/post/:postID", and falls back to defaultTimeout for routes without an override.
+// The deadline is set on the request context, so stores called with it are cancelled as well.
+// If a handler takes longer than the timeout, the request will be aborted
 // and a 408 Request Timeout error will be returned to the client.
+// The handler writes to a buffer that is copied to the client only if it finishes in time, and the middleware
+// waits for the handler to return before the request ends, so the two never share the gin context.
+// Panics of the handler are raised again on the request goroutine, so RecovererMiddleware still handles them.
 //
 // Parameters:
-//   - timeout time.Duration: The duration after which the request should timeout.
+//   - defaultTimeout time.Duration: The duration after which a request should timeout.
+//   - routeTimeouts map[string]time.Duration: Per route timeouts keyed by route template, may be nil.
 //
 // Returns:
 //   - gin.HandlerFunc: A middleware function that enforces request timeouts.
-func TimeoutMiddleware(timeout time.Duration) gin.HandlerFunc {
+func TimeoutMiddleware(defaultTimeout time.Duration, routeTimeouts map[string]time.Duration) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		timeout := defaultTimeout
+		if routeTimeout, ok := routeTimeouts[c.FullPath()]; ok {
+			timeout = routeTimeout
+		}
+
 		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
 		defer cancel()
 
 		c.Request = c.Request.WithContext(ctx)
 
+		writer := c.Writer
+		buffered := newTimeoutResponseWriter(writer)
+		c.Writer = buffered
+
 		finished := make(chan struct{})
+		panicked := make(chan any, 1)
 		go func() {
+			defer close(finished)
+			defer func() {
+				if err := recover(); err != nil {
+					panicked <- err
+				}
+			}()
 			c.Next()
-			finished <- struct{}{}
 		}()
 
+		timedOut := false
 		select {
 		case <-finished:
 		case <-ctx.Done():
-			c.AbortWithStatusJSON(408, gin.H{
+			timedOut = true
+			buffered.timeout()
+
+			writer.WriteHeader(http.StatusRequestTimeout)
+			_ = render.JSON{Data: gin.H{
 				"error": "Request Timeout!",
-			})
+				"code":  helpers.CodeRequestTimeout,
+			}}.Render(writer)
+			writer.Flush()
+
+			// The handler still owns the gin context, it is only released once the handler returned.
+			<-finished
+		}
+
+		c.Writer = writer
+		select {
+		case err := <-panicked:
+			// Re-panic on the request goroutine, a panic in the handler goroutine would crash the server
+			// before RecovererMiddleware could turn it into a 500 Internal Server Error.
+			panic(err)
+		default:
+		}
+
+		if timedOut {
+			c.Abort()
 			return
 		}
+		buffered.flushTo(writer)
 	}
 }
@@ -0,0 +1,130 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestTimeoutMiddlewareRouteOverride(t *testing.T) {
+	const defaultTimeout = 10 * time.Second
+	routeTimeouts := map[string]time.Duration{
+		"/exports/:exportID": time.Minute,
+		"/slow":              20 * time.Millisecond,
+	}
+
+	router := gin.New()
+	router.Use(TimeoutMiddleware(defaultTimeout, routeTimeouts))
+
+	var deadlineIn time.Duration
+	reportDeadline := func(c *gin.Context) {
+		deadline, ok := c.Request.Context().Deadline()
+		if !ok {
+			t.Error("request context has no deadline")
+		}
+		deadlineIn = time.Until(deadline)
+		c.Status(http.StatusOK)
+	}
+	router.GET("/exports/:exportID", reportDeadline)
+	router.GET("/posts/:postID", reportDeadline)
+	router.GET("/slow", func(c *gin.Context) {
+		<-c.Request.Context().Done()
+	})
+
+	tests := []struct {
+		name         string
+		path         string
+		wantStatus   int
+		wantDeadline time.Duration
+	}{
+		{name: "route with override", path: "/exports/42", wantStatus: http.StatusOK, wantDeadline: time.Minute},
+		{name: "route without override", path: "/posts/42", wantStatus: http.StatusOK, wantDeadline: defaultTimeout},
+		{name: "route exceeding its override", path: "/slow", wantStatus: http.StatusRequestTimeout},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deadlineIn = 0
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			if recorder.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", recorder.Code, tt.wantStatus)
+			}
+			if tt.wantDeadline == 0 {
+				return
+			}
+			if deadlineIn > tt.wantDeadline || deadlineIn < tt.wantDeadline-time.Second {
+				t.Fatalf("deadline in %s, want about %s", deadlineIn, tt.wantDeadline)
+			}
+		})
+	}
+}
+
+func TestTimeoutMiddlewareRepanics(t *testing.T) {
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		defer func() {
+			if recover() != nil {
+				c.AbortWithStatus(http.StatusInternalServerError)
+			}
+		}()
+		c.Next()
+	})
+	router.Use(TimeoutMiddleware(time.Second, nil))
+	router.GET("/panic", func(c *gin.Context) {
+		panic("boom")
+	})
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/panic", nil))
+
+	if recorder.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want %d", recorder.Code, http.StatusInternalServerError)
+	}
+}
+
+func TestTimeoutMiddlewareResponses(t *testing.T) {
+	router := gin.New()
+	router.Use(TimeoutMiddleware(20*time.Millisecond, nil))
+	router.POST("/fast", func(c *gin.Context) {
+		c.Header("Location", "/fast/42")
+		c.JSON(http.StatusCreated, gin.H{"id": 42})
+	})
+	router.POST("/late", func(c *gin.Context) {
+		<-c.Request.Context().Done()
+		c.Header("Location", "/late/42")
+		c.JSON(http.StatusCreated, gin.H{"id": 42})
+	})
+
+	tests := []struct {
+		name         string
+		path         string
+		wantStatus   int
+		wantBody     string
+		wantLocation string
+	}{
+		{name: "handler finishing in time", path: "/fast", wantStatus: http.StatusCreated, wantBody: `{"id":42}`, wantLocation: "/fast/42"},
+		{name: "handler writing after the timeout", path: "/late", wantStatus: http.StatusRequestTimeout, wantBody: `{"code":"REQUEST_TIMEOUT","error":"Request Timeout!"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, tt.path, nil))
+
+			if recorder.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", recorder.Code, tt.wantStatus)
+			}
+			if body := recorder.Body.String(); body != tt.wantBody {
+				t.Fatalf("body = %s, want %s", body, tt.wantBody)
+			}
+			if location := recorder.Header().Get("Location"); location != tt.wantLocation {
+				t.Fatalf("Location = %q, want %q", location, tt.wantLocation)
+			}
+		})
+	}
+}
@@ -1,10 +1,32 @@
 package models
 
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
 type TimeoutDuration string
 
+type ModerationLog struct {
+	ID           uuid.UUID           `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	TargetUserID uuid.UUID           `json:"target_user_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Action       string              `json:"action" example:"timeout"`
+	Details      string              `json:"details" example:"timed out for 1h0m0s"`
+	Actor        *ModerationLogActor `json:"actor"`
+	CreatedAt    time.Time           `json:"created_at" example:"2025-01-25T12:34:01.159498Z"`
+	UndoneAt     *time.Time          `json:"undone_at,omitempty" example:"2025-01-25T12:40:12.512074Z"`
+}
+
+type ModerationLogActor struct {
+	ID        uuid.UUID `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Username  string    `json:"username" example:"jane_mod"`
+	RoleLevel int       `json:"role_level" example:"2"`
+}
+
 // Timeout User Models
 type TimeoutUserPayload struct {
-	TimeoutDuration TimeoutDuration `json:"timeout_duration" binding:"required,oneof=30m 1h 6h 12h 1d" example:"1h"`
+	TimeoutDuration TimeoutDuration `json:"timeout_duration" binding:"required" example:"1h"`
 }
 
 type TimeoutUserSuccessResponse struct {
@@ -14,6 +36,7 @@ type TimeoutUserSuccessResponse struct {
 type TimeoutUserErrorResponse struct {
 	Message string `json:"message"`
 	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"BAD_REQUEST"`
 }
 
 // Remove Timeout User Models
@@ -24,6 +47,24 @@ type RemoveTimeoutUserSuccessResponse struct {
 type RemoveTimeoutUserErrorResponse struct {
 	Message string `json:"message"`
 	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"BAD_REQUEST"`
+}
+
+// List Timeout Options Models
+type TimeoutOption struct {
+	Value   string `json:"value" example:"1h"`
+	Seconds int64  `json:"seconds" example:"3600"`
+}
+
+type ListTimeoutOptionsSuccessResponse struct {
+	Message string          `json:"message" example:"Timeout Options Retrieved Successfully"`
+	Options []TimeoutOption `json:"options"`
+}
+
+type ListTimeoutOptionsErrorResponse struct {
+	Message string `json:"message"`
+	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"BAD_REQUEST"`
 }
 
 // List Timed Out Users Models
@@ -35,6 +76,7 @@ type ListTimedOutUsersSuccessResponse struct {
 type ListTimedOutUsersErrorResponse struct {
 	Message string `json:"message"`
 	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"BAD_REQUEST"`
 }
 
 // Deactivate User Models
@@ -45,6 +87,7 @@ type DeactivateUserSuccessResponse struct {
 type DeactivateUserErrorResponse struct {
 	Message string `json:"message"`
 	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"BAD_REQUEST"`
 }
 
 // Ban User Models
@@ -55,6 +98,7 @@ type BanUserSuccessResponse struct {
 type BanUserErrorResponse struct {
 	Message string `json:"message"`
 	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"BAD_REQUEST"`
 }
 
 // Unban User Models
@@ -65,4 +109,135 @@ type UnbanUserSuccessResponse struct {
 type UnbanUserErrorResponse struct {
 	Message string `json:"message"`
 	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"BAD_REQUEST"`
+}
+
+// List All Posts Models
+type ListAllPostsSuccessResponse struct {
+	Message string  `json:"message" example:"Posts Retrieved Successfully"`
+	Posts   []*Post `json:"posts"`
+}
+
+type ListAllPostsErrorResponse struct {
+	Message string `json:"message"`
+	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"BAD_REQUEST"`
+}
+
+// Update User Role Models
+type UpdateUserRolePayload struct {
+	Level int `json:"level" binding:"required,min=1,max=3" example:"2"`
+}
+
+type UpdateUserRoleSuccessResponse struct {
+	Message string `json:"message" example:"User Role Updated Successfully"`
+}
+
+type UpdateUserRoleErrorResponse struct {
+	Message string `json:"message"`
+	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"BAD_REQUEST"`
+}
+
+// Force Logout User Models
+type ForceLogoutUserSuccessResponse struct {
+	Message         string `json:"message" example:"User Logged Out Everywhere Successfully"`
+	RevokedSessions int    `json:"revoked_sessions" example:"3"`
+}
+
+type ForceLogoutUserErrorResponse struct {
+	Message string `json:"message"`
+	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"BAD_REQUEST"`
+}
+
+// Impersonate User Models
+type ImpersonateUserSuccessResponse struct {
+	Message     string    `json:"message" example:"Impersonation Token Issued Successfully"`
+	AccessToken string    `json:"access_token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	ExpiresAt   time.Time `json:"expires_at" example:"2025-01-25T12:44:01Z"`
+	ReadOnly    bool      `json:"read_only" example:"true"`
+}
+
+type ImpersonateUserErrorResponse struct {
+	Message string `json:"message"`
+	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"BAD_REQUEST"`
+}
+
+// List User Moderation History Models
+type ListUserModerationHistorySuccessResponse struct {
+	Message string           `json:"message" example:"Moderation History Retrieved Successfully"`
+	History []*ModerationLog `json:"history"`
+}
+
+type ListUserModerationHistoryErrorResponse struct {
+	Message string `json:"message"`
+	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"BAD_REQUEST"`
+}
+
+// Transfer Post Author Models
+type TransferPostAuthorPayload struct {
+	AuthorID uuid.UUID `json:"author_id" binding:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+}
+
+type TransferPostAuthorSuccessResponse struct {
+	Message string `json:"message" example:"Post Author Transferred Successfully"`
+}
+
+type TransferPostAuthorErrorResponse struct {
+	Message string `json:"message"`
+	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"BAD_REQUEST"`
+}
+
+// Undo Moderation Action Models
+type UndoModerationActionSuccessResponse struct {
+	Message string `json:"message" example:"Moderation Action Undone Successfully"`
+}
+
+type UndoModerationActionErrorResponse struct {
+	Message string `json:"message"`
+	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"BAD_REQUEST"`
+}
+
+// Get Platform Stats Models
+type PlatformStats struct {
+	TotalUsers      int64     `json:"total_users" example:"1520"`
+	ActiveUsers     int64     `json:"active_users" example:"1384"`
+	BannedUsers     int64     `json:"banned_users" example:"12"`
+	TotalPosts      int64     `json:"total_posts" example:"20431"`
+	TotalComments   int64     `json:"total_comments" example:"85210"`
+	PostsLast24h    int64     `json:"posts_last_24h" example:"312"`
+	NewUsersLast24h int64     `json:"new_users_last_24h" example:"27"`
+	ComputedAt      time.Time `json:"computed_at" example:"2025-01-25T12:34:01.159498Z"`
+}
+
+type GetPlatformStatsSuccessResponse struct {
+	Message string         `json:"message" example:"Platform Stats Retrieved Successfully"`
+	Stats   *PlatformStats `json:"stats"`
+}
+
+type GetPlatformStatsErrorResponse struct {
+	Message string `json:"message"`
+	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"BAD_REQUEST"`
+}
+
+// Set Maintenance Mode Models
+type SetMaintenanceModePayload struct {
+	ReadOnly *bool `json:"read_only" binding:"required" example:"true"`
+}
+
+type SetMaintenanceModeSuccessResponse struct {
+	Message  string `json:"message" example:"Maintenance Mode Updated Successfully"`
+	ReadOnly bool   `json:"read_only" example:"true"`
+}
+
+type SetMaintenanceModeErrorResponse struct {
+	Message string `json:"message"`
+	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"BAD_REQUEST"`
 }
@@ -0,0 +1,59 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type APIKey struct {
+	ID         uuid.UUID  `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	UserID     uuid.UUID  `json:"user_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Name       string     `json:"name" example:"admin-dashboard"`
+	Scopes     []string   `json:"scopes" example:"read,write"`
+	Role       *Role      `json:"role,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty" example:"2025-01-25T12:34:01.159498Z"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty" example:"2025-01-25T12:34:01.159498Z"`
+	CreatedAt  time.Time  `json:"created_at" example:"2025-01-25T12:34:01.159498Z"`
+}
+
+// Create API Key Models
+type CreateAPIKeyPayload struct {
+	Name   string   `json:"name" binding:"required,notblank,max=64" example:"deploy-bot"`
+	Scopes []string `json:"scopes,omitempty" binding:"omitempty,dive,oneof=read write" example:"read"`
+}
+
+type CreateAPIKeySuccessResponse struct {
+	Message string  `json:"message" example:"API Key Created Successfully"`
+	APIKey  *APIKey `json:"api_key"`
+	Key     string  `json:"key" example:"gsb_9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"`
+}
+
+type CreateAPIKeyErrorResponse struct {
+	Message string `json:"message"`
+	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"API_KEY_LIMIT_REACHED"`
+}
+
+// List API Keys Models
+type ListAPIKeysSuccessResponse struct {
+	Message string    `json:"message" example:"API Keys Retrieved Successfully"`
+	APIKeys []*APIKey `json:"api_keys"`
+}
+
+type ListAPIKeysErrorResponse struct {
+	Message string `json:"message"`
+	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"UNAUTHORIZED"`
+}
+
+// Revoke API Key Models
+type RevokeAPIKeySuccessResponse struct {
+	Message string `json:"message" example:"API Key Revoked Successfully"`
+}
+
+type RevokeAPIKeyErrorResponse struct {
+	Message string `json:"message"`
+	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"API_KEY_NOT_FOUND"`
+}
@@ -0,0 +1,54 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Bookmark struct {
+	UserID    uuid.UUID `json:"user_id"`
+	PostID    uuid.UUID `json:"post_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type BookmarkedPost struct {
+	BookmarkedAt time.Time `json:"bookmarked_at" example:"2025-01-25T12:34:01.159498Z"`
+	Post         *Post     `json:"post"`
+}
+
+// Add Bookmark Models
+type AddBookmarkSuccessResponse struct {
+	Message  string    `json:"message" example:"Post Bookmarked Successfully"`
+	Bookmark *Bookmark `json:"bookmark"`
+}
+
+type AddBookmarkErrorResponse struct {
+	Message string `json:"message"`
+	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"BAD_REQUEST"`
+}
+
+// Remove Bookmark Models
+type RemoveBookmarkSuccessResponse struct {
+	Message string `json:"message" example:"Bookmark Removed Successfully"`
+}
+
+type RemoveBookmarkErrorResponse struct {
+	Message string `json:"message"`
+	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"BAD_REQUEST"`
+}
+
+// List Bookmarks Models
+type ListBookmarksSuccessResponse struct {
+	Message    string            `json:"message" example:"Bookmarks Retrieved Successfully"`
+	Bookmarks  []*BookmarkedPost `json:"bookmarks"`
+	Pagination *Pagination       `json:"pagination"`
+}
+
+type ListBookmarksErrorResponse struct {
+	Message string `json:"message"`
+	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"UNAUTHORIZED"`
+}
@@ -21,6 +21,7 @@ type LikeCommentSuccessResponse struct {
 type LikeCommentErrorResponse struct {
 	Message string `json:"message"`
 	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"BAD_REQUEST"`
 }
 
 // Unlike Comment Models
@@ -31,6 +32,7 @@ type UnlikeCommentSuccessResponse struct {
 type UnlikeCommentErrorResponse struct {
 	Message string `json:"message"`
 	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"BAD_REQUEST"`
 }
 
 // Dislike Comment Models
@@ -41,6 +43,7 @@ type DislikeCommentSuccessResponse struct {
 type DislikeCommentErrorResponse struct {
 	Message string `json:"message"`
 	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"BAD_REQUEST"`
 }
 
 // Undislike Comment Models
@@ -51,26 +54,57 @@ type UndislikeCommentSuccessResponse struct {
 type UndislikeCommentErrorResponse struct {
 	Message string `json:"message"`
 	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"BAD_REQUEST"`
 }
 
 // List Liked Comments Under Post Models
 type ListLikedCommentsUnderPostSuccessResponse struct {
-	Message  string     `json:"message" example:"Liked Comments Retrieved Successfully"`
-	Comments []*Comment `json:"comments"`
+	Message    string      `json:"message" example:"Liked Comments Retrieved Successfully"`
+	Comments   []*Comment  `json:"comments"`
+	Pagination *Pagination `json:"pagination"`
 }
 
 type ListLikedCommentsUnderPostErrorResponse struct {
 	Message string `json:"message"`
 	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"BAD_REQUEST"`
 }
 
 // List Disliked Comments Under Post Models
 type ListDislikedCommentsUnderPostSuccessResponse struct {
-	Message  string     `json:"message" example:"Disliked Comments Retrieved Successfully"`
-	Comments []*Comment `json:"comments"`
+	Message    string      `json:"message" example:"Disliked Comments Retrieved Successfully"`
+	Comments   []*Comment  `json:"comments"`
+	Pagination *Pagination `json:"pagination"`
 }
 
 type ListDislikedCommentsUnderPostErrorResponse struct {
 	Message string `json:"message"`
 	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"BAD_REQUEST"`
+}
+
+// List Comment Likers Models
+type ListCommentLikersSuccessResponse struct {
+	Message    string         `json:"message" example:"Comment Likers Retrieved Successfully"`
+	Users      []*UserSummary `json:"users"`
+	Pagination *Pagination    `json:"pagination"`
+}
+
+type ListCommentLikersErrorResponse struct {
+	Message string `json:"message"`
+	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"BAD_REQUEST"`
+}
+
+// List Comment Dislikers Models
+type ListCommentDislikersSuccessResponse struct {
+	Message    string         `json:"message" example:"Comment Dislikers Retrieved Successfully"`
+	Users      []*UserSummary `json:"users"`
+	Pagination *Pagination    `json:"pagination"`
+}
+
+type ListCommentDislikersErrorResponse struct {
+	Message string `json:"message"`
+	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"BAD_REQUEST"`
 }
@@ -7,16 +7,17 @@ import (
 )
 
 type Comment struct {
-	ID        uuid.UUID `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
-	AuthorID  uuid.UUID `json:"-" example:"550e8400-e29b-41d4-a716-446655440000"`
-	Author    *User     `json:"author,omitempty"`
-	PostID    uuid.UUID `json:"-" example:"550e8400-e29b-41d4-a716-446655440000"`
-	Post      *Post     `json:"post,omitempty"`
-	Content   string    `json:"content" example:"This is a comment content"`
-	Likes     uint      `json:"likes" example:"100"`
-	Dislikes  uint      `json:"dislikes" example:"10"`
-	CreatedAt time.Time `json:"created_at" example:"2025-01-25T12:34:01.159498Z"`
-	UpdatedAt time.Time `json:"updated_at" example:"2025-01-25T12:34:01.159498Z"`
+	ID             uuid.UUID `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	AuthorID       uuid.UUID `json:"-" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Author         *User     `json:"author,omitempty"`
+	PostID         uuid.UUID `json:"-" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Post           *Post     `json:"post,omitempty"`
+	Content        string    `json:"content" example:"This is a comment content"`
+	Likes          uint      `json:"likes" example:"100"`
+	Dislikes       uint      `json:"dislikes" example:"10"`
+	ViewerReaction *string   `json:"viewer_reaction,omitempty" example:"like"`
+	CreatedAt      time.Time `json:"created_at" example:"2025-01-25T12:34:01.159498Z"`
+	UpdatedAt      time.Time `json:"updated_at" example:"2025-01-25T12:34:01.159498Z"`
 }
 
 // Create Comment Models
@@ -32,6 +33,7 @@ type CreateCommentSuccessResponse struct {
 type CreateCommentErrorResponse struct {
 	Message string `json:"message"`
 	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"BAD_REQUEST"`
 }
 
 // Update Comment Models
@@ -47,6 +49,7 @@ type UpdateCommentSuccessResponse struct {
 type UpdateCommentErrorResponse struct {
 	Message string `json:"message"`
 	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"BAD_REQUEST"`
 }
 
 // Delete Comment Models
@@ -57,6 +60,7 @@ type DeleteCommentSuccessResponse struct {
 type DeleteCommentErrorResponse struct {
 	Message string `json:"message"`
 	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"BAD_REQUEST"`
 }
 
 // Get Comment Models
@@ -68,6 +72,7 @@ type GetCommentSuccessResponse struct {
 type GetCommentErrorResponse struct {
 	Message string `json:"message"`
 	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"BAD_REQUEST"`
 }
 
 // List My Comments Models
@@ -79,6 +84,19 @@ type ListMyCommentsSuccessResponse struct {
 type ListMyCommentsErrorResponse struct {
 	Message string `json:"message"`
 	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"BAD_REQUEST"`
+}
+
+// List All My Comments Models
+type ListAllMyCommentsSuccessResponse struct {
+	Message  string     `json:"message" example:"Comments Retrieved Successfully"`
+	Comments []*Comment `json:"comments"`
+}
+
+type ListAllMyCommentsErrorResponse struct {
+	Message string `json:"message"`
+	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"BAD_REQUEST"`
 }
 
 // List User Comments Models
@@ -90,4 +108,17 @@ type ListUserCommentsSuccessResponse struct {
 type ListUserCommentsErrorResponse struct {
 	Message string `json:"message"`
 	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"BAD_REQUEST"`
+}
+
+// List Posts Commented By User Models
+type ListPostsCommentedByUserSuccessResponse struct {
+	Message string  `json:"message" example:"Commented Posts Retrieved Successfully"`
+	Posts   []*Post `json:"posts"`
+}
+
+type ListPostsCommentedByUserErrorResponse struct {
+	Message string `json:"message"`
+	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"BAD_REQUEST"`
 }
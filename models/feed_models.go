@@ -9,6 +9,7 @@ type ListFeedSuccessResponse struct {
 type ListFeedErrorResponse struct {
 	Message string `json:"message"`
 	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"BAD_REQUEST"`
 }
 
 // Get Feed Post Models
@@ -20,10 +21,12 @@ type GetFeedPostSuccessResponse struct {
 type GetFeedPostErrorResponse struct {
 	Message string `json:"message"`
 	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"BAD_REQUEST"`
 }
 
 // FeedPost Model
 type FeedPost struct {
-	Post     *Post      `json:"post"`
-	Comments []*Comment `json:"comments"`
+	Post       *Post       `json:"post"`
+	Comments   []*Comment  `json:"comments"`
+	Pagination *Pagination `json:"pagination"`
 }
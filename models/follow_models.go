@@ -14,14 +14,30 @@ type Follow struct {
 	CreatedAt  time.Time `json:"created_at"`
 }
 
+type FollowRequest struct {
+	ID          uuid.UUID `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	RequesterID uuid.UUID `json:"-"`
+	Requester   *User     `json:"requester"`
+	TargetID    uuid.UUID `json:"-"`
+	CreatedAt   time.Time `json:"created_at" example:"2025-01-25T12:34:01.159498Z"`
+}
+
+type UserSuggestion struct {
+	User            *UserSummary `json:"user"`
+	MutualFollowers uint         `json:"mutual_followers" example:"3"`
+	RecentActivity  uint         `json:"recent_activity" example:"12"`
+}
+
 // Follow User Models
 type FollowUserSuccessResponse struct {
-	Message string `json:"message" example:"User Followed Successfully"`
+	Message   string `json:"message" example:"User Followed Successfully"`
+	Requested bool   `json:"requested,omitempty" example:"false"`
 }
 
 type FollowUserErrorResponse struct {
 	Message string `json:"message"`
 	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"BAD_REQUEST"`
 }
 
 // Unfollow User Models
@@ -32,48 +48,139 @@ type UnfollowUserSuccessResponse struct {
 type UnfollowUserErrorResponse struct {
 	Message string `json:"message"`
 	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"BAD_REQUEST"`
+}
+
+// Remove Follower Models
+type RemoveFollowerSuccessResponse struct {
+	Message string `json:"message" example:"Follower Removed Successfully"`
+}
+
+type RemoveFollowerErrorResponse struct {
+	Message string `json:"message"`
+	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"BAD_REQUEST"`
 }
 
 // Get Followers Models
 type GetFollowersSuccessResponse struct {
-	Message   string  `json:"message" example:"Followers Retrieved Successfully"`
-	Followers []*User `json:"followers"`
+	Message   string         `json:"message" example:"Followers Retrieved Successfully"`
+	Followers []*UserSummary `json:"followers"`
 }
 
 type GetFollowersErrorResponse struct {
 	Message string `json:"message"`
 	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"BAD_REQUEST"`
 }
 
 // Get Following Models
 type GetFollowingSuccessResponse struct {
-	Message   string  `json:"message" example:"Following Users Retrieved Successfully"`
-	Following []*User `json:"following"`
+	Message   string         `json:"message" example:"Following Users Retrieved Successfully"`
+	Following []*UserSummary `json:"following"`
 }
 
 type GetFollowingErrorResponse struct {
 	Message string `json:"message"`
 	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"BAD_REQUEST"`
 }
 
 // Get User Followers Models
 type GetUserFollowersSuccessResponse struct {
-	Message   string  `json:"message" example:"User Followers Retrieved Successfully"`
-	Followers []*User `json:"followers"`
+	Message   string         `json:"message" example:"User Followers Retrieved Successfully"`
+	Followers []*UserSummary `json:"followers"`
 }
 
 type GetUserFollowersErrorResponse struct {
 	Message string `json:"message"`
 	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"BAD_REQUEST"`
 }
 
 // Get User Following Models
 type GetUserFollowingSuccessResponse struct {
-	Message   string  `json:"message" example:"User Following Users Retrieved Successfully"`
-	Following []*User `json:"following"`
+	Message   string         `json:"message" example:"User Following Users Retrieved Successfully"`
+	Following []*UserSummary `json:"following"`
 }
 
 type GetUserFollowingErrorResponse struct {
 	Message string `json:"message"`
 	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"BAD_REQUEST"`
+}
+
+// List Follow Requests Models
+type ListFollowRequestsSuccessResponse struct {
+	Message        string           `json:"message" example:"Follow Requests Retrieved Successfully"`
+	FollowRequests []*FollowRequest `json:"follow_requests"`
+}
+
+type ListFollowRequestsErrorResponse struct {
+	Message string `json:"message"`
+	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"BAD_REQUEST"`
+}
+
+// Accept Follow Request Models
+type AcceptFollowRequestSuccessResponse struct {
+	Message string `json:"message" example:"Follow Request Accepted Successfully"`
+}
+
+type AcceptFollowRequestErrorResponse struct {
+	Message string `json:"message"`
+	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"BAD_REQUEST"`
+}
+
+// Reject Follow Request Models
+type RejectFollowRequestSuccessResponse struct {
+	Message string `json:"message" example:"Follow Request Rejected Successfully"`
+}
+
+type RejectFollowRequestErrorResponse struct {
+	Message string `json:"message"`
+	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"BAD_REQUEST"`
+}
+
+// Follow Status Models
+type FollowStatusPayload struct {
+	Identifiers []string `json:"identifiers" binding:"required,min=1,max=100" example:"john_doe,jane@example.com"`
+}
+
+type FollowStatusSuccessResponse struct {
+	Message   string          `json:"message" example:"Follow Status Retrieved Successfully"`
+	Following map[string]bool `json:"following"`
+}
+
+type FollowStatusErrorResponse struct {
+	Message string `json:"message"`
+	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"VALIDATION_FAILED"`
+}
+
+// Suggest Users Models
+type SuggestUsersSuccessResponse struct {
+	Message     string            `json:"message" example:"User Suggestions Retrieved Successfully"`
+	Suggestions []*UserSuggestion `json:"suggestions"`
+}
+
+type SuggestUsersErrorResponse struct {
+	Message string `json:"message"`
+	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"BAD_REQUEST"`
+}
+
+// List Mutuals Models
+type ListMutualsSuccessResponse struct {
+	Message    string         `json:"message" example:"Mutuals Retrieved Successfully"`
+	Mutuals    []*UserSummary `json:"mutuals"`
+	Pagination *Pagination    `json:"pagination"`
+}
+
+type ListMutualsErrorResponse struct {
+	Message string `json:"message"`
+	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"BAD_REQUEST"`
 }
@@ -22,3 +22,49 @@ type PostgresHealthyResponse struct {
 type PostgresUnhealthyResponse struct {
 	Status string `json:"status" example:"Postgres Unhealthy!"`
 }
+
+// Health Info Models
+type BuildInfo struct {
+	Version   string `json:"version" example:"v1.2.0"`
+	Commit    string `json:"commit,omitempty" example:"4f2c1a9d7e3b5c6a8f0e1d2c3b4a59687f6e5d4c"`
+	BuildTime string `json:"build_time,omitempty" example:"2025-01-25T12:34:01Z"`
+	GoVersion string `json:"go_version" example:"go1.23.4"`
+}
+
+type UserCacheStats struct {
+	Hits   int64 `json:"hits" example:"1520"`
+	Misses int64 `json:"misses" example:"48"`
+}
+
+type QueryDurationBucket struct {
+	Le    string `json:"le" example:"100ms"`
+	Count int64  `json:"count" example:"1480"`
+}
+
+type QueryDurationStats struct {
+	Count                     int64                 `json:"count" example:"1520"`
+	SlowCount                 int64                 `json:"slow_count" example:"3"`
+	TotalMilliseconds         int64                 `json:"total_ms" example:"48211"`
+	SlowThresholdMilliseconds int64                 `json:"slow_threshold_ms" example:"500"`
+	Buckets                   []QueryDurationBucket `json:"buckets"`
+}
+
+type DatabasePoolStats struct {
+	TotalConns              int32 `json:"total_conns" example:"10"`
+	AcquiredConns           int32 `json:"acquired_conns" example:"4"`
+	IdleConns               int32 `json:"idle_conns" example:"6"`
+	MaxConns                int32 `json:"max_conns" example:"10"`
+	EmptyAcquireCount       int64 `json:"empty_acquire_count" example:"12"`
+	CanceledAcquireCount    int64 `json:"canceled_acquire_count" example:"0"`
+	AcquireWaitMilliseconds int64 `json:"acquire_wait_ms" example:"310"`
+}
+
+type HealthInfoResponse struct {
+	Status        string             `json:"status" example:"Healthy!"`
+	Build         BuildInfo          `json:"build"`
+	UptimeSeconds int64              `json:"uptime_seconds" example:"3600"`
+	Dependencies  map[string]string  `json:"dependencies"`
+	UserCache     UserCacheStats     `json:"user_cache"`
+	Queries       QueryDurationStats `json:"queries"`
+	DatabasePool  *DatabasePoolStats `json:"database_pool,omitempty"`
+}
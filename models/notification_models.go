@@ -0,0 +1,58 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Notification struct {
+	ID        uuid.UUID  `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	UserID    uuid.UUID  `json:"-"`
+	ActorID   *uuid.UUID `json:"-"`
+	Actor     *User      `json:"actor,omitempty"`
+	Type      string     `json:"type" example:"follow"`
+	EntityID  *uuid.UUID `json:"entity_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"`
+	CreatedAt time.Time  `json:"created_at" example:"2025-01-25T12:34:01.159498Z"`
+	ReadAt    *time.Time `json:"read_at,omitempty" example:"2025-01-25T12:34:01.159498Z"`
+}
+
+// List Notifications Models
+type ListNotificationsSuccessResponse struct {
+	Message       string          `json:"message" example:"Notifications Retrieved Successfully"`
+	Notifications []*Notification `json:"notifications"`
+}
+
+type ListNotificationsErrorResponse struct {
+	Message string `json:"message"`
+	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"BAD_REQUEST"`
+}
+
+// Get Unread Notifications Count Models
+type GetUnreadNotificationsCountSuccessResponse struct {
+	Message string `json:"message" example:"Unread Notifications Count Retrieved Successfully"`
+	Count   int    `json:"count" example:"3"`
+}
+
+type GetUnreadNotificationsCountErrorResponse struct {
+	Message string `json:"message"`
+	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"BAD_REQUEST"`
+}
+
+// Mark Notifications Read Models
+type MarkNotificationsReadPayload struct {
+	IDs []uuid.UUID `json:"ids" binding:"required,min=1,max=100" example:"550e8400-e29b-41d4-a716-446655440000"`
+}
+
+type MarkNotificationsReadSuccessResponse struct {
+	Message     string `json:"message" example:"Notifications Marked as Read Successfully"`
+	UnreadCount int    `json:"unread_count" example:"2"`
+}
+
+type MarkNotificationsReadErrorResponse struct {
+	Message string `json:"message"`
+	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"BAD_REQUEST"`
+}
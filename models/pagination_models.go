@@ -0,0 +1,9 @@
+package models
+
+// Pagination describes the page of a list returned in a response and the size of the whole list.
+type Pagination struct {
+	Page       int `json:"page" example:"1"`
+	PageSize   int `json:"page_size" example:"20"`
+	TotalItems int `json:"total_items" example:"340"`
+	TotalPages int `json:"total_pages" example:"17"`
+}
@@ -13,6 +13,28 @@ type PostLike struct {
 	CreatedAt time.Time `json:"created_at"`
 }
 
+type PostReaction struct {
+	Reaction  string    `json:"reaction" example:"like"`
+	ReactedAt time.Time `json:"reacted_at" example:"2025-01-25T12:34:01.159498Z"`
+	Post      *Post     `json:"post"`
+}
+
+type ReactionTimelineBucket struct {
+	Start              time.Time `json:"start" example:"2025-01-25T12:00:00Z"`
+	Likes              uint      `json:"likes" example:"4"`
+	Dislikes           uint      `json:"dislikes" example:"1"`
+	CumulativeLikes    uint      `json:"cumulative_likes" example:"37"`
+	CumulativeDislikes uint      `json:"cumulative_dislikes" example:"6"`
+}
+
+type ReactionTimeline struct {
+	PostID  uuid.UUID                 `json:"post_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Bucket  string                    `json:"bucket" example:"hour"`
+	Since   time.Time                 `json:"since" example:"2025-01-24T12:00:00Z"`
+	Until   time.Time                 `json:"until" example:"2025-01-25T12:34:01Z"`
+	Buckets []*ReactionTimelineBucket `json:"buckets"`
+}
+
 // Like Post Models
 type LikePostPayload struct {
 	PostID string `json:"post_id" binding:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
@@ -25,6 +47,7 @@ type LikePostSuccessResponse struct {
 type LikePostErrorResponse struct {
 	Message string `json:"message"`
 	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"BAD_REQUEST"`
 }
 
 // Dislike Post Models
@@ -39,6 +62,7 @@ type DislikePostSuccessResponse struct {
 type DislikePostErrorResponse struct {
 	Message string `json:"message"`
 	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"BAD_REQUEST"`
 }
 
 // Unlike Post Models
@@ -49,6 +73,7 @@ type UnlikePostSuccessResponse struct {
 type UnlikePostErrorResponse struct {
 	Message string `json:"message"`
 	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"BAD_REQUEST"`
 }
 
 // Undislike Post Models
@@ -59,6 +84,19 @@ type UndislikePostSuccessResponse struct {
 type UndislikePostErrorResponse struct {
 	Message string `json:"message"`
 	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"BAD_REQUEST"`
+}
+
+// List Post Reactions Models
+type ListPostReactionsSuccessResponse struct {
+	Message   string          `json:"message" example:"Post Reactions Retrieved Successfully"`
+	Reactions []*PostReaction `json:"reactions"`
+}
+
+type ListPostReactionsErrorResponse struct {
+	Message string `json:"message"`
+	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"UNAUTHORIZED"`
 }
 
 // List Liked Posts Models
@@ -70,6 +108,7 @@ type ListLikedPostsSuccessResponse struct {
 type ListLikedPostsErrorResponse struct {
 	Message string `json:"message"`
 	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"BAD_REQUEST"`
 }
 
 // List Disliked Posts Models
@@ -81,6 +120,7 @@ type ListDislikedPostsSuccessResponse struct {
 type ListDislikedPostsErrorResponse struct {
 	Message string `json:"message"`
 	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"BAD_REQUEST"`
 }
 
 // List User Liked Posts Models
@@ -92,6 +132,7 @@ type ListLikedPostsByUserIdentifierSuccessResponse struct {
 type ListLikedPostsByUserIdentifierErrorResponse struct {
 	Message string `json:"message"`
 	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"BAD_REQUEST"`
 }
 
 // List User Disliked Posts Models
@@ -103,4 +144,17 @@ type ListDislikedPostsByUserIdentifierSuccessResponse struct {
 type ListDislikedPostsByUserIdentifierErrorResponse struct {
 	Message string `json:"message"`
 	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"BAD_REQUEST"`
+}
+
+// Get Post Reaction Timeline Models
+type GetPostReactionTimelineSuccessResponse struct {
+	Message  string            `json:"message" example:"Post Reaction Timeline Retrieved Successfully"`
+	Timeline *ReactionTimeline `json:"timeline"`
+}
+
+type GetPostReactionTimelineErrorResponse struct {
+	Message string `json:"message"`
+	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"BAD_REQUEST"`
 }
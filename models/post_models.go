@@ -7,25 +7,30 @@ import (
 )
 
 type Post struct {
-	ID          uuid.UUID `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
-	AuthorID    uuid.UUID `json:"-"`
-	Author      *User     `json:"author,omitempty"`
-	Title       string    `json:"title" example:"My Awesome Post"`
-	SubTitle    string    `json:"sub_title,omitempty" example:"A Catchy Subtitle"`
-	Description string    `json:"description,omitempty" example:"A brief description of the post."`
-	Content     string    `json:"content" example:"This is the main content of my post."`
-	Likes       uint      `json:"likes" example:"100"`
-	Dislikes    uint      `json:"dislikes" example:"10"`
-	CreatedAt   time.Time `json:"created_at" example:"2025-01-25T12:34:01.159498Z"`
-	UpdatedAt   time.Time `json:"updated_at" example:"2025-01-25T12:34:01.159498Z"`
+	ID           uuid.UUID  `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	AuthorID     uuid.UUID  `json:"-"`
+	Author       *User      `json:"author,omitempty"`
+	Title        string     `json:"title" example:"My Awesome Post"`
+	SubTitle     string     `json:"sub_title,omitempty" example:"A Catchy Subtitle"`
+	Description  string     `json:"description,omitempty" example:"A brief description of the post."`
+	Content      string     `json:"content" example:"This is the main content of my post."`
+	Likes        uint       `json:"likes" example:"100"`
+	Dislikes     uint       `json:"dislikes" example:"10"`
+	Comments     uint       `json:"comments_count" example:"5"`
+	Pinned       bool       `json:"pinned,omitempty" example:"false"`
+	QuotedPostID *uuid.UUID `json:"-"`
+	QuotedPost   *Post      `json:"quoted_post,omitempty"`
+	CreatedAt    time.Time  `json:"created_at" example:"2025-01-25T12:34:01.159498Z"`
+	UpdatedAt    time.Time  `json:"updated_at" example:"2025-01-25T12:34:01.159498Z"`
 }
 
 // Create Post Models
 type CreatePostPayload struct {
-	Title       string `json:"title" binding:"required,min=3,max=255" example:"My Awesome Post"`
-	SubTitle    string `json:"sub_title,omitempty" example:"A Catchy Subtitle"`
-	Description string `json:"description,omitempty" example:"A brief description of the post."`
-	Content     string `json:"content" binding:"required" example:"This is the main content of my post."`
+	Title        string `json:"title" binding:"required,notblank,min=3,max=255" example:"My Awesome Post"`
+	SubTitle     string `json:"sub_title,omitempty" binding:"max=255" example:"A Catchy Subtitle"`
+	Description  string `json:"description,omitempty" example:"A brief description of the post."`
+	Content      string `json:"content" binding:"required,notblank,max=50000" example:"This is the main content of my post."`
+	QuotedPostID string `json:"quoted_post_id,omitempty" binding:"omitempty,uuid" example:"550e8400-e29b-41d4-a716-446655440000"`
 }
 
 type CreatePostSuccessResponse struct {
@@ -36,6 +41,7 @@ type CreatePostSuccessResponse struct {
 type CreatePostErrorResponse struct {
 	Message string `json:"message"`
 	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"BAD_REQUEST"`
 }
 
 // Get Post Models
@@ -47,14 +53,27 @@ type GetPostSuccessResponse struct {
 type GetPostErrorResponse struct {
 	Message string `json:"message"`
 	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"BAD_REQUEST"`
+}
+
+// Get Post With Comments Models
+type GetPostWithCommentsSuccessResponse struct {
+	Message string    `json:"message" example:"Post with Comments Retrieved Successfully"`
+	Post    *FeedPost `json:"post"`
+}
+
+type GetPostWithCommentsErrorResponse struct {
+	Message string `json:"message"`
+	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"BAD_REQUEST"`
 }
 
 // Update Post Models
 type UpdatePostPayload struct {
-	Title       string `json:"title,omitempty" example:"Updated Awesome Post"`
-	SubTitle    string `json:"sub_title,omitempty" example:"Updated Catchy Subtitle"`
+	Title       string `json:"title,omitempty" binding:"omitempty,notblank,max=255" example:"Updated Awesome Post"`
+	SubTitle    string `json:"sub_title,omitempty" binding:"max=255" example:"Updated Catchy Subtitle"`
 	Description string `json:"description,omitempty" example:"Updated brief description of the post."`
-	Content     string `json:"content,omitempty" example:"Updated main content of my post."`
+	Content     string `json:"content,omitempty" binding:"omitempty,notblank,max=50000" example:"Updated main content of my post."`
 }
 
 type UpdatePostSuccessResponse struct {
@@ -65,6 +84,7 @@ type UpdatePostSuccessResponse struct {
 type UpdatePostErrorResponse struct {
 	Message string `json:"message"`
 	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"BAD_REQUEST"`
 }
 
 // Delete Post Models
@@ -75,6 +95,41 @@ type DeletePostSuccessResponse struct {
 type DeletePostErrorResponse struct {
 	Message string `json:"message"`
 	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"BAD_REQUEST"`
+}
+
+// Pin Post Models
+type PinPostSuccessResponse struct {
+	Message string `json:"message" example:"Post Pinned Successfully"`
+}
+
+type PinPostErrorResponse struct {
+	Message string `json:"message"`
+	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"POST_NOT_FOUND"`
+}
+
+// Unpin Post Models
+type UnpinPostSuccessResponse struct {
+	Message string `json:"message" example:"Post Unpinned Successfully"`
+}
+
+type UnpinPostErrorResponse struct {
+	Message string `json:"message"`
+	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"POST_NOT_PINNED"`
+}
+
+// List Home Feed Models
+type ListHomeFeedSuccessResponse struct {
+	Message string  `json:"message" example:"Home Feed Retrieved Successfully"`
+	Posts   []*Post `json:"posts"`
+}
+
+type ListHomeFeedErrorResponse struct {
+	Message string `json:"message"`
+	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"UNAUTHORIZED"`
 }
 
 // List My Posts Models
@@ -86,6 +141,7 @@ type ListMyPostsSuccessResponse struct {
 type ListMyPostsErrorResponse struct {
 	Message string `json:"message"`
 	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"BAD_REQUEST"`
 }
 
 // List User Posts Models
@@ -97,4 +153,34 @@ type ListUserPostsSuccessResponse struct {
 type ListUserPostsErrorResponse struct {
 	Message string `json:"message"`
 	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"BAD_REQUEST"`
+}
+
+// Export Post Models
+type PostExportAuthor struct {
+	ID       uuid.UUID `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Username string    `json:"username" example:"john_doe"`
+}
+
+type PostExportComment struct {
+	ID        uuid.UUID         `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Author    *PostExportAuthor `json:"author"`
+	Content   string            `json:"content" example:"This is a comment content"`
+	Likes     uint              `json:"likes" example:"100"`
+	Dislikes  uint              `json:"dislikes" example:"10"`
+	CreatedAt time.Time         `json:"created_at" example:"2025-01-25T12:34:01.159498Z"`
+	UpdatedAt time.Time         `json:"updated_at" example:"2025-01-25T12:34:01.159498Z"`
+}
+
+type PostExport struct {
+	ExportedAt time.Time            `json:"exported_at" example:"2025-01-25T12:34:01.159498Z"`
+	Author     *PostExportAuthor    `json:"author"`
+	Post       *Post                `json:"post"`
+	Comments   []*PostExportComment `json:"comments"`
+}
+
+type ExportPostErrorResponse struct {
+	Message string `json:"message"`
+	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"BAD_REQUEST"`
 }
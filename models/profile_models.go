@@ -7,18 +7,21 @@ import (
 )
 
 type Profile struct {
-	ID            uuid.UUID `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
-	UserID        uuid.UUID `json:"-"`
-	User          *User     `json:"user,omitempty"`
-	FirstName     string    `json:"first_name,omitempty" example:"John"`
-	LastName      string    `json:"last_name,omitempty" example:"Doe"`
-	Website       string    `json:"website,omitempty" example:"https://example.com"`
-	Github        string    `json:"github,omitempty" example:"https://github.com/john_doe"`
-	LinkedIn      string    `json:"linkedin,omitempty" example:"https://linkedin.com/in/john_doe"`
-	Twitter       string    `json:"twitter,omitempty" example:"https://twitter.com/john_doe"`
-	GoogleScholar string    `json:"google_scholar,omitempty" example:"https://scholar.google.com/citations?user=xxxxxxxxxxxxx"`
-	CreatedAt     time.Time `json:"created_at" example:"2025-01-25T12:34:01.159498Z"`
-	UpdatedAt     time.Time `json:"updated_at" example:"2025-01-25T12:34:01.159498Z"`
+	ID            uuid.UUID  `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	UserID        uuid.UUID  `json:"-"`
+	User          *User      `json:"user,omitempty"`
+	FirstName     string     `json:"first_name,omitempty" example:"John"`
+	LastName      string     `json:"last_name,omitempty" example:"Doe"`
+	Website       string     `json:"website,omitempty" example:"https://example.com"`
+	Github        string     `json:"github,omitempty" example:"https://github.com/john_doe"`
+	LinkedIn      string     `json:"linkedin,omitempty" example:"https://linkedin.com/in/john_doe"`
+	Twitter       string     `json:"twitter,omitempty" example:"https://twitter.com/john_doe"`
+	GoogleScholar string     `json:"google_scholar,omitempty" example:"https://scholar.google.com/citations?user=xxxxxxxxxxxxx"`
+	IsPrivate     bool       `json:"is_private" example:"false"`
+	LastSeenAt    *time.Time `json:"last_seen_at,omitempty" example:"2025-01-25T12:34:01.159498Z"`
+	Online        bool       `json:"online" example:"true"`
+	CreatedAt     time.Time  `json:"created_at" example:"2025-01-25T12:34:01.159498Z"`
+	UpdatedAt     time.Time  `json:"updated_at" example:"2025-01-25T12:34:01.159498Z"`
 }
 
 // Update Profile Models
@@ -30,6 +33,7 @@ type UpdateProfilePayload struct {
 	LinkedIn      string `json:"linkedin,omitempty" example:"https://linkedin.com/in/john_doe"`
 	Twitter       string `json:"twitter,omitempty" example:"https://twitter.com/john_doe"`
 	GoogleScholar string `json:"google_scholar,omitempty" example:"https://scholar.google.com/citations?user=xxxxxxxxxxxxx"`
+	IsPrivate     bool   `json:"is_private,omitempty" example:"false"`
 }
 
 type UpdateProfileSuccessResponse struct {
@@ -40,6 +44,7 @@ type UpdateProfileSuccessResponse struct {
 type UpdateProfileErrorResponse struct {
 	Message string `json:"message"`
 	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"BAD_REQUEST"`
 }
 
 // Get Logged In User Profile Models
@@ -51,6 +56,7 @@ type GetLoggedInUserProfileSuccessResponse struct {
 type GetLoggedInUserProfileErrorResponse struct {
 	Message string `json:"message"`
 	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"BAD_REQUEST"`
 }
 
 // Get User Profile Models
@@ -62,4 +68,109 @@ type GetUserProfileSuccessResponse struct {
 type GetUserProfileErrorResponse struct {
 	Message string `json:"message"`
 	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"BAD_REQUEST"`
+}
+
+// Get User Stats Models
+type UserStats struct {
+	UserID                uuid.UUID `json:"user_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	PostsCount            uint      `json:"posts_count" example:"12"`
+	CommentsCount         uint      `json:"comments_count" example:"34"`
+	TotalLikesReceived    uint      `json:"total_likes_received" example:"120"`
+	TotalDislikesReceived uint      `json:"total_dislikes_received" example:"3"`
+	FollowersCount        uint      `json:"followers_count" example:"56"`
+	FollowingCount        uint      `json:"following_count" example:"78"`
+}
+
+type GetUserStatsSuccessResponse struct {
+	Message string     `json:"message" example:"User Stats Retrieved Successfully"`
+	Stats   *UserStats `json:"stats"`
+}
+
+type GetUserStatsErrorResponse struct {
+	Message string `json:"message"`
+	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"BAD_REQUEST"`
+}
+
+// Get Users Batch Models
+type GetUsersBatchPayload struct {
+	Identifiers []string `json:"identifiers" binding:"required,min=1,max=100" example:"john_doe,550e8400-e29b-41d4-a716-446655440000"`
+}
+
+type GetUsersBatchSuccessResponse struct {
+	Message string         `json:"message" example:"Users Retrieved Successfully"`
+	Users   []*UserSummary `json:"users"`
+}
+
+type GetUsersBatchErrorResponse struct {
+	Message string `json:"message"`
+	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"VALIDATION_FAILED"`
+}
+
+// Block User Models
+type BlockUserSuccessResponse struct {
+	Message string `json:"message" example:"User Blocked Successfully"`
+}
+
+type BlockUserErrorResponse struct {
+	Message string `json:"message"`
+	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"ALREADY_BLOCKED"`
+}
+
+// Unblock User Models
+type UnblockUserSuccessResponse struct {
+	Message string `json:"message" example:"User Unblocked Successfully"`
+}
+
+type UnblockUserErrorResponse struct {
+	Message string `json:"message"`
+	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"NOT_BLOCKED"`
+}
+
+// List Blocked Users Models
+type BlockedUser struct {
+	User      *UserSummary `json:"user"`
+	BlockedAt time.Time    `json:"blocked_at" example:"2025-01-25T12:34:01.159498Z"`
+}
+
+type ListBlockedUsersSuccessResponse struct {
+	Message    string         `json:"message" example:"Blocked Users Retrieved Successfully"`
+	Blocked    []*BlockedUser `json:"blocked"`
+	Pagination *Pagination    `json:"pagination"`
+}
+
+type ListBlockedUsersErrorResponse struct {
+	Message string `json:"message"`
+	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"UNAUTHORIZED"`
+}
+
+// Get User Activity Models
+type ActivityBucket struct {
+	Start    time.Time `json:"start" example:"2025-01-20T00:00:00Z"`
+	Posts    uint      `json:"posts" example:"3"`
+	Comments uint      `json:"comments" example:"11"`
+}
+
+type UserActivityHistogram struct {
+	UserID      uuid.UUID         `json:"user_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Granularity string            `json:"granularity" example:"week"`
+	From        time.Time         `json:"from" example:"2025-01-20T00:00:00Z"`
+	To          time.Time         `json:"to" example:"2025-04-13T00:00:00Z"`
+	Buckets     []*ActivityBucket `json:"buckets"`
+}
+
+type GetUserActivitySuccessResponse struct {
+	Message  string                 `json:"message" example:"User Activity Retrieved Successfully"`
+	Activity *UserActivityHistogram `json:"activity"`
+}
+
+type GetUserActivityErrorResponse struct {
+	Message string `json:"message"`
+	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"BAD_REQUEST"`
 }
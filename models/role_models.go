@@ -7,3 +7,15 @@ type Role struct {
 	Level       int       `json:"level" example:"1"`
 	Description string    `json:"description" example:"Normal User"`
 }
+
+// List Roles Models
+type ListRolesSuccessResponse struct {
+	Message string  `json:"message" example:"Roles Retrieved Successfully"`
+	Roles   []*Role `json:"roles"`
+}
+
+type ListRolesErrorResponse struct {
+	Message string `json:"message"`
+	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"INTERNAL_ERROR"`
+}
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Session struct {
+	ID         uuid.UUID `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	UserID     uuid.UUID `json:"-"`
+	UserAgent  string    `json:"user_agent" example:"Mozilla/5.0 (X11; Linux x86_64)"`
+	IPAddress  string    `json:"ip_address" example:"203.0.113.42"`
+	Current    bool      `json:"current" example:"true"`
+	CreatedAt  time.Time `json:"created_at" example:"2025-01-25T12:34:01.159498Z"`
+	LastUsedAt time.Time `json:"last_used_at" example:"2025-01-25T12:34:01.159498Z"`
+}
+
+// List Sessions Models
+type ListSessionsSuccessResponse struct {
+	Message  string     `json:"message" example:"Sessions Retrieved Successfully"`
+	Sessions []*Session `json:"sessions"`
+}
+
+type ListSessionsErrorResponse struct {
+	Message string `json:"message"`
+	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"BAD_REQUEST"`
+}
+
+// Revoke Session Models
+type RevokeSessionSuccessResponse struct {
+	Message string `json:"message" example:"Session Revoked Successfully"`
+}
+
+type RevokeSessionErrorResponse struct {
+	Message string `json:"message"`
+	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty" example:"BAD_REQUEST"`
+}
@@ -0,0 +1,17 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Webhook struct {
+	ID        uuid.UUID `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	URL       string    `json:"url" example:"https://hooks.example.com/gopher-social"`
+	Secret    string    `json:"-"`
+	Events    []string  `json:"events" example:"user.banned,user.registered"`
+	IsActive  bool      `json:"is_active" example:"true"`
+	CreatedAt time.Time `json:"created_at" example:"2025-01-25T12:34:01.159498Z"`
+	UpdatedAt time.Time `json:"updated_at" example:"2025-01-25T12:34:01.159498Z"`
+}
@@ -2,10 +2,12 @@ package routes
 
 import (
 	"github.com/datarohit/gopher-social-backend/controllers"
+	"github.com/datarohit/gopher-social-backend/helpers"
 	"github.com/datarohit/gopher-social-backend/middlewares"
 	"github.com/datarohit/gopher-social-backend/stores"
 	"github.com/gin-gonic/gin"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
 	"github.com/sirupsen/logrus"
 )
 
@@ -14,6 +16,8 @@ import (
 // Parameters:
 //   - router (*gin.RouterGroup): RouterGroup for action routes under /action path.
 //   - dbPool (*pgxpool.Pool): Pgx connection pool to interact with the database.
+//   - redisClient (*redis.Client): Redis client used for the session denylist, token epochs, cached platform stats and the maintenance flag.
+//   - webhookDispatcher (*helpers.WebhookDispatcher): WebhookDispatcher to publish moderation events.
 //   - logger (*logrus.Logger): Logrus logger pointer to log messages.
 //
 // Returns:
@@ -23,26 +27,51 @@ import (
 //   - POST /action/timeout/:userID: Route to timeout a user. Requires moderator or admin role.
 //   - DELETE /action/timeout/:userID: Route to remove timeout from a user. Requires moderator or admin role.
 //   - GET /action/timeout: Route to list all timed out users. Requires moderator or admin role.
+//   - GET /action/timeout/options: Route to list the allowed timeout durations. Requires moderator or admin role.
 //   - DELETE /action/deactivate/:userID: Route to deactivate a user. Requires moderator or admin role.
 //   - POST /action/activate/:userID: Route to activate a user. Requires moderator or admin role.
 //   - POST /action/ban/:userID: Route to ban a user. Requires admin role.
 //   - POST /action/unban/:userID: Route to unban a user. Requires admin role.
 //   - DELETE /action/comment/:commentID: Route to delete a comment. Requires moderator or admin role.
 //   - DELETE /action/post/:postID: Route to delete a post. Requires admin role.
-func ActionRoutes(router *gin.RouterGroup, dbPool *pgxpool.Pool, logger *logrus.Logger) {
+//   - PATCH /action/post/:postID/author: Route to transfer a post to another author. Requires admin role.
+//   - GET /action/posts: Route to list all posts. Requires admin role.
+//   - PATCH /action/role/:userID: Route to change the role of a user. Requires admin role.
+//   - POST /action/logout/:userID: Route to revoke all sessions of a user. Requires admin role.
+//   - POST /action/impersonate/:userID: Route to issue a short-lived read-only token acting as a user. Requires admin role.
+//   - GET /action/user/:userID/history: Route to list the moderation history of a user. Requires moderator or admin role.
+//   - POST /action/logs/:logID/undo: Route to undo a recent ban or timeout. Requires moderator or admin role.
+//   - GET /action/stats: Route to get the platform stats. Requires admin role.
+//   - PUT /action/maintenance: Route to turn read-only maintenance mode on or off. Requires admin role.
+func ActionRoutes(router *gin.RouterGroup, dbPool *pgxpool.Pool, redisClient *redis.Client, webhookDispatcher *helpers.WebhookDispatcher, logger *logrus.Logger) {
 	authStore := stores.NewAuthStore(dbPool)
 	actionStore := stores.NewActionStore(dbPool)
-	actionController := controllers.NewActionController(actionStore, authStore, logger)
+	postStore := stores.NewPostStore(dbPool)
+	sessionStore := stores.NewSessionStore(dbPool, redisClient)
+	moderationLogStore := stores.NewModerationLogStore(dbPool)
+	statsStore := stores.NewStatsStore(dbPool, redisClient)
+	maintenanceStore := stores.NewMaintenanceStore(redisClient)
+	actionController := controllers.NewActionController(actionStore, authStore, postStore, sessionStore, moderationLogStore, statsStore, maintenanceStore, webhookDispatcher, logger)
 
 	actionRouter := router.Group("/action")
 	actionRouter.Use(middlewares.AuthMiddleware(logger))
 	actionRouter.POST("/timeout/:userID", actionController.TimeoutUser)
 	actionRouter.DELETE("/timeout/:userID", actionController.RemoveTimeoutUser)
 	actionRouter.GET("/timeout", middlewares.PaginationMiddleware(), actionController.ListTimedOutUsers)
+	actionRouter.GET("/timeout/options", actionController.ListTimeoutOptions)
 	actionRouter.DELETE("/deactivate/:userID", actionController.DeactivateUser)
 	actionRouter.POST("/activate/:userID", actionController.ActivateUser)
 	actionRouter.POST("/ban/:userID", actionController.BanUser)
 	actionRouter.POST("/unban/:userID", actionController.UnbanUser)
 	actionRouter.DELETE("/comment/:commentID", actionController.DeleteComment)
 	actionRouter.DELETE("/post/:postID", actionController.DeletePost)
+	actionRouter.PATCH("/post/:postID/author", actionController.TransferPostAuthor)
+	actionRouter.GET("/posts", middlewares.PaginationMiddleware(), actionController.ListAllPosts)
+	actionRouter.PATCH("/role/:userID", actionController.UpdateUserRole)
+	actionRouter.POST("/logout/:userID", actionController.ForceLogoutUser)
+	actionRouter.POST("/impersonate/:userID", actionController.ImpersonateUser)
+	actionRouter.GET("/user/:userID/history", middlewares.PaginationMiddleware(), actionController.ListUserModerationHistory)
+	actionRouter.POST("/logs/:logID/undo", actionController.UndoModerationAction)
+	actionRouter.GET("/stats", actionController.GetPlatformStats)
+	actionRouter.PUT("/maintenance", actionController.SetMaintenanceMode)
 }
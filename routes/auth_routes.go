@@ -2,9 +2,12 @@ package routes
 
 import (
 	"github.com/datarohit/gopher-social-backend/controllers"
+	"github.com/datarohit/gopher-social-backend/helpers"
+	"github.com/datarohit/gopher-social-backend/middlewares"
 	"github.com/datarohit/gopher-social-backend/stores"
 	"github.com/gin-gonic/gin"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
 	"github.com/sirupsen/logrus"
 )
 
@@ -13,6 +16,8 @@ import (
 // Parameters:
 //   - router (*gin.RouterGroup): RouterGroup pointer to define routes under /auth path.
 //   - dbPool (*pgxpool.Pool): Pgx connection pool to interact with the database.
+//   - redisClient (*redis.Client): Redis client to store short lived auth state.
+//   - webhookDispatcher (*helpers.WebhookDispatcher): WebhookDispatcher to publish account events.
 //   - logger (*logrus.Logger): Logrus logger pointer to log messages.
 //
 // Returns:
@@ -24,12 +29,30 @@ import (
 //   - /auth/logout (POST): Route to logout user and invalidate JWT tokens.
 //   - /auth/forgot-password (POST): Route to initiate forgot password flow.
 //   - /auth/reset-password (POST): Route to reset password using reset token.
+//   - /auth/change-email (POST): Route to request a change of the email of the logged-in user.
+//   - /auth/verify-email-change (GET): Route to confirm an email change using the email change token.
 //   - /auth/activate (GET): Route to activate user account using activation token.
 //   - /auth/resend-activation-link (POST): Route to resend activation link.
-func AuthRoutes(router *gin.RouterGroup, dbPool *pgxpool.Pool, logger *logrus.Logger) {
+//   - /auth/resend-activation (POST): Route to resend activation link without the password.
+//   - /auth/oauth/google/login (GET): Route to start the Google OAuth login flow.
+//   - /auth/oauth/google/callback (GET): Route to complete the Google OAuth login flow.
+//   - /auth/me (GET): Route to get the logged-in user.
+//   - /auth/me/status (GET): Route to get the active, banned and timeout status of the logged-in user. Allowed while timed out.
+//   - /auth/me/export (GET): Route to download all data of the logged-in user.
+//   - /auth/sessions (GET): Route to list the active sessions of the logged-in user.
+//   - /auth/sessions/:sessionID (DELETE): Route to revoke a session of the logged-in user.
+//   - /auth/api-keys (POST): Route to create an API key for the logged-in user.
+//   - /auth/api-keys (GET): Route to list the API keys of the logged-in user.
+//   - /auth/api-keys/:apiKeyID (DELETE): Route to revoke an API key of the logged-in user.
+func AuthRoutes(router *gin.RouterGroup, dbPool *pgxpool.Pool, redisClient *redis.Client, webhookDispatcher *helpers.WebhookDispatcher, logger *logrus.Logger) {
 	authStore := stores.NewAuthStore(dbPool)
 	profileStore := stores.NewProfileStore(dbPool)
-	authController := controllers.NewAuthController(authStore, profileStore, logger)
+	userStore := stores.NewUserStore(dbPool)
+	sessionStore := stores.NewSessionStore(dbPool, redisClient)
+	mailer := helpers.NewMailer(logger)
+	apiKeyStore := stores.NewAPIKeyStore(dbPool)
+	authController := controllers.NewAuthController(dbPool, authStore, profileStore, userStore, sessionStore, mailer, webhookDispatcher, redisClient, logger)
+	apiKeyController := controllers.NewAPIKeyController(apiKeyStore, logger)
 
 	authRouter := router.Group("/auth")
 	authRouter.POST("/register", authController.Register)
@@ -37,6 +60,19 @@ func AuthRoutes(router *gin.RouterGroup, dbPool *pgxpool.Pool, logger *logrus.Lo
 	authRouter.POST("/logout", authController.Logout)
 	authRouter.POST("/forgot-password", authController.ForgotPassword)
 	authRouter.POST("/reset-password", authController.ResetPassword)
+	authRouter.POST("/change-email", middlewares.AuthMiddleware(logger), authController.ChangeEmail)
+	authRouter.GET("/verify-email-change", authController.VerifyEmailChange)
 	authRouter.GET("/activate", authController.ActivateUser)
 	authRouter.POST("/resend-activation-link", authController.ResendActivationLink)
+	authRouter.POST("/resend-activation", authController.ResendActivation)
+	authRouter.GET("/oauth/google/login", authController.GoogleLogin)
+	authRouter.GET("/oauth/google/callback", authController.GoogleCallback)
+	authRouter.GET("/me", middlewares.AuthMiddleware(logger), authController.GetCurrentUser)
+	authRouter.GET("/me/status", middlewares.AuthMiddleware(logger), authController.GetAccountStatus)
+	authRouter.GET("/me/export", middlewares.AuthMiddleware(logger), authController.ExportUserData)
+	authRouter.GET("/sessions", middlewares.AuthMiddleware(logger), authController.ListSessions)
+	authRouter.DELETE("/sessions/:sessionID", middlewares.AuthMiddleware(logger), authController.RevokeSession)
+	authRouter.POST("/api-keys", middlewares.AuthMiddleware(logger), apiKeyController.CreateAPIKey)
+	authRouter.GET("/api-keys", middlewares.AuthMiddleware(logger), apiKeyController.ListAPIKeys)
+	authRouter.DELETE("/api-keys/:apiKeyID", middlewares.AuthMiddleware(logger), apiKeyController.RevokeAPIKey)
 }
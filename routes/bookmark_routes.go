@@ -0,0 +1,37 @@
+package routes
+
+import (
+	"github.com/datarohit/gopher-social-backend/controllers"
+	"github.com/datarohit/gopher-social-backend/middlewares"
+	"github.com/datarohit/gopher-social-backend/stores"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+)
+
+// BookmarkRoutes defines routes for post bookmark operations.
+//
+// Parameters:
+//   - router (*gin.RouterGroup): RouterGroup for bookmark routes under /post path.
+//   - dbPool (*pgxpool.Pool): Pgx connection pool to interact with the database.
+//   - logger (*logrus.Logger): Logrus logger pointer to log messages.
+//
+// Returns:
+//   - None
+//
+// Routes:
+//   - POST /post/:postID/bookmark: Route to bookmark a post. Requires authentication.
+//   - DELETE /post/:postID/bookmark: Route to remove the bookmark on a post. Requires authentication.
+//   - GET /post/bookmarks: Route to list posts bookmarked by the logged-in user. Requires authentication.
+func BookmarkRoutes(router *gin.RouterGroup, dbPool *pgxpool.Pool, logger *logrus.Logger) {
+	postStore := stores.NewPostStore(dbPool)
+	followStore := stores.NewFollowStore(dbPool)
+	bookmarkStore := stores.NewBookmarkStore(dbPool)
+	bookmarkController := controllers.NewBookmarkController(bookmarkStore, postStore, followStore, logger)
+
+	bookmarkRouter := router.Group("/post")
+	bookmarkRouter.Use(middlewares.AuthMiddleware(logger))
+	bookmarkRouter.POST("/:postID/bookmark", bookmarkController.AddBookmark)
+	bookmarkRouter.DELETE("/:postID/bookmark", bookmarkController.RemoveBookmark)
+	bookmarkRouter.GET("/bookmarks", middlewares.PaginationMiddleware(), bookmarkController.ListBookmarks)
+}
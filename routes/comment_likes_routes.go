@@ -6,6 +6,7 @@ import (
 	"github.com/datarohit/gopher-social-backend/stores"
 	"github.com/gin-gonic/gin"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
 	"github.com/sirupsen/logrus"
 )
 
@@ -14,33 +15,42 @@ import (
 // Parameters:
 //   - router (*gin.RouterGroup): RouterGroup for comment like routes under /post/:postID/comment/:commentID path.
 //   - dbPool (*pgxpool.Pool): Pgx connection pool to interact with the database.
+//   - redisClient (*redis.Client): Redis client to store responses of requests with an Idempotency-Key header.
 //   - logger (*logrus.Logger): Logrus logger pointer to log messages.
 //
 // Returns:
 //   - None
 //
+// POST routes replay their first response when retried with the same Idempotency-Key header.
+//
 // Routes:
 //   - POST /post/:postID/comment/:commentID/like: Route to like a comment. Requires authentication.
 //   - DELETE /post/:postID/comment/:commentID/like: Route to unlike a comment. Requires authentication.
 //   - POST /post/:postID/comment/:commentID/dislike: Route to dislike a comment. Requires authentication.
 //   - DELETE /post/:postID/comment/:commentID/dislike: Route to undislike a comment. Requires authentication.
+//   - GET /post/:postID/comment/:commentID/likes: Route to get the users who liked a comment. Requires authentication.
+//   - GET /post/:postID/comment/:commentID/dislikes: Route to get the users who disliked a comment. Requires authentication.
 //   - GET /post/:postID/comment/liked: Route to get all liked comments under a post by logged-in user. Requires authentication.
 //   - GET /post/:postID/comment/disliked: Route to get all disliked comments under a post by logged-in user. Requires authentication.
 //   - GET /post/:postID/comment/user/:identifier/liked: Route to get all liked comments under a post by a specific user. Requires authentication.
 //   - GET /post/:postID/comment/user/:identifier/disliked: Route to get all disliked comments under a post by a specific user. Requires authentication.
-func CommentLikeRoutes(router *gin.RouterGroup, dbPool *pgxpool.Pool, logger *logrus.Logger) {
+func CommentLikeRoutes(router *gin.RouterGroup, dbPool *pgxpool.Pool, redisClient *redis.Client, logger *logrus.Logger) {
 	authStore := stores.NewAuthStore(dbPool)
 	postStore := stores.NewPostStore(dbPool)
 	commentStore := stores.NewCommentStore(dbPool)
 	commentLikesStore := stores.NewCommentLikeStore(dbPool)
-	commentLikesController := controllers.NewCommentLikesController(commentLikesStore, commentStore, postStore, authStore, logger)
+	blockStore := stores.NewBlockStore(dbPool)
+	commentLikesController := controllers.NewCommentLikesController(commentLikesStore, commentStore, postStore, blockStore, authStore, logger)
 
+	idempotency := middlewares.IdempotencyMiddleware(redisClient, logger)
 	commentLikeRouter := router.Group("/post/:postID/comment")
 	commentLikeRouter.Use(middlewares.AuthMiddleware(logger))
-	commentLikeRouter.POST("/:commentID/like", commentLikesController.LikeComment)
+	commentLikeRouter.POST("/:commentID/like", idempotency, commentLikesController.LikeComment)
 	commentLikeRouter.DELETE("/:commentID/like", commentLikesController.UnlikeComment)
-	commentLikeRouter.POST("/:commentID/dislike", commentLikesController.DislikeComment)
+	commentLikeRouter.POST("/:commentID/dislike", idempotency, commentLikesController.DislikeComment)
 	commentLikeRouter.DELETE("/:commentID/dislike", commentLikesController.UndislikeComment)
+	commentLikeRouter.GET("/:commentID/likes", middlewares.PaginationMiddleware(), commentLikesController.ListCommentLikers)
+	commentLikeRouter.GET("/:commentID/dislikes", middlewares.PaginationMiddleware(), commentLikesController.ListCommentDislikers)
 	commentLikeRouter.GET("/liked", middlewares.PaginationMiddleware(), commentLikesController.ListLikedCommentsUnderPost)
 	commentLikeRouter.GET("/disliked", middlewares.PaginationMiddleware(), commentLikesController.ListDislikedCommentsUnderPost)
 	commentLikeRouter.GET("/user/:identifier/liked", middlewares.PaginationMiddleware(), commentLikesController.ListLikedCommentsByUserIdentifierForPost)
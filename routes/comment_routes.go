@@ -2,6 +2,7 @@ package routes
 
 import (
 	"github.com/datarohit/gopher-social-backend/controllers"
+	"github.com/datarohit/gopher-social-backend/helpers"
 	"github.com/datarohit/gopher-social-backend/middlewares"
 	"github.com/datarohit/gopher-social-backend/stores"
 	"github.com/gin-gonic/gin"
@@ -14,6 +15,7 @@ import (
 // Parameters:
 //   - router (*gin.RouterGroup): RouterGroup for comment routes under /post/:postID/comment path.
 //   - dbPool (*pgxpool.Pool): Pgx connection pool to interact with the database.
+//   - contentPolicy (*helpers.ContentPolicy): ContentPolicy checking new and updated comments for disallowed words.
 //   - logger (*logrus.Logger): Logrus logger pointer to log messages.
 //
 // Returns:
@@ -26,11 +28,13 @@ import (
 //   - GET /post/:postID/comment/:commentID: Route to get a comment by comment ID and post ID. No authentication required.
 //   - GET /post/:postID/comment/user/me: Route to list all comments of logged in user for a post. Requires authentication.
 //   - GET /post/:postID/comment/user/:identifier: Route to list all comments of a user for a post. No authentication required.
-func CommentRoutes(router *gin.RouterGroup, dbPool *pgxpool.Pool, logger *logrus.Logger) {
+//   - GET /user/:identifier/commented: Route to list the posts a user has commented on. Requires authentication.
+//   - GET /comment/me: Route to list all comments of logged in user across all posts. Requires authentication.
+func CommentRoutes(router *gin.RouterGroup, dbPool *pgxpool.Pool, contentPolicy *helpers.ContentPolicy, logger *logrus.Logger) {
 	commentStore := stores.NewCommentStore(dbPool)
 	postStore := stores.NewPostStore(dbPool)
 	authStore := stores.NewAuthStore(dbPool)
-	commentController := controllers.NewCommentController(commentStore, postStore, authStore, logger)
+	commentController := controllers.NewCommentController(commentStore, postStore, authStore, contentPolicy, logger)
 
 	commentRouter := router.Group("/post/:postID/comment")
 	commentRouter.Use(middlewares.AuthMiddleware(logger))
@@ -40,4 +44,12 @@ func CommentRoutes(router *gin.RouterGroup, dbPool *pgxpool.Pool, logger *logrus
 	commentRouter.GET("/:commentID", commentController.GetComment)
 	commentRouter.GET("/user/me", middlewares.PaginationMiddleware(), commentController.ListMyComments)
 	commentRouter.GET("/user/:identifier", middlewares.PaginationMiddleware(), commentController.ListCommentsByUserIdentifier)
+
+	userRouter := router.Group("/user")
+	userRouter.Use(middlewares.AuthMiddleware(logger))
+	userRouter.GET("/:identifier/commented", middlewares.PaginationMiddleware(), commentController.ListPostsCommentedByUser)
+
+	myCommentRouter := router.Group("/comment")
+	myCommentRouter.Use(middlewares.AuthMiddleware(logger))
+	myCommentRouter.GET("/me", middlewares.PaginationMiddleware(), commentController.ListAllMyComments)
 }
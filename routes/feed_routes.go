@@ -6,6 +6,7 @@ import (
 	"github.com/datarohit/gopher-social-backend/stores"
 	"github.com/gin-gonic/gin"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
 	"github.com/sirupsen/logrus"
 )
 
@@ -14,6 +15,7 @@ import (
 // Parameters:
 //   - router (*gin.RouterGroup): RouterGroup for feed routes under /feed path.
 //   - dbPool (*pgxpool.Pool): Pgx connection pool to interact with the database.
+//   - redisClient (*redis.Client): Redis client for caching home feeds.
 //   - logger (*logrus.Logger): Logrus logger pointer to log messages.
 //
 // Returns:
@@ -22,8 +24,8 @@ import (
 // Routes:
 //   - GET /feed: Route to get latest posts for feed. No authentication required.
 //   - GET /feed/:postID: Route to get a specific post with comments for feed. No authentication required.
-func FeedRoutes(router *gin.RouterGroup, dbPool *pgxpool.Pool, logger *logrus.Logger) {
-	feedStore := stores.NewFeedStore(dbPool)
+func FeedRoutes(router *gin.RouterGroup, dbPool *pgxpool.Pool, redisClient *redis.Client, logger *logrus.Logger) {
+	feedStore := stores.NewFeedStore(dbPool, redisClient)
 	feedController := controllers.NewFeedController(feedStore, logger)
 
 	feedRouter := router.Group("/")
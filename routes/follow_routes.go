@@ -6,6 +6,7 @@ import (
 	"github.com/datarohit/gopher-social-backend/stores"
 	"github.com/gin-gonic/gin"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
 	"github.com/sirupsen/logrus"
 )
 
@@ -14,30 +15,50 @@ import (
 // Parameters:
 //   - router (*gin.RouterGroup): RouterGroup for follow routes under /user path.
 //   - dbPool (*pgxpool.Pool): Pgx connection pool to interact with the database.
+//   - redisClient (*redis.Client): Redis client to cache follow suggestions and home feeds, and to store responses of requests with an Idempotency-Key header.
 //   - logger (*logrus.Logger): Logrus logger pointer to log messages.
 //
 // Returns:
 //   - None
 //
+// POST routes replay their first response when retried with the same Idempotency-Key header.
+//
 // Routes:
 //   - POST /user/follow/:identifier: Route to follow a user. Requires authentication.
 //   - DELETE /user/unfollow/:identifier: Route to unfollow a user. Requires authentication.
+//   - DELETE /user/followers/:identifier: Route to remove a follower of logged in user. Requires authentication.
+//   - GET /user/follow-requests: Route to get pending follow requests of logged in user. Requires authentication.
+//   - POST /user/follow-requests/:requestID/accept: Route to accept a follow request. Requires authentication.
+//   - POST /user/follow-requests/:requestID/reject: Route to reject a follow request. Requires authentication.
 //   - GET /user/followers: Route to get followers of logged in user. Requires authentication.
 //   - GET /user/following: Route to get users being followed by logged in user. Requires authentication.
 //   - GET /user/:identifier/followers: Route to get followers of a user by identifier. Requires authentication.
 //   - GET /user/:identifier/following: Route to get users being followed by user by identifier. Requires authentication.
-func FollowRoutes(router *gin.RouterGroup, dbPool *pgxpool.Pool, logger *logrus.Logger) {
+//   - GET /user/:identifier/mutuals: Route to get users who both follow and are followed by user by identifier. Requires authentication.
+//   - POST /user/follow-status: Route to check whether logged in user follows each of a list of users. Requires authentication.
+//   - GET /user/suggestions: Route to get suggested users to follow for logged in user. Requires authentication.
+func FollowRoutes(router *gin.RouterGroup, dbPool *pgxpool.Pool, redisClient *redis.Client, logger *logrus.Logger) {
 	authStore := stores.NewAuthStore(dbPool)
 	profileStore := stores.NewProfileStore(dbPool)
 	followStore := stores.NewFollowStore(dbPool)
-	followController := controllers.NewFollowController(authStore, profileStore, followStore, logger)
+	notificationStore := stores.NewNotificationStore(dbPool)
+	feedStore := stores.NewFeedStore(dbPool, redisClient)
+	followController := controllers.NewFollowController(authStore, profileStore, followStore, notificationStore, feedStore, redisClient, logger)
 
+	idempotency := middlewares.IdempotencyMiddleware(redisClient, logger)
 	followRouter := router.Group("/user")
 	followRouter.Use(middlewares.AuthMiddleware(logger))
-	followRouter.POST("/follow/:identifier", followController.FollowUser)
+	followRouter.POST("/follow/:identifier", idempotency, followController.FollowUser)
 	followRouter.DELETE("/unfollow/:identifier", followController.UnfollowUser)
+	followRouter.DELETE("/followers/:identifier", followController.RemoveFollower)
+	followRouter.GET("/follow-requests", middlewares.PaginationMiddleware(), followController.ListFollowRequests)
+	followRouter.POST("/follow-requests/:requestID/accept", idempotency, followController.AcceptFollowRequest)
+	followRouter.POST("/follow-requests/:requestID/reject", idempotency, followController.RejectFollowRequest)
 	followRouter.GET("/followers", middlewares.PaginationMiddleware(), followController.GetFollowers)
 	followRouter.GET("/following", middlewares.PaginationMiddleware(), followController.GetFollowing)
 	followRouter.GET("/:identifier/followers", middlewares.PaginationMiddleware(), followController.GetUserFollowers)
 	followRouter.GET("/:identifier/following", middlewares.PaginationMiddleware(), followController.GetUserFollowing)
+	followRouter.GET("/:identifier/mutuals", middlewares.PaginationMiddleware(), followController.ListMutuals)
+	followRouter.POST("/follow-status", followController.GetFollowStatus)
+	followRouter.GET("/suggestions", middlewares.PaginationMiddleware(), followController.SuggestUsers)
 }
@@ -17,6 +17,7 @@ import (
 //   - /health/router (GET): Health check for router.
 //   - /health/redis (GET): Health check for redis.
 //   - /health/postgres (GET): Health check for postgres.
+//   - /health/info (GET): Build info, uptime and dependency health.
 func HealthRoutes(router *gin.RouterGroup) {
 	healthController := controllers.NewHealthController()
 
@@ -24,4 +25,5 @@ func HealthRoutes(router *gin.RouterGroup) {
 	routerHealth.GET("/router", healthController.HealthRouter)
 	routerHealth.GET("/redis", healthController.HealthRedis)
 	routerHealth.GET("/postgres", healthController.HealthPostgres)
+	routerHealth.GET("/info", healthController.HealthInfo)
 }
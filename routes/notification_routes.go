@@ -0,0 +1,35 @@
+package routes
+
+import (
+	"github.com/datarohit/gopher-social-backend/controllers"
+	"github.com/datarohit/gopher-social-backend/middlewares"
+	"github.com/datarohit/gopher-social-backend/stores"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+)
+
+// NotificationRoutes defines routes for notification related operations.
+//
+// Parameters:
+//   - router (*gin.RouterGroup): RouterGroup for notification routes under the API root.
+//   - dbPool (*pgxpool.Pool): Pgx connection pool to interact with the database.
+//   - logger (*logrus.Logger): Logrus logger pointer to log messages.
+//
+// Returns:
+//   - None
+//
+// Routes:
+//   - GET /notifications: Route to get notifications of logged in user. Requires authentication.
+//   - GET /notifications/unread-count: Route to get the unread notifications count of logged in user. Requires authentication.
+//   - POST /notifications/read: Route to mark specific notifications of logged in user as read. Requires authentication.
+func NotificationRoutes(router *gin.RouterGroup, dbPool *pgxpool.Pool, logger *logrus.Logger) {
+	notificationStore := stores.NewNotificationStore(dbPool)
+	notificationController := controllers.NewNotificationController(notificationStore, logger)
+
+	notificationRouter := router.Group("/")
+	notificationRouter.Use(middlewares.AuthMiddleware(logger))
+	notificationRouter.GET("/notifications", middlewares.PaginationMiddleware(), notificationController.ListNotifications)
+	notificationRouter.GET("/notifications/unread-count", notificationController.GetUnreadCount)
+	notificationRouter.POST("/notifications/read", notificationController.MarkRead)
+}
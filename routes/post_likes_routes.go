@@ -6,6 +6,7 @@ import (
 	"github.com/datarohit/gopher-social-backend/stores"
 	"github.com/gin-gonic/gin"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
 	"github.com/sirupsen/logrus"
 )
 
@@ -14,32 +15,41 @@ import (
 // Parameters:
 //   - router (*gin.RouterGroup): RouterGroup for post like routes under /post path.
 //   - dbPool (*pgxpool.Pool): Pgx connection pool to interact with the database.
+//   - redisClient (*redis.Client): Redis client to store responses of requests with an Idempotency-Key header.
 //   - logger (*logrus.Logger): Logrus logger pointer to log messages.
 //
 // Returns:
 //   - None
 //
+// POST routes replay their first response when retried with the same Idempotency-Key header.
+//
 // Routes:
 //   - POST /post/:postID/like: Route to like a post. Requires authentication.
 //   - DELETE /post/:postID/unlike: Route to unlike a post. Requires authentication.
 //   - POST /post/:postID/dislike: Route to dislike a post. Requires authentication.
 //   - DELETE /post/:postID/undislike: Route to undislike a post. Requires authentication.
+//   - GET /post/:postID/reactions/timeline: Route to get hourly or daily like and dislike counts of a post. Requires authentication and author or admin role.
+//   - GET /post/reactions: Route to get all likes and dislikes by logged-in user, most recent first. Requires authentication.
 //   - GET /post/liked: Route to get all liked posts by logged-in user. Requires authentication.
 //   - GET /post/disliked: Route to get all disliked posts by logged-in user. Requires authentication.
 //   - GET /post/user/:identifier/liked: Route to get all liked posts of a user by identifier. Requires authentication.
 //   - GET /post/user/:identifier/disliked: Route to get all disliked posts of a user by identifier. Requires authentication.
-func PostLikeRoutes(router *gin.RouterGroup, dbPool *pgxpool.Pool, logger *logrus.Logger) {
+func PostLikeRoutes(router *gin.RouterGroup, dbPool *pgxpool.Pool, redisClient *redis.Client, logger *logrus.Logger) {
 	authStore := stores.NewAuthStore(dbPool)
 	postStore := stores.NewPostStore(dbPool)
 	postLikesStore := stores.NewPostLikeStore(dbPool)
-	postLikesController := controllers.NewPostLikesController(postLikesStore, postStore, authStore, logger)
+	blockStore := stores.NewBlockStore(dbPool)
+	postLikesController := controllers.NewPostLikesController(postLikesStore, postStore, blockStore, authStore, logger)
 
+	idempotency := middlewares.IdempotencyMiddleware(redisClient, logger)
 	postLikeRouter := router.Group("/post")
 	postLikeRouter.Use(middlewares.AuthMiddleware(logger))
-	postLikeRouter.POST("/:postID/like", postLikesController.LikePost)
+	postLikeRouter.POST("/:postID/like", idempotency, postLikesController.LikePost)
 	postLikeRouter.DELETE("/:postID/unlike", postLikesController.UnlikePost)
-	postLikeRouter.POST("/:postID/dislike", postLikesController.DislikePost)
+	postLikeRouter.POST("/:postID/dislike", idempotency, postLikesController.DislikePost)
 	postLikeRouter.DELETE("/:postID/undislike", postLikesController.UndislikePost)
+	postLikeRouter.GET("/:postID/reactions/timeline", postLikesController.GetPostReactionTimeline)
+	postLikeRouter.GET("/reactions", middlewares.PaginationMiddleware(), postLikesController.ListPostReactions)
 	postLikeRouter.GET("/liked", middlewares.PaginationMiddleware(), postLikesController.ListLikedPosts)
 	postLikeRouter.GET("/disliked", middlewares.PaginationMiddleware(), postLikesController.ListDislikedPosts)
 	postLikeRouter.GET("/user/:identifier/liked", middlewares.PaginationMiddleware(), postLikesController.ListLikedPostsByUserIdentifier)
@@ -2,10 +2,12 @@ package routes
 
 import (
 	"github.com/datarohit/gopher-social-backend/controllers"
+	"github.com/datarohit/gopher-social-backend/helpers"
 	"github.com/datarohit/gopher-social-backend/middlewares"
 	"github.com/datarohit/gopher-social-backend/stores"
 	"github.com/gin-gonic/gin"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
 	"github.com/sirupsen/logrus"
 )
 
@@ -14,6 +16,8 @@ import (
 // Parameters:
 //   - router (*gin.RouterGroup): RouterGroup for post routes under /posts path.
 //   - dbPool (*pgxpool.Pool): Pgx connection pool to interact with the database.
+//   - redisClient (*redis.Client): Redis client for caching home feeds.
+//   - contentPolicy (*helpers.ContentPolicy): ContentPolicy checking new and updated posts for disallowed words.
 //   - logger (*logrus.Logger): Logrus logger pointer to log messages.
 //
 // Returns:
@@ -23,20 +27,33 @@ import (
 //   - POST /post/create: Route to create a new post. Requires authentication.
 //   - PUT /post/:postID: Route to update an existing post. Requires authentication and author role.
 //   - DELETE /post/:postID: Route to delete an existing post. Requires authentication and author role.
+//   - POST /post/:postID/pin: Route to pin a post to the top of the author's profile. Requires authentication and author role.
+//   - DELETE /post/:postID/pin: Route to unpin a post from the author's profile. Requires authentication and author role.
 //   - GET /post/:postID: Route to get a post by ID. Requires authentication.
+//   - GET /post/:postID/full: Route to get a post with a page of its comments. Requires authentication.
+//   - GET /post/:postID/export: Route to download a post and its comments as JSON or Markdown. Requires authentication and author or admin role.
+//   - GET /post/feed: Route to get the home feed of posts by followed users. Requires authentication.
 //   - GET /post/me: Route to list posts created by the logged-in user. Requires authentication.
 //   - GET /post/user/:identifier: Route to list posts created by a user identifier. Requires authentication.
-func PostRoutes(router *gin.RouterGroup, dbPool *pgxpool.Pool, logger *logrus.Logger) {
+func PostRoutes(router *gin.RouterGroup, dbPool *pgxpool.Pool, redisClient *redis.Client, contentPolicy *helpers.ContentPolicy, logger *logrus.Logger) {
 	authStore := stores.NewAuthStore(dbPool)
 	postStore := stores.NewPostStore(dbPool)
-	postController := controllers.NewPostController(postStore, authStore, logger)
+	followStore := stores.NewFollowStore(dbPool)
+	feedStore := stores.NewFeedStore(dbPool, redisClient)
+	notificationStore := stores.NewNotificationStore(dbPool)
+	postController := controllers.NewPostController(postStore, authStore, followStore, feedStore, notificationStore, contentPolicy, logger)
 
 	postRouter := router.Group("/post")
 	postRouter.Use(middlewares.AuthMiddleware(logger))
 	postRouter.POST("/create", postController.CreatePost)
 	postRouter.PUT("/:postID", postController.UpdatePost)
 	postRouter.DELETE("/:postID", postController.DeletePost)
+	postRouter.POST("/:postID/pin", postController.PinPost)
+	postRouter.DELETE("/:postID/pin", postController.UnpinPost)
 	postRouter.GET("/:postID", postController.GetPost)
+	postRouter.GET("/:postID/full", postController.GetPostWithComments)
+	postRouter.GET("/:postID/export", postController.ExportPost)
+	postRouter.GET("/feed", middlewares.PaginationMiddleware(), postController.ListHomeFeed)
 	postRouter.GET("/me", middlewares.PaginationMiddleware(), postController.ListMyPosts)
 	postRouter.GET("/user/:identifier", middlewares.PaginationMiddleware(), postController.ListPostsByUserIdentifier)
 }
@@ -6,6 +6,7 @@ import (
 	"github.com/datarohit/gopher-social-backend/stores"
 	"github.com/gin-gonic/gin"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
 	"github.com/sirupsen/logrus"
 )
 
@@ -14,6 +15,7 @@ import (
 // Parameters:
 //   - router (*gin.RouterGroup): RouterGroup for profile routes under /profile path.
 //   - dbPool (*pgxpool.Pool): Pgx connection pool to interact with the database.
+//   - redisClient (*redis.Client): Redis client used by the stats store.
 //   - logger (*logrus.Logger): Logrus logger pointer to log messages.
 //
 // Returns:
@@ -23,13 +25,31 @@ import (
 //   - PUT /profile/update: Route to update user profile. Requires authentication.
 //   - GET /profile/me: Route to get logged-in user profile. Requires authentication.
 //   - GET /profile/:identifier: Route to get user profile by identifier. Requires authentication.
-func ProfileRoutes(router *gin.RouterGroup, dbPool *pgxpool.Pool, logger *logrus.Logger) {
+//   - GET /user/blocks: Route to list the users blocked by the logged-in user. Requires authentication.
+//   - POST /user/blocks/:identifier: Route to block a user by identifier. Requires authentication.
+//   - DELETE /user/blocks/:identifier: Route to unblock a user by identifier. Requires authentication.
+//   - POST /user/batch: Route to get public summaries of up to 100 users by username or user ID. Requires authentication.
+//   - GET /user/:identifier/stats: Route to get aggregate stats of a user by identifier. Requires authentication.
+//   - GET /user/:identifier/activity: Route to get daily or weekly post and comment counts of a user. Requires authentication.
+func ProfileRoutes(router *gin.RouterGroup, dbPool *pgxpool.Pool, redisClient *redis.Client, logger *logrus.Logger) {
 	profileStore := stores.NewProfileStore(dbPool)
-	profileController := controllers.NewProfileController(profileStore, logger)
+	authStore := stores.NewAuthStore(dbPool)
+	statsStore := stores.NewStatsStore(dbPool, redisClient)
+	blockStore := stores.NewBlockStore(dbPool)
+	profileController := controllers.NewProfileController(profileStore, authStore, statsStore, blockStore, logger)
 
 	profileRouter := router.Group("/profile")
 	profileRouter.Use(middlewares.AuthMiddleware(logger))
 	profileRouter.PUT("/update", profileController.UpdateProfile)
 	profileRouter.GET("/me", profileController.GetLoggedInUserProfile)
 	profileRouter.GET("/:identifier", profileController.GetUserProfile)
+
+	userRouter := router.Group("/user")
+	userRouter.Use(middlewares.AuthMiddleware(logger))
+	userRouter.GET("/blocks", middlewares.PaginationMiddleware(), profileController.ListBlockedUsers)
+	userRouter.POST("/blocks/:identifier", profileController.BlockUser)
+	userRouter.DELETE("/blocks/:identifier", profileController.UnblockUser)
+	userRouter.POST("/batch", profileController.GetUsersBatch)
+	userRouter.GET("/:identifier/stats", profileController.GetUserStats)
+	userRouter.GET("/:identifier/activity", profileController.GetUserActivity)
 }
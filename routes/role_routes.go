@@ -0,0 +1,34 @@
+package routes
+
+import (
+	"github.com/datarohit/gopher-social-backend/controllers"
+	"github.com/datarohit/gopher-social-backend/helpers"
+	"github.com/datarohit/gopher-social-backend/middlewares"
+	"github.com/datarohit/gopher-social-backend/stores"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+)
+
+// RoleRoutes defines routes for the role catalog.
+//
+// Parameters:
+//   - router (*gin.RouterGroup): RouterGroup for role routes under the API root.
+//   - dbPool (*pgxpool.Pool): Pgx connection pool to interact with the database.
+//   - logger (*logrus.Logger): Logrus logger pointer to log messages.
+//
+// Returns:
+//   - None
+//
+// Routes:
+//   - GET /roles: Route to list the level and description of every role. Requires authentication if ROLES_REQUIRE_AUTH is set.
+func RoleRoutes(router *gin.RouterGroup, dbPool *pgxpool.Pool, logger *logrus.Logger) {
+	authStore := stores.NewAuthStore(dbPool)
+	roleController := controllers.NewRoleController(authStore, logger)
+
+	roleHandlers := []gin.HandlerFunc{roleController.ListRoles}
+	if helpers.GetEnvAsBool("ROLES_REQUIRE_AUTH", false) {
+		roleHandlers = append([]gin.HandlerFunc{middlewares.AuthMiddleware(logger)}, roleHandlers...)
+	}
+	router.GET("/roles", roleHandlers...)
+}
@@ -4,10 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
+	"strconv"
 	"time"
 
 	"github.com/datarohit/gopher-social-backend/models"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -52,60 +55,120 @@ var ErrAdminCannotBanAdmin = errors.New("admin cannot ban another admin")
 // ErrAdminCannotUnbanAdmin is returned when an admin tries to unban another admin.
 var ErrAdminCannotUnbanAdmin = errors.New("admin cannot unban another admin")
 
+// ErrAdminCannotLogoutAdmin is returned when an admin tries to force logout another admin.
+var ErrAdminCannotLogoutAdmin = errors.New("admin cannot force logout another admin")
+
+// ErrAdminCannotImpersonateAdmin is returned when an admin tries to impersonate another admin.
+var ErrAdminCannotImpersonateAdmin = errors.New("admin cannot impersonate another admin")
+
 // ErrAdminOnlyOperation is returned when a moderator tries to perform an admin only operation.
 var ErrAdminOnlyOperation = errors.New("this operation is restricted to admins only")
 
+// ErrInvalidTimeoutSort is returned when an unknown timed out users sort order is requested.
+var ErrInvalidTimeoutSort = errors.New("invalid sort value, must be one of expiry_asc, expiry_desc, recent")
+
+// ErrCannotTransferPostToBannedUser is returned when transferring a post to a banned user.
+var ErrCannotTransferPostToBannedUser = errors.New("cannot transfer post to a banned user")
+
+// ErrCannotTransferPostToInactiveUser is returned when transferring a post to a user whose account is not active.
+var ErrCannotTransferPostToInactiveUser = errors.New("cannot transfer post to an inactive user")
+
+// ErrPostAlreadyOwnedByUser is returned when transferring a post to the user who already authors it.
+var ErrPostAlreadyOwnedByUser = errors.New("post is already authored by this user")
+
+// ErrModerationLogNotFound is returned when a moderation log entry is not found.
+var ErrModerationLogNotFound = errors.New("moderation log entry not found")
+
+// ErrModerationActionNotUndoable is returned when undoing a moderation action other than a ban or a timeout.
+var ErrModerationActionNotUndoable = errors.New("only bans and timeouts can be undone")
+
+// ErrCannotUndoHigherRoleAction is returned when a moderator tries to undo an action taken by a user with a higher role.
+var ErrCannotUndoHigherRoleAction = errors.New("actions can only be undone by their author or a user with the same or a higher role")
+
+// ErrModerationActionAlreadyUndone is returned when undoing a moderation action that was already undone.
+var ErrModerationActionAlreadyUndone = errors.New("moderation action was already undone")
+
+// ErrModerationActionSuperseded is returned when undoing a moderation action that a later ban, unban, timeout or timeout removal replaced.
+var ErrModerationActionSuperseded = errors.New("moderation action was superseded by a later action")
+
+// ErrUndoWindowExpired is returned when undoing a moderation action after the undo window has passed.
+var ErrUndoWindowExpired = errors.New("undo window for this moderation action has passed")
+
+// Supported sort orders for the timed out users listing.
+const (
+	TimeoutSortExpiryAsc  = "expiry_asc"
+	TimeoutSortExpiryDesc = "expiry_desc"
+	TimeoutSortRecent     = "recent"
+)
+
+// TimeoutSorts lists the supported timed out users sort orders.
+var TimeoutSorts = []string{TimeoutSortExpiryAsc, TimeoutSortExpiryDesc, TimeoutSortRecent}
+
+// timeoutSortOrders maps the supported sort orders to whitelisted ORDER BY clauses.
+// undoableModerationActions maps the moderation actions that can be undone to the actions which supersede them.
+var undoableModerationActions = map[string][]string{
+	ModerationActionBan:     {ModerationActionBan, ModerationActionUnban},
+	ModerationActionTimeout: {ModerationActionTimeout, ModerationActionRemoveTimeout},
+}
+
+// moderationUndoWindow is how long after a ban or timeout it can be undone.
+var moderationUndoWindow = moderationUndoWindowFromEnv()
+
+// moderationUndoWindowFromEnv reads MODERATION_UNDO_WINDOW_MINUTES, defaulting to 15 minutes.
+func moderationUndoWindowFromEnv() time.Duration {
+	minutes, err := strconv.Atoi(os.Getenv("MODERATION_UNDO_WINDOW_MINUTES"))
+	if err != nil || minutes <= 0 {
+		return 15 * time.Minute
+	}
+	return time.Duration(minutes) * time.Minute
+}
+
+var timeoutSortOrders = map[string]string{
+	TimeoutSortExpiryAsc:  "u.timeout_until ASC",
+	TimeoutSortExpiryDesc: "u.timeout_until DESC",
+	TimeoutSortRecent:     "u.updated_at DESC, u.timeout_until ASC",
+}
+
 
 // TimeoutUser applies a timeout to a user until the specified time.
 //
 // Parameters:
 //   - ctx (context.Context): Context for the database operation.
+//   - actorID (uuid.UUID): ID of the moderator or admin applying the timeout.
 //   - targetUserID (uuid.UUID): ID of the user to timeout.
 //   - timeoutDuration (time.Duration): Duration of the timeout.
 //
 // Returns:
 //   - error: An error if the operation fails.
-func (as *ActionStore) TimeoutUser(ctx context.Context, targetUserID uuid.UUID, timeoutDuration time.Duration) error {
+func (as *ActionStore) TimeoutUser(ctx context.Context, actorID uuid.UUID, targetUserID uuid.UUID, timeoutDuration time.Duration) error {
 	expiryTime := time.Now().Add(timeoutDuration)
 
-	commandTag, err := as.dbPool.Exec(ctx, `
+	details := fmt.Sprintf("timed out for %s", timeoutDuration)
+	return as.updateUserWithModerationLog(ctx, actorID, targetUserID, ModerationActionTimeout, details, `
 		UPDATE users
 		SET timeout_until = $2
 		WHERE id = $1
 	`, targetUserID, expiryTime)
-	if err != nil {
-		return fmt.Errorf("failed to timeout user: %w", err)
-	}
-	if commandTag.RowsAffected() == 0 {
-		return ErrUserNotFound
-	}
-	return nil
 }
 
 // RemoveTimeoutUser removes the timeout from a user.
 //
 // Parameters:
 //   - ctx (context.Context): Context for the database operation.
+//   - actorID (uuid.UUID): ID of the moderator or admin removing the timeout.
 //   - targetUserID (uuid.UUID): ID of the user to remove timeout from.
 //
 // Returns:
 //   - error: An error if the operation fails.
-func (as *ActionStore) RemoveTimeoutUser(ctx context.Context, targetUserID uuid.UUID) error {
-	commandTag, err := as.dbPool.Exec(ctx, `
+func (as *ActionStore) RemoveTimeoutUser(ctx context.Context, actorID uuid.UUID, targetUserID uuid.UUID) error {
+	return as.updateUserWithModerationLog(ctx, actorID, targetUserID, ModerationActionRemoveTimeout, "timeout removed", `
 		UPDATE users
 		SET timeout_until = NULL
 		WHERE id = $1
 	`, targetUserID)
-	if err != nil {
-		return fmt.Errorf("failed to remove timeout from user: %w", err)
-	}
-	if commandTag.RowsAffected() == 0 {
-		return ErrUserNotFound
-	}
-	return nil
 }
 
-// ListTimedOutUsers retrieves a list of users who are currently timed out.
+// ListTimedOutUsers retrieves a list of users who are currently timed out, soonest expiry first.
 //
 // Parameters:
 //   - ctx (context.Context): Context for the database operation.
@@ -116,19 +179,42 @@ func (as *ActionStore) RemoveTimeoutUser(ctx context.Context, targetUserID uuid.
 //   - []*models.User: A slice of User pointers, or nil if no users are timed out.
 //   - error: An error if the database query fails.
 func (as *ActionStore) ListTimedOutUsers(ctx context.Context, pageNumber int, pageSize int) ([]*models.User, error) {
+	return as.ListTimedOutUsersSorted(ctx, TimeoutSortExpiryAsc, nil, pageNumber, pageSize)
+}
+
+// ListTimedOutUsersSorted retrieves a list of users who are currently timed out in the requested order,
+// optionally limited to timeouts that expire before a given time. Each user includes the remaining timeout in seconds.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the database operation.
+//   - sort (string): One of TimeoutSortExpiryAsc, TimeoutSortExpiryDesc or TimeoutSortRecent.
+//   - expiresBefore (*time.Time): Only timeouts ending at or before this time are returned, nil for no filter.
+//   - pageNumber (int): Page number for pagination.
+//   - pageSize (int): Page size for pagination.
+//
+// Returns:
+//   - []*models.User: A slice of User pointers, or nil if no users are timed out.
+//   - error: ErrInvalidTimeoutSort if the sort value is unknown, or an error if the database query fails.
+func (as *ActionStore) ListTimedOutUsersSorted(ctx context.Context, sort string, expiresBefore *time.Time, pageNumber int, pageSize int) ([]*models.User, error) {
+	orderBy, ok := timeoutSortOrders[sort]
+	if !ok {
+		return nil, ErrInvalidTimeoutSort
+	}
+
 	offset := (pageNumber - 1) * pageSize
 	rows, err := as.dbPool.Query(ctx, `
 		SELECT
 			u.id, u.username, u.email, u.timeout_until, u.banned, u.is_active, u.created_at, u.updated_at,
 			r.id as role_id, r.level, r.description,
-			(SELECT COUNT(*) FROM follows WHERE followee_id = u.id) as followers_count,
-			(SELECT COUNT(*) FROM follows WHERE follower_id = u.id) as following_count
+			u.followers_count,
+			u.following_count,
+			CEIL(EXTRACT(EPOCH FROM (u.timeout_until - NOW())))::BIGINT as timeout_remaining_seconds
 		FROM users u
 		INNER JOIN roles r ON u.role_id = r.id
-		WHERE u.timeout_until > NOW()
-		ORDER BY u.timeout_until ASC
-		LIMIT $1 OFFSET $2
-	`, pageSize, offset)
+		WHERE u.timeout_until > NOW() AND ($1::TIMESTAMPTZ IS NULL OR u.timeout_until <= $1)
+		ORDER BY `+orderBy+`
+		LIMIT $2 OFFSET $3
+	`, expiresBefore, pageSize, offset)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list timed out users: %w", err)
 	}
@@ -138,16 +224,19 @@ func (as *ActionStore) ListTimedOutUsers(ctx context.Context, pageNumber int, pa
 	for rows.Next() {
 		timedOutUser := &models.User{Role: &models.Role{}}
 		var timeoutUntil time.Time
+		var timeoutRemaining int64
 
 		err := rows.Scan(
 			&timedOutUser.ID, &timedOutUser.Username, &timedOutUser.Email, &timeoutUntil, &timedOutUser.Banned, &timedOutUser.IsActive, &timedOutUser.CreatedAt, &timedOutUser.UpdatedAt,
 			&timedOutUser.Role.ID, &timedOutUser.Role.Level, &timedOutUser.Role.Description,
 			&timedOutUser.Followers, &timedOutUser.Following,
+			&timeoutRemaining,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan timed out user row: %w", err)
 		}
 		timedOutUser.TimeoutUntil = &timeoutUntil
+		timedOutUser.TimeoutRemainingSeconds = &timeoutRemaining
 		timedOutUsers = append(timedOutUsers, timedOutUser)
 	}
 
@@ -162,87 +251,121 @@ func (as *ActionStore) ListTimedOutUsers(ctx context.Context, pageNumber int, pa
 //
 // Parameters:
 //   - ctx (context.Context): Context for the database operation.
+//   - actorID (uuid.UUID): ID of the moderator or admin deactivating the user.
 //   - targetUserID (uuid.UUID): ID of the user to deactivate.
 //
 // Returns:
 //   - error: An error if the operation fails.
-func (as *ActionStore) DeactivateUser(ctx context.Context, targetUserID uuid.UUID) error {
-	commandTag, err := as.dbPool.Exec(ctx, `
+func (as *ActionStore) DeactivateUser(ctx context.Context, actorID uuid.UUID, targetUserID uuid.UUID) error {
+	return as.updateUserWithModerationLog(ctx, actorID, targetUserID, ModerationActionDeactivate, "account deactivated", `
 		UPDATE users
 		SET is_active = FALSE
 		WHERE id = $1
 	`, targetUserID)
-	if err != nil {
-		return fmt.Errorf("failed to deactivate user: %w", err)
-	}
-	if commandTag.RowsAffected() == 0 {
-		return ErrUserNotFound
-	}
-	return nil
 }
 
 // ActivateUser activates a user by setting their is_active status to true.
 //
 // Parameters:
 //   - ctx (context.Context): Context for the database operation.
+//   - actorID (uuid.UUID): ID of the moderator or admin activating the user.
 //   - targetUserID (uuid.UUID): ID of the user to activate.
 //
 // Returns:
 //   - error: An error if the operation fails.
-func (as *ActionStore) ActivateUser(ctx context.Context, targetUserID uuid.UUID) error {
-	commandTag, err := as.dbPool.Exec(ctx, `
+func (as *ActionStore) ActivateUser(ctx context.Context, actorID uuid.UUID, targetUserID uuid.UUID) error {
+	return as.updateUserWithModerationLog(ctx, actorID, targetUserID, ModerationActionActivate, "account activated", `
 		UPDATE users
-		SET is_active = TRUE
+		SET is_active = TRUE, activated_at = COALESCE(activated_at, now())
 		WHERE id = $1
 	`, targetUserID)
-	if err != nil {
-		return fmt.Errorf("failed to activate user: %w", err)
-	}
-	if commandTag.RowsAffected() == 0 {
-		return ErrUserNotFound
-	}
-	return nil
 }
 
-// BanUser bans a user, deactivates them, deletes their posts, and sets the banned status to true.
+// BanUser bans a user, deactivates them, and removes their footprint from the platform.
+// In a single transaction it deletes their posts, comments, post and comment likes,
+// follow edges and follow requests in both directions. Any failure rolls back the whole ban.
 //
 // Parameters:
 //   - ctx (context.Context): Context for the database operation.
+//   - actorID (uuid.UUID): ID of the admin banning the user.
 //   - targetUserID (uuid.UUID): ID of the user to ban.
 //
 // Returns:
-//   - error: An error if the operation fails.
-func (as *ActionStore) BanUser(ctx context.Context, targetUserID uuid.UUID) error {
-	tx, err := as.dbPool.Begin(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to begin transaction: %w", err)
-	}
-	defer tx.Rollback(ctx)
+//   - error: ErrUserNotFound if the user does not exist, or an error if the operation fails.
+func (as *ActionStore) BanUser(ctx context.Context, actorID uuid.UUID, targetUserID uuid.UUID) error {
+	err := WithTx(ctx, as.dbPool, func(tx pgx.Tx) error {
+		// Deactivate User and set banned to true
+		commandTag, err := tx.Exec(ctx, `
+			UPDATE users
+			SET is_active = FALSE, banned = TRUE
+			WHERE id = $1
+		`, targetUserID)
+		if err != nil {
+			return fmt.Errorf("failed to deactivate user: %w", err)
+		}
+		if commandTag.RowsAffected() == 0 {
+			return ErrUserNotFound
+		}
 
-	// Deactivate User and set banned to true
-	_, err = tx.Exec(ctx, `
-		UPDATE users
-		SET is_active = FALSE, banned = TRUE
-		WHERE id = $1
-	`, targetUserID)
-	if err != nil {
-		return fmt.Errorf("failed to deactivate user: %w", err)
-	}
+		// Delete User's Likes and Dislikes
+		_, err = tx.Exec(ctx, `
+			DELETE FROM post_likes
+			WHERE user_id = $1
+		`, targetUserID)
+		if err != nil {
+			return fmt.Errorf("failed to delete user's post likes: %w", err)
+		}
 
-	// Delete User's Posts
-	_, err = tx.Exec(ctx, `
-		DELETE FROM posts
-		WHERE author_id = $1
-	`, targetUserID)
-	if err != nil {
-		return fmt.Errorf("failed to delete user's posts: %w", err)
-	}
+		_, err = tx.Exec(ctx, `
+			DELETE FROM comment_likes
+			WHERE user_id = $1
+		`, targetUserID)
+		if err != nil {
+			return fmt.Errorf("failed to delete user's comment likes: %w", err)
+		}
+
+		// Delete User's Comments
+		_, err = tx.Exec(ctx, `
+			DELETE FROM comments
+			WHERE author_id = $1
+		`, targetUserID)
+		if err != nil {
+			return fmt.Errorf("failed to delete user's comments: %w", err)
+		}
 
-	err = tx.Commit(ctx)
+		// Delete User's Posts
+		_, err = tx.Exec(ctx, `
+			DELETE FROM posts
+			WHERE author_id = $1
+		`, targetUserID)
+		if err != nil {
+			return fmt.Errorf("failed to delete user's posts: %w", err)
+		}
+
+		// Delete User's Follow Edges and Follow Requests in Both Directions
+		_, err = tx.Exec(ctx, `
+			DELETE FROM follows
+			WHERE follower_id = $1 OR followee_id = $1
+		`, targetUserID)
+		if err != nil {
+			return fmt.Errorf("failed to delete user's follows: %w", err)
+		}
+
+		_, err = tx.Exec(ctx, `
+			DELETE FROM follow_requests
+			WHERE requester_id = $1 OR target_id = $1
+		`, targetUserID)
+		if err != nil {
+			return fmt.Errorf("failed to delete user's follow requests: %w", err)
+		}
+
+		return recordModerationAction(ctx, tx, actorID, targetUserID, ModerationActionBan, "account banned")
+	})
 	if err != nil {
-		return fmt.Errorf("failed to commit transaction: %w", err)
+		return err
 	}
 
+	invalidateCachedUser(ctx, targetUserID)
 	return nil
 }
 
@@ -250,22 +373,39 @@ func (as *ActionStore) BanUser(ctx context.Context, targetUserID uuid.UUID) erro
 //
 // Parameters:
 //   - ctx (context.Context): Context for the database operation.
+//   - actorID (uuid.UUID): ID of the admin unbanning the user.
 //   - targetUserID (uuid.UUID): ID of the user to unban.
 //
 // Returns:
 //   - error: An error if the operation fails.
-func (as *ActionStore) UnbanUser(ctx context.Context, targetUserID uuid.UUID) error {
-	commandTag, err := as.dbPool.Exec(ctx, `
+func (as *ActionStore) UnbanUser(ctx context.Context, actorID uuid.UUID, targetUserID uuid.UUID) error {
+	return as.updateUserWithModerationLog(ctx, actorID, targetUserID, ModerationActionUnban, "account unbanned", `
 		UPDATE users
 		SET banned = FALSE
 		WHERE id = $1
 	`, targetUserID)
+}
+
+// updateUserWithModerationLog runs a single-row update on a user and records it in the moderation audit log,
+// in one transaction. The update must take the target user ID as $1.
+// It returns ErrUserNotFound if the update affected no rows. The user is dropped from the user cache once committed.
+func (as *ActionStore) updateUserWithModerationLog(ctx context.Context, actorID uuid.UUID, targetUserID uuid.UUID, action string, details string, query string, args ...interface{}) error {
+	err := WithTx(ctx, as.dbPool, func(tx pgx.Tx) error {
+		commandTag, err := tx.Exec(ctx, query, args...)
+		if err != nil {
+			return fmt.Errorf("failed to apply %s to user: %w", action, err)
+		}
+		if commandTag.RowsAffected() == 0 {
+			return ErrUserNotFound
+		}
+
+		return recordModerationAction(ctx, tx, actorID, targetUserID, action, details)
+	})
 	if err != nil {
-		return fmt.Errorf("failed to unban user: %w", err)
-	}
-	if commandTag.RowsAffected() == 0 {
-		return ErrUserNotFound
+		return err
 	}
+
+	invalidateCachedUser(ctx, targetUserID)
 	return nil
 }
 
@@ -312,3 +452,211 @@ func (as *ActionStore) DeletePostByPostID(ctx context.Context, postID uuid.UUID)
 	}
 	return nil
 }
+
+// TransferPostAuthor reassigns a post to another author and records the transfer in the moderation audit log
+// against the previous author, in one transaction. The post is unpinned from the previous author's profile.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the database operation.
+//   - actorID (uuid.UUID): ID of the admin transferring the post.
+//   - postID (uuid.UUID): ID of the post to transfer.
+//   - newAuthorID (uuid.UUID): ID of the user who becomes the author of the post.
+//
+// Returns:
+//   - error: ErrPostNotFound if the post does not exist, ErrUserNotFound if the new author does not exist,
+//     ErrCannotTransferPostToBannedUser or ErrCannotTransferPostToInactiveUser if the new author is banned or inactive,
+//     ErrPostAlreadyOwnedByUser if the new author already authors the post, or an error if the operation fails.
+func (as *ActionStore) TransferPostAuthor(ctx context.Context, actorID uuid.UUID, postID uuid.UUID, newAuthorID uuid.UUID) error {
+	return WithTx(ctx, as.dbPool, func(tx pgx.Tx) error {
+		var previousAuthorID uuid.UUID
+		err := tx.QueryRow(ctx, `
+			SELECT author_id FROM posts
+			WHERE id = $1
+			FOR UPDATE
+		`, postID).Scan(&previousAuthorID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ErrPostNotFound
+			}
+			return fmt.Errorf("failed to get post author: %w", err)
+		}
+
+		var banned, isActive bool
+		err = tx.QueryRow(ctx, `
+			SELECT banned, is_active FROM users
+			WHERE id = $1
+		`, newAuthorID).Scan(&banned, &isActive)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ErrUserNotFound
+			}
+			return fmt.Errorf("failed to get new author: %w", err)
+		}
+		if banned {
+			return ErrCannotTransferPostToBannedUser
+		}
+		if !isActive {
+			return ErrCannotTransferPostToInactiveUser
+		}
+		if previousAuthorID == newAuthorID {
+			return ErrPostAlreadyOwnedByUser
+		}
+
+		_, err = tx.Exec(ctx, `
+			UPDATE posts
+			SET author_id = $2
+			WHERE id = $1
+		`, postID, newAuthorID)
+		if err != nil {
+			return fmt.Errorf("failed to update post author: %w", err)
+		}
+
+		_, err = tx.Exec(ctx, `
+			UPDATE profiles
+			SET pinned_post_id = NULL
+			WHERE pinned_post_id = $1
+		`, postID)
+		if err != nil {
+			return fmt.Errorf("failed to unpin transferred post: %w", err)
+		}
+
+		details := fmt.Sprintf("post %s transferred to user %s", postID, newAuthorID)
+		return recordModerationAction(ctx, tx, actorID, previousAuthorID, ModerationActionTransferPost, details)
+	})
+}
+
+// UndoModerationAction reverts a ban or timeout recorded in the moderation audit log, within MODERATION_UNDO_WINDOW_MINUTES
+// of it being taken. An undone ban unbans the user and reactivates them if they had ever activated their account, but the
+// posts, comments, likes and follows removed by the ban are not restored. An undone timeout is removed. The log entry is
+// marked as undone and the undo is recorded in the audit log, in one transaction.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the database operation.
+//   - actorID (uuid.UUID): ID of the moderator or admin undoing the action.
+//   - actorRoleLevel (int): Role level of the moderator or admin undoing the action.
+//   - logID (uuid.UUID): ID of the moderation log entry to undo.
+//
+// Returns:
+//   - *models.ModerationLog: The undone moderation log entry.
+//   - error: ErrModerationLogNotFound, ErrModerationActionNotUndoable, ErrCannotUndoHigherRoleAction,
+//     ErrModerationActionAlreadyUndone, ErrUndoWindowExpired or ErrModerationActionSuperseded if the action cannot be undone,
+//     or an error if the operation fails.
+func (as *ActionStore) UndoModerationAction(ctx context.Context, actorID uuid.UUID, actorRoleLevel int, logID uuid.UUID) (*models.ModerationLog, error) {
+	var undone models.ModerationLog
+	err := WithTx(ctx, as.dbPool, func(tx pgx.Tx) error {
+		var targetUserID *uuid.UUID
+		var originalActorID *uuid.UUID
+		var originalActorRoleLevel *int
+		var expired bool
+		err := tx.QueryRow(ctx, `
+			SELECT
+				ml.id, ml.target_user_id, ml.action, ml.details, ml.created_at, ml.undone_at,
+				ml.actor_id, r.level,
+				ml.created_at < now() - $2 * interval '1 second'
+			FROM moderation_logs ml
+			LEFT JOIN users u ON ml.actor_id = u.id
+			LEFT JOIN roles r ON u.role_id = r.id
+			WHERE ml.id = $1
+			FOR UPDATE OF ml
+		`, logID, moderationUndoWindow.Seconds()).Scan(
+			&undone.ID, &targetUserID, &undone.Action, &undone.Details, &undone.CreatedAt, &undone.UndoneAt,
+			&originalActorID, &originalActorRoleLevel,
+			&expired,
+		)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ErrModerationLogNotFound
+			}
+			return fmt.Errorf("failed to get moderation log entry: %w", err)
+		}
+
+		supersedingActions, undoable := undoableModerationActions[undone.Action]
+		if !undoable || targetUserID == nil {
+			return ErrModerationActionNotUndoable
+		}
+		undone.TargetUserID = *targetUserID
+
+		// Actions of deleted moderators can only be undone by admins.
+		if originalActorID == nil || *originalActorID != actorID {
+			requiredRoleLevel := adminRoleLevel
+			if originalActorRoleLevel != nil {
+				requiredRoleLevel = *originalActorRoleLevel
+			}
+			if actorRoleLevel < requiredRoleLevel {
+				return ErrCannotUndoHigherRoleAction
+			}
+		}
+
+		if undone.UndoneAt != nil {
+			return ErrModerationActionAlreadyUndone
+		}
+		if expired {
+			return ErrUndoWindowExpired
+		}
+
+		var superseded bool
+		err = tx.QueryRow(ctx, `
+			SELECT EXISTS (
+				SELECT 1 FROM moderation_logs
+				WHERE target_user_id = $1 AND action = ANY($2) AND created_at > $3
+			)
+		`, undone.TargetUserID, supersedingActions, undone.CreatedAt).Scan(&superseded)
+		if err != nil {
+			return fmt.Errorf("failed to check for later moderation actions: %w", err)
+		}
+		if superseded {
+			return ErrModerationActionSuperseded
+		}
+
+		var query string
+		switch undone.Action {
+		case ModerationActionBan:
+			query = `UPDATE users SET banned = FALSE, is_active = (activated_at IS NOT NULL) WHERE id = $1`
+		case ModerationActionTimeout:
+			query = `UPDATE users SET timeout_until = NULL WHERE id = $1`
+		}
+		commandTag, err := tx.Exec(ctx, query, undone.TargetUserID)
+		if err != nil {
+			return fmt.Errorf("failed to undo %s: %w", undone.Action, err)
+		}
+		if commandTag.RowsAffected() == 0 {
+			return ErrUserNotFound
+		}
+
+		err = tx.QueryRow(ctx, `
+			UPDATE moderation_logs
+			SET undone_at = now()
+			WHERE id = $1
+			RETURNING undone_at
+		`, logID).Scan(&undone.UndoneAt)
+		if err != nil {
+			return fmt.Errorf("failed to mark moderation log entry as undone: %w", err)
+		}
+
+		details := fmt.Sprintf("undid %s %s", undone.Action, logID)
+		return recordModerationAction(ctx, tx, actorID, undone.TargetUserID, ModerationActionUndo, details)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	invalidateCachedUser(ctx, undone.TargetUserID)
+	return &undone, nil
+}
+
+// RecordImpersonation records in the moderation audit log that an admin was issued a token to impersonate a user.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the database operation.
+//   - actorID (uuid.UUID): ID of the admin impersonating the user.
+//   - targetUserID (uuid.UUID): ID of the impersonated user.
+//   - expiresAt (time.Time): Time the impersonation token expires at.
+//
+// Returns:
+//   - error: An error if the entry could not be written.
+func (as *ActionStore) RecordImpersonation(ctx context.Context, actorID uuid.UUID, targetUserID uuid.UUID, expiresAt time.Time) error {
+	return WithTx(ctx, as.dbPool, func(tx pgx.Tx) error {
+		details := fmt.Sprintf("read-only impersonation token issued, expires at %s", expiresAt.UTC().Format(time.RFC3339))
+		return recordModerationAction(ctx, tx, actorID, targetUserID, ModerationActionImpersonate, details)
+	})
+}
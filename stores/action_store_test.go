@@ -0,0 +1,210 @@
+package stores
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/datarohit/gopher-social-backend/database/dbtest"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// banFootprint counts the rows BanUser removes for a user: posts, comments, post and comment likes,
+// follow edges and follow requests.
+type banFootprint struct {
+	posts, comments, postLikes, commentLikes, follows, followRequests int
+}
+
+func footprintOf(t *testing.T, pool *pgxpool.Pool, userID uuid.UUID) banFootprint {
+	t.Helper()
+
+	return banFootprint{
+		posts:          dbtest.Count(t, pool, `SELECT COUNT(*) FROM posts WHERE author_id = $1`, userID),
+		comments:       dbtest.Count(t, pool, `SELECT COUNT(*) FROM comments WHERE author_id = $1`, userID),
+		postLikes:      dbtest.Count(t, pool, `SELECT COUNT(*) FROM post_likes WHERE user_id = $1`, userID),
+		commentLikes:   dbtest.Count(t, pool, `SELECT COUNT(*) FROM comment_likes WHERE user_id = $1`, userID),
+		follows:        dbtest.Count(t, pool, `SELECT COUNT(*) FROM follows WHERE follower_id = $1 OR followee_id = $1`, userID),
+		followRequests: dbtest.Count(t, pool, `SELECT COUNT(*) FROM follow_requests WHERE requester_id = $1 OR target_id = $1`, userID),
+	}
+}
+
+// seedBanTarget gives the target user a post, a comment, a like on a post and a comment, follow edges in both
+// directions and a follow request in both directions, all involving the other user.
+func seedBanTarget(t *testing.T, pool *pgxpool.Pool, targetID uuid.UUID, otherID uuid.UUID) (uuid.UUID, uuid.UUID) {
+	t.Helper()
+
+	dbtest.CreatePost(t, pool, targetID)
+	otherPostID := dbtest.CreatePost(t, pool, otherID)
+	otherCommentID := dbtest.CreateComment(t, pool, otherID, otherPostID)
+	dbtest.CreateComment(t, pool, targetID, otherPostID)
+
+	dbtest.Exec(t, pool, `INSERT INTO post_likes (user_id, post_id, liked) VALUES ($1, $2, TRUE)`, targetID, otherPostID)
+	dbtest.Exec(t, pool, `INSERT INTO comment_likes (user_id, comment_id, liked) VALUES ($1, $2, FALSE)`, targetID, otherCommentID)
+	dbtest.Exec(t, pool, `INSERT INTO follows (follower_id, followee_id) VALUES ($1, $2), ($2, $1)`, targetID, otherID)
+	dbtest.Exec(t, pool, `INSERT INTO follow_requests (requester_id, target_id) VALUES ($1, $2), ($2, $1)`, targetID, otherID)
+	return otherPostID, otherCommentID
+}
+
+func TestBanUserRemovesFootprint(t *testing.T) {
+	pool := dbtest.NewPool(t)
+	ctx := context.Background()
+
+	adminID := dbtest.CreateUser(t, pool, "admin", 3)
+	targetID := dbtest.CreateUser(t, pool, "target", 1)
+	otherID := dbtest.CreateUser(t, pool, "other", 1)
+	otherPostID, otherCommentID := seedBanTarget(t, pool, targetID, otherID)
+
+	want := banFootprint{posts: 1, comments: 1, postLikes: 1, commentLikes: 1, follows: 2, followRequests: 2}
+	if got := footprintOf(t, pool, targetID); got != want {
+		t.Fatalf("footprint before the ban = %+v, want %+v", got, want)
+	}
+
+	if err := NewActionStore(pool).BanUser(ctx, adminID, targetID); err != nil {
+		t.Fatalf("BanUser() error = %v", err)
+	}
+
+	if got := footprintOf(t, pool, targetID); got != (banFootprint{}) {
+		t.Fatalf("footprint after the ban = %+v, want none", got)
+	}
+	if n := dbtest.Count(t, pool, `SELECT COUNT(*) FROM users WHERE id = $1 AND banned AND NOT is_active`, targetID); n != 1 {
+		t.Fatal("user is not banned and deactivated")
+	}
+	if n := dbtest.Count(t, pool, `SELECT COUNT(*) FROM moderation_logs WHERE actor_id = $1 AND target_user_id = $2 AND action = $3`, adminID, targetID, ModerationActionBan); n != 1 {
+		t.Fatalf("ban moderation log entries = %d, want 1", n)
+	}
+	if n := dbtest.Count(t, pool, `SELECT COUNT(*) FROM posts WHERE id = $1`, otherPostID); n != 1 {
+		t.Fatal("post of another user was deleted")
+	}
+	if n := dbtest.Count(t, pool, `SELECT COUNT(*) FROM comments WHERE id = $1`, otherCommentID); n != 1 {
+		t.Fatal("comment of another user was deleted")
+	}
+	if n := dbtest.Count(t, pool, `SELECT COUNT(*) FROM users WHERE id = $1 AND followers_count = 0 AND following_count = 0`, otherID); n != 1 {
+		t.Fatal("follow counts of the other user still include the banned user")
+	}
+}
+
+func TestBanUserRollsBackOnFailure(t *testing.T) {
+	pool := dbtest.NewPool(t)
+	ctx := context.Background()
+
+	targetID := dbtest.CreateUser(t, pool, "target", 1)
+	otherID := dbtest.CreateUser(t, pool, "other", 1)
+	seedBanTarget(t, pool, targetID, otherID)
+	before := footprintOf(t, pool, targetID)
+
+	// The moderation log is written last and references the actor, so an unknown actor fails the ban after
+	// every delete has run.
+	if err := NewActionStore(pool).BanUser(ctx, uuid.New(), targetID); err == nil {
+		t.Fatal("BanUser() with an unknown actor error = nil, want an error")
+	}
+
+	if got := footprintOf(t, pool, targetID); got != before {
+		t.Fatalf("footprint after the failed ban = %+v, want %+v", got, before)
+	}
+	if n := dbtest.Count(t, pool, `SELECT COUNT(*) FROM users WHERE id = $1 AND NOT banned AND is_active`, targetID); n != 1 {
+		t.Fatal("failed ban left the user banned or deactivated")
+	}
+	if n := dbtest.Count(t, pool, `SELECT COUNT(*) FROM moderation_logs WHERE target_user_id = $1`, targetID); n != 0 {
+		t.Fatalf("failed ban left %d moderation log entries", n)
+	}
+}
+
+func TestBanUserNotFound(t *testing.T) {
+	pool := dbtest.NewPool(t)
+	adminID := dbtest.CreateUser(t, pool, "admin", 3)
+
+	if err := NewActionStore(pool).BanUser(context.Background(), adminID, uuid.New()); !errors.Is(err, ErrUserNotFound) {
+		t.Fatalf("BanUser() of an unknown user error = %v, want %v", err, ErrUserNotFound)
+	}
+}
+
+// latestModerationLogID returns the ID of the latest moderation log entry of the action taken on the user.
+func latestModerationLogID(t *testing.T, pool *pgxpool.Pool, targetID uuid.UUID, action string) uuid.UUID {
+	t.Helper()
+
+	var logID uuid.UUID
+	err := pool.QueryRow(context.Background(), `
+		SELECT id FROM moderation_logs
+		WHERE target_user_id = $1 AND action = $2
+		ORDER BY created_at DESC
+		LIMIT 1
+	`, targetID, action).Scan(&logID)
+	if err != nil {
+		t.Fatalf("failed to get the %s moderation log entry: %v", action, err)
+	}
+	return logID
+}
+
+func TestUndoModerationActionBan(t *testing.T) {
+	pool := dbtest.NewPool(t)
+	store := NewActionStore(pool)
+	ctx := context.Background()
+
+	moderatorID := dbtest.CreateUser(t, pool, "moderator", 2)
+	adminID := dbtest.CreateUser(t, pool, "admin", 3)
+	targetID := dbtest.CreateUser(t, pool, "target", 1)
+
+	if err := store.BanUser(ctx, adminID, targetID); err != nil {
+		t.Fatalf("BanUser() error = %v", err)
+	}
+	logID := latestModerationLogID(t, pool, targetID, ModerationActionBan)
+
+	if _, err := store.UndoModerationAction(ctx, moderatorID, 2, logID); !errors.Is(err, ErrCannotUndoHigherRoleAction) {
+		t.Fatalf("UndoModerationAction() of an admin ban by a moderator error = %v, want %v", err, ErrCannotUndoHigherRoleAction)
+	}
+
+	undone, err := store.UndoModerationAction(ctx, adminID, 3, logID)
+	if err != nil {
+		t.Fatalf("UndoModerationAction() error = %v", err)
+	}
+	if undone.UndoneAt == nil {
+		t.Fatal("undone entry has no undone_at")
+	}
+	if n := dbtest.Count(t, pool, `SELECT COUNT(*) FROM users WHERE id = $1 AND NOT banned AND is_active`, targetID); n != 1 {
+		t.Fatal("undone ban left the user banned or deactivated")
+	}
+	if n := dbtest.Count(t, pool, `SELECT COUNT(*) FROM moderation_logs WHERE target_user_id = $1 AND action = $2`, targetID, ModerationActionUndo); n != 1 {
+		t.Fatalf("%d undo entries in the moderation log, want 1", n)
+	}
+
+	if _, err := store.UndoModerationAction(ctx, adminID, 3, logID); !errors.Is(err, ErrModerationActionAlreadyUndone) {
+		t.Fatalf("second UndoModerationAction() error = %v, want %v", err, ErrModerationActionAlreadyUndone)
+	}
+	if _, err := store.UndoModerationAction(ctx, adminID, 3, uuid.New()); !errors.Is(err, ErrModerationLogNotFound) {
+		t.Fatalf("UndoModerationAction() of an unknown entry error = %v, want %v", err, ErrModerationLogNotFound)
+	}
+}
+
+func TestUndoModerationActionTimeout(t *testing.T) {
+	pool := dbtest.NewPool(t)
+	store := NewActionStore(pool)
+	ctx := context.Background()
+
+	moderatorID := dbtest.CreateUser(t, pool, "moderator", 2)
+	otherModeratorID := dbtest.CreateUser(t, pool, "other_moderator", 2)
+	targetID := dbtest.CreateUser(t, pool, "target", 1)
+
+	if err := store.TimeoutUser(ctx, moderatorID, targetID, time.Hour); err != nil {
+		t.Fatalf("TimeoutUser() error = %v", err)
+	}
+	if _, err := store.UndoModerationAction(ctx, otherModeratorID, 2, latestModerationLogID(t, pool, targetID, ModerationActionTimeout)); err != nil {
+		t.Fatalf("UndoModerationAction() by a moderator of the same role error = %v", err)
+	}
+	if n := dbtest.Count(t, pool, `SELECT COUNT(*) FROM users WHERE id = $1 AND timeout_until IS NULL`, targetID); n != 1 {
+		t.Fatal("undone timeout left the user timed out")
+	}
+
+	if err := store.TimeoutUser(ctx, moderatorID, targetID, time.Hour); err != nil {
+		t.Fatalf("TimeoutUser() error = %v", err)
+	}
+	logID := latestModerationLogID(t, pool, targetID, ModerationActionTimeout)
+	dbtest.Exec(t, pool, `UPDATE moderation_logs SET created_at = now() - $2 * interval '1 second' WHERE id = $1`, logID, (moderationUndoWindow + time.Minute).Seconds())
+	if _, err := store.UndoModerationAction(ctx, moderatorID, 2, logID); !errors.Is(err, ErrUndoWindowExpired) {
+		t.Fatalf("UndoModerationAction() after the window error = %v, want %v", err, ErrUndoWindowExpired)
+	}
+	if n := dbtest.Count(t, pool, `SELECT COUNT(*) FROM users WHERE id = $1 AND timeout_until > now()`, targetID); n != 1 {
+		t.Fatal("expired undo removed the timeout")
+	}
+}
@@ -0,0 +1,223 @@
+package stores
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/datarohit/gopher-social-backend/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// API key scopes. A read key may only make safe requests, a write key may make any request.
+const (
+	APIKeyScopeRead  = "read"
+	APIKeyScopeWrite = "write"
+)
+
+// ErrInvalidAPIKey is returned when an API key does not exist or has been revoked.
+var ErrInvalidAPIKey = errors.New("invalid api key")
+
+// ErrAPIKeyNotFound is returned when a user has no active API key with the given ID.
+var ErrAPIKeyNotFound = errors.New("api key not found")
+
+// ErrAPIKeyLimitReached is returned when a user already has the maximum number of active API keys.
+var ErrAPIKeyLimitReached = errors.New("maximum number of active api keys reached, revoke one first")
+
+// maxActiveAPIKeys is the number of API keys a user may have active at the same time.
+var maxActiveAPIKeys = maxActiveAPIKeysFromEnv()
+
+// maxActiveAPIKeysFromEnv reads MAX_API_KEYS_PER_USER, defaulting to 5.
+func maxActiveAPIKeysFromEnv() int {
+	limit, err := strconv.Atoi(os.Getenv("MAX_API_KEYS_PER_USER"))
+	if err != nil || limit <= 0 {
+		return 5
+	}
+	return limit
+}
+
+type APIKeyStore struct {
+	dbPool *pgxpool.Pool
+}
+
+// NewAPIKeyStore creates a new APIKeyStore.
+//
+// Parameters:
+//   - dbPool (*pgxpool.Pool): Pgx connection pool.
+//
+// Returns:
+//   - *APIKeyStore: APIKeyStore instance.
+func NewAPIKeyStore(dbPool *pgxpool.Pool) *APIKeyStore {
+	return &APIKeyStore{
+		dbPool: dbPool,
+	}
+}
+
+// HashAPIKey returns the hex encoded SHA-256 hash of an API key, which is what the api_keys table stores.
+//
+// Parameters:
+//   - key (string): Plaintext API key.
+//
+// Returns:
+//   - string: Hash of the key.
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// Validate looks up an API key by its plaintext value and records that it was used.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the database operation.
+//   - key (string): Plaintext API key from the request.
+//
+// Returns:
+//   - *models.APIKey: The API key with the role it grants.
+//   - error: ErrInvalidAPIKey if the key does not exist or is revoked, or other errors during database query.
+func (aks *APIKeyStore) Validate(ctx context.Context, key string) (*models.APIKey, error) {
+	apiKey := &models.APIKey{Role: &models.Role{}}
+	err := aks.dbPool.QueryRow(ctx, `
+		UPDATE api_keys ak
+		SET last_used_at = now()
+		FROM roles r
+		WHERE ak.key_hash = $1 AND ak.revoked_at IS NULL AND r.id = ak.role_id
+		RETURNING ak.id, ak.user_id, ak.name, ak.scopes, ak.last_used_at, ak.created_at, r.id, r.level, r.description
+	`, HashAPIKey(key)).Scan(
+		&apiKey.ID, &apiKey.UserID, &apiKey.Name, &apiKey.Scopes, &apiKey.LastUsedAt, &apiKey.CreatedAt,
+		&apiKey.Role.ID, &apiKey.Role.Level, &apiKey.Role.Description,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrInvalidAPIKey
+		}
+		return nil, fmt.Errorf("failed to validate api key: %w", err)
+	}
+
+	return apiKey, nil
+}
+
+// Create stores a new API key of a user, acting with the given role. Only the hash of the key is stored.
+// The user row is locked while the active keys are counted, so concurrent requests cannot exceed the limit.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the database operation.
+//   - userID (uuid.UUID): ID of the user owning the key.
+//   - roleID (uuid.UUID): ID of the role the key acts with.
+//   - name (string): Name of the key.
+//   - scopes ([]string): Scopes of the key, APIKeyScopeRead or APIKeyScopeWrite.
+//   - key (string): Plaintext API key.
+//
+// Returns:
+//   - *models.APIKey: The created API key with the role it grants.
+//   - error: ErrAPIKeyLimitReached if the user has MAX_API_KEYS_PER_USER active keys, or other errors during database query.
+func (aks *APIKeyStore) Create(ctx context.Context, userID uuid.UUID, roleID uuid.UUID, name string, scopes []string, key string) (*models.APIKey, error) {
+	apiKey := &models.APIKey{Role: &models.Role{}}
+	err := WithTx(ctx, aks.dbPool, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, `SELECT 1 FROM users WHERE id = $1 FOR UPDATE`, userID); err != nil {
+			return fmt.Errorf("failed to lock user for api key creation: %w", err)
+		}
+
+		var activeKeys int
+		err := tx.QueryRow(ctx, `SELECT COUNT(*) FROM api_keys WHERE user_id = $1 AND revoked_at IS NULL`, userID).Scan(&activeKeys)
+		if err != nil {
+			return fmt.Errorf("failed to count active api keys: %w", err)
+		}
+		if activeKeys >= maxActiveAPIKeys {
+			return ErrAPIKeyLimitReached
+		}
+
+		err = tx.QueryRow(ctx, `
+			WITH inserted AS (
+				INSERT INTO api_keys (user_id, name, key_hash, scopes, role_id)
+				VALUES ($1, $2, $3, $4, $5)
+				RETURNING id, user_id, name, scopes, role_id, created_at
+			)
+			SELECT i.id, i.user_id, i.name, i.scopes, i.created_at, r.id, r.level, r.description
+			FROM inserted i
+			INNER JOIN roles r ON r.id = i.role_id
+		`, userID, name, HashAPIKey(key), scopes, roleID).Scan(
+			&apiKey.ID, &apiKey.UserID, &apiKey.Name, &apiKey.Scopes, &apiKey.CreatedAt,
+			&apiKey.Role.ID, &apiKey.Role.Level, &apiKey.Role.Description,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create api key: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return apiKey, nil
+}
+
+// ListByUser retrieves the active and revoked API keys of a user, newest first. Key hashes are never returned.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the database operation.
+//   - userID (uuid.UUID): ID of the user owning the keys.
+//
+// Returns:
+//   - []*models.APIKey: List of API keys with the role they grant, empty if the user has none.
+//   - error: An error if the database query fails.
+func (aks *APIKeyStore) ListByUser(ctx context.Context, userID uuid.UUID) ([]*models.APIKey, error) {
+	rows, err := aks.dbPool.Query(ctx, `
+		SELECT ak.id, ak.user_id, ak.name, ak.scopes, ak.last_used_at, ak.revoked_at, ak.created_at, r.id, r.level, r.description
+		FROM api_keys ak
+		INNER JOIN roles r ON r.id = ak.role_id
+		WHERE ak.user_id = $1
+		ORDER BY ak.created_at DESC
+	`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+	defer rows.Close()
+
+	apiKeys := []*models.APIKey{}
+	for rows.Next() {
+		apiKey := &models.APIKey{Role: &models.Role{}}
+		err := rows.Scan(
+			&apiKey.ID, &apiKey.UserID, &apiKey.Name, &apiKey.Scopes, &apiKey.LastUsedAt, &apiKey.RevokedAt, &apiKey.CreatedAt,
+			&apiKey.Role.ID, &apiKey.Role.Level, &apiKey.Role.Description,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan api key row: %w", err)
+		}
+		apiKeys = append(apiKeys, apiKey)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during api keys rows iteration: %w", err)
+	}
+
+	return apiKeys, nil
+}
+
+// Revoke revokes an active API key of a user. The key stops working immediately and stays listed with its revocation time.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the database operation.
+//   - userID (uuid.UUID): ID of the user owning the key.
+//   - keyID (uuid.UUID): ID of the key to revoke.
+//
+// Returns:
+//   - error: ErrAPIKeyNotFound if the user has no active key with that ID, or other errors during database query.
+func (aks *APIKeyStore) Revoke(ctx context.Context, userID uuid.UUID, keyID uuid.UUID) error {
+	commandTag, err := aks.dbPool.Exec(ctx, `
+		UPDATE api_keys SET revoked_at = now()
+		WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL
+	`, keyID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke api key: %w", err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return ErrAPIKeyNotFound
+	}
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/datarohit/gopher-social-backend/models"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -35,17 +36,49 @@ var ErrUserAlreadyExists = errors.New("user already exists")
 // ErrUserNotFound is returned when a user is not found.
 var ErrUserNotFound = errors.New("user not found")
 
+// ErrUserAlreadyActivated is returned when an activation link is used for a user who was activated before.
+var ErrUserAlreadyActivated = errors.New("user already activated")
+
 // ErrInvalidOrExpiredToken is returned when a password reset token is invalid or expired.
 var ErrInvalidOrExpiredToken = errors.New("invalid or expired reset token")
 
 // ErrInvalidOrExpiredActivationToken is returned when an activation token is invalid or expired.
 var ErrInvalidOrExpiredActivationToken = errors.New("invalid or expired activation token")
 
+// ErrInvalidOrExpiredEmailChangeToken is returned when an email change token is invalid or expired.
+var ErrInvalidOrExpiredEmailChangeToken = errors.New("invalid or expired email change token")
+
+// ErrEmailAlreadyInUse is returned when changing the email of a user to the email of another user.
+var ErrEmailAlreadyInUse = errors.New("email already in use")
+
+// ErrRoleNotFound is returned when no role exists for a requested role level.
+var ErrRoleNotFound = errors.New("role not found")
+
+// ErrAdminCannotChangeAdminRole is returned when an admin tries to change the role of another admin.
+var ErrAdminCannotChangeAdminRole = errors.New("admin cannot change the role of another admin")
+
+// ErrLastAdminCannotBeDemoted is returned when demoting a user would leave no admins.
+var ErrLastAdminCannotBeDemoted = errors.New("cannot demote the last remaining admin")
+
+// adminRoleLevel is the role level of admins (Admin - Level 3).
+const adminRoleLevel = 3
+
+// uniqueViolationCode is the PostgreSQL SQLSTATE for a unique constraint violation.
+const uniqueViolationCode = "23505"
+
+// isUniqueViolation reports whether err is a PostgreSQL unique constraint violation.
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode
+}
+
 // defaultRoleLevel is the default role level for new users (Normal User - Level 1).
 const defaultRoleLevel = 1
 
 // CreateUser creates a new user in the database.
-// It checks if a user with the same username or email already exists before creating a new user.
+// It checks if a user with the same username or email already exists before creating a new user. Two registrations
+// racing past that check are caught by the unique constraints on username and email, which are also reported as
+// ErrUserAlreadyExists.
 //
 // Parameters:
 //   - ctx (context.Context): Context for the database operation.
@@ -85,12 +118,101 @@ func (as *AuthStore) CreateUser(ctx context.Context, user *models.User) (*models
 		&createdUser.ID, &createdUser.Username, &createdUser.Email, &createdUser.PasswordHash, &createdUser.RoleID, &createdUser.TimeoutUntil, &createdUser.Banned, &createdUser.IsActive, &createdUser.CreatedAt, &createdUser.UpdatedAt, &createdUser.ActivationToken, &createdUser.ActivationTokenExpiry,
 	)
 	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, ErrUserAlreadyExists
+		}
 		return nil, fmt.Errorf("failed to create user: %w", err)
 	}
 
 	return &createdUser, nil
 }
 
+// CreateOAuthUser creates a new, already activated user that signed up through an OAuth provider.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the database operation.
+//   - user (*models.User): User object to be created, OAuthProvider must be set.
+//
+// Returns:
+//   - *models.User: The created user if successful.
+//   - error: ErrUserAlreadyExists if the username or email is taken, or other errors during creation.
+func (as *AuthStore) CreateOAuthUser(ctx context.Context, user *models.User) (*models.User, error) {
+	var exists bool
+	err := as.dbPool.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM users WHERE username = $1 OR email = $2)`, user.Username, user.Email).Scan(&exists)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check for existing user: %w", err)
+	}
+	if exists {
+		return nil, ErrUserAlreadyExists
+	}
+
+	var defaultRoleID uuid.UUID
+	err = as.dbPool.QueryRow(ctx, `SELECT id FROM roles WHERE level = $1`, defaultRoleLevel).Scan(&defaultRoleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get default role id: %w", err)
+	}
+	user.RoleID = defaultRoleID
+
+	var createdUser models.User
+	err = as.dbPool.QueryRow(ctx, `
+		INSERT INTO users (username, email, password_hash, role_id, is_active, activated_at, oauth_provider)
+		VALUES ($1, $2, $3, $4, TRUE, now(), $5)
+		RETURNING id, username, email, password_hash, role_id, timeout_until, banned, is_active, created_at, updated_at, oauth_provider
+		`, user.Username, user.Email, user.PasswordHash, user.RoleID, user.OAuthProvider).Scan(
+		&createdUser.ID, &createdUser.Username, &createdUser.Email, &createdUser.PasswordHash, &createdUser.RoleID, &createdUser.TimeoutUntil, &createdUser.Banned, &createdUser.IsActive, &createdUser.CreatedAt, &createdUser.UpdatedAt, &createdUser.OAuthProvider,
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, ErrUserAlreadyExists
+		}
+		return nil, fmt.Errorf("failed to create oauth user: %w", err)
+	}
+
+	return &createdUser, nil
+}
+
+// nullableProfile holds the left joined profile columns of a user, which are all NULL if the user has no profile yet.
+type nullableProfile struct {
+	ID            *uuid.UUID
+	FirstName     string
+	LastName      string
+	Website       string
+	Github        string
+	LinkedIn      string
+	Twitter       string
+	GoogleScholar string
+	IsPrivate     bool
+	CreatedAt     *time.Time
+	UpdatedAt     *time.Time
+}
+
+// toProfile converts the left joined profile columns into a profile.
+//
+// Parameters:
+//   - userID (uuid.UUID): ID of the user owning the profile.
+//
+// Returns:
+//   - *models.Profile: The profile, or nil if the user has no profile yet.
+func (np nullableProfile) toProfile(userID uuid.UUID) *models.Profile {
+	if np.ID == nil {
+		return nil
+	}
+	return &models.Profile{
+		ID:            *np.ID,
+		UserID:        userID,
+		FirstName:     np.FirstName,
+		LastName:      np.LastName,
+		Website:       np.Website,
+		Github:        np.Github,
+		LinkedIn:      np.LinkedIn,
+		Twitter:       np.Twitter,
+		GoogleScholar: np.GoogleScholar,
+		IsPrivate:     np.IsPrivate,
+		CreatedAt:     *np.CreatedAt,
+		UpdatedAt:     *np.UpdatedAt,
+	}
+}
+
 // GetUserByUsernameOrEmail retrieves a user from the database by username or email.
 //
 // Parameters:
@@ -102,20 +224,24 @@ func (as *AuthStore) CreateUser(ctx context.Context, user *models.User) (*models
 //   - error: ErrUserNotFound if user not found or other errors during database query.
 func (as *AuthStore) GetUserByUsernameOrEmail(ctx context.Context, identifier string) (*models.User, error) {
 	var user models.User
+	var profile nullableProfile
 	user.Role = &models.Role{}
 	err := as.dbPool.QueryRow(ctx, `
 		SELECT
-			u.id, u.username, u.email, u.password_hash, u.role_id, u.timeout_until, u.banned, u.is_active, u.created_at, u.updated_at, u.password_reset_token, u.reset_token_expiry, u.activation_token, u.activation_token_expiry,
+			u.id, u.username, u.email, u.password_hash, u.role_id, u.timeout_until, u.banned, u.is_active, u.created_at, u.updated_at, u.password_reset_token, u.reset_token_expiry, u.activation_token, u.activation_token_expiry, u.oauth_provider,
 			r.level, r.description,
-			(SELECT COUNT(*) FROM follows WHERE followee_id = u.id) as followers_count,
-			(SELECT COUNT(*) FROM follows WHERE follower_id = u.id) as following_count
+			u.followers_count,
+			u.following_count,
+			p.id, COALESCE(p.first_name, ''), COALESCE(p.last_name, ''), COALESCE(p.website, ''), COALESCE(p.github, ''), COALESCE(p.linkedin, ''), COALESCE(p.twitter, ''), COALESCE(p.google_scholar, ''), COALESCE(p.is_private, FALSE), p.created_at, p.updated_at
 		FROM users u
 		INNER JOIN roles r ON u.role_id = r.id
+		LEFT JOIN profiles p ON p.user_id = u.id
 		WHERE u.username = $1 OR u.email = $1
 	`, identifier).Scan(
-		&user.ID, &user.Username, &user.Email, &user.PasswordHash, &user.RoleID, &user.TimeoutUntil, &user.Banned, &user.IsActive, &user.CreatedAt, &user.UpdatedAt, &user.PasswordResetToken, &user.ResetTokenExpiry, &user.ActivationToken, &user.ActivationTokenExpiry,
+		&user.ID, &user.Username, &user.Email, &user.PasswordHash, &user.RoleID, &user.TimeoutUntil, &user.Banned, &user.IsActive, &user.CreatedAt, &user.UpdatedAt, &user.PasswordResetToken, &user.ResetTokenExpiry, &user.ActivationToken, &user.ActivationTokenExpiry, &user.OAuthProvider,
 		&user.Role.Level, &user.Role.Description,
 		&user.Followers, &user.Following,
+		&profile.ID, &profile.FirstName, &profile.LastName, &profile.Website, &profile.Github, &profile.LinkedIn, &profile.Twitter, &profile.GoogleScholar, &profile.IsPrivate, &profile.CreatedAt, &profile.UpdatedAt,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -124,10 +250,14 @@ func (as *AuthStore) GetUserByUsernameOrEmail(ctx context.Context, identifier st
 		return nil, fmt.Errorf("failed to get user by username or email: %w", err)
 	}
 
+	user.Profile = profile.toProfile(user.ID)
+
 	return &user, nil
 }
 
-// GetUserByID retrieves a user from the database by ID.
+// GetUserByID retrieves a user by ID, from the user cache if EnableUserCache was called, otherwise from the database.
+// Users read from the cache have no password hash and no reset or activation tokens, callers needing those
+// must use GetUserByUsernameOrEmail.
 //
 // Parameters:
 //   - ctx (context.Context): Context for the database operation.
@@ -137,21 +267,29 @@ func (as *AuthStore) GetUserByUsernameOrEmail(ctx context.Context, identifier st
 //   - *models.User: The retrieved user if found.
 //   - error: ErrUserNotFound if user not found or other errors during database query.
 func (as *AuthStore) GetUserByID(ctx context.Context, id uuid.UUID) (*models.User, error) {
+	if cached := getCachedUser(ctx, id); cached != nil {
+		return cached, nil
+	}
+
 	var user models.User
+	var profile nullableProfile
 	user.Role = &models.Role{}
 	err := as.dbPool.QueryRow(ctx, `
 		SELECT
-			u.id, u.username, u.email, u.password_hash, u.role_id, u.timeout_until, u.banned, u.is_active, u.created_at, u.updated_at, u.password_reset_token, u.reset_token_expiry, u.activation_token, u.activation_token_expiry,
+			u.id, u.username, u.email, u.password_hash, u.role_id, u.timeout_until, u.banned, u.is_active, u.created_at, u.updated_at, u.password_reset_token, u.reset_token_expiry, u.activation_token, u.activation_token_expiry, u.oauth_provider,
 			r.level, r.description,
-			(SELECT COUNT(*) FROM follows WHERE followee_id = u.id) as followers_count,
-			(SELECT COUNT(*) FROM follows WHERE follower_id = u.id) as following_count
+			u.followers_count,
+			u.following_count,
+			p.id, COALESCE(p.first_name, ''), COALESCE(p.last_name, ''), COALESCE(p.website, ''), COALESCE(p.github, ''), COALESCE(p.linkedin, ''), COALESCE(p.twitter, ''), COALESCE(p.google_scholar, ''), COALESCE(p.is_private, FALSE), p.created_at, p.updated_at
 		FROM users u
 		INNER JOIN roles r ON u.role_id = r.id
+		LEFT JOIN profiles p ON p.user_id = u.id
 		WHERE u.id = $1
 	`, id).Scan(
-		&user.ID, &user.Username, &user.Email, &user.PasswordHash, &user.RoleID, &user.TimeoutUntil, &user.Banned, &user.IsActive, &user.CreatedAt, &user.UpdatedAt, &user.PasswordResetToken, &user.ResetTokenExpiry, &user.ActivationToken, &user.ActivationTokenExpiry,
+		&user.ID, &user.Username, &user.Email, &user.PasswordHash, &user.RoleID, &user.TimeoutUntil, &user.Banned, &user.IsActive, &user.CreatedAt, &user.UpdatedAt, &user.PasswordResetToken, &user.ResetTokenExpiry, &user.ActivationToken, &user.ActivationTokenExpiry, &user.OAuthProvider,
 		&user.Role.Level, &user.Role.Description,
 		&user.Followers, &user.Following,
+		&profile.ID, &profile.FirstName, &profile.LastName, &profile.Website, &profile.Github, &profile.LinkedIn, &profile.Twitter, &profile.GoogleScholar, &profile.IsPrivate, &profile.CreatedAt, &profile.UpdatedAt,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -160,9 +298,176 @@ func (as *AuthStore) GetUserByID(ctx context.Context, id uuid.UUID) (*models.Use
 		return nil, fmt.Errorf("failed to get user by id: %w", err)
 	}
 
+	user.Profile = profile.toProfile(user.ID)
+	cacheUser(ctx, &user)
+
+	return &user, nil
+}
+
+// GetUsersByIdentifiers retrieves the public summaries of several users identified by username or user ID.
+// Repeated identifiers, and identifiers of the same user, yield the user once. Unknown identifiers, and banned
+// or inactive users, are omitted. Emails are not accepted, so the lookup cannot be used to find who owns an email.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the database operation.
+//   - identifiers ([]string): Usernames or user IDs of the users.
+//
+// Returns:
+//   - []*models.UserSummary: Summaries with role and follower and following counts, in the order of the first
+//     identifier resolving to each user, empty if none resolve.
+//   - error: An error if the database query fails.
+func (as *AuthStore) GetUsersByIdentifiers(ctx context.Context, identifiers []string) ([]*models.UserSummary, error) {
+	var userIDs []uuid.UUID
+	for _, identifier := range identifiers {
+		if userID, err := uuid.Parse(identifier); err == nil {
+			userIDs = append(userIDs, userID)
+		}
+	}
+
+	rows, err := as.dbPool.Query(ctx, `
+		SELECT
+			u.id, u.username, u.created_at,
+			r.level, r.description,
+			u.followers_count,
+			u.following_count
+		FROM users u
+		INNER JOIN roles r ON u.role_id = r.id
+		WHERE u.id IN (
+			SELECT id FROM users WHERE username = ANY($1)
+			UNION
+			SELECT id FROM users WHERE id = ANY($2)
+		) AND u.banned = FALSE AND u.is_active = TRUE
+	`, identifiers, userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get users by identifiers: %w", err)
+	}
+	defer rows.Close()
+
+	usersByIdentifier := make(map[string]*models.UserSummary)
+	for rows.Next() {
+		user := &models.UserSummary{Role: &models.Role{}}
+		err := rows.Scan(
+			&user.ID, &user.Username, &user.CreatedAt,
+			&user.Role.Level, &user.Role.Description,
+			&user.Followers, &user.Following,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan user summary row: %w", err)
+		}
+		usersByIdentifier[user.Username] = user
+		usersByIdentifier[user.ID.String()] = user
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during user summaries rows iteration: %w", err)
+	}
+
+	users := []*models.UserSummary{}
+	seen := make(map[uuid.UUID]bool)
+	for _, identifier := range identifiers {
+		key := identifier
+		if userID, err := uuid.Parse(identifier); err == nil {
+			key = userID.String()
+		}
+		user, found := usersByIdentifier[key]
+		if !found || seen[user.ID] {
+			continue
+		}
+		seen[user.ID] = true
+		users = append(users, user)
+	}
+
+	return users, nil
+}
+
+// GetCurrentUserByID retrieves a user with role, follower, following and post counts in a single query.
+// It does not select password or token columns and is meant for the "who am I" endpoint.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the database operation.
+//   - id (uuid.UUID): User ID to identify the user.
+//
+// Returns:
+//   - *models.User: The retrieved user if found.
+//   - error: ErrUserNotFound if user not found or other errors during database query.
+func (as *AuthStore) GetCurrentUserByID(ctx context.Context, id uuid.UUID) (*models.User, error) {
+	var user models.User
+	var postsCount uint
+	var profile nullableProfile
+	user.Role = &models.Role{}
+	err := as.dbPool.QueryRow(ctx, `
+		SELECT
+			u.id, u.username, u.email, u.role_id, u.timeout_until, u.banned, u.is_active, u.created_at, u.updated_at, u.oauth_provider,
+			r.id, r.level, r.description,
+			u.followers_count,
+			u.following_count,
+			(SELECT COUNT(*) FROM posts WHERE author_id = u.id) as posts_count,
+			p.id, COALESCE(p.first_name, ''), COALESCE(p.last_name, ''), COALESCE(p.website, ''), COALESCE(p.github, ''), COALESCE(p.linkedin, ''), COALESCE(p.twitter, ''), COALESCE(p.google_scholar, ''), COALESCE(p.is_private, FALSE), p.created_at, p.updated_at
+		FROM users u
+		INNER JOIN roles r ON u.role_id = r.id
+		LEFT JOIN profiles p ON p.user_id = u.id
+		WHERE u.id = $1
+	`, id).Scan(
+		&user.ID, &user.Username, &user.Email, &user.RoleID, &user.TimeoutUntil, &user.Banned, &user.IsActive, &user.CreatedAt, &user.UpdatedAt, &user.OAuthProvider,
+		&user.Role.ID, &user.Role.Level, &user.Role.Description,
+		&user.Followers, &user.Following, &postsCount,
+		&profile.ID, &profile.FirstName, &profile.LastName, &profile.Website, &profile.Github, &profile.LinkedIn, &profile.Twitter, &profile.GoogleScholar, &profile.IsPrivate, &profile.CreatedAt, &profile.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get current user by id: %w", err)
+	}
+	user.Posts = &postsCount
+	user.Profile = profile.toProfile(user.ID)
+
 	return &user, nil
 }
 
+// GetAccountStatus retrieves whether a user is active, banned or timed out. A timeout that has already expired is
+// not reported. The reason is the details of the latest ban or timeout moderation action, while the user is banned or timed out.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the database operation.
+//   - userID (uuid.UUID): ID of the user.
+//
+// Returns:
+//   - *models.AccountStatus: The account status of the user.
+//   - error: ErrUserNotFound if user not found or other errors during database query.
+func (as *AuthStore) GetAccountStatus(ctx context.Context, userID uuid.UUID) (*models.AccountStatus, error) {
+	var status models.AccountStatus
+	err := as.dbPool.QueryRow(ctx, `
+		SELECT
+			u.is_active, u.banned,
+			CASE WHEN u.timeout_until > now() THEN u.timeout_until END,
+			CASE WHEN u.banned OR u.timeout_until > now() THEN (
+				SELECT ml.details
+				FROM moderation_logs ml
+				WHERE ml.target_user_id = u.id AND ml.action = CASE WHEN u.banned THEN $2 ELSE $3 END
+				ORDER BY ml.created_at DESC
+				LIMIT 1
+			) END
+		FROM users u
+		WHERE u.id = $1
+	`, userID, ModerationActionBan, ModerationActionTimeout).Scan(
+		&status.Active, &status.Banned, &status.TimeoutUntil, &status.Reason,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get account status: %w", err)
+	}
+
+	if status.TimeoutUntil != nil {
+		remaining := int64(time.Until(*status.TimeoutUntil).Seconds())
+		status.TimeoutRemainingSeconds = &remaining
+	}
+
+	return &status, nil
+}
+
 // GetUserByActivationToken retrieves a user from the database by activation token.
 //
 // Parameters:
@@ -177,15 +482,15 @@ func (as *AuthStore) GetUserByActivationToken(ctx context.Context, tokenString s
 	user.Role = &models.Role{}
 	err := as.dbPool.QueryRow(ctx, `
 		SELECT
-			u.id, u.username, u.email, u.password_hash, u.role_id, u.timeout_until, u.banned, u.is_active, u.created_at, u.updated_at, u.password_reset_token, u.reset_token_expiry, u.activation_token, u.activation_token_expiry,
+			u.id, u.username, u.email, u.password_hash, u.role_id, u.timeout_until, u.banned, u.is_active, u.created_at, u.updated_at, u.password_reset_token, u.reset_token_expiry, u.activation_token, u.activation_token_expiry, u.oauth_provider,
 			r.level, r.description,
-			(SELECT COUNT(*) FROM follows WHERE followee_id = u.id) as followers_count,
-			(SELECT COUNT(*) FROM follows WHERE follower_id = u.id) as following_count
+			u.followers_count,
+			u.following_count
 		FROM users u
 		INNER JOIN roles r ON u.role_id = r.id
 		WHERE u.activation_token = $1
 	`, tokenString).Scan(
-		&user.ID, &user.Username, &user.Email, &user.PasswordHash, &user.RoleID, &user.TimeoutUntil, &user.Banned, &user.IsActive, &user.CreatedAt, &user.UpdatedAt, &user.PasswordResetToken, &user.ResetTokenExpiry, &user.ActivationToken, &user.ActivationTokenExpiry,
+		&user.ID, &user.Username, &user.Email, &user.PasswordHash, &user.RoleID, &user.TimeoutUntil, &user.Banned, &user.IsActive, &user.CreatedAt, &user.UpdatedAt, &user.PasswordResetToken, &user.ResetTokenExpiry, &user.ActivationToken, &user.ActivationTokenExpiry, &user.OAuthProvider,
 		&user.Role.Level, &user.Role.Description,
 		&user.Followers, &user.Following,
 	)
@@ -199,6 +504,7 @@ func (as *AuthStore) GetUserByActivationToken(ctx context.Context, tokenString s
 }
 
 // CreatePasswordResetToken stores a password reset token and its expiry time for a user.
+// A user has a single reset token, so storing a new one invalidates any link sent before.
 //
 // Parameters:
 //   - ctx (context.Context): Context for the database operation.
@@ -358,6 +664,168 @@ func (as *AuthStore) InvalidateActivationToken(ctx context.Context, tokenString
 	return nil
 }
 
+// CreateEmailChangeRequest stores the pending new email of a user with its verification token and expiry time.
+// The live email is unchanged until the token is confirmed with ConfirmEmailChange. A new request replaces
+// any pending one.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the database operation.
+//   - userID (uuid.UUID): ID of the user changing their email.
+//   - newEmail (string): The requested new email.
+//   - token (string): The email change token sent to the new email.
+//   - expiryTime (time.Time): The expiry time of the token.
+//
+// Returns:
+//   - error: ErrEmailAlreadyInUse if another user has the new email, or an error if storing the request fails.
+func (as *AuthStore) CreateEmailChangeRequest(ctx context.Context, userID uuid.UUID, newEmail string, token string, expiryTime time.Time) error {
+	var inUse bool
+	err := as.dbPool.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM users WHERE email = $1 AND id <> $2)`, newEmail, userID).Scan(&inUse)
+	if err != nil {
+		return fmt.Errorf("failed to check email availability: %w", err)
+	}
+	if inUse {
+		return ErrEmailAlreadyInUse
+	}
+
+	_, err = as.dbPool.Exec(ctx, `
+		UPDATE users
+		SET pending_email = $2, email_change_token = $3, email_change_token_expiry = $4
+		WHERE id = $1
+	`, userID, newEmail, token, expiryTime)
+	if err != nil {
+		return fmt.Errorf("failed to store email change request: %w", err)
+	}
+	return nil
+}
+
+// ConfirmEmailChange validates an email change token and replaces the email of its user with the pending email.
+// The token is single use, it is cleared together with the pending email. If another user took the pending email
+// since the request was made, the request is dropped and ErrEmailAlreadyInUse is returned.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the database operation.
+//   - tokenString (string): The email change token string.
+//   - currentTime (time.Time): The current time to check for expiry.
+//
+// Returns:
+//   - uuid.UUID: ID of the user whose email changed.
+//   - string: The new email.
+//   - error: ErrInvalidOrExpiredEmailChangeToken if the token is invalid or expired, ErrEmailAlreadyInUse if the
+//     new email was taken, or other errors.
+func (as *AuthStore) ConfirmEmailChange(ctx context.Context, tokenString string, currentTime time.Time) (uuid.UUID, string, error) {
+	var userID uuid.UUID
+	var newEmail string
+	err := WithTx(ctx, as.dbPool, func(tx pgx.Tx) error {
+		var pendingEmail *string
+		var expiryTime *time.Time
+		err := tx.QueryRow(ctx, `
+			SELECT id, pending_email, email_change_token_expiry
+			FROM users
+			WHERE email_change_token = $1
+			FOR UPDATE
+		`, tokenString).Scan(&userID, &pendingEmail, &expiryTime)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ErrInvalidOrExpiredEmailChangeToken
+			}
+			return fmt.Errorf("failed to retrieve email change token: %w", err)
+		}
+
+		if pendingEmail == nil || expiryTime == nil || currentTime.After(*expiryTime) {
+			return ErrInvalidOrExpiredEmailChangeToken
+		}
+		newEmail = *pendingEmail
+
+		_, err = tx.Exec(ctx, `
+			UPDATE users
+			SET email = pending_email, pending_email = NULL, email_change_token = NULL, email_change_token_expiry = NULL
+			WHERE id = $1
+		`, userID)
+		if err != nil {
+			if isUniqueViolation(err) {
+				return ErrEmailAlreadyInUse
+			}
+			return fmt.Errorf("failed to change email: %w", err)
+		}
+		return nil
+	})
+	if errors.Is(err, ErrEmailAlreadyInUse) {
+		if _, clearErr := as.dbPool.Exec(ctx, `
+			UPDATE users
+			SET pending_email = NULL, email_change_token = NULL, email_change_token_expiry = NULL
+			WHERE email_change_token = $1
+		`, tokenString); clearErr != nil {
+			return uuid.Nil, "", fmt.Errorf("failed to clear email change request: %w", clearErr)
+		}
+	}
+	if err != nil {
+		return uuid.Nil, "", err
+	}
+
+	invalidateCachedUser(ctx, userID)
+	return userID, newEmail, nil
+}
+
+// ClearExpiredTokens nulls activation and password reset tokens whose expiry has passed.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the database operation.
+//   - now (time.Time): Current time, tokens expiring before it are cleared.
+//
+// Returns:
+//   - int64: Number of activation tokens cleared.
+//   - int64: Number of password reset tokens cleared.
+//   - error: An error if clearing the tokens fails.
+func (as *AuthStore) ClearExpiredTokens(ctx context.Context, now time.Time) (int64, int64, error) {
+	activationTag, err := as.dbPool.Exec(ctx, `
+		UPDATE users
+		SET activation_token = NULL, activation_token_expiry = NULL
+		WHERE activation_token_expiry < $1
+	`, now)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to clear expired activation tokens: %w", err)
+	}
+
+	resetTag, err := as.dbPool.Exec(ctx, `
+		UPDATE users
+		SET password_reset_token = NULL, reset_token_expiry = NULL
+		WHERE reset_token_expiry < $1
+	`, now)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to clear expired password reset tokens: %w", err)
+	}
+
+	return activationTag.RowsAffected(), resetTag.RowsAffected(), nil
+}
+
+// PurgeUnactivatedUsers deletes users who registered before createdBefore and never activated their account,
+// together with their profiles. Users who were activated at any point, even if deactivated since, are kept.
+// The users are locked while they are deleted, so a concurrent activation either wins or waits and then finds no user.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the database operation.
+//   - createdBefore (time.Time): Only users registered before this time are deleted.
+//
+// Returns:
+//   - int64: Number of users deleted.
+//   - error: An error if deleting the users fails.
+func (as *AuthStore) PurgeUnactivatedUsers(ctx context.Context, createdBefore time.Time) (int64, error) {
+	commandTag, err := as.dbPool.Exec(ctx, `
+		WITH purged AS (
+			SELECT id FROM users
+			WHERE activated_at IS NULL AND is_active = FALSE AND created_at < $1
+			FOR UPDATE
+		), purged_profiles AS (
+			DELETE FROM profiles WHERE user_id IN (SELECT id FROM purged)
+		)
+		DELETE FROM users WHERE id IN (SELECT id FROM purged)
+	`, createdBefore)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge unactivated users: %w", err)
+	}
+	return commandTag.RowsAffected(), nil
+}
+
 // UpdateUserPassword updates a user's password in the database.
 //
 // Parameters:
@@ -376,10 +844,12 @@ func (as *AuthStore) UpdateUserPassword(ctx context.Context, userID uuid.UUID, h
 	if err != nil {
 		return fmt.Errorf("failed to update user password: %w", err)
 	}
+	invalidateCachedUser(ctx, userID)
 	return nil
 }
 
 // ActivateUser updates a user's is_active status to true in the database.
+// The first activation is recorded in activated_at, which protects the user from the unactivated account purge.
 //
 // Parameters:
 //   - ctx (context.Context): Context for the database operation.
@@ -388,13 +858,180 @@ func (as *AuthStore) UpdateUserPassword(ctx context.Context, userID uuid.UUID, h
 // Returns:
 //   - error: An error if activating the user fails.
 func (as *AuthStore) ActivateUser(ctx context.Context, userID uuid.UUID) error {
-	_, err := as.dbPool.Exec(ctx, `
+	return activateUser(ctx, as.dbPool, userID)
+}
+
+// ActivateUserTx records the first activation of a user as one step of the transaction tx, see WithTx.
+// Unlike ActivateUser it never activates a user who was activated before, so a user deactivated by a moderator
+// cannot reactivate themselves with a still valid activation link, and it never activates a banned user.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the database operation.
+//   - tx (pgx.Tx): Transaction to run the update in.
+//   - userID (uuid.UUID): ID of the user to activate.
+//
+// Returns:
+//   - error: An error if activating the user fails, or ErrUserAlreadyActivated if the user was activated before or is banned.
+func (as *AuthStore) ActivateUserTx(ctx context.Context, tx pgx.Tx, userID uuid.UUID) error {
+	commandTag, err := tx.Exec(ctx, `
+		UPDATE users
+		SET is_active = TRUE, activated_at = now()
+		WHERE id = $1 AND activated_at IS NULL AND banned = FALSE
+	`, userID)
+	if err != nil {
+		return fmt.Errorf("failed to activate user: %w", err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return ErrUserAlreadyActivated
+	}
+	invalidateCachedUser(ctx, userID)
+	return nil
+}
+
+// activateUser sets is_active and the first activation time of a user using the given executor.
+func activateUser(ctx context.Context, db dbExecutor, userID uuid.UUID) error {
+	_, err := db.Exec(ctx, `
 		UPDATE users
-		SET is_active = TRUE
+		SET is_active = TRUE, activated_at = COALESCE(activated_at, now())
 		WHERE id = $1
 	`, userID)
 	if err != nil {
 		return fmt.Errorf("failed to activate user: %w", err)
 	}
+	invalidateCachedUser(ctx, userID)
+	return nil
+}
+
+// UpdateLastSeen records the current time as the last time the user was active.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the database operation.
+//   - userID (uuid.UUID): ID of the active user.
+//
+// Returns:
+//   - error: An error if updating the last seen time fails.
+func (as *AuthStore) UpdateLastSeen(ctx context.Context, userID uuid.UUID) error {
+	_, err := as.dbPool.Exec(ctx, `
+		UPDATE users
+		SET last_seen_at = now()
+		WHERE id = $1
+	`, userID)
+	if err != nil {
+		return fmt.Errorf("failed to update user last seen: %w", err)
+	}
+	return nil
+}
+
+// UpdateUserRole changes the role of a user to the role with the given level and records it in the moderation audit log.
+// An admin cannot change the role of another admin, and an admin cannot demote themselves if they are the last admin.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the database operation.
+//   - actorID (uuid.UUID): ID of the admin performing the change.
+//   - targetUserID (uuid.UUID): ID of the user whose role is changed.
+//   - level (int): Role level to assign.
+//
+// Returns:
+//   - error: ErrUserNotFound, ErrRoleNotFound, ErrAdminCannotChangeAdminRole, ErrLastAdminCannotBeDemoted, or other errors.
+func (as *AuthStore) UpdateUserRole(ctx context.Context, actorID uuid.UUID, targetUserID uuid.UUID, level int) error {
+	err := WithTx(ctx, as.dbPool, func(tx pgx.Tx) error {
+		var currentLevel int
+		err := tx.QueryRow(ctx, `
+			SELECT r.level
+			FROM users u
+			INNER JOIN roles r ON u.role_id = r.id
+			WHERE u.id = $1
+			FOR UPDATE OF u
+		`, targetUserID).Scan(&currentLevel)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ErrUserNotFound
+			}
+			return fmt.Errorf("failed to get user role: %w", err)
+		}
+
+		var roleID uuid.UUID
+		err = tx.QueryRow(ctx, `SELECT id FROM roles WHERE level = $1`, level).Scan(&roleID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ErrRoleNotFound
+			}
+			return fmt.Errorf("failed to get role by level: %w", err)
+		}
+
+		if currentLevel == adminRoleLevel && targetUserID != actorID {
+			return ErrAdminCannotChangeAdminRole
+		}
+
+		if currentLevel == adminRoleLevel && level != adminRoleLevel {
+			// Lock the admin rows so concurrent self-demotions cannot both pass the check.
+			var adminCount int
+			err = tx.QueryRow(ctx, `
+				SELECT COUNT(*) FROM (
+					SELECT u.id
+					FROM users u
+					INNER JOIN roles r ON u.role_id = r.id
+					WHERE r.level = $1
+					FOR UPDATE OF u
+				) admins
+			`, adminRoleLevel).Scan(&adminCount)
+			if err != nil {
+				return fmt.Errorf("failed to count admins: %w", err)
+			}
+			if adminCount <= 1 {
+				return ErrLastAdminCannotBeDemoted
+			}
+		}
+
+		_, err = tx.Exec(ctx, `UPDATE users SET role_id = $1 WHERE id = $2`, roleID, targetUserID)
+		if err != nil {
+			return fmt.Errorf("failed to update user role: %w", err)
+		}
+
+		details := fmt.Sprintf("role level changed from %d to %d", currentLevel, level)
+		return recordModerationAction(ctx, tx, actorID, targetUserID, ModerationActionRoleChange, details)
+	})
+	if err != nil {
+		return err
+	}
+	invalidateCachedUser(ctx, targetUserID)
+
 	return nil
 }
+
+// ListRoles retrieves all roles ordered by level. Roles are served from memory for 10 minutes after they are read,
+// callers must not modify the returned roles.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the database operation.
+//
+// Returns:
+//   - []*models.Role: List of roles, lowest level first.
+//   - error: An error if the database query fails.
+func (as *AuthStore) ListRoles(ctx context.Context) ([]*models.Role, error) {
+	if roles := cachedRoles(); roles != nil {
+		return roles, nil
+	}
+
+	rows, err := as.dbPool.Query(ctx, `SELECT id, level, description FROM roles ORDER BY level`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list roles: %w", err)
+	}
+	defer rows.Close()
+
+	roles := []*models.Role{}
+	for rows.Next() {
+		role := &models.Role{}
+		if err := rows.Scan(&role.ID, &role.Level, &role.Description); err != nil {
+			return nil, fmt.Errorf("failed to scan role row: %w", err)
+		}
+		roles = append(roles, role)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during roles rows iteration: %w", err)
+	}
+
+	cacheRoles(roles)
+	return roles, nil
+}
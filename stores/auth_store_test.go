@@ -0,0 +1,81 @@
+package stores
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/datarohit/gopher-social-backend/database/dbtest"
+	"github.com/datarohit/gopher-social-backend/models"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func TestIsUniqueViolation(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "unique violation", err: &pgconn.PgError{Code: "23505"}, want: true},
+		{name: "wrapped unique violation", err: fmt.Errorf("failed to create user: %w", &pgconn.PgError{Code: "23505"}), want: true},
+		{name: "foreign key violation", err: &pgconn.PgError{Code: "23503"}, want: false},
+		{name: "other error", err: errors.New("connection refused"), want: false},
+		{name: "nil", err: nil, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isUniqueViolation(tt.err); got != tt.want {
+				t.Fatalf("isUniqueViolation(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestCreateUserConcurrentDuplicateEmail registers the same email from several goroutines at once, so most of them
+// pass the existence check before any insert commits. Exactly one must succeed and the others must get
+// ErrUserAlreadyExists from the unique constraint rather than a raw database error.
+func TestCreateUserConcurrentDuplicateEmail(t *testing.T) {
+	pool := dbtest.NewPool(t)
+	authStore := NewAuthStore(pool)
+
+	const attempts = 8
+	start := make(chan struct{})
+	errs := make(chan error, attempts)
+	var wg sync.WaitGroup
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			_, err := authStore.CreateUser(context.Background(), &models.User{
+				Username:     fmt.Sprintf("racer_%d", i),
+				Email:        "racer@example.com",
+				PasswordHash: "not-a-password-hash",
+			})
+			errs <- err
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+	close(errs)
+
+	created := 0
+	for err := range errs {
+		switch {
+		case err == nil:
+			created++
+		case errors.Is(err, ErrUserAlreadyExists):
+		default:
+			t.Fatalf("CreateUser() error = %v, want nil or %v", err, ErrUserAlreadyExists)
+		}
+	}
+	if created != 1 {
+		t.Fatalf("%d registrations succeeded, want 1", created)
+	}
+	if count := dbtest.Count(t, pool, `SELECT COUNT(*) FROM users WHERE email = $1`, "racer@example.com"); count != 1 {
+		t.Fatalf("%d users with the email, want 1", count)
+	}
+}
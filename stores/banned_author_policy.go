@@ -0,0 +1,78 @@
+package stores
+
+import (
+	"context"
+	"log"
+	"os"
+
+	"github.com/datarohit/gopher-social-backend/models"
+)
+
+// Policies for posts and comments of banned authors in lists, selected with BANNED_AUTHOR_CONTENT.
+// Moderators and admins always see the content with its author.
+const (
+	BannedAuthorContentShow      = "show"
+	BannedAuthorContentHide      = "hide"
+	BannedAuthorContentTombstone = "tombstone"
+)
+
+// BannedAuthorTombstoneUsername is the username of the author shown instead of a banned author with the tombstone policy.
+const BannedAuthorTombstoneUsername = "[banned]"
+
+// bannedAuthorContent is the policy for content of banned authors in lists.
+var bannedAuthorContent = bannedAuthorContentFromEnv()
+
+// bannedAuthorContentFromEnv reads BANNED_AUTHOR_CONTENT, defaulting to show.
+func bannedAuthorContentFromEnv() string {
+	policy := os.Getenv("BANNED_AUTHOR_CONTENT")
+	switch policy {
+	case BannedAuthorContentShow, BannedAuthorContentHide, BannedAuthorContentTombstone:
+		return policy
+	case "":
+		return BannedAuthorContentShow
+	default:
+		log.Printf("Warning: BANNED_AUTHOR_CONTENT must be show, hide or tombstone, got %q. Using default value: %s", policy, BannedAuthorContentShow)
+		return BannedAuthorContentShow
+	}
+}
+
+// moderatorViewerKey is the context key marking requests of moderators and admins.
+type moderatorViewerKey struct{}
+
+// WithModeratorViewer marks a context as belonging to a request of a moderator or admin,
+// who see content of banned authors regardless of BANNED_AUTHOR_CONTENT and may comment
+// on posts regardless of MAX_COMMENTS_PER_POST.
+//
+// Parameters:
+//   - ctx (context.Context): Context of the request.
+//
+// Returns:
+//   - context.Context: The context marked as a moderator request.
+func WithModeratorViewer(ctx context.Context) context.Context {
+	return context.WithValue(ctx, moderatorViewerKey{}, true)
+}
+
+// isModeratorViewer reports whether the context was marked by WithModeratorViewer.
+func isModeratorViewer(ctx context.Context) bool {
+	moderator, _ := ctx.Value(moderatorViewerKey{}).(bool)
+	return moderator
+}
+
+// bannedAuthorCondition returns the SQL condition on an author ID column that hides content of banned authors
+// from the viewer of the context. It is TRUE unless the hide policy applies to the viewer.
+// The column is always a constant of the calling query, never user input.
+func bannedAuthorCondition(ctx context.Context, authorIDColumn string) string {
+	if bannedAuthorContent != BannedAuthorContentHide || isModeratorViewer(ctx) {
+		return "TRUE"
+	}
+	return "NOT EXISTS (SELECT 1 FROM users bu WHERE bu.id = " + authorIDColumn + " AND bu.banned = TRUE)"
+}
+
+// tombstoneBannedAuthor returns the author to show to the viewer of the context. A banned author is replaced
+// with a tombstone carrying no details of the user when the tombstone policy applies to the viewer.
+func tombstoneBannedAuthor(ctx context.Context, author *models.User) *models.User {
+	if author == nil || !author.Banned || bannedAuthorContent != BannedAuthorContentTombstone || isModeratorViewer(ctx) {
+		return author
+	}
+	return &models.User{Username: BannedAuthorTombstoneUsername, Banned: true}
+}
@@ -0,0 +1,152 @@
+package stores
+
+import (
+	"context"
+	"testing"
+
+	"github.com/datarohit/gopher-social-backend/database/dbtest"
+	"github.com/datarohit/gopher-social-backend/models"
+	"github.com/google/uuid"
+)
+
+// setBannedAuthorContent switches the banned author policy for the duration of the test.
+func setBannedAuthorContent(t *testing.T, policy string) {
+	t.Helper()
+
+	previous := bannedAuthorContent
+	bannedAuthorContent = policy
+	t.Cleanup(func() { bannedAuthorContent = previous })
+}
+
+func TestBannedAuthorPolicy(t *testing.T) {
+	const hideCondition = "NOT EXISTS (SELECT 1 FROM users bu WHERE bu.id = p.author_id AND bu.banned = TRUE)"
+
+	tests := []struct {
+		name          string
+		policy        string
+		moderator     bool
+		wantCondition string
+		wantTombstone bool
+	}{
+		{name: "show to user", policy: BannedAuthorContentShow, wantCondition: "TRUE"},
+		{name: "hide from user", policy: BannedAuthorContentHide, wantCondition: hideCondition},
+		{name: "hide from moderator", policy: BannedAuthorContentHide, moderator: true, wantCondition: "TRUE"},
+		{name: "tombstone for user", policy: BannedAuthorContentTombstone, wantCondition: "TRUE", wantTombstone: true},
+		{name: "tombstone for moderator", policy: BannedAuthorContentTombstone, moderator: true, wantCondition: "TRUE"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setBannedAuthorContent(t, tt.policy)
+			ctx := context.Background()
+			if tt.moderator {
+				ctx = WithModeratorViewer(ctx)
+			}
+
+			if got := bannedAuthorCondition(ctx, "p.author_id"); got != tt.wantCondition {
+				t.Fatalf("bannedAuthorCondition() = %q, want %q", got, tt.wantCondition)
+			}
+
+			banned := &models.User{ID: uuid.New(), Username: "spammer", Email: "spammer@example.com", Banned: true, Role: &models.Role{Level: 1}}
+			got := tombstoneBannedAuthor(ctx, banned)
+			if !tt.wantTombstone {
+				if got != banned {
+					t.Fatalf("tombstoneBannedAuthor() = %+v, want the author unchanged", got)
+				}
+			} else if got.Username != BannedAuthorTombstoneUsername || got.ID != uuid.Nil || got.Email != "" || got.Role != nil || !got.Banned {
+				t.Fatalf("tombstoneBannedAuthor() = %+v, want a tombstone without user details", got)
+			}
+
+			active := &models.User{ID: uuid.New(), Username: "gopher"}
+			if got := tombstoneBannedAuthor(ctx, active); got != active {
+				t.Fatalf("tombstoneBannedAuthor() of an active author = %+v, want the author unchanged", got)
+			}
+			if got := tombstoneBannedAuthor(ctx, nil); got != nil {
+				t.Fatalf("tombstoneBannedAuthor(nil) = %+v, want nil", got)
+			}
+		})
+	}
+}
+
+func TestBannedAuthorContentFromEnv(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{value: "", want: BannedAuthorContentShow},
+		{value: "show", want: BannedAuthorContentShow},
+		{value: "hide", want: BannedAuthorContentHide},
+		{value: "tombstone", want: BannedAuthorContentTombstone},
+		{value: "delete", want: BannedAuthorContentShow},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			t.Setenv("BANNED_AUTHOR_CONTENT", tt.value)
+			if got := bannedAuthorContentFromEnv(); got != tt.want {
+				t.Fatalf("bannedAuthorContentFromEnv() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestBannedAuthorCommentList checks the policies end to end on the comments of a post, one by an active
+// author and one by a banned author.
+func TestBannedAuthorCommentList(t *testing.T) {
+	pool := dbtest.NewPool(t)
+	commentStore := NewCommentStore(pool)
+
+	authorID := dbtest.CreateUser(t, pool, "author", 1)
+	bannedID := dbtest.CreateUser(t, pool, "spammer", 1)
+	postID := dbtest.CreatePost(t, pool, authorID)
+	dbtest.CreateComment(t, pool, authorID, postID)
+	dbtest.CreateComment(t, pool, bannedID, postID)
+	dbtest.Exec(t, pool, `UPDATE users SET banned = TRUE, is_active = FALSE WHERE id = $1`, bannedID)
+
+	tests := []struct {
+		name          string
+		policy        string
+		moderator     bool
+		wantUsernames []string
+	}{
+		{name: "show", policy: BannedAuthorContentShow, wantUsernames: []string{"author", "spammer"}},
+		{name: "hide", policy: BannedAuthorContentHide, wantUsernames: []string{"author"}},
+		{name: "tombstone", policy: BannedAuthorContentTombstone, wantUsernames: []string{"author", BannedAuthorTombstoneUsername}},
+		{name: "hide for moderator", policy: BannedAuthorContentHide, moderator: true, wantUsernames: []string{"author", "spammer"}},
+		{name: "tombstone for moderator", policy: BannedAuthorContentTombstone, moderator: true, wantUsernames: []string{"author", "spammer"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setBannedAuthorContent(t, tt.policy)
+			ctx := context.Background()
+			if tt.moderator {
+				ctx = WithModeratorViewer(ctx)
+			}
+
+			comments, pagination, err := commentStore.ListCommentsByPostIDLatestFirst(ctx, postID, uuid.Nil, 1, 10)
+			if err != nil {
+				t.Fatalf("ListCommentsByPostIDLatestFirst() error = %v", err)
+			}
+			if pagination.TotalItems != len(tt.wantUsernames) {
+				t.Fatalf("total items = %d, want %d", pagination.TotalItems, len(tt.wantUsernames))
+			}
+
+			got := map[string]bool{}
+			for _, comment := range comments {
+				got[comment.Author.Username] = true
+				if comment.Author.Username == BannedAuthorTombstoneUsername && comment.Author.ID != uuid.Nil {
+					t.Fatalf("tombstone author carries user ID %s", comment.Author.ID)
+				}
+			}
+			if len(got) != len(tt.wantUsernames) {
+				t.Fatalf("comment authors = %v, want %v", got, tt.wantUsernames)
+			}
+			for _, username := range tt.wantUsernames {
+				if !got[username] {
+					t.Fatalf("comment authors = %v, want %v", got, tt.wantUsernames)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,224 @@
+package stores
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/datarohit/gopher-social-backend/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type BlockStore struct {
+	dbPool *pgxpool.Pool
+}
+
+// NewBlockStore creates a new BlockStore.
+//
+// Parameters:
+//   - dbPool (*pgxpool.Pool): Pgx connection pool.
+//
+// Returns:
+//   - *BlockStore: BlockStore instance.
+func NewBlockStore(dbPool *pgxpool.Pool) *BlockStore {
+	return &BlockStore{
+		dbPool: dbPool,
+	}
+}
+
+// ErrBlockedByAuthor is returned when the author of a post or comment has blocked the requesting user.
+var ErrBlockedByAuthor = errors.New("blocked by content author")
+
+// ErrAlreadyBlocked is returned when a user has already blocked another user.
+var ErrAlreadyBlocked = errors.New("already blocked user")
+
+// ErrNotBlocked is returned when a user has not blocked another user.
+var ErrNotBlocked = errors.New("not blocked user")
+
+// Block records that a user has blocked another user.
+// Banned and inactive users can be blocked as well.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the database operation.
+//   - blockerID (uuid.UUID): ID of the user blocking.
+//   - blockedID (uuid.UUID): ID of the user to block.
+//
+// Returns:
+//   - error: An error if blocking fails, ErrUserNotFound if the user to block does not exist, or ErrAlreadyBlocked.
+func (bs *BlockStore) Block(ctx context.Context, blockerID uuid.UUID, blockedID uuid.UUID) error {
+	commandTag, err := bs.dbPool.Exec(ctx, `
+		INSERT INTO blocks (blocker_id, blocked_id)
+		SELECT $1, u.id FROM users u
+		WHERE u.id = $2
+		ON CONFLICT (blocker_id, blocked_id) DO NOTHING
+	`, blockerID, blockedID)
+	if err != nil {
+		return fmt.Errorf("failed to block user: %w", err)
+	}
+	if commandTag.RowsAffected() > 0 {
+		return nil
+	}
+
+	var exists bool
+	err = bs.dbPool.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM users WHERE id = $1)`, blockedID).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("failed to check blocked user: %w", err)
+	}
+	if !exists {
+		return ErrUserNotFound
+	}
+	return ErrAlreadyBlocked
+}
+
+// Unblock removes the block of a user on another user.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the database operation.
+//   - blockerID (uuid.UUID): ID of the user unblocking.
+//   - blockedID (uuid.UUID): ID of the user to unblock.
+//
+// Returns:
+//   - error: An error if unblocking fails or ErrNotBlocked if the user is not blocked.
+func (bs *BlockStore) Unblock(ctx context.Context, blockerID uuid.UUID, blockedID uuid.UUID) error {
+	commandTag, err := bs.dbPool.Exec(ctx, `
+		DELETE FROM blocks
+		WHERE blocker_id = $1 AND blocked_id = $2
+	`, blockerID, blockedID)
+	if err != nil {
+		return fmt.Errorf("failed to unblock user: %w", err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return ErrNotBlocked
+	}
+	return nil
+}
+
+// CheckPostAuthorBlock checks in one query that a post exists and that its author has not blocked a user.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the database operation.
+//   - postID (uuid.UUID): ID of the post.
+//   - userID (uuid.UUID): ID of the user reacting to the post.
+//
+// Returns:
+//   - error: ErrPostNotFound if the post does not exist, ErrBlockedByAuthor if its author has blocked the user,
+//     or an error if the check fails.
+func (bs *BlockStore) CheckPostAuthorBlock(ctx context.Context, postID uuid.UUID, userID uuid.UUID) error {
+	var blocked bool
+	err := bs.dbPool.QueryRow(ctx, `
+		SELECT EXISTS (SELECT 1 FROM blocks b WHERE b.blocker_id = p.author_id AND b.blocked_id = $2)
+		FROM posts p
+		WHERE p.id = $1
+	`, postID, userID).Scan(&blocked)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrPostNotFound
+		}
+		return fmt.Errorf("failed to check post author block: %w", err)
+	}
+	if blocked {
+		return ErrBlockedByAuthor
+	}
+	return nil
+}
+
+// CheckCommentAuthorBlock checks in one query that a comment exists on a post and that its author has not blocked a user.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the database operation.
+//   - commentID (uuid.UUID): ID of the comment.
+//   - postID (uuid.UUID): ID of the post the comment must belong to.
+//   - userID (uuid.UUID): ID of the user reacting to the comment.
+//
+// Returns:
+//   - error: ErrCommentNotFound if the comment does not exist on the post, ErrBlockedByAuthor if its author has
+//     blocked the user, or an error if the check fails.
+func (bs *BlockStore) CheckCommentAuthorBlock(ctx context.Context, commentID uuid.UUID, postID uuid.UUID, userID uuid.UUID) error {
+	var blocked bool
+	err := bs.dbPool.QueryRow(ctx, `
+		SELECT EXISTS (SELECT 1 FROM blocks b WHERE b.blocker_id = c.author_id AND b.blocked_id = $3)
+		FROM comments c
+		WHERE c.id = $1 AND c.post_id = $2
+	`, commentID, postID, userID).Scan(&blocked)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrCommentNotFound
+		}
+		return fmt.Errorf("failed to check comment author block: %w", err)
+	}
+	if blocked {
+		return ErrBlockedByAuthor
+	}
+	return nil
+}
+
+// ListBlocked retrieves the users a user has blocked, most recently blocked first.
+// Banned and inactive users are included, so they can still be unblocked.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the database operation.
+//   - blockerID (uuid.UUID): ID of the user whose blocks to list.
+//   - pageNumber (int): Page number for pagination.
+//   - pageSize (int): Page size for pagination.
+//
+// Returns:
+//   - []*models.BlockedUser: List of blocked users with their role, follower and following counts and block time, empty if there are none.
+//   - *models.Pagination: Pagination metadata including the total number of blocked users.
+//   - error: An error if fetching blocked users fails.
+func (bs *BlockStore) ListBlocked(ctx context.Context, blockerID uuid.UUID, pageNumber int, pageSize int) ([]*models.BlockedUser, *models.Pagination, error) {
+	offset := (pageNumber - 1) * pageSize
+	rows, err := bs.dbPool.Query(ctx, `
+		SELECT
+			u.id, u.username, u.created_at,
+			r.level, r.description,
+			u.followers_count,
+			u.following_count,
+			b.created_at,
+			COUNT(*) OVER() as total_blocked
+		FROM blocks b
+		INNER JOIN users u ON b.blocked_id = u.id
+		INNER JOIN roles r ON u.role_id = r.id
+		WHERE b.blocker_id = $1
+		ORDER BY b.created_at DESC, u.id
+		LIMIT $2 OFFSET $3
+	`, blockerID, pageSize, offset)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get blocked users: %w", err)
+	}
+	defer rows.Close()
+
+	blocked := []*models.BlockedUser{}
+	var totalBlocked int
+	for rows.Next() {
+		blockedUser := &models.BlockedUser{User: &models.UserSummary{Role: &models.Role{}}}
+		err := rows.Scan(
+			&blockedUser.User.ID, &blockedUser.User.Username, &blockedUser.User.CreatedAt,
+			&blockedUser.User.Role.Level, &blockedUser.User.Role.Description,
+			&blockedUser.User.Followers, &blockedUser.User.Following,
+			&blockedUser.BlockedAt,
+			&totalBlocked,
+		)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to scan blocked user row: %w", err)
+		}
+		blocked = append(blocked, blockedUser)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("error during blocked users rows iteration: %w", err)
+	}
+
+	// A page past the end has no rows to carry the window count, so count the blocked users separately.
+	if len(blocked) == 0 && offset > 0 {
+		err := bs.dbPool.QueryRow(ctx, `
+			SELECT COUNT(*) FROM blocks WHERE blocker_id = $1
+		`, blockerID).Scan(&totalBlocked)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to count blocked users: %w", err)
+		}
+	}
+
+	return blocked, newPagination(pageNumber, pageSize, totalBlocked), nil
+}
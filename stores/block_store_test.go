@@ -0,0 +1,54 @@
+package stores
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/datarohit/gopher-social-backend/database/dbtest"
+	"github.com/google/uuid"
+)
+
+func TestCheckAuthorBlock(t *testing.T) {
+	pool := dbtest.NewPool(t)
+	ctx := context.Background()
+	store := NewBlockStore(pool)
+
+	authorID := dbtest.CreateUser(t, pool, "author", 1)
+	blockedID := dbtest.CreateUser(t, pool, "blocked", 1)
+	readerID := dbtest.CreateUser(t, pool, "reader", 1)
+	postID := dbtest.CreatePost(t, pool, authorID)
+	otherPostID := dbtest.CreatePost(t, pool, readerID)
+	commentID := dbtest.CreateComment(t, pool, authorID, postID)
+	if err := store.Block(ctx, authorID, blockedID); err != nil {
+		t.Fatalf("Block() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		check   func() error
+		wantErr error
+	}{
+		{name: "post of a blocking author", check: func() error { return store.CheckPostAuthorBlock(ctx, postID, blockedID) }, wantErr: ErrBlockedByAuthor},
+		{name: "post of another author", check: func() error { return store.CheckPostAuthorBlock(ctx, postID, readerID) }},
+		{name: "missing post", check: func() error { return store.CheckPostAuthorBlock(ctx, uuid.New(), readerID) }, wantErr: ErrPostNotFound},
+		{name: "comment of a blocking author", check: func() error { return store.CheckCommentAuthorBlock(ctx, commentID, postID, blockedID) }, wantErr: ErrBlockedByAuthor},
+		{name: "comment of another author", check: func() error { return store.CheckCommentAuthorBlock(ctx, commentID, postID, readerID) }},
+		{name: "comment on another post", check: func() error { return store.CheckCommentAuthorBlock(ctx, commentID, otherPostID, readerID) }, wantErr: ErrCommentNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.check(); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if err := store.Unblock(ctx, authorID, blockedID); err != nil {
+		t.Fatalf("Unblock() error = %v", err)
+	}
+	if err := store.CheckPostAuthorBlock(ctx, postID, blockedID); err != nil {
+		t.Fatalf("CheckPostAuthorBlock() after unblocking error = %v, want nil", err)
+	}
+}
@@ -0,0 +1,175 @@
+package stores
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/datarohit/gopher-social-backend/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type BookmarkStore struct {
+	dbPool *pgxpool.Pool
+}
+
+// NewBookmarkStore creates a new BookmarkStore.
+//
+// Parameters:
+//   - dbPool (*pgxpool.Pool): Pgx connection pool.
+//
+// Returns:
+//   - *BookmarkStore: BookmarkStore instance.
+func NewBookmarkStore(dbPool *pgxpool.Pool) *BookmarkStore {
+	return &BookmarkStore{
+		dbPool: dbPool,
+	}
+}
+
+// ErrBookmarkAlreadyExists is returned when a user has already bookmarked a post.
+var ErrBookmarkAlreadyExists = errors.New("post already bookmarked")
+
+// ErrBookmarkNotFound is returned when a user has not bookmarked a post.
+var ErrBookmarkNotFound = errors.New("bookmark not found")
+
+// Add bookmarks a post for a user. Bookmarks are private and only listed to the user who created them.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the database operation.
+//   - userID (uuid.UUID): ID of the user bookmarking the post.
+//   - postID (uuid.UUID): ID of the post to bookmark.
+//
+// Returns:
+//   - *models.Bookmark: The created bookmark.
+//   - error: ErrBookmarkAlreadyExists if the post is already bookmarked, or other errors during database query.
+func (bs *BookmarkStore) Add(ctx context.Context, userID uuid.UUID, postID uuid.UUID) (*models.Bookmark, error) {
+	var bookmark models.Bookmark
+	err := bs.dbPool.QueryRow(ctx, `
+		INSERT INTO bookmarks (user_id, post_id)
+		VALUES ($1, $2)
+		RETURNING user_id, post_id, created_at
+	`, userID, postID).Scan(&bookmark.UserID, &bookmark.PostID, &bookmark.CreatedAt)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, ErrBookmarkAlreadyExists
+		}
+		return nil, fmt.Errorf("failed to bookmark post: %w", err)
+	}
+
+	return &bookmark, nil
+}
+
+// Remove deletes the bookmark of a user on a post.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the database operation.
+//   - userID (uuid.UUID): ID of the user removing the bookmark.
+//   - postID (uuid.UUID): ID of the bookmarked post.
+//
+// Returns:
+//   - error: ErrBookmarkNotFound if the user has not bookmarked the post, or other errors during database query.
+func (bs *BookmarkStore) Remove(ctx context.Context, userID uuid.UUID, postID uuid.UUID) error {
+	commandTag, err := bs.dbPool.Exec(ctx, `
+		DELETE FROM bookmarks
+		WHERE user_id = $1 AND post_id = $2
+	`, userID, postID)
+	if err != nil {
+		return fmt.Errorf("failed to remove bookmark: %w", err)
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return ErrBookmarkNotFound
+	}
+
+	return nil
+}
+
+// ListByUser retrieves the posts bookmarked by a user with pagination, most recently bookmarked first.
+// Each post includes like, dislike and comment counts, and author information including follower and following counts.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the database operation.
+//   - userID (uuid.UUID): ID of the user.
+//   - pageNumber (int): Page number for pagination.
+//   - pageSize (int): Page size for pagination.
+//
+// Returns:
+//   - []*models.BookmarkedPost: List of bookmarked posts, empty if the user has no bookmarks.
+//   - *models.Pagination: Pagination metadata including the total number of bookmarks.
+//   - error: An error if the database query fails.
+func (bs *BookmarkStore) ListByUser(ctx context.Context, userID uuid.UUID, pageNumber int, pageSize int) ([]*models.BookmarkedPost, *models.Pagination, error) {
+	offset := (pageNumber - 1) * pageSize
+	rows, err := tracedQuery(ctx, bs.dbPool, "BookmarkStore.ListByUser", `
+		SELECT
+			b.created_at,
+			p.id, p.author_id, p.title, p.sub_title, p.description, p.content, p.created_at, p.updated_at,
+			u.id, u.username, u.email, u.banned, u.is_active, u.created_at, u.updated_at,
+			r.level, r.description,
+			(SELECT COUNT(*) FROM post_likes pl WHERE pl.post_id = p.id AND pl.liked = TRUE AND `+selfLikeCondition("pl.user_id", "p.author_id")+`) as likes_count,
+			(SELECT COUNT(*) FROM post_likes pd WHERE pd.post_id = p.id AND pd.liked = FALSE) as dislikes_count,
+			(SELECT COUNT(*) FROM comments cm WHERE cm.post_id = p.id) as comments_count,
+			u.followers_count,
+			u.following_count,
+			COUNT(*) OVER() as total_bookmarks
+		FROM bookmarks b
+		INNER JOIN posts p ON b.post_id = p.id
+		INNER JOIN users u ON p.author_id = u.id
+		INNER JOIN roles r ON u.role_id = r.id
+		WHERE b.user_id = $1 AND `+bannedAuthorCondition(ctx, "p.author_id")+`
+		ORDER BY b.created_at DESC
+		LIMIT $2 OFFSET $3
+	`, userID, pageSize, offset)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list bookmarks: %w", err)
+	}
+	defer rows.Close()
+
+	bookmarks := []*models.BookmarkedPost{}
+	var totalBookmarks int
+	for rows.Next() {
+		post := &models.Post{Author: &models.User{Role: &models.Role{}}}
+		bookmark := &models.BookmarkedPost{Post: post}
+		err := rows.Scan(
+			&bookmark.BookmarkedAt,
+			&post.ID, &post.AuthorID, &post.Title, &post.SubTitle, &post.Description, &post.Content, &post.CreatedAt, &post.UpdatedAt,
+			&post.Author.ID, &post.Author.Username, &post.Author.Email, &post.Author.Banned, &post.Author.IsActive, &post.Author.CreatedAt, &post.Author.UpdatedAt,
+			&post.Author.Role.Level, &post.Author.Role.Description,
+			&post.Likes, &post.Dislikes, &post.Comments,
+			&post.Author.Followers, &post.Author.Following,
+			&totalBookmarks,
+		)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to scan bookmark row: %w", err)
+		}
+		post.Author = tombstoneBannedAuthor(ctx, post.Author)
+		bookmarks = append(bookmarks, bookmark)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("error during bookmarks rows iteration: %w", err)
+	}
+
+	// A page past the end has no rows to carry the window count, so count the bookmarks separately.
+	if len(bookmarks) == 0 && offset > 0 {
+		err := bs.dbPool.QueryRow(ctx, `
+			SELECT COUNT(*)
+			FROM bookmarks b
+			INNER JOIN posts p ON b.post_id = p.id
+			WHERE b.user_id = $1 AND `+bannedAuthorCondition(ctx, "p.author_id")+`
+		`, userID).Scan(&totalBookmarks)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to count bookmarks: %w", err)
+		}
+	}
+
+	posts := make([]*models.Post, len(bookmarks))
+	for i, bookmark := range bookmarks {
+		posts[i] = bookmark.Post
+	}
+	if err := attachQuotedPosts(ctx, bs.dbPool, posts); err != nil {
+		return nil, nil, err
+	}
+
+	return bookmarks, newPagination(pageNumber, pageSize, totalBookmarks), nil
+}
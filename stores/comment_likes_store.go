@@ -47,11 +47,12 @@ var ErrCommentDislikeNotFound = errors.New("comment dislike not found")
 //   - ctx (context.Context): Context for the database operation.
 //   - userID (uuid.UUID): ID of the user who liked the comment.
 //   - commentID (uuid.UUID): ID of the comment that was liked.
+//   - postID (uuid.UUID): ID of the post the comment must belong to.
 //
 // Returns:
 //   - *models.CommentLike: The created CommentLike object if successful.
-//   - error: An error if creating the like record fails or if the like already exists.
-func (cls *CommentLikeStore) LikeComment(ctx context.Context, userID uuid.UUID, commentID uuid.UUID) (*models.CommentLike, error) {
+//   - error: ErrCommentNotFound if the comment does not belong to the post, or an error if creating the like record fails or if the like already exists.
+func (cls *CommentLikeStore) LikeComment(ctx context.Context, userID uuid.UUID, commentID uuid.UUID, postID uuid.UUID) (*models.CommentLike, error) {
 	var existingLike models.CommentLike
 	err := cls.dbPool.QueryRow(ctx, `SELECT user_id, comment_id, liked, created_at FROM comment_likes WHERE user_id = $1 AND comment_id = $2`, userID, commentID).Scan(
 		&existingLike.UserID, &existingLike.CommentID, &existingLike.Liked, &existingLike.CreatedAt,
@@ -66,11 +67,15 @@ func (cls *CommentLikeStore) LikeComment(ctx context.Context, userID uuid.UUID,
 			UPDATE comment_likes
 			SET liked = TRUE
 			WHERE user_id = $1 AND comment_id = $2
+			AND EXISTS (SELECT 1 FROM comments WHERE id = $2 AND post_id = $3)
 			RETURNING user_id, comment_id, liked, created_at
-		`, userID, commentID).Scan(
+		`, userID, commentID, postID).Scan(
 			&updatedLike.UserID, &updatedLike.CommentID, &updatedLike.Liked, &updatedLike.CreatedAt,
 		)
 		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, ErrCommentNotFound
+			}
 			return nil, fmt.Errorf("failed to update comment like from dislike to like: %w", err)
 		}
 		return &updatedLike, nil
@@ -82,12 +87,16 @@ func (cls *CommentLikeStore) LikeComment(ctx context.Context, userID uuid.UUID,
 	var createdLike models.CommentLike
 	err = cls.dbPool.QueryRow(ctx, `
 		INSERT INTO comment_likes (user_id, comment_id, liked)
-		VALUES ($1, $2, TRUE)
+		SELECT $1, $2, TRUE
+		WHERE EXISTS (SELECT 1 FROM comments WHERE id = $2 AND post_id = $3)
 		RETURNING user_id, comment_id, liked, created_at
-	`, userID, commentID).Scan(
+	`, userID, commentID, postID).Scan(
 		&createdLike.UserID, &createdLike.CommentID, &createdLike.Liked, &createdLike.CreatedAt,
 	)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrCommentNotFound
+		}
 		return nil, fmt.Errorf("failed to like comment: %w", err)
 	}
 
@@ -127,11 +136,12 @@ func (cls *CommentLikeStore) UnlikeComment(ctx context.Context, userID uuid.UUID
 //   - ctx (context.Context): Context for the database operation.
 //   - userID (uuid.UUID): ID of the user who disliked the comment.
 //   - commentID (uuid.UUID): ID of the comment that was disliked.
+//   - postID (uuid.UUID): ID of the post the comment must belong to.
 //
 // Returns:
 //   - *models.CommentLike: The created CommentLike object if successful.
-//   - error: An error if creating the dislike record fails or if the dislike already exists.
-func (cls *CommentLikeStore) DislikeComment(ctx context.Context, userID uuid.UUID, commentID uuid.UUID) (*models.CommentLike, error) {
+//   - error: ErrCommentNotFound if the comment does not belong to the post, or an error if creating the dislike record fails or if the dislike already exists.
+func (cls *CommentLikeStore) DislikeComment(ctx context.Context, userID uuid.UUID, commentID uuid.UUID, postID uuid.UUID) (*models.CommentLike, error) {
 	var existingDislike models.CommentLike
 	err := cls.dbPool.QueryRow(ctx, `SELECT user_id, comment_id, liked, created_at FROM comment_likes WHERE user_id = $1 AND comment_id = $2`, userID, commentID).Scan(
 		&existingDislike.UserID, &existingDislike.CommentID, &existingDislike.Liked, &existingDislike.CreatedAt,
@@ -146,11 +156,15 @@ func (cls *CommentLikeStore) DislikeComment(ctx context.Context, userID uuid.UUI
 			UPDATE comment_likes
 			SET liked = FALSE
 			WHERE user_id = $1 AND comment_id = $2
+			AND EXISTS (SELECT 1 FROM comments WHERE id = $2 AND post_id = $3)
 			RETURNING user_id, comment_id, liked, created_at
-		`, userID, commentID).Scan(
+		`, userID, commentID, postID).Scan(
 			&updatedDislike.UserID, &updatedDislike.CommentID, &updatedDislike.Liked, &updatedDislike.CreatedAt,
 		)
 		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, ErrCommentNotFound
+			}
 			return nil, fmt.Errorf("failed to update comment like from like to dislike: %w", err)
 		}
 		return &updatedDislike, nil
@@ -162,12 +176,16 @@ func (cls *CommentLikeStore) DislikeComment(ctx context.Context, userID uuid.UUI
 	var createdDislike models.CommentLike
 	err = cls.dbPool.QueryRow(ctx, `
 		INSERT INTO comment_likes (user_id, comment_id, liked)
-		VALUES ($1, $2, FALSE)
+		SELECT $1, $2, FALSE
+		WHERE EXISTS (SELECT 1 FROM comments WHERE id = $2 AND post_id = $3)
 		RETURNING user_id, comment_id, liked, created_at
-	`, userID, commentID).Scan(
+	`, userID, commentID, postID).Scan(
 		&createdDislike.UserID, &createdDislike.CommentID, &createdDislike.Liked, &createdDislike.CreatedAt,
 	)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrCommentNotFound
+		}
 		return nil, fmt.Errorf("failed to dislike comment: %w", err)
 	}
 
@@ -211,9 +229,10 @@ func (cls *CommentLikeStore) UndislikeComment(ctx context.Context, userID uuid.U
 //   - pageSize (int): Page size for pagination.
 //
 // Returns:
-//   - []*models.Comment: A slice of Comment pointers, or nil if no comments are found for the given user ID and post ID.
+//   - []*models.Comment: A slice of Comment pointers, empty if no comments are found for the given user ID and post ID.
+//   - *models.Pagination: Pagination metadata including the total number of matching comments.
 //   - error: An error if fetching the comments fails.
-func (cls *CommentLikeStore) ListLikedCommentsByUserIDForPost(ctx context.Context, userID uuid.UUID, postID uuid.UUID, pageNumber int, pageSize int) ([]*models.Comment, error) {
+func (cls *CommentLikeStore) ListLikedCommentsByUserIDForPost(ctx context.Context, userID uuid.UUID, postID uuid.UUID, pageNumber int, pageSize int) ([]*models.Comment, *models.Pagination, error) {
 	return cls.listLikedCommentsByUserStatusForPostByUserID(ctx, userID, postID, pageNumber, pageSize, true)
 }
 
@@ -228,9 +247,10 @@ func (cls *CommentLikeStore) ListLikedCommentsByUserIDForPost(ctx context.Contex
 //   - pageSize (int): Page size for pagination.
 //
 // Returns:
-//   - []*models.Comment: A slice of Comment pointers, or nil if no comments are found for the given user ID and post ID.
+//   - []*models.Comment: A slice of Comment pointers, empty if no comments are found for the given user ID and post ID.
+//   - *models.Pagination: Pagination metadata including the total number of matching comments.
 //   - error: An error if fetching the comments fails.
-func (cls *CommentLikeStore) ListDislikedCommentsByUserIDForPost(ctx context.Context, userID uuid.UUID, postID uuid.UUID, pageNumber int, pageSize int) ([]*models.Comment, error) {
+func (cls *CommentLikeStore) ListDislikedCommentsByUserIDForPost(ctx context.Context, userID uuid.UUID, postID uuid.UUID, pageNumber int, pageSize int) ([]*models.Comment, *models.Pagination, error) {
 	return cls.listLikedCommentsByUserStatusForPostByUserID(ctx, userID, postID, pageNumber, pageSize, false)
 }
 
@@ -245,9 +265,10 @@ func (cls *CommentLikeStore) ListDislikedCommentsByUserIDForPost(ctx context.Con
 //   - pageSize (int): Page size for pagination.
 //
 // Returns:
-//   - []*models.Comment: A slice of Comment pointers, or nil if no comments are found for the given user identifier and post ID.
+//   - []*models.Comment: A slice of Comment pointers, empty if no comments are found for the given user identifier and post ID.
+//   - *models.Pagination: Pagination metadata including the total number of matching comments.
 //   - error: An error if fetching the comments fails.
-func (cls *CommentLikeStore) ListLikedCommentsByUserIdentifierForPost(ctx context.Context, identifier string, postID uuid.UUID, pageNumber int, pageSize int) ([]*models.Comment, error) {
+func (cls *CommentLikeStore) ListLikedCommentsByUserIdentifierForPost(ctx context.Context, identifier string, postID uuid.UUID, pageNumber int, pageSize int) ([]*models.Comment, *models.Pagination, error) {
 	return cls.listLikedCommentsByUserStatusForPost(ctx, identifier, postID, pageNumber, pageSize, true)
 }
 
@@ -262,9 +283,10 @@ func (cls *CommentLikeStore) ListLikedCommentsByUserIdentifierForPost(ctx contex
 //   - pageSize (int): Page size for pagination.
 //
 // Returns:
-//   - []*models.Comment: A slice of Comment pointers, or nil if no comments are found for the given user identifier and post ID.
+//   - []*models.Comment: A slice of Comment pointers, empty if no comments are found for the given user identifier and post ID.
+//   - *models.Pagination: Pagination metadata including the total number of matching comments.
 //   - error: An error if fetching the comments fails.
-func (cls *CommentLikeStore) ListDislikedCommentsByUserIdentifierForPost(ctx context.Context, identifier string, postID uuid.UUID, pageNumber int, pageSize int) ([]*models.Comment, error) {
+func (cls *CommentLikeStore) ListDislikedCommentsByUserIdentifierForPost(ctx context.Context, identifier string, postID uuid.UUID, pageNumber int, pageSize int) ([]*models.Comment, *models.Pagination, error) {
 	return cls.listLikedCommentsByUserStatusForPost(ctx, identifier, postID, pageNumber, pageSize, false)
 }
 
@@ -280,23 +302,24 @@ func (cls *CommentLikeStore) ListDislikedCommentsByUserIdentifierForPost(ctx con
 //   - liked (bool): True to retrieve liked comments, false for disliked comments.
 //
 // Returns:
-//   - []*models.Comment: A slice of Comment pointers, or nil if no comments are found for the given like status and user identifier.
+//   - []*models.Comment: A slice of Comment pointers, empty if no comments are found for the given like status and user identifier.
+//   - *models.Pagination: Pagination metadata including the total number of matching comments.
 //   - error: ErrUserNotFound if user is not found, or other errors during database query.
-func (cls *CommentLikeStore) listLikedCommentsByUserStatusForPost(ctx context.Context, identifier string, postID uuid.UUID, pageNumber int, pageSize int, liked bool) ([]*models.Comment, error) {
+func (cls *CommentLikeStore) listLikedCommentsByUserStatusForPost(ctx context.Context, identifier string, postID uuid.UUID, pageNumber int, pageSize int, liked bool) ([]*models.Comment, *models.Pagination, error) {
 	authStore := NewAuthStore(cls.dbPool)
 	user, err := authStore.GetUserByUsernameOrEmail(ctx, identifier)
 	if err != nil {
 		if errors.Is(err, ErrUserNotFound) {
 			userID, uuidErr := uuid.Parse(identifier)
 			if uuidErr != nil {
-				return nil, ErrUserNotFound
+				return nil, nil, ErrUserNotFound
 			}
 			user, err = authStore.GetUserByID(ctx, userID)
 			if err != nil {
-				return nil, ErrUserNotFound
+				return nil, nil, ErrUserNotFound
 			}
 		} else {
-			return nil, fmt.Errorf("failed to get user by identifier: %w", err)
+			return nil, nil, fmt.Errorf("failed to get user by identifier: %w", err)
 		}
 	}
 
@@ -315,33 +338,36 @@ func (cls *CommentLikeStore) listLikedCommentsByUserStatusForPost(ctx context.Co
 //   - liked (bool): True to retrieve liked comments, false for disliked comments.
 //
 // Returns:
-//   - []*models.Comment: A slice of Comment pointers, or nil if no comments are found for the given like status and user identifier.
+//   - []*models.Comment: A slice of Comment pointers, empty if no comments are found for the given like status and user identifier.
+//   - *models.Pagination: Pagination metadata including the total number of matching comments.
 //   - error: ErrUserNotFound if user is not found, or other errors during database query.
-func (cls *CommentLikeStore) listLikedCommentsByUserStatusForPostByUserID(ctx context.Context, userID uuid.UUID, postID uuid.UUID, pageNumber int, pageSize int, liked bool) ([]*models.Comment, error) {
+func (cls *CommentLikeStore) listLikedCommentsByUserStatusForPostByUserID(ctx context.Context, userID uuid.UUID, postID uuid.UUID, pageNumber int, pageSize int, liked bool) ([]*models.Comment, *models.Pagination, error) {
 	offset := (pageNumber - 1) * pageSize
-	rows, err := cls.dbPool.Query(ctx, `
+	rows, err := tracedQuery(ctx, cls.dbPool, "CommentLikeStore.listLikedCommentsByUserStatusForPostByUserID", `
 		SELECT
 			c.id, c.author_id, c.post_id, c.content, c.created_at, c.updated_at,
 			u.id, u.username, u.email, u.banned, u.is_active, u.created_at, u.updated_at,
 			r.level, r.description,
-			(SELECT COUNT(*) FROM follows WHERE followee_id = u.id) as followers_count,
-			(SELECT COUNT(*) FROM follows WHERE follower_id = u.id) as following_count,
-			(SELECT COUNT(*) FROM comment_likes cl_count WHERE cl_count.comment_id = c.id AND cl_count.liked = TRUE) as likes,
-			(SELECT COUNT(*) FROM comment_likes cd_count WHERE cd_count.comment_id = c.id AND cd_count.liked = FALSE) as dislikes
+			u.followers_count,
+			u.following_count,
+			(SELECT COUNT(*) FROM comment_likes cl_count WHERE cl_count.comment_id = c.id AND cl_count.liked = TRUE AND `+selfLikeCondition("cl_count.user_id", "c.author_id")+`) as likes,
+			(SELECT COUNT(*) FROM comment_likes cd_count WHERE cd_count.comment_id = c.id AND cd_count.liked = FALSE) as dislikes,
+			COUNT(*) OVER() as total_comments
 		FROM comment_likes cl
 		INNER JOIN comments c ON cl.comment_id = c.id
 		INNER JOIN users u ON c.author_id = u.id
 		INNER JOIN roles r ON u.role_id = r.id
-		WHERE cl.user_id = $1 AND c.post_id = $2 AND cl.liked = $3
+		WHERE cl.user_id = $1 AND c.post_id = $2 AND cl.liked = $3 AND `+bannedAuthorCondition(ctx, "c.author_id")+`
 		ORDER BY c.created_at DESC
 		LIMIT $4 OFFSET $5
 	`, userID, postID, liked, pageSize, offset)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list liked comments for post: %w", err)
+		return nil, nil, fmt.Errorf("failed to list liked comments for post: %w", err)
 	}
 	defer rows.Close()
 
-	var comments []*models.Comment
+	comments := []*models.Comment{}
+	var totalComments int
 	for rows.Next() {
 		comment := &models.Comment{Author: &models.User{Role: &models.Role{}}}
 		err := rows.Scan(
@@ -350,16 +376,102 @@ func (cls *CommentLikeStore) listLikedCommentsByUserStatusForPostByUserID(ctx co
 			&comment.Author.Role.Level, &comment.Author.Role.Description,
 			&comment.Author.Followers, &comment.Author.Following,
 			&comment.Likes, &comment.Dislikes,
+			&totalComments,
 		)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan comment row: %w", err)
+			return nil, nil, fmt.Errorf("failed to scan comment row: %w", err)
 		}
+		comment.Author = tombstoneBannedAuthor(ctx, comment.Author)
 		comments = append(comments, comment)
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error during comments rows iteration: %w", err)
+		return nil, nil, fmt.Errorf("error during comments rows iteration: %w", err)
+	}
+
+	// A page past the end has no rows to carry the window count, so count the comments separately.
+	if len(comments) == 0 && offset > 0 {
+		err := cls.dbPool.QueryRow(ctx, `
+			SELECT COUNT(*)
+			FROM comment_likes cl
+			INNER JOIN comments c ON cl.comment_id = c.id
+			WHERE cl.user_id = $1 AND c.post_id = $2 AND cl.liked = $3 AND `+bannedAuthorCondition(ctx, "c.author_id")+`
+		`, userID, postID, liked).Scan(&totalComments)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to count liked comments for post: %w", err)
+		}
+	}
+
+	return comments, newPagination(pageNumber, pageSize, totalComments), nil
+}
+
+// ListUsersByCommentReaction retrieves the users who liked or disliked a comment, most recent reaction first.
+// Banned and inactive users are left out.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the database operation.
+//   - commentID (uuid.UUID): ID of the comment.
+//   - liked (bool): True to retrieve users who liked the comment, false for users who disliked it.
+//   - pageNumber (int): Page number for pagination.
+//   - pageSize (int): Page size for pagination.
+//
+// Returns:
+//   - []*models.UserSummary: List of users with their role and follower and following counts, empty if there are none.
+//   - *models.Pagination: Pagination metadata including the total number of matching users.
+//   - error: An error if fetching the users fails.
+func (cls *CommentLikeStore) ListUsersByCommentReaction(ctx context.Context, commentID uuid.UUID, liked bool, pageNumber int, pageSize int) ([]*models.UserSummary, *models.Pagination, error) {
+	offset := (pageNumber - 1) * pageSize
+	rows, err := cls.dbPool.Query(ctx, `
+		SELECT
+			u.id, u.username, u.created_at,
+			r.level, r.description,
+			u.followers_count,
+			u.following_count,
+			COUNT(*) OVER() as total_users
+		FROM comment_likes cl
+		INNER JOIN users u ON cl.user_id = u.id
+		INNER JOIN roles r ON u.role_id = r.id
+		WHERE cl.comment_id = $1 AND cl.liked = $2 AND u.banned = FALSE AND u.is_active = TRUE
+		ORDER BY cl.created_at DESC, u.id
+		LIMIT $3 OFFSET $4
+	`, commentID, liked, pageSize, offset)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list users by comment reaction: %w", err)
+	}
+	defer rows.Close()
+
+	users := []*models.UserSummary{}
+	var totalUsers int
+	for rows.Next() {
+		user := &models.UserSummary{Role: &models.Role{}}
+		err := rows.Scan(
+			&user.ID, &user.Username, &user.CreatedAt,
+			&user.Role.Level, &user.Role.Description,
+			&user.Followers, &user.Following,
+			&totalUsers,
+		)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to scan user row: %w", err)
+		}
+		users = append(users, user)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("error during users rows iteration: %w", err)
+	}
+
+	// A page past the end has no rows to carry the window count, so count the users separately.
+	if len(users) == 0 && offset > 0 {
+		err := cls.dbPool.QueryRow(ctx, `
+			SELECT COUNT(*)
+			FROM comment_likes cl
+			INNER JOIN users u ON cl.user_id = u.id
+			WHERE cl.comment_id = $1 AND cl.liked = $2 AND u.banned = FALSE AND u.is_active = TRUE
+		`, commentID, liked).Scan(&totalUsers)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to count users by comment reaction: %w", err)
+		}
 	}
 
-	return comments, nil
+	return users, newPagination(pageNumber, pageSize, totalUsers), nil
 }
@@ -0,0 +1,43 @@
+package stores
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/datarohit/gopher-social-backend/database/dbtest"
+)
+
+// TestCommentReactionThroughOtherPost likes and dislikes a comment through a post it does not belong to.
+// Both must fail with ErrCommentNotFound and leave the reactions of the user unchanged.
+func TestCommentReactionThroughOtherPost(t *testing.T) {
+	pool := dbtest.NewPool(t)
+	store := NewCommentLikeStore(pool)
+	ctx := context.Background()
+
+	authorID := dbtest.CreateUser(t, pool, "author", 1)
+	readerID := dbtest.CreateUser(t, pool, "reader", 1)
+	postID := dbtest.CreatePost(t, pool, authorID)
+	otherPostID := dbtest.CreatePost(t, pool, authorID)
+	commentID := dbtest.CreateComment(t, pool, authorID, postID)
+
+	if _, err := store.LikeComment(ctx, readerID, commentID, otherPostID); !errors.Is(err, ErrCommentNotFound) {
+		t.Fatalf("LikeComment() through another post error = %v, want %v", err, ErrCommentNotFound)
+	}
+	if _, err := store.DislikeComment(ctx, readerID, commentID, otherPostID); !errors.Is(err, ErrCommentNotFound) {
+		t.Fatalf("DislikeComment() through another post error = %v, want %v", err, ErrCommentNotFound)
+	}
+	if count := dbtest.Count(t, pool, `SELECT COUNT(*) FROM comment_likes WHERE comment_id = $1`, commentID); count != 0 {
+		t.Fatalf("%d reactions recorded through another post, want 0", count)
+	}
+
+	if _, err := store.DislikeComment(ctx, readerID, commentID, postID); err != nil {
+		t.Fatalf("DislikeComment() error = %v", err)
+	}
+	if _, err := store.LikeComment(ctx, readerID, commentID, otherPostID); !errors.Is(err, ErrCommentNotFound) {
+		t.Fatalf("LikeComment() switching a dislike through another post error = %v, want %v", err, ErrCommentNotFound)
+	}
+	if count := dbtest.Count(t, pool, `SELECT COUNT(*) FROM comment_likes WHERE comment_id = $1 AND liked = FALSE`, commentID); count != 1 {
+		t.Fatalf("dislike changed through another post, %d dislikes left, want 1", count)
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
+	"strconv"
 
 	"github.com/datarohit/gopher-social-backend/models"
 	"github.com/google/uuid"
@@ -18,6 +20,27 @@ type CommentStore struct {
 // ErrCommentNotFound is returned when a comment is not found.
 var ErrCommentNotFound = errors.New("comment not found")
 
+// ErrCommentLimitReached is returned when a post already has the maximum number of comments.
+var ErrCommentLimitReached = errors.New("post has reached the maximum number of comments")
+
+// maxCommentsPerPost is the number of comments a post may have, 0 when comments are not limited.
+var maxCommentsPerPost = maxCommentsPerPostFromEnv()
+
+// maxCommentsPerPostFromEnv reads MAX_COMMENTS_PER_POST, defaulting to 0 which disables the limit.
+func maxCommentsPerPostFromEnv() int {
+	limit, err := strconv.Atoi(os.Getenv("MAX_COMMENTS_PER_POST"))
+	if err != nil || limit < 0 {
+		return 0
+	}
+	return limit
+}
+
+// commentLimitReached reports whether a post with the given number of comments accepts no further comments
+// from a commenter who is not exempt from MAX_COMMENTS_PER_POST.
+func commentLimitReached(commentCount int, limit int) bool {
+	return limit > 0 && commentCount >= limit
+}
+
 // NewCommentStore creates a new CommentStore.
 //
 // Parameters:
@@ -32,6 +55,9 @@ func NewCommentStore(dbPool *pgxpool.Pool) *CommentStore {
 }
 
 // CreateComment creates a new comment in the database.
+// When MAX_COMMENTS_PER_POST is set, a post with that many comments accepts no further comments, except from
+// its author and from moderators and admins. The post row is locked while its comments are counted, so
+// concurrent comments cannot exceed the limit.
 //
 // Parameters:
 //   - ctx (context.Context): Context for the database operation.
@@ -39,19 +65,48 @@ func NewCommentStore(dbPool *pgxpool.Pool) *CommentStore {
 //
 // Returns:
 //   - *models.Comment: The created comment if successful.
-//   - error: An error if comment creation fails.
+//   - error: ErrPostNotFound if the post does not exist, ErrCommentLimitReached if the post has the maximum
+//     number of comments, or other errors during database query.
 func (cs *CommentStore) CreateComment(ctx context.Context, comment *models.Comment) (*models.Comment, error) {
 	comment.ID = uuid.New()
-	_, err := cs.dbPool.Exec(ctx, `
-		INSERT INTO comments (
-			id,
-			author_id,
-			post_id,
-			content
-		) VALUES ($1, $2, $3, $4)
-	`, comment.ID, comment.AuthorID, comment.PostID, comment.Content)
+	err := WithTx(ctx, cs.dbPool, func(tx pgx.Tx) error {
+		if maxCommentsPerPost > 0 && !isModeratorViewer(ctx) {
+			var postAuthorID uuid.UUID
+			err := tx.QueryRow(ctx, `SELECT author_id FROM posts WHERE id = $1 FOR UPDATE`, comment.PostID).Scan(&postAuthorID)
+			if err != nil {
+				if errors.Is(err, pgx.ErrNoRows) {
+					return ErrPostNotFound
+				}
+				return fmt.Errorf("failed to lock post for comment creation: %w", err)
+			}
+
+			if postAuthorID != comment.AuthorID {
+				var commentCount int
+				err := tx.QueryRow(ctx, `SELECT COUNT(*) FROM comments WHERE post_id = $1`, comment.PostID).Scan(&commentCount)
+				if err != nil {
+					return fmt.Errorf("failed to count comments of post: %w", err)
+				}
+				if commentLimitReached(commentCount, maxCommentsPerPost) {
+					return ErrCommentLimitReached
+				}
+			}
+		}
+
+		_, err := tx.Exec(ctx, `
+			INSERT INTO comments (
+				id,
+				author_id,
+				post_id,
+				content
+			) VALUES ($1, $2, $3, $4)
+		`, comment.ID, comment.AuthorID, comment.PostID, comment.Content)
+		if err != nil {
+			return fmt.Errorf("failed to create comment: %w", err)
+		}
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to create comment: %w", err)
+		return nil, err
 	}
 
 	return cs.GetCommentByID(ctx, comment.ID, comment.PostID)
@@ -78,12 +133,13 @@ func (cs *CommentStore) GetCommentByID(ctx context.Context, commentID uuid.UUID,
 			c.id, c.author_id, c.post_id, c.content, c.created_at, c.updated_at,
 			u.id, u.username, u.email, u.banned, u.is_active, u.created_at, u.updated_at,
 			r.level, r.description,
-			(SELECT COUNT(*) FROM follows WHERE followee_id = u.id) as followers_count,
-			(SELECT COUNT(*) FROM follows WHERE follower_id = u.id) as following_count,
+			u.followers_count,
+			u.following_count,
 			p.id, p.author_id, p.title, p.sub_title, p.description, p.content, p.created_at, p.updated_at,
-			(SELECT COUNT(*) FROM post_likes pl WHERE pl.post_id = p.id AND pl.liked = TRUE) as likes_count,
+			(SELECT COUNT(*) FROM post_likes pl WHERE pl.post_id = p.id AND pl.liked = TRUE AND `+selfLikeCondition("pl.user_id", "p.author_id")+`) as likes_count,
 			(SELECT COUNT(*) FROM post_likes pd WHERE pd.post_id = p.id AND pd.liked = FALSE) as dislikes_count,
-			(SELECT COUNT(*) FROM comment_likes cl WHERE cl.comment_id = c.id AND cl.liked = TRUE) as likes,
+			(SELECT COUNT(*) FROM comments cm WHERE cm.post_id = p.id) as comments_count,
+			(SELECT COUNT(*) FROM comment_likes cl WHERE cl.comment_id = c.id AND cl.liked = TRUE AND `+selfLikeCondition("cl.user_id", "c.author_id")+`) as likes,
 			(SELECT COUNT(*) FROM comment_likes cd WHERE cd.comment_id = c.id AND cd.liked = FALSE) as dislikes
 		FROM comments c
 		INNER JOIN users u ON c.author_id = u.id
@@ -96,7 +152,7 @@ func (cs *CommentStore) GetCommentByID(ctx context.Context, commentID uuid.UUID,
 		&comment.Author.Role.Level, &comment.Author.Role.Description,
 		&comment.Author.Followers, &comment.Author.Following,
 		&comment.Post.ID, &comment.Post.AuthorID, &comment.Post.Title, &comment.Post.SubTitle, &comment.Post.Description, &comment.Post.Content, &comment.Post.CreatedAt, &comment.Post.UpdatedAt,
-		&comment.Post.Likes, &comment.Post.Dislikes,
+		&comment.Post.Likes, &comment.Post.Dislikes, &comment.Post.Comments,
 		&comment.Likes, &comment.Dislikes,
 	)
 	if err != nil {
@@ -168,32 +224,35 @@ func (cs *CommentStore) DeleteComment(ctx context.Context, commentID uuid.UUID,
 //   - ctx (context.Context): Context for the database operation.
 //   - authorID (uuid.UUID): ID of the author of comments.
 //   - postID (uuid.UUID): ID of the post to which the comments belongs.
+//   - viewerID (uuid.UUID): ID of the user viewing the comments, used to populate the viewer reaction. uuid.Nil for anonymous viewers.
 //   - pageNumber (int): Page number for pagination.
 //   - pageSize (int): Number of comments per page.
 //
 // Returns:
 //   - []*models.Comment: List of comments if found.
 //   - error: An error if retrieval fails.
-func (cs *CommentStore) ListCommentsByAuthorIDForPost(ctx context.Context, authorID uuid.UUID, postID uuid.UUID, pageNumber int, pageSize int) ([]*models.Comment, error) {
+func (cs *CommentStore) ListCommentsByAuthorIDForPost(ctx context.Context, authorID uuid.UUID, postID uuid.UUID, viewerID uuid.UUID, pageNumber int, pageSize int) ([]*models.Comment, error) {
 	var comments []*models.Comment
 	offset := (pageNumber - 1) * pageSize
 
-	rows, err := cs.dbPool.Query(ctx, `
+	rows, err := tracedQuery(ctx, cs.dbPool, "CommentStore.ListCommentsByAuthorIDForPost", `
 		SELECT
 			c.id, c.author_id, c.post_id, c.content, c.created_at, c.updated_at,
 			u.id, u.username, u.email, u.banned, u.is_active, u.created_at, u.updated_at,
 			r.level, r.description,
-			(SELECT COUNT(*) FROM follows WHERE followee_id = u.id) as followers_count,
-			(SELECT COUNT(*) FROM follows WHERE follower_id = u.id) as following_count,
-			(SELECT COUNT(*) FROM comment_likes cl WHERE cl.comment_id = c.id AND cl.liked = TRUE) as likes,
-			(SELECT COUNT(*) FROM comment_likes cd WHERE cd.comment_id = c.id AND cd.liked = FALSE) as dislikes
+			u.followers_count,
+			u.following_count,
+			(SELECT COUNT(*) FROM comment_likes cl WHERE cl.comment_id = c.id AND cl.liked = TRUE AND `+selfLikeCondition("cl.user_id", "c.author_id")+`) as likes,
+			(SELECT COUNT(*) FROM comment_likes cd WHERE cd.comment_id = c.id AND cd.liked = FALSE) as dislikes,
+			CASE WHEN vr.liked IS NULL THEN NULL WHEN vr.liked THEN 'like' ELSE 'dislike' END as viewer_reaction
 		FROM comments c
 		INNER JOIN users u ON c.author_id = u.id
 		INNER JOIN roles r ON u.role_id = r.id
-		WHERE c.author_id = $1 AND c.post_id = $2
+		LEFT JOIN comment_likes vr ON vr.comment_id = c.id AND vr.user_id = $5
+		WHERE c.author_id = $1 AND c.post_id = $2 AND `+bannedAuthorCondition(ctx, "c.author_id")+`
 		ORDER BY c.created_at DESC
 		LIMIT $3 OFFSET $4
-	`, authorID, postID, pageSize, offset)
+	`, authorID, postID, pageSize, offset, viewerID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list comments by author for post: %w", err)
 	}
@@ -209,6 +268,68 @@ func (cs *CommentStore) ListCommentsByAuthorIDForPost(ctx context.Context, autho
 			&comment.Author.Role.Level, &comment.Author.Role.Description,
 			&comment.Author.Followers, &comment.Author.Following,
 			&comment.Likes, &comment.Dislikes,
+			&comment.ViewerReaction,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan comment row: %w", err)
+		}
+		comment.Author = tombstoneBannedAuthor(ctx, comment.Author)
+		comments = append(comments, comment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during comments rows iteration: %w", err)
+	}
+
+	return comments, nil
+}
+
+// ListCommentsByAuthorID retrieves all comments made by a specific author across all posts, newest first, with pagination.
+// Each comment includes the post it belongs to with the post's like and dislike counts.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the database operation.
+//   - authorID (uuid.UUID): ID of the author of comments.
+//   - pageNumber (int): Page number for pagination.
+//   - pageSize (int): Number of comments per page.
+//
+// Returns:
+//   - []*models.Comment: List of comments if found.
+//   - error: An error if retrieval fails.
+func (cs *CommentStore) ListCommentsByAuthorID(ctx context.Context, authorID uuid.UUID, pageNumber int, pageSize int) ([]*models.Comment, error) {
+	var comments []*models.Comment
+	offset := (pageNumber - 1) * pageSize
+
+	rows, err := tracedQuery(ctx, cs.dbPool, "CommentStore.ListCommentsByAuthorID", `
+		SELECT
+			c.id, c.author_id, c.post_id, c.content, c.created_at, c.updated_at,
+			p.id, p.author_id, p.title, p.sub_title, p.description, p.content, p.created_at, p.updated_at,
+			(SELECT COUNT(*) FROM post_likes pl WHERE pl.post_id = p.id AND pl.liked = TRUE AND `+selfLikeCondition("pl.user_id", "p.author_id")+`) as post_likes,
+			(SELECT COUNT(*) FROM post_likes pd WHERE pd.post_id = p.id AND pd.liked = FALSE) as post_dislikes,
+			(SELECT COUNT(*) FROM comments cm WHERE cm.post_id = p.id) as comments_count,
+			(SELECT COUNT(*) FROM comment_likes cl WHERE cl.comment_id = c.id AND cl.liked = TRUE AND `+selfLikeCondition("cl.user_id", "c.author_id")+`) as likes,
+			(SELECT COUNT(*) FROM comment_likes cd WHERE cd.comment_id = c.id AND cd.liked = FALSE) as dislikes,
+			CASE WHEN vr.liked IS NULL THEN NULL WHEN vr.liked THEN 'like' ELSE 'dislike' END as viewer_reaction
+		FROM comments c
+		INNER JOIN posts p ON c.post_id = p.id
+		LEFT JOIN comment_likes vr ON vr.comment_id = c.id AND vr.user_id = $1
+		WHERE c.author_id = $1
+		ORDER BY c.created_at DESC
+		LIMIT $2 OFFSET $3
+	`, authorID, pageSize, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list comments by author: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		comment := &models.Comment{}
+		comment.Post = &models.Post{}
+		if err := rows.Scan(
+			&comment.ID, &comment.AuthorID, &comment.PostID, &comment.Content, &comment.CreatedAt, &comment.UpdatedAt,
+			&comment.Post.ID, &comment.Post.AuthorID, &comment.Post.Title, &comment.Post.SubTitle, &comment.Post.Description, &comment.Post.Content, &comment.Post.CreatedAt, &comment.Post.UpdatedAt,
+			&comment.Post.Likes, &comment.Post.Dislikes, &comment.Post.Comments,
+			&comment.Likes, &comment.Dislikes,
+			&comment.ViewerReaction,
 		); err != nil {
 			return nil, fmt.Errorf("failed to scan comment row: %w", err)
 		}
@@ -228,13 +349,14 @@ func (cs *CommentStore) ListCommentsByAuthorIDForPost(ctx context.Context, autho
 //   - ctx (context.Context): Context for the database operation.
 //   - identifier (string):  Username or Email or UserID of the author of comments.
 //   - postID (uuid.UUID): ID of the post to which the comments belongs.
+//   - viewerID (uuid.UUID): ID of the user viewing the comments, used to populate the viewer reaction. uuid.Nil for anonymous viewers.
 //   - pageNumber (int): Page number for pagination.
 //   - pageSize (int): Number of comments per page.
 //
 // Returns:
 //   - []*models.Comment: List of comments if found.
 //   - error: An error if retrieval fails.
-func (cs *CommentStore) ListCommentsByUserIdentifierForPost(ctx context.Context, identifier string, postID uuid.UUID, pageNumber int, pageSize int) ([]*models.Comment, error) {
+func (cs *CommentStore) ListCommentsByUserIdentifierForPost(ctx context.Context, identifier string, postID uuid.UUID, viewerID uuid.UUID, pageNumber int, pageSize int) ([]*models.Comment, error) {
 	authStore := NewAuthStore(cs.dbPool) // Create a new AuthStore instance
 	user, err := authStore.GetUserByUsernameOrEmail(ctx, identifier)
 	if err != nil {
@@ -252,7 +374,7 @@ func (cs *CommentStore) ListCommentsByUserIdentifierForPost(ctx context.Context,
 		}
 	}
 
-	return cs.ListCommentsByAuthorIDForPost(ctx, user.ID, postID, pageNumber, pageSize)
+	return cs.ListCommentsByAuthorIDForPost(ctx, user.ID, postID, viewerID, pageNumber, pageSize)
 }
 
 // ListCommentsByPostID retrieves all comments for a given post from the database with pagination, ordered by creation time.
@@ -260,14 +382,16 @@ func (cs *CommentStore) ListCommentsByUserIdentifierForPost(ctx context.Context,
 // Parameters:
 //   - ctx (context.Context): Context for the database operation.
 //   - postID (uuid.UUID): ID of the post.
+//   - viewerID (uuid.UUID): ID of the user viewing the comments, used to populate the viewer reaction. uuid.Nil for anonymous viewers.
 //   - pageNumber (int): Page number for pagination.
 //   - pageSize (int): Number of comments per page.
 //
 // Returns:
 //   - []*models.Comment: List of comments if found.
+//   - *models.Pagination: Pagination metadata including the total number of comments on the post.
 //   - error: An error if retrieval fails.
-func (cs *CommentStore) ListCommentsByPostID(ctx context.Context, postID uuid.UUID, pageNumber int, pageSize int) ([]*models.Comment, error) {
-	return cs.listCommentsByPostIDOrdered(ctx, postID, pageNumber, pageSize, "c.created_at ASC")
+func (cs *CommentStore) ListCommentsByPostID(ctx context.Context, postID uuid.UUID, viewerID uuid.UUID, pageNumber int, pageSize int) ([]*models.Comment, *models.Pagination, error) {
+	return cs.listCommentsByPostIDOrdered(ctx, postID, viewerID, pageNumber, pageSize, "c.created_at ASC")
 }
 
 // ListCommentsByPostIDLatestFirst retrieves all comments for a given post from the database with pagination, ordered by creation time, latest first.
@@ -275,14 +399,16 @@ func (cs *CommentStore) ListCommentsByPostID(ctx context.Context, postID uuid.UU
 // Parameters:
 //   - ctx (context.Context): Context for the database operation.
 //   - postID (uuid.UUID): ID of the post.
+//   - viewerID (uuid.UUID): ID of the user viewing the comments, used to populate the viewer reaction. uuid.Nil for anonymous viewers.
 //   - pageNumber (int): Page number for pagination.
 //   - pageSize (int): Number of comments per page.
 //
 // Returns:
 //   - []*models.Comment: List of comments if found.
+//   - *models.Pagination: Pagination metadata including the total number of comments on the post.
 //   - error: An error if retrieval fails.
-func (cs *CommentStore) ListCommentsByPostIDLatestFirst(ctx context.Context, postID uuid.UUID, pageNumber int, pageSize int) ([]*models.Comment, error) {
-	return cs.listCommentsByPostIDOrdered(ctx, postID, pageNumber, pageSize, "c.created_at DESC")
+func (cs *CommentStore) ListCommentsByPostIDLatestFirst(ctx context.Context, postID uuid.UUID, viewerID uuid.UUID, pageNumber int, pageSize int) ([]*models.Comment, *models.Pagination, error) {
+	return cs.listCommentsByPostIDOrdered(ctx, postID, viewerID, pageNumber, pageSize, "c.created_at DESC")
 }
 
 // listCommentsByPostIDOrdered is a helper function to retrieve comments for a given post from the database with pagination and custom ordering.
@@ -290,35 +416,58 @@ func (cs *CommentStore) ListCommentsByPostIDLatestFirst(ctx context.Context, pos
 // Parameters:
 //   - ctx (context.Context): Context for the database operation.
 //   - postID (uuid.UUID): ID of the post.
+//   - viewerID (uuid.UUID): ID of the user viewing the comments, used to populate the viewer reaction. uuid.Nil for anonymous viewers.
 //   - pageNumber (int): Page number for pagination.
 //   - pageSize (int): Number of comments per page.
 //   - orderBy (string): SQL order by clause.
 //
 // Returns:
 //   - []*models.Comment: List of comments if found.
+//   - *models.Pagination: Pagination metadata including the total number of comments on the post.
 //   - error: An error if retrieval fails.
-func (cs *CommentStore) listCommentsByPostIDOrdered(ctx context.Context, postID uuid.UUID, pageNumber int, pageSize int, orderBy string) ([]*models.Comment, error) {
+func (cs *CommentStore) listCommentsByPostIDOrdered(ctx context.Context, postID uuid.UUID, viewerID uuid.UUID, pageNumber int, pageSize int, orderBy string) ([]*models.Comment, *models.Pagination, error) {
 	var comments []*models.Comment
+	var totalComments int
 	offset := (pageNumber - 1) * pageSize
 
-	rows, err := cs.dbPool.Query(ctx, `
+	// The page is selected first, then the reactions of its comments are counted in one grouped pass
+	// instead of two correlated subqueries per comment. Follow counts are read from the users row.
+	rows, err := tracedQuery(ctx, cs.dbPool, "CommentStore.listCommentsByPostIDOrdered", `
+		WITH page AS (
+			SELECT c.id, c.author_id, c.post_id, c.content, c.created_at, c.updated_at,
+				COUNT(*) OVER() as total_comments
+			FROM comments c
+			WHERE c.post_id = $1 AND `+bannedAuthorCondition(ctx, "c.author_id")+`
+			ORDER BY `+orderBy+`
+			LIMIT $2 OFFSET $3
+		),
+		reactions AS (
+			SELECT cl.comment_id,
+				COUNT(*) FILTER (WHERE cl.liked) as likes,
+				COUNT(*) FILTER (WHERE NOT cl.liked) as dislikes
+			FROM comment_likes cl
+			WHERE cl.comment_id IN (SELECT id FROM page)
+			GROUP BY cl.comment_id
+		)
 		SELECT
 			c.id, c.author_id, c.post_id, c.content, c.created_at, c.updated_at,
 			u.id, u.username, u.email, u.banned, u.is_active, u.created_at, u.updated_at,
 			r.level, r.description,
-			(SELECT COUNT(*) FROM follows WHERE followee_id = u.id) as followers_count,
-			(SELECT COUNT(*) FROM follows WHERE follower_id = u.id) as following_count,
-			(SELECT COUNT(*) FROM comment_likes cl WHERE cl.comment_id = c.id AND cl.liked = TRUE) as likes,
-			(SELECT COUNT(*) FROM comment_likes cd WHERE cd.comment_id = c.id AND cd.liked = FALSE) as dislikes
-		FROM comments c
+			u.followers_count,
+			u.following_count,
+			COALESCE(cr.likes, 0) as likes,
+			COALESCE(cr.dislikes, 0) as dislikes,
+			CASE WHEN vr.liked IS NULL THEN NULL WHEN vr.liked THEN 'like' ELSE 'dislike' END as viewer_reaction,
+			c.total_comments
+		FROM page c
 		INNER JOIN users u ON c.author_id = u.id
 		INNER JOIN roles r ON u.role_id = r.id
-		WHERE c.post_id = $1
+		LEFT JOIN reactions cr ON cr.comment_id = c.id
+		LEFT JOIN comment_likes vr ON vr.comment_id = c.id AND vr.user_id = $4
 		ORDER BY `+orderBy+`
-		LIMIT $2 OFFSET $3
-	`, postID, pageSize, offset)
+	`, postID, pageSize, offset, viewerID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list comments by post id: %w", err)
+		return nil, nil, fmt.Errorf("failed to list comments by post id: %w", err)
 	}
 	defer rows.Close()
 
@@ -332,15 +481,115 @@ func (cs *CommentStore) listCommentsByPostIDOrdered(ctx context.Context, postID
 			&comment.Author.Role.Level, &comment.Author.Role.Description,
 			&comment.Author.Followers, &comment.Author.Following,
 			&comment.Likes, &comment.Dislikes,
+			&comment.ViewerReaction,
+			&totalComments,
 		); err != nil {
-			return nil, fmt.Errorf("failed to scan comment row: %w", err)
+			return nil, nil, fmt.Errorf("failed to scan comment row: %w", err)
 		}
+		comment.Author = tombstoneBannedAuthor(ctx, comment.Author)
 		comments = append(comments, comment)
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error during comments rows iteration: %w", err)
+		return nil, nil, fmt.Errorf("error during comments rows iteration: %w", err)
 	}
 
-	return comments, nil
+	// A page past the end has no rows to carry the window count, so count the comments separately.
+	if len(comments) == 0 && offset > 0 {
+		err := cs.dbPool.QueryRow(ctx, `SELECT COUNT(*) FROM comments c WHERE c.post_id = $1 AND `+bannedAuthorCondition(ctx, "c.author_id"), postID).Scan(&totalComments)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to count comments by post id: %w", err)
+		}
+	}
+
+	return comments, newPagination(pageNumber, pageSize, totalComments), nil
+}
+
+// ListPostsCommentedByUser retrieves the distinct posts a user identified by username, email or userID has commented on,
+// ordered by the user's most recent comment on each post, with pagination.
+// Posts of private authors are only included if the viewer is the author or follows them.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the database operation.
+//   - identifier (string): Username or Email or UserID of the commenter.
+//   - viewerID (uuid.UUID): ID of the user viewing the posts.
+//   - pageNumber (int): Page number for pagination.
+//   - pageSize (int): Number of posts per page.
+//
+// Returns:
+//   - []*models.Post: List of posts with author details and like counts.
+//   - error: ErrUserNotFound if the user does not exist, or an error if retrieval fails.
+func (cs *CommentStore) ListPostsCommentedByUser(ctx context.Context, identifier string, viewerID uuid.UUID, pageNumber int, pageSize int) ([]*models.Post, error) {
+	authStore := NewAuthStore(cs.dbPool)
+	user, err := authStore.GetUserByUsernameOrEmail(ctx, identifier)
+	if err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			userID, uuidErr := uuid.Parse(identifier)
+			if uuidErr != nil {
+				return nil, ErrUserNotFound
+			}
+			user, err = authStore.GetUserByID(ctx, userID)
+			if err != nil {
+				return nil, ErrUserNotFound
+			}
+		} else {
+			return nil, fmt.Errorf("failed to get user by identifier: %w", err)
+		}
+	}
+
+	offset := (pageNumber - 1) * pageSize
+	rows, err := tracedQuery(ctx, cs.dbPool, "CommentStore.ListPostsCommentedByUser", `
+		SELECT
+			p.id, p.author_id, p.title, p.sub_title, p.description, p.content, p.created_at, p.updated_at,
+			(SELECT COUNT(*) FROM post_likes pl WHERE pl.post_id = p.id AND pl.liked = TRUE AND `+selfLikeCondition("pl.user_id", "p.author_id")+`) as likes_count,
+			(SELECT COUNT(*) FROM post_likes pd WHERE pd.post_id = p.id AND pd.liked = FALSE) as dislikes_count,
+			(SELECT COUNT(*) FROM comments cm WHERE cm.post_id = p.id) as comments_count,
+			u.id, u.username, u.email, u.timeout_until, u.banned, u.is_active, u.created_at, u.updated_at,
+			r.level, r.description
+		FROM (
+			SELECT post_id, MAX(created_at) as last_commented_at
+			FROM comments
+			WHERE author_id = $1
+			GROUP BY post_id
+		) lc
+		INNER JOIN posts p ON lc.post_id = p.id
+		INNER JOIN users u ON p.author_id = u.id
+		INNER JOIN roles r ON u.role_id = r.id
+		LEFT JOIN profiles pr ON pr.user_id = u.id
+		WHERE u.banned = FALSE
+		AND (
+			COALESCE(pr.is_private, FALSE) = FALSE
+			OR p.author_id = $2
+			OR EXISTS (SELECT 1 FROM follows WHERE follower_id = $2 AND followee_id = p.author_id)
+		)
+		ORDER BY lc.last_commented_at DESC
+		LIMIT $3 OFFSET $4
+	`, user.ID, viewerID, pageSize, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list posts commented by user: %w", err)
+	}
+	defer rows.Close()
+
+	var posts []*models.Post
+	for rows.Next() {
+		post := &models.Post{}
+		post.Author = &models.User{}
+		post.Author.Role = &models.Role{}
+		err := rows.Scan(
+			&post.ID, &post.AuthorID, &post.Title, &post.SubTitle, &post.Description, &post.Content, &post.CreatedAt, &post.UpdatedAt,
+			&post.Likes, &post.Dislikes, &post.Comments,
+			&post.Author.ID, &post.Author.Username, &post.Author.Email, &post.Author.TimeoutUntil, &post.Author.Banned, &post.Author.IsActive, &post.Author.CreatedAt, &post.Author.UpdatedAt,
+			&post.Author.Role.Level, &post.Author.Role.Description,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan post row: %w", err)
+		}
+		posts = append(posts, post)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during posts rows iteration: %w", err)
+	}
+
+	return posts, nil
 }
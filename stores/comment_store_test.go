@@ -0,0 +1,120 @@
+package stores
+
+import (
+	"context"
+	"testing"
+
+	"github.com/datarohit/gopher-social-backend/database/dbtest"
+)
+
+func TestCommentLimitReached(t *testing.T) {
+	tests := []struct {
+		name         string
+		commentCount int
+		limit        int
+		want         bool
+	}{
+		{name: "limit disabled", commentCount: 1000, limit: 0, want: false},
+		{name: "no comments", commentCount: 0, limit: 5, want: false},
+		{name: "one below limit", commentCount: 4, limit: 5, want: false},
+		{name: "at limit", commentCount: 5, limit: 5, want: true},
+		{name: "over limit", commentCount: 7, limit: 5, want: true},
+		{name: "limit of one", commentCount: 1, limit: 1, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := commentLimitReached(tt.commentCount, tt.limit); got != tt.want {
+				t.Fatalf("commentLimitReached(%d, %d) = %v, want %v", tt.commentCount, tt.limit, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMaxCommentsPerPostFromEnv(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  int
+	}{
+		{name: "unset", value: "", want: 0},
+		{name: "limit", value: "50", want: 50},
+		{name: "negative", value: "-1", want: 0},
+		{name: "invalid", value: "many", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("MAX_COMMENTS_PER_POST", tt.value)
+			if got := maxCommentsPerPostFromEnv(); got != tt.want {
+				t.Fatalf("maxCommentsPerPostFromEnv() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+// commentsWithReactionSubqueries is the comment listing as it was before the reactions were counted in a grouped
+// CTE, counting the likes and dislikes of every comment with correlated subqueries.
+const commentsWithReactionSubqueries = `
+	SELECT
+		c.id, c.author_id, c.post_id, c.content, c.created_at, c.updated_at,
+		u.id, u.username, u.email, u.banned, u.is_active, u.created_at, u.updated_at,
+		r.level, r.description,
+		u.followers_count,
+		u.following_count,
+		(SELECT COUNT(*) FROM comment_likes cl WHERE cl.comment_id = c.id AND cl.liked = TRUE) as likes,
+		(SELECT COUNT(*) FROM comment_likes cd WHERE cd.comment_id = c.id AND cd.liked = FALSE) as dislikes,
+		CASE WHEN vr.liked IS NULL THEN NULL WHEN vr.liked THEN 'like' ELSE 'dislike' END as viewer_reaction,
+		COUNT(*) OVER() as total_comments
+	FROM comments c
+	INNER JOIN users u ON c.author_id = u.id
+	INNER JOIN roles r ON u.role_id = r.id
+	LEFT JOIN comment_likes vr ON vr.comment_id = c.id AND vr.user_id = $4
+	WHERE c.post_id = $1
+	ORDER BY c.created_at ASC
+	LIMIT $2 OFFSET $3
+`
+
+// BenchmarkListCommentsByPostID compares a 50 comment page of a post with 2000 commenters, counting reactions in a
+// grouped CTE as the store does, with counting them per comment. Every commenter reacts to 10 comments.
+func BenchmarkListCommentsByPostID(b *testing.B) {
+	pool := dbtest.NewPool(b)
+	ctx := context.Background()
+
+	authorID := dbtest.CreateUser(b, pool, "author", 1)
+	postID := dbtest.CreatePost(b, pool, authorID)
+	commenterIDs := dbtest.CreateUsers(b, pool, "commenter", 2000)
+	dbtest.Exec(b, pool, `INSERT INTO comments (author_id, post_id, content) SELECT unnest($1::uuid[]), $2, 'Benchmark comment.'`, commenterIDs, postID)
+	dbtest.Exec(b, pool, `
+		INSERT INTO comment_likes (user_id, comment_id, liked)
+		SELECT u.id, c.id, (u.n + c.n) % 3 <> 0
+		FROM unnest($1::uuid[]) WITH ORDINALITY u(id, n)
+		INNER JOIN (SELECT id, row_number() OVER (ORDER BY created_at, id) as n FROM comments WHERE post_id = $2) c
+			ON c.n BETWEEN u.n + 1 AND u.n + 10
+	`, commenterIDs, postID)
+	dbtest.Exec(b, pool, `ANALYZE`)
+
+	store := NewCommentStore(pool)
+	viewerID := commenterIDs[0]
+	b.Run("grouped CTE", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, _, err := store.ListCommentsByPostID(ctx, postID, viewerID, 1, 50); err != nil {
+				b.Fatalf("ListCommentsByPostID() error = %v", err)
+			}
+		}
+	})
+	b.Run("reaction subqueries", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			rows, err := pool.Query(ctx, commentsWithReactionSubqueries, postID, 50, 0, viewerID)
+			if err != nil {
+				b.Fatalf("query error = %v", err)
+			}
+			for rows.Next() {
+			}
+			rows.Close()
+			if err := rows.Err(); err != nil {
+				b.Fatalf("rows error = %v", err)
+			}
+		}
+	})
+}
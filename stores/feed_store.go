@@ -3,31 +3,59 @@ package stores
 import (
 	"context"
 	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/datarohit/gopher-social-backend/models"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
 )
 
+// feedKeyPrefix is the Redis key prefix for the sorted set of post IDs in a user's home feed, scored by creation time.
+const feedKeyPrefix = "feed:"
+
+// feedCacheTTL is how long an idle home feed stays cached.
+const feedCacheTTL = 24 * time.Hour
+
+// feedCacheMaxLength is the number of most recent posts kept in a cached home feed.
+// Older pages are read from the database.
+var feedCacheMaxLength = feedCacheMaxLengthFromEnv()
+
+// feedCacheMaxLengthFromEnv reads FEED_CACHE_MAX_LENGTH, defaulting to 500.
+func feedCacheMaxLengthFromEnv() int {
+	value, err := strconv.Atoi(strings.TrimSpace(os.Getenv("FEED_CACHE_MAX_LENGTH")))
+	if err != nil || value <= 0 {
+		return 500
+	}
+	return value
+}
+
 type FeedStore struct {
-	dbPool *pgxpool.Pool
+	dbPool      *pgxpool.Pool
+	redisClient *redis.Client
 }
 
 // NewFeedStore creates a new FeedStore.
 //
 // Parameters:
 //   - dbPool (*pgxpool.Pool): Pgx connection pool.
+//   - redisClient (*redis.Client): Redis client used to cache home feeds.
 //
 // Returns:
 //   - *FeedStore: FeedStore instance.
-func NewFeedStore(dbPool *pgxpool.Pool) *FeedStore {
+func NewFeedStore(dbPool *pgxpool.Pool, redisClient *redis.Client) *FeedStore {
 	return &FeedStore{
-		dbPool: dbPool,
+		dbPool:      dbPool,
+		redisClient: redisClient,
 	}
 }
 
 // ListLatestPosts retrieves the latest posts from the database with pagination for the feed.
 // It includes author information, follower/following counts, and like/dislike counts for each post.
+// Posts of users with private profiles are excluded.
 //
 // Parameters:
 //   - ctx (context.Context): Context for the database operation.
@@ -39,18 +67,21 @@ func NewFeedStore(dbPool *pgxpool.Pool) *FeedStore {
 //   - error: An error if the database query fails.
 func (fs *FeedStore) ListLatestPosts(ctx context.Context, pageNumber int, pageSize int) ([]*models.Post, error) {
 	offset := (pageNumber - 1) * pageSize
-	rows, err := fs.dbPool.Query(ctx, `
+	rows, err := tracedQuery(ctx, fs.dbPool, "FeedStore.ListLatestPosts", `
 		SELECT
 			p.id, p.author_id, p.title, p.sub_title, p.description, p.content, p.created_at, p.updated_at,
 			u.id, u.username, u.email, u.banned, u.is_active, u.created_at, u.updated_at,
 			r.level, r.description,
-			(SELECT COUNT(*) FROM post_likes pl WHERE pl.post_id = p.id AND pl.liked = TRUE) as likes_count,
+			(SELECT COUNT(*) FROM post_likes pl WHERE pl.post_id = p.id AND pl.liked = TRUE AND `+selfLikeCondition("pl.user_id", "p.author_id")+`) as likes_count,
 			(SELECT COUNT(*) FROM post_likes pd WHERE pd.post_id = p.id AND pd.liked = FALSE) as dislikes_count,
-			(SELECT COUNT(*) FROM follows WHERE followee_id = u.id) as followers_count,
-			(SELECT COUNT(*) FROM follows WHERE follower_id = u.id) as following_count
+			(SELECT COUNT(*) FROM comments cm WHERE cm.post_id = p.id) as comments_count,
+			u.followers_count,
+			u.following_count
 		FROM posts p
 		INNER JOIN users u ON p.author_id = u.id
 		INNER JOIN roles r ON u.role_id = r.id
+		WHERE NOT EXISTS (SELECT 1 FROM profiles pr WHERE pr.user_id = p.author_id AND pr.is_private = TRUE)
+		AND `+bannedAuthorCondition(ctx, "p.author_id")+`
 		ORDER BY p.created_at DESC
 		LIMIT $1 OFFSET $2
 	`, pageSize, offset)
@@ -66,12 +97,13 @@ func (fs *FeedStore) ListLatestPosts(ctx context.Context, pageNumber int, pageSi
 			&post.ID, &post.AuthorID, &post.Title, &post.SubTitle, &post.Description, &post.Content, &post.CreatedAt, &post.UpdatedAt,
 			&post.Author.ID, &post.Author.Username, &post.Author.Email, &post.Author.Banned, &post.Author.IsActive, &post.Author.CreatedAt, &post.Author.UpdatedAt,
 			&post.Author.Role.Level, &post.Author.Role.Description,
-			&post.Likes, &post.Dislikes,
+			&post.Likes, &post.Dislikes, &post.Comments,
 			&post.Author.Followers, &post.Author.Following,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan post row: %w", err)
 		}
+		post.Author = tombstoneBannedAuthor(ctx, post.Author)
 		posts = append(posts, post)
 	}
 
@@ -79,11 +111,16 @@ func (fs *FeedStore) ListLatestPosts(ctx context.Context, pageNumber int, pageSi
 		return nil, fmt.Errorf("error during posts rows iteration: %w", err)
 	}
 
+	if err := attachQuotedPosts(ctx, fs.dbPool, posts); err != nil {
+		return nil, err
+	}
+
 	return posts, nil
 }
 
 // GetPostWithComments retrieves a specific post by postID along with its comments in paginated form for the feed.
 // It includes post details, author information, comment details, and comment author information.
+// Posts of users with private profiles are reported as not found.
 //
 // Parameters:
 //   - ctx (context.Context): Context for the database operation.
@@ -92,7 +129,7 @@ func (fs *FeedStore) ListLatestPosts(ctx context.Context, pageNumber int, pageSi
 //   - pageSize (int): Number of comments per page.
 //
 // Returns:
-//   - *models.FeedPost: A FeedPost object containing the post and its comments.
+//   - *models.FeedPost: A FeedPost object containing the post, a page of its comments and the comments pagination.
 //   - error: An error if the database query fails or post is not found.
 func (fs *FeedStore) GetPostWithComments(ctx context.Context, postID uuid.UUID, pageNumber int, pageSize int) (*models.FeedPost, error) {
 	postStore := NewPostStore(fs.dbPool)
@@ -103,14 +140,25 @@ func (fs *FeedStore) GetPostWithComments(ctx context.Context, postID uuid.UUID,
 		return nil, fmt.Errorf("failed to get post by id: %w", err)
 	}
 
-	comments, err := commentStore.ListCommentsByPostIDLatestFirst(ctx, postID, pageNumber, pageSize)
+	// Posts of private profiles are only visible to followers, which the public feed cannot identify.
+	var authorIsPrivate bool
+	err = fs.dbPool.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM profiles WHERE user_id = $1 AND is_private = TRUE)`, retrievedPost.AuthorID).Scan(&authorIsPrivate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check profile privacy: %w", err)
+	}
+	if authorIsPrivate {
+		return nil, ErrPostNotFound
+	}
+
+	comments, pagination, err := commentStore.ListCommentsByPostIDLatestFirst(ctx, postID, uuid.Nil, pageNumber, pageSize)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list comments for post: %w", err)
 	}
 
 	feedPost := &models.FeedPost{
-		Post:     retrievedPost,
-		Comments: comments,
+		Post:       retrievedPost,
+		Comments:   comments,
+		Pagination: pagination,
 	}
 
 	postAuthor, err := fs.getAuthorDetailsForPost(ctx, feedPost.Post.AuthorID)
@@ -124,7 +172,7 @@ func (fs *FeedStore) GetPostWithComments(ctx context.Context, postID uuid.UUID,
 		if err != nil {
 			return nil, fmt.Errorf("failed to get author details for comment: %w", err)
 		}
-		comment.Author = commentAuthor
+		comment.Author = tombstoneBannedAuthor(ctx, commentAuthor)
 	}
 
 	return feedPost, nil
@@ -138,8 +186,8 @@ func (fs *FeedStore) getAuthorDetailsForPost(ctx context.Context, authorID uuid.
 		SELECT
 			u.id, u.username, u.email, u.banned, u.is_active, u.created_at, u.updated_at,
 			r.level, r.description,
-			(SELECT COUNT(*) FROM follows WHERE followee_id = u.id) as followers_count,
-			(SELECT COUNT(*) FROM follows WHERE follower_id = u.id) as following_count
+			u.followers_count,
+			u.following_count
 		FROM users u
 		INNER JOIN roles r ON u.role_id = r.id
 		WHERE u.id = $1
@@ -162,8 +210,8 @@ func (fs *FeedStore) getCommentAuthorDetails(ctx context.Context, authorID uuid.
 		SELECT
 			u.id, u.username, u.email, u.banned, u.is_active, u.created_at, u.updated_at,
 			r.level, r.description,
-			(SELECT COUNT(*) FROM follows WHERE followee_id = u.id) as followers_count,
-			(SELECT COUNT(*) FROM follows WHERE follower_id = u.id) as following_count
+			u.followers_count,
+			u.following_count
 		FROM users u
 		INNER JOIN roles r ON u.role_id = r.id
 		WHERE u.id = $1
@@ -177,3 +225,293 @@ func (fs *FeedStore) getCommentAuthorDetails(ctx context.Context, authorID uuid.
 	}
 	return &author, nil
 }
+
+// ListHomeFeed retrieves the newest posts of the users a user follows, and of the user themselves, with pagination.
+// Pages within the most recent feedCacheMaxLength posts are served from the user's cached feed in Redis,
+// which is rebuilt from the database on a cache miss. Older pages are read from the database directly.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the database operation.
+//   - userID (uuid.UUID): ID of the user whose home feed is retrieved.
+//   - pageNumber (int): Page number for pagination.
+//   - pageSize (int): Number of posts per page.
+//
+// Returns:
+//   - []*models.Post: A slice of Post pointers with author details and like counts, newest first.
+//   - error: An error if reading the cache or the database fails.
+func (fs *FeedStore) ListHomeFeed(ctx context.Context, userID uuid.UUID, pageNumber int, pageSize int) ([]*models.Post, error) {
+	offset := (pageNumber - 1) * pageSize
+	if offset+pageSize > feedCacheMaxLength {
+		return fs.listHomeFeedFromDB(ctx, userID, pageSize, offset)
+	}
+
+	key := feedKeyPrefix + userID.String()
+
+	exists, err := fs.redisClient.Exists(ctx, key).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to check cached home feed: %w", err)
+	}
+	if exists == 0 {
+		if err := fs.rebuildHomeFeed(ctx, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	members, err := fs.redisClient.ZRevRange(ctx, key, int64(offset), int64(offset+pageSize-1)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cached home feed: %w", err)
+	}
+
+	postIDs := make([]uuid.UUID, 0, len(members))
+	for _, member := range members {
+		postID, err := uuid.Parse(member)
+		if err != nil {
+			continue
+		}
+		postIDs = append(postIDs, postID)
+	}
+
+	return fs.getPostsByIDs(ctx, postIDs)
+}
+
+// FanOutPost adds a new post to the cached home feeds of its author and the author's followers.
+// Feeds that are not cached are left alone, they are rebuilt from the database when next read.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the database operation.
+//   - post (*models.Post): The created post, ID, AuthorID and CreatedAt must be populated.
+//
+// Returns:
+//   - error: An error if reading the followers or updating the cache fails.
+func (fs *FeedStore) FanOutPost(ctx context.Context, post *models.Post) error {
+	keys, err := fs.feedKeysForAuthor(ctx, post.AuthorID)
+	if err != nil {
+		return err
+	}
+
+	existsPipe := fs.redisClient.Pipeline()
+	existsCmds := make([]*redis.IntCmd, len(keys))
+	for i, key := range keys {
+		existsCmds[i] = existsPipe.Exists(ctx, key)
+	}
+	if _, err := existsPipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to check cached home feeds: %w", err)
+	}
+
+	member := redis.Z{Score: float64(post.CreatedAt.UnixMicro()), Member: post.ID.String()}
+	pipe := fs.redisClient.Pipeline()
+	for i, key := range keys {
+		if existsCmds[i].Val() == 0 {
+			continue
+		}
+		pipe.ZAdd(ctx, key, member)
+		pipe.ZRemRangeByRank(ctx, key, 0, int64(-feedCacheMaxLength-1))
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to fan out post to home feeds: %w", err)
+	}
+
+	return nil
+}
+
+// RemovePostFromFeeds removes a deleted post from the cached home feeds of its author and the author's followers.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the database operation.
+//   - authorID (uuid.UUID): ID of the author of the post.
+//   - postID (uuid.UUID): ID of the deleted post.
+//
+// Returns:
+//   - error: An error if reading the followers or updating the cache fails.
+func (fs *FeedStore) RemovePostFromFeeds(ctx context.Context, authorID uuid.UUID, postID uuid.UUID) error {
+	keys, err := fs.feedKeysForAuthor(ctx, authorID)
+	if err != nil {
+		return err
+	}
+
+	pipe := fs.redisClient.Pipeline()
+	for _, key := range keys {
+		pipe.ZRem(ctx, key, postID.String())
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to remove post from home feeds: %w", err)
+	}
+
+	return nil
+}
+
+// InvalidateHomeFeed drops the cached home feed of a user, for example after they followed or unfollowed someone.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the operation.
+//   - userID (uuid.UUID): ID of the user whose cached feed is dropped.
+//
+// Returns:
+//   - error: An error if deleting the cached feed fails.
+func (fs *FeedStore) InvalidateHomeFeed(ctx context.Context, userID uuid.UUID) error {
+	if err := fs.redisClient.Del(ctx, feedKeyPrefix+userID.String()).Err(); err != nil {
+		return fmt.Errorf("failed to invalidate home feed: %w", err)
+	}
+	return nil
+}
+
+// feedKeysForAuthor returns the home feed keys of an author and all of the author's followers.
+func (fs *FeedStore) feedKeysForAuthor(ctx context.Context, authorID uuid.UUID) ([]string, error) {
+	rows, err := fs.dbPool.Query(ctx, `SELECT follower_id FROM follows WHERE followee_id = $1`, authorID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get followers for feed fan out: %w", err)
+	}
+	defer rows.Close()
+
+	keys := []string{feedKeyPrefix + authorID.String()}
+	for rows.Next() {
+		var followerID uuid.UUID
+		if err := rows.Scan(&followerID); err != nil {
+			return nil, fmt.Errorf("failed to scan follower row: %w", err)
+		}
+		keys = append(keys, feedKeyPrefix+followerID.String())
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during followers rows iteration: %w", err)
+	}
+
+	return keys, nil
+}
+
+// rebuildHomeFeed loads the most recent posts of a user's home feed from the database into Redis.
+// An empty feed is not cached, so it is checked against the database on every read.
+func (fs *FeedStore) rebuildHomeFeed(ctx context.Context, userID uuid.UUID) error {
+	rows, err := fs.dbPool.Query(ctx, `
+		SELECT p.id, p.created_at
+		FROM posts p
+		WHERE p.author_id = $1 OR p.author_id IN (SELECT followee_id FROM follows WHERE follower_id = $1)
+		ORDER BY p.created_at DESC
+		LIMIT $2
+	`, userID, feedCacheMaxLength)
+	if err != nil {
+		return fmt.Errorf("failed to rebuild home feed: %w", err)
+	}
+	defer rows.Close()
+
+	var members []redis.Z
+	for rows.Next() {
+		var postID uuid.UUID
+		var createdAt time.Time
+		if err := rows.Scan(&postID, &createdAt); err != nil {
+			return fmt.Errorf("failed to scan home feed row: %w", err)
+		}
+		members = append(members, redis.Z{Score: float64(createdAt.UnixMicro()), Member: postID.String()})
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error during home feed rows iteration: %w", err)
+	}
+
+	if len(members) == 0 {
+		return nil
+	}
+
+	key := feedKeyPrefix + userID.String()
+	pipe := fs.redisClient.TxPipeline()
+	pipe.Del(ctx, key)
+	pipe.ZAdd(ctx, key, members...)
+	pipe.Expire(ctx, key, feedCacheTTL)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to cache home feed: %w", err)
+	}
+
+	return nil
+}
+
+// listHomeFeedFromDB retrieves a page of a user's home feed directly from the database.
+func (fs *FeedStore) listHomeFeedFromDB(ctx context.Context, userID uuid.UUID, limit int, offset int) ([]*models.Post, error) {
+	rows, err := tracedQuery(ctx, fs.dbPool, "FeedStore.listHomeFeedFromDB", `
+		SELECT p.id
+		FROM posts p
+		WHERE p.author_id = $1 OR p.author_id IN (SELECT followee_id FROM follows WHERE follower_id = $1)
+		ORDER BY p.created_at DESC
+		LIMIT $2 OFFSET $3
+	`, userID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list home feed: %w", err)
+	}
+	defer rows.Close()
+
+	var postIDs []uuid.UUID
+	for rows.Next() {
+		var postID uuid.UUID
+		if err := rows.Scan(&postID); err != nil {
+			return nil, fmt.Errorf("failed to scan home feed row: %w", err)
+		}
+		postIDs = append(postIDs, postID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during home feed rows iteration: %w", err)
+	}
+
+	return fs.getPostsByIDs(ctx, postIDs)
+}
+
+// getPostsByIDs retrieves posts with author details and like counts in one query, in the order of postIDs.
+// Posts that no longer exist are skipped.
+func (fs *FeedStore) getPostsByIDs(ctx context.Context, postIDs []uuid.UUID) ([]*models.Post, error) {
+	if len(postIDs) == 0 {
+		return nil, nil
+	}
+
+	rows, err := tracedQuery(ctx, fs.dbPool, "FeedStore.getPostsByIDs", `
+		SELECT
+			p.id, p.author_id, p.title, p.sub_title, p.description, p.content, p.created_at, p.updated_at,
+			u.id, u.username, u.email, u.banned, u.is_active, u.created_at, u.updated_at,
+			r.level, r.description,
+			(SELECT COUNT(*) FROM post_likes pl WHERE pl.post_id = p.id AND pl.liked = TRUE AND `+selfLikeCondition("pl.user_id", "p.author_id")+`) as likes_count,
+			(SELECT COUNT(*) FROM post_likes pd WHERE pd.post_id = p.id AND pd.liked = FALSE) as dislikes_count,
+			(SELECT COUNT(*) FROM comments cm WHERE cm.post_id = p.id) as comments_count,
+			u.followers_count,
+			u.following_count
+		FROM posts p
+		INNER JOIN users u ON p.author_id = u.id
+		INNER JOIN roles r ON u.role_id = r.id
+		WHERE p.id = ANY($1) AND `+bannedAuthorCondition(ctx, "p.author_id")+`
+	`, postIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get posts by ids: %w", err)
+	}
+	defer rows.Close()
+
+	postsByID := make(map[uuid.UUID]*models.Post, len(postIDs))
+	for rows.Next() {
+		post := &models.Post{Author: &models.User{Role: &models.Role{}}}
+		err := rows.Scan(
+			&post.ID, &post.AuthorID, &post.Title, &post.SubTitle, &post.Description, &post.Content, &post.CreatedAt, &post.UpdatedAt,
+			&post.Author.ID, &post.Author.Username, &post.Author.Email, &post.Author.Banned, &post.Author.IsActive, &post.Author.CreatedAt, &post.Author.UpdatedAt,
+			&post.Author.Role.Level, &post.Author.Role.Description,
+			&post.Likes, &post.Dislikes, &post.Comments,
+			&post.Author.Followers, &post.Author.Following,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan post row: %w", err)
+		}
+		post.Author = tombstoneBannedAuthor(ctx, post.Author)
+		postsByID[post.ID] = post
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during posts rows iteration: %w", err)
+	}
+
+	posts := make([]*models.Post, 0, len(postsByID))
+	for _, postID := range postIDs {
+		if post, ok := postsByID[postID]; ok {
+			posts = append(posts, post)
+		}
+	}
+
+	if err := attachQuotedPosts(ctx, fs.dbPool, posts); err != nil {
+		return nil, err
+	}
+
+	return posts, nil
+}
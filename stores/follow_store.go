@@ -34,7 +34,22 @@ var ErrAlreadyFollowing = errors.New("already following user")
 // ErrNotFollowing is returned when a user is not following another user.
 var ErrNotFollowing = errors.New("not following user")
 
+// ErrFollowRequestAlreadyExists is returned when a user has already requested to follow a private user.
+var ErrFollowRequestAlreadyExists = errors.New("follow request already exists")
+
+// ErrFollowRequestNotFound is returned when a follow request is not found.
+var ErrFollowRequestNotFound = errors.New("follow request not found")
+
+// ErrPrivateAccount is returned when a user's posts are only visible to their followers.
+var ErrPrivateAccount = errors.New("account is private")
+
+// ErrCannotFollowInactiveUser is returned when the user to follow is banned or not active.
+var ErrCannotFollowInactiveUser = errors.New("cannot follow banned or inactive user")
+
 // FollowUser creates a new follow relationship in the database.
+// If the followee has a private profile, a pending follow request is created instead.
+// The followee is checked to be active and not banned in the insert itself, so a user banned
+// or deactivated concurrently cannot gain a follower.
 //
 // Parameters:
 //   - ctx (context.Context): Context for the database operation.
@@ -42,22 +57,79 @@ var ErrNotFollowing = errors.New("not following user")
 //   - followeeID (uuid.UUID): ID of the followee user.
 //
 // Returns:
-//   - error: An error if creating the follow relationship fails or if already following.
-func (fs *FollowStore) FollowUser(ctx context.Context, followerID uuid.UUID, followeeID uuid.UUID) error {
+//   - bool: True if a follow request was created instead of a follow relationship.
+//   - error: An error if creating the follow relationship fails, if already following, ErrFollowRequestAlreadyExists,
+//     ErrUserNotFound if the followee does not exist, or ErrCannotFollowInactiveUser if the followee is banned or inactive.
+func (fs *FollowStore) FollowUser(ctx context.Context, followerID uuid.UUID, followeeID uuid.UUID) (bool, error) {
 	var existingFollow models.Follow
 	err := fs.dbPool.QueryRow(ctx, `SELECT follower_id, followee_id, created_at FROM follows WHERE follower_id = $1 AND followee_id = $2`, followerID, followeeID).Scan(
 		&existingFollow.FollowerID, &existingFollow.FolloweeID, &existingFollow.CreatedAt,
 	)
 	if err == nil || !errors.Is(err, pgx.ErrNoRows) {
-		return ErrAlreadyFollowing
+		return false, ErrAlreadyFollowing
 	}
 
-	_, err = fs.dbPool.Exec(ctx, `
+	var isPrivate bool
+	err = fs.dbPool.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM profiles WHERE user_id = $1 AND is_private = TRUE)`, followeeID).Scan(&isPrivate)
+	if err != nil {
+		return false, fmt.Errorf("failed to check profile privacy: %w", err)
+	}
+
+	if isPrivate {
+		commandTag, err := fs.dbPool.Exec(ctx, `
+			INSERT INTO follow_requests (requester_id, target_id)
+			SELECT $1, u.id FROM users u
+			WHERE u.id = $2 AND u.banned = FALSE AND u.is_active = TRUE
+			ON CONFLICT (requester_id, target_id) DO NOTHING
+		`, followerID, followeeID)
+		if err != nil {
+			return false, fmt.Errorf("failed to create follow request: %w", err)
+		}
+		if commandTag.RowsAffected() == 0 {
+			if err := fs.checkFolloweeAvailable(ctx, followeeID); err != nil {
+				return false, err
+			}
+			return false, ErrFollowRequestAlreadyExists
+		}
+		return true, nil
+	}
+
+	commandTag, err := fs.dbPool.Exec(ctx, `
 		INSERT INTO follows (follower_id, followee_id)
-		VALUES ($1, $2)
+		SELECT $1, u.id FROM users u
+		WHERE u.id = $2 AND u.banned = FALSE AND u.is_active = TRUE
 	`, followerID, followeeID)
 	if err != nil {
-		return fmt.Errorf("failed to follow user: %w", err)
+		return false, fmt.Errorf("failed to follow user: %w", err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		if err := fs.checkFolloweeAvailable(ctx, followeeID); err != nil {
+			return false, err
+		}
+		return false, ErrCannotFollowInactiveUser
+	}
+	return false, nil
+}
+
+// checkFolloweeAvailable tells why a follow insert matched no followee.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the database operation.
+//   - followeeID (uuid.UUID): ID of the followee user.
+//
+// Returns:
+//   - error: ErrUserNotFound if the followee does not exist, ErrCannotFollowInactiveUser if it is banned or inactive,
+//     nil if it can be followed, or an error if the check fails.
+func (fs *FollowStore) checkFolloweeAvailable(ctx context.Context, followeeID uuid.UUID) error {
+	var banned, isActive bool
+	err := fs.dbPool.QueryRow(ctx, `SELECT banned, is_active FROM users WHERE id = $1`, followeeID).Scan(&banned, &isActive)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrUserNotFound
+	} else if err != nil {
+		return fmt.Errorf("failed to check followee status: %w", err)
+	}
+	if banned || !isActive {
+		return ErrCannotFollowInactiveUser
 	}
 	return nil
 }
@@ -92,6 +164,61 @@ func (fs *FollowStore) UnfollowUser(ctx context.Context, followerID uuid.UUID, f
 	return nil
 }
 
+// RemoveFollower removes a follower of a user from the database.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the database operation.
+//   - followeeID (uuid.UUID): ID of the user removing the follower.
+//   - followerID (uuid.UUID): ID of the follower to remove.
+//
+// Returns:
+//   - error: An error if removing the follower fails or ErrNotFollowing if the follower is not following the user.
+func (fs *FollowStore) RemoveFollower(ctx context.Context, followeeID uuid.UUID, followerID uuid.UUID) error {
+	commandTag, err := fs.dbPool.Exec(ctx, `
+		DELETE FROM follows
+		WHERE follower_id = $1 AND followee_id = $2
+	`, followerID, followeeID)
+	if err != nil {
+		return fmt.Errorf("failed to remove follower: %w", err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return ErrNotFollowing
+	}
+	return nil
+}
+
+// ListFollowerIDs retrieves the IDs of all followers of a user.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the database operation.
+//   - followeeID (uuid.UUID): ID of the user whose followers are listed.
+//
+// Returns:
+//   - []uuid.UUID: IDs of the followers.
+//   - error: An error if the database query fails.
+func (fs *FollowStore) ListFollowerIDs(ctx context.Context, followeeID uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := fs.dbPool.Query(ctx, `SELECT follower_id FROM follows WHERE followee_id = $1`, followeeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list follower IDs: %w", err)
+	}
+	defer rows.Close()
+
+	var followerIDs []uuid.UUID
+	for rows.Next() {
+		var followerID uuid.UUID
+		if err := rows.Scan(&followerID); err != nil {
+			return nil, fmt.Errorf("failed to scan follower ID row: %w", err)
+		}
+		followerIDs = append(followerIDs, followerID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during follower IDs rows iteration: %w", err)
+	}
+
+	return followerIDs, nil
+}
+
 // GetFollowersByUserID retrieves all followers of a user, excluding banned users and includes follower/following counts.
 //
 // Parameters:
@@ -101,16 +228,16 @@ func (fs *FollowStore) UnfollowUser(ctx context.Context, followerID uuid.UUID, f
 //   - pageSize (int): Page size for pagination.
 //
 // Returns:
-//   - []*models.User: List of users following the user (followee) with follower and following counts.
+//   - []*models.UserSummary: List of users following the user (followee) with their role and follower and following counts.
 //   - error: An error if fetching followers fails.
-func (fs *FollowStore) GetFollowersByUserID(ctx context.Context, followeeID uuid.UUID, pageNumber int, pageSize int) ([]*models.User, error) {
+func (fs *FollowStore) GetFollowersByUserID(ctx context.Context, followeeID uuid.UUID, pageNumber int, pageSize int) ([]*models.UserSummary, error) {
 	offset := (pageNumber - 1) * pageSize
 	rows, err := fs.dbPool.Query(ctx, `
 		SELECT
-			u.id, u.username, u.email, u.role_id, u.timeout_until, u.is_active, u.created_at, u.updated_at,
+			u.id, u.username, u.created_at,
 			r.level, r.description,
-			(SELECT COUNT(*) FROM follows WHERE followee_id = u.id) as followers_count,
-			(SELECT COUNT(*) FROM follows WHERE follower_id = u.id) as following_count
+			u.followers_count,
+			u.following_count
 		FROM follows f
 		INNER JOIN users u ON f.follower_id = u.id
 		INNER JOIN roles r ON u.role_id = r.id
@@ -123,11 +250,11 @@ func (fs *FollowStore) GetFollowersByUserID(ctx context.Context, followeeID uuid
 	}
 	defer rows.Close()
 
-	var followers []*models.User
+	var followers []*models.UserSummary
 	for rows.Next() {
-		user := &models.User{Role: &models.Role{}}
+		user := &models.UserSummary{Role: &models.Role{}}
 		err := rows.Scan(
-			&user.ID, &user.Username, &user.Email, &user.RoleID, &user.TimeoutUntil, &user.IsActive, &user.CreatedAt, &user.UpdatedAt,
+			&user.ID, &user.Username, &user.CreatedAt,
 			&user.Role.Level, &user.Role.Description,
 			&user.Followers, &user.Following,
 		)
@@ -153,16 +280,16 @@ func (fs *FollowStore) GetFollowersByUserID(ctx context.Context, followeeID uuid
 //   - pageSize (int): Page size for pagination.
 //
 // Returns:
-//   - []*models.User: List of users being followed by the user (follower) with follower and following counts.
+//   - []*models.UserSummary: List of users being followed by the user (follower) with their role and follower and following counts.
 //   - error: An error if fetching following users fails.
-func (fs *FollowStore) GetFollowingByUserID(ctx context.Context, followerID uuid.UUID, pageNumber int, pageSize int) ([]*models.User, error) {
+func (fs *FollowStore) GetFollowingByUserID(ctx context.Context, followerID uuid.UUID, pageNumber int, pageSize int) ([]*models.UserSummary, error) {
 	offset := (pageNumber - 1) * pageSize
 	rows, err := fs.dbPool.Query(ctx, `
 		SELECT
-			u.id, u.username, u.email, u.role_id, u.timeout_until, u.is_active, u.created_at, u.updated_at,
+			u.id, u.username, u.created_at,
 			r.level, r.description,
-			(SELECT COUNT(*) FROM follows WHERE followee_id = u.id) as followers_count,
-			(SELECT COUNT(*) FROM follows WHERE follower_id = u.id) as following_count
+			u.followers_count,
+			u.following_count
 		FROM follows f
 		INNER JOIN users u ON f.followee_id = u.id
 		INNER JOIN roles r ON u.role_id = r.id
@@ -175,11 +302,11 @@ func (fs *FollowStore) GetFollowingByUserID(ctx context.Context, followerID uuid
 	}
 	defer rows.Close()
 
-	var following []*models.User
+	var following []*models.UserSummary
 	for rows.Next() {
-		user := &models.User{Role: &models.Role{}}
+		user := &models.UserSummary{Role: &models.Role{}}
 		err := rows.Scan(
-			&user.ID, &user.Username, &user.Email, &user.RoleID, &user.TimeoutUntil, &user.IsActive, &user.CreatedAt, &user.UpdatedAt,
+			&user.ID, &user.Username, &user.CreatedAt,
 			&user.Role.Level, &user.Role.Description,
 			&user.Followers, &user.Following,
 		)
@@ -195,3 +322,367 @@ func (fs *FollowStore) GetFollowingByUserID(ctx context.Context, followerID uuid
 
 	return following, nil
 }
+
+// ListMutuals retrieves the users who both follow and are followed by a user, excluding banned and inactive users.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the database operation.
+//   - userID (uuid.UUID): ID of the user to get mutuals for.
+//   - pageNumber (int): Page number for pagination.
+//   - pageSize (int): Page size for pagination.
+//
+// Returns:
+//   - []*models.UserSummary: List of mutuals with their role and follower and following counts, empty if there are none.
+//   - *models.Pagination: Pagination metadata including the total number of mutuals.
+//   - error: An error if fetching mutuals fails.
+func (fs *FollowStore) ListMutuals(ctx context.Context, userID uuid.UUID, pageNumber int, pageSize int) ([]*models.UserSummary, *models.Pagination, error) {
+	offset := (pageNumber - 1) * pageSize
+	rows, err := fs.dbPool.Query(ctx, `
+		SELECT
+			u.id, u.username, u.created_at,
+			r.level, r.description,
+			u.followers_count,
+			u.following_count,
+			COUNT(*) OVER() as total_mutuals
+		FROM follows follower
+		INNER JOIN follows followee ON followee.follower_id = follower.followee_id AND followee.followee_id = follower.follower_id
+		INNER JOIN users u ON follower.follower_id = u.id
+		INNER JOIN roles r ON u.role_id = r.id
+		WHERE follower.followee_id = $1 AND u.banned = FALSE AND u.is_active = TRUE
+		ORDER BY u.created_at DESC
+		LIMIT $2 OFFSET $3
+	`, userID, pageSize, offset)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get mutuals: %w", err)
+	}
+	defer rows.Close()
+
+	mutuals := []*models.UserSummary{}
+	var totalMutuals int
+	for rows.Next() {
+		user := &models.UserSummary{Role: &models.Role{}}
+		err := rows.Scan(
+			&user.ID, &user.Username, &user.CreatedAt,
+			&user.Role.Level, &user.Role.Description,
+			&user.Followers, &user.Following,
+			&totalMutuals,
+		)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to scan mutual row: %w", err)
+		}
+		mutuals = append(mutuals, user)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("error during mutuals rows iteration: %w", err)
+	}
+
+	// A page past the end has no rows to carry the window count, so count the mutuals separately.
+	if len(mutuals) == 0 && offset > 0 {
+		err := fs.dbPool.QueryRow(ctx, `
+			SELECT COUNT(*)
+			FROM follows follower
+			INNER JOIN follows followee ON followee.follower_id = follower.followee_id AND followee.followee_id = follower.follower_id
+			INNER JOIN users u ON follower.follower_id = u.id
+			WHERE follower.followee_id = $1 AND u.banned = FALSE AND u.is_active = TRUE
+		`, userID).Scan(&totalMutuals)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to count mutuals: %w", err)
+		}
+	}
+
+	return mutuals, newPagination(pageNumber, pageSize, totalMutuals), nil
+}
+
+// SuggestUsers retrieves users the user may want to follow, ranked by the number of people the user follows
+// who follow them, then by their posts, comments and reactions over the last 30 days.
+// The user, users they already follow or requested to follow, blocked users in either direction, and
+// banned or inactive users are excluded.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the database operation.
+//   - userID (uuid.UUID): ID of the user to suggest users for.
+//   - pageNumber (int): Page number for pagination.
+//   - pageSize (int): Page size for pagination.
+//
+// Returns:
+//   - []*models.UserSuggestion: List of suggested users with their role and follower and following counts.
+//   - error: An error if fetching suggestions fails.
+func (fs *FollowStore) SuggestUsers(ctx context.Context, userID uuid.UUID, pageNumber int, pageSize int) ([]*models.UserSuggestion, error) {
+	offset := (pageNumber - 1) * pageSize
+	rows, err := fs.dbPool.Query(ctx, `
+		SELECT
+			u.id, u.username, u.created_at,
+			r.level, r.description,
+			u.followers_count,
+			u.following_count,
+			(SELECT COUNT(*) FROM follows mf INNER JOIN follows mine ON mine.followee_id = mf.follower_id AND mine.follower_id = $1 WHERE mf.followee_id = u.id) as mutual_followers,
+			(SELECT COUNT(*) FROM posts WHERE author_id = u.id AND created_at > now() - INTERVAL '30 days') +
+			(SELECT COUNT(*) FROM comments WHERE author_id = u.id AND created_at > now() - INTERVAL '30 days') +
+			(SELECT COUNT(*) FROM post_likes WHERE user_id = u.id AND created_at > now() - INTERVAL '30 days') +
+			(SELECT COUNT(*) FROM comment_likes WHERE user_id = u.id AND created_at > now() - INTERVAL '30 days') as recent_activity
+		FROM users u
+		INNER JOIN roles r ON u.role_id = r.id
+		WHERE u.id != $1 AND u.banned = FALSE AND u.is_active = TRUE
+			AND NOT EXISTS (SELECT 1 FROM follows WHERE follower_id = $1 AND followee_id = u.id)
+			AND NOT EXISTS (SELECT 1 FROM follow_requests WHERE requester_id = $1 AND target_id = u.id)
+			AND NOT EXISTS (SELECT 1 FROM blocks WHERE (blocker_id = $1 AND blocked_id = u.id) OR (blocker_id = u.id AND blocked_id = $1))
+		ORDER BY mutual_followers DESC, recent_activity DESC, u.created_at DESC
+		LIMIT $2 OFFSET $3
+	`, userID, pageSize, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user suggestions: %w", err)
+	}
+	defer rows.Close()
+
+	var suggestions []*models.UserSuggestion
+	for rows.Next() {
+		suggestion := &models.UserSuggestion{User: &models.UserSummary{Role: &models.Role{}}}
+		user := suggestion.User
+		err := rows.Scan(
+			&user.ID, &user.Username, &user.CreatedAt,
+			&user.Role.Level, &user.Role.Description,
+			&user.Followers, &user.Following,
+			&suggestion.MutualFollowers, &suggestion.RecentActivity,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan user suggestion row: %w", err)
+		}
+		suggestions = append(suggestions, suggestion)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during user suggestions rows iteration: %w", err)
+	}
+
+	return suggestions, nil
+}
+
+// CanViewPosts checks whether a viewer may see the posts of an author.
+// Posts of public profiles are visible to everyone, posts of private profiles only to the author and their followers.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the database operation.
+//   - viewerID (uuid.UUID): ID of the user viewing the posts.
+//   - authorID (uuid.UUID): ID of the author of the posts.
+//
+// Returns:
+//   - bool: True if the viewer may see the author's posts.
+//   - error: An error if the check fails.
+func (fs *FollowStore) CanViewPosts(ctx context.Context, viewerID uuid.UUID, authorID uuid.UUID) (bool, error) {
+	var canView bool
+	err := fs.dbPool.QueryRow(ctx, `
+		SELECT
+			$1 = $2
+			OR NOT EXISTS (SELECT 1 FROM profiles WHERE user_id = $2 AND is_private = TRUE)
+			OR EXISTS (SELECT 1 FROM follows WHERE follower_id = $1 AND followee_id = $2)
+	`, viewerID, authorID).Scan(&canView)
+	if err != nil {
+		return false, fmt.Errorf("failed to check post visibility: %w", err)
+	}
+	return canView, nil
+}
+
+// ListFollowRequests retrieves the pending follow requests received by a user, newest first.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the database operation.
+//   - targetID (uuid.UUID): ID of the user who received the requests.
+//   - pageNumber (int): Page number for pagination.
+//   - pageSize (int): Page size for pagination.
+//
+// Returns:
+//   - []*models.FollowRequest: List of follow requests with requester details.
+//   - error: An error if fetching follow requests fails.
+func (fs *FollowStore) ListFollowRequests(ctx context.Context, targetID uuid.UUID, pageNumber int, pageSize int) ([]*models.FollowRequest, error) {
+	offset := (pageNumber - 1) * pageSize
+	rows, err := fs.dbPool.Query(ctx, `
+		SELECT
+			fr.id, fr.requester_id, fr.target_id, fr.created_at,
+			u.id, u.username, u.email, u.role_id, u.is_active, u.created_at, u.updated_at,
+			r.level, r.description,
+			u.followers_count,
+			u.following_count
+		FROM follow_requests fr
+		INNER JOIN users u ON fr.requester_id = u.id
+		INNER JOIN roles r ON u.role_id = r.id
+		WHERE fr.target_id = $1 AND u.banned = FALSE AND u.is_active = TRUE
+		ORDER BY fr.created_at DESC
+		LIMIT $2 OFFSET $3
+	`, targetID, pageSize, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list follow requests: %w", err)
+	}
+	defer rows.Close()
+
+	var followRequests []*models.FollowRequest
+	for rows.Next() {
+		followRequest := &models.FollowRequest{Requester: &models.User{Role: &models.Role{}}}
+		err := rows.Scan(
+			&followRequest.ID, &followRequest.RequesterID, &followRequest.TargetID, &followRequest.CreatedAt,
+			&followRequest.Requester.ID, &followRequest.Requester.Username, &followRequest.Requester.Email, &followRequest.Requester.RoleID, &followRequest.Requester.IsActive, &followRequest.Requester.CreatedAt, &followRequest.Requester.UpdatedAt,
+			&followRequest.Requester.Role.Level, &followRequest.Requester.Role.Description,
+			&followRequest.Requester.Followers, &followRequest.Requester.Following,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan follow request row: %w", err)
+		}
+		followRequests = append(followRequests, followRequest)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during follow requests rows iteration: %w", err)
+	}
+
+	return followRequests, nil
+}
+
+// AcceptFollowRequest accepts a pending follow request and creates the follow relationship in a single transaction.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the database operation.
+//   - targetID (uuid.UUID): ID of the user who received the request.
+//   - requestID (uuid.UUID): ID of the follow request.
+//
+// Returns:
+//   - uuid.UUID: ID of the user who sent the request and is now following the target.
+//   - error: ErrFollowRequestNotFound if the request does not exist for the user, or other errors.
+func (fs *FollowStore) AcceptFollowRequest(ctx context.Context, targetID uuid.UUID, requestID uuid.UUID) (uuid.UUID, error) {
+	var requesterID uuid.UUID
+	err := WithTx(ctx, fs.dbPool, func(tx pgx.Tx) error {
+		err := tx.QueryRow(ctx, `
+			DELETE FROM follow_requests
+			WHERE id = $1 AND target_id = $2
+			RETURNING requester_id
+		`, requestID, targetID).Scan(&requesterID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ErrFollowRequestNotFound
+			}
+			return fmt.Errorf("failed to delete follow request: %w", err)
+		}
+
+		_, err = tx.Exec(ctx, `
+			INSERT INTO follows (follower_id, followee_id)
+			VALUES ($1, $2)
+			ON CONFLICT DO NOTHING
+		`, requesterID, targetID)
+		if err != nil {
+			return fmt.Errorf("failed to create follow from request: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	return requesterID, nil
+}
+
+// RejectFollowRequest deletes a pending follow request without creating a follow relationship.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the database operation.
+//   - targetID (uuid.UUID): ID of the user who received the request.
+//   - requestID (uuid.UUID): ID of the follow request.
+//
+// Returns:
+//   - error: ErrFollowRequestNotFound if the request does not exist for the user, or other errors.
+func (fs *FollowStore) RejectFollowRequest(ctx context.Context, targetID uuid.UUID, requestID uuid.UUID) error {
+	commandTag, err := fs.dbPool.Exec(ctx, `
+		DELETE FROM follow_requests
+		WHERE id = $1 AND target_id = $2
+	`, requestID, targetID)
+	if err != nil {
+		return fmt.Errorf("failed to reject follow request: %w", err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return ErrFollowRequestNotFound
+	}
+	return nil
+}
+
+// FollowingStatusBatch reports for each identifier (username, email, or user ID) whether a user follows that user.
+// Identifiers that do not resolve to a user are reported as not followed.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the database operation.
+//   - followerID (uuid.UUID): ID of the user whose followings are checked.
+//   - identifiers ([]string): Usernames, emails, or user IDs of the users to check.
+//
+// Returns:
+//   - map[string]bool: Follow status keyed by the given identifiers.
+//   - error: An error if the database query fails.
+func (fs *FollowStore) FollowingStatusBatch(ctx context.Context, followerID uuid.UUID, identifiers []string) (map[string]bool, error) {
+	statuses := make(map[string]bool, len(identifiers))
+	var userIDs []uuid.UUID
+	for _, identifier := range identifiers {
+		statuses[identifier] = false
+		if userID, err := uuid.Parse(identifier); err == nil {
+			userIDs = append(userIDs, userID)
+		}
+	}
+
+	rows, err := fs.dbPool.Query(ctx, `
+		SELECT id, username, email
+		FROM users
+		WHERE username = ANY($1) OR email = ANY($1) OR id = ANY($2)
+	`, identifiers, userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve user identifiers: %w", err)
+	}
+	defer rows.Close()
+
+	identifiersByUserID := make(map[uuid.UUID][]string)
+	for rows.Next() {
+		var userID uuid.UUID
+		var username, email string
+		if err := rows.Scan(&userID, &username, &email); err != nil {
+			return nil, fmt.Errorf("failed to scan user row: %w", err)
+		}
+		for _, key := range []string{username, email, userID.String()} {
+			if _, requested := statuses[key]; requested {
+				identifiersByUserID[userID] = append(identifiersByUserID[userID], key)
+			}
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during users rows iteration: %w", err)
+	}
+
+	if len(identifiersByUserID) == 0 {
+		return statuses, nil
+	}
+
+	followeeIDs := make([]uuid.UUID, 0, len(identifiersByUserID))
+	for userID := range identifiersByUserID {
+		followeeIDs = append(followeeIDs, userID)
+	}
+
+	rows, err = fs.dbPool.Query(ctx, `
+		SELECT followee_id
+		FROM follows
+		WHERE follower_id = $1 AND followee_id = ANY($2)
+	`, followerID, followeeIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get following status: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var followeeID uuid.UUID
+		if err := rows.Scan(&followeeID); err != nil {
+			return nil, fmt.Errorf("failed to scan follow row: %w", err)
+		}
+		for _, identifier := range identifiersByUserID[followeeID] {
+			statuses[identifier] = true
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during follows rows iteration: %w", err)
+	}
+
+	return statuses, nil
+}
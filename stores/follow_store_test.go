@@ -0,0 +1,158 @@
+package stores
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/datarohit/gopher-social-backend/database/dbtest"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// TestFollowUserInactiveTarget checks that following a banned or inactive user, with a public or a private profile,
+// fails with ErrCannotFollowInactiveUser and creates neither a follow nor a follow request.
+func TestFollowUserInactiveTarget(t *testing.T) {
+	tests := []struct {
+		name    string
+		setup   func(t *testing.T, pool *pgxpool.Pool, targetID uuid.UUID)
+		wantErr error
+	}{
+		{
+			name: "banned",
+			setup: func(t *testing.T, pool *pgxpool.Pool, targetID uuid.UUID) {
+				dbtest.Exec(t, pool, `UPDATE users SET banned = TRUE, is_active = FALSE WHERE id = $1`, targetID)
+			},
+			wantErr: ErrCannotFollowInactiveUser,
+		},
+		{
+			name: "inactive",
+			setup: func(t *testing.T, pool *pgxpool.Pool, targetID uuid.UUID) {
+				dbtest.Exec(t, pool, `UPDATE users SET is_active = FALSE WHERE id = $1`, targetID)
+			},
+			wantErr: ErrCannotFollowInactiveUser,
+		},
+		{
+			name: "banned with a private profile",
+			setup: func(t *testing.T, pool *pgxpool.Pool, targetID uuid.UUID) {
+				dbtest.Exec(t, pool, `UPDATE profiles SET is_private = TRUE WHERE user_id = $1`, targetID)
+				dbtest.Exec(t, pool, `UPDATE users SET banned = TRUE, is_active = FALSE WHERE id = $1`, targetID)
+			},
+			wantErr: ErrCannotFollowInactiveUser,
+		},
+		{
+			name: "missing",
+			setup: func(t *testing.T, pool *pgxpool.Pool, targetID uuid.UUID) {
+				dbtest.Exec(t, pool, `DELETE FROM users WHERE id = $1`, targetID)
+			},
+			wantErr: ErrUserNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool := dbtest.NewPool(t)
+
+			followerID := dbtest.CreateUser(t, pool, "follower", 1)
+			targetID := dbtest.CreateUser(t, pool, "target", 1)
+			tt.setup(t, pool, targetID)
+
+			if _, err := NewFollowStore(pool).FollowUser(context.Background(), followerID, targetID); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("FollowUser() error = %v, want %v", err, tt.wantErr)
+			}
+			if count := dbtest.Count(t, pool, `SELECT COUNT(*) FROM follows WHERE follower_id = $1`, followerID); count != 0 {
+				t.Fatalf("%d follows created, want 0", count)
+			}
+			if count := dbtest.Count(t, pool, `SELECT COUNT(*) FROM follow_requests WHERE requester_id = $1`, followerID); count != 0 {
+				t.Fatalf("%d follow requests created, want 0", count)
+			}
+		})
+	}
+}
+
+// followersWithCountSubqueries is the followers listing as it was before the follow counts were denormalized,
+// counting the followers and followings of every listed user with correlated subqueries.
+const followersWithCountSubqueries = `
+	SELECT
+		u.id, u.username, u.created_at,
+		r.level, r.description,
+		(SELECT COUNT(*) FROM follows WHERE followee_id = u.id) as followers_count,
+		(SELECT COUNT(*) FROM follows WHERE follower_id = u.id) as following_count
+	FROM follows f
+	INNER JOIN users u ON f.follower_id = u.id
+	INNER JOIN roles r ON u.role_id = r.id
+	WHERE f.followee_id = $1 AND u.banned = FALSE AND u.is_active = TRUE
+	ORDER BY u.created_at DESC
+	LIMIT $2 OFFSET $3
+`
+
+// BenchmarkFollowersListing compares a page of the followers listing reading the denormalized follow counts
+// with the same page counting follows per row. Every follower of the listed user also follows 20 other users.
+func BenchmarkFollowersListing(b *testing.B) {
+	pool := dbtest.NewPool(b)
+	ctx := context.Background()
+
+	userID := dbtest.CreateUser(b, pool, "popular", 1)
+	followerIDs := dbtest.CreateUsers(b, pool, "follower", 2000)
+	dbtest.Exec(b, pool, `INSERT INTO follows (follower_id, followee_id) SELECT unnest($1::uuid[]), $2`, followerIDs, userID)
+	dbtest.Exec(b, pool, `
+		INSERT INTO follows (follower_id, followee_id)
+		SELECT f.id, g.id
+		FROM unnest($1::uuid[]) WITH ORDINALITY f(id, n)
+		INNER JOIN unnest($1::uuid[]) WITH ORDINALITY g(id, n) ON g.n BETWEEN f.n + 1 AND f.n + 20
+	`, followerIDs)
+	dbtest.Exec(b, pool, `ANALYZE`)
+
+	store := NewFollowStore(pool)
+	b.Run("denormalized counts", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := store.GetFollowersByUserID(ctx, userID, 1, 50); err != nil {
+				b.Fatalf("GetFollowersByUserID() error = %v", err)
+			}
+		}
+	})
+	b.Run("count subqueries", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			rows, err := pool.Query(ctx, followersWithCountSubqueries, userID, 50, 0)
+			if err != nil {
+				b.Fatalf("query error = %v", err)
+			}
+			for rows.Next() {
+			}
+			rows.Close()
+			if err := rows.Err(); err != nil {
+				b.Fatalf("rows error = %v", err)
+			}
+		}
+	})
+}
+
+// TestFollowCountsMaintained checks that the denormalized follow counts follow follows and unfollows.
+func TestFollowCountsMaintained(t *testing.T) {
+	pool := dbtest.NewPool(t)
+	ctx := context.Background()
+	store := NewFollowStore(pool)
+
+	followerID := dbtest.CreateUser(t, pool, "follower", 1)
+	followeeID := dbtest.CreateUser(t, pool, "followee", 1)
+
+	assertCounts := func(userID uuid.UUID, wantFollowers int, wantFollowing int) {
+		t.Helper()
+		count := dbtest.Count(t, pool, `SELECT COUNT(*) FROM users WHERE id = $1 AND followers_count = $2 AND following_count = $3`, userID, wantFollowers, wantFollowing)
+		if count != 1 {
+			t.Fatalf("user %s does not have %d followers and %d followings", userID, wantFollowers, wantFollowing)
+		}
+	}
+
+	if _, err := store.FollowUser(ctx, followerID, followeeID); err != nil {
+		t.Fatalf("FollowUser() error = %v", err)
+	}
+	assertCounts(followerID, 0, 1)
+	assertCounts(followeeID, 1, 0)
+
+	if err := store.UnfollowUser(ctx, followerID, followeeID); err != nil {
+		t.Fatalf("UnfollowUser() error = %v", err)
+	}
+	assertCounts(followerID, 0, 0)
+	assertCounts(followeeID, 0, 0)
+}
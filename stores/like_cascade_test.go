@@ -0,0 +1,88 @@
+package stores
+
+import (
+	"context"
+	"testing"
+
+	"github.com/datarohit/gopher-social-backend/database/dbtest"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// TestLikesRemovedWithParent deletes a liked comment or post through the author and moderator paths and checks
+// that the foreign keys cascade to its like rows, and from a post to the likes of its comments.
+// Likes of content that is not deleted must be kept.
+func TestLikesRemovedWithParent(t *testing.T) {
+	tests := []struct {
+		name          string
+		deleteComment bool
+		remove        func(ctx context.Context, pool *pgxpool.Pool, postID uuid.UUID, commentID uuid.UUID) error
+	}{
+		{
+			name:          "comment deleted by its author",
+			deleteComment: true,
+			remove: func(ctx context.Context, pool *pgxpool.Pool, postID uuid.UUID, commentID uuid.UUID) error {
+				return NewCommentStore(pool).DeleteComment(ctx, commentID, postID)
+			},
+		},
+		{
+			name:          "comment deleted by a moderator",
+			deleteComment: true,
+			remove: func(ctx context.Context, pool *pgxpool.Pool, postID uuid.UUID, commentID uuid.UUID) error {
+				return NewActionStore(pool).DeleteCommentByCommentID(ctx, commentID)
+			},
+		},
+		{
+			name: "post deleted by its author",
+			remove: func(ctx context.Context, pool *pgxpool.Pool, postID uuid.UUID, commentID uuid.UUID) error {
+				return NewPostStore(pool).DeletePost(ctx, postID)
+			},
+		},
+		{
+			name: "post deleted by a moderator",
+			remove: func(ctx context.Context, pool *pgxpool.Pool, postID uuid.UUID, commentID uuid.UUID) error {
+				return NewActionStore(pool).DeletePostByPostID(ctx, postID)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool := dbtest.NewPool(t)
+			ctx := context.Background()
+
+			authorID := dbtest.CreateUser(t, pool, "author", 1)
+			readerID := dbtest.CreateUser(t, pool, "reader", 1)
+			postID := dbtest.CreatePost(t, pool, authorID)
+			commentID := dbtest.CreateComment(t, pool, authorID, postID)
+			keptPostID := dbtest.CreatePost(t, pool, authorID)
+			keptCommentID := dbtest.CreateComment(t, pool, authorID, keptPostID)
+
+			for _, userID := range []uuid.UUID{authorID, readerID} {
+				dbtest.Exec(t, pool, `INSERT INTO post_likes (user_id, post_id, liked) VALUES ($1, $2, TRUE), ($1, $3, TRUE)`, userID, postID, keptPostID)
+				dbtest.Exec(t, pool, `INSERT INTO comment_likes (user_id, comment_id, liked) VALUES ($1, $2, FALSE), ($1, $3, TRUE)`, userID, commentID, keptCommentID)
+			}
+
+			if err := tt.remove(ctx, pool, postID, commentID); err != nil {
+				t.Fatalf("delete error = %v", err)
+			}
+
+			if count := dbtest.Count(t, pool, `SELECT COUNT(*) FROM comment_likes WHERE comment_id = $1`, commentID); count != 0 {
+				t.Fatalf("%d likes left on the deleted comment, want 0", count)
+			}
+			wantPostLikes := 0
+			if tt.deleteComment {
+				wantPostLikes = 2
+			}
+			if count := dbtest.Count(t, pool, `SELECT COUNT(*) FROM post_likes WHERE post_id = $1`, postID); count != wantPostLikes {
+				t.Fatalf("%d likes left on the post, want %d", count, wantPostLikes)
+			}
+			if count := dbtest.Count(t, pool, `SELECT COUNT(*) FROM post_likes WHERE post_id = $1`, keptPostID); count != 2 {
+				t.Fatalf("%d likes left on the other post, want 2", count)
+			}
+			if count := dbtest.Count(t, pool, `SELECT COUNT(*) FROM comment_likes WHERE comment_id = $1`, keptCommentID); count != 2 {
+				t.Fatalf("%d likes left on the other comment, want 2", count)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package stores
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+type MaintenanceStore struct {
+	redisClient *redis.Client
+}
+
+// NewMaintenanceStore creates a new MaintenanceStore.
+//
+// Parameters:
+//   - redisClient (*redis.Client): Redis client holding the maintenance flag.
+//
+// Returns:
+//   - *MaintenanceStore: MaintenanceStore instance.
+func NewMaintenanceStore(redisClient *redis.Client) *MaintenanceStore {
+	return &MaintenanceStore{
+		redisClient: redisClient,
+	}
+}
+
+// maintenanceReadOnlyKey is the Redis key set while the API is in read-only maintenance mode.
+// It is shared by all instances of the server, so toggling it on one instance affects every instance.
+const maintenanceReadOnlyKey = "maintenance:readonly"
+
+// IsReadOnly reports whether the API is in read-only maintenance mode.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the Redis operation.
+//
+// Returns:
+//   - bool: True if writes are blocked.
+//   - error: An error if reading the flag fails.
+func (ms *MaintenanceStore) IsReadOnly(ctx context.Context) (bool, error) {
+	err := ms.redisClient.Get(ctx, maintenanceReadOnlyKey).Err()
+	if errors.Is(err, redis.Nil) {
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("failed to get maintenance flag: %w", err)
+	}
+	return true, nil
+}
+
+// SetReadOnly turns read-only maintenance mode on or off. The flag has no expiry, it stays set until turned off.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the Redis operation.
+//   - readOnly (bool): True to block writes, false to allow them again.
+//
+// Returns:
+//   - error: An error if updating the flag fails.
+func (ms *MaintenanceStore) SetReadOnly(ctx context.Context, readOnly bool) error {
+	var err error
+	if readOnly {
+		err = ms.redisClient.Set(ctx, maintenanceReadOnlyKey, "1", 0).Err()
+	} else {
+		err = ms.redisClient.Del(ctx, maintenanceReadOnlyKey).Err()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to set maintenance flag: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package stores
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/datarohit/gopher-social-backend/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Moderation actions recorded in the moderation audit log.
+const (
+	ModerationActionRoleChange    = "role_change"
+	ModerationActionForceLogout   = "force_logout"
+	ModerationActionTimeout       = "timeout"
+	ModerationActionRemoveTimeout = "remove_timeout"
+	ModerationActionDeactivate    = "deactivate"
+	ModerationActionActivate      = "activate"
+	ModerationActionBan           = "ban"
+	ModerationActionUnban         = "unban"
+	ModerationActionTransferPost  = "transfer_post"
+	ModerationActionUndo          = "undo"
+	ModerationActionImpersonate   = "impersonate"
+)
+
+type ModerationLogStore struct {
+	dbPool *pgxpool.Pool
+}
+
+// NewModerationLogStore creates a new ModerationLogStore.
+//
+// Parameters:
+//   - dbPool (*pgxpool.Pool): Pgx connection pool.
+//
+// Returns:
+//   - *ModerationLogStore: ModerationLogStore instance.
+func NewModerationLogStore(dbPool *pgxpool.Pool) *ModerationLogStore {
+	return &ModerationLogStore{
+		dbPool: dbPool,
+	}
+}
+
+// ListModerationHistoryByUserID retrieves the moderation actions taken against a user with pagination, most recent first.
+// Each entry includes the moderator or admin who performed it, which is nil if their account has since been deleted,
+// and when it was undone, if it was.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the database operation.
+//   - targetUserID (uuid.UUID): ID of the user whose history is retrieved.
+//   - pageNumber (int): Page number for pagination.
+//   - pageSize (int): Page size for pagination.
+//
+// Returns:
+//   - []*models.ModerationLog: A slice of ModerationLog pointers, or nil if no action was taken against the user.
+//   - error: An error if the database query fails.
+func (mls *ModerationLogStore) ListModerationHistoryByUserID(ctx context.Context, targetUserID uuid.UUID, pageNumber int, pageSize int) ([]*models.ModerationLog, error) {
+	offset := (pageNumber - 1) * pageSize
+	rows, err := mls.dbPool.Query(ctx, `
+		SELECT
+			ml.id, ml.target_user_id, ml.action, ml.details, ml.created_at, ml.undone_at,
+			u.id, u.username, r.level
+		FROM moderation_logs ml
+		LEFT JOIN users u ON ml.actor_id = u.id
+		LEFT JOIN roles r ON u.role_id = r.id
+		WHERE ml.target_user_id = $1
+		ORDER BY ml.created_at DESC
+		LIMIT $2 OFFSET $3
+	`, targetUserID, pageSize, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list moderation history: %w", err)
+	}
+	defer rows.Close()
+
+	var logs []*models.ModerationLog
+	for rows.Next() {
+		log := &models.ModerationLog{}
+		var actorID *uuid.UUID
+		var actorUsername *string
+		var actorRoleLevel *int
+		err := rows.Scan(
+			&log.ID, &log.TargetUserID, &log.Action, &log.Details, &log.CreatedAt, &log.UndoneAt,
+			&actorID, &actorUsername, &actorRoleLevel,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan moderation log row: %w", err)
+		}
+		if actorID != nil {
+			log.Actor = &models.ModerationLogActor{ID: *actorID, Username: *actorUsername, RoleLevel: *actorRoleLevel}
+		}
+		logs = append(logs, log)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during moderation logs rows iteration: %w", err)
+	}
+
+	return logs, nil
+}
+
+// recordModerationAction inserts an entry into the moderation audit log as part of a transaction.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the database operation.
+//   - tx (pgx.Tx): Transaction the entry is written in.
+//   - actorID (uuid.UUID): ID of the user performing the action.
+//   - targetUserID (uuid.UUID): ID of the user the action is performed on.
+//   - action (string): One of the ModerationAction constants.
+//   - details (string): Human readable details of the action.
+//
+// Returns:
+//   - error: An error if the entry could not be written.
+func recordModerationAction(ctx context.Context, tx pgx.Tx, actorID uuid.UUID, targetUserID uuid.UUID, action string, details string) error {
+	_, err := tx.Exec(ctx, `
+		INSERT INTO moderation_logs (actor_id, target_user_id, action, details)
+		VALUES ($1, $2, $3, $4)
+	`, actorID, targetUserID, action, details)
+	if err != nil {
+		return fmt.Errorf("failed to record moderation action: %w", err)
+	}
+	return nil
+}
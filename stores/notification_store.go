@@ -0,0 +1,208 @@
+package stores
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/datarohit/gopher-social-backend/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Notification types recorded in the notifications table.
+const (
+	NotificationTypeFollow        = "follow"
+	NotificationTypeFollowRequest = "follow_request"
+	NotificationTypeQuote         = "quote"
+	NotificationTypeNewPost       = "new_post"
+)
+
+// notificationBatchSize is the most notifications inserted by one statement in CreateBatch,
+// keeping each statement well under the PostgreSQL limit of 65535 parameters.
+const notificationBatchSize = 1000
+
+type NotificationStore struct {
+	dbPool *pgxpool.Pool
+}
+
+// NewNotificationStore creates a new NotificationStore.
+//
+// Parameters:
+//   - dbPool (*pgxpool.Pool): Pgx connection pool.
+//
+// Returns:
+//   - *NotificationStore: NotificationStore instance.
+func NewNotificationStore(dbPool *pgxpool.Pool) *NotificationStore {
+	return &NotificationStore{
+		dbPool: dbPool,
+	}
+}
+
+// CreateNotification creates an unread notification for a user.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the database operation.
+//   - userID (uuid.UUID): ID of the user receiving the notification.
+//   - actorID (uuid.UUID): ID of the user who triggered the notification.
+//   - notificationType (string): Type of the notification.
+//   - entityID (*uuid.UUID): Optional ID of the entity the notification refers to.
+//
+// Returns:
+//   - error: An error if creating the notification fails.
+func (ns *NotificationStore) CreateNotification(ctx context.Context, userID uuid.UUID, actorID uuid.UUID, notificationType string, entityID *uuid.UUID) error {
+	_, err := ns.dbPool.Exec(ctx, `
+		INSERT INTO notifications (user_id, actor_id, type, entity_id)
+		VALUES ($1, $2, $3, $4)
+	`, userID, actorID, notificationType, entityID)
+	if err != nil {
+		return fmt.Errorf("failed to create notification: %w", err)
+	}
+	return nil
+}
+
+// CreateBatch creates unread notifications for many users at once, such as the followers of a user who posted.
+// Notifications are inserted with multi-row INSERT statements of at most notificationBatchSize rows,
+// all in one transaction, so either every notification is created or none is.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the database operation.
+//   - notifications ([]*models.Notification): Notifications to create, UserID, ActorID, Type and EntityID are used.
+//
+// Returns:
+//   - error: An error if creating the notifications fails.
+func (ns *NotificationStore) CreateBatch(ctx context.Context, notifications []*models.Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+
+	return WithTx(ctx, ns.dbPool, func(tx pgx.Tx) error {
+		for start := 0; start < len(notifications); start += notificationBatchSize {
+			end := min(start+notificationBatchSize, len(notifications))
+			chunk := notifications[start:end]
+
+			values := make([]string, 0, len(chunk))
+			args := make([]interface{}, 0, len(chunk)*4)
+			for i, notification := range chunk {
+				values = append(values, fmt.Sprintf("($%d, $%d, $%d, $%d)", i*4+1, i*4+2, i*4+3, i*4+4))
+				args = append(args, notification.UserID, notification.ActorID, notification.Type, notification.EntityID)
+			}
+
+			query := "INSERT INTO notifications (user_id, actor_id, type, entity_id) VALUES " + strings.Join(values, ", ")
+			if _, err := tx.Exec(ctx, query, args...); err != nil {
+				return fmt.Errorf("failed to create notifications batch: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// ListNotifications retrieves the notifications of a user, newest first.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the database operation.
+//   - userID (uuid.UUID): ID of the user.
+//   - pageNumber (int): Page number for pagination.
+//   - pageSize (int): Page size for pagination.
+//
+// Returns:
+//   - []*models.Notification: List of notifications with actor details.
+//   - error: An error if fetching notifications fails.
+func (ns *NotificationStore) ListNotifications(ctx context.Context, userID uuid.UUID, pageNumber int, pageSize int) ([]*models.Notification, error) {
+	offset := (pageNumber - 1) * pageSize
+	rows, err := ns.dbPool.Query(ctx, `
+		SELECT
+			n.id, n.user_id, n.actor_id, n.type, n.entity_id, n.created_at, n.read_at,
+			u.id, u.username, u.email, u.created_at, u.updated_at
+		FROM notifications n
+		LEFT JOIN users u ON n.actor_id = u.id
+		WHERE n.user_id = $1
+		ORDER BY n.created_at DESC
+		LIMIT $2 OFFSET $3
+	`, userID, pageSize, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notifications: %w", err)
+	}
+	defer rows.Close()
+
+	var notifications []*models.Notification
+	for rows.Next() {
+		notification := &models.Notification{}
+		var actor struct {
+			ID        *uuid.UUID
+			Username  *string
+			Email     *string
+			CreatedAt *time.Time
+			UpdatedAt *time.Time
+		}
+		err := rows.Scan(
+			&notification.ID, &notification.UserID, &notification.ActorID, &notification.Type, &notification.EntityID, &notification.CreatedAt, &notification.ReadAt,
+			&actor.ID, &actor.Username, &actor.Email, &actor.CreatedAt, &actor.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan notification row: %w", err)
+		}
+		if actor.ID != nil {
+			notification.Actor = &models.User{
+				ID:        *actor.ID,
+				Username:  *actor.Username,
+				Email:     *actor.Email,
+				CreatedAt: *actor.CreatedAt,
+				UpdatedAt: *actor.UpdatedAt,
+			}
+		}
+		notifications = append(notifications, notification)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during notifications rows iteration: %w", err)
+	}
+
+	return notifications, nil
+}
+
+// CountUnread counts the unread notifications of a user using the partial unread index.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the database operation.
+//   - userID (uuid.UUID): ID of the user.
+//
+// Returns:
+//   - int: Number of unread notifications.
+//   - error: An error if counting fails.
+func (ns *NotificationStore) CountUnread(ctx context.Context, userID uuid.UUID) (int, error) {
+	var count int
+	err := ns.dbPool.QueryRow(ctx, `
+		SELECT COUNT(*) FROM notifications WHERE user_id = $1 AND read_at IS NULL
+	`, userID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count unread notifications: %w", err)
+	}
+	return count, nil
+}
+
+// MarkReadByIDs marks the given unread notifications of a user as read.
+// IDs of notifications belonging to other users are ignored.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the database operation.
+//   - userID (uuid.UUID): ID of the user owning the notifications.
+//   - notificationIDs ([]uuid.UUID): IDs of the notifications to mark as read.
+//
+// Returns:
+//   - int: Number of unread notifications left after marking.
+//   - error: An error if the update fails.
+func (ns *NotificationStore) MarkReadByIDs(ctx context.Context, userID uuid.UUID, notificationIDs []uuid.UUID) (int, error) {
+	_, err := ns.dbPool.Exec(ctx, `
+		UPDATE notifications
+		SET read_at = NOW()
+		WHERE user_id = $1 AND id = ANY($2) AND read_at IS NULL
+	`, userID, notificationIDs)
+	if err != nil {
+		return 0, fmt.Errorf("failed to mark notifications as read: %w", err)
+	}
+
+	return ns.CountUnread(ctx, userID)
+}
@@ -0,0 +1,83 @@
+package stores
+
+import (
+	"context"
+	"testing"
+
+	"github.com/datarohit/gopher-social-backend/database/dbtest"
+	"github.com/datarohit/gopher-social-backend/models"
+)
+
+// BenchmarkNewPostFanOut compares notifying 10k followers of a new post one INSERT at a time with CreateBatch.
+func BenchmarkNewPostFanOut(b *testing.B) {
+	pool := dbtest.NewPool(b)
+	ctx := context.Background()
+
+	authorID := dbtest.CreateUser(b, pool, "author", 1)
+	postID := dbtest.CreatePost(b, pool, authorID)
+	followerIDs := dbtest.CreateUsers(b, pool, "follower", 10000)
+
+	notifications := make([]*models.Notification, 0, len(followerIDs))
+	for _, followerID := range followerIDs {
+		notifications = append(notifications, &models.Notification{UserID: followerID, ActorID: &authorID, Type: NotificationTypeNewPost, EntityID: &postID})
+	}
+	store := NewNotificationStore(pool)
+
+	benchmarks := []struct {
+		name   string
+		notify func() error
+	}{
+		{
+			name: "per row",
+			notify: func() error {
+				for _, followerID := range followerIDs {
+					if err := store.CreateNotification(ctx, followerID, authorID, NotificationTypeNewPost, &postID); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+		},
+		{
+			name: "batch",
+			notify: func() error {
+				return store.CreateBatch(ctx, notifications)
+			},
+		},
+	}
+
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if err := bm.notify(); err != nil {
+					b.Fatalf("fan-out error = %v", err)
+				}
+
+				b.StopTimer()
+				dbtest.Exec(b, pool, `DELETE FROM notifications`)
+				b.StartTimer()
+			}
+		})
+	}
+}
+
+// TestCreateBatchChunks checks that CreateBatch creates every notification when they span several statements.
+func TestCreateBatchChunks(t *testing.T) {
+	pool := dbtest.NewPool(t)
+
+	authorID := dbtest.CreateUser(t, pool, "author", 1)
+	followerIDs := dbtest.CreateUsers(t, pool, "follower", notificationBatchSize+1)
+
+	notifications := make([]*models.Notification, 0, len(followerIDs))
+	for _, followerID := range followerIDs {
+		notifications = append(notifications, &models.Notification{UserID: followerID, ActorID: &authorID, Type: NotificationTypeFollow})
+	}
+	if err := NewNotificationStore(pool).CreateBatch(context.Background(), notifications); err != nil {
+		t.Fatalf("CreateBatch() error = %v", err)
+	}
+
+	count := dbtest.Count(t, pool, `SELECT COUNT(*) FROM notifications WHERE actor_id = $1 AND user_id = ANY($2) AND read_at IS NULL`, authorID, followerIDs)
+	if count != len(followerIDs) {
+		t.Fatalf("created %d notifications, want %d", count, len(followerIDs))
+	}
+}
@@ -0,0 +1,25 @@
+package stores
+
+import "github.com/datarohit/gopher-social-backend/models"
+
+// newPagination builds the pagination metadata of a page from the total number of items in the list.
+//
+// Parameters:
+//   - pageNumber (int): Page number of the returned page.
+//   - pageSize (int): Page size of the returned page.
+//   - totalItems (int): Number of items in the whole list.
+//
+// Returns:
+//   - *models.Pagination: Pagination metadata, with the total pages rounded up.
+func newPagination(pageNumber int, pageSize int, totalItems int) *models.Pagination {
+	totalPages := 0
+	if pageSize > 0 {
+		totalPages = (totalItems + pageSize - 1) / pageSize
+	}
+	return &models.Pagination{
+		Page:       pageNumber,
+		PageSize:   pageSize,
+		TotalItems: totalItems,
+		TotalPages: totalPages,
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/datarohit/gopher-social-backend/models"
 	"github.com/google/uuid"
@@ -40,6 +41,43 @@ var ErrPostLikeNotFound = errors.New("post like not found")
 // ErrPostDislikeNotFound is returned when a post dislike is not found.
 var ErrPostDislikeNotFound = errors.New("post dislike not found")
 
+// ErrInvalidReactionTimelineBucket is returned when an unknown reaction timeline bucket is requested.
+var ErrInvalidReactionTimelineBucket = errors.New("invalid bucket value, must be one of hour, day")
+
+// ErrInvalidReactionTimelineRange is returned when the range of a reaction timeline is reversed or too long.
+var ErrInvalidReactionTimelineRange = errors.New("invalid range, since must not be after until and the range must be at most 168 hourly or 366 daily buckets")
+
+// Supported bucket sizes of post reaction timelines.
+const (
+	ReactionTimelineBucketHour = "hour"
+	ReactionTimelineBucketDay  = "day"
+)
+
+// ReactionTimelineBuckets lists the supported bucket sizes of post reaction timelines.
+var ReactionTimelineBuckets = []string{ReactionTimelineBucketHour, ReactionTimelineBucketDay}
+
+// reactionTimelineMaxRanges is the longest range a reaction timeline may cover for each bucket size.
+var reactionTimelineMaxRanges = map[string]time.Duration{
+	ReactionTimelineBucketHour: 168 * time.Hour,
+	ReactionTimelineBucketDay:  366 * 24 * time.Hour,
+}
+
+// ReactionTimelineMaxRange returns the longest range a reaction timeline may cover with a bucket size.
+//
+// Parameters:
+//   - bucket (string): Bucket size, hour or day.
+//
+// Returns:
+//   - time.Duration: Longest allowed range.
+//   - error: ErrInvalidReactionTimelineBucket if the bucket size is unknown.
+func ReactionTimelineMaxRange(bucket string) (time.Duration, error) {
+	maxRange, ok := reactionTimelineMaxRanges[bucket]
+	if !ok {
+		return 0, ErrInvalidReactionTimelineBucket
+	}
+	return maxRange, nil
+}
+
 // LikePost creates a new post like record in the database.
 // It records that a user has liked a specific post.
 //
@@ -232,18 +270,21 @@ func (pls *PostLikeStore) GetPostLikeByUserAndPost(ctx context.Context, userID u
 
 // ListLikedPostsByUserID retrieves all posts liked by a user from the database with pagination.
 // It returns a list of posts with like and dislike counts, and author information including follower and following counts.
+// If since or until is given, only posts liked within that range are returned, ordered by like time, newest first.
 //
 // Parameters:
 //   - ctx (context.Context): Context for the database operation.
 //   - userID (uuid.UUID): ID of the user.
+//   - since (*time.Time): Only posts liked at or after this time are returned, nil for no lower bound.
+//   - until (*time.Time): Only posts liked at or before this time are returned, nil for no upper bound.
 //   - pageNumber (int): Page number for pagination.
 //   - pageSize (int): Page size for pagination.
 //
 // Returns:
 //   - []*models.Post: A slice of Post pointers, or nil if no liked posts are found.
 //   - error: An error if the database query fails.
-func (pls *PostLikeStore) ListLikedPostsByUserID(ctx context.Context, userID uuid.UUID, pageNumber int, pageSize int) ([]*models.Post, error) {
-	return pls.listPostsByLikeStatus(ctx, userID, true, pageNumber, pageSize)
+func (pls *PostLikeStore) ListLikedPostsByUserID(ctx context.Context, userID uuid.UUID, since *time.Time, until *time.Time, pageNumber int, pageSize int) ([]*models.Post, error) {
+	return pls.listPostsByLikeStatus(ctx, userID, true, since, until, pageNumber, pageSize)
 }
 
 // ListDislikedPostsByUserID retrieves all posts disliked by a user from the database with pagination.
@@ -259,7 +300,79 @@ func (pls *PostLikeStore) ListLikedPostsByUserID(ctx context.Context, userID uui
 //   - []*models.Post: A slice of Post pointers, or nil if no disliked posts are found.
 //   - error: An error if the database query fails.
 func (pls *PostLikeStore) ListDislikedPostsByUserID(ctx context.Context, userID uuid.UUID, pageNumber int, pageSize int) ([]*models.Post, error) {
-	return pls.listPostsByLikeStatus(ctx, userID, false, pageNumber, pageSize)
+	return pls.listPostsByLikeStatus(ctx, userID, false, nil, nil, pageNumber, pageSize)
+}
+
+// ListReactionsByUserID retrieves the likes and dislikes of a user on posts with pagination, most recent reaction first.
+// Each reaction includes the post with like, dislike and comment counts, and author information including follower and following counts.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the database operation.
+//   - userID (uuid.UUID): ID of the user.
+//   - pageNumber (int): Page number for pagination.
+//   - pageSize (int): Page size for pagination.
+//
+// Returns:
+//   - []*models.PostReaction: A slice of PostReaction pointers, or nil if the user has no reactions.
+//   - error: An error if the database query fails.
+func (pls *PostLikeStore) ListReactionsByUserID(ctx context.Context, userID uuid.UUID, pageNumber int, pageSize int) ([]*models.PostReaction, error) {
+	offset := (pageNumber - 1) * pageSize
+	rows, err := tracedQuery(ctx, pls.dbPool, "PostLikeStore.ListReactionsByUserID", `
+		SELECT
+			CASE WHEN pl.liked THEN 'like' ELSE 'dislike' END as reaction, pl.created_at,
+			p.id, p.author_id, p.title, p.sub_title, p.description, p.content, p.created_at, p.updated_at,
+			u.id, u.username, u.email, u.banned, u.is_active, u.created_at, u.updated_at,
+			r.level, r.description,
+			(SELECT COUNT(*) FROM post_likes plc WHERE plc.post_id = p.id AND plc.liked = TRUE AND `+selfLikeCondition("plc.user_id", "p.author_id")+`) as likes_count,
+			(SELECT COUNT(*) FROM post_likes pd WHERE pd.post_id = p.id AND pd.liked = FALSE) as dislikes_count,
+			(SELECT COUNT(*) FROM comments cm WHERE cm.post_id = p.id) as comments_count,
+			u.followers_count,
+			u.following_count
+		FROM post_likes pl
+		INNER JOIN posts p ON pl.post_id = p.id
+		INNER JOIN users u ON p.author_id = u.id
+		INNER JOIN roles r ON u.role_id = r.id
+		WHERE pl.user_id = $1 AND `+bannedAuthorCondition(ctx, "p.author_id")+`
+		ORDER BY pl.created_at DESC
+		LIMIT $2 OFFSET $3
+	`, userID, pageSize, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list post reactions: %w", err)
+	}
+	defer rows.Close()
+
+	var reactions []*models.PostReaction
+	for rows.Next() {
+		post := &models.Post{Author: &models.User{Role: &models.Role{}}}
+		reaction := &models.PostReaction{Post: post}
+		err := rows.Scan(
+			&reaction.Reaction, &reaction.ReactedAt,
+			&post.ID, &post.AuthorID, &post.Title, &post.SubTitle, &post.Description, &post.Content, &post.CreatedAt, &post.UpdatedAt,
+			&post.Author.ID, &post.Author.Username, &post.Author.Email, &post.Author.Banned, &post.Author.IsActive, &post.Author.CreatedAt, &post.Author.UpdatedAt,
+			&post.Author.Role.Level, &post.Author.Role.Description,
+			&post.Likes, &post.Dislikes, &post.Comments,
+			&post.Author.Followers, &post.Author.Following,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan post reaction row: %w", err)
+		}
+		post.Author = tombstoneBannedAuthor(ctx, post.Author)
+		reactions = append(reactions, reaction)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during post reactions rows iteration: %w", err)
+	}
+
+	posts := make([]*models.Post, len(reactions))
+	for i, reaction := range reactions {
+		posts[i] = reaction.Post
+	}
+	if err := attachQuotedPosts(ctx, pls.dbPool, posts); err != nil {
+		return nil, err
+	}
+
+	return reactions, nil
 }
 
 // ListLikedPostsByUserIdentifier retrieves all liked posts of a user by user identifier (username, email, or user ID) with pagination.
@@ -317,41 +430,53 @@ func (pls *PostLikeStore) listPostsByLikeStatusByIdentifier(ctx context.Context,
 		return nil, fmt.Errorf("failed to get user by identifier: %w", err)
 	}
 
-	return pls.listPostsByLikeStatus(ctx, user.ID, liked, pageNumber, pageSize)
+	return pls.listPostsByLikeStatus(ctx, user.ID, liked, nil, nil, pageNumber, pageSize)
 }
 
 // listPostsByLikeStatus is a helper function to retrieve posts based on like status (liked or disliked) with pagination.
 // It is used by ListLikedPostsByUserID and ListDislikedPostsByUserID to avoid code duplication.
+// Posts are ordered by creation time, or by reaction time when the reaction time is filtered.
 //
 // Parameters:
 //   - ctx (context.Context): Context for the database operation.
 //   - userID (uuid.UUID): ID of the user.
 //   - liked (bool): True to retrieve liked posts, false for disliked posts.
+//   - since (*time.Time): Only posts reacted to at or after this time are returned, nil for no lower bound.
+//   - until (*time.Time): Only posts reacted to at or before this time are returned, nil for no upper bound.
 //   - pageNumber (int): Page number for pagination.
 //   - pageSize (int): Page size for pagination.
 //
 // Returns:
 //   - []*models.Post: A slice of Post pointers, or nil if no posts are found for the given like status.
 //   - error: An error if the database query fails.
-func (pls *PostLikeStore) listPostsByLikeStatus(ctx context.Context, userID uuid.UUID, liked bool, pageNumber int, pageSize int) ([]*models.Post, error) {
+func (pls *PostLikeStore) listPostsByLikeStatus(ctx context.Context, userID uuid.UUID, liked bool, since *time.Time, until *time.Time, pageNumber int, pageSize int) ([]*models.Post, error) {
+	orderBy := "p.created_at DESC"
+	if since != nil || until != nil {
+		orderBy = "pl.created_at DESC"
+	}
+
 	offset := (pageNumber - 1) * pageSize
-	rows, err := pls.dbPool.Query(ctx, `
+	rows, err := tracedQuery(ctx, pls.dbPool, "PostLikeStore.listPostsByLikeStatus", `
 		SELECT
 			p.id, p.author_id, p.title, p.sub_title, p.description, p.content, p.created_at, p.updated_at,
 			u.id, u.username, u.email, u.banned, u.is_active, u.created_at, u.updated_at,
 			r.level, r.description,
-			(SELECT COUNT(*) FROM post_likes pl WHERE pl.post_id = p.id AND pl.liked = TRUE) as likes_count,
+			(SELECT COUNT(*) FROM post_likes pl WHERE pl.post_id = p.id AND pl.liked = TRUE AND `+selfLikeCondition("pl.user_id", "p.author_id")+`) as likes_count,
 			(SELECT COUNT(*) FROM post_likes pd WHERE pd.post_id = p.id AND pd.liked = FALSE) as dislikes_count,
-			(SELECT COUNT(*) FROM follows WHERE followee_id = u.id) as followers_count,
-			(SELECT COUNT(*) FROM follows WHERE follower_id = u.id) as following_count
+			(SELECT COUNT(*) FROM comments cm WHERE cm.post_id = p.id) as comments_count,
+			u.followers_count,
+			u.following_count
 		FROM post_likes pl
 		INNER JOIN posts p ON pl.post_id = p.id
 		INNER JOIN users u ON p.author_id = u.id
 		INNER JOIN roles r ON u.role_id = r.id
 		WHERE pl.user_id = $1 AND pl.liked = $2
-		ORDER BY p.created_at DESC
-		LIMIT $3 OFFSET $4
-	`, userID, liked, pageSize, offset)
+			AND ($3::TIMESTAMPTZ IS NULL OR pl.created_at >= $3)
+			AND ($4::TIMESTAMPTZ IS NULL OR pl.created_at <= $4)
+			AND `+bannedAuthorCondition(ctx, "p.author_id")+`
+		ORDER BY `+orderBy+`
+		LIMIT $5 OFFSET $6
+	`, userID, liked, since, until, pageSize, offset)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list posts by like status: %w", err)
 	}
@@ -364,12 +489,13 @@ func (pls *PostLikeStore) listPostsByLikeStatus(ctx context.Context, userID uuid
 			&post.ID, &post.AuthorID, &post.Title, &post.SubTitle, &post.Description, &post.Content, &post.CreatedAt, &post.UpdatedAt,
 			&post.Author.ID, &post.Author.Username, &post.Author.Email, &post.Author.Banned, &post.Author.IsActive, &post.Author.CreatedAt, &post.Author.UpdatedAt,
 			&post.Author.Role.Level, &post.Author.Role.Description,
-			&post.Likes, &post.Dislikes,
+			&post.Likes, &post.Dislikes, &post.Comments,
 			&post.Author.Followers, &post.Author.Following,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan post row: %w", err)
 		}
+		post.Author = tombstoneBannedAuthor(ctx, post.Author)
 		posts = append(posts, post)
 	}
 
@@ -377,5 +503,98 @@ func (pls *PostLikeStore) listPostsByLikeStatus(ctx context.Context, userID uuid
 		return nil, fmt.Errorf("error during posts rows iteration: %w", err)
 	}
 
+	if err := attachQuotedPosts(ctx, pls.dbPool, posts); err != nil {
+		return nil, err
+	}
+
 	return posts, nil
 }
+
+// GetReactionTimeline counts the likes and dislikes of a post per hour or per day, in UTC, over a range of time.
+// Every bucket of the range is returned, including empty ones, with the number of reactions created in the bucket
+// and the running totals including reactions created before the range. since is rounded down to the start of its bucket.
+// A reaction switched between like and dislike counts as its current kind at the time it was first created.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the database operation.
+//   - postID (uuid.UUID): ID of the post.
+//   - bucket (string): Bucket size, hour or day.
+//   - since (time.Time): Start of the range.
+//   - until (time.Time): End of the range, included.
+//
+// Returns:
+//   - *models.ReactionTimeline: The like and dislike counts of each bucket, oldest first.
+//   - error: ErrInvalidReactionTimelineBucket or ErrInvalidReactionTimelineRange for invalid parameters,
+//     or other errors during database query.
+func (pls *PostLikeStore) GetReactionTimeline(ctx context.Context, postID uuid.UUID, bucket string, since time.Time, until time.Time) (*models.ReactionTimeline, error) {
+	maxRange, err := ReactionTimelineMaxRange(bucket)
+	if err != nil {
+		return nil, err
+	}
+
+	since = since.UTC().Truncate(time.Hour)
+	if bucket == ReactionTimelineBucketDay {
+		since = time.Date(since.Year(), since.Month(), since.Day(), 0, 0, 0, 0, time.UTC)
+	}
+	until = until.UTC()
+	if until.Before(since) || until.Sub(since) >= maxRange {
+		return nil, ErrInvalidReactionTimelineRange
+	}
+
+	rows, err := pls.dbPool.Query(ctx, `
+		WITH buckets AS (
+			SELECT generate_series($3::timestamptz AT TIME ZONE 'UTC', date_trunc($2::text, $4::timestamptz AT TIME ZONE 'UTC'), ('1 ' || $2::text)::interval) as bucket
+		),
+		reactions AS (
+			SELECT
+				date_trunc($2::text, pl.created_at AT TIME ZONE 'UTC') as bucket,
+				COUNT(*) FILTER (WHERE pl.liked AND `+selfLikeCondition("pl.user_id", "p.author_id")+`) as likes,
+				COUNT(*) FILTER (WHERE NOT pl.liked) as dislikes
+			FROM post_likes pl
+			INNER JOIN posts p ON p.id = pl.post_id
+			WHERE pl.post_id = $1 AND pl.created_at >= $3 AND pl.created_at <= $4
+			GROUP BY 1
+		),
+		earlier AS (
+			SELECT
+				COUNT(*) FILTER (WHERE pl.liked AND `+selfLikeCondition("pl.user_id", "p.author_id")+`) as likes,
+				COUNT(*) FILTER (WHERE NOT pl.liked) as dislikes
+			FROM post_likes pl
+			INNER JOIN posts p ON p.id = pl.post_id
+			WHERE pl.post_id = $1 AND pl.created_at < $3
+		)
+		SELECT
+			b.bucket,
+			COALESCE(r.likes, 0),
+			COALESCE(r.dislikes, 0),
+			(e.likes + SUM(COALESCE(r.likes, 0)) OVER (ORDER BY b.bucket))::BIGINT,
+			(e.dislikes + SUM(COALESCE(r.dislikes, 0)) OVER (ORDER BY b.bucket))::BIGINT
+		FROM buckets b
+		CROSS JOIN earlier e
+		LEFT JOIN reactions r ON r.bucket = b.bucket
+		ORDER BY b.bucket
+	`, postID, bucket, since, until)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get post reaction timeline: %w", err)
+	}
+	defer rows.Close()
+
+	timeline := &models.ReactionTimeline{PostID: postID, Bucket: bucket, Since: since, Until: until, Buckets: []*models.ReactionTimelineBucket{}}
+	for rows.Next() {
+		timelineBucket := &models.ReactionTimelineBucket{}
+		err := rows.Scan(
+			&timelineBucket.Start, &timelineBucket.Likes, &timelineBucket.Dislikes,
+			&timelineBucket.CumulativeLikes, &timelineBucket.CumulativeDislikes,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan reaction timeline row: %w", err)
+		}
+		timeline.Buckets = append(timeline.Buckets, timelineBucket)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during reaction timeline rows iteration: %w", err)
+	}
+
+	return timeline, nil
+}
@@ -2,8 +2,12 @@ package stores
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"strings"
+	"time"
 
 	"github.com/datarohit/gopher-social-backend/models"
 	"github.com/google/uuid"
@@ -31,19 +35,95 @@ func NewPostStore(dbPool *pgxpool.Pool) *PostStore {
 // ErrPostNotFound is returned when a post is not found.
 var ErrPostNotFound = errors.New("post not found")
 
+// ErrPostNotPinned is returned when unpinning a post that is not the pinned post of the user.
+var ErrPostNotPinned = errors.New("post is not pinned")
+
+// ErrQuotedPostNotFound is returned when creating a post that quotes a post which does not exist.
+var ErrQuotedPostNotFound = errors.New("quoted post not found")
+
+// ErrPostCannotBeQuoted is returned when quoting a post of a banned or private author, or of an author blocking or blocked by the user.
+var ErrPostCannotBeQuoted = errors.New("post cannot be quoted")
+
+// ErrInvalidPostSort is returned when an unknown post sort order is requested.
+var ErrInvalidPostSort = errors.New("invalid sort value, must be one of newest, oldest, most_liked, most_commented")
+
+// ErrInvalidPostExportFormat is returned when an unknown post export format is requested.
+var ErrInvalidPostExportFormat = errors.New("invalid format value, must be one of json, markdown")
+
+// Supported sort orders for post listings.
+const (
+	PostSortNewest        = "newest"
+	PostSortOldest        = "oldest"
+	PostSortMostLiked     = "most_liked"
+	PostSortMostCommented = "most_commented"
+)
+
+// PostSorts lists the supported post sort orders.
+var PostSorts = []string{PostSortNewest, PostSortOldest, PostSortMostLiked, PostSortMostCommented}
+
+// postSortOrders maps the supported sort orders to whitelisted ORDER BY clauses.
+var postSortOrders = map[string]string{
+	PostSortNewest:        "p.created_at DESC",
+	PostSortOldest:        "p.created_at ASC",
+	PostSortMostLiked:     "likes_count DESC, p.created_at DESC",
+	PostSortMostCommented: "(SELECT COUNT(*) FROM comments cm WHERE cm.post_id = p.id) DESC, p.created_at DESC",
+}
+
+// Supported formats for post exports.
+const (
+	PostExportFormatJSON     = "json"
+	PostExportFormatMarkdown = "markdown"
+)
+
+// PostExportFormats lists the supported post export formats.
+var PostExportFormats = []string{PostExportFormatJSON, PostExportFormatMarkdown}
+
+// postExportFormats maps the supported export formats to their content type and file extension.
+var postExportFormats = map[string]struct {
+	contentType string
+	extension   string
+}{
+	PostExportFormatJSON:     {"application/json; charset=utf-8", "json"},
+	PostExportFormatMarkdown: {"text/markdown; charset=utf-8", "md"},
+}
+
+// PostExportContentType returns the content type and file extension of a post export format.
+//
+// Parameters:
+//   - format (string): Export format, json or markdown.
+//
+// Returns:
+//   - string: Content type of the export.
+//   - string: File extension of the export, without the dot.
+//   - error: ErrInvalidPostExportFormat if the format is not supported.
+func PostExportContentType(format string) (string, string, error) {
+	exportFormat, ok := postExportFormats[format]
+	if !ok {
+		return "", "", ErrInvalidPostExportFormat
+	}
+	return exportFormat.contentType, exportFormat.extension, nil
+}
+
 // CreatePost creates a new post in the database.
+// If the post quotes another post, the quoted post is checked and embedded in the returned post.
 //
 // Parameters:
 //   - ctx (context.Context): Context for the database operation.
-//   - post (*models.Post): Post object to be created.
+//   - post (*models.Post): Post object to be created. QuotedPostID is optional.
 //
 // Returns:
 //   - *models.Post: The created post if successful.
-//   - error: An error if post creation fails.
+//   - error: ErrQuotedPostNotFound or ErrPostCannotBeQuoted if the quoted post cannot be quoted, or an error if post creation fails.
 func (ps *PostStore) CreatePost(ctx context.Context, post *models.Post) (*models.Post, error) {
 	var createdPost models.Post
 	post.ID = uuid.New()
 
+	if post.QuotedPostID != nil {
+		if err := ps.checkQuotablePost(ctx, *post.QuotedPostID, post.AuthorID); err != nil {
+			return nil, err
+		}
+	}
+
 	err := ps.dbPool.QueryRow(ctx, `
 		INSERT INTO posts (
 			id,
@@ -52,22 +132,126 @@ func (ps *PostStore) CreatePost(ctx context.Context, post *models.Post) (*models
 			sub_title,
 			description,
 			content,
+			quoted_post_id,
 			created_at,
 			updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, NOW(), NOW())
-		RETURNING id, author_id, title, sub_title, description, content, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, NOW(), NOW())
+		RETURNING id, author_id, title, sub_title, description, content, quoted_post_id, created_at, updated_at
 	`,
-		post.ID, post.AuthorID, post.Title, post.SubTitle, post.Description, post.Content,
+		post.ID, post.AuthorID, post.Title, post.SubTitle, post.Description, post.Content, post.QuotedPostID,
 	).Scan(
-		&createdPost.ID, &createdPost.AuthorID, &createdPost.Title, &createdPost.SubTitle, &createdPost.Description, &createdPost.Content, &createdPost.CreatedAt, &createdPost.UpdatedAt,
+		&createdPost.ID, &createdPost.AuthorID, &createdPost.Title, &createdPost.SubTitle, &createdPost.Description, &createdPost.Content, &createdPost.QuotedPostID, &createdPost.CreatedAt, &createdPost.UpdatedAt,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create post: %w", err)
 	}
 
+	if err := attachQuotedPosts(ctx, ps.dbPool, []*models.Post{&createdPost}); err != nil {
+		return nil, err
+	}
+
 	return &createdPost, nil
 }
 
+// checkQuotablePost checks that a post exists and may be quoted by a user.
+// Posts of banned authors, of private profiles, and of authors blocking or blocked by the user cannot be quoted.
+func (ps *PostStore) checkQuotablePost(ctx context.Context, quotedPostID uuid.UUID, userID uuid.UUID) error {
+	var authorBanned, authorPrivate, blocked bool
+	err := ps.dbPool.QueryRow(ctx, `
+		SELECT
+			u.banned,
+			EXISTS (SELECT 1 FROM profiles pr WHERE pr.user_id = u.id AND pr.is_private = TRUE),
+			EXISTS (SELECT 1 FROM blocks b WHERE (b.blocker_id = u.id AND b.blocked_id = $2) OR (b.blocker_id = $2 AND b.blocked_id = u.id))
+		FROM posts p
+		INNER JOIN users u ON p.author_id = u.id
+		WHERE p.id = $1
+	`, quotedPostID, userID).Scan(&authorBanned, &authorPrivate, &blocked)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrQuotedPostNotFound
+		}
+		return fmt.Errorf("failed to check quoted post: %w", err)
+	}
+
+	if authorBanned || authorPrivate || blocked {
+		return ErrPostCannotBeQuoted
+	}
+
+	return nil
+}
+
+// attachQuotedPosts embeds the posts quoted by the given posts, with author details and like and comment counts, in one query.
+// Only one level is embedded, so quotes of quotes cannot form cycles. Quoted posts of banned authors or private profiles are left out.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the database operation.
+//   - dbPool (*pgxpool.Pool): Pgx connection pool.
+//   - posts ([]*models.Post): Posts to embed the quoted posts in, their IDs must be populated.
+//
+// Returns:
+//   - error: An error if the database query fails.
+func attachQuotedPosts(ctx context.Context, dbPool *pgxpool.Pool, posts []*models.Post) error {
+	if len(posts) == 0 {
+		return nil
+	}
+
+	postsByID := make(map[uuid.UUID]*models.Post, len(posts))
+	postIDs := make([]uuid.UUID, 0, len(posts))
+	for _, post := range posts {
+		postsByID[post.ID] = post
+		postIDs = append(postIDs, post.ID)
+	}
+
+	rows, err := dbPool.Query(ctx, `
+		SELECT
+			src.id,
+			p.id, p.author_id, p.title, p.sub_title, p.description, p.content, p.created_at, p.updated_at,
+			u.id, u.username, u.email, u.banned, u.is_active, u.created_at, u.updated_at,
+			r.level, r.description,
+			(SELECT COUNT(*) FROM post_likes pl WHERE pl.post_id = p.id AND pl.liked = TRUE AND `+selfLikeCondition("pl.user_id", "p.author_id")+`) as likes_count,
+			(SELECT COUNT(*) FROM post_likes pd WHERE pd.post_id = p.id AND pd.liked = FALSE) as dislikes_count,
+			(SELECT COUNT(*) FROM comments cm WHERE cm.post_id = p.id) as comments_count,
+			u.followers_count,
+			u.following_count
+		FROM posts src
+		INNER JOIN posts p ON p.id = src.quoted_post_id
+		INNER JOIN users u ON p.author_id = u.id
+		INNER JOIN roles r ON u.role_id = r.id
+		WHERE src.id = ANY($1) AND u.banned = FALSE
+		AND NOT EXISTS (SELECT 1 FROM profiles pr WHERE pr.user_id = p.author_id AND pr.is_private = TRUE)
+	`, postIDs)
+	if err != nil {
+		return fmt.Errorf("failed to get quoted posts: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var sourceID uuid.UUID
+		quoted := &models.Post{Author: &models.User{Role: &models.Role{}}}
+		err := rows.Scan(
+			&sourceID,
+			&quoted.ID, &quoted.AuthorID, &quoted.Title, &quoted.SubTitle, &quoted.Description, &quoted.Content, &quoted.CreatedAt, &quoted.UpdatedAt,
+			&quoted.Author.ID, &quoted.Author.Username, &quoted.Author.Email, &quoted.Author.Banned, &quoted.Author.IsActive, &quoted.Author.CreatedAt, &quoted.Author.UpdatedAt,
+			&quoted.Author.Role.Level, &quoted.Author.Role.Description,
+			&quoted.Likes, &quoted.Dislikes, &quoted.Comments,
+			&quoted.Author.Followers, &quoted.Author.Following,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to scan quoted post row: %w", err)
+		}
+		if post, ok := postsByID[sourceID]; ok {
+			post.QuotedPostID = &quoted.ID
+			post.QuotedPost = quoted
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error during quoted posts rows iteration: %w", err)
+	}
+
+	return nil
+}
+
 // GetPostByID retrieves a post from the database by its ID.
 //
 // Parameters:
@@ -82,13 +266,14 @@ func (ps *PostStore) GetPostByID(ctx context.Context, postID uuid.UUID) (*models
 	err := ps.dbPool.QueryRow(ctx, `
 		SELECT
 			p.id, p.author_id, p.title, p.sub_title, p.description, p.content, p.created_at, p.updated_at,
-			(SELECT COUNT(*) FROM post_likes pl WHERE pl.post_id = p.id AND pl.liked = TRUE) as likes_count,
-			(SELECT COUNT(*) FROM post_likes pd WHERE pd.post_id = p.id AND pd.liked = FALSE) as dislikes_count
+			(SELECT COUNT(*) FROM post_likes pl WHERE pl.post_id = p.id AND pl.liked = TRUE AND `+selfLikeCondition("pl.user_id", "p.author_id")+`) as likes_count,
+			(SELECT COUNT(*) FROM post_likes pd WHERE pd.post_id = p.id AND pd.liked = FALSE) as dislikes_count,
+			(SELECT COUNT(*) FROM comments cm WHERE cm.post_id = p.id) as comments_count
 		FROM posts p
 		WHERE id = $1
 	`, postID).Scan(
 		&post.ID, &post.AuthorID, &post.Title, &post.SubTitle, &post.Description, &post.Content, &post.CreatedAt, &post.UpdatedAt,
-		&post.Likes, &post.Dislikes,
+		&post.Likes, &post.Dislikes, &post.Comments,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -97,9 +282,231 @@ func (ps *PostStore) GetPostByID(ctx context.Context, postID uuid.UUID) (*models
 		return nil, fmt.Errorf("failed to get post by id: %w", err)
 	}
 
+	if err := attachQuotedPosts(ctx, ps.dbPool, []*models.Post{&post}); err != nil {
+		return nil, err
+	}
+
 	return &post, nil
 }
 
+// GetPostWithComments retrieves a post together with a page of its comments, oldest first, in one call.
+// The post is looked up and its visibility checked before any comment is read, so a missing post, or a post of a
+// private profile the viewer does not follow, returns ErrPostNotFound without fetching comments.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the database operation.
+//   - postID (uuid.UUID): ID of the post to retrieve.
+//   - viewerID (uuid.UUID): ID of the user viewing the post, used for visibility and comment viewer reactions.
+//   - commentsPage (int): Page number of the comments.
+//   - commentsPageSize (int): Number of comments per page.
+//
+// Returns:
+//   - *models.FeedPost: The post, a page of its comments and the comments pagination.
+//   - error: ErrPostNotFound if the post does not exist or is not visible, or other errors during database query.
+func (ps *PostStore) GetPostWithComments(ctx context.Context, postID uuid.UUID, viewerID uuid.UUID, commentsPage int, commentsPageSize int) (*models.FeedPost, error) {
+	post, err := ps.GetPostByID(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+
+	canView, err := NewFollowStore(ps.dbPool).CanViewPosts(ctx, viewerID, post.AuthorID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check post visibility: %w", err)
+	}
+	if !canView {
+		return nil, ErrPostNotFound
+	}
+
+	comments, pagination, err := NewCommentStore(ps.dbPool).ListCommentsByPostID(ctx, postID, viewerID, commentsPage, commentsPageSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list comments for post: %w", err)
+	}
+
+	return &models.FeedPost{
+		Post:       post,
+		Comments:   comments,
+		Pagination: pagination,
+	}, nil
+}
+
+// ExportPost writes a post and all its comments, oldest first, as JSON shaped like models.PostExport or as Markdown
+// with the post under headers followed by a list of its comments. Comments are written one at a time as they
+// are read from the database, so the export is never held in memory as a whole.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the database operation.
+//   - post (*models.Post): The post to export, as returned by GetPostByID.
+//   - format (string): Export format, json or markdown.
+//   - w (io.Writer): Writer the export is written to.
+//
+// Returns:
+//   - error: ErrInvalidPostExportFormat if the format is not supported, or an error if a query or write fails.
+func (ps *PostStore) ExportPost(ctx context.Context, post *models.Post, format string, w io.Writer) error {
+	if _, ok := postExportFormats[format]; !ok {
+		return ErrInvalidPostExportFormat
+	}
+
+	author := &models.PostExportAuthor{ID: post.AuthorID}
+	err := ps.dbPool.QueryRow(ctx, `SELECT username FROM users WHERE id = $1`, post.AuthorID).Scan(&author.Username)
+	if err != nil {
+		return fmt.Errorf("failed to get post author for export: %w", err)
+	}
+
+	rows, err := ps.dbPool.Query(ctx, `
+		WITH reactions AS (
+			SELECT cl.comment_id,
+				COUNT(*) FILTER (WHERE cl.liked) as likes,
+				COUNT(*) FILTER (WHERE NOT cl.liked) as dislikes
+			FROM comment_likes cl
+			INNER JOIN comments cm ON cm.id = cl.comment_id
+			WHERE cm.post_id = $1
+			GROUP BY cl.comment_id
+		)
+		SELECT
+			c.id, c.content, c.created_at, c.updated_at,
+			u.id, u.username,
+			COALESCE(cr.likes, 0), COALESCE(cr.dislikes, 0)
+		FROM comments c
+		INNER JOIN users u ON c.author_id = u.id
+		LEFT JOIN reactions cr ON cr.comment_id = c.id
+		WHERE c.post_id = $1
+		ORDER BY c.created_at ASC, c.id ASC
+	`, post.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list comments for post export: %w", err)
+	}
+	defer rows.Close()
+
+	var writeComment func(comment *models.PostExportComment, first bool) error
+	var end string
+	if format == PostExportFormatJSON {
+		writeComment, end, err = writePostExportJSONHeader(w, post, author)
+	} else {
+		writeComment, end, err = writePostExportMarkdownHeader(w, post, author)
+	}
+	if err != nil {
+		return err
+	}
+
+	first := true
+	for rows.Next() {
+		comment := &models.PostExportComment{Author: &models.PostExportAuthor{}}
+		if err := rows.Scan(
+			&comment.ID, &comment.Content, &comment.CreatedAt, &comment.UpdatedAt,
+			&comment.Author.ID, &comment.Author.Username,
+			&comment.Likes, &comment.Dislikes,
+		); err != nil {
+			return fmt.Errorf("failed to scan exported comment row: %w", err)
+		}
+		if err := writeComment(comment, first); err != nil {
+			return err
+		}
+		first = false
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error during exported comments rows iteration: %w", err)
+	}
+
+	if first && format == PostExportFormatMarkdown {
+		end = "_No comments yet._\n"
+	}
+	if _, err := io.WriteString(w, end); err != nil {
+		return fmt.Errorf("failed to write post export: %w", err)
+	}
+
+	return nil
+}
+
+// writePostExportJSONHeader writes the post of a JSON post export and opens its comments array.
+//
+// Parameters:
+//   - w (io.Writer): Writer the export is written to.
+//   - post (*models.Post): The exported post.
+//   - author (*models.PostExportAuthor): Author of the exported post.
+//
+// Returns:
+//   - func(*models.PostExportComment, bool) error: Function writing a comment, told whether it is the first one.
+//   - string: Text closing the export after the last comment.
+//   - error: An error if encoding or writing fails.
+func writePostExportJSONHeader(w io.Writer, post *models.Post, author *models.PostExportAuthor) (func(*models.PostExportComment, bool) error, string, error) {
+	exportedAt, err := json.Marshal(time.Now().UTC())
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to encode export time: %w", err)
+	}
+	authorJSON, err := json.Marshal(author)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to encode post author: %w", err)
+	}
+	postJSON, err := json.Marshal(post)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to encode post: %w", err)
+	}
+
+	if _, err := fmt.Fprintf(w, `{"exported_at":%s,"author":%s,"post":%s,"comments":[`, exportedAt, authorJSON, postJSON); err != nil {
+		return nil, "", fmt.Errorf("failed to write post export: %w", err)
+	}
+
+	writeComment := func(comment *models.PostExportComment, first bool) error {
+		encoded, err := json.Marshal(comment)
+		if err != nil {
+			return fmt.Errorf("failed to encode exported comment: %w", err)
+		}
+		if !first {
+			encoded = append([]byte(","), encoded...)
+		}
+		if _, err := w.Write(encoded); err != nil {
+			return fmt.Errorf("failed to write post export: %w", err)
+		}
+		return nil
+	}
+	return writeComment, "]}", nil
+}
+
+// writePostExportMarkdownHeader writes the post of a Markdown post export under headers and opens its comments section.
+//
+// Parameters:
+//   - w (io.Writer): Writer the export is written to.
+//   - post (*models.Post): The exported post.
+//   - author (*models.PostExportAuthor): Author of the exported post.
+//
+// Returns:
+//   - func(*models.PostExportComment, bool) error: Function writing a comment as a list item.
+//   - string: Text closing the export after the last comment.
+//   - error: An error if writing fails.
+func writePostExportMarkdownHeader(w io.Writer, post *models.Post, author *models.PostExportAuthor) (func(*models.PostExportComment, bool) error, string, error) {
+	var header strings.Builder
+	fmt.Fprintf(&header, "# %s\n\n", markdownLine(post.Title))
+	if post.SubTitle != "" {
+		fmt.Fprintf(&header, "## %s\n\n", markdownLine(post.SubTitle))
+	}
+	fmt.Fprintf(&header, "_By @%s on %s, %d likes, %d dislikes, %d comments_\n\n", author.Username, post.CreatedAt.UTC().Format(time.RFC1123), post.Likes, post.Dislikes, post.Comments)
+	if post.Description != "" {
+		fmt.Fprintf(&header, "> %s\n\n", strings.ReplaceAll(strings.TrimSpace(post.Description), "\n", "\n> "))
+	}
+	fmt.Fprintf(&header, "%s\n\n## Comments\n\n", strings.TrimSpace(post.Content))
+
+	if _, err := io.WriteString(w, header.String()); err != nil {
+		return nil, "", fmt.Errorf("failed to write post export: %w", err)
+	}
+
+	writeComment := func(comment *models.PostExportComment, first bool) error {
+		_, err := fmt.Fprintf(w, "- **@%s** on %s (%d likes, %d dislikes)\n\n  %s\n\n",
+			comment.Author.Username, comment.CreatedAt.UTC().Format(time.RFC1123), comment.Likes, comment.Dislikes,
+			strings.ReplaceAll(strings.TrimSpace(comment.Content), "\n", "\n  "))
+		if err != nil {
+			return fmt.Errorf("failed to write post export: %w", err)
+		}
+		return nil
+	}
+	return writeComment, "", nil
+}
+
+// markdownLine joins the lines of a text, so it can be written as a Markdown header.
+func markdownLine(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
+
 // UpdatePost updates an existing post in the database.
 //
 // Parameters:
@@ -160,7 +567,7 @@ func (ps *PostStore) DeletePost(ctx context.Context, postID uuid.UUID) error {
 	return nil
 }
 
-// ListPostsByAuthorID retrieves all posts from the database for a given author ID with pagination.
+// ListPostsByAuthorID retrieves all posts from the database for a given author ID with pagination, newest first.
 //
 // Parameters:
 //   - ctx (context.Context): Context for the database operation.
@@ -172,17 +579,43 @@ func (ps *PostStore) DeletePost(ctx context.Context, postID uuid.UUID) error {
 //   - []*models.Post: A slice of Post pointers, or nil if no posts are found.
 //   - error: An error if the database query fails.
 func (ps *PostStore) ListPostsByAuthorID(ctx context.Context, authorID uuid.UUID, pageNumber int, pageSize int) ([]*models.Post, error) {
+	return ps.ListPostsByAuthorIDSorted(ctx, authorID, PostSortNewest, nil, pageNumber, pageSize)
+}
+
+// ListPostsByAuthorIDSorted retrieves posts from the database for a given author ID with pagination, custom ordering and an optional creation time filter.
+// The author's pinned post, if any, comes first regardless of the ordering.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the database operation.
+//   - authorID (uuid.UUID): ID of the author whose posts are to be retrieved.
+//   - sort (string): One of PostSortNewest, PostSortOldest, PostSortMostLiked or PostSortMostCommented.
+//   - since (*time.Time): Only posts created at or after this time are returned, nil for no filter.
+//   - pageNumber (int): Page number for pagination.
+//   - pageSize (int): Page size for pagination.
+//
+// Returns:
+//   - []*models.Post: A slice of Post pointers, or nil if no posts are found.
+//   - error: ErrInvalidPostSort if the sort value is unknown, or an error if the database query fails.
+func (ps *PostStore) ListPostsByAuthorIDSorted(ctx context.Context, authorID uuid.UUID, sort string, since *time.Time, pageNumber int, pageSize int) ([]*models.Post, error) {
+	orderBy, ok := postSortOrders[sort]
+	if !ok {
+		return nil, ErrInvalidPostSort
+	}
+
 	offset := (pageNumber - 1) * pageSize
-	rows, err := ps.dbPool.Query(ctx, `
+	rows, err := tracedQuery(ctx, ps.dbPool, "PostStore.ListPostsByAuthorIDSorted", `
 		SELECT
 			p.id, p.author_id, p.title, p.sub_title, p.description, p.content, p.created_at, p.updated_at,
-			(SELECT COUNT(*) FROM post_likes pl WHERE pl.post_id = p.id AND pl.liked = TRUE) as likes_count,
-			(SELECT COUNT(*) FROM post_likes pd WHERE pd.post_id = p.id AND pd.liked = FALSE) as dislikes_count
+			(SELECT COUNT(*) FROM post_likes pl WHERE pl.post_id = p.id AND pl.liked = TRUE AND `+selfLikeCondition("pl.user_id", "p.author_id")+`) as likes_count,
+			(SELECT COUNT(*) FROM post_likes pd WHERE pd.post_id = p.id AND pd.liked = FALSE) as dislikes_count,
+			(SELECT COUNT(*) FROM comments cm WHERE cm.post_id = p.id) as comments_count,
+			COALESCE(pr.pinned_post_id = p.id, FALSE) as pinned
 		FROM posts p
-		WHERE author_id = $1
-		ORDER BY created_at DESC
-		LIMIT $2 OFFSET $3
-	`, authorID, pageSize, offset)
+		LEFT JOIN profiles pr ON pr.user_id = p.author_id
+		WHERE p.author_id = $1 AND ($2::TIMESTAMPTZ IS NULL OR p.created_at >= $2)
+		ORDER BY pinned DESC, `+orderBy+`
+		LIMIT $3 OFFSET $4
+	`, authorID, since, pageSize, offset)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list posts by author id: %w", err)
 	}
@@ -193,7 +626,7 @@ func (ps *PostStore) ListPostsByAuthorID(ctx context.Context, authorID uuid.UUID
 		post := &models.Post{}
 		err := rows.Scan(
 			&post.ID, &post.AuthorID, &post.Title, &post.SubTitle, &post.Description, &post.Content, &post.CreatedAt, &post.UpdatedAt,
-			&post.Likes, &post.Dislikes,
+			&post.Likes, &post.Dislikes, &post.Comments, &post.Pinned,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan post row: %w", err)
@@ -205,5 +638,128 @@ func (ps *PostStore) ListPostsByAuthorID(ctx context.Context, authorID uuid.UUID
 		return nil, fmt.Errorf("error during posts rows iteration: %w", err)
 	}
 
+	if err := attachQuotedPosts(ctx, ps.dbPool, posts); err != nil {
+		return nil, err
+	}
+
+	return posts, nil
+}
+
+// PinPost pins a post to the top of its author's profile, replacing a previously pinned post.
+// The profile is created if the author does not have one yet.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the database operation.
+//   - authorID (uuid.UUID): ID of the author of the post.
+//   - postID (uuid.UUID): ID of the post to pin.
+//
+// Returns:
+//   - error: ErrPostNotFound if the post does not exist or is not by the author, or other errors during database query.
+func (ps *PostStore) PinPost(ctx context.Context, authorID uuid.UUID, postID uuid.UUID) error {
+	commandTag, err := ps.dbPool.Exec(ctx, `
+		INSERT INTO profiles (user_id, pinned_post_id)
+		SELECT p.author_id, p.id
+		FROM posts p
+		WHERE p.id = $2 AND p.author_id = $1
+		ON CONFLICT (user_id) DO UPDATE SET pinned_post_id = EXCLUDED.pinned_post_id
+	`, authorID, postID)
+	if err != nil {
+		return fmt.Errorf("failed to pin post: %w", err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return ErrPostNotFound
+	}
+
+	return nil
+}
+
+// UnpinPost removes a post from the top of its author's profile.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the database operation.
+//   - authorID (uuid.UUID): ID of the author of the post.
+//   - postID (uuid.UUID): ID of the pinned post.
+//
+// Returns:
+//   - error: ErrPostNotPinned if the post is not the author's pinned post, or other errors during database query.
+func (ps *PostStore) UnpinPost(ctx context.Context, authorID uuid.UUID, postID uuid.UUID) error {
+	commandTag, err := ps.dbPool.Exec(ctx, `
+		UPDATE profiles
+		SET pinned_post_id = NULL
+		WHERE user_id = $1 AND pinned_post_id = $2
+	`, authorID, postID)
+	if err != nil {
+		return fmt.Errorf("failed to unpin post: %w", err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return ErrPostNotPinned
+	}
+
+	return nil
+}
+
+// ListAllPosts retrieves posts of all users from the database with pagination, newest first.
+// It is meant for moderation and includes author details and like counts.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the database operation.
+//   - authorID (*uuid.UUID): Only posts of this author are returned, nil for all authors.
+//   - from (*time.Time): Only posts created at or after this time are returned, nil for no lower bound.
+//   - to (*time.Time): Only posts created at or before this time are returned, nil for no upper bound.
+//   - pageNumber (int): Page number for pagination.
+//   - pageSize (int): Page size for pagination.
+//
+// Returns:
+//   - []*models.Post: A slice of Post pointers, or nil if no posts are found.
+//   - error: An error if the database query fails.
+func (ps *PostStore) ListAllPosts(ctx context.Context, authorID *uuid.UUID, from *time.Time, to *time.Time, pageNumber int, pageSize int) ([]*models.Post, error) {
+	offset := (pageNumber - 1) * pageSize
+	rows, err := tracedQuery(ctx, ps.dbPool, "PostStore.ListAllPosts", `
+		SELECT
+			p.id, p.author_id, p.title, p.sub_title, p.description, p.content, p.created_at, p.updated_at,
+			(SELECT COUNT(*) FROM post_likes pl WHERE pl.post_id = p.id AND pl.liked = TRUE AND `+selfLikeCondition("pl.user_id", "p.author_id")+`) as likes_count,
+			(SELECT COUNT(*) FROM post_likes pd WHERE pd.post_id = p.id AND pd.liked = FALSE) as dislikes_count,
+			(SELECT COUNT(*) FROM comments cm WHERE cm.post_id = p.id) as comments_count,
+			u.id, u.username, u.email, u.timeout_until, u.banned, u.is_active, u.created_at, u.updated_at,
+			r.level, r.description
+		FROM posts p
+		INNER JOIN users u ON p.author_id = u.id
+		INNER JOIN roles r ON u.role_id = r.id
+		WHERE ($1::UUID IS NULL OR p.author_id = $1)
+		AND ($2::TIMESTAMPTZ IS NULL OR p.created_at >= $2)
+		AND ($3::TIMESTAMPTZ IS NULL OR p.created_at <= $3)
+		ORDER BY p.created_at DESC
+		LIMIT $4 OFFSET $5
+	`, authorID, from, to, pageSize, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list all posts: %w", err)
+	}
+	defer rows.Close()
+
+	var posts []*models.Post
+	for rows.Next() {
+		post := &models.Post{}
+		post.Author = &models.User{}
+		post.Author.Role = &models.Role{}
+		err := rows.Scan(
+			&post.ID, &post.AuthorID, &post.Title, &post.SubTitle, &post.Description, &post.Content, &post.CreatedAt, &post.UpdatedAt,
+			&post.Likes, &post.Dislikes, &post.Comments,
+			&post.Author.ID, &post.Author.Username, &post.Author.Email, &post.Author.TimeoutUntil, &post.Author.Banned, &post.Author.IsActive, &post.Author.CreatedAt, &post.Author.UpdatedAt,
+			&post.Author.Role.Level, &post.Author.Role.Description,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan post row: %w", err)
+		}
+		posts = append(posts, post)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during posts rows iteration: %w", err)
+	}
+
+	if err := attachQuotedPosts(ctx, ps.dbPool, posts); err != nil {
+		return nil, err
+	}
+
 	return posts, nil
 }
@@ -0,0 +1,87 @@
+package stores
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/datarohit/gopher-social-backend/database/dbtest"
+	"github.com/datarohit/gopher-social-backend/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// postCommentCounts returns the comments count of a post as read through each path returning posts.
+// The reader must have liked the post so that it is in their liked posts.
+func postCommentCounts(t *testing.T, pool *pgxpool.Pool, authorID uuid.UUID, readerID uuid.UUID, postID uuid.UUID) map[string]uint {
+	t.Helper()
+
+	ctx := context.Background()
+	counts := map[string]uint{}
+	find := func(source string, posts []*models.Post, err error) {
+		if err != nil {
+			t.Fatalf("%s error = %v", source, err)
+		}
+		for _, post := range posts {
+			if post.ID == postID {
+				counts[source] = post.Comments
+				return
+			}
+		}
+		t.Fatalf("%s did not return post %s", source, postID)
+	}
+
+	post, err := NewPostStore(pool).GetPostByID(ctx, postID)
+	find("GetPostByID", []*models.Post{post}, err)
+	posts, err := NewPostStore(pool).ListPostsByAuthorID(ctx, authorID, 1, 10)
+	find("ListPostsByAuthorID", posts, err)
+	posts, err = NewFeedStore(pool, nil).ListLatestPosts(ctx, 1, 10)
+	find("ListLatestPosts", posts, err)
+	posts, err = NewPostLikeStore(pool).ListLikedPostsByUserID(ctx, readerID, nil, nil, 1, 10)
+	find("ListLikedPostsByUserID", posts, err)
+	return counts
+}
+
+// TestPostCommentsCount checks that every path returning a post reports its comments, including none,
+// and follows comments being added and deleted.
+func TestPostCommentsCount(t *testing.T) {
+	pool := dbtest.NewPool(t)
+	ctx := context.Background()
+
+	authorID := dbtest.CreateUser(t, pool, "author", 1)
+	readerID := dbtest.CreateUser(t, pool, "reader", 1)
+	postID := dbtest.CreatePost(t, pool, authorID)
+	dbtest.Exec(t, pool, `INSERT INTO post_likes (user_id, post_id, liked) VALUES ($1, $2, TRUE)`, readerID, postID)
+
+	assertCounts := func(want uint) {
+		t.Helper()
+		for source, got := range postCommentCounts(t, pool, authorID, readerID, postID) {
+			if got != want {
+				t.Fatalf("%s comments count = %d, want %d", source, got, want)
+			}
+		}
+	}
+
+	assertCounts(0)
+	post, err := NewPostStore(pool).GetPostByID(ctx, postID)
+	if err != nil {
+		t.Fatalf("GetPostByID() error = %v", err)
+	}
+	body, err := json.Marshal(post)
+	if err != nil {
+		t.Fatalf("failed to encode post: %v", err)
+	}
+	if !strings.Contains(string(body), `"comments_count":0`) {
+		t.Fatalf("post JSON = %s, want comments_count 0", body)
+	}
+
+	commentID := dbtest.CreateComment(t, pool, readerID, postID)
+	dbtest.CreateComment(t, pool, authorID, postID)
+	assertCounts(2)
+
+	if err := NewCommentStore(pool).DeleteComment(ctx, commentID, postID); err != nil {
+		t.Fatalf("DeleteComment() error = %v", err)
+	}
+	assertCounts(1)
+}
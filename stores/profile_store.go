@@ -4,7 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/datarohit/gopher-social-backend/models"
 	"github.com/google/uuid"
@@ -12,6 +15,23 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// onlineThreshold is how recently a user must have been seen to be shown as online.
+var onlineThreshold = onlineThresholdFromEnv()
+
+// onlineThresholdFromEnv reads ONLINE_THRESHOLD_MINUTES, defaulting to 5 minutes.
+func onlineThresholdFromEnv() time.Duration {
+	minutes, err := strconv.Atoi(strings.TrimSpace(os.Getenv("ONLINE_THRESHOLD_MINUTES")))
+	if err != nil || minutes <= 0 {
+		return 5 * time.Minute
+	}
+	return time.Duration(minutes) * time.Minute
+}
+
+// isOnline reports whether a user last seen at lastSeenAt is within the online threshold.
+func isOnline(lastSeenAt *time.Time) bool {
+	return lastSeenAt != nil && time.Since(*lastSeenAt) <= onlineThreshold
+}
+
 type ProfileStore struct {
 	dbPool *pgxpool.Pool
 }
@@ -53,10 +73,11 @@ func (ps *ProfileStore) UpdateProfile(ctx context.Context, profile *models.Profi
 			linkedin,
 			twitter,
 			google_scholar,
+			is_private,
 			created_at,
 			updated_at
 		) VALUES (
-			$1, $2, $3, $4, $5, $6, $7, $8, NOW(), NOW()
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, NOW(), NOW()
 		) ON CONFLICT (user_id) DO UPDATE SET
 			first_name = EXCLUDED.first_name,
 			last_name = EXCLUDED.last_name,
@@ -65,31 +86,53 @@ func (ps *ProfileStore) UpdateProfile(ctx context.Context, profile *models.Profi
 			linkedin = EXCLUDED.linkedin,
 			twitter = EXCLUDED.twitter,
 			google_scholar = EXCLUDED.google_scholar,
+			is_private = EXCLUDED.is_private,
 			updated_at = NOW()
-		RETURNING id, user_id, first_name, last_name, website, github, linkedin, twitter, google_scholar, created_at, updated_at
-	`, profile.UserID, profile.FirstName, profile.LastName, profile.Website, profile.Github, profile.LinkedIn, profile.Twitter, profile.GoogleScholar).Scan(
-		&updatedProfile.ID, &updatedProfile.UserID, &updatedProfile.FirstName, &updatedProfile.LastName, &updatedProfile.Website, &updatedProfile.Github, &updatedProfile.LinkedIn, &updatedProfile.Twitter, &updatedProfile.GoogleScholar, &updatedProfile.CreatedAt, &updatedProfile.UpdatedAt,
+		RETURNING id, user_id, first_name, last_name, website, github, linkedin, twitter, google_scholar, is_private, created_at, updated_at
+	`, profile.UserID, profile.FirstName, profile.LastName, profile.Website, profile.Github, profile.LinkedIn, profile.Twitter, profile.GoogleScholar, profile.IsPrivate).Scan(
+		&updatedProfile.ID, &updatedProfile.UserID, &updatedProfile.FirstName, &updatedProfile.LastName, &updatedProfile.Website, &updatedProfile.Github, &updatedProfile.LinkedIn, &updatedProfile.Twitter, &updatedProfile.GoogleScholar, &updatedProfile.IsPrivate, &updatedProfile.CreatedAt, &updatedProfile.UpdatedAt,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update or create profile: %w", err)
 	}
+	invalidateCachedUser(ctx, profile.UserID)
 
 	return &updatedProfile, nil
 }
 
 // CreateProfile creates a new user profile in the database.
+// If the user already has a profile, it is left unchanged and returned instead.
 //
 // Parameters:
 //   - ctx (context.Context): Context for the database operation.
 //   - profile (*models.Profile): Profile object containing profile information.
 //
 // Returns:
-//   - *models.Profile: The created profile if successful.
+//   - *models.Profile: The created or already existing profile if successful.
 //   - error: ErrProfileNotFound if profile not found or other errors during database query.
 func (ps *ProfileStore) CreateProfile(ctx context.Context, profile *models.Profile) (*models.Profile, error) {
+	return createProfile(ctx, ps.dbPool, profile)
+}
+
+// CreateProfileTx is CreateProfile run as one step of the transaction tx, see WithTx.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the database operation.
+//   - tx (pgx.Tx): Transaction to run the insert in.
+//   - profile (*models.Profile): Profile object containing profile information.
+//
+// Returns:
+//   - *models.Profile: The created or already existing profile if successful.
+//   - error: An error if creating the profile fails.
+func (ps *ProfileStore) CreateProfileTx(ctx context.Context, tx pgx.Tx, profile *models.Profile) (*models.Profile, error) {
+	return createProfile(ctx, tx, profile)
+}
+
+// createProfile inserts a profile, or returns the existing one of the user, using the given executor.
+func createProfile(ctx context.Context, db dbExecutor, profile *models.Profile) (*models.Profile, error) {
 	var createdProfile models.Profile
 	profile.ID = uuid.New()
-	err := ps.dbPool.QueryRow(ctx, `
+	err := db.QueryRow(ctx, `
 		INSERT INTO profiles (
 			id,
 			user_id,
@@ -105,10 +148,21 @@ func (ps *ProfileStore) CreateProfile(ctx context.Context, profile *models.Profi
 		) VALUES (
 			$1, $2, $3, $4, $5, $6, $7, $8, $9, NOW(), NOW()
 		)
-		RETURNING id, user_id, first_name, last_name, website, github, linkedin, twitter, google_scholar, created_at, updated_at
+		ON CONFLICT (user_id) DO NOTHING
+		RETURNING id, user_id, first_name, last_name, website, github, linkedin, twitter, google_scholar, is_private, created_at, updated_at
 	`, profile.ID, profile.UserID, profile.FirstName, profile.LastName, profile.Website, profile.Github, profile.LinkedIn, profile.Twitter, profile.GoogleScholar).Scan(
-		&createdProfile.ID, &createdProfile.UserID, &createdProfile.FirstName, &createdProfile.LastName, &createdProfile.Website, &createdProfile.Github, &createdProfile.LinkedIn, &createdProfile.Twitter, &createdProfile.GoogleScholar, &createdProfile.CreatedAt, &createdProfile.UpdatedAt,
+		&createdProfile.ID, &createdProfile.UserID, &createdProfile.FirstName, &createdProfile.LastName, &createdProfile.Website, &createdProfile.Github, &createdProfile.LinkedIn, &createdProfile.Twitter, &createdProfile.GoogleScholar, &createdProfile.IsPrivate, &createdProfile.CreatedAt, &createdProfile.UpdatedAt,
 	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		// The user already has a profile, return the existing one.
+		err = db.QueryRow(ctx, `
+			SELECT id, user_id, first_name, last_name, website, github, linkedin, twitter, google_scholar, is_private, created_at, updated_at
+			FROM profiles
+			WHERE user_id = $1
+		`, profile.UserID).Scan(
+			&createdProfile.ID, &createdProfile.UserID, &createdProfile.FirstName, &createdProfile.LastName, &createdProfile.Website, &createdProfile.Github, &createdProfile.LinkedIn, &createdProfile.Twitter, &createdProfile.GoogleScholar, &createdProfile.IsPrivate, &createdProfile.CreatedAt, &createdProfile.UpdatedAt,
+		)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to create profile: %w", err)
 	}
@@ -132,18 +186,18 @@ func (ps *ProfileStore) GetProfileByUserID(ctx context.Context, userID uuid.UUID
 
 	err := ps.dbPool.QueryRow(ctx, `
 		SELECT
-			p.id, p.user_id, p.first_name, p.last_name, p.website, p.github, p.linkedin, p.twitter, p.google_scholar, p.created_at, p.updated_at,
-			u.id, u.username, u.email, u.timeout_until, u.banned, u.is_active, u.created_at, u.updated_at,
+			p.id, p.user_id, p.first_name, p.last_name, p.website, p.github, p.linkedin, p.twitter, p.google_scholar, p.is_private, p.created_at, p.updated_at,
+			u.id, u.username, u.email, u.timeout_until, u.banned, u.is_active, u.last_seen_at, u.created_at, u.updated_at,
 			r.level, r.description,
-			(SELECT COUNT(*) FROM follows WHERE followee_id = u.id) as followers_count,
-			(SELECT COUNT(*) FROM follows WHERE follower_id = u.id) as following_count
+			u.followers_count,
+			u.following_count
 		FROM profiles p
 		INNER JOIN users u ON p.user_id = u.id
 		INNER JOIN roles r ON u.role_id = r.id
 		WHERE p.user_id = $1
 	`, userID).Scan(
-		&profile.ID, &profile.UserID, &profile.FirstName, &profile.LastName, &profile.Website, &profile.Github, &profile.LinkedIn, &profile.Twitter, &profile.GoogleScholar, &profile.CreatedAt, &profile.UpdatedAt,
-		&profile.User.ID, &profile.User.Username, &profile.User.Email, &profile.User.TimeoutUntil, &profile.User.Banned, &profile.User.IsActive, &profile.User.CreatedAt, &profile.User.UpdatedAt,
+		&profile.ID, &profile.UserID, &profile.FirstName, &profile.LastName, &profile.Website, &profile.Github, &profile.LinkedIn, &profile.Twitter, &profile.GoogleScholar, &profile.IsPrivate, &profile.CreatedAt, &profile.UpdatedAt,
+		&profile.User.ID, &profile.User.Username, &profile.User.Email, &profile.User.TimeoutUntil, &profile.User.Banned, &profile.User.IsActive, &profile.LastSeenAt, &profile.User.CreatedAt, &profile.User.UpdatedAt,
 		&profile.User.Role.Level, &profile.User.Role.Description,
 		&profile.User.Followers, &profile.User.Following,
 	)
@@ -154,6 +208,7 @@ func (ps *ProfileStore) GetProfileByUserID(ctx context.Context, userID uuid.UUID
 		return nil, fmt.Errorf("failed to get profile by user ID: %w", err)
 	}
 
+	profile.Online = isOnline(profile.LastSeenAt)
 	return &profile, nil
 }
 
@@ -231,11 +286,11 @@ func (ps *ProfileStore) getProfileByUsernameOrEmail(ctx context.Context, identif
 	if strings.Contains(identifier, "@") {
 		query = `
 			SELECT
-				p.id, p.user_id, p.first_name, p.last_name, p.website, p.github, p.linkedin, p.twitter, p.google_scholar, p.created_at, p.updated_at,
-				u.id, u.username, u.email, u.timeout_until, u.banned, u.is_active, u.created_at, u.updated_at,
+				p.id, p.user_id, p.first_name, p.last_name, p.website, p.github, p.linkedin, p.twitter, p.google_scholar, p.is_private, p.created_at, p.updated_at,
+				u.id, u.username, u.email, u.timeout_until, u.banned, u.is_active, u.last_seen_at, u.created_at, u.updated_at,
 				r.level, r.description,
-				(SELECT COUNT(*) FROM follows WHERE followee_id = u.id) as followers_count,
-				(SELECT COUNT(*) FROM follows WHERE follower_id = u.id) as following_count
+				u.followers_count,
+				u.following_count
 			FROM profiles p
 			INNER JOIN users u ON p.user_id = u.id
 			INNER JOIN roles r ON u.role_id = r.id
@@ -244,11 +299,11 @@ func (ps *ProfileStore) getProfileByUsernameOrEmail(ctx context.Context, identif
 	} else {
 		query = `
 			SELECT
-				p.id, p.user_id, p.first_name, p.last_name, p.website, p.github, p.linkedin, p.twitter, p.google_scholar, p.created_at, p.updated_at,
-				u.id, u.username, u.email, u.timeout_until, u.banned, u.is_active, u.created_at, u.updated_at,
+				p.id, p.user_id, p.first_name, p.last_name, p.website, p.github, p.linkedin, p.twitter, p.google_scholar, p.is_private, p.created_at, p.updated_at,
+				u.id, u.username, u.email, u.timeout_until, u.banned, u.is_active, u.last_seen_at, u.created_at, u.updated_at,
 				r.level, r.description,
-				(SELECT COUNT(*) FROM follows WHERE followee_id = u.id) as followers_count,
-				(SELECT COUNT(*) FROM follows WHERE follower_id = u.id) as following_count
+				u.followers_count,
+				u.following_count
 			FROM profiles p
 			INNER JOIN users u ON p.user_id = u.id
 			INNER JOIN roles r ON u.role_id = r.id
@@ -257,8 +312,8 @@ func (ps *ProfileStore) getProfileByUsernameOrEmail(ctx context.Context, identif
 	}
 
 	err := ps.dbPool.QueryRow(ctx, query, identifier).Scan(
-		&profile.ID, &profile.UserID, &profile.FirstName, &profile.LastName, &profile.Website, &profile.Github, &profile.LinkedIn, &profile.Twitter, &profile.GoogleScholar, &profile.CreatedAt, &profile.UpdatedAt,
-		&profile.User.ID, &profile.User.Username, &profile.User.Email, &profile.User.TimeoutUntil, &profile.User.Banned, &profile.User.IsActive, &profile.User.CreatedAt, &profile.User.UpdatedAt,
+		&profile.ID, &profile.UserID, &profile.FirstName, &profile.LastName, &profile.Website, &profile.Github, &profile.LinkedIn, &profile.Twitter, &profile.GoogleScholar, &profile.IsPrivate, &profile.CreatedAt, &profile.UpdatedAt,
+		&profile.User.ID, &profile.User.Username, &profile.User.Email, &profile.User.TimeoutUntil, &profile.User.Banned, &profile.User.IsActive, &profile.LastSeenAt, &profile.User.CreatedAt, &profile.User.UpdatedAt,
 		&profile.User.Role.Level, &profile.User.Role.Description,
 		&profile.User.Followers, &profile.User.Following,
 	)
@@ -269,5 +324,60 @@ func (ps *ProfileStore) getProfileByUsernameOrEmail(ctx context.Context, identif
 		return nil, fmt.Errorf("failed to get profile by username or email: %w", err)
 	}
 
+	profile.Online = isOnline(profile.LastSeenAt)
 	return &profile, nil
 }
+
+// GetUserStats retrieves aggregate activity counts for a user identified by user ID, username, or email.
+// Likes and dislikes received are counted across the posts and comments authored by the user.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the database operation.
+//   - identifier (string): User ID, username, or email of the user.
+//
+// Returns:
+//   - *models.UserStats: The aggregate stats of the user.
+//   - error: ErrUserNotFound if the user does not exist or other errors during database query.
+func (ps *ProfileStore) GetUserStats(ctx context.Context, identifier string) (*models.UserStats, error) {
+	condition, arg := userIdentifierCondition(identifier)
+
+	query := `
+		SELECT
+			u.id,
+			(SELECT COUNT(*) FROM posts WHERE author_id = u.id) AS posts_count,
+			(SELECT COUNT(*) FROM comments WHERE author_id = u.id) AS comments_count,
+			(SELECT COUNT(*) FROM post_likes pl INNER JOIN posts p ON pl.post_id = p.id WHERE p.author_id = u.id AND pl.liked = TRUE AND ` + selfLikeCondition("pl.user_id", "p.author_id") + `)
+				+ (SELECT COUNT(*) FROM comment_likes cl INNER JOIN comments c ON cl.comment_id = c.id WHERE c.author_id = u.id AND cl.liked = TRUE AND ` + selfLikeCondition("cl.user_id", "c.author_id") + `) AS total_likes_received,
+			(SELECT COUNT(*) FROM post_likes pl INNER JOIN posts p ON pl.post_id = p.id WHERE p.author_id = u.id AND pl.liked = FALSE)
+				+ (SELECT COUNT(*) FROM comment_likes cl INNER JOIN comments c ON cl.comment_id = c.id WHERE c.author_id = u.id AND cl.liked = FALSE) AS total_dislikes_received,
+			u.followers_count,
+			u.following_count
+		FROM users u
+		WHERE ` + condition
+
+	var stats models.UserStats
+	err := ps.dbPool.QueryRow(ctx, query, arg).Scan(
+		&stats.UserID, &stats.PostsCount, &stats.CommentsCount,
+		&stats.TotalLikesReceived, &stats.TotalDislikesReceived,
+		&stats.FollowersCount, &stats.FollowingCount,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get user stats: %w", err)
+	}
+
+	return &stats, nil
+}
+
+// userIdentifierCondition returns the condition on the users table aliased u matching a user ID, email or username,
+// taking the identifier as its $1 argument.
+func userIdentifierCondition(identifier string) (string, interface{}) {
+	if userID, err := uuid.Parse(identifier); err == nil {
+		return "u.id = $1", userID
+	} else if strings.Contains(identifier, "@") {
+		return "u.email = $1", identifier
+	}
+	return "u.username = $1", identifier
+}
@@ -0,0 +1,158 @@
+package stores
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/datarohit/gopher-social-backend/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/sirupsen/logrus"
+)
+
+// slowQueryThreshold is the duration above which tracedQuery logs a query as slow.
+var slowQueryThreshold = slowQueryThresholdFromEnv()
+
+// slowQueryThresholdFromEnv reads SLOW_QUERY_THRESHOLD_MS, defaulting to 500 milliseconds.
+func slowQueryThresholdFromEnv() time.Duration {
+	milliseconds, err := strconv.Atoi(os.Getenv("SLOW_QUERY_THRESHOLD_MS"))
+	if err != nil || milliseconds <= 0 {
+		return 500 * time.Millisecond
+	}
+	return time.Duration(milliseconds) * time.Millisecond
+}
+
+// queryDurationBounds are the upper bounds of the buckets of the query duration histogram.
+// Queries slower than the last bound are counted in a final unbounded bucket.
+var queryDurationBounds = []time.Duration{
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+}
+
+// queryDurationCounts counts traced queries per bucket of queryDurationBounds, the last entry counts slower queries.
+var queryDurationCounts = make([]atomic.Int64, len(queryDurationBounds)+1)
+
+// queryDurationTotal, queryCount and slowQueryCount sum the durations of traced queries and count all and slow ones.
+var queryDurationTotal, queryCount, slowQueryCount atomic.Int64
+
+// queryLogger logs slow queries, nil until EnableQueryTracing is called.
+var queryLogger *logrus.Logger
+
+// querier runs queries, implemented by *pgxpool.Pool and pgx.Tx.
+type querier interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
+// EnableQueryTracing makes tracedQuery log queries slower than SLOW_QUERY_THRESHOLD_MS at warn level.
+// Query durations are recorded in the histogram whether or not it is called.
+//
+// Parameters:
+//   - logger (*logrus.Logger): Logger for slow queries.
+func EnableQueryTracing(logger *logrus.Logger) {
+	queryLogger = logger
+}
+
+// QueryDurationStats returns the histogram of the durations of traced queries since the server started.
+//
+// Returns:
+//   - models.QueryDurationStats: Number of traced and slow queries, their total duration and the cumulative histogram buckets.
+func QueryDurationStats() models.QueryDurationStats {
+	stats := models.QueryDurationStats{
+		Count:                     queryCount.Load(),
+		SlowCount:                 slowQueryCount.Load(),
+		TotalMilliseconds:         time.Duration(queryDurationTotal.Load()).Milliseconds(),
+		SlowThresholdMilliseconds: slowQueryThreshold.Milliseconds(),
+		Buckets:                   make([]models.QueryDurationBucket, 0, len(queryDurationCounts)),
+	}
+
+	var cumulative int64
+	for i := range queryDurationCounts {
+		cumulative += queryDurationCounts[i].Load()
+		le := "+Inf"
+		if i < len(queryDurationBounds) {
+			le = queryDurationBounds[i].String()
+		}
+		stats.Buckets = append(stats.Buckets, models.QueryDurationBucket{Le: le, Count: cumulative})
+	}
+	return stats
+}
+
+// tracedQuery runs a query and records its duration, from sending it until its last row is read, in the
+// query duration histogram. Queries slower than SLOW_QUERY_THRESHOLD_MS are logged with the operation name.
+// Callers must close the returned rows, as every store already does with a deferred Close.
+func tracedQuery(ctx context.Context, db querier, operation string, sql string, args ...any) (pgx.Rows, error) {
+	start := time.Now()
+	rows, err := db.Query(ctx, sql, args...)
+	if err != nil {
+		recordQueryDuration(operation, time.Since(start), err)
+		return nil, err
+	}
+	return &tracedRows{Rows: rows, operation: operation, start: start}, nil
+}
+
+// tracedRows records the duration of the query of its rows once they are read to the end or closed.
+type tracedRows struct {
+	pgx.Rows
+	operation string
+	start     time.Time
+	once      sync.Once
+}
+
+// Next advances to the next row, recording the duration of the query after the last row.
+func (tr *tracedRows) Next() bool {
+	if tr.Rows.Next() {
+		return true
+	}
+	tr.finish()
+	return false
+}
+
+// Close closes the rows and records the duration of the query if it was not read to the end.
+func (tr *tracedRows) Close() {
+	tr.Rows.Close()
+	tr.finish()
+}
+
+// finish records the duration of the query the first time it is called.
+func (tr *tracedRows) finish() {
+	tr.once.Do(func() {
+		recordQueryDuration(tr.operation, time.Since(tr.start), tr.Rows.Err())
+	})
+}
+
+// recordQueryDuration adds a query duration to the histogram and logs the query if it was slow.
+func recordQueryDuration(operation string, duration time.Duration, err error) {
+	bucket := len(queryDurationBounds)
+	for i, bound := range queryDurationBounds {
+		if duration <= bound {
+			bucket = i
+			break
+		}
+	}
+	queryDurationCounts[bucket].Add(1)
+	queryDurationTotal.Add(int64(duration))
+	queryCount.Add(1)
+
+	if duration <= slowQueryThreshold {
+		return
+	}
+	slowQueryCount.Add(1)
+	if queryLogger != nil {
+		fields := logrus.Fields{"operation": operation, "durationMs": duration.Milliseconds(), "thresholdMs": slowQueryThreshold.Milliseconds()}
+		if err != nil {
+			fields["error"] = err
+		}
+		queryLogger.WithFields(fields).Warn("Slow Database Query")
+	}
+}
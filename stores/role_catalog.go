@@ -0,0 +1,39 @@
+package stores
+
+import (
+	"sync"
+	"time"
+
+	"github.com/datarohit/gopher-social-backend/models"
+)
+
+// roleCatalogTTL is how long ListRoles serves the roles from memory before reading them again.
+// Roles only change with migrations, the TTL merely bounds how long a changed catalog takes to show.
+const roleCatalogTTL = 10 * time.Minute
+
+// roleCatalog is the in-memory copy of the roles table, shared by every AuthStore.
+var roleCatalog struct {
+	mu       sync.RWMutex
+	roles    []*models.Role
+	loadedAt time.Time
+}
+
+// cachedRoles returns the cached roles, or nil if they were never loaded or have expired.
+func cachedRoles() []*models.Role {
+	roleCatalog.mu.RLock()
+	defer roleCatalog.mu.RUnlock()
+
+	if roleCatalog.roles == nil || time.Since(roleCatalog.loadedAt) > roleCatalogTTL {
+		return nil
+	}
+	return roleCatalog.roles
+}
+
+// cacheRoles stores the roles read from the database in memory.
+func cacheRoles(roles []*models.Role) {
+	roleCatalog.mu.Lock()
+	defer roleCatalog.mu.Unlock()
+
+	roleCatalog.roles = roles
+	roleCatalog.loadedAt = time.Now()
+}
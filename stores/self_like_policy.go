@@ -0,0 +1,26 @@
+package stores
+
+import (
+	"os"
+	"strconv"
+)
+
+// excludeSelfLikes is set when likes of authors on their own posts and comments are left out of like counts.
+var excludeSelfLikes = excludeSelfLikesFromEnv()
+
+// excludeSelfLikesFromEnv reads EXCLUDE_SELF_LIKES, defaulting to false.
+func excludeSelfLikesFromEnv() bool {
+	exclude, err := strconv.ParseBool(os.Getenv("EXCLUDE_SELF_LIKES"))
+	return err == nil && exclude
+}
+
+// selfLikeCondition returns the SQL condition on a like that leaves self-likes out of like counts.
+// It is TRUE unless EXCLUDE_SELF_LIKES is set. Self-likes are still stored, so the author sees their own
+// like, and counting them again only needs the setting turned off.
+// The columns are always constants of the calling query, never user input.
+func selfLikeCondition(likeUserIDColumn string, authorIDColumn string) string {
+	if !excludeSelfLikes {
+		return "TRUE"
+	}
+	return likeUserIDColumn + " <> " + authorIDColumn
+}
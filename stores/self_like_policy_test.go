@@ -0,0 +1,48 @@
+package stores
+
+import "testing"
+
+func TestSelfLikeCondition(t *testing.T) {
+	tests := []struct {
+		name    string
+		exclude bool
+		want    string
+	}{
+		{name: "self-likes counted", exclude: false, want: "TRUE"},
+		{name: "self-likes excluded", exclude: true, want: "pl.user_id <> p.author_id"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			previous := excludeSelfLikes
+			excludeSelfLikes = tt.exclude
+			t.Cleanup(func() { excludeSelfLikes = previous })
+
+			if got := selfLikeCondition("pl.user_id", "p.author_id"); got != tt.want {
+				t.Fatalf("selfLikeCondition() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExcludeSelfLikesFromEnv(t *testing.T) {
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{value: "", want: false},
+		{value: "true", want: true},
+		{value: "1", want: true},
+		{value: "false", want: false},
+		{value: "yes", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			t.Setenv("EXCLUDE_SELF_LIKES", tt.value)
+			if got := excludeSelfLikesFromEnv(); got != tt.want {
+				t.Fatalf("excludeSelfLikesFromEnv() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,282 @@
+package stores
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/datarohit/gopher-social-backend/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
+)
+
+type SessionStore struct {
+	dbPool      *pgxpool.Pool
+	redisClient *redis.Client
+}
+
+// NewSessionStore creates a new SessionStore.
+//
+// Parameters:
+//   - dbPool (*pgxpool.Pool): Pgx connection pool.
+//   - redisClient (*redis.Client): Redis client used for the session denylist.
+//
+// Returns:
+//   - *SessionStore: SessionStore instance.
+func NewSessionStore(dbPool *pgxpool.Pool, redisClient *redis.Client) *SessionStore {
+	return &SessionStore{
+		dbPool:      dbPool,
+		redisClient: redisClient,
+	}
+}
+
+// ErrSessionNotFound is returned when a session is not found.
+var ErrSessionNotFound = errors.New("session not found")
+
+// ErrSessionRevoked is returned when a session has been revoked.
+var ErrSessionRevoked = errors.New("session revoked")
+
+// sessionDenylistKeyPrefix is the Redis key prefix for revoked session IDs.
+const sessionDenylistKeyPrefix = "session_denylist:"
+
+// tokenEpochKeyPrefix is the Redis key prefix for the per-user token epoch.
+// Tokens of a user issued before their epoch are rejected.
+const tokenEpochKeyPrefix = "token_epoch:"
+
+// CreateSession creates a new session in the database.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the database operation.
+//   - session (*models.Session): Session object to be created.
+//
+// Returns:
+//   - *models.Session: The created session if successful.
+//   - error: An error if session creation fails.
+func (ss *SessionStore) CreateSession(ctx context.Context, session *models.Session) (*models.Session, error) {
+	var createdSession models.Session
+	err := ss.dbPool.QueryRow(ctx, `
+		INSERT INTO sessions (user_id, user_agent, ip_address)
+		VALUES ($1, $2, $3)
+		RETURNING id, user_id, user_agent, ip_address, created_at, last_used_at
+	`, session.UserID, session.UserAgent, session.IPAddress).Scan(
+		&createdSession.ID, &createdSession.UserID, &createdSession.UserAgent, &createdSession.IPAddress, &createdSession.CreatedAt, &createdSession.LastUsedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+
+	return &createdSession, nil
+}
+
+// ListSessionsByUserID retrieves all sessions of a user, most recently used first.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the database operation.
+//   - userID (uuid.UUID): ID of the user whose sessions to retrieve.
+//
+// Returns:
+//   - []*models.Session: List of sessions.
+//   - error: An error if retrieving the sessions fails.
+func (ss *SessionStore) ListSessionsByUserID(ctx context.Context, userID uuid.UUID) ([]*models.Session, error) {
+	rows, err := ss.dbPool.Query(ctx, `
+		SELECT id, user_id, user_agent, ip_address, created_at, last_used_at
+		FROM sessions
+		WHERE user_id = $1
+		ORDER BY last_used_at DESC
+	`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+	defer rows.Close()
+
+	var sessions []*models.Session
+	for rows.Next() {
+		var session models.Session
+		err := rows.Scan(&session.ID, &session.UserID, &session.UserAgent, &session.IPAddress, &session.CreatedAt, &session.LastUsedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan session row: %w", err)
+		}
+		sessions = append(sessions, &session)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating session rows: %w", err)
+	}
+
+	return sessions, nil
+}
+
+// TouchSession updates the last used time of a session.
+// It is called whenever the session's refresh token is used.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the database operation.
+//   - sessionID (uuid.UUID): ID of the session.
+//
+// Returns:
+//   - error: ErrSessionRevoked if the session is on the denylist, ErrSessionNotFound if it does not exist, or other errors.
+func (ss *SessionStore) TouchSession(ctx context.Context, sessionID uuid.UUID) error {
+	revoked, err := ss.IsSessionRevoked(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	if revoked {
+		return ErrSessionRevoked
+	}
+
+	commandTag, err := ss.dbPool.Exec(ctx, `UPDATE sessions SET last_used_at = now() WHERE id = $1`, sessionID)
+	if err != nil {
+		return fmt.Errorf("failed to update session: %w", err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return ErrSessionNotFound
+	}
+
+	return nil
+}
+
+// IsSessionRevoked checks whether a session is on the denylist.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the Redis operation.
+//   - sessionID (uuid.UUID): ID of the session.
+//
+// Returns:
+//   - bool: True if the session has been revoked.
+//   - error: An error if the denylist lookup fails.
+func (ss *SessionStore) IsSessionRevoked(ctx context.Context, sessionID uuid.UUID) (bool, error) {
+	count, err := ss.redisClient.Exists(ctx, sessionDenylistKeyPrefix+sessionID.String()).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to check session denylist: %w", err)
+	}
+	return count > 0, nil
+}
+
+// RevokeSession deletes a session of a user and adds it to the denylist so that its tokens stop working.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the database operation.
+//   - userID (uuid.UUID): ID of the user owning the session.
+//   - sessionID (uuid.UUID): ID of the session to revoke.
+//   - ttl (time.Duration): How long the session stays on the denylist, should match the refresh token lifetime.
+//
+// Returns:
+//   - error: ErrSessionNotFound if the session does not exist for the user, or other errors.
+func (ss *SessionStore) RevokeSession(ctx context.Context, userID uuid.UUID, sessionID uuid.UUID, ttl time.Duration) error {
+	commandTag, err := ss.dbPool.Exec(ctx, `DELETE FROM sessions WHERE id = $1 AND user_id = $2`, sessionID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete session: %w", err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return ErrSessionNotFound
+	}
+
+	if err := ss.redisClient.Set(ctx, sessionDenylistKeyPrefix+sessionID.String(), userID.String(), ttl).Err(); err != nil {
+		return fmt.Errorf("failed to add session to denylist: %w", err)
+	}
+
+	return nil
+}
+
+// RevokeAllSessions force logs out a user everywhere. In a single transaction it deletes all sessions of the user
+// and records the action in the moderation audit log, then adds every session to the denylist and bumps the
+// user's token epoch so that any token issued before now is rejected.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the database operation.
+//   - actorID (uuid.UUID): ID of the admin performing the action.
+//   - userID (uuid.UUID): ID of the user whose sessions are revoked.
+//   - ttl (time.Duration): How long the denylist entries and the epoch are kept, should match the refresh token lifetime.
+//
+// Returns:
+//   - int: Number of sessions revoked.
+//   - error: An error if the operation fails.
+func (ss *SessionStore) RevokeAllSessions(ctx context.Context, actorID uuid.UUID, userID uuid.UUID, ttl time.Duration) (int, error) {
+	return ss.revokeAllSessions(ctx, userID, ttl, func(tx pgx.Tx, revoked int) error {
+		details := fmt.Sprintf("revoked %d sessions", revoked)
+		return recordModerationAction(ctx, tx, actorID, userID, ModerationActionForceLogout, details)
+	})
+}
+
+// EndAllSessions logs a user out everywhere after a change of their own account, such as a new email.
+// It works like RevokeAllSessions, but is not a moderation action and is not recorded in the moderation audit log.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the database operation.
+//   - userID (uuid.UUID): ID of the user whose sessions are ended.
+//   - ttl (time.Duration): How long the denylist entries and the epoch are kept, should match the refresh token lifetime.
+//
+// Returns:
+//   - int: Number of sessions ended.
+//   - error: An error if the operation fails.
+func (ss *SessionStore) EndAllSessions(ctx context.Context, userID uuid.UUID, ttl time.Duration) (int, error) {
+	return ss.revokeAllSessions(ctx, userID, ttl, nil)
+}
+
+// revokeAllSessions deletes all sessions of a user in a transaction, running audit in the same transaction if it is
+// not nil, then adds every session to the denylist and bumps the user's token epoch.
+func (ss *SessionStore) revokeAllSessions(ctx context.Context, userID uuid.UUID, ttl time.Duration, audit func(tx pgx.Tx, revoked int) error) (int, error) {
+	var sessionIDs []uuid.UUID
+	err := WithTx(ctx, ss.dbPool, func(tx pgx.Tx) error {
+		rows, err := tx.Query(ctx, `DELETE FROM sessions WHERE user_id = $1 RETURNING id`, userID)
+		if err != nil {
+			return fmt.Errorf("failed to delete sessions: %w", err)
+		}
+
+		for rows.Next() {
+			var sessionID uuid.UUID
+			if err := rows.Scan(&sessionID); err != nil {
+				rows.Close()
+				return fmt.Errorf("failed to scan session id: %w", err)
+			}
+			sessionIDs = append(sessionIDs, sessionID)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("error iterating session rows: %w", err)
+		}
+
+		if audit != nil {
+			return audit(tx, len(sessionIDs))
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	pipe := ss.redisClient.Pipeline()
+	for _, sessionID := range sessionIDs {
+		pipe.Set(ctx, sessionDenylistKeyPrefix+sessionID.String(), userID.String(), ttl)
+	}
+	pipe.Set(ctx, tokenEpochKeyPrefix+userID.String(), time.Now().Unix(), ttl)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, fmt.Errorf("failed to revoke session tokens: %w", err)
+	}
+
+	return len(sessionIDs), nil
+}
+
+// IsTokenBeforeEpoch checks whether a token of a user was issued before the user's token epoch.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the Redis operation.
+//   - userID (uuid.UUID): ID of the user owning the token.
+//   - issuedAt (time.Time): Time the token was issued at.
+//
+// Returns:
+//   - bool: True if the token was issued before the current epoch and must be rejected.
+//   - error: An error if the epoch lookup fails.
+func (ss *SessionStore) IsTokenBeforeEpoch(ctx context.Context, userID uuid.UUID, issuedAt time.Time) (bool, error) {
+	epoch, err := ss.redisClient.Get(ctx, tokenEpochKeyPrefix+userID.String()).Int64()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get token epoch: %w", err)
+	}
+	return issuedAt.Unix() < epoch, nil
+}
@@ -0,0 +1,211 @@
+package stores
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/datarohit/gopher-social-backend/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
+)
+
+type StatsStore struct {
+	dbPool      *pgxpool.Pool
+	redisClient *redis.Client
+}
+
+// NewStatsStore creates a new StatsStore.
+//
+// Parameters:
+//   - dbPool (*pgxpool.Pool): Pgx connection pool.
+//   - redisClient (*redis.Client): Redis client used to cache the platform stats.
+//
+// Returns:
+//   - *StatsStore: StatsStore instance.
+func NewStatsStore(dbPool *pgxpool.Pool, redisClient *redis.Client) *StatsStore {
+	return &StatsStore{
+		dbPool:      dbPool,
+		redisClient: redisClient,
+	}
+}
+
+// ErrInvalidActivityGranularity is returned when an unknown activity histogram granularity is requested.
+var ErrInvalidActivityGranularity = errors.New("invalid granularity value, must be one of day, week")
+
+// ErrInvalidActivityRange is returned when the range of an activity histogram is reversed or too long.
+var ErrInvalidActivityRange = errors.New("invalid range, from must not be after to and the range must be at most 366 days")
+
+// Supported bucket sizes of activity histograms.
+const (
+	ActivityGranularityDay  = "day"
+	ActivityGranularityWeek = "week"
+)
+
+// ActivityGranularities lists the supported bucket sizes of activity histograms.
+var ActivityGranularities = []string{ActivityGranularityDay, ActivityGranularityWeek}
+
+// MaxActivityRangeDays is the longest range, in days, an activity histogram may cover.
+const MaxActivityRangeDays = 366
+
+// platformStatsCacheKey is the Redis key of the cached platform stats.
+const platformStatsCacheKey = "platform_stats"
+
+// platformStatsCacheTTL is how long the platform stats are served from Redis before they are computed again.
+var platformStatsCacheTTL = platformStatsCacheTTLFromEnv()
+
+// platformStatsCacheTTLFromEnv reads PLATFORM_STATS_CACHE_TTL_SECONDS, defaulting to 60 seconds.
+func platformStatsCacheTTLFromEnv() time.Duration {
+	seconds, err := strconv.Atoi(os.Getenv("PLATFORM_STATS_CACHE_TTL_SECONDS"))
+	if err != nil || seconds <= 0 {
+		return 60 * time.Second
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// GetPlatformStats retrieves the user, post and comment totals of the platform.
+// The stats are cached in Redis for PLATFORM_STATS_CACHE_TTL_SECONDS, so they can lag behind by that long.
+// If Redis is unavailable the stats are computed on every call.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the database operation.
+//
+// Returns:
+//   - *models.PlatformStats: The platform stats.
+//   - error: An error if computing the stats fails.
+func (ss *StatsStore) GetPlatformStats(ctx context.Context) (*models.PlatformStats, error) {
+	if data, err := ss.redisClient.Get(ctx, platformStatsCacheKey).Bytes(); err == nil {
+		var cached models.PlatformStats
+		if err := json.Unmarshal(data, &cached); err == nil {
+			return &cached, nil
+		}
+	}
+
+	stats := &models.PlatformStats{}
+	err := ss.dbPool.QueryRow(ctx, `
+		SELECT
+			u.total_users, u.active_users, u.banned_users, u.new_users_24h,
+			p.total_posts, p.posts_24h,
+			c.total_comments,
+			NOW()
+		FROM (
+			SELECT
+				COUNT(*) as total_users,
+				COUNT(*) FILTER (WHERE is_active = TRUE AND banned = FALSE) as active_users,
+				COUNT(*) FILTER (WHERE banned = TRUE) as banned_users,
+				COUNT(*) FILTER (WHERE created_at >= NOW() - INTERVAL '24 hours') as new_users_24h
+			FROM users
+		) u
+		CROSS JOIN (
+			SELECT
+				COUNT(*) as total_posts,
+				COUNT(*) FILTER (WHERE created_at >= NOW() - INTERVAL '24 hours') as posts_24h
+			FROM posts
+		) p
+		CROSS JOIN (
+			SELECT COUNT(*) as total_comments FROM comments
+		) c
+	`).Scan(
+		&stats.TotalUsers, &stats.ActiveUsers, &stats.BannedUsers, &stats.NewUsersLast24h,
+		&stats.TotalPosts, &stats.PostsLast24h,
+		&stats.TotalComments,
+		&stats.ComputedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get platform stats: %w", err)
+	}
+
+	if data, err := json.Marshal(stats); err == nil {
+		ss.redisClient.Set(ctx, platformStatsCacheKey, data, platformStatsCacheTTL)
+	}
+
+	return stats, nil
+}
+
+// GetUserActivityHistogram counts the posts and comments a user created per day or per week over a range of days.
+// Days and weeks are in UTC and weeks start on Monday. Every bucket of the range is returned, including empty ones,
+// and the first week bucket starts on the Monday of the week of from.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the database operation.
+//   - identifier (string): User ID, username, or email of the user.
+//   - granularity (string): Bucket size, day or week.
+//   - from (time.Time): First day of the range.
+//   - to (time.Time): Last day of the range, included.
+//
+// Returns:
+//   - *models.UserActivityHistogram: The posts and comments counts of each bucket, oldest first.
+//   - error: ErrInvalidActivityGranularity or ErrInvalidActivityRange for invalid parameters, ErrUserNotFound if the
+//     user does not exist, or other errors during database query.
+func (ss *StatsStore) GetUserActivityHistogram(ctx context.Context, identifier string, granularity string, from time.Time, to time.Time) (*models.UserActivityHistogram, error) {
+	if granularity != ActivityGranularityDay && granularity != ActivityGranularityWeek {
+		return nil, ErrInvalidActivityGranularity
+	}
+
+	start := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	end := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, 1)
+	if !start.Before(end) || end.Sub(start) > MaxActivityRangeDays*24*time.Hour {
+		return nil, ErrInvalidActivityRange
+	}
+	if granularity == ActivityGranularityWeek {
+		start = start.AddDate(0, 0, -((int(start.Weekday()) + 6) % 7))
+	}
+
+	condition, arg := userIdentifierCondition(identifier)
+	histogram := &models.UserActivityHistogram{Granularity: granularity, Buckets: []*models.ActivityBucket{}}
+	err := ss.dbPool.QueryRow(ctx, `SELECT u.id FROM users u WHERE `+condition, arg).Scan(&histogram.UserID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get user for activity histogram: %w", err)
+	}
+
+	rows, err := ss.dbPool.Query(ctx, `
+		WITH buckets AS (
+			SELECT generate_series($3::timestamp, $4::timestamp - INTERVAL '1 day', ('1 ' || $2::text)::interval) as bucket
+		),
+		post_counts AS (
+			SELECT date_trunc($2::text, created_at AT TIME ZONE 'UTC') as bucket, COUNT(*) as posts
+			FROM posts
+			WHERE author_id = $1 AND created_at >= $3::timestamp AT TIME ZONE 'UTC' AND created_at < $4::timestamp AT TIME ZONE 'UTC'
+			GROUP BY 1
+		),
+		comment_counts AS (
+			SELECT date_trunc($2::text, created_at AT TIME ZONE 'UTC') as bucket, COUNT(*) as comments
+			FROM comments
+			WHERE author_id = $1 AND created_at >= $3::timestamp AT TIME ZONE 'UTC' AND created_at < $4::timestamp AT TIME ZONE 'UTC'
+			GROUP BY 1
+		)
+		SELECT b.bucket, COALESCE(pc.posts, 0), COALESCE(cc.comments, 0)
+		FROM buckets b
+		LEFT JOIN post_counts pc ON pc.bucket = b.bucket
+		LEFT JOIN comment_counts cc ON cc.bucket = b.bucket
+		ORDER BY b.bucket
+	`, histogram.UserID, granularity, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user activity histogram: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		bucket := &models.ActivityBucket{}
+		if err := rows.Scan(&bucket.Start, &bucket.Posts, &bucket.Comments); err != nil {
+			return nil, fmt.Errorf("failed to scan activity bucket row: %w", err)
+		}
+		histogram.Buckets = append(histogram.Buckets, bucket)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during activity bucket rows iteration: %w", err)
+	}
+
+	histogram.From = start
+	histogram.To = end.AddDate(0, 0, -1)
+	return histogram, nil
+}
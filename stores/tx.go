@@ -0,0 +1,46 @@
+package stores
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// dbExecutor is implemented by both *pgxpool.Pool and pgx.Tx, so a store query can run on its own
+// or as one step of a transaction.
+type dbExecutor interface {
+	Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+}
+
+// WithTx runs fn inside a database transaction, committing it if fn returns nil and rolling it back otherwise.
+// It lets controllers compose several transaction-aware store calls into one atomic operation.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the database operation.
+//   - dbPool (*pgxpool.Pool): Pgx connection pool to begin the transaction on.
+//   - fn (func(tx pgx.Tx) error): Function running the steps of the transaction.
+//
+// Returns:
+//   - error: The error returned by fn, or an error if beginning or committing the transaction fails.
+func WithTx(ctx context.Context, dbPool *pgxpool.Pool, fn func(tx pgx.Tx) error) error {
+	tx, err := dbPool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,112 @@
+package stores
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/datarohit/gopher-social-backend/database/dbtest"
+	"github.com/datarohit/gopher-social-backend/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// createInactiveUser creates a user that is not activated yet and has no profile, as registration leaves it.
+func createInactiveUser(t *testing.T, pool *pgxpool.Pool, username string) uuid.UUID {
+	t.Helper()
+
+	ctx := context.Background()
+	userID := dbtest.CreateUser(t, pool, username, 1)
+	if _, err := pool.Exec(ctx, `DELETE FROM profiles WHERE user_id = $1`, userID); err != nil {
+		t.Fatalf("failed to delete profile: %v", err)
+	}
+	if _, err := pool.Exec(ctx, `UPDATE users SET is_active = FALSE, activated_at = NULL WHERE id = $1`, userID); err != nil {
+		t.Fatalf("failed to deactivate user: %v", err)
+	}
+	return userID
+}
+
+// activationState returns whether the user is active and whether they have a profile.
+func activationState(t *testing.T, pool *pgxpool.Pool, userID uuid.UUID) (bool, bool) {
+	t.Helper()
+
+	var isActive, hasProfile bool
+	err := pool.QueryRow(context.Background(), `
+		SELECT u.is_active, EXISTS(SELECT 1 FROM profiles p WHERE p.user_id = u.id)
+		FROM users u
+		WHERE u.id = $1
+	`, userID).Scan(&isActive, &hasProfile)
+	if err != nil {
+		t.Fatalf("failed to read activation state: %v", err)
+	}
+	return isActive, hasProfile
+}
+
+func TestWithTxActivation(t *testing.T) {
+	pool := dbtest.NewPool(t)
+	ctx := context.Background()
+
+	authStore := NewAuthStore(pool)
+	profileStore := NewProfileStore(pool)
+	errStepFailed := errors.New("step failed")
+
+	tests := []struct {
+		name        string
+		profile     func(userID uuid.UUID) *models.Profile
+		afterCreate error
+		wantErr     bool
+		wantActive  bool
+	}{
+		{
+			name:       "all steps succeed",
+			profile:    func(userID uuid.UUID) *models.Profile { return &models.Profile{UserID: userID} },
+			wantActive: true,
+		},
+		{
+			name: "profile insert fails",
+			profile: func(userID uuid.UUID) *models.Profile {
+				return &models.Profile{UserID: userID, FirstName: strings.Repeat("a", 256)}
+			},
+			wantErr: true,
+		},
+		{
+			name:        "later step fails",
+			profile:     func(userID uuid.UUID) *models.Profile { return &models.Profile{UserID: userID} },
+			afterCreate: errStepFailed,
+			wantErr:     true,
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userID := createInactiveUser(t, pool, fmt.Sprintf("user%d", i))
+
+			err := WithTx(ctx, pool, func(tx pgx.Tx) error {
+				if err := authStore.ActivateUserTx(ctx, tx, userID); err != nil {
+					return err
+				}
+				if _, err := profileStore.CreateProfileTx(ctx, tx, tt.profile(userID)); err != nil {
+					return err
+				}
+				return tt.afterCreate
+			})
+			if tt.wantErr && err == nil {
+				t.Fatal("WithTx() error = nil, want an error")
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("WithTx() error = %v", err)
+			}
+			if tt.afterCreate != nil && !errors.Is(err, tt.afterCreate) {
+				t.Fatalf("WithTx() error = %v, want the error of the failed step", err)
+			}
+
+			isActive, hasProfile := activationState(t, pool, userID)
+			if isActive != tt.wantActive || hasProfile != tt.wantActive {
+				t.Fatalf("active = %v, has profile = %v, want both %v", isActive, hasProfile, tt.wantActive)
+			}
+		})
+	}
+}
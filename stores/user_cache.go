@@ -0,0 +1,108 @@
+package stores
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/datarohit/gopher-social-backend/models"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// userCacheKeyPrefix is the Redis key prefix for users cached by GetUserByID.
+const userCacheKeyPrefix = "user:"
+
+// userCacheTTL is how long a user stays cached, which bounds how stale a user can be if an invalidation fails.
+var userCacheTTL = userCacheTTLFromEnv()
+
+// userCacheTTLFromEnv reads USER_CACHE_TTL_SECONDS, defaulting to 30 seconds.
+func userCacheTTLFromEnv() time.Duration {
+	seconds, err := strconv.Atoi(os.Getenv("USER_CACHE_TTL_SECONDS"))
+	if err != nil || seconds <= 0 {
+		return 30 * time.Second
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// userCacheClient is the Redis client of the user cache, nil until EnableUserCache is called.
+var userCacheClient *redis.Client
+
+// userCacheHits and userCacheMisses count GetUserByID lookups answered from and not found in the user cache.
+var userCacheHits, userCacheMisses atomic.Int64
+
+// cachedUser is the user stored in the user cache. The password hash and the reset and activation tokens
+// are never written to Redis. RoleID is kept separately as it is not part of the JSON shape of a user.
+type cachedUser struct {
+	User   *models.User `json:"user"`
+	RoleID uuid.UUID    `json:"role_id"`
+}
+
+// EnableUserCache makes GetUserByID cache users in Redis for USER_CACHE_TTL_SECONDS.
+// Stores changing the role, ban, timeout, activation, password or profile of a user drop the cached user.
+//
+// Parameters:
+//   - redisClient (*redis.Client): Redis client used to cache users.
+func EnableUserCache(redisClient *redis.Client) {
+	userCacheClient = redisClient
+}
+
+// UserCacheStats returns the number of GetUserByID lookups answered from the user cache and the number that missed it.
+//
+// Returns:
+//   - int64: Number of cache hits.
+//   - int64: Number of cache misses.
+func UserCacheStats() (int64, int64) {
+	return userCacheHits.Load(), userCacheMisses.Load()
+}
+
+// getCachedUser returns the cached user with the given ID, or nil if the user is not cached or the cache is unavailable.
+func getCachedUser(ctx context.Context, userID uuid.UUID) *models.User {
+	if userCacheClient == nil {
+		return nil
+	}
+
+	data, err := userCacheClient.Get(ctx, userCacheKeyPrefix+userID.String()).Bytes()
+	if err != nil {
+		userCacheMisses.Add(1)
+		return nil
+	}
+
+	var cached cachedUser
+	if err := json.Unmarshal(data, &cached); err != nil || cached.User == nil {
+		userCacheMisses.Add(1)
+		return nil
+	}
+
+	userCacheHits.Add(1)
+	cached.User.RoleID = cached.RoleID
+	if cached.User.Profile != nil {
+		cached.User.Profile.UserID = cached.User.ID
+	}
+	return cached.User
+}
+
+// cacheUser stores a user in the user cache. Failures are ignored, the next lookup reads the database again.
+func cacheUser(ctx context.Context, user *models.User) {
+	if userCacheClient == nil {
+		return
+	}
+
+	data, err := json.Marshal(cachedUser{User: user, RoleID: user.RoleID})
+	if err != nil {
+		return
+	}
+	userCacheClient.Set(ctx, userCacheKeyPrefix+user.ID.String(), data, userCacheTTL)
+}
+
+// invalidateCachedUser drops a user from the user cache after it was changed in the database.
+// Failures are ignored, the cached user then expires after USER_CACHE_TTL_SECONDS.
+func invalidateCachedUser(ctx context.Context, userID uuid.UUID) {
+	if userCacheClient == nil {
+		return
+	}
+	userCacheClient.Del(ctx, userCacheKeyPrefix+userID.String())
+}
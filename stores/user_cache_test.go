@@ -0,0 +1,229 @@
+package stores
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/datarohit/gopher-social-backend/database/dbtest"
+	"github.com/datarohit/gopher-social-backend/models"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// enableTestUserCache points the user cache at an in-memory Redis server for the duration of the test.
+func enableTestUserCache(t *testing.T) *miniredis.Miniredis {
+	t.Helper()
+
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	previous := userCacheClient
+	EnableUserCache(client)
+	t.Cleanup(func() {
+		userCacheClient = previous
+		client.Close()
+	})
+	return server
+}
+
+func TestUserCacheHitAndMiss(t *testing.T) {
+	server := enableTestUserCache(t)
+	ctx := context.Background()
+
+	resetToken := "reset-token"
+	activationToken := "activation-token"
+	user := &models.User{
+		ID:                 uuid.New(),
+		Username:           "gopher",
+		Email:              "gopher@example.com",
+		PasswordHash:       "$2a$10$secret-password-hash",
+		PasswordResetToken: &resetToken,
+		ActivationToken:    &activationToken,
+		RoleID:             uuid.New(),
+		Role:               &models.Role{Level: 2, Description: "Moderator"},
+		IsActive:           true,
+		Profile:            &models.Profile{ID: uuid.New(), FirstName: "Go"},
+	}
+
+	hits, misses := UserCacheStats()
+	if cached := getCachedUser(ctx, user.ID); cached != nil {
+		t.Fatalf("getCachedUser() before caching = %+v, want nil", cached)
+	}
+	if _, gotMisses := UserCacheStats(); gotMisses != misses+1 {
+		t.Fatalf("cache misses = %d, want %d", gotMisses, misses+1)
+	}
+
+	cacheUser(ctx, user)
+
+	stored, err := server.Get(userCacheKeyPrefix + user.ID.String())
+	if err != nil {
+		t.Fatalf("user was not written to the cache: %v", err)
+	}
+	for _, secret := range []string{user.PasswordHash, resetToken, activationToken} {
+		if strings.Contains(stored, secret) {
+			t.Fatalf("cached user %s contains secret %q", stored, secret)
+		}
+	}
+	if ttl := server.TTL(userCacheKeyPrefix + user.ID.String()); ttl != userCacheTTL {
+		t.Fatalf("cache TTL = %s, want %s", ttl, userCacheTTL)
+	}
+
+	cached := getCachedUser(ctx, user.ID)
+	if cached == nil {
+		t.Fatal("getCachedUser() after caching = nil, want the user")
+	}
+	if gotHits, _ := UserCacheStats(); gotHits != hits+1 {
+		t.Fatalf("cache hits = %d, want %d", gotHits, hits+1)
+	}
+	if cached.ID != user.ID || cached.Username != user.Username || cached.RoleID != user.RoleID {
+		t.Fatalf("cached user = %+v, want ID, username and role ID of %+v", cached, user)
+	}
+	if cached.Role == nil || cached.Role.Level != 2 {
+		t.Fatalf("cached role = %+v, want level 2", cached.Role)
+	}
+	if cached.PasswordHash != "" || cached.PasswordResetToken != nil || cached.ActivationToken != nil {
+		t.Fatal("cached user has a password hash or token")
+	}
+	if cached.Profile == nil || cached.Profile.UserID != user.ID {
+		t.Fatalf("cached profile = %+v, want the profile of user %s", cached.Profile, user.ID)
+	}
+
+	invalidateCachedUser(ctx, user.ID)
+	if cached := getCachedUser(ctx, user.ID); cached != nil {
+		t.Fatalf("getCachedUser() after invalidation = %+v, want nil", cached)
+	}
+
+	server.Set(userCacheKeyPrefix+user.ID.String(), "not json")
+	if cached := getCachedUser(ctx, user.ID); cached != nil {
+		t.Fatalf("getCachedUser() of a corrupt entry = %+v, want nil", cached)
+	}
+}
+
+func TestUserCacheDisabled(t *testing.T) {
+	previous := userCacheClient
+	userCacheClient = nil
+	t.Cleanup(func() { userCacheClient = previous })
+
+	ctx := context.Background()
+	user := &models.User{ID: uuid.New()}
+
+	hits, misses := UserCacheStats()
+	cacheUser(ctx, user)
+	invalidateCachedUser(ctx, user.ID)
+	if cached := getCachedUser(ctx, user.ID); cached != nil {
+		t.Fatalf("getCachedUser() without a cache = %+v, want nil", cached)
+	}
+	if gotHits, gotMisses := UserCacheStats(); gotHits != hits || gotMisses != misses {
+		t.Fatalf("cache stats changed without a cache: hits %d, misses %d", gotHits-hits, gotMisses-misses)
+	}
+}
+
+// TestUserCacheInvalidation checks that every store changing a user drops it from the cache, so the auth
+// middleware never keeps acting on a stale role, ban, timeout, activation or password.
+func TestUserCacheInvalidation(t *testing.T) {
+	pool := dbtest.NewPool(t)
+	server := enableTestUserCache(t)
+	ctx := context.Background()
+
+	authStore := NewAuthStore(pool)
+	actionStore := NewActionStore(pool)
+	adminID := dbtest.CreateUser(t, pool, "admin", 3)
+
+	tests := []struct {
+		name   string
+		change func(userID uuid.UUID) error
+		check  func(t *testing.T, user *models.User)
+	}{
+		{
+			name: "role change",
+			change: func(userID uuid.UUID) error {
+				return authStore.UpdateUserRole(ctx, adminID, userID, 2)
+			},
+			check: func(t *testing.T, user *models.User) {
+				if user.Role.Level != 2 {
+					t.Fatalf("role level = %d, want 2", user.Role.Level)
+				}
+			},
+		},
+		{
+			name: "timeout",
+			change: func(userID uuid.UUID) error {
+				return actionStore.TimeoutUser(ctx, adminID, userID, time.Hour)
+			},
+			check: func(t *testing.T, user *models.User) {
+				if user.TimeoutUntil == nil || !user.TimeoutUntil.After(time.Now()) {
+					t.Fatalf("timeout until = %v, want a time in the future", user.TimeoutUntil)
+				}
+			},
+		},
+		{
+			name: "ban",
+			change: func(userID uuid.UUID) error {
+				return actionStore.BanUser(ctx, adminID, userID)
+			},
+			check: func(t *testing.T, user *models.User) {
+				if !user.Banned {
+					t.Fatal("banned = false, want true")
+				}
+			},
+		},
+		{
+			name: "activation",
+			change: func(userID uuid.UUID) error {
+				if _, err := pool.Exec(ctx, `UPDATE users SET is_active = FALSE WHERE id = $1`, userID); err != nil {
+					return err
+				}
+				invalidateCachedUser(ctx, userID)
+				if _, err := authStore.GetUserByID(ctx, userID); err != nil {
+					return err
+				}
+				return authStore.ActivateUser(ctx, userID)
+			},
+			check: func(t *testing.T, user *models.User) {
+				if !user.IsActive {
+					t.Fatal("is_active = false, want true")
+				}
+			},
+		},
+		{
+			name: "password change",
+			change: func(userID uuid.UUID) error {
+				return authStore.UpdateUserPassword(ctx, userID, "new-password-hash")
+			},
+			check: func(t *testing.T, user *models.User) {
+				if user.PasswordHash != "new-password-hash" {
+					t.Fatal("password hash was not updated")
+				}
+			},
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userID := dbtest.CreateUser(t, pool, fmt.Sprintf("user%d", i), 1)
+
+			if _, err := authStore.GetUserByID(ctx, userID); err != nil {
+				t.Fatalf("GetUserByID() error = %v", err)
+			}
+			if !server.Exists(userCacheKeyPrefix + userID.String()) {
+				t.Fatal("GetUserByID() did not cache the user")
+			}
+
+			if err := tt.change(userID); err != nil {
+				t.Fatalf("change error = %v", err)
+			}
+			if server.Exists(userCacheKeyPrefix + userID.String()) {
+				t.Fatal("user is still cached after the change")
+			}
+
+			user, err := authStore.GetUserByID(ctx, userID)
+			if err != nil {
+				t.Fatalf("GetUserByID() after the change error = %v", err)
+			}
+			tt.check(t, user)
+		})
+	}
+}
@@ -0,0 +1,213 @@
+package stores
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/datarohit/gopher-social-backend/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type UserStore struct {
+	dbPool *pgxpool.Pool
+}
+
+// NewUserStore creates a new UserStore.
+//
+// Parameters:
+//   - dbPool (*pgxpool.Pool): Pgx connection pool.
+//
+// Returns:
+//   - *UserStore: UserStore instance.
+func NewUserStore(dbPool *pgxpool.Pool) *UserStore {
+	return &UserStore{
+		dbPool: dbPool,
+	}
+}
+
+// ExportUserData writes all data of a user as a single JSON document shaped like models.UserDataExport.
+// The user with their profile is written first, followed by their posts, comments, post and comment
+// reactions, followers and followings. Rows are encoded one at a time as they are read from the
+// database, so the export is never held in memory as a whole.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the database operation.
+//   - userID (uuid.UUID): ID of the user whose data is exported.
+//   - w (io.Writer): Writer the JSON document is written to.
+//
+// Returns:
+//   - error: ErrUserNotFound if the user does not exist, or an error if a query or write fails.
+func (us *UserStore) ExportUserData(ctx context.Context, userID uuid.UUID, w io.Writer) error {
+	user, err := NewAuthStore(us.dbPool).GetCurrentUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	exportedAt, err := json.Marshal(time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("failed to encode export time: %w", err)
+	}
+	userJSON, err := json.Marshal(user)
+	if err != nil {
+		return fmt.Errorf("failed to encode user: %w", err)
+	}
+
+	if _, err := fmt.Fprintf(w, `{"exported_at":%s,"user":%s`, exportedAt, userJSON); err != nil {
+		return fmt.Errorf("failed to write user data export: %w", err)
+	}
+
+	err = us.streamJSONArray(ctx, w, "posts", `
+		SELECT
+			p.id, p.title, COALESCE(p.sub_title, ''), COALESCE(p.description, ''), p.content, p.created_at, p.updated_at,
+			(SELECT COUNT(*) FROM post_likes pl WHERE pl.post_id = p.id AND pl.liked = TRUE AND `+selfLikeCondition("pl.user_id", "p.author_id")+`) as likes,
+			(SELECT COUNT(*) FROM post_likes pl WHERE pl.post_id = p.id AND pl.liked = FALSE) as dislikes,
+			(SELECT COUNT(*) FROM comments cm WHERE cm.post_id = p.id) as comments_count
+		FROM posts p
+		WHERE p.author_id = $1
+		ORDER BY p.created_at
+	`, userID, func(rows pgx.Rows) (interface{}, error) {
+		var post models.Post
+		err := rows.Scan(&post.ID, &post.Title, &post.SubTitle, &post.Description, &post.Content, &post.CreatedAt, &post.UpdatedAt, &post.Likes, &post.Dislikes, &post.Comments)
+		return &post, err
+	})
+	if err != nil {
+		return err
+	}
+
+	err = us.streamJSONArray(ctx, w, "comments", `
+		SELECT id, post_id, content, created_at, updated_at
+		FROM comments
+		WHERE author_id = $1
+		ORDER BY created_at
+	`, userID, func(rows pgx.Rows) (interface{}, error) {
+		var comment models.ExportedComment
+		err := rows.Scan(&comment.ID, &comment.PostID, &comment.Content, &comment.CreatedAt, &comment.UpdatedAt)
+		return &comment, err
+	})
+	if err != nil {
+		return err
+	}
+
+	err = us.streamJSONArray(ctx, w, "post_reactions", `
+		SELECT post_id, CASE WHEN liked THEN 'like' ELSE 'dislike' END, created_at
+		FROM post_likes
+		WHERE user_id = $1
+		ORDER BY created_at
+	`, userID, scanExportedReaction)
+	if err != nil {
+		return err
+	}
+
+	err = us.streamJSONArray(ctx, w, "comment_reactions", `
+		SELECT comment_id, CASE WHEN liked THEN 'like' ELSE 'dislike' END, created_at
+		FROM comment_likes
+		WHERE user_id = $1
+		ORDER BY created_at
+	`, userID, scanExportedReaction)
+	if err != nil {
+		return err
+	}
+
+	err = us.streamJSONArray(ctx, w, "followers", `
+		SELECT u.id, u.username, f.created_at
+		FROM follows f
+		INNER JOIN users u ON u.id = f.follower_id
+		WHERE f.followee_id = $1
+		ORDER BY f.created_at
+	`, userID, scanExportedFollow)
+	if err != nil {
+		return err
+	}
+
+	err = us.streamJSONArray(ctx, w, "following", `
+		SELECT u.id, u.username, f.created_at
+		FROM follows f
+		INNER JOIN users u ON u.id = f.followee_id
+		WHERE f.follower_id = $1
+		ORDER BY f.created_at
+	`, userID, scanExportedFollow)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.WriteString(w, "}"); err != nil {
+		return fmt.Errorf("failed to write user data export: %w", err)
+	}
+
+	return nil
+}
+
+// streamJSONArray runs a query and writes its rows as the JSON array field name of the export.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the database operation.
+//   - w (io.Writer): Writer the field is written to.
+//   - name (string): JSON name of the field.
+//   - query (string): Query selecting the rows, taking the user ID as its only argument.
+//   - userID (uuid.UUID): ID of the user whose data is exported.
+//   - scan (func(pgx.Rows) (interface{}, error)): Function scanning the current row into a value to encode.
+//
+// Returns:
+//   - error: An error if the query, a scan or a write fails.
+func (us *UserStore) streamJSONArray(ctx context.Context, w io.Writer, name string, query string, userID uuid.UUID, scan func(pgx.Rows) (interface{}, error)) error {
+	rows, err := us.dbPool.Query(ctx, query, userID)
+	if err != nil {
+		return fmt.Errorf("failed to export %s: %w", name, err)
+	}
+	defer rows.Close()
+
+	if _, err := fmt.Fprintf(w, `,%q:[`, name); err != nil {
+		return fmt.Errorf("failed to write user data export: %w", err)
+	}
+
+	first := true
+	for rows.Next() {
+		value, err := scan(rows)
+		if err != nil {
+			return fmt.Errorf("failed to scan exported %s row: %w", name, err)
+		}
+
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("failed to encode exported %s row: %w", name, err)
+		}
+
+		if !first {
+			encoded = append([]byte(","), encoded...)
+		}
+		first = false
+
+		if _, err := w.Write(encoded); err != nil {
+			return fmt.Errorf("failed to write user data export: %w", err)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error during exported %s rows iteration: %w", name, err)
+	}
+
+	if _, err := io.WriteString(w, "]"); err != nil {
+		return fmt.Errorf("failed to write user data export: %w", err)
+	}
+
+	return nil
+}
+
+// scanExportedReaction scans a row of target ID, reaction and creation time into an exported reaction.
+func scanExportedReaction(rows pgx.Rows) (interface{}, error) {
+	var reaction models.ExportedReaction
+	err := rows.Scan(&reaction.TargetID, &reaction.Reaction, &reaction.CreatedAt)
+	return &reaction, err
+}
+
+// scanExportedFollow scans a row of user ID, username and follow time into an exported follow.
+func scanExportedFollow(rows pgx.Rows) (interface{}, error) {
+	var follow models.ExportedFollow
+	err := rows.Scan(&follow.UserID, &follow.Username, &follow.CreatedAt)
+	return &follow, err
+}
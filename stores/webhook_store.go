@@ -0,0 +1,88 @@
+package stores
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/datarohit/gopher-social-backend/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type WebhookStore struct {
+	dbPool *pgxpool.Pool
+}
+
+// NewWebhookStore creates a new WebhookStore.
+//
+// Parameters:
+//   - dbPool (*pgxpool.Pool): Pgx connection pool.
+//
+// Returns:
+//   - *WebhookStore: WebhookStore instance.
+func NewWebhookStore(dbPool *pgxpool.Pool) *WebhookStore {
+	return &WebhookStore{
+		dbPool: dbPool,
+	}
+}
+
+// ListActiveWebhooksForEvent retrieves the active webhooks subscribed to an event.
+// Webhooks without any configured events are subscribed to all events.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the database operation.
+//   - event (string): Name of the event.
+//
+// Returns:
+//   - []*models.Webhook: List of webhooks to deliver the event to.
+//   - error: An error if fetching webhooks fails.
+func (ws *WebhookStore) ListActiveWebhooksForEvent(ctx context.Context, event string) ([]*models.Webhook, error) {
+	rows, err := ws.dbPool.Query(ctx, `
+		SELECT id, url, secret, events, is_active, created_at, updated_at
+		FROM webhooks
+		WHERE is_active = TRUE AND (cardinality(events) = 0 OR $1 = ANY(events))
+	`, event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+	defer rows.Close()
+
+	var webhooks []*models.Webhook
+	for rows.Next() {
+		webhook := &models.Webhook{}
+		err := rows.Scan(&webhook.ID, &webhook.URL, &webhook.Secret, &webhook.Events, &webhook.IsActive, &webhook.CreatedAt, &webhook.UpdatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webhook row: %w", err)
+		}
+		webhooks = append(webhooks, webhook)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during webhooks rows iteration: %w", err)
+	}
+
+	return webhooks, nil
+}
+
+// RecordDeadLetter stores an event that could not be delivered to a webhook after all attempts.
+//
+// Parameters:
+//   - ctx (context.Context): Context for the database operation.
+//   - webhookID (uuid.UUID): ID of the webhook the delivery failed for.
+//   - event (string): Name of the event.
+//   - payload ([]byte): JSON body of the delivery.
+//   - attempts (int): Number of delivery attempts made.
+//   - lastError (string): Error of the last attempt.
+//
+// Returns:
+//   - error: An error if storing the dead letter fails.
+func (ws *WebhookStore) RecordDeadLetter(ctx context.Context, webhookID uuid.UUID, event string, payload []byte, attempts int, lastError string) error {
+	_, err := ws.dbPool.Exec(ctx, `
+		INSERT INTO webhook_dead_letters (webhook_id, event, payload, attempts, last_error)
+		VALUES ($1, $2, $3, $4, $5)
+	`, webhookID, event, payload, attempts, lastError)
+	if err != nil {
+		return fmt.Errorf("failed to record webhook dead letter: %w", err)
+	}
+	return nil
+}